@@ -21,6 +21,10 @@ package main
 // @BasePath /
 // @schemes http https
 
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+
 import (
 	"context"
 	"fmt"
@@ -28,13 +32,18 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // часовые пояса IANA без зависимости от tzdata образа
 
 	_ "github.com/location-microservice/docs/swagger"
+	"github.com/location-microservice/internal/bootstrap"
 	"github.com/location-microservice/internal/config"
 	httpDelivery "github.com/location-microservice/internal/delivery/http"
 	"github.com/location-microservice/internal/delivery/http/handler"
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/domain/repository"
 	"github.com/location-microservice/internal/pkg/logger"
 	"github.com/location-microservice/internal/repository/cache"
+	"github.com/location-microservice/internal/repository/mbtiles"
 	"github.com/location-microservice/internal/repository/postgresosm"
 	"github.com/location-microservice/internal/usecase"
 	"go.uber.org/zap"
@@ -72,6 +81,16 @@ func main() {
 	}()
 	log.Info("OSM PostgreSQL connected")
 
+	// 3c. Optional OSM read-replica for tiles and analytics
+	if cfg.OSMDBReplica.Host != "" {
+		osmReplica, err := postgresosm.New(&cfg.OSMDBReplica, log)
+		if err != nil {
+			log.Fatal("Failed to connect to OSM PostgreSQL replica", zap.Error(err))
+		}
+		osmDB.SetReplica(osmReplica)
+		log.Info("OSM PostgreSQL replica connected")
+	}
+
 	// 4. Connect to Redis
 	redisClient, err := cache.NewRedis(&cfg.Redis, log)
 	if err != nil {
@@ -99,14 +118,85 @@ func main() {
 	log.Info("All connections healthy")
 
 	// 6. Initialize Repositories
+	// Настройки, общие с воркером (категории POI, фильтры объектов, геокодирование, формат ответов)
+	if err := bootstrap.ConfigureShared(cfg); err != nil {
+		log.Fatal("Invalid config", zap.Error(err))
+	}
+	osmQueryOpts, err := bootstrap.OSMQueryOptions(cfg)
+	if err != nil {
+		log.Fatal("Invalid config", zap.Error(err))
+	}
+	if err := bootstrap.CheckOSMSchema(ctx, cfg, osmDB); err != nil {
+		log.Fatal("OSM database schema does not match config", zap.Error(err))
+	}
+	typeLabels, err := domain.ParseTypeLabels(cfg.Response.TypeLabels)
+	if err != nil {
+		log.Fatal("Invalid type labels config", zap.Error(err))
+	}
+	tileZoomPolicy, err := domain.ParseTileZoomPolicy(cfg.Tile.ZoomPolicy)
+	if err != nil {
+		log.Fatal("Invalid tile zoom policy config", zap.Error(err))
+	}
+	tileLayerMinZooms, err := domain.ParseTileLayerMinZooms(cfg.Tile.LayerMinZoom)
+	if err != nil {
+		log.Fatal("Invalid TILE_LAYER_MIN_ZOOM", zap.Error(err))
+	}
+	tileZoomPolicy = tileZoomPolicy.WithLayerMinZooms(tileLayerMinZooms)
+	postgresosm.ConfigureTileZoomPolicy(tileZoomPolicy)
+	usecase.ConfigureTileZoomPolicy(tileZoomPolicy)
+	poiZoomCategories, err := domain.ParsePOIZoomCategories(cfg.Tile.POIZoomCategories)
+	if err != nil {
+		log.Fatal("Invalid POI zoom categories config", zap.Error(err))
+	}
+	postgresosm.ConfigurePOIZoomCategories(poiZoomCategories)
+	if err := postgresosm.ConfigureAreaLayerSimplification(cfg.Tile.LayerSimplifyPx, cfg.Tile.LayerMinAreaPx); err != nil {
+		log.Fatal("Invalid tile layer simplification config", zap.Error(err))
+	}
+	if err := usecase.ConfigureTileDataVersion(cfg.Tile.DataVersion); err != nil {
+		log.Fatal("Invalid tile data version", zap.Error(err))
+	}
+	postgresosm.ConfigurePOITileMaxFeatures(cfg.Tile.POIMaxFeatures)
+
 	// OSM репозитории (работают с planet_osm_* таблицами из OSM базы)
-	boundaryRepo := postgresosm.NewBoundaryRepository(osmDB)
-	transportRepo := postgresosm.NewTransportRepository(osmDB)
-	poiRepo := postgresosm.NewPOIRepository(osmDB)
-	environmentRepo := postgresosm.NewEnvironmentRepository(osmDB)
+	boundaryRepo := postgresosm.NewBoundaryRepository(osmDB, osmQueryOpts)
+	transportRepo := postgresosm.NewTransportRepository(osmDB, osmQueryOpts)
+	poiRepo := postgresosm.NewPOIRepository(osmDB, osmQueryOpts)
+	environmentRepo := postgresosm.NewEnvironmentRepository(osmDB, osmQueryOpts)
+	debugRepo := postgresosm.NewDebugRepository(osmDB, osmQueryOpts)
+
+	// Колонки osm_version/osm_timestamp/osm_user определяются один раз при старте
+	editMetaCtx, editMetaCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	editMetaRepo, err := postgresosm.NewEditMetaRepository(editMetaCtx, osmDB)
+	editMetaCancel()
+	if err != nil {
+		log.Fatal("Failed to detect OSM edit metadata columns", zap.Error(err))
+	}
+
+	// Таблица часовых поясов необязательна: без нее пояс берется из тегов границ
+	timezoneCtx, timezoneCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	timezoneRepo, err := postgresosm.NewTimezoneRepository(timezoneCtx, osmDB)
+	timezoneCancel()
+	if err != nil {
+		log.Fatal("Failed to detect timezone table", zap.Error(err))
+	}
 
 	// Postgres репозитории (основная база данных для статистики и других данных)
-	cacheRepo := cache.NewCacheRepository(redisClient)
+	cacheRepo := cache.NewCacheRepository(redisClient, cfg.Cache.TTLJitterPercent)
+
+	// Предрассчитанные тайлы статичных регионов (MBTiles), отсутствующие в архиве генерируются из PostGIS
+	var tileArchive repository.TileArchiveRepository
+	if len(cfg.Tile.MBTiles) > 0 {
+		mbtilesRepo, err := mbtiles.NewRepository(cfg.Tile.MBTiles, log)
+		if err != nil {
+			log.Fatal("Failed to open MBTiles archives", zap.Error(err))
+		}
+		defer func() {
+			if err := mbtilesRepo.Close(); err != nil {
+				log.Error("Failed to close MBTiles archives", zap.Error(err))
+			}
+		}()
+		tileArchive = mbtilesRepo
+	}
 
 	log.Info("Repositories initialized")
 
@@ -116,6 +206,10 @@ func main() {
 		cacheRepo,
 		log,
 		cfg.Cache.SearchCacheTTL,
+		usecase.PolygonLimits{
+			MaxAreaSqKm: cfg.Geocode.PolygonMaxAreaSqKm,
+			MaxVertices: cfg.Geocode.PolygonMaxVertices,
+		},
 	)
 
 	transportUC := usecase.NewTransportUseCase(
@@ -125,6 +219,7 @@ func main() {
 
 	poiUC := usecase.NewPOIUseCase(
 		poiRepo,
+		cacheRepo,
 		log,
 	)
 
@@ -134,6 +229,8 @@ func main() {
 		environmentRepo,
 		poiRepo,
 		cacheRepo,
+		tileArchive,
+		postgresosm.TileLayerSchemas(),
 		log,
 		cfg.Cache.TilesCacheTTL,
 	)
@@ -141,35 +238,98 @@ func main() {
 	poiTileUC := usecase.NewPOITileUseCase(
 		poiRepo,
 		cacheRepo,
+		tileArchive,
 		log,
 		cfg.Cache.POITileCacheTTL,
-		cfg.Tile.POIMaxFeatures,
 	)
 
 	// TODO: statsRepo not implemented yet, using nil for now
 	statsUC := usecase.NewStatsUseCase(
 		nil, // statsRepo
+		boundaryRepo,
 		cacheRepo,
 		log,
 	)
 
+	// Радиус поиска транспорта по плотности станций (WORKER_TRANSPORT_ADAPTIVE_RADIUS) - общий с воркером
+	adaptiveTransportRadius, err := bootstrap.AdaptiveTransportRadius(cfg)
+	if err != nil {
+		log.Fatal("Invalid adaptive transport radius config", zap.Error(err))
+	}
+
 	// EnrichedLocationUseCase - для полного обогащения локаций
-	enrichedLocationUC := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, log)
+	enrichedLocationUC := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, adaptiveTransportRadius, log)
+
+	// EnrichmentUseCase — обогащение по именованным профилям (minimal, full, кастомные из конфига)
+	enrichmentProfiles, err := domain.BuildEnrichmentProfiles(cfg.Enrichment.Profiles)
+	if err != nil {
+		log.Fatal("Invalid enrichment profiles config", zap.Error(err))
+	}
+	resolutionOrder, err := domain.ParseResolutionOrder(cfg.Enrichment.ResolutionOrder)
+	if err != nil {
+		log.Fatal("Invalid ENRICHMENT_RESOLUTION_ORDER", zap.Error(err))
+	}
+	excludedLevels, err := domain.ParseEnrichedLocationLevels(cfg.Enrichment.ExcludeLevels)
+	if err != nil {
+		log.Fatal("Invalid ENRICHMENT_EXCLUDE_LEVELS", zap.Error(err))
+	}
+	usecase.ConfigureEnrichedLocationLevels(excludedLevels)
+	enrichmentUC := usecase.NewEnrichmentUseCase(
+		boundaryRepo,
+		transportRepo,
+		environmentRepo,
+		poiRepo,
+		log,
+		cfg.Worker.TransportTypes,
+		cfg.Worker.TransportRadius/1000, // WORKER_TRANSPORT_RADIUS в метрах, репозиторий ожидает км
+		enrichmentProfiles,
+		cfg.Enrichment.DefaultProfile,
+		resolutionOrder,
+		adaptiveTransportRadius,
+	)
+
+	// EnrichmentDebugUseCase — стратегия резолва и частичные ошибки обогащения
+	enrichmentDebugUC := usecase.NewEnrichmentDebugUseCase(enrichmentUC, log)
 
 	// NearbyUseCase — для получения данных поблизости по категории
 	nearbyUC := usecase.NewNearbyUseCase(transportUC, poiUC, log)
 
+	// NeighborhoodUseCase — удобства и транспорт рядом с объектом одним запросом
+	neighborhoodUC := usecase.NewNeighborhoodUseCase(poiRepo, transportUC, cacheRepo, log)
+
+	// LocationScoreUseCase — композитная оценка локации с весами из конфига
+	locationScoreWeights, err := domain.ParseLocationScoreWeights(cfg.LocationScore.Weights)
+	if err != nil {
+		log.Fatal("Invalid location score weights config", zap.Error(err))
+	}
+	locationScoreUC := usecase.NewLocationScoreUseCase(transportRepo, environmentRepo, poiRepo, locationScoreWeights, log)
+
+	// EnvironmentUseCase — выборки зеленых зон и других экологических объектов
+	environmentUC := usecase.NewEnvironmentUseCase(environmentRepo, log)
+
+	// DebugUseCase — EXPLAIN планов запросов (endpoint регистрируется только вне production)
+	debugUC := usecase.NewDebugUseCase(debugRepo, log)
+	editMetaUC := usecase.NewEditMetaUseCase(editMetaRepo, log)
+	timezoneUC := usecase.NewTimezoneUseCase(timezoneRepo, log)
+	cacheAdminUC := usecase.NewCacheAdminUseCase(cacheRepo, searchUC, log)
+
 	log.Info("Use cases initialized")
 
 	// 8. Initialize HTTP Handlers
-	searchHandler := handler.NewSearchHandler(searchUC, log)
-	transportHandler := handler.NewTransportHandler(transportUC, log)
-	poiHandler := handler.NewPOIHandler(poiUC, log)
+	searchHandler := handler.NewSearchHandler(searchUC, editMetaUC, log)
+	transportHandler := handler.NewTransportHandler(transportUC, editMetaUC, typeLabels, log)
+	poiHandler := handler.NewPOIHandler(poiUC, editMetaUC, typeLabels, log)
 	tileHandler := handler.NewTileHandler(tileUC, log)
 	poiTileHandler := handler.NewPOITileHandler(poiTileUC, log)
 	statsHandler := handler.NewStatsHandler(statsUC, log)
-	enrichedLocationHandler := handler.NewEnrichedLocationHandler(enrichedLocationUC, log)
-	nearbyHandler := handler.NewNearbyHandler(nearbyUC, log)
+	enrichedLocationHandler := handler.NewEnrichedLocationHandler(enrichedLocationUC, typeLabels, log)
+	nearbyHandler := handler.NewNearbyHandler(nearbyUC, neighborhoodUC, typeLabels, log)
+	enrichmentHandler := handler.NewEnrichmentHandler(enrichmentUC, enrichmentDebugUC, log)
+	debugHandler := handler.NewDebugHandler(debugUC, log)
+	locationScoreHandler := handler.NewLocationScoreHandler(locationScoreUC, log)
+	environmentHandler := handler.NewEnvironmentHandler(environmentUC, log)
+	timezoneHandler := handler.NewTimezoneHandler(timezoneUC, log)
+	adminHandler := handler.NewAdminHandler(cacheAdminUC, log)
 
 	log.Info("HTTP handlers initialized")
 
@@ -185,6 +345,12 @@ func main() {
 		statsHandler,
 		enrichedLocationHandler,
 		nearbyHandler,
+		enrichmentHandler,
+		debugHandler,
+		locationScoreHandler,
+		environmentHandler,
+		timezoneHandler,
+		adminHandler,
 	)
 
 	log.Info("HTTP server initialized")
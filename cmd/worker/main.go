@@ -8,9 +8,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/location-microservice/internal/bootstrap"
 	"github.com/location-microservice/internal/config"
+	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/pkg/logger"
 	"github.com/location-microservice/internal/repository/cache"
+	"github.com/location-microservice/internal/repository/postgres"
 	"github.com/location-microservice/internal/repository/postgresosm"
 	redisRepo "github.com/location-microservice/internal/repository/redis"
 	"github.com/location-microservice/internal/usecase"
@@ -86,15 +89,38 @@ func main() {
 	}()
 
 	// 6. Initialize repositories (using OSM database)
-	boundaryRepo := postgresosm.NewBoundaryRepository(osmDB)
-	transportRepo := postgresosm.NewTransportRepository(osmDB)
+	// Те же настройки запросов, что у API: иначе результаты обогащения расходились бы с API
+	if err := bootstrap.ConfigureShared(cfg); err != nil {
+		log.Fatal("Invalid config", zap.Error(err))
+	}
+	osmQueryOpts, err := bootstrap.OSMQueryOptions(cfg)
+	if err != nil {
+		log.Fatal("Invalid config", zap.Error(err))
+	}
+	schemaCtx, schemaCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	err = bootstrap.CheckOSMSchema(schemaCtx, cfg, osmDB)
+	schemaCancel()
+	if err != nil {
+		log.Fatal("OSM database schema does not match config", zap.Error(err))
+	}
+	boundaryRepo := postgresosm.NewBoundaryRepository(osmDB, osmQueryOpts)
+	transportRepo := postgresosm.NewTransportRepository(osmDB, osmQueryOpts)
 	streamRepo := redisRepo.NewStreamRepository(streamsRedis, log)
-	cacheRepo := cache.NewCacheRepository(cacheRedis)
+	cacheRepo := cache.NewCacheRepository(cacheRedis, cfg.Cache.TTLJitterPercent)
 
 	// 7. Initialize use cases
-	searchUC := usecase.NewSearchUseCase(boundaryRepo, cacheRepo, log, cfg.Cache.SearchCacheTTL)
+	excludedLevels, err := domain.ParseEnrichedLocationLevels(cfg.Enrichment.ExcludeLevels)
+	if err != nil {
+		log.Fatal("Invalid ENRICHMENT_EXCLUDE_LEVELS", zap.Error(err))
+	}
+	usecase.ConfigureEnrichedLocationLevels(excludedLevels)
+	searchUC := usecase.NewSearchUseCase(boundaryRepo, cacheRepo, log, cfg.Cache.SearchCacheTTL, usecase.PolygonLimits{})
 	transportUC := usecase.NewTransportUseCase(transportRepo, log)
-	enrichedLocationUC := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, log)
+	adaptiveTransportRadius, err := bootstrap.AdaptiveTransportRadius(cfg)
+	if err != nil {
+		log.Fatal("Invalid adaptive transport radius config", zap.Error(err))
+	}
+	enrichedLocationUC := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, adaptiveTransportRadius, log)
 
 	// 8. Initialize worker
 	locationWorker := location.NewLocationEnrichmentWorker(
@@ -104,6 +130,23 @@ func main() {
 		cfg.Worker.MaxRetries,
 		log,
 	)
+	concurrency := locationWorker.SetConcurrency(cfg.Worker.Concurrency, cfg.OSMDB.MaxConns)
+	log.Info("Location enrichment worker concurrency", zap.Int("consumers", concurrency))
+
+	// Опционально: результаты обогащения сохраняются в основную БД для повторных запросов и аудита
+	if cfg.Worker.PersistResults {
+		mainDB, err := postgres.New(&cfg.Database, log)
+		if err != nil {
+			log.Fatal("Failed to connect to PostgreSQL", zap.Error(err))
+		}
+		defer func() {
+			if err := mainDB.Close(); err != nil {
+				log.Error("Failed to close PostgreSQL connection", zap.Error(err))
+			}
+		}()
+		locationWorker.SetResultRepository(postgres.NewEnrichmentResultRepository(mainDB))
+		log.Info("Enrichment results persistence enabled")
+	}
 
 	// 9. Create worker manager and register workers
 	workerManager := worker.NewWorkerManager(log)
@@ -0,0 +1,95 @@
+// Package bootstrap - общая настройка процесса для cmd/api и cmd/worker
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/location-microservice/internal/config"
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/repository/postgresosm"
+	"github.com/location-microservice/internal/usecase"
+)
+
+// ConfigureShared применяет настройки уровня пакетов, от которых зависят запросы и ответы
+// и API, и воркера обогащения: без них воркер строил бы SQL с настройками по умолчанию
+// и возвращал бы другие результаты, чем API. Вызывается один раз при старте, до создания
+// репозиториев и use case. Настройки только тайлов остаются в cmd/api.
+func ConfigureShared(cfg *config.Config) error {
+	// Категории POI из конфига компилируются в SQL до создания репозиториев
+	if err := postgresosm.ConfigurePOICategories(cfg.POI.CategoryRules); err != nil {
+		return fmt.Errorf("invalid POI category rules config: %w", err)
+	}
+	if err := postgresosm.ConfigurePOINameFallback(cfg.POI.NameFallback, cfg.POI.NameFallbackLang); err != nil {
+		return fmt.Errorf("invalid POI name fallback config: %w", err)
+	}
+
+	if err := postgresosm.ConfigureNameLanguages(cfg.Response.NameLanguages); err != nil {
+		return fmt.Errorf("invalid name languages config: %w", err)
+	}
+	if err := postgresosm.ConfigureCountryPriority(cfg.Geocode.CountryPriority); err != nil {
+		return fmt.Errorf("invalid country priority config: %w", err)
+	}
+	usecase.ConfigureGeocodeCellCache(cfg.Geocode.CellCacheSize)
+
+	// Точность координат и расстояний в ответах (округление на уровне usecase/DTO)
+	if cfg.Response.CoordinatePrecision != 0 {
+		if err := utils.ConfigureCoordinatePrecision(cfg.Response.CoordinatePrecision); err != nil {
+			return fmt.Errorf("invalid coordinate precision config: %w", err)
+		}
+	}
+	if err := utils.ConfigureDistancePrecision(cfg.Response.DistancePrecision); err != nil {
+		return fmt.Errorf("invalid distance precision config: %w", err)
+	}
+
+	// Лимиты пакетных запросов: больше MAX_BATCH_SIZE - 413, большие пакеты делятся на под-пакеты
+	if err := usecase.ConfigureBatchLimits(cfg.Batch.MaxSize, cfg.Batch.ChunkSize); err != nil {
+		return fmt.Errorf("invalid batch limits config: %w", err)
+	}
+	return nil
+}
+
+// OSMQueryOptions собирает настройки SQL репозиториев OSM (фильтры объектов, упрощенная геометрия границ).
+// Передаются в конструкторы репозиториев API и воркера, чтобы их запросы совпадали.
+func OSMQueryOptions(cfg *config.Config) (postgresosm.QueryOptions, error) {
+	opts, err := postgresosm.QueryOptions{
+		IncludeInactiveFeatures:  cfg.FeatureFilter.IncludeInactive,
+		IncludeRestrictedBeaches: cfg.FeatureFilter.IncludeRestrictedBeaches,
+		SimplifiedContains:       cfg.Geocode.SimplifiedContains,
+		SimplifiedMaxLevel:       cfg.Geocode.SimplifiedMaxLevel,
+	}.Validate()
+	if err != nil {
+		return opts, fmt.Errorf("invalid boundary simplified geometry config: %w", err)
+	}
+	return opts, nil
+}
+
+// CheckOSMSchema проверяет, что в OSM базе построено все, чего требует конфиг: иначе процесс
+// стартовал бы и отвечал ошибкой БД на каждый запрос. Вызывается после ConfigureShared и OSMQueryOptions.
+func CheckOSMSchema(ctx context.Context, cfg *config.Config, osmDB *postgresosm.DB) error {
+	if cfg.Geocode.SimplifiedContains {
+		if err := postgresosm.CheckBoundarySimplifiedGeometry(ctx, osmDB); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AdaptiveTransportRadius собирает радиус поиска транспорта по плотности станций из
+// WORKER_TRANSPORT_*: общий для обогащения в API и в воркере. nil - адаптивный радиус выключен.
+func AdaptiveTransportRadius(cfg *config.Config) (*domain.AdaptiveTransportRadius, error) {
+	if !cfg.Worker.AdaptiveTransportRadius {
+		return nil, nil
+	}
+	adaptive := &domain.AdaptiveTransportRadius{
+		ProbeRadiusM: cfg.Worker.TransportProbeRadius,
+		TargetCount:  cfg.Worker.TransportTargetCount,
+		MinRadiusM:   cfg.Worker.TransportMinRadius,
+		MaxRadiusM:   cfg.Worker.TransportMaxRadius,
+	}
+	if err := adaptive.Validate(); err != nil {
+		return nil, err
+	}
+	return adaptive, nil
+}
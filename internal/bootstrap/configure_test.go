@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/location-microservice/internal/config"
+)
+
+func TestConfigureShared(t *testing.T) {
+	t.Run("defaults are valid", func(t *testing.T) {
+		assert.NoError(t, ConfigureShared(&config.Config{}))
+	})
+
+	t.Run("invalid setting is reported", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.POI.NameFallback = "osm_id"
+
+		err := ConfigureShared(cfg)
+
+		assert.ErrorContains(t, err, "POI name fallback")
+	})
+}
+
+func TestOSMQueryOptions(t *testing.T) {
+	t.Run("defaults are valid", func(t *testing.T) {
+		opts, err := OSMQueryOptions(&config.Config{})
+
+		assert.NoError(t, err)
+		assert.False(t, opts.IncludeInactiveFeatures)
+		assert.Equal(t, 4, opts.SimplifiedMaxLevel)
+	})
+
+	t.Run("settings come from config", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.FeatureFilter.IncludeInactive = true
+		cfg.FeatureFilter.IncludeRestrictedBeaches = true
+		cfg.Geocode.SimplifiedContains = true
+		cfg.Geocode.SimplifiedMaxLevel = 6
+
+		opts, err := OSMQueryOptions(cfg)
+
+		assert.NoError(t, err)
+		assert.True(t, opts.IncludeInactiveFeatures)
+		assert.True(t, opts.IncludeRestrictedBeaches)
+		assert.True(t, opts.SimplifiedContains)
+		assert.Equal(t, 6, opts.SimplifiedMaxLevel)
+	})
+
+	t.Run("invalid setting is reported", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Geocode.SimplifiedMaxLevel = 9
+
+		_, err := OSMQueryOptions(cfg)
+
+		assert.ErrorContains(t, err, "boundary simplified geometry")
+	})
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,35 +10,72 @@ import (
 )
 
 type Config struct {
-	Server       ServerConfig
-	Database     DatabaseConfig
-	OSMDB        DatabaseConfig
-	Redis        RedisConfig
-	RedisStreams RedisStreamsConfig
-	Cache        CacheConfig
-	Tile         TileConfig
-	Log          LogConfig
-	Worker       WorkerConfig
-	Mapbox       MapboxConfig
+	Server        ServerConfig
+	Database      DatabaseConfig
+	OSMDB         DatabaseConfig
+	OSMDBReplica  DatabaseConfig // read-replica OSM базы для тайлов и аналитики, пустой Host - не используется
+	Redis         RedisConfig
+	RedisStreams  RedisStreamsConfig
+	Cache         CacheConfig
+	Tile          TileConfig
+	Log           LogConfig
+	Worker        WorkerConfig
+	Mapbox        MapboxConfig
+	Enrichment    EnrichmentConfig
+	POI           POIConfig
+	FeatureFilter FeatureFilterConfig
+	LocationScore LocationScoreConfig
+	Response      ResponseConfig
+	Batch         BatchConfig
+	RateLimit     RateLimitConfig
+	Geocode       GeocodeConfig
 }
 
 type ServerConfig struct {
-	Host string
-	Port int
-	Env  string
+	Host         string
+	Port         int
+	Env          string
+	DebugExplain bool // /debug/explain endpoint, никогда не включается в production
+	AdminToken   string // токен /admin/* эндпоинтов, пусто - эндпоинты не регистрируются
+
+	// AllowedOrigins - источники, которым разрешен CORS (тайлы и API). Пусто или "*" - любой источник
+	AllowedOrigins []string
+
+	// IP клиента за прокси: заголовок ProxyHeader читается только у запросов от TrustedProxies
+	// (IP или CIDR), у остальных IP клиента - адрес соединения. Пустой ProxyHeader - всегда адрес соединения
+	ProxyHeader    string
+	TrustedProxies []string
+
+	// Тюнинг соединений fasthttp. HTTP/2 fasthttp не поддерживает: мультиплексирование
+	// тайловых запросов делает TLS-прокси перед сервисом, см. README
+	ReadTimeout      time.Duration
+	WriteTimeout     time.Duration
+	IdleTimeout      time.Duration // сколько keep-alive соединение ждет следующего запроса
+	Concurrency      int           // максимум одновременных соединений, 0 - по умолчанию fiber (256K)
+	DisableKeepalive bool
+
+	// Защита от огромных тел запросов пакетных эндпоинтов: тело больше BodyLimit отклоняется
+	// с 413 до чтения целиком, JSON глубже MaxJSONDepth - с 400 до BodyParser
+	BodyLimit    int // байт
+	MaxJSONDepth int
 }
 
 type DatabaseConfig struct {
-	Host            string
-	Port            int
-	User            string
-	Password        string
-	DBName          string
-	SSLMode         string
-	MaxConns        int
-	MaxIdleConns    int
-	ConnMaxLifetime time.Duration
-	ConnMaxIdleTime time.Duration
+	Host               string
+	Port               int
+	User               string
+	Password           string
+	DBName             string
+	SSLMode            string
+	MaxConns           int
+	MaxIdleConns       int
+	ConnMaxLifetime    time.Duration
+	ConnMaxIdleTime    time.Duration
+	// SlowQueryThreshold - запросы дольше порога логируются WARN; 0 - по умолчанию (500ms), < 0 - выключено
+	SlowQueryThreshold time.Duration
+	// StatementTimeout - statement_timeout сессий пула: Postgres сам прерывает запрос дольше лимита,
+	// даже если отмена по context не сработала; 0 - не задается (у реплики - как у основной БД)
+	StatementTimeout   time.Duration
 }
 
 type RedisConfig struct {
@@ -59,10 +97,25 @@ type CacheConfig struct {
 	SearchCacheTTL        time.Duration
 	POITileCacheTTL       time.Duration
 	TransportTileCacheTTL time.Duration
+	// TTLJitterPercent - случайный разброс TTL (±%), чтобы записи не истекали одновременно. 0 - выключено
+	TTLJitterPercent float64
+	// Endpoints - префикс пути -> включен ли кеш; действует самый длинный префикс, без правила - включен
+	Endpoints map[string]bool
 }
 
 type TileConfig struct {
-	POIMaxFeatures int
+	POIMaxFeatures    int               // верхний предел POI в тайле; эффективный лимит - min(лимит зума, POIMaxFeatures)
+	ZoomPolicy        string            // политика слоев по зумам, см. domain.ParseTileZoomPolicy; пусто - по умолчанию
+	LayerMinZoom      map[string]string // слой -> минимальный зум показа, поверх ZoomPolicy
+	POIZoomCategories string            // подкатегории POI по зумам, см. domain.ParsePOIZoomCategories; пусто - по умолчанию
+	MBTiles           map[string]string // слой -> путь к MBTiles архиву с предрассчитанными тайлами
+	// Упрощение площадных слоев (green_spaces, water): слой -> допуск упрощения в пикселях
+	// и слой -> минимальная площадь полигона в квадратных пикселях после упрощения
+	LayerSimplifyPx map[string]string
+	LayerMinAreaPx  map[string]string
+	// Версия данных тайлов (дата импорта OSM): входит в ETag, клиент с ETag текущей версии
+	// получает 304 без генерации тайла; пусто - ETag только по содержимому тайла
+	DataVersion string
 }
 
 type LogConfig struct {
@@ -79,6 +132,66 @@ type MapboxConfig struct {
 	BatchInterval   time.Duration // Time to wait before processing batch
 }
 
+type EnrichmentConfig struct {
+	DefaultProfile  string
+	Profiles        map[string][]string // имя профиля -> features, дополняют встроенные minimal/full
+	ResolutionOrder []string            // порядок стратегий резолва: name, coordinates, country
+	ExcludeLevels   []string            // admin_level, исключаемые из обогащенной локации (7, 11, ...)
+}
+
+type POIConfig struct {
+	CategoryRules    map[string][]string // категория -> OSM теги "key:value", дополняют встроенный маппинг
+	NameFallback     string              // имя безымянного POI: synthesize, category, empty; пусто - synthesize
+	NameFallbackLang string              // язык подписи категории в имени безымянного POI; пусто - en
+}
+
+type FeatureFilterConfig struct {
+	IncludeInactive          bool // не отсекать disused/abandoned/construction объекты OSM
+	IncludeRestrictedBeaches bool // не отсекать пляжи с access=private|no в выдаче рядом с точкой
+}
+
+type LocationScoreConfig struct {
+	Weights string // веса составляющих оценки локации "component=weight,...", пусто - по умолчанию
+}
+
+type ResponseConfig struct {
+	CoordinatePrecision int                 // знаков после запятой в координатах ответов, 0 - по умолчанию (6)
+	NameLanguages       []string            // языки name:<lang> в многоязычных полях границ и туристических зон; пусто - en,es,ca,ru,uk,fr,pt,it,de
+	DistancePrecision   map[string]string   // единица (m, km) -> знаков после запятой в расстояниях ответов; пусто - m=2,km=3
+	TypeLabels          map[string][]string // язык -> "код:подпись" для type_label и category_label; без подписи - сам код
+}
+
+type GeocodeConfig struct {
+	CountryPriority []string // страны (название или osm_id) по убыванию приоритета для точек на спорных границах
+	CellCacheSize   int      // ячеек в in-process кеше обратного геокодирования; 0 - по умолчанию (1000), < 0 - выключен
+
+	// Проверка принадлежности точки крупным границам (admin_level <= SimplifiedMaxLevel) по упрощенной
+	// геометрии way_simplified (scripts/post-import.sql); более мелкие уровни - всегда по точной
+	SimplifiedContains bool
+	SimplifiedMaxLevel int // 0 - по умолчанию (4), допустимо 2-6
+
+	// Ограничения полигона POST /reverse-geocode/polygon; 0 - по умолчанию (50000 км², 10000 вершин)
+	PolygonMaxAreaSqKm float64
+	PolygonMaxVertices int
+}
+
+type BatchConfig struct {
+	MaxSize   int // максимум точек/локаций в пакетном запросе, больше - 413; 0 - по умолчанию (100)
+	ChunkSize int // размер под-пакета для запросов к БД; 0 - по умолчанию (50)
+}
+
+// RateLimitConfig - token bucket по API ключу (X-API-Key) или IP, отдельные бюджеты
+// для дешевых и дорогих эндпоинтов
+type RateLimitConfig struct {
+	Enabled        bool
+	APIKeys        []string // известные ключи X-API-Key; запросы с другим ключом ограничиваются по IP
+	CheapRPS       float64
+	CheapBurst     int
+	ExpensiveRPS   float64
+	ExpensiveBurst int
+	ExpensivePaths []string // подстроки пути дорогих эндпоинтов: пакетные запросы, тайлы в радиусе, потоки
+}
+
 type WorkerConfig struct {
 	Enabled               bool
 	ConsumerGroup         string
@@ -92,6 +205,16 @@ type WorkerConfig struct {
 	MaxTram               int
 	MaxBus                int
 	POIRadius             float64
+	// Радиус транспорта по плотности станций (метры); выключено - фиксированный TransportRadius
+	AdaptiveTransportRadius bool
+	TransportProbeRadius    float64
+	TransportTargetCount    int
+	TransportMinRadius      float64
+	TransportMaxRadius      float64
+	// Сохранять результаты обогащения в основную БД (таблица enrichment_results)
+	PersistResults bool
+	// Параллельных потребителей стрима в процессе, не больше OSM_DB_MAX_CONNS/2; 0 - по умолчанию (1)
+	Concurrency int
 }
 
 func Load() (*Config, error) {
@@ -104,33 +227,65 @@ func Load() (*Config, error) {
 
 	cfg := &Config{
 		Server: ServerConfig{
-			Host: viper.GetString("API_HOST"),
-			Port: viper.GetInt("API_PORT"),
-			Env:  viper.GetString("API_ENV"),
+			Host:         viper.GetString("API_HOST"),
+			Port:         viper.GetInt("API_PORT"),
+			Env:          viper.GetString("API_ENV"),
+			DebugExplain: viper.GetBool("DEBUG_EXPLAIN_ENABLED"),
+			AdminToken:   viper.GetString("ADMIN_TOKEN"),
+
+			AllowedOrigins: parseCommaList(viper.GetString("CORS_ALLOWED_ORIGINS")),
+
+			ProxyHeader:    viper.GetString("API_PROXY_HEADER"),
+			TrustedProxies: parseCommaList(viper.GetString("API_TRUSTED_PROXIES")),
+
+			ReadTimeout:      time.Duration(viper.GetInt("API_READ_TIMEOUT")) * time.Second,
+			WriteTimeout:     time.Duration(viper.GetInt("API_WRITE_TIMEOUT")) * time.Second,
+			IdleTimeout:      time.Duration(viper.GetInt("API_IDLE_TIMEOUT")) * time.Second,
+			Concurrency:      viper.GetInt("API_CONCURRENCY"),
+			DisableKeepalive: viper.GetBool("API_DISABLE_KEEPALIVE"),
+			BodyLimit:        viper.GetInt("API_BODY_LIMIT_BYTES"),
+			MaxJSONDepth:     viper.GetInt("API_MAX_JSON_DEPTH"),
 		},
 		Database: DatabaseConfig{
-			Host:            viper.GetString("DB_HOST"),
-			Port:            viper.GetInt("DB_PORT"),
-			User:            viper.GetString("DB_USER"),
-			Password:        viper.GetString("DB_PASSWORD"),
-			DBName:          viper.GetString("DB_NAME"),
-			SSLMode:         viper.GetString("DB_SSLMODE"),
-			MaxConns:        viper.GetInt("DB_MAX_CONNS"),
-			MaxIdleConns:    viper.GetInt("DB_MAX_IDLE_CONNS"),
-			ConnMaxLifetime: time.Duration(viper.GetInt("DB_CONN_MAX_LIFETIME")) * time.Second,
-			ConnMaxIdleTime: time.Duration(viper.GetInt("DB_CONN_MAX_IDLE_TIME")) * time.Second,
+			Host:             viper.GetString("DB_HOST"),
+			Port:             viper.GetInt("DB_PORT"),
+			User:             viper.GetString("DB_USER"),
+			Password:         viper.GetString("DB_PASSWORD"),
+			DBName:           viper.GetString("DB_NAME"),
+			SSLMode:          viper.GetString("DB_SSLMODE"),
+			MaxConns:         viper.GetInt("DB_MAX_CONNS"),
+			MaxIdleConns:     viper.GetInt("DB_MAX_IDLE_CONNS"),
+			ConnMaxLifetime:  time.Duration(viper.GetInt("DB_CONN_MAX_LIFETIME")) * time.Second,
+			ConnMaxIdleTime:  time.Duration(viper.GetInt("DB_CONN_MAX_IDLE_TIME")) * time.Second,
+			StatementTimeout: time.Duration(viper.GetInt("DB_STATEMENT_TIMEOUT_MS")) * time.Millisecond,
 		},
 		OSMDB: DatabaseConfig{
-			Host:            viper.GetString("OSM_DB_HOST"),
-			Port:            viper.GetInt("OSM_DB_PORT"),
-			User:            viper.GetString("OSM_DB_USER"),
-			Password:        viper.GetString("OSM_DB_PASSWORD"),
-			DBName:          viper.GetString("OSM_DB_NAME"),
-			SSLMode:         viper.GetString("OSM_DB_SSLMODE"),
-			MaxConns:        viper.GetInt("OSM_DB_MAX_CONNS"),
-			MaxIdleConns:    viper.GetInt("OSM_DB_MAX_IDLE_CONNS"),
-			ConnMaxLifetime: time.Duration(viper.GetInt("OSM_DB_CONN_MAX_LIFETIME")) * time.Second,
-			ConnMaxIdleTime: time.Duration(viper.GetInt("OSM_DB_CONN_MAX_IDLE_TIME")) * time.Second,
+			Host:               viper.GetString("OSM_DB_HOST"),
+			Port:               viper.GetInt("OSM_DB_PORT"),
+			User:               viper.GetString("OSM_DB_USER"),
+			Password:           viper.GetString("OSM_DB_PASSWORD"),
+			DBName:             viper.GetString("OSM_DB_NAME"),
+			SSLMode:            viper.GetString("OSM_DB_SSLMODE"),
+			MaxConns:           viper.GetInt("OSM_DB_MAX_CONNS"),
+			MaxIdleConns:       viper.GetInt("OSM_DB_MAX_IDLE_CONNS"),
+			ConnMaxLifetime:    time.Duration(viper.GetInt("OSM_DB_CONN_MAX_LIFETIME")) * time.Second,
+			ConnMaxIdleTime:    time.Duration(viper.GetInt("OSM_DB_CONN_MAX_IDLE_TIME")) * time.Second,
+			SlowQueryThreshold: time.Duration(viper.GetInt("OSM_DB_SLOW_QUERY_THRESHOLD_MS")) * time.Millisecond,
+			StatementTimeout:   time.Duration(viper.GetInt("OSM_DB_STATEMENT_TIMEOUT_MS")) * time.Millisecond,
+		},
+		OSMDBReplica: DatabaseConfig{
+			Host:               viper.GetString("OSM_DB_REPLICA_HOST"),
+			Port:               viper.GetInt("OSM_DB_REPLICA_PORT"),
+			User:               viper.GetString("OSM_DB_REPLICA_USER"),
+			Password:           viper.GetString("OSM_DB_REPLICA_PASSWORD"),
+			DBName:             viper.GetString("OSM_DB_REPLICA_NAME"),
+			SSLMode:            viper.GetString("OSM_DB_REPLICA_SSLMODE"),
+			MaxConns:           viper.GetInt("OSM_DB_REPLICA_MAX_CONNS"),
+			MaxIdleConns:       viper.GetInt("OSM_DB_REPLICA_MAX_IDLE_CONNS"),
+			ConnMaxLifetime:    time.Duration(viper.GetInt("OSM_DB_REPLICA_CONN_MAX_LIFETIME")) * time.Second,
+			ConnMaxIdleTime:    time.Duration(viper.GetInt("OSM_DB_REPLICA_CONN_MAX_IDLE_TIME")) * time.Second,
+			SlowQueryThreshold: time.Duration(viper.GetInt("OSM_DB_REPLICA_SLOW_QUERY_THRESHOLD_MS")) * time.Millisecond,
+			StatementTimeout:   time.Duration(viper.GetInt("OSM_DB_REPLICA_STATEMENT_TIMEOUT_MS")) * time.Millisecond,
 		},
 		Redis: RedisConfig{
 			Host:     viper.GetString("REDIS_HOST"),
@@ -149,9 +304,18 @@ func Load() (*Config, error) {
 			SearchCacheTTL:        time.Duration(viper.GetInt("SEARCH_CACHE_TTL")) * time.Second,
 			POITileCacheTTL:       time.Duration(viper.GetInt("POI_TILE_CACHE_TTL")) * time.Second,
 			TransportTileCacheTTL: time.Duration(viper.GetInt("TRANSPORT_TILE_CACHE_TTL")) * time.Second,
+			TTLJitterPercent:      viper.GetFloat64("CACHE_TTL_JITTER_PERCENT"),
+			Endpoints:             parseNamedBools(viper.GetString("CACHE_ENDPOINTS")),
 		},
 		Tile: TileConfig{
-			POIMaxFeatures: viper.GetInt("POI_TILE_MAX_FEATURES"),
+			POIMaxFeatures:    viper.GetInt("POI_TILE_MAX_FEATURES"),
+			ZoomPolicy:        viper.GetString("TILE_ZOOM_POLICY"),
+			LayerMinZoom:      parseNamedValues(viper.GetString("TILE_LAYER_MIN_ZOOM")),
+			POIZoomCategories: viper.GetString("POI_TILE_ZOOM_CATEGORIES"),
+			MBTiles:           parseNamedValues(viper.GetString("TILE_MBTILES")),
+			LayerSimplifyPx:   parseNamedValues(viper.GetString("TILE_LAYER_SIMPLIFY_PX")),
+			LayerMinAreaPx:    parseNamedValues(viper.GetString("TILE_LAYER_MIN_AREA_PX")),
+			DataVersion:       viper.GetString("TILE_DATA_VERSION"),
 		},
 		Log: LogConfig{
 			Level: viper.GetString("LOG_LEVEL"),
@@ -166,22 +330,85 @@ func Load() (*Config, error) {
 			BatchInterval:   time.Duration(viper.GetInt("MAPBOX_BATCH_INTERVAL_MS")) * time.Millisecond,
 		},
 		Worker: WorkerConfig{
-			Enabled:               viper.GetBool("WORKER_ENABLED"),
-			ConsumerGroup:         viper.GetString("WORKER_CONSUMER_GROUP"),
-			StreamReadTimeout:     time.Duration(viper.GetInt("WORKER_STREAM_READ_TIMEOUT")) * time.Millisecond,
-			MaxRetries:            viper.GetInt("WORKER_MAX_RETRIES"),
-			TransportRadius:       viper.GetFloat64("WORKER_TRANSPORT_RADIUS"),
-			TransportTypes:        parseTransportTypes(viper.GetString("WORKER_TRANSPORT_TYPES")),
-			InfrastructureEnabled: viper.GetBool("WORKER_INFRASTRUCTURE_ENABLED"),
-			MaxMetro:              viper.GetInt("WORKER_MAX_METRO"),
-			MaxTrain:              viper.GetInt("WORKER_MAX_TRAIN"),
-			MaxTram:               viper.GetInt("WORKER_MAX_TRAM"),
-			MaxBus:                viper.GetInt("WORKER_MAX_BUS"),
-			POIRadius:             viper.GetFloat64("WORKER_POI_RADIUS"),
+			Enabled:                 viper.GetBool("WORKER_ENABLED"),
+			ConsumerGroup:           viper.GetString("WORKER_CONSUMER_GROUP"),
+			StreamReadTimeout:       time.Duration(viper.GetInt("WORKER_STREAM_READ_TIMEOUT")) * time.Millisecond,
+			MaxRetries:              viper.GetInt("WORKER_MAX_RETRIES"),
+			TransportRadius:         viper.GetFloat64("WORKER_TRANSPORT_RADIUS"),
+			TransportTypes:          parseCommaList(viper.GetString("WORKER_TRANSPORT_TYPES")),
+			InfrastructureEnabled:   viper.GetBool("WORKER_INFRASTRUCTURE_ENABLED"),
+			MaxMetro:                viper.GetInt("WORKER_MAX_METRO"),
+			MaxTrain:                viper.GetInt("WORKER_MAX_TRAIN"),
+			MaxTram:                 viper.GetInt("WORKER_MAX_TRAM"),
+			MaxBus:                  viper.GetInt("WORKER_MAX_BUS"),
+			POIRadius:               viper.GetFloat64("WORKER_POI_RADIUS"),
+			AdaptiveTransportRadius: viper.GetBool("WORKER_TRANSPORT_ADAPTIVE_RADIUS"),
+			TransportProbeRadius:    viper.GetFloat64("WORKER_TRANSPORT_PROBE_RADIUS"),
+			TransportTargetCount:    viper.GetInt("WORKER_TRANSPORT_TARGET_COUNT"),
+			TransportMinRadius:      viper.GetFloat64("WORKER_TRANSPORT_MIN_RADIUS"),
+			TransportMaxRadius:      viper.GetFloat64("WORKER_TRANSPORT_MAX_RADIUS"),
+			PersistResults:          viper.GetBool("WORKER_PERSIST_RESULTS"),
+			Concurrency:             viper.GetInt("WORKER_CONCURRENCY"),
+		},
+		Enrichment: EnrichmentConfig{
+			DefaultProfile:  viper.GetString("ENRICHMENT_DEFAULT_PROFILE"),
+			Profiles:        parseNamedLists(viper.GetString("ENRICHMENT_PROFILES")),
+			ResolutionOrder: parseCommaList(viper.GetString("ENRICHMENT_RESOLUTION_ORDER")),
+			ExcludeLevels:   parseCommaList(viper.GetString("ENRICHMENT_EXCLUDE_LEVELS")),
+		},
+		POI: POIConfig{
+			CategoryRules:    parseNamedLists(viper.GetString("POI_CATEGORY_RULES")),
+			NameFallback:     viper.GetString("POI_NAME_FALLBACK"),
+			NameFallbackLang: viper.GetString("POI_NAME_FALLBACK_LANG"),
+		},
+		FeatureFilter: FeatureFilterConfig{
+			IncludeInactive:          viper.GetBool("INCLUDE_INACTIVE_FEATURES"),
+			IncludeRestrictedBeaches: viper.GetBool("INCLUDE_RESTRICTED_BEACHES"),
+		},
+		LocationScore: LocationScoreConfig{
+			Weights: viper.GetString("LOCATION_SCORE_WEIGHTS"),
+		},
+		Response: ResponseConfig{
+			CoordinatePrecision: viper.GetInt("COORDINATE_PRECISION"),
+			NameLanguages:       parseCommaList(viper.GetString("NAME_LANGUAGES")),
+			DistancePrecision:   parseNamedValues(viper.GetString("DISTANCE_PRECISION")),
+			TypeLabels:          parseNamedLists(viper.GetString("TYPE_LABELS")),
+		},
+		Batch: BatchConfig{
+			MaxSize:   viper.GetInt("MAX_BATCH_SIZE"),
+			ChunkSize: viper.GetInt("BATCH_CHUNK_SIZE"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:        viper.GetBool("RATE_LIMIT_ENABLED"),
+			CheapRPS:       viper.GetFloat64("RATE_LIMIT_CHEAP_RPS"),
+			CheapBurst:     viper.GetInt("RATE_LIMIT_CHEAP_BURST"),
+			ExpensiveRPS:   viper.GetFloat64("RATE_LIMIT_EXPENSIVE_RPS"),
+			ExpensiveBurst: viper.GetInt("RATE_LIMIT_EXPENSIVE_BURST"),
+			ExpensivePaths: parseCommaList(viper.GetString("RATE_LIMIT_EXPENSIVE_PATHS")),
+			APIKeys:        parseCommaList(viper.GetString("RATE_LIMIT_API_KEYS")),
+		},
+		Geocode: GeocodeConfig{
+			CountryPriority: parseCommaList(viper.GetString("GEOCODE_COUNTRY_PRIORITY")),
+			CellCacheSize:   viper.GetInt("GEOCODE_CELL_CACHE_SIZE"),
+
+			SimplifiedContains: viper.GetBool("BOUNDARY_SIMPLIFIED_CONTAINS"),
+			SimplifiedMaxLevel: viper.GetInt("BOUNDARY_SIMPLIFIED_MAX_LEVEL"),
+
+			PolygonMaxAreaSqKm: viper.GetFloat64("GEOCODE_POLYGON_MAX_AREA_SQ_KM"),
+			PolygonMaxVertices: viper.GetInt("GEOCODE_POLYGON_MAX_VERTICES"),
 		},
 	}
 
 	// Set default values if not provided
+	if cfg.OSMDB.SlowQueryThreshold == 0 {
+		cfg.OSMDB.SlowQueryThreshold = 500 * time.Millisecond // медленные запросы к OSM базе - WARN
+	}
+	if cfg.OSMDBReplica.Host != "" {
+		inheritDatabaseConfig(&cfg.OSMDBReplica, cfg.OSMDB)
+	}
+	if cfg.Geocode.CellCacheSize == 0 {
+		cfg.Geocode.CellCacheSize = 1000
+	}
 	if cfg.Worker.ConsumerGroup == "" {
 		cfg.Worker.ConsumerGroup = "location-enrichment-workers"
 	}
@@ -191,6 +418,9 @@ func Load() (*Config, error) {
 	if cfg.Worker.MaxRetries == 0 {
 		cfg.Worker.MaxRetries = 3
 	}
+	if cfg.Worker.Concurrency == 0 {
+		cfg.Worker.Concurrency = 1
+	}
 	if cfg.Worker.TransportRadius == 0 {
 		cfg.Worker.TransportRadius = 1000
 	}
@@ -212,6 +442,18 @@ func Load() (*Config, error) {
 	if cfg.Worker.POIRadius == 0 {
 		cfg.Worker.POIRadius = 1500
 	}
+	if cfg.Worker.TransportProbeRadius == 0 {
+		cfg.Worker.TransportProbeRadius = 1000
+	}
+	if cfg.Worker.TransportTargetCount == 0 {
+		cfg.Worker.TransportTargetCount = 10
+	}
+	if cfg.Worker.TransportMinRadius == 0 {
+		cfg.Worker.TransportMinRadius = 400
+	}
+	if cfg.Worker.TransportMaxRadius == 0 {
+		cfg.Worker.TransportMaxRadius = 3000
+	}
 	if cfg.Mapbox.BaseURL == "" {
 		cfg.Mapbox.BaseURL = "https://api.mapbox.com"
 	}
@@ -233,20 +475,117 @@ func Load() (*Config, error) {
 	if cfg.Server.Port == 0 {
 		cfg.Server.Port = 8085
 	}
+	if cfg.Server.ReadTimeout == 0 {
+		cfg.Server.ReadTimeout = 10 * time.Second
+	}
+	if cfg.Server.WriteTimeout == 0 {
+		cfg.Server.WriteTimeout = 10 * time.Second
+	}
+	if cfg.Server.IdleTimeout == 0 {
+		cfg.Server.IdleTimeout = 60 * time.Second
+	}
+	if cfg.Server.ReadTimeout < 0 || cfg.Server.WriteTimeout < 0 || cfg.Server.IdleTimeout < 0 {
+		return nil, fmt.Errorf("API_READ_TIMEOUT, API_WRITE_TIMEOUT and API_IDLE_TIMEOUT must be positive")
+	}
+	if cfg.Server.Concurrency < 0 {
+		return nil, fmt.Errorf("API_CONCURRENCY must not be negative")
+	}
+	if cfg.Server.BodyLimit == 0 {
+		cfg.Server.BodyLimit = 4 * 1024 * 1024
+	}
+	if cfg.Server.MaxJSONDepth == 0 {
+		cfg.Server.MaxJSONDepth = 32
+	}
+	if cfg.Server.BodyLimit < 0 || cfg.Server.MaxJSONDepth < 0 {
+		return nil, fmt.Errorf("API_BODY_LIMIT_BYTES and API_MAX_JSON_DEPTH must be positive")
+	}
+	if cfg.Server.ProxyHeader != "" && len(cfg.Server.TrustedProxies) == 0 {
+		return nil, fmt.Errorf("API_PROXY_HEADER requires API_TRUSTED_PROXIES: otherwise any client can spoof its IP")
+	}
+	if cfg.RateLimit.CheapRPS == 0 {
+		cfg.RateLimit.CheapRPS = 20
+	}
+	if cfg.RateLimit.CheapBurst == 0 {
+		cfg.RateLimit.CheapBurst = 40
+	}
+	if cfg.RateLimit.ExpensiveRPS == 0 {
+		cfg.RateLimit.ExpensiveRPS = 2
+	}
+	if cfg.RateLimit.ExpensiveBurst == 0 {
+		cfg.RateLimit.ExpensiveBurst = 5
+	}
+	if cfg.RateLimit.CheapBurst < 0 || cfg.RateLimit.ExpensiveBurst < 0 {
+		return nil, fmt.Errorf("RATE_LIMIT_CHEAP_BURST and RATE_LIMIT_EXPENSIVE_BURST must be positive")
+	}
+	if len(cfg.RateLimit.ExpensivePaths) == 0 {
+		cfg.RateLimit.ExpensivePaths = []string{"/batch", "/radius/", "/stream", "/along-path", "/reverse-geocode/polygon", "/lines.pbf"}
+	}
 	if cfg.Cache.POITileCacheTTL == 0 {
 		cfg.Cache.POITileCacheTTL = time.Hour // 1 hour default
 	}
 	if cfg.Cache.TransportTileCacheTTL == 0 {
 		cfg.Cache.TransportTileCacheTTL = time.Hour // 1 hour default
 	}
+	if cfg.Cache.TTLJitterPercent < 0 {
+		cfg.Cache.TTLJitterPercent = 0
+	}
+	if cfg.Cache.TTLJitterPercent > 50 {
+		cfg.Cache.TTLJitterPercent = 50 // больший разброс почти обнуляет короткие TTL
+	}
 	if cfg.Tile.POIMaxFeatures == 0 {
 		cfg.Tile.POIMaxFeatures = 1000 // Default max features per tile
 	}
+	if cfg.Enrichment.DefaultProfile == "" {
+		cfg.Enrichment.DefaultProfile = "minimal"
+	}
+	for _, origin := range cfg.Server.AllowedOrigins {
+		if origin != "*" && !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			return nil, fmt.Errorf("invalid CORS_ALLOWED_ORIGINS entry %q: expected scheme://host or *", origin)
+		}
+	}
 
 	return cfg, nil
 }
 
-func parseTransportTypes(s string) []string {
+// inheritDatabaseConfig заполняет незаданные параметры реплики значениями основной БД
+func inheritDatabaseConfig(replica *DatabaseConfig, primary DatabaseConfig) {
+	if replica.Port == 0 {
+		replica.Port = primary.Port
+	}
+	if replica.User == "" {
+		replica.User = primary.User
+	}
+	if replica.Password == "" {
+		replica.Password = primary.Password
+	}
+	if replica.DBName == "" {
+		replica.DBName = primary.DBName
+	}
+	if replica.SSLMode == "" {
+		replica.SSLMode = primary.SSLMode
+	}
+	if replica.MaxConns == 0 {
+		replica.MaxConns = primary.MaxConns
+	}
+	if replica.MaxIdleConns == 0 {
+		replica.MaxIdleConns = primary.MaxIdleConns
+	}
+	if replica.ConnMaxLifetime == 0 {
+		replica.ConnMaxLifetime = primary.ConnMaxLifetime
+	}
+	if replica.ConnMaxIdleTime == 0 {
+		replica.ConnMaxIdleTime = primary.ConnMaxIdleTime
+	}
+	if replica.SlowQueryThreshold == 0 {
+		replica.SlowQueryThreshold = primary.SlowQueryThreshold
+	}
+	if replica.StatementTimeout == 0 {
+		replica.StatementTimeout = primary.StatementTimeout
+	}
+}
+
+// parseCommaList разбирает список через запятую, пропуская пустые элементы; пусто - nil
+func parseCommaList(s string) []string {
 	if s == "" {
 		return nil
 	}
@@ -260,10 +599,78 @@ func parseTransportTypes(s string) []string {
 	return result
 }
 
+// parseNamedLists разбирает строку вида "standard=admin,transport;eco=admin,environment"
+func parseNamedLists(s string) map[string][]string {
+	if s == "" {
+		return nil
+	}
+	result := make(map[string][]string)
+	for _, entry := range strings.Split(s, ";") {
+		name, features, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+		result[name] = parseCommaList(features)
+	}
+	return result
+}
+
+// parseNamedValues разбирает строку вида "water=/data/water.mbtiles,pois=/data/pois.mbtiles"
+func parseNamedValues(s string) map[string]string {
+	if s == "" {
+		return nil
+	}
+	result := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(entry, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			continue
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// parseNamedBools разбирает строку вида "/api/v1/tiles=off,/api/v1/stats=false"; значения - on/off
+// или любые, понятные strconv.ParseBool. Записи с нераспознанным значением пропускаются
+func parseNamedBools(s string) map[string]bool {
+	values := parseNamedValues(s)
+	if values == nil {
+		return nil
+	}
+	result := make(map[string]bool, len(values))
+	for name, value := range values {
+		switch strings.ToLower(value) {
+		case "on":
+			result[name] = true
+		case "off":
+			result[name] = false
+		default:
+			if enabled, err := strconv.ParseBool(value); err == nil {
+				result[name] = enabled
+			}
+		}
+	}
+	return result
+}
+
 func (c *Config) GetServerAddr() string {
 	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
 }
 
+// IsProduction возвращает true для production окружения
+func (c *Config) IsProduction() bool {
+	env := strings.ToLower(strings.TrimSpace(c.Server.Env))
+	return env == "production" || env == "prod"
+}
+
+// IsDebugExplainEnabled возвращает true, если /debug/explain разрешен (флаг включен и окружение не production)
+func (c *Config) IsDebugExplainEnabled() bool {
+	return c.Server.DebugExplain && !c.IsProduction()
+}
+
 func (c *Config) GetDatabaseDSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
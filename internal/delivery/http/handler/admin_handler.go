@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+// AdminHandler - административные операции (сброс кеша)
+type AdminHandler struct {
+	cacheAdminUC *usecase.CacheAdminUseCase
+	logger       *zap.Logger
+}
+
+// NewAdminHandler - создание нового AdminHandler
+func NewAdminHandler(cacheAdminUC *usecase.CacheAdminUseCase, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		cacheAdminUC: cacheAdminUC,
+		logger:       logger,
+	}
+}
+
+// FlushCache godoc
+// @Summary Сброс кеша
+// @Description Удаляет ключи Redis пространства имен (tiles, search, stats), шаблона внутри пространства (tile:water:*) или диапазона тайлов одного зума через SCAN+DEL, не трогая остальные ключи. namespace=geocode очищает in-process кеш ячеек обратного геокодирования экземпляра, принявшего запрос (остальные процессы обновят ячейки по SEARCH_CACHE_TTL). Нужен после переимпорта данных. Требует Authorization: Bearer <ADMIN_TOKEN>.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param request body dto.CacheFlushRequest true "Что сбросить"
+// @Success 200 {object} utils.SuccessResponse{data=dto.CacheFlushResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /admin/cache/flush [post]
+func (h *AdminHandler) FlushCache(c *fiber.Ctx) error {
+	var req dto.CacheFlushRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	result, err := h.cacheAdminUC.Flush(c.Context(), req)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	h.logger.Info("Cache flushed",
+		zap.String("namespace", req.Namespace),
+		zap.String("pattern", result.Pattern),
+		zap.Int("deleted", result.Deleted),
+		zap.String("ip", c.IP()))
+
+	return utils.SendSuccess(c, result, nil)
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/utils"
+)
+
+// queryCoordinates разбирает обязательные ?lat и ?lon. Проверяется наличие параметров, а не
+// ненулевое значение: точки на экваторе и нулевом меридиане допустимы. false - параметра нет,
+// он не число или координаты вне диапазона.
+func queryCoordinates(c *fiber.Ctx) (float64, float64, bool) {
+	if c.Query("lat") == "" || c.Query("lon") == "" {
+		return 0, 0, false
+	}
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	lon, err := strconv.ParseFloat(c.Query("lon"), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return lat, lon, utils.ValidateCoordinates(lat, lon)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// DebugHandler - обработчик диагностических запросов (доступен только вне production)
+type DebugHandler struct {
+	debugUC *usecase.DebugUseCase
+	logger  *zap.Logger
+}
+
+// NewDebugHandler - создание нового DebugHandler
+func NewDebugHandler(debugUC *usecase.DebugUseCase, logger *zap.Logger) *DebugHandler {
+	return &DebugHandler{
+		debugUC: debugUC,
+		logger:  logger,
+	}
+}
+
+// Explain godoc
+// @Summary EXPLAIN для шаблона SQL запроса
+// @Description Выполняет EXPLAIN (ANALYZE, BUFFERS) для именованного шаблона запроса с тестовыми параметрами и возвращает план, таблицы с seq scan и использованные индексы. Без параметра query возвращает список шаблонов. Доступно только при DEBUG_EXPLAIN_ENABLED=true вне production.
+// @Tags Debug
+// @Produce json
+// @Param query query string false "Имя шаблона запроса (например, lines_in_radius)"
+// @Param lat query number false "Широта тестовой точки"
+// @Param lon query number false "Долгота тестовой точки"
+// @Success 200 {object} utils.SuccessResponse{data=dto.ExplainResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /debug/explain [get]
+func (h *DebugHandler) Explain(c *fiber.Ctx) error {
+	name := c.Query("query")
+	if name == "" {
+		queries := h.debugUC.ListQueries()
+		return utils.SendSuccess(c, fiber.Map{"queries": queries}, &utils.Meta{Total: len(queries)})
+	}
+
+	var lat, lon *float64
+	if c.Query("lat") != "" || c.Query("lon") != "" {
+		parsedLat, err := strconv.ParseFloat(c.Query("lat"), 64)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid lat"})
+		}
+		parsedLon, err := strconv.ParseFloat(c.Query("lon"), 64)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid lon"})
+		}
+		lat, lon = &parsedLat, &parsedLon
+	}
+
+	result, err := h.debugUC.Explain(c.Context(), name, lat, lon)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
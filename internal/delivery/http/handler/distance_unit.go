@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/utils"
+)
+
+// requestDistanceUnit - единица расстояний ответа: ?distance_unit, иначе body - значение из тела
+// запроса; пусто - метры. false - неизвестная единица. Usecase считают расстояния в метрах,
+// в единицу запроса ответ переводится один раз через ConvertDistances.
+func requestDistanceUnit(c *fiber.Ctx, body string) (utils.DistanceUnit, bool) {
+	raw := body
+	if unit := c.Query("distance_unit"); unit != "" {
+		raw = unit
+	}
+	return utils.ParseDistanceUnit(raw)
+}
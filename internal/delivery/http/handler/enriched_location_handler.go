@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/pkg/validator"
 	"github.com/location-microservice/internal/usecase"
@@ -12,16 +16,19 @@ import (
 // EnrichedLocationHandler - обработчик для обогащения локаций
 type EnrichedLocationHandler struct {
 	enrichedLocationUC *usecase.EnrichedLocationUseCase
+	typeLabels         domain.TypeLabels
 	logger             *zap.Logger
 }
 
 // NewEnrichedLocationHandler создает новый EnrichedLocationHandler
 func NewEnrichedLocationHandler(
 	enrichedLocationUC *usecase.EnrichedLocationUseCase,
+	typeLabels domain.TypeLabels,
 	logger *zap.Logger,
 ) *EnrichedLocationHandler {
 	return &EnrichedLocationHandler{
 		enrichedLocationUC: enrichedLocationUC,
+		typeLabels:         typeLabels,
 		logger:             logger,
 	}
 }
@@ -32,9 +39,10 @@ func NewEnrichedLocationHandler(
 // @Tags Location Enrichment
 // @Accept json
 // @Produce json
-// @Param request body dto.EnrichLocationBatchRequest true "Массив локаций для обогащения (до 100)"
+// @Param request body dto.EnrichLocationBatchRequest true "Массив локаций для обогащения (до MAX_BATCH_SIZE, по умолчанию 100)"
 // @Success 200 {object} utils.SuccessResponse{data=dto.EnrichLocationBatchResponse}
 // @Failure 400 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse "Превышен MAX_BATCH_SIZE"
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/locations/enrich/batch [post]
 func (h *EnrichedLocationHandler) EnrichLocationBatch(c *fiber.Ctx) error {
@@ -123,9 +131,10 @@ func (h *EnrichedLocationHandler) EnrichSingleLocation(c *fiber.Ctx) error {
 // @Tags Location Detection
 // @Accept json
 // @Produce json
-// @Param request body dto.DetectLocationBatchRequest true "Массив локаций для детекции (до 100)"
+// @Param request body dto.DetectLocationBatchRequest true "Массив локаций для детекции (до MAX_BATCH_SIZE, по умолчанию 100)"
 // @Success 200 {object} utils.SuccessResponse{data=dto.DetectLocationBatchResponse}
 // @Failure 400 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse "Превышен MAX_BATCH_SIZE"
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/locations/detect/batch [post]
 func (h *EnrichedLocationHandler) DetectLocationBatch(c *fiber.Ctx) error {
@@ -164,25 +173,50 @@ func (h *EnrichedLocationHandler) DetectLocationBatch(c *fiber.Ctx) error {
 // @Param lon query number true "Долгота"
 // @Param radius query number false "Радиус поиска в метрах" default(1500)
 // @Param limit query int false "Максимальное количество станций" default(5)
+// @Param group_lines_by_mode query bool false "Сгруппировать линии станций по виду транспорта (lines_by_mode)"
+// @Param include_entrances query bool false "Добавить станциям метро входы (railway=subway_entrance) в entrances"
+// @Param distance_mode query string false "Расчет walking_distance: straight - по прямой +20%, snapped - через привязку точки и станции к пешеходным дорогам" Enums(straight, snapped) default(straight)
+// @Param distance_unit query string false "Единица linear_distance, walking_distance и расстояний до входов" Enums(m, km) default(m)
+// @Param min_per_mode query string false "Минимум станций по видам через запятую (bus:1,tram:1): резерв вне приоритета, остальные слоты по расстоянию"
+// @Param format query string false "geojson - ответ FeatureCollection (то же, что Accept: application/geo+json)"
+// @Param language query string false "Язык подписей type_label (по умолчанию из Accept-Language)"
 // @Success 200 {object} utils.SuccessResponse{data=dto.PriorityTransportResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/transport/priority [get]
 func (h *EnrichedLocationHandler) GetPriorityTransport(c *fiber.Ctx) error {
-	lat := c.QueryFloat("lat", 0)
-	lon := c.QueryFloat("lon", 0)
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
+	}
+
 	radius := c.QueryFloat("radius", 1500)
 	limit := c.QueryInt("limit", 5)
 
-	if lat == 0 || lon == 0 {
-		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required"})
+	req := dto.PriorityTransportRequest{
+		Lat:              lat,
+		Lon:              lon,
+		Radius:           radius,
+		Limit:            limit,
+		GroupLinesByMode: c.QueryBool("group_lines_by_mode", false),
+		IncludeEntrances: c.QueryBool("include_entrances", false),
+		DistanceMode:     c.Query("distance_mode"),
+	}
+	unit, ok := requestDistanceUnit(c, "")
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
 	}
 
-	req := dto.PriorityTransportRequest{
-		Lat:    lat,
-		Lon:    lon,
-		Radius: radius,
-		Limit:  limit,
+	if raw := c.Query("min_per_mode", ""); raw != "" {
+		req.MinPerMode = make(map[string]int)
+		for _, part := range strings.Split(raw, ",") {
+			mode, countStr, ok := strings.Cut(strings.TrimSpace(part), ":")
+			count, err := strconv.Atoi(strings.TrimSpace(countStr))
+			if !ok || err != nil {
+				return c.Status(400).JSON(fiber.Map{"error": "invalid min_per_mode, expected mode:count"})
+			}
+			req.MinPerMode[strings.TrimSpace(mode)] = count
+		}
 	}
 
 	h.logger.Info("GetPriorityTransport request",
@@ -194,9 +228,20 @@ func (h *EnrichedLocationHandler) GetPriorityTransport(c *fiber.Ctx) error {
 		h.logger.Error("GetPriorityTransport failed", zap.Error(err))
 		return utils.SendError(c, err)
 	}
+	result.ConvertDistances(unit)
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
+
+	if wantsGeoJSON(c) {
+		features := make([]geoJSONFeature, 0, len(result.Stations))
+		for _, station := range result.Stations {
+			features = append(features, priorityStationFeature(station))
+		}
+		return sendGeoJSON(c, features)
+	}
 
 	return utils.SendSuccess(c, result, &utils.Meta{
-		Total: result.Meta.TotalFound,
+		Total:        result.Meta.TotalFound,
+		DistanceUnit: string(unit),
 	})
 }
 
@@ -206,9 +251,14 @@ func (h *EnrichedLocationHandler) GetPriorityTransport(c *fiber.Ctx) error {
 // @Tags Transport
 // @Accept json
 // @Produce json
-// @Param request body dto.PriorityTransportBatchRequest true "Массив точек"
+// @Param request body dto.PriorityTransportBatchRequest true "Массив точек (до MAX_BATCH_SIZE, по умолчанию 100)"
+// @Param distance_unit query string false "Единица расстояний в ответе (перекрывает distance_unit в теле)" Enums(m, km) default(m)
+// @Param distance_mode query string false "Расчет walking_distance (перекрывает distance_mode в теле): straight - по прямой +20%, snapped - через привязку точек и станций к пешеходным дорогам" Enums(straight, snapped) default(straight)
+// @Param format query string false "geojson - все станции одной FeatureCollection с point_index в properties"
+// @Param language query string false "Язык подписей type_label (по умолчанию из Accept-Language)"
 // @Success 200 {object} utils.SuccessResponse{data=dto.PriorityTransportBatchResponse}
 // @Failure 400 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse "Превышен MAX_BATCH_SIZE"
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/transport/priority/batch [post]
 func (h *EnrichedLocationHandler) GetPriorityTransportBatch(c *fiber.Ctx) error {
@@ -216,6 +266,13 @@ func (h *EnrichedLocationHandler) GetPriorityTransportBatch(c *fiber.Ctx) error
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
+	unit, ok := requestDistanceUnit(c, req.DistanceUnit)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
+	if mode := c.Query("distance_mode"); mode != "" {
+		req.DistanceMode = mode
+	}
 
 	if err := validator.Validate(&req); err != nil {
 		return utils.SendError(c, err)
@@ -229,8 +286,23 @@ func (h *EnrichedLocationHandler) GetPriorityTransportBatch(c *fiber.Ctx) error
 		h.logger.Error("GetPriorityTransportBatch failed", zap.Error(err))
 		return utils.SendError(c, err)
 	}
+	result.ConvertDistances(unit)
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
+
+	if wantsGeoJSON(c) {
+		features := make([]geoJSONFeature, 0, result.Meta.TotalStations)
+		for _, point := range result.Results {
+			for _, station := range point.Stations {
+				feature := priorityStationFeature(station)
+				feature.Properties["point_index"] = point.PointIndex
+				features = append(features, feature)
+			}
+		}
+		return sendGeoJSON(c, features)
+	}
 
 	return utils.SendSuccess(c, result, &utils.Meta{
-		Total: result.Meta.TotalStations,
+		Total:        result.Meta.TotalStations,
+		DistanceUnit: string(unit),
 	})
 }
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/pkg/validator"
+	"github.com/location-microservice/internal/usecase"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+// EnrichmentHandler - обработчик обогащения локаций по профилям
+type EnrichmentHandler struct {
+	enrichmentUC      *usecase.EnrichmentUseCase
+	enrichmentDebugUC *usecase.EnrichmentDebugUseCase
+	logger            *zap.Logger
+}
+
+// NewEnrichmentHandler создает новый EnrichmentHandler
+func NewEnrichmentHandler(enrichmentUC *usecase.EnrichmentUseCase, enrichmentDebugUC *usecase.EnrichmentDebugUseCase, logger *zap.Logger) *EnrichmentHandler {
+	return &EnrichmentHandler{
+		enrichmentUC:      enrichmentUC,
+		enrichmentDebugUC: enrichmentDebugUC,
+		logger:            logger,
+	}
+}
+
+// GetProfiles godoc
+// @Summary Список профилей обогащения
+// @Description Возвращает доступные профили обогащения и набор блоков данных каждого профиля (admin, transport, environment, poi). Профили задаются в ENRICHMENT_PROFILES.
+// @Tags Location Enrichment
+// @Produce json
+// @Success 200 {object} utils.SuccessResponse{data=dto.EnrichmentProfilesResponse}
+// @Router /api/v1/enrichment/profiles [get]
+func (h *EnrichmentHandler) GetProfiles(c *fiber.Ctx) error {
+	profiles := h.enrichmentUC.Profiles()
+
+	return utils.SendSuccess(c, dto.EnrichmentProfilesResponse{
+		DefaultProfile: h.enrichmentUC.DefaultProfile(),
+		Profiles:       profiles,
+	}, &utils.Meta{Total: len(profiles)})
+}
+
+// Enrich godoc
+// @Summary Обогащение локации по профилю
+// @Description Обогащает локацию блоками данных выбранного профиля: minimal - только административная иерархия, full - иерархия, транспорт, экология и ближайшие POI. Блоки выполняются параллельно.
+// @Tags Location Enrichment
+// @Accept json
+// @Produce json
+// @Param profile query string false "Имя профиля (по умолчанию ENRICHMENT_DEFAULT_PROFILE)"
+// @Param request body dto.EnrichSingleLocationRequest true "Данные локации"
+// @Success 200 {object} utils.SuccessResponse{data=domain.LocationDoneEvent}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/enrichment/enrich [post]
+func (h *EnrichmentHandler) Enrich(c *fiber.Ctx) error {
+	var req dto.EnrichSingleLocationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result, err := h.enrichmentUC.EnrichLocationWithProfile(c.Context(), enrichEventFromRequest(&req), c.Query("profile"))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
+
+// EnrichAddress godoc
+// @Summary Обогащение локации по адресу одной строкой
+// @Description Разбирает адрес одной строкой ("Carrer de Mallorca 401, 08013 Barcelona, España") на улицу, номер дома, индекс и административные уровни и обогащает локацию по профилю как /enrichment/enrich. Страна - последняя часть адреса, без нее используется country из запроса. В ответе parsed - результат разбора, result - результат обогащения.
+// @Tags Location Enrichment
+// @Accept json
+// @Produce json
+// @Param profile query string false "Имя профиля (по умолчанию ENRICHMENT_DEFAULT_PROFILE)"
+// @Param request body dto.EnrichAddressRequest true "Адрес"
+// @Success 200 {object} utils.SuccessResponse{data=dto.EnrichAddressResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/enrichment/enrich/address [post]
+func (h *EnrichmentHandler) EnrichAddress(c *fiber.Ctx) error {
+	var req dto.EnrichAddressRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result, err := h.enrichmentUC.EnrichAddress(c.Context(), req, c.Query("profile"))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
+
+// Debug godoc
+// @Summary Диагностика обогащения локации
+// @Description Обогащает локацию как /enrichment/enrich и дополнительно возвращает resolution_strategy - путь, которым получена административная иерархия (name:level8 - по названию уровня 8, coordinates - по координатам, fallback:level8/fallback:coordinates/fallback:country - иерархия достроена запасной стратегией, unresolved, skipped), и warnings - частичные сбои (неполная иерархия, не найдена страна, ошибки блоков транспорта и POI).
+// @Tags Location Enrichment
+// @Accept json
+// @Produce json
+// @Param profile query string false "Имя профиля (по умолчанию ENRICHMENT_DEFAULT_PROFILE)"
+// @Param request body dto.EnrichSingleLocationRequest true "Данные локации"
+// @Success 200 {object} utils.SuccessResponse{data=dto.EnrichmentDebugResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/enrichment/debug [post]
+func (h *EnrichmentHandler) Debug(c *fiber.Ctx) error {
+	var req dto.EnrichSingleLocationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result, err := h.enrichmentDebugUC.Enrich(c.Context(), enrichEventFromRequest(&req), c.Query("profile"))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
+
+// enrichEventFromRequest преобразует запрос обогащения в событие
+func enrichEventFromRequest(req *dto.EnrichSingleLocationRequest) *domain.LocationEnrichEvent {
+	return &domain.LocationEnrichEvent{
+		Country:      req.Country,
+		Region:       req.Region,
+		Province:     req.Province,
+		City:         req.City,
+		District:     req.District,
+		Neighborhood: req.Neighborhood,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		IsVisible:    req.IsVisible,
+	}
+}
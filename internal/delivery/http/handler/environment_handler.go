@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// EnvironmentHandler - обработчик выборок экологических объектов
+type EnvironmentHandler struct {
+	environmentUC *usecase.EnvironmentUseCase
+	logger        *zap.Logger
+}
+
+// NewEnvironmentHandler создает новый EnvironmentHandler
+func NewEnvironmentHandler(environmentUC *usecase.EnvironmentUseCase, logger *zap.Logger) *EnvironmentHandler {
+	return &EnvironmentHandler{
+		environmentUC: environmentUC,
+		logger:        logger,
+	}
+}
+
+// GetGreenSpacesInBoundary godoc
+// @Summary Крупнейшие зеленые зоны границы
+// @Description Возвращает зеленые зоны (парки, сады, леса, заповедники), пересекающие административную границу, по убыванию площади. Для зон, выходящих за край границы, указывается intersection_area_sq_m - площадь части внутри границы.
+// @Tags Environment
+// @Produce json
+// @Param id path string true "ID административной границы"
+// @Param limit query int false "Количество зон (по умолчанию 10, максимум 50)"
+// @Success 200 {object} utils.SuccessResponse{data=[]domain.GreenSpace}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/boundaries/{id}/green-spaces [get]
+func (h *EnvironmentHandler) GetGreenSpacesInBoundary(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid boundary ID"})
+	}
+
+	spaces, err := h.environmentUC.GetGreenSpacesInBoundary(c.Context(), id, c.QueryInt("limit", 0))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, spaces, &utils.Meta{Total: len(spaces)})
+}
+
+// GetEnvironmentSummary godoc
+// @Summary Ближайшие объекты окружения
+// @Description Одним запросом возвращает ближайшие зеленую зону, пляж, водоем и источник шума с расстояниями. Вид без объекта в радиусе возвращается как null; если запрос одного вида упал, он перечисляется в failed, остальные виды возвращаются.
+// @Tags Environment
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param radius_km query number false "Радиус поиска в км (0.1-100)" default(2)
+// @Param distance_unit query string false "Единица расстояний в ответе" Enums(m, km) default(m)
+// @Success 200 {object} utils.SuccessResponse{data=dto.EnvironmentSummaryResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/environment/summary [get]
+func (h *EnvironmentHandler) GetEnvironmentSummary(c *fiber.Ctx) error {
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
+	}
+
+	unit, ok := requestDistanceUnit(c, "")
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
+
+	summary, err := h.environmentUC.GetEnvironmentSummary(c.Context(), lat, lon, c.QueryFloat("radius_km", 0))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+	summary.ConvertDistances(unit)
+
+	return utils.SendSuccess(c, summary, &utils.Meta{DistanceUnit: string(unit)})
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/usecase/dto"
+)
+
+// contentTypeGeoJSON - MIME тип GeoJSON (RFC 7946)
+const contentTypeGeoJSON = "application/geo+json"
+
+// geoJSONFeatureCollection - ответ в формате GeoJSON для передачи в картографическую библиотеку без преобразований
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// geoJSONFeature - объект GeoJSON с точечной геометрией
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id"`
+	Geometry   geoJSONPoint           `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geoJSONPoint - геометрия Point, координаты в порядке [lon, lat]
+type geoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// wantsGeoJSON - клиент запросил GeoJSON через ?format=geojson или Accept: application/geo+json
+func wantsGeoJSON(c *fiber.Ctx) bool {
+	if format := c.Query("format"); format != "" {
+		return strings.EqualFold(format, "geojson")
+	}
+	return strings.Contains(c.Get(fiber.HeaderAccept), contentTypeGeoJSON)
+}
+
+// sendGeoJSON отправляет FeatureCollection без обертки SuccessResponse
+func sendGeoJSON(c *fiber.Ctx, features []geoJSONFeature) error {
+	return c.JSON(geoJSONFeatureCollection{Type: "FeatureCollection", Features: features}, contentTypeGeoJSON)
+}
+
+// stationFeature - станция как Point объект; properties дополняются именем, типом станции и его подписью
+func stationFeature(id, name, stationType, typeLabel string, lat, lon float64, properties map[string]interface{}) geoJSONFeature {
+	properties["name"] = name
+	properties["type"] = stationType
+	if typeLabel != "" {
+		properties["type_label"] = typeLabel
+	}
+	return geoJSONFeature{
+		Type:       "Feature",
+		ID:         id,
+		Geometry:   geoJSONPoint{Type: "Point", Coordinates: [2]float64{lon, lat}},
+		Properties: properties,
+	}
+}
+
+// nearestStationFeature - станция из /transport/nearest: расстояние и линии в properties
+func nearestStationFeature(s dto.TransportStationWithLines) geoJSONFeature {
+	return stationFeature(s.ID, s.Name, s.Type, s.TypeLabel, s.Lat, s.Lon, map[string]interface{}{
+		"distance": s.Distance,
+		"lines":    s.Lines,
+	})
+}
+
+// priorityStationFeature - станция из /transport/priority: расстояния, время пешком и линии в properties
+func priorityStationFeature(s dto.PriorityTransportStation) geoJSONFeature {
+	properties := map[string]interface{}{
+		"linear_distance":  s.LinearDistance,
+		"walking_distance": s.WalkingDistance,
+		"walking_time":     s.WalkingTime,
+	}
+	if s.NameEn != nil {
+		properties["name_en"] = *s.NameEn
+	}
+	if s.LinesByMode != nil {
+		properties["lines_by_mode"] = s.LinesByMode
+		properties["line_modes"] = s.LineModes
+	} else {
+		lines := s.Lines
+		if lines == nil {
+			lines = []dto.TransportLineInfoEnriched{}
+		}
+		properties["lines"] = lines
+	}
+	if s.Entrances != nil {
+		properties["entrances"] = s.Entrances
+	}
+	return stationFeature(strconv.FormatInt(s.StationID, 10), s.Name, s.Type, s.TypeLabel, s.Lat, s.Lon, properties)
+}
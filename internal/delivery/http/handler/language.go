@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// requestLanguage - язык подписей ответа: ?language, иначе основной тег первого языка из
+// Accept-Language ("es-ES,es;q=0.9" -> "es"); "" - язык не указан, подписи совпадают с кодами
+func requestLanguage(c *fiber.Ctx) string {
+	if lang := c.Query("language"); lang != "" {
+		return strings.ToLower(lang)
+	}
+
+	first, _, _ := strings.Cut(c.Get(fiber.HeaderAcceptLanguage), ",")
+	first, _, _ = strings.Cut(first, ";")
+	first, _, _ = strings.Cut(strings.TrimSpace(first), "-")
+	if first == "*" {
+		return ""
+	}
+	return strings.ToLower(first)
+}
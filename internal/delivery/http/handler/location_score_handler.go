@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// LocationScoreHandler - обработчик композитной оценки локации
+type LocationScoreHandler struct {
+	locationScoreUC *usecase.LocationScoreUseCase
+	logger          *zap.Logger
+}
+
+// NewLocationScoreHandler создает новый LocationScoreHandler
+func NewLocationScoreHandler(locationScoreUC *usecase.LocationScoreUseCase, logger *zap.Logger) *LocationScoreHandler {
+	return &LocationScoreHandler{
+		locationScoreUC: locationScoreUC,
+		logger:          logger,
+	}
+}
+
+// GetLocationScore godoc
+// @Summary Композитная оценка локации
+// @Description Оценивает точку по транспортной доступности, близости зеленых зон, тишине и плотности инфраструктуры (0-100). Составляющие считаются параллельно, композит - взвешенное среднее (веса в LOCATION_SCORE_WEIGHTS). Каждая составляющая содержит объекты, сформировавшие оценку.
+// @Tags Location Enrichment
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Success 200 {object} utils.SuccessResponse{data=domain.LocationScore}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/locations/score [get]
+func (h *LocationScoreHandler) GetLocationScore(c *fiber.Ctx) error {
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
+	}
+
+	result, err := h.locationScoreUC.GetLocationScore(c.Context(), lat, lon)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
+
+// GetElevationContext godoc
+// @Summary Высотный контекст локации
+// @Description Приблизительная высота по данным OSM без DEM: ближайший объект с тегом ele (вершина, геодезический пункт, горизонталь) и минимальная/максимальная высота объектов в радиусе. Если объектов с ele в радиусе нет, возвращается has_data=false.
+// @Tags Location Enrichment
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param radius_km query number false "Радиус поиска, км (по умолчанию 1, максимум 10)"
+// @Success 200 {object} utils.SuccessResponse{data=domain.ElevationContext}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/locations/elevation [get]
+func (h *LocationScoreHandler) GetElevationContext(c *fiber.Ctx) error {
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
+	}
+
+	result, err := h.locationScoreUC.GetElevationContext(c.Context(), lat, lon, c.QueryFloat("radius_km", 0))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
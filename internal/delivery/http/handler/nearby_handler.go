@@ -1,27 +1,36 @@
 package handler
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/usecase"
+	"github.com/location-microservice/internal/usecase/dto"
 	"go.uber.org/zap"
 )
 
 // NearbyHandler — обработчик для получения данных поблизости по категории
 type NearbyHandler struct {
-	nearbyUC *usecase.NearbyUseCase
-	logger   *zap.Logger
+	nearbyUC       *usecase.NearbyUseCase
+	neighborhoodUC *usecase.NeighborhoodUseCase
+	typeLabels     domain.TypeLabels
+	logger         *zap.Logger
 }
 
 // NewNearbyHandler создает новый NearbyHandler
 func NewNearbyHandler(
 	nearbyUC *usecase.NearbyUseCase,
+	neighborhoodUC *usecase.NeighborhoodUseCase,
+	typeLabels domain.TypeLabels,
 	logger *zap.Logger,
 ) *NearbyHandler {
 	return &NearbyHandler{
-		nearbyUC: nearbyUC,
-		logger:   logger,
+		nearbyUC:       nearbyUC,
+		neighborhoodUC: neighborhoodUC,
+		typeLabels:     typeLabels,
+		logger:         logger,
 	}
 }
 
@@ -38,6 +47,7 @@ func NewNearbyHandler(
 // @Param lon query number true "Долгота"
 // @Param radius query number false "Радиус поиска в км (для POI) или метрах (для transport)" default(1)
 // @Param limit query int false "Максимальное количество результатов" default(20)
+// @Param language query string false "Язык подписей: type_label станций для transport, category_label и subcategory_label POI для остальных категорий (по умолчанию из Accept-Language)"
 // @Success 200 {object} utils.SuccessResponse "Для transport: data=dto.PriorityTransportResponse, для остальных: data=dto.NearbyPOIResponse"
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -48,10 +58,9 @@ func (h *NearbyHandler) GetNearby(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "invalid category: " + category})
 	}
 
-	lat := c.QueryFloat("lat", 0)
-	lon := c.QueryFloat("lon", 0)
-	if lat == 0 || lon == 0 {
-		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required"})
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
 	}
 
 	radius := c.QueryFloat("radius", 0)
@@ -71,6 +80,7 @@ func (h *NearbyHandler) GetNearby(c *fiber.Ctx) error {
 			h.logger.Error("GetNearbyTransport failed", zap.Error(err))
 			return utils.SendError(c, err)
 		}
+		result.LocalizeTypes(h.typeLabels, requestLanguage(c))
 		return utils.SendSuccess(c, result, &utils.Meta{
 			Total: result.Meta.TotalFound,
 		})
@@ -82,8 +92,107 @@ func (h *NearbyHandler) GetNearby(c *fiber.Ctx) error {
 		h.logger.Error("GetNearbyPOI failed", zap.String("category", category), zap.Error(err))
 		return utils.SendError(c, err)
 	}
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
 
 	return utils.SendSuccess(c, result, &utils.Meta{
 		Total: result.Total,
 	})
 }
+
+// GetNeighborhoodContext godoc
+// @Summary Удобства и транспорт рядом с объектом
+// @Description Блок страницы объявления одним запросом: ближайший POI каждой категории (amenities) и ближайшие станции транспорта с линиями и временем пешком (transport). Удобства и транспорт ищутся параллельно, блок кешируется целиком. Если транспорт не удалось получить, блок возвращается без станций с meta.transport_error=true.
+// @Tags Nearby
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param categories query string false "Категории POI через запятую (healthcare, shopping, education, leisure, food_drink), по умолчанию все"
+// @Param poi_radius query number false "Радиус поиска удобств в км (до 5)" default(1)
+// @Param transport_radius query number false "Радиус поиска транспорта в метрах (100-10000)" default(1500)
+// @Param transport_limit query int false "Максимум станций (до 20)" default(5)
+// @Param distance_unit query string false "Единица расстояний" Enums(m, km) default(m)
+// @Param language query string false "Язык подписей type_label, category_label и subcategory_label (по умолчанию из Accept-Language)"
+// @Success 200 {object} utils.SuccessResponse{data=dto.NeighborhoodContextResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/neighborhood [get]
+func (h *NearbyHandler) GetNeighborhoodContext(c *fiber.Ctx) error {
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
+	}
+
+	var categories []string
+	if raw := c.Query("categories"); raw != "" {
+		categories = strings.Split(raw, ",")
+	}
+
+	opts := dto.NeighborhoodContextOptions{
+		Categories:      categories,
+		POIRadiusKm:     c.QueryFloat("poi_radius", 0),
+		TransportRadius: c.QueryFloat("transport_radius", 0),
+		TransportLimit:  c.QueryInt("transport_limit", 0),
+	}
+	unit, ok := requestDistanceUnit(c, "")
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
+
+	result, err := h.neighborhoodUC.GetNeighborhoodContext(c.Context(), lat, lon, opts)
+	if err != nil {
+		h.logger.Error("GetNeighborhoodContext failed", zap.Error(err))
+		return utils.SendError(c, err)
+	}
+	result.ConvertDistances(unit)
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
+
+	return utils.SendSuccess(c, result, &utils.Meta{
+		Total:        len(result.Amenities) + len(result.Transport),
+		DistanceUnit: string(unit),
+	})
+}
+
+// GetNearbyPOIDelta godoc
+// @Summary Изменения POI поблизости при смещении центра
+// @Description Для карты, которую двигает пользователь: при каждом новом центре возвращает только изменения набора POI относительно предыдущего центра той же сессии — вошедшие в радиус POI (entered) и ID покинувших его (left).
+// @Description Клиент повторяет запрос с новыми lat/lon и session_id из предыдущего ответа. Первый запрос, истекшая через 5 минут без обновлений сессия или сессия другой категории возвращают весь набор в entered и новый session_id.
+// @Tags Nearby
+// @Produce json
+// @Param category query string true "Категория POI" Enums(schools, medical, groceries, shopping, restaurants, sports, entertainment, parks, beauty, attractions)
+// @Param lat query number true "Широта нового центра"
+// @Param lon query number true "Долгота нового центра"
+// @Param radius query number false "Радиус поиска в км" default(1)
+// @Param limit query int false "Максимум POI в радиусе (до 100)" default(20)
+// @Param session query string false "session_id из предыдущего ответа"
+// @Success 200 {object} utils.SuccessResponse{data=dto.NearbyDelta}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/poi/nearby/delta [get]
+func (h *NearbyHandler) GetNearbyPOIDelta(c *fiber.Ctx) error {
+	category := c.Query("category")
+	if category == domain.TransportCategory || !domain.IsValidNearbyCategory(category) {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid category: " + category})
+	}
+
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
+	}
+
+	req := dto.NearbyDeltaRequest{
+		SessionID: c.Query("session"),
+		Category:  category,
+		Lat:       lat,
+		Lon:       lon,
+		RadiusKm:  c.QueryFloat("radius", 0),
+		Limit:     c.QueryInt("limit", 0),
+	}
+
+	delta, err := h.nearbyUC.GetNearbyPOIDelta(c.Context(), req)
+	if err != nil {
+		h.logger.Error("GetNearbyPOIDelta failed", zap.String("category", category), zap.Error(err))
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, delta, &utils.Meta{Total: delta.Total})
+}
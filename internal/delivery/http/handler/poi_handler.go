@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/pkg/validator"
 	"github.com/location-microservice/internal/usecase"
@@ -14,25 +15,35 @@ import (
 
 // POIHandler - обработчик для POI (точки интереса) запросов
 type POIHandler struct {
-	poiUC  *usecase.POIUseCase
-	logger *zap.Logger
+	poiUC      *usecase.POIUseCase
+	editMetaUC *usecase.EditMetaUseCase
+	typeLabels domain.TypeLabels
+	logger     *zap.Logger
 }
 
 // NewPOIHandler - создание нового POIHandler
-func NewPOIHandler(poiUC *usecase.POIUseCase, logger *zap.Logger) *POIHandler {
+func NewPOIHandler(poiUC *usecase.POIUseCase, editMetaUC *usecase.EditMetaUseCase, typeLabels domain.TypeLabels, logger *zap.Logger) *POIHandler {
 	return &POIHandler{
-		poiUC:  poiUC,
-		logger: logger,
+		poiUC:      poiUC,
+		editMetaUC: editMetaUC,
+		typeLabels: typeLabels,
+		logger:     logger,
 	}
 }
 
 // SearchByRadius godoc
 // @Summary Поиск точек интереса (POI) в радиусе
-// @Description Находит точки интереса (магазины, рестораны, больницы и т.д.) в указанном радиусе от точки. Поддерживает фильтрацию по категориям.
+// @Description Находит точки интереса (магазины, рестораны, больницы и т.д.) в указанном радиусе от точки. Поддерживает фильтрацию по категориям и тегам OSM: has_tags - наличие ключа (["website"]), require_tags - точное значение ({"outdoor_seating": "yes"}). Не более 10 тегов суммарно. category_priority - категории по убыванию важности: POI сортируются по уровню категории, затем по расстоянию (уровень учитывается до limit).
 // @Tags POI
 // @Accept json
 // @Produce json
 // @Param request body dto.RadiusPOIRequest true "Параметры поиска POI"
+// @Param surface_only query bool false "Исключить подземные и indoor объекты (location=underground, indoor=yes)"
+// @Param include_address query bool false "Добавить структурированный адрес из тегов addr:*"
+// @Param min_importance query int false "Минимальная оценка значимости 0-10: wikidata 4, wikipedia 2, название 1, вес подкатегории до 3 (перекрывает min_importance в теле)"
+// @Param order_by query string false "Порядок: distance или importance - сначала значимые (перекрывает order_by в теле)" Enums(distance, importance) default(distance)
+// @Param distance_unit query string false "Единица расстояний в ответе (перекрывает distance_unit в теле)" Enums(m, km) default(m)
+// @Param language query string false "Язык подписей category_label и subcategory_label (по умолчанию из Accept-Language)"
 // @Success 200 {object} utils.SuccessResponse{data=dto.RadiusPOIResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -42,6 +53,26 @@ func (h *POIHandler) SearchByRadius(c *fiber.Ctx) error {
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
+	if c.QueryBool("surface_only") {
+		req.SurfaceOnly = true
+	}
+	if c.QueryBool("include_address") {
+		req.IncludeAddress = true
+	}
+	if raw := c.Query("min_importance"); raw != "" {
+		minImportance, err := strconv.Atoi(raw)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid min_importance"})
+		}
+		req.MinImportance = minImportance
+	}
+	if orderBy := c.Query("order_by"); orderBy != "" {
+		req.OrderBy = orderBy
+	}
+	unit, ok := requestDistanceUnit(c, req.DistanceUnit)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
 
 	if err := validator.Validate(&req); err != nil {
 		return utils.SendError(c, err)
@@ -51,10 +82,51 @@ func (h *POIHandler) SearchByRadius(c *fiber.Ctx) error {
 	if err != nil {
 		return utils.SendError(c, err)
 	}
+	result.ConvertDistances(unit)
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
 
-	return utils.SendSuccess(c, result, &utils.Meta{
-		Total: result.Total,
-	})
+	meta := result.Page.Meta(result.Total)
+	meta.DistanceUnit = string(unit)
+	return utils.SendSuccess(c, result, meta)
+}
+
+// GetPOIsAlongPath godoc
+// @Summary Поиск POI вдоль маршрута
+// @Description Находит точки интереса в коридоре заданной ширины вокруг маршрута (например, заправки по пути). Результаты упорядочены по ходу движения: path_offset - позиция POI на маршруте от его начала, distance - расстояние до маршрута. Маршрут - от 2 до 1000 точек, длина не более 200 км.
+// @Tags POI
+// @Accept json
+// @Produce json
+// @Param request body dto.PathPOIRequest true "Маршрут и параметры поиска"
+// @Param distance_unit query string false "Единица расстояний в ответе (перекрывает distance_unit в теле)" Enums(m, km) default(m)
+// @Param language query string false "Язык подписей category_label и subcategory_label (по умолчанию из Accept-Language)"
+// @Success 200 {object} utils.SuccessResponse{data=dto.PathPOIResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/poi/along-path [post]
+func (h *POIHandler) GetPOIsAlongPath(c *fiber.Ctx) error {
+	var req dto.PathPOIRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	unit, ok := requestDistanceUnit(c, req.DistanceUnit)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result, err := h.poiUC.GetPOIsAlongPath(c.Context(), req)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+	result.ConvertDistances(unit)
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
+
+	meta := result.Page.Meta(result.Total)
+	meta.DistanceUnit = string(unit)
+	return utils.SendSuccess(c, result, meta)
 }
 
 // GetCategories godoc
@@ -82,6 +154,31 @@ func (h *POIHandler) GetCategories(c *fiber.Ctx) error {
 	})
 }
 
+// GetCategoryTree godoc
+// @Summary Дерево категорий и подкатегорий POI
+// @Description Возвращает все категории с подкатегориями, количеством POI и подписями из таксономии одним ответом - для двухуровневого фильтра на клиенте вместо запроса категорий и N запросов подкатегорий. Дерево кешируется до следующего импорта (24 часа).
+// @Tags POI
+// @Accept json
+// @Produce json
+// @Param language query string false "Язык подписей (en, es, ca, ru, uk, fr, pt, it, de)" default(en)
+// @Success 200 {object} utils.SuccessResponse{data=map[string]interface{}}
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/poi/categories/tree [get]
+func (h *POIHandler) GetCategoryTree(c *fiber.Ctx) error {
+	lang := c.Query("language", "en")
+
+	tree, err := h.poiUC.GetCategoryTree(c.Context(), lang)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, fiber.Map{
+		"categories": tree,
+	}, &utils.Meta{
+		Total: len(tree),
+	})
+}
+
 // GetPOIInBBox godoc
 // @Summary Получение POI в видимой области карты (bbox)
 // @Description Возвращает точки интереса в указанном прямоугольнике карты с пагинацией. Поддерживает фильтрацию по категориям и подкатегориям.
@@ -96,6 +193,9 @@ func (h *POIHandler) GetCategories(c *fiber.Ctx) error {
 // @Param subcategories query string false "Подкатегории через запятую"
 // @Param limit query int false "Лимит результатов (по умолчанию 10, максимум 100)"
 // @Param offset query int false "Смещение для пагинации"
+// @Param min_importance query int false "Минимальная оценка значимости 0-10 (total учитывает отбор)"
+// @Param order_by query string false "Порядок: importance - сначала значимые" Enums(importance)
+// @Param language query string false "Язык подписей category_label и subcategory_label (по умолчанию из Accept-Language)"
 // @Success 200 {object} utils.SuccessResponse{data=dto.BBoxPOIResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -120,6 +220,10 @@ func (h *POIHandler) GetPOIInBBox(c *fiber.Ctx) error {
 
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	minImportance, err := strconv.Atoi(c.Query("min_importance", "0"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid min_importance"})
+	}
 
 	var categories []string
 	if cats := c.Query("categories", ""); cats != "" {
@@ -144,6 +248,8 @@ func (h *POIHandler) GetPOIInBBox(c *fiber.Ctx) error {
 		NeLon:         neLon,
 		Categories:    categories,
 		Subcategories: subcategories,
+		MinImportance: minImportance,
+		OrderBy:       c.Query("order_by"),
 		Limit:         limit,
 		Offset:        offset,
 	}
@@ -152,18 +258,82 @@ func (h *POIHandler) GetPOIInBBox(c *fiber.Ctx) error {
 	if err != nil {
 		return utils.SendError(c, err)
 	}
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
+
+	return utils.SendSuccess(c, result, result.Page.Meta(result.Total))
+}
+
+// GetActivityCenter godoc
+// @Summary Центр активности видимой области карты (bbox)
+// @Description Возвращает средние координаты POI в прямоугольнике карты - куда тяготеет район - и число POI, по которым они посчитаны (чтобы клиент оценил значимость). При weighted=true POI взвешиваются по значимости (wikidata, достопримечательности). Если POI нет, lat и lon равны null. Стороны bbox - не больше 0.5 градуса.
+// @Tags POI
+// @Accept json
+// @Produce json
+// @Param sw_lat query number true "Широта юго-западного угла"
+// @Param sw_lon query number true "Долгота юго-западного угла"
+// @Param ne_lat query number true "Широта северо-восточного угла"
+// @Param ne_lon query number true "Долгота северо-восточного угла"
+// @Param categories query string false "Категории через запятую"
+// @Param weighted query bool false "Взвешивать POI по значимости (по умолчанию false)"
+// @Success 200 {object} utils.SuccessResponse{data=dto.ActivityCenterResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/poi/activity-center [get]
+func (h *POIHandler) GetActivityCenter(c *fiber.Ctx) error {
+	swLat, err := strconv.ParseFloat(c.Query("sw_lat"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid sw_lat"})
+	}
+	swLon, err := strconv.ParseFloat(c.Query("sw_lon"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid sw_lon"})
+	}
+	neLat, err := strconv.ParseFloat(c.Query("ne_lat"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid ne_lat"})
+	}
+	neLon, err := strconv.ParseFloat(c.Query("ne_lon"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid ne_lon"})
+	}
+
+	var categories []string
+	if cats := c.Query("categories", ""); cats != "" {
+		categories = strings.Split(cats, ",")
+		for i := range categories {
+			categories[i] = strings.TrimSpace(categories[i])
+		}
+	}
+
+	req := dto.ActivityCenterRequest{
+		SwLat:      swLat,
+		SwLon:      swLon,
+		NeLat:      neLat,
+		NeLon:      neLon,
+		Categories: categories,
+		Weighted:   c.QueryBool("weighted", false),
+	}
+
+	result, err := h.poiUC.GetActivityCenter(c.Context(), req)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
 
-	return utils.SendSuccess(c, result, &utils.Meta{Total: result.Total})
+	return utils.SendSuccess(c, result, nil)
 }
 
 // GetSubcategories godoc
 // @Summary Получение подкатегорий для категории
-// @Description Возвращает список подкатегорий для указанной категории POI (например, для healthcare: pharmacy, hospital, clinic)
+// @Description Возвращает список подкатегорий для указанной категории POI (например, для healthcare: pharmacy, hospital, clinic) с количеством POI в каждой и подписями из таксономии. При заданном bbox количество считается только в прямоугольнике - для фасетного фильтра по видимой области.
 // @Tags POI
 // @Accept json
 // @Produce json
 // @Param id path int true "ID категории"
 // @Param language query string false "Язык результатов (en, es, ca, ru, uk, fr, pt, it, de)" default(en)
+// @Param sw_lat query number false "Широта юго-западного угла bbox"
+// @Param sw_lon query number false "Долгота юго-западного угла bbox"
+// @Param ne_lat query number false "Широта северо-восточного угла bbox"
+// @Param ne_lon query number false "Долгота северо-восточного угла bbox"
 // @Success 200 {object} utils.SuccessResponse{data=map[string]interface{}}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -176,7 +346,20 @@ func (h *POIHandler) GetSubcategories(c *fiber.Ctx) error {
 	}
 	lang := c.Query("language", "en")
 
-	subcategories, err := h.poiUC.GetSubcategories(c.Context(), int64(categoryID), lang)
+	// bbox необязателен, но задается целиком
+	var bbox *domain.BoundingBox
+	if c.Query("sw_lat") != "" || c.Query("sw_lon") != "" || c.Query("ne_lat") != "" || c.Query("ne_lon") != "" {
+		swLat, errSwLat := strconv.ParseFloat(c.Query("sw_lat"), 64)
+		swLon, errSwLon := strconv.ParseFloat(c.Query("sw_lon"), 64)
+		neLat, errNeLat := strconv.ParseFloat(c.Query("ne_lat"), 64)
+		neLon, errNeLon := strconv.ParseFloat(c.Query("ne_lon"), 64)
+		if errSwLat != nil || errSwLon != nil || errNeLat != nil || errNeLon != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "sw_lat, sw_lon, ne_lat and ne_lon must all be valid numbers"})
+		}
+		bbox = &domain.BoundingBox{MinLat: swLat, MinLon: swLon, MaxLat: neLat, MaxLon: neLon}
+	}
+
+	subcategories, err := h.poiUC.GetSubcategories(c.Context(), int64(categoryID), lang, bbox)
 	if err != nil {
 		return utils.SendError(c, err)
 	}
@@ -187,3 +370,64 @@ func (h *POIHandler) GetSubcategories(c *fiber.Ctx) error {
 		Total: len(subcategories),
 	})
 }
+
+// GetPOIByID godoc
+// @Summary Получение POI по ID
+// @Description Возвращает подробную информацию о точке интереса: полный набор OSM тегов в поле tags и основные поля (phone, website, opening_hours, wheelchair)
+// @Tags POI
+// @Accept json
+// @Produce json
+// @Param id path int true "OSM ID точки интереса"
+// @Param include_meta query bool false "Добавить метаданные последней правки OSM (osm_version, osm_timestamp, osm_user)"
+// @Success 200 {object} utils.SuccessResponse{data=dto.POIDetailsResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/poi/{id} [get]
+func (h *POIHandler) GetPOIByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid POI ID format"})
+	}
+
+	result, err := h.poiUC.GetByID(c.Context(), id)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	if c.QueryBool("include_meta") {
+		meta, err := h.editMetaUC.GetEditMeta(c.Context(), domain.EditFeaturePOI, id)
+		if err != nil {
+			return utils.SendError(c, err)
+		}
+		result.EditMeta = meta
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
+
+// GetPOIBuilding godoc
+// @Summary Контур здания POI
+// @Description Возвращает здание, в контуре которого находится POI, или ближайшее здание в радиусе 30 м: GeoJSON геометрия, тип здания, building:levels и height, если указаны
+// @Tags POI
+// @Accept json
+// @Produce json
+// @Param id path int true "OSM ID точки интереса"
+// @Success 200 {object} utils.SuccessResponse{data=domain.Building}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/poi/{id}/building [get]
+func (h *POIHandler) GetPOIBuilding(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid POI ID format"})
+	}
+
+	result, err := h.poiUC.GetBuilding(c.Context(), id)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/usecase"
 	"go.uber.org/zap"
 )
@@ -34,25 +35,20 @@ func NewPOITileHandler(poiTileUC *usecase.POITileUseCase, logger *zap.Logger) *P
 // @Param y path int true "Tile Y coordinate"
 // @Param categories query string false "Категории через запятую (healthcare,shopping,education)"
 // @Param subcategories query string false "Подкатегории через запятую (pharmacy,hospital,school)"
+// @Param surface_only query bool false "Исключить подземные и indoor объекты (location=underground, indoor=yes)"
+// @Param labels query bool false "Добавить атрибуты rank (приоритет подписи) и min_zoom (zoom появления POI)"
+// @Param category_limit query int false "Максимум POI одной категории в тайле; по умолчанию лимит тайла делится между выбранными категориями поровну"
 // @Success 200 {file} byte "Vector tile in PBF format"
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 204 "Пустой тайл: в нем нет объектов"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse "Слой выключен на этом зуме (TILE_ZOOM_POLICY)"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/tiles/poi/{z}/{x}/{y}.pbf [get]
 func (h *POITileHandler) GetPOITile(c *fiber.Ctx) error {
 	// Парсинг параметров тайла
-	z, err := strconv.Atoi(c.Params("z"))
+	z, x, y, err := parseTileCoordinates(c)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid zoom parameter"})
-	}
-
-	x, err := strconv.Atoi(c.Params("x"))
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid x parameter"})
-	}
-
-	y, err := strconv.Atoi(c.Params("y"))
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid y parameter"})
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	// Парсинг query параметров
@@ -76,8 +72,16 @@ func (h *POITileHandler) GetPOITile(c *fiber.Ctx) error {
 		}
 	}
 
+	surfaceOnly := c.QueryBool("surface_only")
+	withLabels := c.QueryBool("labels")
+
+	categoryLimit, err := strconv.Atoi(c.Query("category_limit", "0"))
+	if err != nil || categoryLimit < 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid category_limit parameter"})
+	}
+
 	// Получение тайла
-	tile, err := h.poiTileUC.GetPOITile(c.Context(), z, x, y, categories, subcategories)
+	tile, err := h.poiTileUC.GetPOITile(c.Context(), z, x, y, categories, subcategories, surfaceOnly, withLabels, categoryLimit)
 	if err != nil {
 		h.logger.Error("Failed to get POI tile",
 			zap.Int("z", z),
@@ -86,7 +90,7 @@ func (h *POITileHandler) GetPOITile(c *fiber.Ctx) error {
 			zap.Strings("categories", categories),
 			zap.Strings("subcategories", subcategories),
 			zap.Error(err))
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to generate tile"})
+		return utils.SendError(c, err)
 	}
 
 	// Устанавливаем заголовки и отправляем тайл
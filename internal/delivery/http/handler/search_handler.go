@@ -1,7 +1,14 @@
 package handler
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/pkg/validator"
 	"github.com/location-microservice/internal/usecase"
@@ -11,15 +18,17 @@ import (
 
 // SearchHandler - обработчик для поисковых запросов
 type SearchHandler struct {
-	searchUC *usecase.SearchUseCase
-	logger   *zap.Logger
+	searchUC   *usecase.SearchUseCase
+	editMetaUC *usecase.EditMetaUseCase
+	logger     *zap.Logger
 }
 
 // NewSearchHandler - создание нового SearchHandler
-func NewSearchHandler(searchUC *usecase.SearchUseCase, logger *zap.Logger) *SearchHandler {
+func NewSearchHandler(searchUC *usecase.SearchUseCase, editMetaUC *usecase.EditMetaUseCase, logger *zap.Logger) *SearchHandler {
 	return &SearchHandler{
-		searchUC: searchUC,
-		logger:   logger,
+		searchUC:   searchUC,
+		editMetaUC: editMetaUC,
+		logger:     logger,
 	}
 }
 
@@ -32,6 +41,10 @@ func NewSearchHandler(searchUC *usecase.SearchUseCase, logger *zap.Logger) *Sear
 // @Param q query string true "Поисковый запрос (минимум 2 символа)"
 // @Param language query string false "Язык результатов (en, es, ca, ru, uk, fr, pt, it, de)" default(en)
 // @Param limit query int false "Максимальное количество результатов" default(10)
+// @Param min_population query int false "Минимальное население (границы без тега population исключаются)"
+// @Param min_area_sq_km query number false "Минимальная площадь, км²"
+// @Param order_by query string false "Сортировка: level (уровень, имя) или population (самые населенные первыми)" default(level)
+// @Param name_display query string false "Название в name: language - перевод на язык запроса, matched - совпавшее с запросом (при совпадении обоих - перевод), primary - name из OSM; исходное name возвращается в original_name" Enums(language, matched, primary) default(language)
 // @Success 200 {object} utils.SuccessResponse{data=dto.SearchResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -41,6 +54,10 @@ func (h *SearchHandler) Search(c *fiber.Ctx) error {
 	req.Query = c.Query("q")
 	req.Language = c.Query("language", "en")
 	req.Limit = c.QueryInt("limit", 10)
+	req.MinPopulation = c.QueryInt("min_population", 0)
+	req.MinAreaSqKm = c.QueryFloat("min_area_sq_km", 0)
+	req.OrderBy = c.Query("order_by")
+	req.NameDisplay = c.Query("name_display")
 
 	// Валидация
 	if err := validator.Validate(&req); err != nil {
@@ -53,9 +70,7 @@ func (h *SearchHandler) Search(c *fiber.Ctx) error {
 		return utils.SendError(c, err)
 	}
 
-	return utils.SendSuccess(c, result, &utils.Meta{
-		Total: result.Total,
-	})
+	return utils.SendSuccess(c, result, result.Page.Meta(result.Total))
 }
 
 // ReverseGeocode godoc
@@ -87,15 +102,172 @@ func (h *SearchHandler) ReverseGeocode(c *fiber.Ctx) error {
 	return utils.SendSuccess(c, result, nil)
 }
 
+// GetBreadcrumb godoc
+// @Summary Административный путь точки (breadcrumb)
+// @Description Возвращает уровни от страны к самому детальному (level, name, id) и готовую строку вида "Spain › Catalonia › Barcelona › Eixample". Названия переводятся на выбранный язык, если перевод есть. Безымянные уровни пропускаются, подряд идущие одинаковые названия схлопываются в самый детальный уровень.
+// @Tags Search
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param language query string false "Язык названий (en, es, ca, ...); без перевода - основное название"
+// @Param sep query string false "Разделитель уровней (до 8 символов)" default( › )
+// @Success 200 {object} utils.SuccessResponse{data=dto.BreadcrumbResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/reverse-geocode/breadcrumb [get]
+func (h *SearchHandler) GetBreadcrumb(c *fiber.Ctx) error {
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
+	}
+
+	result, err := h.searchUC.GetBreadcrumb(c.Context(), lat, lon, c.Query("language"), c.Query("sep"))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
+
+// GetNearestBoundaries godoc
+// @Summary Ближайшие границы уровня
+// @Description Возвращает границы уровня level, ближайшие к точке, с расстоянием до полигона (distance_m) - даже если ни одна из них не содержит точку. Помогает, когда координата чуть сдвинута и попала за границу города. Содержащие точку границы идут первыми с distance_m = 0 и contains = true.
+// @Tags Search
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param level query int true "Административный уровень (2-11)"
+// @Param limit query int false "Количество границ (1-20)" default(5)
+// @Param distance_unit query string false "Единица distance в ответе (distance_m - всегда метры)" Enums(m, km) default(m)
+// @Success 200 {object} utils.SuccessResponse{data=[]domain.NearestBoundary}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/boundaries/nearest [get]
+func (h *SearchHandler) GetNearestBoundaries(c *fiber.Ctx) error {
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
+	}
+
+	unit, ok := requestDistanceUnit(c, "")
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
+
+	result, err := h.searchUC.GetNearestBoundaries(c.Context(), lat, lon, c.QueryInt("level", 0), c.QueryInt("limit", 0))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+	dto.ConvertNearestBoundaryDistances(result, unit)
+
+	return utils.SendSuccess(c, result, &utils.Meta{Total: len(result), DistanceUnit: string(unit)})
+}
+
+// GetNearestPlace godoc
+// @Summary Ближайший населенный пункт
+// @Description Возвращает ближайшую к точке точку населенного пункта OSM (place=city|town|village|hamlet) с названием, типом, населением и расстоянием - для подписей вида "3 км от Sitges". Дополняет обратное геокодирование по полигонам: точка place часто ближе к тому, как место называют люди.
+// @Tags Search
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param distance_unit query string false "Единица distance в ответе (distance_m - всегда метры)" Enums(m, km) default(m)
+// @Success 200 {object} utils.SuccessResponse{data=domain.Place}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/places/nearest [get]
+func (h *SearchHandler) GetNearestPlace(c *fiber.Ctx) error {
+	lat, lon, ok := queryCoordinates(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required and must be valid coordinates"})
+	}
+
+	unit, ok := requestDistanceUnit(c, "")
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
+
+	result, err := h.searchUC.GetNearestPlace(c.Context(), lat, lon)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+	dto.ConvertPlaceDistance(result, unit)
+
+	return utils.SendSuccess(c, result, &utils.Meta{DistanceUnit: string(unit)})
+}
+
+// ReverseGeocodeWithConfidence godoc
+// @Summary Обратное геокодирование с оценкой достоверности
+// @Description Определяет административный адрес по координатам и возвращает confidence (0..1), рассчитанный по количеству совпавших уровней иерархии и расстоянию до края самого детального полигона
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param request body dto.ReverseGeocodeRequest true "Координаты точки"
+// @Success 200 {object} utils.SuccessResponse{data=dto.ReverseGeocodeConfidenceResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/reverse-geocode/confidence [post]
+func (h *SearchHandler) ReverseGeocodeWithConfidence(c *fiber.Ctx) error {
+	var req dto.ReverseGeocodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result, err := h.searchUC.ReverseGeocodeWithConfidence(c.Context(), req)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
+
+// GetBoundariesIntersectingPolygon godoc
+// @Summary Границы, пересекающие полигон
+// @Description Возвращает административные границы, которые пересекает GeoJSON полигон (например, зона доставки), с площадью пересечения и долей площади полигона внутри каждой границы. Невалидный полигон исправляется через ST_MakeValid. Полигон больше GEOCODE_POLYGON_MAX_AREA_SQ_KM (по умолчанию 50000 км²) или с числом вершин больше GEOCODE_POLYGON_MAX_VERTICES (10000) отклоняется с 400.
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param request body dto.PolygonBoundariesRequest true "Полигон GeoJSON и уровни границ"
+// @Success 200 {object} utils.SuccessResponse{data=[]domain.BoundaryOverlap}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/reverse-geocode/polygon [post]
+func (h *SearchHandler) GetBoundariesIntersectingPolygon(c *fiber.Ctx) error {
+	var req dto.PolygonBoundariesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result, err := h.searchUC.GetBoundariesIntersectingPolygon(c.Context(), req)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, &utils.Meta{
+		Total: len(result),
+	})
+}
+
 // BatchReverseGeocode godoc
 // @Summary Пакетное обратное геокодирование
-// @Description Определяет административные адреса для нескольких точек за один запрос (до 100 точек)
+// @Description Определяет административные адреса для нескольких точек за один запрос (до MAX_BATCH_SIZE точек, по умолчанию 100)
 // @Tags Search
 // @Accept json
 // @Produce json
 // @Param request body dto.BatchReverseGeocodeRequest true "Массив координат точек"
 // @Success 200 {object} utils.SuccessResponse{data=dto.BatchReverseGeocodeResponse}
 // @Failure 400 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse "Превышен MAX_BATCH_SIZE"
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/batch/reverse-geocode [post]
 func (h *SearchHandler) BatchReverseGeocode(c *fiber.Ctx) error {
@@ -116,24 +288,180 @@ func (h *SearchHandler) BatchReverseGeocode(c *fiber.Ctx) error {
 	return utils.SendSuccess(c, result, nil)
 }
 
+// BatchForwardGeocode godoc
+// @Summary Пакетное прямое геокодирование
+// @Description Находит координаты для структурированных адресов (до MAX_BATCH_SIZE, по умолчанию 100). Административные уровни ищутся по названию, адреса одной найденной области (города) ищутся вместе; улица и дом - внутри области не крупнее города. В results на каждый адрес (index - позиция в запросе) точка дома, улицы или точка внутри самой детальной найденной границы (precision house, street, boundary) с confidence; ненайденные адреса - с error.
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param request body dto.ForwardGeocodeBatchRequest true "Массив адресов"
+// @Success 200 {object} utils.SuccessResponse{data=dto.ForwardGeocodeBatchResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse "Превышен MAX_BATCH_SIZE"
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/batch/geocode [post]
+func (h *SearchHandler) BatchForwardGeocode(c *fiber.Ctx) error {
+	var req dto.ForwardGeocodeBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result, err := h.searchUC.ForwardGeocodeBatch(c.Context(), req)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, &utils.Meta{
+		Total: result.Meta.TotalAddresses,
+	})
+}
+
+// StreamReverseGeocode godoc
+// @Summary Потоковое обратное геокодирование (NDJSON)
+// @Description Определяет административные адреса для большого набора точек (до 5000) и отдает результат в формате NDJSON: одна строка на точку, строки отправляются по мере обработки под-пакетов. Строка содержит index исходной точки, address (null, если адрес не найден) и error при сбое под-пакета.
+// @Tags Search
+// @Accept json
+// @Produce application/x-ndjson
+// @Param request body dto.StreamReverseGeocodeRequest true "Массив координат точек"
+// @Success 200 {object} dto.ReverseGeocodeStreamItem "Одна строка NDJSON на точку"
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /api/v1/geocode/reverse/stream [post]
+func (h *SearchHandler) StreamReverseGeocode(c *fiber.Ctx) error {
+	var req dto.StreamReverseGeocodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/x-ndjson")
+	// Тело пишется после возврата из handler, поэтому fiber.Ctx внутри writer не используется
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		enc := json.NewEncoder(w)
+		err := h.searchUC.StreamReverseGeocode(context.Background(), req.Points, func(item dto.ReverseGeocodeStreamItem) error {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+			return w.Flush()
+		})
+		if err != nil {
+			h.logger.Warn("Reverse geocode stream interrupted", zap.Int("points", len(req.Points)), zap.Error(err))
+		}
+	})
+
+	return nil
+}
+
+// SearchWithinParent godoc
+// @Summary Поиск границ внутри родительской границы
+// @Description Ищет административные границы по названию, центроид которых лежит внутри указанной родительской границы. Используется для автодополнения городов в выбранном регионе. Без admin_levels возвращаются только границы более детального уровня, чем родитель.
+// @Tags Search
+// @Produce json
+// @Param id path string true "ID родительской границы"
+// @Param q query string true "Поисковый запрос (минимум 2 символа)"
+// @Param admin_levels query string false "Административные уровни через запятую (например 8,9)"
+// @Param limit query int false "Максимальное количество результатов" default(10)
+// @Success 200 {object} utils.SuccessResponse{data=dto.SearchResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/boundaries/{id}/search [get]
+func (h *SearchHandler) SearchWithinParent(c *fiber.Ctx) error {
+	parentID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid boundary ID"})
+	}
+
+	req := dto.SearchWithinParentRequest{
+		Query:    c.Query("q"),
+		ParentID: parentID,
+		Limit:    c.QueryInt("limit", 10),
+	}
+
+	if raw := c.Query("admin_levels", ""); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			level, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return c.Status(400).JSON(fiber.Map{"error": "Invalid admin_levels"})
+			}
+			req.AdminLevels = append(req.AdminLevels, level)
+		}
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result, err := h.searchUC.SearchWithinParent(c.Context(), req)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, result.Page.Meta(result.Total))
+}
+
+// GetBoundaryBBox godoc
+// @Summary Bbox границы
+// @Description Возвращает ограничивающий прямоугольник (min/max lat/lon) и центроид административной границы без полной геометрии. Используется для подгонки карты к выбранному региону.
+// @Tags Search
+// @Produce json
+// @Param id path string true "ID административной границы"
+// @Success 200 {object} utils.SuccessResponse{data=domain.BoundaryBBox}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/boundaries/{id}/bbox [get]
+func (h *SearchHandler) GetBoundaryBBox(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid boundary ID"})
+	}
+
+	result, err := h.searchUC.GetBoundaryBBox(c.Context(), id)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
+
 // GetBoundaryByID godoc
 // @Summary Получение границы по ID
 // @Description Возвращает подробную информацию об административной границе по её идентификатору
 // @Tags Search
 // @Accept json
 // @Produce json
-// @Param id path string true "ID административной границы"
-// @Success 200 {object} map[string]interface{} "Информация о границе"
+// @Param id path int true "ID административной границы"
+// @Param include_meta query bool false "Добавить метаданные последней правки OSM (osm_version, osm_timestamp, osm_user)"
+// @Success 200 {object} utils.SuccessResponse{data=dto.BoundaryDetailsResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/boundaries/{id} [get]
 func (h *SearchHandler) GetBoundaryByID(c *fiber.Ctx) error {
-	id := c.Params("id")
-	if id == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "ID required"})
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid boundary ID"})
 	}
 
-	// TODO: Добавить метод GetByID в use case
-	return c.JSON(fiber.Map{"message": "Get boundary by ID - not implemented yet"})
+	result, err := h.searchUC.GetBoundaryByID(c.Context(), id)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	if c.QueryBool("include_meta") {
+		meta, err := h.editMetaUC.GetEditMeta(c.Context(), domain.EditFeatureBoundary, id)
+		if err != nil {
+			return utils.SendError(c, err)
+		}
+		result.EditMeta = meta
+	}
+
+	return utils.SendSuccess(c, result, nil)
 }
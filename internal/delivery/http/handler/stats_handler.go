@@ -43,3 +43,40 @@ func (h *StatsHandler) GetStatistics(c *fiber.Ctx) error {
 
 	return utils.SendSuccess(c, stats, nil)
 }
+
+// GetDataCoverage godoc
+// @Summary Охват данных
+// @Description Возвращает территорию, для которой загружены данные OSM: bbox точечных объектов (ST_Extent), количество административных границ по уровням и, при grid > 0, сетку grid x grid с флагом пустых ячеек. Позволяет клиенту отличить "нет данных в регионе" от пустого результата. Кешируется на 24 часа.
+// @Tags Statistics
+// @Produce json
+// @Param grid query int false "Размер сетки покрытия (0 - без сетки, максимум 50); округляется вверх до 2, 5, 10, 20 или 50"
+// @Success 200 {object} utils.SuccessResponse{data=domain.DataCoverage}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/coverage [get]
+func (h *StatsHandler) GetDataCoverage(c *fiber.Ctx) error {
+	coverage, err := h.statsUC.GetDataCoverage(c.Context(), c.QueryInt("grid", 0))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, coverage, nil)
+}
+
+// GetAdminLevels godoc
+// @Summary Административные уровни в данных
+// @Description Возвращает admin_level, которые есть в импортированных данных OSM, с количеством границ и примером названия (самая большая граница уровня). Наборы уровней различаются между развертываниями (в некоторых нет 9/10/11) - клиент обратного геокодирования и карты может скрыть недоступные уровни. Кешируется на 24 часа.
+// @Tags Statistics
+// @Produce json
+// @Success 200 {object} utils.SuccessResponse{data=[]domain.AdminLevelInfo}
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/admin-levels [get]
+func (h *StatsHandler) GetAdminLevels(c *fiber.Ctx) error {
+	levels, err := h.statsUC.GetAdminLevels(c.Context())
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, levels, &utils.Meta{Total: len(levels)})
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
-	"crypto/md5"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/pkg/validator"
 	"github.com/location-microservice/internal/usecase"
@@ -14,9 +16,17 @@ import (
 )
 
 // sendTile отправляет тайл-данные клиенту с правильными HTTP заголовками:
-// Content-Type, Cache-Control, Access-Control-Allow-Origin, ETag/If-None-Match.
+// Content-Type, Cache-Control, ETag/If-None-Match. CORS заголовки выставляет middleware по allowlist.
+// ETag включает версию данных (TILE_DATA_VERSION), по ней middleware.TileNotModified отвечает 304 без генерации.
+// Пустой тайл (валидный MVT без объектов) - 204 No Content, чтобы клиент отличал его от ошибки;
+// ошибки тайловых эндпоинтов отдаются через utils.SendError (выключенный на зуме слой - 404, сбой генерации - 500).
 func sendTile(c *fiber.Ctx, tile []byte, contentType string, maxAge int) error {
-	etag := fmt.Sprintf(`"%x"`, md5.Sum(tile))
+	if len(tile) == 0 {
+		c.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	etag := domain.TileETag(usecase.TileDataVersion(), tile)
 	if c.Get("If-None-Match") == etag {
 		return c.SendStatus(fiber.StatusNotModified)
 	}
@@ -24,10 +34,24 @@ func sendTile(c *fiber.Ctx, tile []byte, contentType string, maxAge int) error {
 	c.Set("Content-Type", contentType)
 	c.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
 	c.Set("ETag", etag)
-	c.Set("Access-Control-Allow-Origin", "*")
 	return c.Send(tile)
 }
 
+// parseTileCoordinates разбирает z/x/y из пути тайла. Здесь проверяется только, что это числа;
+// диапазоны (z до TileMaxZoom, 0 <= x, y < 2^z) проверяет use case, одинаково для всех слоев.
+func parseTileCoordinates(c *fiber.Ctx) (z, x, y int, err error) {
+	if z, err = strconv.Atoi(c.Params("z")); err != nil {
+		return 0, 0, 0, fmt.Errorf("Invalid zoom parameter")
+	}
+	if x, err = strconv.Atoi(c.Params("x")); err != nil {
+		return 0, 0, 0, fmt.Errorf("Invalid x parameter")
+	}
+	if y, err = strconv.Atoi(c.Params("y")); err != nil {
+		return 0, 0, 0, fmt.Errorf("Invalid y parameter")
+	}
+	return z, x, y, nil
+}
+
 const (
 	contentTypePBF = "application/x-protobuf"
 	contentTypeMVT = "application/vnd.mapbox-vector-tile"
@@ -63,23 +87,37 @@ func NewTileHandler(tileUC *usecase.TileUseCase, logger *zap.Logger) *TileHandle
 // @Param z path int true "Zoom level (0-22)"
 // @Param x path int true "Tile X coordinate"
 // @Param y path int true "Tile Y coordinate"
+// @Param min_area_sq_km query number false "Минимальная площадь полигона в км² (по умолчанию зависит от зума)"
 // @Success 200 {file} byte "Vector tile in PBF format"
-// @Failure 500 {string} string "Failed to generate tile"
+// @Success 204 "Пустой тайл: в нем нет объектов"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} utils.ErrorResponse "Слой выключен на этом зуме (TILE_ZOOM_POLICY)"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/boundaries/tiles/{z}/{x}/{y}.pbf [get]
 func (h *TileHandler) GetBoundaryTile(c *fiber.Ctx) error {
-	z, _ := strconv.Atoi(c.Params("z"))
-	x, _ := strconv.Atoi(c.Params("x"))
-	y, _ := strconv.Atoi(c.Params("y"))
+	z, x, y, err := parseTileCoordinates(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var minAreaSqKm float64
+	if v := c.Query("min_area_sq_km"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid min_area_sq_km"})
+		}
+		minAreaSqKm = parsed
+	}
 
 	h.logger.Info("Boundary tile request",
 		zap.Int("z", z),
 		zap.Int("x", x),
 		zap.Int("y", y))
 
-	tile, err := h.tileUC.GetBoundaryTile(c.Context(), z, x, y)
+	tile, err := h.tileUC.GetBoundaryTile(c.Context(), z, x, y, minAreaSqKm)
 	if err != nil {
 		h.logger.Error("Failed to get boundary tile", zap.Error(err))
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	if len(tile) == 0 {
@@ -108,16 +146,19 @@ func (h *TileHandler) GetBoundaryTile(c *fiber.Ctx) error {
 // @Param x path int true "Tile X coordinate"
 // @Param y path int true "Tile Y coordinate"
 // @Success 200 {file} byte "Vector tile in PBF format"
-// @Failure 500 {string} string "Failed to generate tile"
+// @Success 204 "Пустой тайл: в нем нет объектов"
+// @Failure 400 {object} utils.ErrorResponse "z вне 0-22 или x, y вне 0..2^z-1"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/transport/tiles/{z}/{x}/{y}.pbf [get]
 func (h *TileHandler) GetTransportTile(c *fiber.Ctx) error {
-	z, _ := strconv.Atoi(c.Params("z"))
-	x, _ := strconv.Atoi(c.Params("x"))
-	y, _ := strconv.Atoi(c.Params("y"))
+	z, x, y, err := parseTileCoordinates(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 
 	tile, err := h.tileUC.GetTransportTile(c.Context(), z, x, y)
 	if err != nil {
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	return sendTile(c, tile, contentTypePBF, cacheMaxAgeTiles)
@@ -133,16 +174,20 @@ func (h *TileHandler) GetTransportTile(c *fiber.Ctx) error {
 // @Param x path int true "Tile X coordinate"
 // @Param y path int true "Tile Y coordinate"
 // @Success 200 {file} byte "Vector tile in PBF format"
-// @Failure 500 {string} string "Failed to generate tile"
+// @Success 204 "Пустой тайл: в нем нет объектов"
+// @Failure 400 {object} utils.ErrorResponse "z вне 0-22 или x, y вне 0..2^z-1"
+// @Failure 404 {object} utils.ErrorResponse "Слой выключен на этом зуме (TILE_ZOOM_POLICY)"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/green-spaces/tiles/{z}/{x}/{y}.pbf [get]
 func (h *TileHandler) GetGreenSpacesTile(c *fiber.Ctx) error {
-	z, _ := strconv.Atoi(c.Params("z"))
-	x, _ := strconv.Atoi(c.Params("x"))
-	y, _ := strconv.Atoi(c.Params("y"))
+	z, x, y, err := parseTileCoordinates(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 
 	tile, err := h.tileUC.GetGreenSpacesTile(c.Context(), z, x, y)
 	if err != nil {
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	return sendTile(c, tile, contentTypePBF, cacheMaxAgeEnvironment)
@@ -158,17 +203,21 @@ func (h *TileHandler) GetGreenSpacesTile(c *fiber.Ctx) error {
 // @Param x path int true "Tile X coordinate"
 // @Param y path int true "Tile Y coordinate"
 // @Success 200 {file} byte "Vector tile in PBF format"
-// @Failure 500 {string} string "Failed to generate tile"
+// @Success 204 "Пустой тайл: в нем нет объектов"
+// @Failure 400 {object} utils.ErrorResponse "z вне 0-22 или x, y вне 0..2^z-1"
+// @Failure 404 {object} utils.ErrorResponse "Слой выключен на этом зуме (TILE_ZOOM_POLICY)"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/water/tiles/{z}/{x}/{y}.pbf [get]
 func (h *TileHandler) GetWaterTile(c *fiber.Ctx) error {
-	z, _ := strconv.Atoi(c.Params("z"))
-	x, _ := strconv.Atoi(c.Params("x"))
-	y, _ := strconv.Atoi(c.Params("y"))
+	z, x, y, err := parseTileCoordinates(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 
 	tile, err := h.tileUC.GetWaterTile(c.Context(), z, x, y)
 	if err != nil {
 		h.logger.Error("Failed to get water tile", zap.Error(err))
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	return sendTile(c, tile, contentTypePBF, cacheMaxAgeEnvironment)
@@ -176,31 +225,29 @@ func (h *TileHandler) GetWaterTile(c *fiber.Ctx) error {
 
 // GetBeachesTile godoc
 // @Summary Получение векторного тайла с пляжами
-// @Description Возвращает векторный тайл (Mapbox Vector Tile) с пляжами. Минимальный уровень зума: 12.
+// @Description Возвращает векторный тайл (Mapbox Vector Tile) с пляжами. Зумы, на которых слой виден, задает TILE_ZOOM_POLICY (по умолчанию с 12), на остальных тайл пустой.
 // @Tags Tiles
 // @Accept json
 // @Produce application/vnd.mapbox-vector-tile
-// @Param z path int true "Zoom level (min: 12, max: 22)"
+// @Param z path int true "Zoom level (0-22)"
 // @Param x path int true "Tile X coordinate"
 // @Param y path int true "Tile Y coordinate"
 // @Success 200 {file} byte "Vector tile in PBF format"
-// @Failure 400 {object} map[string]string "Minimum zoom level is 12"
-// @Failure 500 {string} string "Failed to generate tile"
+// @Success 204 "Пустой тайл: в нем нет объектов"
+// @Failure 400 {object} utils.ErrorResponse "z вне 0-22 или x, y вне 0..2^z-1"
+// @Failure 404 {object} utils.ErrorResponse "Слой выключен на этом зуме (TILE_ZOOM_POLICY)"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/beaches/tiles/{z}/{x}/{y}.pbf [get]
 func (h *TileHandler) GetBeachesTile(c *fiber.Ctx) error {
-	z, _ := strconv.Atoi(c.Params("z"))
-	x, _ := strconv.Atoi(c.Params("x"))
-	y, _ := strconv.Atoi(c.Params("y"))
-
-	// Валидация zoom >= 12
-	if z < 12 {
-		return c.Status(400).JSON(fiber.Map{"error": "Minimum zoom level is 12 for beaches"})
+	z, x, y, err := parseTileCoordinates(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	tile, err := h.tileUC.GetBeachesTile(c.Context(), z, x, y)
 	if err != nil {
 		h.logger.Error("Failed to get beaches tile", zap.Error(err))
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	return sendTile(c, tile, contentTypePBF, cacheMaxAgeEnvironment)
@@ -216,17 +263,21 @@ func (h *TileHandler) GetBeachesTile(c *fiber.Ctx) error {
 // @Param x path int true "Tile X coordinate"
 // @Param y path int true "Tile Y coordinate"
 // @Success 200 {file} byte "Vector tile in PBF format"
-// @Failure 500 {string} string "Failed to generate tile"
+// @Success 204 "Пустой тайл: в нем нет объектов"
+// @Failure 400 {object} utils.ErrorResponse "z вне 0-22 или x, y вне 0..2^z-1"
+// @Failure 404 {object} utils.ErrorResponse "Слой выключен на этом зуме (TILE_ZOOM_POLICY)"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/noise-sources/tiles/{z}/{x}/{y}.pbf [get]
 func (h *TileHandler) GetNoiseSourcesTile(c *fiber.Ctx) error {
-	z, _ := strconv.Atoi(c.Params("z"))
-	x, _ := strconv.Atoi(c.Params("x"))
-	y, _ := strconv.Atoi(c.Params("y"))
+	z, x, y, err := parseTileCoordinates(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 
 	tile, err := h.tileUC.GetNoiseSourcesTile(c.Context(), z, x, y)
 	if err != nil {
 		h.logger.Error("Failed to get noise sources tile", zap.Error(err))
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	return sendTile(c, tile, contentTypePBF, cacheMaxAgeEnvironment)
@@ -234,31 +285,29 @@ func (h *TileHandler) GetNoiseSourcesTile(c *fiber.Ctx) error {
 
 // GetTouristZonesTile godoc
 // @Summary Получение векторного тайла с туристическими зонами
-// @Description Возвращает векторный тайл (Mapbox Vector Tile) с туристическими зонами и достопримечательностями. Минимальный уровень зума: 11.
+// @Description Возвращает векторный тайл (Mapbox Vector Tile) с туристическими зонами и достопримечательностями. Зумы, на которых слой виден, задает TILE_ZOOM_POLICY (по умолчанию с 11), на остальных тайл пустой.
 // @Tags Tiles
 // @Accept json
 // @Produce application/vnd.mapbox-vector-tile
-// @Param z path int true "Zoom level (min: 11, max: 22)"
+// @Param z path int true "Zoom level (0-22)"
 // @Param x path int true "Tile X coordinate"
 // @Param y path int true "Tile Y coordinate"
 // @Success 200 {file} byte "Vector tile in PBF format"
-// @Failure 400 {object} map[string]string "Minimum zoom level is 11"
-// @Failure 500 {string} string "Failed to generate tile"
+// @Success 204 "Пустой тайл: в нем нет объектов"
+// @Failure 400 {object} utils.ErrorResponse "z вне 0-22 или x, y вне 0..2^z-1"
+// @Failure 404 {object} utils.ErrorResponse "Слой выключен на этом зуме (TILE_ZOOM_POLICY)"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/tourist-zones/tiles/{z}/{x}/{y}.pbf [get]
 func (h *TileHandler) GetTouristZonesTile(c *fiber.Ctx) error {
-	z, _ := strconv.Atoi(c.Params("z"))
-	x, _ := strconv.Atoi(c.Params("x"))
-	y, _ := strconv.Atoi(c.Params("y"))
-
-	// Валидация zoom >= 11
-	if z < 11 {
-		return c.Status(400).JSON(fiber.Map{"error": "Minimum zoom level is 11 for tourist zones"})
+	z, x, y, err := parseTileCoordinates(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	tile, err := h.tileUC.GetTouristZonesTile(c.Context(), z, x, y)
 	if err != nil {
 		h.logger.Error("Failed to get tourist zones tile", zap.Error(err))
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	return sendTile(c, tile, contentTypePBF, cacheMaxAgeEnvironment)
@@ -272,8 +321,9 @@ func (h *TileHandler) GetTouristZonesTile(c *fiber.Ctx) error {
 // @Produce application/vnd.mapbox-vector-tile
 // @Param id path int true "ID транспортной линии"
 // @Success 200 {file} byte "Vector tile in PBF format"
+// @Success 204 "Пустой тайл: в нем нет объектов"
 // @Failure 400 {object} map[string]string "Invalid line ID"
-// @Failure 500 {string} string "Failed to generate tile"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/transport/lines/{id}.pbf [get]
 func (h *TileHandler) GetTransportLineTile(c *fiber.Ctx) error {
 	// Parse string ID from path parameter
@@ -288,7 +338,7 @@ func (h *TileHandler) GetTransportLineTile(c *fiber.Ctx) error {
 		h.logger.Error("Failed to get transport line tile",
 			zap.Int64("line_id", lineID),
 			zap.Error(err))
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	return sendTile(c, tile, contentTypeMVT, cacheMaxAgeTiles)
@@ -302,8 +352,9 @@ func (h *TileHandler) GetTransportLineTile(c *fiber.Ctx) error {
 // @Produce application/vnd.mapbox-vector-tile
 // @Param request body dto.TransportLinesRequest true "Массив ID линий"
 // @Success 200 {file} byte "Vector tile in PBF format"
+// @Success 204 "Пустой тайл: в нем нет объектов"
 // @Failure 400 {object} map[string]string "Invalid request or too many IDs"
-// @Failure 500 {string} string "Failed to generate tile"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/transport/lines.pbf [post]
 func (h *TileHandler) GetTransportLinesTile(c *fiber.Ctx) error {
 	var req struct {
@@ -337,12 +388,105 @@ func (h *TileHandler) GetTransportLinesTile(c *fiber.Ctx) error {
 		h.logger.Error("Failed to get transport lines tile",
 			zap.Int64s("line_ids", lineIDs),
 			zap.Error(err))
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	return sendTile(c, tile, contentTypeMVT, cacheMaxAgeTiles)
 }
 
+// GetTileSchema godoc
+// @Summary Схема слоев векторных тайлов
+// @Description Возвращает для каждого слоя MVT тип геометрии, имена и типы атрибутов. Атрибуты с condition есть в тайле только при этом условии (with_labels, clustered).
+// @Tags Tiles
+// @Produce json
+// @Success 200 {object} utils.SuccessResponse{data=dto.TileSchemaResponse}
+// @Router /api/v1/schema [get]
+func (h *TileHandler) GetTileSchema(c *fiber.Ctx) error {
+	c.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", cacheMaxAgeTiles))
+	return utils.SendSuccess(c, h.tileUC.GetTileSchema(), nil)
+}
+
+// GetTileDelta godoc
+// @Summary Изменения тайла относительно закешированной версии
+// @Description Сравнивает версию тайла клиента (ETag из since или If-None-Match) с текущей версией данных (TILE_DATA_VERSION). status: unchanged - тайл клиента актуален, full - тайл нужно запросить заново, patch - зарезервирован под пообъектные изменения (changes). Тайл при этом не генерируется. Слои: boundaries, transport, pois, green_spaces, water, beaches, noise_sources, tourist_zones.
+// @Tags Tiles
+// @Produce json
+// @Param layer path string true "Слой тайла"
+// @Param z path int true "Zoom level (0-22)"
+// @Param x path int true "Tile X coordinate"
+// @Param y path int true "Tile Y coordinate"
+// @Param since query string false "ETag закешированного тайла; по умолчанию - заголовок If-None-Match"
+// @Success 200 {object} utils.SuccessResponse{data=dto.TileDeltaResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /api/v1/tiles/delta/{layer}/{z}/{x}/{y} [get]
+func (h *TileHandler) GetTileDelta(c *fiber.Ctx) error {
+	z, x, y, err := parseTileCoordinates(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	since := c.Query("since")
+	if since == "" {
+		since = c.Get(fiber.HeaderIfNoneMatch)
+	}
+
+	result, err := h.tileUC.GetTileDelta(c.Params("layer"), z, x, y, since)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	c.Set("Cache-Control", "no-cache")
+	return utils.SendSuccess(c, result, nil)
+}
+
+// GetTileCover godoc
+// @Summary Тайлы, покрывающие bbox
+// @Description Возвращает координаты z/x/y тайлов зума zoom, покрывающих прямоугольник bbox (схема slippy map), для прогрева кеша и управления кешем тайлов на клиенте. Вычисляется без БД. Не больше 1024 тайлов: для большего bbox нужен меньший зум. bbox через антимеридиан не поддерживается.
+// @Tags Tiles
+// @Produce json
+// @Param bbox query string true "min_lon,min_lat,max_lon,max_lat"
+// @Param zoom query int true "Zoom level (0-22)"
+// @Success 200 {object} utils.SuccessResponse{data=dto.TileCoverResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /api/v1/tiles/cover [get]
+func (h *TileHandler) GetTileCover(c *fiber.Ctx) error {
+	bbox, err := parseBBoxQuery(c.Query("bbox"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	z, err := strconv.Atoi(c.Query("zoom"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid zoom parameter"})
+	}
+
+	result, err := h.tileUC.GetTileCover(bbox, z)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	c.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", cacheMaxAgeTiles))
+	return utils.SendSuccess(c, result, &utils.Meta{Total: result.Count})
+}
+
+// parseBBoxQuery разбирает bbox вида min_lon,min_lat,max_lon,max_lat (порядок OGC/GeoJSON).
+// Диапазоны координат проверяет use case.
+func parseBBoxQuery(raw string) (domain.BoundingBox, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return domain.BoundingBox{}, fmt.Errorf("Invalid bbox parameter: expected min_lon,min_lat,max_lon,max_lat")
+	}
+
+	coords := make([]float64, 4)
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return domain.BoundingBox{}, fmt.Errorf("Invalid bbox parameter: expected min_lon,min_lat,max_lon,max_lat")
+		}
+		coords[i] = v
+	}
+	return domain.BoundingBox{MinLon: coords[0], MinLat: coords[1], MaxLon: coords[2], MaxLat: coords[3]}, nil
+}
+
 // GetRadiusTiles godoc
 // @Summary Получение всех данных в радиусе в формате векторного тайла
 // @Description Возвращает векторный тайл (Mapbox Vector Tile) со всеми типами данных в указанном радиусе от точки: границы, транспорт, POI, зеленые зоны, воду и т.д. Можно фильтровать слои через параметр layers.
@@ -351,8 +495,9 @@ func (h *TileHandler) GetTransportLinesTile(c *fiber.Ctx) error {
 // @Produce application/vnd.mapbox-vector-tile
 // @Param request body dto.RadiusTilesRequest true "Координаты центра, радиус и опциональный список слоев"
 // @Success 200 {file} byte "Vector tile in PBF format"
+// @Success 204 "Пустой тайл: в нем нет объектов"
 // @Failure 400 {object} utils.ErrorResponse
-// @Failure 500 {string} string "Failed to generate tile"
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/radius/tiles.pbf [post]
 func (h *TileHandler) GetRadiusTiles(c *fiber.Ctx) error {
 	var req dto.RadiusTilesRequest
@@ -372,7 +517,7 @@ func (h *TileHandler) GetRadiusTiles(c *fiber.Ctx) error {
 			zap.Float64("lon", req.Lon),
 			zap.Float64("radius_km", req.RadiusKm),
 			zap.Error(err))
-		return c.Status(500).SendString("Failed to generate tile")
+		return utils.SendError(c, err)
 	}
 
 	return sendTile(c, tile, contentTypeMVT, cacheMaxAgeTiles)
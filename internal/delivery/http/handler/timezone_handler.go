@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// TimezoneHandler - обработчик часовых поясов
+type TimezoneHandler struct {
+	timezoneUC *usecase.TimezoneUseCase
+	logger     *zap.Logger
+}
+
+// NewTimezoneHandler - создание нового TimezoneHandler
+func NewTimezoneHandler(timezoneUC *usecase.TimezoneUseCase, logger *zap.Logger) *TimezoneHandler {
+	return &TimezoneHandler{
+		timezoneUC: timezoneUC,
+		logger:     logger,
+	}
+}
+
+// GetTimezone godoc
+// @Summary Часовой пояс координаты
+// @Description Возвращает часовой пояс IANA точки и текущее смещение от UTC. Пояс ищется в таблице timezones (timezone-boundary-builder), если она импортирована, иначе по тегу timezone административных границ OSM. Нет данных - 404 TIMEZONE_UNAVAILABLE.
+// @Tags Timezone
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Success 200 {object} utils.SuccessResponse{data=dto.TimezoneResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/timezone [get]
+func (h *TimezoneHandler) GetTimezone(c *fiber.Ctx) error {
+	if c.Query("lat") == "" || c.Query("lon") == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "lat and lon are required"})
+	}
+
+	result, err := h.timezoneUC.GetTimezone(c.Context(), c.QueryFloat("lat", 0), c.QueryFloat("lon", 0))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
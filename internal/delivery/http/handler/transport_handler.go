@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/pkg/validator"
 	"github.com/location-microservice/internal/usecase"
@@ -15,13 +16,17 @@ import (
 // TransportHandler - обработчик для транспортных запросов
 type TransportHandler struct {
 	transportUC *usecase.TransportUseCase
+	editMetaUC  *usecase.EditMetaUseCase
+	typeLabels  domain.TypeLabels
 	logger      *zap.Logger
 }
 
 // NewTransportHandler - создание нового TransportHandler
-func NewTransportHandler(transportUC *usecase.TransportUseCase, logger *zap.Logger) *TransportHandler {
+func NewTransportHandler(transportUC *usecase.TransportUseCase, editMetaUC *usecase.EditMetaUseCase, typeLabels domain.TypeLabels, logger *zap.Logger) *TransportHandler {
 	return &TransportHandler{
 		transportUC: transportUC,
+		editMetaUC:  editMetaUC,
+		typeLabels:  typeLabels,
 		logger:      logger,
 	}
 }
@@ -33,6 +38,10 @@ func NewTransportHandler(transportUC *usecase.TransportUseCase, logger *zap.Logg
 // @Accept json
 // @Produce json
 // @Param request body dto.NearestTransportRequest true "Параметры поиска станций"
+// @Param surface_only query bool false "Исключить подземные и indoor станции (location=underground, indoor=yes)"
+// @Param distance_unit query string false "Единица расстояний в ответе (перекрывает distance_unit в теле)" Enums(m, km) default(m)
+// @Param format query string false "geojson - ответ FeatureCollection (то же, что Accept: application/geo+json)"
+// @Param language query string false "Язык подписей type_label (по умолчанию из Accept-Language)"
 // @Success 200 {object} utils.SuccessResponse{data=dto.NearestTransportResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -42,6 +51,13 @@ func (h *TransportHandler) GetNearestStations(c *fiber.Ctx) error {
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
+	if c.QueryBool("surface_only") {
+		req.SurfaceOnly = true
+	}
+	unit, ok := requestDistanceUnit(c, req.DistanceUnit)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
 
 	if err := validator.Validate(&req); err != nil {
 		return utils.SendError(c, err)
@@ -51,21 +67,36 @@ func (h *TransportHandler) GetNearestStations(c *fiber.Ctx) error {
 	if err != nil {
 		return utils.SendError(c, err)
 	}
+	result.ConvertDistances(unit)
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
+
+	if wantsGeoJSON(c) {
+		features := make([]geoJSONFeature, 0, len(result.Stations))
+		for _, station := range result.Stations {
+			features = append(features, nearestStationFeature(station))
+		}
+		return sendGeoJSON(c, features)
+	}
 
 	return utils.SendSuccess(c, result, &utils.Meta{
-		Total: len(result.Stations),
+		Total:        len(result.Stations),
+		DistanceUnit: string(unit),
 	})
 }
 
 // BatchGetNearestStations godoc
 // @Summary Пакетный поиск ближайших станций для нескольких точек
-// @Description Находит ближайшие станции общественного транспорта для нескольких точек одновременно (до 100 точек за запрос)
+// @Description Находит ближайшие станции общественного транспорта для нескольких точек одновременно (до MAX_BATCH_SIZE точек за запрос, по умолчанию 100)
 // @Tags Transport
 // @Accept json
 // @Produce json
 // @Param request body dto.BatchNearestTransportRequest true "Массив точек и параметры поиска"
+// @Param distance_unit query string false "Единица расстояний в ответе (перекрывает distance_unit в теле)" Enums(m, km) default(m)
+// @Param format query string false "geojson - все станции одной FeatureCollection с point_index в properties"
+// @Param language query string false "Язык подписей type_label (по умолчанию из Accept-Language)"
 // @Success 200 {object} utils.SuccessResponse{data=dto.BatchNearestTransportResponse}
 // @Failure 400 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse "Превышен MAX_BATCH_SIZE"
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/batch/transport/nearest [post]
 func (h *TransportHandler) BatchGetNearestStations(c *fiber.Ctx) error {
@@ -73,6 +104,10 @@ func (h *TransportHandler) BatchGetNearestStations(c *fiber.Ctx) error {
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
+	unit, ok := requestDistanceUnit(c, req.DistanceUnit)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
 
 	if err := validator.Validate(&req); err != nil {
 		return utils.SendError(c, err)
@@ -82,6 +117,20 @@ func (h *TransportHandler) BatchGetNearestStations(c *fiber.Ctx) error {
 	if err != nil {
 		return utils.SendError(c, err)
 	}
+	result.ConvertDistances(unit)
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
+
+	if wantsGeoJSON(c) {
+		features := make([]geoJSONFeature, 0)
+		for i, stations := range result.Results {
+			for _, station := range stations {
+				feature := nearestStationFeature(station)
+				feature.Properties["point_index"] = i
+				features = append(features, feature)
+			}
+		}
+		return sendGeoJSON(c, features)
+	}
 
 	// Подсчет общего количества найденных станций
 	totalStations := 0
@@ -90,7 +139,8 @@ func (h *TransportHandler) BatchGetNearestStations(c *fiber.Ctx) error {
 	}
 
 	return utils.SendSuccess(c, result, &utils.Meta{
-		Total: totalStations,
+		Total:        totalStations,
+		DistanceUnit: string(unit),
 	})
 }
 
@@ -104,25 +154,17 @@ func (h *TransportHandler) BatchGetNearestStations(c *fiber.Ctx) error {
 // @Param x path int true "Tile X coordinate"
 // @Param y path int true "Tile Y coordinate"
 // @Param types query string false "Типы транспорта через запятую (metro,bus,tram,train)"
+// @Param surface_only query bool false "Исключить подземные и indoor станции (location=underground, indoor=yes)"
 // @Success 200 {file} byte "Vector tile in PBF format"
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 204 "Пустой тайл: в нем нет объектов"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/tiles/transport/{z}/{x}/{y}.pbf [get]
 func (h *TransportHandler) GetTransportTileByTypes(c *fiber.Ctx) error {
 	// Парсинг параметров тайла
-	z, err := strconv.Atoi(c.Params("z"))
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid zoom parameter"})
-	}
-
-	x, err := strconv.Atoi(c.Params("x"))
+	z, x, y, err := parseTileCoordinates(c)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid x parameter"})
-	}
-
-	y, err := strconv.Atoi(c.Params("y"))
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid y parameter"})
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	// Парсинг query параметров
@@ -136,8 +178,10 @@ func (h *TransportHandler) GetTransportTileByTypes(c *fiber.Ctx) error {
 		}
 	}
 
+	surfaceOnly := c.QueryBool("surface_only")
+
 	// Получение тайла
-	tile, err := h.transportUC.GetTransportTileByTypes(c.Context(), z, x, y, types)
+	tile, err := h.transportUC.GetTransportTileByTypes(c.Context(), z, x, y, types, surfaceOnly)
 	if err != nil {
 		h.logger.Error("Failed to get transport tile by types",
 			zap.Int("z", z),
@@ -145,13 +189,97 @@ func (h *TransportHandler) GetTransportTileByTypes(c *fiber.Ctx) error {
 			zap.Int("y", y),
 			zap.Strings("types", types),
 			zap.Error(err))
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to generate tile"})
+		return utils.SendError(c, err)
 	}
 
 	// Устанавливаем заголовки и отправляем тайл
 	return sendTile(c, tile, contentTypePBF, cacheMaxAgeTiles)
 }
 
+// GetLinesInBBox godoc
+// @Summary Получение линий транспорта в видимой области карты (bbox)
+// @Description Возвращает линии маршрутов, проходящие через прямоугольник карты, с GeoJSON геометрией, обрезанной по bbox. Направления одной линии возвращаются один раз (по ref). Сторона bbox не более 0.5 градуса.
+// @Tags Transport
+// @Accept json
+// @Produce json
+// @Param sw_lat query number true "Широта юго-западного угла"
+// @Param sw_lon query number true "Долгота юго-западного угла"
+// @Param ne_lat query number true "Широта северо-восточного угла"
+// @Param ne_lon query number true "Долгота северо-восточного угла"
+// @Param types query string false "Типы транспорта через запятую (metro,bus,tram,train)"
+// @Success 200 {object} utils.SuccessResponse{data=dto.TransportLinesGeometryResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/transport/lines/bbox [get]
+func (h *TransportHandler) GetLinesInBBox(c *fiber.Ctx) error {
+	swLat, err := strconv.ParseFloat(c.Query("sw_lat"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid sw_lat"})
+	}
+	swLon, err := strconv.ParseFloat(c.Query("sw_lon"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid sw_lon"})
+	}
+	neLat, err := strconv.ParseFloat(c.Query("ne_lat"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid ne_lat"})
+	}
+	neLon, err := strconv.ParseFloat(c.Query("ne_lon"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid ne_lon"})
+	}
+
+	var types []string
+	if t := c.Query("types", ""); t != "" {
+		types = strings.Split(t, ",")
+		for i := range types {
+			types[i] = strings.TrimSpace(types[i])
+		}
+	}
+
+	result, err := h.transportUC.GetLinesInBBox(c.Context(), dto.BBoxTransportLinesRequest{
+		SwLat: swLat,
+		SwLon: swLon,
+		NeLat: neLat,
+		NeLon: neLon,
+		Types: types,
+	})
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, &utils.Meta{Total: result.Total})
+}
+
+// GetLinesGeometry godoc
+// @Summary Получение геометрий нескольких транспортных линий
+// @Description Возвращает GeoJSON геометрии нескольких линий одним запросом (для отрисовки всей сети). При dedupe_by_ref направления одной линии возвращаются один раз.
+// @Tags Transport
+// @Accept json
+// @Produce json
+// @Param request body dto.TransportLinesGeometryRequest true "Массив ID линий"
+// @Success 200 {object} utils.SuccessResponse{data=dto.TransportLinesGeometryResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/transport/lines/geometry [post]
+func (h *TransportHandler) GetLinesGeometry(c *fiber.Ctx) error {
+	var req dto.TransportLinesGeometryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result, err := h.transportUC.GetLinesGeometry(c.Context(), req)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, result, &utils.Meta{Total: result.Total})
+}
+
 // GetLinesByStationID godoc
 // @Summary Получение линий для станции
 // @Description Возвращает список транспортных линий, которые проходят через указанную станцию (с информацией о цветах, операторах и т.д.)
@@ -208,6 +336,209 @@ func (h *TransportHandler) GetLinesByStationID(c *fiber.Ctx) error {
 	})
 }
 
+// GetStationByID godoc
+// @Summary Станция транспорта по ID
+// @Description Возвращает станцию (точку остановки OSM) с линиями, проходящими через нее
+// @Tags Transport
+// @Produce json
+// @Param station_id path int true "ID станции"
+// @Param include_meta query bool false "Добавить метаданные последней правки OSM (osm_version, osm_timestamp, osm_user)"
+// @Success 200 {object} utils.SuccessResponse{data=dto.StationDetailsResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/transport/station/{station_id} [get]
+func (h *TransportHandler) GetStationByID(c *fiber.Ctx) error {
+	stationID, err := strconv.ParseInt(c.Params("station_id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid station ID"})
+	}
+
+	station, lines, err := h.transportUC.GetStationByID(c.Context(), stationID)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	result := dto.StationDetailsResponse{
+		TransportStation: station,
+		Lines:            make([]dto.TransportLineInfo, 0, len(lines)),
+	}
+	for _, line := range lines {
+		result.Lines = append(result.Lines, dto.TransportLineInfo{
+			ID:        strconv.FormatInt(line.ID, 10),
+			Name:      line.Name,
+			Ref:       line.Ref,
+			Type:      line.Type,
+			Color:     line.Color,
+			TextColor: line.TextColor,
+			Operator:  line.Operator,
+			Network:   line.Network,
+		})
+	}
+
+	if c.QueryBool("include_meta") {
+		meta, err := h.editMetaUC.GetEditMeta(c.Context(), domain.EditFeatureStation, stationID)
+		if err != nil {
+			return utils.SendError(c, err)
+		}
+		result.EditMeta = meta
+	}
+
+	return utils.SendSuccess(c, result, nil)
+}
+
+// GetStationsAlongLine godoc
+// @Summary Станции вдоль линии (приближение)
+// @Description Возвращает станции в буфере вокруг геометрии линии, упорядоченные по положению вдоль линии; станции с одинаковым названием схлопываются. Это геометрическое приближение до разбора relation members: рядом проходящие линии могут добавить лишние станции.
+// @Tags Transport
+// @Produce json
+// @Param id path int true "ID линии"
+// @Param buffer_m query number false "Буфер вокруг линии в метрах (по умолчанию 50, максимум 500)"
+// @Success 200 {object} utils.SuccessResponse{data=[]domain.TransportStation}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/transport/lines/{id}/stations [get]
+func (h *TransportHandler) GetStationsAlongLine(c *fiber.Ctx) error {
+	lineID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid line ID"})
+	}
+
+	stations, err := h.transportUC.GetStationsAlongLine(c.Context(), lineID, c.QueryFloat("buffer_m", 0))
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, stations, &utils.Meta{Total: len(stations)})
+}
+
+// GetStationsInBoundary godoc
+// @Summary Станции внутри границы
+// @Description Возвращает станции транспорта внутри административной границы (например, все станции метро города), отсортированные по названию. Станции с одинаковым названием и типом (выходы метро) возвращаются один раз.
+// @Tags Transport
+// @Produce json
+// @Param id path int true "ID административной границы"
+// @Param types query string false "Типы транспорта через запятую (metro,bus,tram,train)"
+// @Success 200 {object} utils.SuccessResponse{data=[]domain.TransportStation}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/boundaries/{id}/stations [get]
+func (h *TransportHandler) GetStationsInBoundary(c *fiber.Ctx) error {
+	boundaryID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid boundary ID"})
+	}
+
+	var types []string
+	if t := c.Query("types", ""); t != "" {
+		types = strings.Split(t, ",")
+		for i := range types {
+			types[i] = strings.TrimSpace(types[i])
+		}
+	}
+
+	stations, err := h.transportUC.GetStationsInBoundary(c.Context(), boundaryID, types)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, stations, &utils.Meta{Total: len(stations)})
+}
+
+// GetTransportCoverage godoc
+// @Summary Покрытие транспортом вокруг точки
+// @Description Возвращает количество станций каждого типа (metro, train, tram, bus, ferry, other) во вложенных радиусах вокруг точки. Станции с одинаковым названием и типом считаются одной станцией.
+// @Tags Transport
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param bands query string false "Радиусы в метрах через запятую (по умолчанию 300,600,1000, максимум 5000)"
+// @Success 200 {object} utils.SuccessResponse{data=domain.TransportCoverage}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/transport/coverage [get]
+func (h *TransportHandler) GetTransportCoverage(c *fiber.Ctx) error {
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid lat"})
+	}
+	lon, err := strconv.ParseFloat(c.Query("lon"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid lon"})
+	}
+
+	var bands []float64
+	if raw := c.Query("bands", ""); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			band, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return c.Status(400).JSON(fiber.Map{"error": "invalid bands"})
+			}
+			bands = append(bands, band)
+		}
+	}
+
+	coverage, err := h.transportUC.GetTransportCoverage(c.Context(), lat, lon, bands)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+
+	return utils.SendSuccess(c, coverage, nil)
+}
+
+// GetStationComplexes godoc
+// @Summary Пересадочные узлы рядом с точкой
+// @Description Объединяет станции крупных пересадочных узлов (метро + электрички + автобусы) в комплексы: станции ближе cluster_distance друг к другу (по цепочке) с похожими названиями. Станции узла сгруппированы по виду транспорта в stations_by_mode, lines - объединение линий всех станций узла. Узлы упорядочены по расстоянию до ближайшей станции.
+// @Tags Transport
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param radius query number false "Радиус поиска в метрах (100-5000)" default(1000)
+// @Param cluster_distance query number false "Максимальное расстояние между соседними станциями узла в метрах (20-500)" default(150)
+// @Param limit query int false "Максимум узлов (до 50)" default(10)
+// @Param distance_unit query string false "Единица расстояний" Enums(m, km) default(m)
+// @Param language query string false "Язык подписей type_label (по умолчанию из Accept-Language)"
+// @Success 200 {object} utils.SuccessResponse{data=dto.StationComplexesResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/transport/complexes [get]
+func (h *TransportHandler) GetStationComplexes(c *fiber.Ctx) error {
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid lat"})
+	}
+	lon, err := strconv.ParseFloat(c.Query("lon"), 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid lon"})
+	}
+
+	req := dto.StationComplexRequest{
+		Lat:             lat,
+		Lon:             lon,
+		Radius:          c.QueryFloat("radius", 0),
+		ClusterDistance: c.QueryFloat("cluster_distance", 0),
+		Limit:           c.QueryInt("limit", 0),
+	}
+	unit, ok := requestDistanceUnit(c, "")
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "distance_unit must be m or km"})
+	}
+
+	result, err := h.transportUC.GetStationComplexes(c.Context(), req)
+	if err != nil {
+		return utils.SendError(c, err)
+	}
+	result.ConvertDistances(unit)
+	result.LocalizeTypes(h.typeLabels, requestLanguage(c))
+
+	return utils.SendSuccess(c, result, &utils.Meta{
+		Total:        len(result.Complexes),
+		DistanceUnit: string(unit),
+	})
+}
+
 // GetTransportInBBox godoc
 // @Summary Получение транспортных станций в видимой области карты (bbox)
 // @Description Возвращает транспортные станции с линиями в указанном прямоугольнике карты с пагинацией. Поддерживает фильтрацию по типам транспорта.
@@ -221,6 +552,7 @@ func (h *TransportHandler) GetLinesByStationID(c *fiber.Ctx) error {
 // @Param types query string false "Типы транспорта через запятую (metro,bus,tram,train)"
 // @Param limit query int false "Лимит результатов (по умолчанию 10, максимум 100)"
 // @Param offset query int false "Смещение для пагинации"
+// @Param language query string false "Язык подписей type_label (по умолчанию из Accept-Language)"
 // @Success 200 {object} utils.SuccessResponse{data=dto.BBoxTransportResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -268,6 +600,7 @@ result, err := h.transportUC.GetTransportInBBox(c.Context(), req)
 if err != nil {
 return utils.SendError(c, err)
 }
+result.LocalizeTypes(h.typeLabels, requestLanguage(c))
 
-return utils.SendSuccess(c, result, &utils.Meta{Total: result.Total})
+return utils.SendSuccess(c, result, result.Page.Meta(result.Total))
 }
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// AdminAuth - middleware административных эндпоинтов: запрос должен нести токен
+// в заголовке Authorization: Bearer <token>. Каждое обращение логируется.
+func AdminAuth(token string, logger *zap.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		provided := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			logger.Warn("Admin request rejected",
+				zap.String("path", c.Path()),
+				zap.String("ip", c.IP()))
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid admin token"})
+		}
+
+		logger.Info("Admin request",
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.String("ip", c.IP()))
+		return c.Next()
+	}
+}
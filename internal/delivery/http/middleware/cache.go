@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/cachemode"
+)
+
+// cacheRule - включен ли кеш для путей с префиксом
+type cacheRule struct {
+	prefix  string
+	enabled bool
+}
+
+// Cache - middleware, задающий режим кеширования запроса (см. cachemode). endpoints - префикс пути ->
+// включен ли кеш; действует самый длинный совпавший префикс, пути без правила кешируются.
+// Заголовок Cache-Control: no-cache (или Pragma: no-cache) обходит чтение кеша независимо от настроек:
+// ответ считается заново и обновляет кеш, если кеш эндпоинта не выключен.
+func Cache(endpoints map[string]bool) fiber.Handler {
+	rules := make([]cacheRule, 0, len(endpoints))
+	for prefix, enabled := range endpoints {
+		rules = append(rules, cacheRule{prefix: prefix, enabled: enabled})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return len(rules[i].prefix) > len(rules[j].prefix)
+	})
+
+	return func(c *fiber.Ctx) error {
+		mode := cachemode.Default
+		if !cacheEnabled(rules, c.Path()) {
+			mode = cachemode.Disabled
+		} else if requestsNoCache(c) {
+			mode = cachemode.Refresh
+		}
+
+		if mode != cachemode.Default {
+			c.Context().SetUserValue(cachemode.ContextKey, mode)
+			c.SetUserContext(cachemode.WithMode(c.UserContext(), mode))
+		}
+		return c.Next()
+	}
+}
+
+// cacheEnabled возвращает настройку самого длинного префикса пути; rules отсортированы по убыванию длины
+func cacheEnabled(rules []cacheRule, path string) bool {
+	for _, rule := range rules {
+		if strings.HasPrefix(path, rule.prefix) {
+			return rule.enabled
+		}
+	}
+	return true
+}
+
+// requestsNoCache проверяет директиву no-cache в Cache-Control или Pragma запроса
+func requestsNoCache(c *fiber.Ctx) bool {
+	for _, directive := range strings.Split(c.Get(fiber.HeaderCacheControl), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+			return true
+		}
+	}
+	return strings.EqualFold(strings.TrimSpace(c.Get(fiber.HeaderPragma)), "no-cache")
+}
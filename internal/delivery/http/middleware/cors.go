@@ -1,16 +1,43 @@
 package middleware
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
+// corsPreflightMaxAge - время кэширования preflight-ответа браузером, секунды.
+// POST batch-эндпоинты с JSON телом всегда требуют preflight, кэш избавляет от OPTIONS на каждый запрос.
+const corsPreflightMaxAge = 600
+
 // CORS - middleware для настройки Cross-Origin Resource Sharing.
-// Разрешает запросы со всех источников для корректной работы тайлов и API на фронтенде.
-func CORS() fiber.Handler {
+// Карта на фронтенде загружает тайлы и API с другого источника, поэтому middleware покрывает все маршруты.
+// allowedOrigins - разрешенные источники; пустой список или "*" разрешают любой источник (разработка).
+func CORS(allowedOrigins []string) fiber.Handler {
 	return cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Content-Type,Accept,Accept-Language,Authorization,If-None-Match",
+		AllowOrigins:  corsAllowOrigins(allowedOrigins),
+		AllowMethods:  "GET,POST,PUT,DELETE,OPTIONS",
+		AllowHeaders:  "Content-Type,Accept,Accept-Language,Authorization,If-None-Match,X-API-Key",
+		ExposeHeaders: "ETag,Cache-Control,Content-Length,Retry-After",
+		MaxAge:        corsPreflightMaxAge,
 	})
 }
+
+// corsAllowOrigins собирает значение AllowOrigins для fiber cors
+func corsAllowOrigins(origins []string) string {
+	allowed := make([]string, 0, len(origins))
+	for _, origin := range origins {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin == "*" {
+			return "*"
+		}
+		if origin != "" {
+			allowed = append(allowed, origin)
+		}
+	}
+	if len(allowed) == 0 {
+		return "*"
+	}
+	return strings.Join(allowed, ",")
+}
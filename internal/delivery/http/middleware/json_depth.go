@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+)
+
+// JSONDepthLimit - middleware, отклоняющий JSON тела с вложенностью глубже maxDepth до BodyParser.
+// Пакетные запросы - плоские массивы объектов, глубокая вложенность бывает только у мусорных
+// или злонамеренных тел, разбор которых тратит память и стек декодера. maxDepth <= 0 - без проверки.
+func JSONDepthLimit(maxDepth int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if maxDepth <= 0 || !strings.Contains(c.Get(fiber.HeaderContentType), "json") {
+			return c.Next()
+		}
+
+		body := c.Body()
+		if len(body) == 0 || jsonDepthWithin(body, maxDepth) {
+			return c.Next()
+		}
+
+		return utils.SendError(c, errors.ErrJSONTooDeep.WithDetails(map[string]interface{}{
+			"max_depth": maxDepth,
+		}))
+	}
+}
+
+// jsonDepthWithin проверяет, что вложенность объектов и массивов не превышает maxDepth.
+// Скобки внутри строк не считаются; синтаксис не проверяется - это делает декодер.
+func jsonDepthWithin(body []byte, maxDepth int) bool {
+	depth := 0
+	inString, escaped := false, false
+	for _, b := range body {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch b {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > maxDepth {
+				return false
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+	return true
+}
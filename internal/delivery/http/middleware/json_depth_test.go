@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONDepthWithin(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		maxDepth int
+		want     bool
+	}{
+		{name: "flat batch", body: `[{"lat":41.38,"lon":2.17},{"lat":40.4,"lon":-3.7}]`, maxDepth: 2, want: true},
+		{name: "depth exactly at limit", body: `{"a":{"b":[1]}}`, maxDepth: 3, want: true},
+		{name: "one level over limit", body: `{"a":{"b":[[1]]}}`, maxDepth: 3, want: false},
+		{name: "brackets inside strings", body: `{"q":"[[[{{{"}`, maxDepth: 1, want: true},
+		{name: "escaped quote keeps string open", body: `{"q":"say \"[[[\" twice"}`, maxDepth: 1, want: true},
+		{name: "escaped backslash closes string", body: `{"q":"dir\\","r":[[1]]}`, maxDepth: 2, want: false},
+		{name: "scalar body", body: `"text"`, maxDepth: 1, want: true},
+		{name: "depth counted after closing", body: `[[1],[2],[[3]]]`, maxDepth: 2, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jsonDepthWithin([]byte(tt.body), tt.maxDepth))
+		})
+	}
+}
+
+func TestJSONDepthLimit(t *testing.T) {
+	app := fiber.New()
+	app.Use(JSONDepthLimit(2))
+	app.Post("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	post := func(body, contentType string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, contentType)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, post(`[{"lat":1}]`, fiber.MIMEApplicationJSON))
+	assert.Equal(t, fiber.StatusBadRequest, post(`[[[1]]]`, fiber.MIMEApplicationJSON))
+	// Не JSON тела не проверяются
+	assert.Equal(t, fiber.StatusOK, post(`[[[1]]]`, fiber.MIMETextPlain))
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"container/list"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	// rateLimitAPIKeyHeader - клиенты с известным ключом ограничиваются по ключу, остальные по IP
+	rateLimitAPIKeyHeader = "X-API-Key"
+	// rateLimitIdleTTL - корзина клиента без запросов дольше TTL забывается
+	rateLimitIdleTTL = 10 * time.Minute
+	// maxRateLimitClients - верхняя граница числа корзин в памяти на класс
+	maxRateLimitClients = 100000
+)
+
+// RateLimitBudget - бюджет token bucket: RPS токенов в секунду, не более Burst подряд
+type RateLimitBudget struct {
+	RPS   float64
+	Burst int
+}
+
+// RateLimitConfig - ограничение частоты запросов по классам эндпоинтов.
+// Дорогие эндпоинты (пакетные запросы, тайлы в радиусе, потоки) определяются по подстроке пути.
+// Отдельная корзина по ключу - только для ключей из APIKeys: иначе клиент обходил бы лимит,
+// присылая новый ключ в каждом запросе, и раздувал бы число корзин.
+type RateLimitConfig struct {
+	Cheap          RateLimitBudget
+	Expensive      RateLimitBudget
+	ExpensivePaths []string
+	APIKeys        []string
+}
+
+// tokenBucket - корзина одного клиента
+type tokenBucket struct {
+	key    string
+	tokens float64
+	seenAt time.Time
+}
+
+// rateLimiter - корзины клиентов одного класса в памяти процесса (LRU). Лимит действует на инстанс:
+// при нескольких репликах клиент получает бюджет каждой.
+type rateLimiter struct {
+	mu      sync.Mutex
+	budget  RateLimitBudget
+	entries *list.List               // *tokenBucket, в начале - последние обращавшиеся клиенты
+	buckets map[string]*list.Element // ключ клиента -> элемент списка
+	now     func() time.Time
+}
+
+func newRateLimiter(budget RateLimitBudget) *rateLimiter {
+	return &rateLimiter{
+		budget:  budget,
+		entries: list.New(),
+		buckets: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// allow списывает токен клиента. Если токенов нет, возвращает время до появления следующего.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var bucket *tokenBucket
+	if el, ok := l.buckets[key]; ok {
+		bucket = el.Value.(*tokenBucket)
+		l.entries.MoveToFront(el)
+	} else {
+		l.evict(now)
+		bucket = &tokenBucket{key: key, tokens: float64(l.budget.Burst), seenAt: now}
+		l.buckets[key] = l.entries.PushFront(bucket)
+	}
+
+	elapsed := now.Sub(bucket.seenAt).Seconds()
+	bucket.tokens = math.Min(float64(l.budget.Burst), bucket.tokens+elapsed*l.budget.RPS)
+	bucket.seenAt = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / l.budget.RPS * float64(time.Second))
+	return false, wait
+}
+
+// evict удаляет с конца списка (там самые давние клиенты) простаивающие корзины и освобождает
+// место под нового клиента. Вызывается под mu.
+func (l *rateLimiter) evict(now time.Time) {
+	for el := l.entries.Back(); el != nil; el = l.entries.Back() {
+		if l.entries.Len() < maxRateLimitClients && now.Sub(el.Value.(*tokenBucket).seenAt) <= rateLimitIdleTTL {
+			return
+		}
+		bucket := l.entries.Remove(el).(*tokenBucket)
+		delete(l.buckets, bucket.key)
+	}
+}
+
+// RateLimit - middleware ограничения частоты запросов к /api/v1 по известному API ключу или IP.
+// IP клиента за прокси берется из c.IP(): ProxyHeader и доверенные прокси задаются в fiber.Config.
+// Дешевые и дорогие эндпоинты расходуют отдельные бюджеты; при превышении - 429 с Retry-After.
+// Бюджет с RPS <= 0 отключает ограничение своего класса.
+func RateLimit(cfg RateLimitConfig, logger *zap.Logger) fiber.Handler {
+	cheap := newRateLimiter(cfg.Cheap)
+	expensive := newRateLimiter(cfg.Expensive)
+	apiKeys := make(map[string]bool, len(cfg.APIKeys))
+	for _, key := range cfg.APIKeys {
+		apiKeys[key] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		if !strings.HasPrefix(path, "/api/") || c.Method() == fiber.MethodOptions {
+			return c.Next()
+		}
+
+		limiter, class := cheap, "cheap"
+		for _, fragment := range cfg.ExpensivePaths {
+			if strings.Contains(path, fragment) {
+				limiter, class = expensive, "expensive"
+				break
+			}
+		}
+		if limiter.budget.RPS <= 0 {
+			return c.Next()
+		}
+
+		key := "ip:" + c.IP()
+		if apiKey := c.Get(rateLimitAPIKeyHeader); apiKey != "" && apiKeys[apiKey] {
+			key = "key:" + apiKey
+		}
+
+		allowed, wait := limiter.allow(key)
+		if allowed {
+			return c.Next()
+		}
+
+		retryAfter := int(math.Ceil(wait.Seconds()))
+		logger.Warn("Rate limit exceeded",
+			zap.String("class", class),
+			zap.String("path", path),
+			zap.String("ip", c.IP()),
+			zap.Bool("api_key", strings.HasPrefix(key, "key:")))
+
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+		return utils.SendError(c, errors.ErrRateLimited.WithDetails(map[string]interface{}{
+			"class":       class,
+			"retry_after": retryAfter,
+		}))
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newRateLimitApp(cfg fiber.Config, apiKeys []string) *fiber.App {
+	app := fiber.New(cfg)
+	app.Use(RateLimit(RateLimitConfig{
+		Cheap:          RateLimitBudget{RPS: 0.001, Burst: 2},
+		Expensive:      RateLimitBudget{RPS: 0.001, Burst: 1},
+		ExpensivePaths: []string{"/batch"},
+		APIKeys:        apiKeys,
+	}, zap.NewNop()))
+	app.Get("/api/v1/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func doRateLimited(t *testing.T, app *fiber.App, path string, headers map[string]string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	return resp.StatusCode
+}
+
+func TestRateLimit_BudgetPerClass(t *testing.T) {
+	app := newRateLimitApp(fiber.Config{}, nil)
+
+	assert.Equal(t, fiber.StatusOK, doRateLimited(t, app, "/api/v1/batch/geocode", nil))
+	assert.Equal(t, fiber.StatusTooManyRequests, doRateLimited(t, app, "/api/v1/batch/geocode", nil))
+
+	// Дешевые эндпоинты расходуют свой бюджет
+	assert.Equal(t, fiber.StatusOK, doRateLimited(t, app, "/api/v1/search", nil))
+	assert.Equal(t, fiber.StatusOK, doRateLimited(t, app, "/api/v1/search", nil))
+	assert.Equal(t, fiber.StatusTooManyRequests, doRateLimited(t, app, "/api/v1/search", nil))
+}
+
+func TestRateLimit_APIKeys(t *testing.T) {
+	t.Run("known key gets its own bucket", func(t *testing.T) {
+		app := newRateLimitApp(fiber.Config{}, []string{"partner-key"})
+		withKey := map[string]string{rateLimitAPIKeyHeader: "partner-key"}
+
+		assert.Equal(t, fiber.StatusOK, doRateLimited(t, app, "/api/v1/batch/geocode", nil))
+		assert.Equal(t, fiber.StatusOK, doRateLimited(t, app, "/api/v1/batch/geocode", withKey))
+		assert.Equal(t, fiber.StatusTooManyRequests, doRateLimited(t, app, "/api/v1/batch/geocode", withKey))
+	})
+
+	t.Run("unknown keys are limited by IP", func(t *testing.T) {
+		app := newRateLimitApp(fiber.Config{}, []string{"partner-key"})
+
+		first := map[string]string{rateLimitAPIKeyHeader: "random-1"}
+		second := map[string]string{rateLimitAPIKeyHeader: "random-2"}
+
+		assert.Equal(t, fiber.StatusOK, doRateLimited(t, app, "/api/v1/batch/geocode", first))
+		assert.Equal(t, fiber.StatusTooManyRequests, doRateLimited(t, app, "/api/v1/batch/geocode", second),
+			"new key must not reset the budget")
+	})
+}
+
+func TestRateLimit_ClientIPBehindProxy(t *testing.T) {
+	client := func(ip string) map[string]string {
+		return map[string]string{fiber.HeaderXForwardedFor: ip}
+	}
+
+	t.Run("trusted proxy header separates clients", func(t *testing.T) {
+		app := newRateLimitApp(fiber.Config{
+			ProxyHeader:             fiber.HeaderXForwardedFor,
+			EnableTrustedProxyCheck: true,
+			TrustedProxies:          []string{"0.0.0.0/0"},
+			EnableIPValidation:      true,
+		}, nil)
+
+		assert.Equal(t, fiber.StatusOK, doRateLimited(t, app, "/api/v1/batch/geocode", client("203.0.113.1")))
+		assert.Equal(t, fiber.StatusOK, doRateLimited(t, app, "/api/v1/batch/geocode", client("203.0.113.2")))
+		assert.Equal(t, fiber.StatusTooManyRequests, doRateLimited(t, app, "/api/v1/batch/geocode", client("203.0.113.1")))
+	})
+
+	t.Run("header from untrusted peer is ignored", func(t *testing.T) {
+		app := newRateLimitApp(fiber.Config{
+			ProxyHeader:             fiber.HeaderXForwardedFor,
+			EnableTrustedProxyCheck: true,
+			TrustedProxies:          []string{"10.0.0.1"},
+			EnableIPValidation:      true,
+		}, nil)
+
+		assert.Equal(t, fiber.StatusOK, doRateLimited(t, app, "/api/v1/batch/geocode", client("203.0.113.1")))
+		assert.Equal(t, fiber.StatusTooManyRequests, doRateLimited(t, app, "/api/v1/batch/geocode", client("203.0.113.2")))
+	})
+}
+
+func TestRateLimiter_EvictsWhenFull(t *testing.T) {
+	l := newRateLimiter(RateLimitBudget{RPS: 1, Burst: 1})
+	for i := 0; i < maxRateLimitClients+10; i++ {
+		l.allow(fmt.Sprintf("ip:%d", i))
+	}
+
+	assert.LessOrEqual(t, len(l.buckets), maxRateLimitClients)
+}
+
+func TestRateLimiter_EvictsIdleAndLeastRecentlySeen(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter(RateLimitBudget{RPS: 1, Burst: 1})
+	l.now = func() time.Time { return now }
+
+	l.allow("ip:idle")
+	now = now.Add(rateLimitIdleTTL + time.Second)
+	l.allow("ip:active")
+	assert.NotContains(t, l.buckets, "ip:idle", "idle bucket is dropped when a new client arrives")
+
+	for i := 0; l.entries.Len() < maxRateLimitClients; i++ {
+		l.allow(fmt.Sprintf("ip:%d", i))
+	}
+	l.allow("ip:active")
+	l.allow("ip:new")
+
+	assert.Contains(t, l.buckets, "ip:active", "recently seen client is kept")
+	assert.NotContains(t, l.buckets, "ip:0", "least recently seen client is evicted")
+	assert.Equal(t, maxRateLimitClients, len(l.buckets))
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/location-microservice/internal/domain"
+)
+
+// TileNotModified - middleware для GET тайлов (*.pbf): если If-None-Match клиента содержит ETag
+// текущей версии данных, отвечает 304 до генерации тайла. Тайлы меняются только с импортом данных,
+// поэтому ETag той же версии означает тот же тайл. dataVersion пусто - проверка выключена,
+// 304 по хешу содержимого отдает обработчик после генерации.
+func TileNotModified(dataVersion string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if dataVersion == "" || c.Method() != fiber.MethodGet || !strings.HasSuffix(c.Path(), ".pbf") {
+			return c.Next()
+		}
+
+		for _, etag := range strings.Split(c.Get(fiber.HeaderIfNoneMatch), ",") {
+			if domain.TileETagVersion(etag) == dataVersion {
+				c.Set(fiber.HeaderETag, strings.TrimSpace(etag))
+				return c.SendStatus(fiber.StatusNotModified)
+			}
+		}
+		return c.Next()
+	}
+}
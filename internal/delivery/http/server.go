@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,6 +10,8 @@ import (
 	"github.com/location-microservice/internal/config"
 	"github.com/location-microservice/internal/delivery/http/handler"
 	"github.com/location-microservice/internal/delivery/http/middleware"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 	"go.uber.org/zap"
 )
@@ -29,6 +32,12 @@ type Server struct {
 	apiExplorerHandler      *handler.APIExplorerHandler
 	enrichedLocationHandler *handler.EnrichedLocationHandler
 	nearbyHandler           *handler.NearbyHandler
+	enrichmentHandler       *handler.EnrichmentHandler
+	debugHandler            *handler.DebugHandler
+	locationScoreHandler    *handler.LocationScoreHandler
+	environmentHandler      *handler.EnvironmentHandler
+	timezoneHandler         *handler.TimezoneHandler
+	adminHandler            *handler.AdminHandler
 }
 
 // NewServer - создание нового HTTP сервера
@@ -43,13 +52,28 @@ func NewServer(
 	statsHandler *handler.StatsHandler,
 	enrichedLocationHandler *handler.EnrichedLocationHandler,
 	nearbyHandler *handler.NearbyHandler,
+	enrichmentHandler *handler.EnrichmentHandler,
+	debugHandler *handler.DebugHandler,
+	locationScoreHandler *handler.LocationScoreHandler,
+	environmentHandler *handler.EnvironmentHandler,
+	timezoneHandler *handler.TimezoneHandler,
+	adminHandler *handler.AdminHandler,
 ) *Server {
 	app := fiber.New(fiber.Config{
-		AppName:      "Location Microservice",
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  60 * time.Second,
-		ErrorHandler: customErrorHandler(logger),
+		AppName:          "Location Microservice",
+		ReadTimeout:      cfg.Server.ReadTimeout,
+		WriteTimeout:     cfg.Server.WriteTimeout,
+		IdleTimeout:      cfg.Server.IdleTimeout,
+		Concurrency:      cfg.Server.Concurrency,
+		DisableKeepalive: cfg.Server.DisableKeepalive,
+		BodyLimit:        cfg.Server.BodyLimit,
+		ErrorHandler:     customErrorHandler(logger),
+
+		// c.IP() берет адрес из ProxyHeader только у доверенных прокси (config проверяет, что они заданы)
+		ProxyHeader:             cfg.Server.ProxyHeader,
+		EnableTrustedProxyCheck: cfg.Server.ProxyHeader != "",
+		TrustedProxies:          cfg.Server.TrustedProxies,
+		EnableIPValidation:      cfg.Server.ProxyHeader != "",
 	})
 
 	// Создаём API Explorer handler
@@ -71,6 +95,12 @@ func NewServer(
 		apiExplorerHandler:      apiExplorerHandler,
 		enrichedLocationHandler: enrichedLocationHandler,
 		nearbyHandler:           nearbyHandler,
+		enrichmentHandler:       enrichmentHandler,
+		debugHandler:            debugHandler,
+		locationScoreHandler:    locationScoreHandler,
+		environmentHandler:      environmentHandler,
+		timezoneHandler:         timezoneHandler,
+		adminHandler:            adminHandler,
 	}
 
 	s.setupMiddlewares()
@@ -83,8 +113,24 @@ func NewServer(
 func (s *Server) setupMiddlewares() {
 	s.app.Use(middleware.Recovery())
 	s.app.Use(middleware.Logger(s.logger))
-	s.app.Use(middleware.CORS())
+	s.app.Use(middleware.CORS(s.config.Server.AllowedOrigins))
+	// Лимит после CORS: ответ 429 должен быть доступен браузерному клиенту
+	if s.config.RateLimit.Enabled {
+		s.app.Use(middleware.RateLimit(middleware.RateLimitConfig{
+			Cheap:          middleware.RateLimitBudget{RPS: s.config.RateLimit.CheapRPS, Burst: s.config.RateLimit.CheapBurst},
+			Expensive:      middleware.RateLimitBudget{RPS: s.config.RateLimit.ExpensiveRPS, Burst: s.config.RateLimit.ExpensiveBurst},
+			ExpensivePaths: s.config.RateLimit.ExpensivePaths,
+			APIKeys:        s.config.RateLimit.APIKeys,
+		}, s.logger))
+	}
+	s.app.Use(middleware.JSONDepthLimit(s.config.Server.MaxJSONDepth))
+	s.app.Use(middleware.Cache(s.config.Cache.Endpoints))
+	s.app.Use(middleware.TileNotModified(s.config.Tile.DataVersion))
 	s.app.Use(compress.New(compress.Config{
+		// Потоковые NDJSON ответы не сжимаем: gzip буферизует строки и ломает построчную отдачу
+		Next: func(c *fiber.Ctx) bool {
+			return strings.HasSuffix(c.Path(), "/stream")
+		},
 		Level: compress.LevelBestSpeed,
 	}))
 }
@@ -111,6 +157,18 @@ func (s *Server) setupRoutes() {
 		return c.Redirect("/static/api-explorer.html")
 	})
 
+	// EXPLAIN для SQL шаблонов - только при DEBUG_EXPLAIN_ENABLED и не в production
+	if s.debugHandler != nil && s.config.IsDebugExplainEnabled() {
+		s.app.Get("/debug/explain", s.debugHandler.Explain)
+		s.logger.Warn("Debug EXPLAIN endpoint enabled", zap.String("env", s.config.Server.Env))
+	}
+
+	// Административные операции - только с ADMIN_TOKEN
+	if s.adminHandler != nil && s.config.Server.AdminToken != "" {
+		admin := s.app.Group("/admin", middleware.AdminAuth(s.config.Server.AdminToken, s.logger))
+		admin.Post("/cache/flush", s.adminHandler.FlushCache)
+	}
+
 	api := s.app.Group("/api/v1")
 
 	// Health check
@@ -124,29 +182,58 @@ func (s *Server) setupRoutes() {
 	// Search routes
 	api.Get("/search", s.searchHandler.Search)
 	api.Post("/reverse-geocode", s.searchHandler.ReverseGeocode)
+	api.Post("/reverse-geocode/confidence", s.searchHandler.ReverseGeocodeWithConfidence)
+	api.Get("/reverse-geocode/breadcrumb", s.searchHandler.GetBreadcrumb)
+	api.Post("/reverse-geocode/polygon", s.searchHandler.GetBoundariesIntersectingPolygon)
 	api.Post("/batch/reverse-geocode", s.searchHandler.BatchReverseGeocode)
+	api.Post("/batch/geocode", s.searchHandler.BatchForwardGeocode)
+	api.Post("/geocode/reverse/stream", s.searchHandler.StreamReverseGeocode)
 
 	// Boundary routes
+	api.Get("/boundaries/nearest", s.searchHandler.GetNearestBoundaries)
+	api.Get("/places/nearest", s.searchHandler.GetNearestPlace)
 	api.Get("/boundaries/:id", s.searchHandler.GetBoundaryByID)
+	api.Get("/boundaries/:id/search", s.searchHandler.SearchWithinParent)
+	api.Get("/boundaries/:id/bbox", s.searchHandler.GetBoundaryBBox)
+	api.Get("/boundaries/:id/green-spaces", s.environmentHandler.GetGreenSpacesInBoundary)
+	api.Get("/environment/summary", s.environmentHandler.GetEnvironmentSummary)
+	api.Get("/boundaries/:id/stations", s.transportHandler.GetStationsInBoundary)
 	api.Get("/boundaries/tiles/:z/:x/:y.pbf", s.tileHandler.GetBoundaryTile)
 
 	// Transport routes
 	api.Post("/transport/nearest", s.transportHandler.GetNearestStations)
 	api.Get("/transport/bbox", s.transportHandler.GetTransportInBBox)
+	api.Get("/transport/coverage", s.transportHandler.GetTransportCoverage)
+	api.Get("/transport/complexes", s.transportHandler.GetStationComplexes)
 	api.Get("/transport/tiles/:z/:x/:y.pbf", s.tileHandler.GetTransportTile)
 	api.Post("/batch/transport/nearest", s.transportHandler.BatchGetNearestStations)
+	api.Get("/transport/lines/bbox", s.transportHandler.GetLinesInBBox)
 	api.Get("/transport/lines/:id.pbf", s.tileHandler.GetTransportLineTile)
 	api.Post("/transport/lines.pbf", s.tileHandler.GetTransportLinesTile)
+	api.Post("/transport/lines/geometry", s.transportHandler.GetLinesGeometry)
+	api.Get("/transport/lines/:id/stations", s.transportHandler.GetStationsAlongLine)
+	api.Get("/transport/station/:station_id", s.transportHandler.GetStationByID)
 	api.Get("/transport/station/:station_id/lines", s.transportHandler.GetLinesByStationID)
 
 	// POI routes
 	api.Post("/radius/poi", s.poiHandler.SearchByRadius)
 	api.Get("/poi/categories", s.poiHandler.GetCategories)
+	api.Get("/poi/categories/tree", s.poiHandler.GetCategoryTree)
 	api.Get("/poi/categories/:id/subcategories", s.poiHandler.GetSubcategories)
 	api.Get("/poi/bbox", s.poiHandler.GetPOIInBBox)
+	api.Get("/poi/activity-center", s.poiHandler.GetActivityCenter)
+	api.Post("/poi/along-path", s.poiHandler.GetPOIsAlongPath)
+	api.Get("/poi/nearby/delta", s.nearbyHandler.GetNearbyPOIDelta)
+	api.Get("/poi/:id", s.poiHandler.GetPOIByID)
+	api.Get("/poi/:id/building", s.poiHandler.GetPOIBuilding)
+
+	// Часовой пояс IANA координаты
+	api.Get("/timezone", s.timezoneHandler.GetTimezone)
 
 	// Nearby — данные поблизости по категории (transport, schools, medical, ...)
 	api.Get("/nearby/:category", s.nearbyHandler.GetNearby)
+	// Удобства и транспорт рядом с объектом одним блоком (страница объявления)
+	api.Get("/neighborhood", s.nearbyHandler.GetNeighborhoodContext)
 
 	// POI Tile routes - новые эндпоинты
 	api.Get("/tiles/poi/:z/:x/:y.pbf", s.poiTileHandler.GetPOITile)
@@ -161,6 +248,15 @@ func (s *Server) setupRoutes() {
 	api.Get("/noise-sources/tiles/:z/:x/:y.pbf", s.tileHandler.GetNoiseSourcesTile)
 	api.Get("/tourist-zones/tiles/:z/:x/:y.pbf", s.tileHandler.GetTouristZonesTile)
 
+	// Схема атрибутов слоев MVT
+	api.Get("/schema", s.tileHandler.GetTileSchema)
+
+	// Дельта тайла относительно версии клиента
+	api.Get("/tiles/delta/:layer/:z/:x/:y", s.tileHandler.GetTileDelta)
+
+	// Тайлы, покрывающие bbox на зуме (без БД)
+	api.Get("/tiles/cover", s.tileHandler.GetTileCover)
+
 	// Radius tiles - комплексный endpoint для получения всех данных в радиусе
 	api.Post("/radius/tiles.pbf", s.tileHandler.GetRadiusTiles)
 
@@ -169,6 +265,16 @@ func (s *Server) setupRoutes() {
 	api.Post("/locations/enrich/batch", s.enrichedLocationHandler.EnrichLocationBatch)
 	api.Post("/locations/detect/batch", s.enrichedLocationHandler.DetectLocationBatch)
 
+	// Композитная оценка локации (транспорт, зелень, шум, плотность POI)
+	api.Get("/locations/score", s.locationScoreHandler.GetLocationScore)
+	api.Get("/locations/elevation", s.locationScoreHandler.GetElevationContext)
+
+	// Enrichment profiles - набор блоков данных выбирается через ?profile=
+	api.Get("/enrichment/profiles", s.enrichmentHandler.GetProfiles)
+	api.Post("/enrichment/enrich", s.enrichmentHandler.Enrich)
+	api.Post("/enrichment/enrich/address", s.enrichmentHandler.EnrichAddress)
+	api.Post("/enrichment/debug", s.enrichmentHandler.Debug)
+
 	// Priority Transport routes (новые - вместо /debug/)
 	api.Get("/transport/priority", s.enrichedLocationHandler.GetPriorityTransport)
 	api.Post("/transport/priority/batch", s.enrichedLocationHandler.GetPriorityTransportBatch)
@@ -183,12 +289,20 @@ func (s *Server) setupRoutes() {
 
 	// Stats
 	api.Get("/stats", s.statsHandler.GetStatistics)
+	api.Get("/coverage", s.statsHandler.GetDataCoverage)
+	api.Get("/admin-levels", s.statsHandler.GetAdminLevels)
 }
 
 // Start - запуск HTTP сервера
 func (s *Server) Start() error {
 	addr := s.config.GetServerAddr()
-	s.logger.Info("Starting HTTP server", zap.String("address", addr))
+	s.logger.Info("Starting HTTP server",
+		zap.String("address", addr),
+		zap.Duration("read_timeout", s.config.Server.ReadTimeout),
+		zap.Duration("write_timeout", s.config.Server.WriteTimeout),
+		zap.Duration("idle_timeout", s.config.Server.IdleTimeout),
+		zap.Int("concurrency", s.config.Server.Concurrency),
+		zap.Bool("keepalive", !s.config.Server.DisableKeepalive))
 	return s.app.Listen(addr)
 }
 
@@ -207,6 +321,16 @@ func customErrorHandler(logger *zap.Logger) fiber.ErrorHandler {
 			code = e.Code
 		}
 
+		// Тело больше BodyLimit fasthttp отклоняет до вызова обработчиков
+		if code == fiber.StatusRequestEntityTooLarge {
+			logger.Warn("Request body too large",
+				zap.String("path", c.Path()),
+				zap.String("ip", c.IP()))
+			return utils.SendError(c, errors.ErrPayloadTooLarge.WithDetails(map[string]interface{}{
+				"max_bytes": c.App().Config().BodyLimit,
+			}))
+		}
+
 		logger.Error("HTTP Error",
 			zap.String("path", c.Path()),
 			zap.Int("status", code),
@@ -15,6 +15,7 @@ type AdminBoundary struct {
 	NamePt       string                 `json:"name_pt" db:"name_pt"`
 	NameIt       string                 `json:"name_it" db:"name_it"`
 	NameDe       string                 `json:"name_de" db:"name_de"`
+	Names        map[string]string      `json:"names,omitempty" db:"-"` // переводы для языков из конфига (NAME_LANGUAGES)
 	Type         string                 `json:"type" db:"type"`
 	AdminLevel   int                    `json:"admin_level" db:"admin_level"`
 	CenterLat    float64                `json:"center_lat" db:"center_lat"`
@@ -25,6 +26,8 @@ type AdminBoundary struct {
 	Population   *int                   `json:"population,omitempty" db:"population"`
 	AreaSqKm     *float64               `json:"area_sq_km,omitempty" db:"area_sq_km"`
 	Tags         map[string]string      `json:"tags,omitempty" db:"tags"`
+	OriginalName string                 `json:"original_name,omitempty" db:"-"` // name из OSM, если в Name другое название (текстовый поиск)
+	MatchedField string                 `json:"matched_field,omitempty" db:"-"` // поле, совпавшее с запросом: name или name:<lang> (текстовый поиск)
 	CreatedAt    time.Time              `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time              `json:"updated_at" db:"updated_at"`
 }
@@ -38,6 +41,19 @@ type Address struct {
 	District     *string `json:"district,omitempty"`     // admin_level 9
 	Subdistrict  *string `json:"subdistrict,omitempty"`  // admin_level 10
 	Neighborhood *string `json:"neighborhood,omitempty"` // admin_level 11
+
+	// Точка попала в несколько стран (спорная граница): Country выбрана по приоритету
+	// из конфига или детерминированно, все кандидаты перечислены в CountryCandidates
+	CountryAmbiguous  bool               `json:"country_ambiguous,omitempty"`
+	CountryCandidates []CountryCandidate `json:"country_candidates,omitempty"`
+}
+
+// ReverseGeocodeMatch - результат обратного геокодирования с данными о глубине вложенности
+type ReverseGeocodeMatch struct {
+	Address            Address
+	MatchedLevels      int     // количество совпавших административных уровней
+	SmallestAdminLevel int     // admin_level самого детального содержащего полигона
+	EdgeDistanceMeters float64 // расстояние от точки до границы самого детального полигона
 }
 
 // LatLon представляет координаты точки
@@ -52,6 +68,7 @@ type BoundarySearchRequest struct {
 	Name        string // искомое название
 	AdminLevel  int    // уровень административной единицы (2, 4, 6, 8, 9, 10)
 	CountryHint string // опциональная подсказка страны для уточнения поиска
+	ParentID    int64  // osm_id границы, внутри которой должна лежать найденная (по ST_PointOnSurface); 0 - везде
 }
 
 // BoundarySearchResult - результат поиска границы для батча
@@ -60,3 +77,142 @@ type BoundarySearchResult struct {
 	Boundary *AdminBoundary // найденная граница (nil если не найдена)
 	Found    bool           // флаг успешного поиска
 }
+
+// BoundaryBBox - ограничивающий прямоугольник и центроид границы (для подгонки карты без полной геометрии)
+type BoundaryBBox struct {
+	ID        int64       `json:"id"`
+	BBox      BoundingBox `json:"bbox"`
+	CenterLat float64     `json:"center_lat"`
+	CenterLon float64     `json:"center_lon"`
+}
+
+// BoundaryOverlap - административная граница, пересекающая заданный полигон, с долей покрытия
+type BoundaryOverlap struct {
+	ID              int64   `json:"id"`
+	Name            string  `json:"name"`
+	AdminLevel      int     `json:"admin_level"`
+	AreaSqKm        float64 `json:"area_sq_km"`         // площадь границы
+	OverlapAreaSqKm float64 `json:"overlap_area_sq_km"` // площадь пересечения с полигоном
+	OverlapPercent  float64 `json:"overlap_percent"`    // доля площади входного полигона внутри границы, %
+}
+
+// NearestBoundary - граница уровня, ближайшая к точке, независимо от того, содержит ли она точку
+type NearestBoundary struct {
+	AdminBoundary
+	DistanceM float64 `json:"distance_m"` // расстояние от точки до полигона в метрах, 0 - точка внутри
+	Distance  float64 `json:"distance"`   // то же расстояние в единицах distance_unit запроса
+	Contains  bool    `json:"contains"`
+}
+
+// PlaceTypes - значения тега place населенных пунктов, от крупных к мелким
+var PlaceTypes = []string{"city", "town", "village", "hamlet"}
+
+// Place - населенный пункт, отмеченный точкой (place=city|town|village|hamlet), для подписей "3 км от Sitges".
+// В отличие от административного полигона точка place - то, как место называют местные жители.
+type Place struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	Type       string  `json:"type"` // значение тега place
+	Population *int    `json:"population,omitempty"`
+	Lat        float64 `json:"lat"`
+	Lon        float64 `json:"lon"`
+	DistanceM  float64 `json:"distance_m"` // метры
+	Distance   float64 `json:"distance"`   // в единицах distance_unit запроса
+}
+
+// BoundarySearchOrderBy - порядок результатов текстового поиска границ
+type BoundarySearchOrderBy string
+
+const (
+	BoundarySearchOrderByLevel      BoundarySearchOrderBy = "level"      // по уровню, затем по имени (по умолчанию)
+	BoundarySearchOrderByPopulation BoundarySearchOrderBy = "population" // самые населенные первыми
+)
+
+// BoundaryNameDisplay - какое название границы показывать в результатах текстового поиска
+type BoundaryNameDisplay string
+
+const (
+	BoundaryNameDisplayLanguage BoundaryNameDisplay = "language" // перевод на язык запроса, без перевода - name (по умолчанию)
+	BoundaryNameDisplayMatched  BoundaryNameDisplay = "matched"  // название, совпавшее с запросом
+	BoundaryNameDisplayPrimary  BoundaryNameDisplay = "primary"  // name из OSM
+)
+
+// BoundarySearchOptions - дополнительные фильтры текстового поиска границ
+type BoundarySearchOptions struct {
+	MinPopulation int                   // > 0 - только границы с тегом population не меньше значения
+	MinAreaSqKm   float64               // > 0 - только границы не меньше площади
+	OrderBy       BoundarySearchOrderBy // пусто - BoundarySearchOrderByLevel
+	NameDisplay   BoundaryNameDisplay   // пусто - BoundaryNameDisplayLanguage
+}
+
+// BoundaryNameMatch - названия границы, по которым шел текстовый поиск: name из OSM и перевод
+// на язык запроса, с признаками совпадения каждого с запросом
+type BoundaryNameMatch struct {
+	Name               string // name из OSM
+	Lang               string // язык запроса, "" - поиск только по name
+	Translation        string // name:<Lang>, "" - перевода нет
+	NameMatched        bool
+	TranslationMatched bool
+}
+
+// Resolve возвращает отображаемое название по режиму display и поле, совпавшее с запросом
+// (name или name:<lang>). Если запросу соответствуют оба поля, совпавшим считается перевод:
+// пользователь искал на своем языке, поэтому в режиме matched показывается перевод.
+func (m BoundaryNameMatch) Resolve(display BoundaryNameDisplay) (name, matchedField string) {
+	if m.TranslationMatched && m.Translation != "" {
+		matchedField = "name:" + m.Lang
+	} else if m.NameMatched {
+		matchedField = "name"
+	}
+
+	switch display {
+	case BoundaryNameDisplayPrimary:
+		return m.Name, matchedField
+	case BoundaryNameDisplayMatched:
+		if matchedField == "name" {
+			return m.Name, matchedField
+		}
+	}
+	if m.Translation != "" {
+		return m.Translation, matchedField
+	}
+	return m.Name, matchedField
+}
+
+// PickBoundaryPerLevel оставляет одну границу на административный уровень, сохраняя порядок уровней.
+// Несколько границ одного уровня в точке - ошибка разметки OSM или спорная территория, которую
+// размечают обе стороны (например, Западная Сахара или Кашмир). Выбор не претендует на политическую
+// корректность, он только детерминирован: побеждает граница с наименьшей площадью (более конкретная,
+// без площади - последней), при равенстве - с меньшим osm_id. Отброшенные кандидаты возвращаются
+// для диагностики.
+func PickBoundaryPerLevel(boundaries []*AdminBoundary) (picked, discarded []*AdminBoundary) {
+	byLevel := make(map[int]int, len(boundaries)) // admin_level -> индекс в picked
+	for _, b := range boundaries {
+		i, ok := byLevel[b.AdminLevel]
+		if !ok {
+			byLevel[b.AdminLevel] = len(picked)
+			picked = append(picked, b)
+			continue
+		}
+		if boundaryMoreSpecific(b, picked[i]) {
+			discarded = append(discarded, picked[i])
+			picked[i] = b
+		} else {
+			discarded = append(discarded, b)
+		}
+	}
+	return picked, discarded
+}
+
+// boundaryMoreSpecific сравнивает границы одного уровня: меньшая площадь, затем меньший osm_id
+func boundaryMoreSpecific(a, b *AdminBoundary) bool {
+	switch {
+	case a.AreaSqKm != nil && b.AreaSqKm == nil:
+		return true
+	case a.AreaSqKm == nil && b.AreaSqKm != nil:
+		return false
+	case a.AreaSqKm != nil && *a.AreaSqKm != *b.AreaSqKm:
+		return *a.AreaSqKm < *b.AreaSqKm
+	}
+	return a.OSMId < b.OSMId
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickBoundaryPerLevel(t *testing.T) {
+	area := func(v float64) *float64 { return &v }
+
+	country := &AdminBoundary{OSMId: 1, AdminLevel: 2, AreaSqKm: area(500000)}
+	large := &AdminBoundary{OSMId: 10, AdminLevel: 4, AreaSqKm: area(32000)}
+	small := &AdminBoundary{OSMId: 20, AdminLevel: 4, AreaSqKm: area(8000)}
+	unknown := &AdminBoundary{OSMId: 5, AdminLevel: 4}
+	city := &AdminBoundary{OSMId: 30, AdminLevel: 8, AreaSqKm: area(100)}
+	cityTwin := &AdminBoundary{OSMId: 31, AdminLevel: 8, AreaSqKm: area(100)}
+
+	picked, discarded := PickBoundaryPerLevel([]*AdminBoundary{country, large, unknown, small, cityTwin, city})
+
+	assert.Equal(t, []*AdminBoundary{country, small, city}, picked)
+	assert.ElementsMatch(t, []*AdminBoundary{large, unknown, cityTwin}, discarded)
+
+	picked, discarded = PickBoundaryPerLevel([]*AdminBoundary{country, city})
+	assert.Len(t, picked, 2)
+	assert.Empty(t, discarded)
+}
+
+func TestBoundaryNameMatchResolve(t *testing.T) {
+	translated := BoundaryNameMatch{Name: "London", Lang: "es", Translation: "Londres", TranslationMatched: true}
+	primary := BoundaryNameMatch{Name: "Москва", Lang: "en", Translation: "Moscow", NameMatched: true}
+	tie := BoundaryNameMatch{Name: "Barcelona", Lang: "es", Translation: "Barcelona", NameMatched: true, TranslationMatched: true}
+	untranslated := BoundaryNameMatch{Name: "Sitges", Lang: "ru", NameMatched: true}
+
+	cases := []struct {
+		match   BoundaryNameMatch
+		display BoundaryNameDisplay
+		name    string
+		field   string
+	}{
+		{translated, "", "Londres", "name:es"},
+		{translated, BoundaryNameDisplayMatched, "Londres", "name:es"},
+		{translated, BoundaryNameDisplayPrimary, "London", "name:es"},
+		{primary, BoundaryNameDisplayLanguage, "Moscow", "name"},
+		{primary, BoundaryNameDisplayMatched, "Москва", "name"},
+		{tie, BoundaryNameDisplayMatched, "Barcelona", "name:es"},
+		{untranslated, BoundaryNameDisplayLanguage, "Sitges", "name"},
+		{untranslated, BoundaryNameDisplayMatched, "Sitges", "name"},
+	}
+	for _, tc := range cases {
+		name, field := tc.match.Resolve(tc.display)
+		assert.Equal(t, tc.name, name, "%s/%s", tc.match.Name, tc.display)
+		assert.Equal(t, tc.field, field, "%s/%s", tc.match.Name, tc.display)
+	}
+}
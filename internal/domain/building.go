@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Building - контур здания из planet_osm_polygon, в котором находится POI или ближайший к нему
+type Building struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"` // значение тега building: yes, residential, retail, ...
+	Name      string          `json:"name,omitempty"`
+	Levels    *float64        `json:"levels,omitempty"` // building:levels
+	Height    *float64        `json:"height,omitempty"` // height, метры
+	Contains  bool            `json:"contains"`         // POI внутри контура; false - ближайшее здание
+	DistanceM float64         `json:"distance"`         // 0, если POI внутри контура
+	Geometry  json.RawMessage `json:"geometry"`         // GeoJSON, EPSG:4326
+}
+
+// ParseOSMNumber разбирает числовой тег OSM вида "12", "12.5", "12 m" или "3,5".
+// Единицы, отличные от метров (например "40'"), не поддерживаются - результат nil.
+func ParseOSMNumber(raw string) *float64 {
+	s := strings.TrimSpace(raw)
+	s = strings.TrimSpace(strings.TrimSuffix(s, "m"))
+	s = strings.Replace(s, ",", ".", 1)
+	if s == "" {
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOSMNumber(t *testing.T) {
+	for raw, want := range map[string]float64{"12": 12, "12.5": 12.5, " 18 m": 18, "3,5": 3.5, "4m": 4} {
+		got := ParseOSMNumber(raw)
+		require.NotNil(t, got, raw)
+		assert.Equal(t, want, *got, raw)
+	}
+
+	for _, raw := range []string{"", "40'", "-3", "tall"} {
+		assert.Nil(t, ParseOSMNumber(raw), raw)
+	}
+}
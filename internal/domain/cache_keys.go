@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Пространства имен ключей кеша Redis. Каждый ключ начинается с "<namespace>:", поэтому
+// пространство можно сбросить целиком через SCAN по шаблону, не задевая остальные ключи.
+const (
+	CacheNamespaceTiles  = "tile"   // тайлы: tile:<слой>:<z>:<x>:<y>[:параметры]
+	CacheNamespaceSearch = "search" // результаты поиска
+	CacheNamespaceStats  = "stats"  // статистика и покрытие данных
+)
+
+// CacheNamespaces - пространства имен Redis, доступные для сброса кеша, по имени из API.
+// Обратное геокодирование кешируется в памяти процесса (ячейки), а не в Redis, см. CacheFlushGeocode.
+var CacheNamespaces = map[string]string{
+	"tiles":  CacheNamespaceTiles,
+	"search": CacheNamespaceSearch,
+	"stats":  CacheNamespaceStats,
+}
+
+// CacheFlushGeocode - имя в API для сброса in-process кеша ячеек обратного геокодирования
+const CacheFlushGeocode = "geocode"
+
+// TileCacheKey возвращает ключ тайла слоя layer; параметры фильтров дописываются к нему через ":"
+func TileCacheKey(layer string, z, x, y int) string {
+	return fmt.Sprintf("%s:%s:%d:%d:%d", CacheNamespaceTiles, layer, z, x, y)
+}
+
+// TileCacheRange - прямоугольник тайлов одного зума для сброса кеша; пустой Layer - все слои
+type TileCacheRange struct {
+	Layer string
+	Z     int
+	MinX  int
+	MaxX  int
+	MinY  int
+	MaxY  int
+}
+
+// Pattern возвращает шаблон SCAN, отбирающий ключи слоя и зума диапазона
+func (r TileCacheRange) Pattern() string {
+	layer := r.Layer
+	if layer == "" {
+		layer = "*"
+	}
+	return fmt.Sprintf("%s:%s:%d:*", CacheNamespaceTiles, layer, r.Z)
+}
+
+// Contains проверяет, что ключ тайла (TileCacheKey с параметрами или без) попадает в диапазон.
+// Ключи без координат z/x/y (тайлы линий, радиуса) в диапазон не попадают.
+func (r TileCacheRange) Contains(key string) bool {
+	parts := strings.Split(key, ":")
+	if len(parts) < 5 || parts[0] != CacheNamespaceTiles {
+		return false
+	}
+	if r.Layer != "" && parts[1] != r.Layer {
+		return false
+	}
+
+	coords := make([]int, 3)
+	for i, part := range parts[2:5] {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return false
+		}
+		coords[i] = v
+	}
+
+	z, x, y := coords[0], coords[1], coords[2]
+	return z == r.Z && x >= r.MinX && x <= r.MaxX && y >= r.MinY && y <= r.MaxY
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileCacheKey(t *testing.T) {
+	assert.Equal(t, "tile:poi:14:8290:6119", TileCacheKey("poi", 14, 8290, 6119))
+}
+
+func TestTileCacheRange(t *testing.T) {
+	r := TileCacheRange{Layer: "poi", Z: 14, MinX: 8290, MaxX: 8291, MinY: 6119, MaxY: 6120}
+
+	assert.Equal(t, "tile:poi:14:*", r.Pattern())
+	assert.True(t, r.Contains("tile:poi:14:8290:6119"))
+	assert.True(t, r.Contains("tile:poi:14:8291:6120:4b2c"), "filter suffix is part of the same tile")
+	assert.False(t, r.Contains("tile:poi:14:8292:6119"))
+	assert.False(t, r.Contains("tile:poi:13:8290:6119"))
+	assert.False(t, r.Contains("tile:water:14:8290:6119"))
+	assert.False(t, r.Contains("tile:line:42"))
+	assert.False(t, r.Contains("stats:current"))
+
+	all := TileCacheRange{Z: 14, MinX: 8290, MaxX: 8290, MinY: 6119, MaxY: 6119}
+	assert.Equal(t, "tile:*:14:*", all.Pattern())
+	assert.True(t, all.Contains("tile:water:14:8290:6119"))
+}
@@ -103,3 +103,38 @@ type CoverageStats struct {
 	CenterLon  float64 `json:"center_lon"`
 	AreaSqKm   float64 `json:"area_sq_km"`
 }
+
+// DataCoverage - территория, для которой в развертывании есть данные OSM
+type DataCoverage struct {
+	BBox         BoundingBox   `json:"bbox"` // охват импортированных точечных объектов
+	CenterLat    float64       `json:"center_lat"`
+	CenterLon    float64       `json:"center_lon"`
+	TotalPoints  int64         `json:"total_points"`
+	ByAdminLevel map[int]int   `json:"by_admin_level"` // количество административных границ по уровням
+	Grid         *CoverageGrid `json:"grid,omitempty"`
+}
+
+// AdminLevelInfo - административный уровень, присутствующий в данных развертывания
+type AdminLevelInfo struct {
+	Level       int    `json:"level" db:"level"`
+	Count       int    `json:"count" db:"count"`               // количество границ уровня
+	ExampleName string `json:"example_name" db:"example_name"` // название самой большой по площади границы уровня
+}
+
+// CoverageGrid - грубая сетка size x size поверх bbox покрытия (в градусах)
+type CoverageGrid struct {
+	Size       int            `json:"size"`
+	CellWidth  float64        `json:"cell_width"`  // градусы долготы
+	CellHeight float64        `json:"cell_height"` // градусы широты
+	EmptyCells int            `json:"empty_cells"`
+	Cells      []CoverageCell `json:"cells"` // построчно с юга на север, в строке с запада на восток
+}
+
+// CoverageCell - ячейка сетки покрытия; Empty - в ячейке нет ни одного точечного объекта
+type CoverageCell struct {
+	Row    int         `json:"row"`
+	Col    int         `json:"col"`
+	BBox   BoundingBox `json:"bbox"`
+	Points int64       `json:"points"`
+	Empty  bool        `json:"empty"`
+}
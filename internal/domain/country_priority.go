@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CountryCandidate - страна (admin_level 2), полигон которой содержит точку
+type CountryCandidate struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// CountryPriority - порядок предпочтения стран для точек, попавших в несколько стран
+// (спорные или неточно размеченные границы). Элемент - название страны (без учета регистра)
+// или osm_id ее границы.
+type CountryPriority []string
+
+// ParseCountryPriority проверяет список из конфига: пустые элементы и дубли не допускаются
+func ParseCountryPriority(entries []string) (CountryPriority, error) {
+	priority := make(CountryPriority, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		key := strings.ToLower(strings.TrimSpace(entry))
+		if key == "" {
+			return nil, fmt.Errorf("country priority: empty entry")
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("country priority: %q listed twice", entry)
+		}
+		seen[key] = true
+		priority = append(priority, key)
+	}
+	return priority, nil
+}
+
+// Resolve выбирает страну из кандидатов. Единственный кандидат возвращается как есть; из нескольких -
+// первый по списку приоритета, а если ни один не указан в списке - с наибольшим названием
+// (как прежний MAX(name), чтобы выбор не зависел от плана запроса). ok=false - кандидатов нет.
+func (p CountryPriority) Resolve(candidates []CountryCandidate) (CountryCandidate, bool) {
+	if len(candidates) == 0 {
+		return CountryCandidate{}, false
+	}
+
+	for _, entry := range p {
+		for _, c := range candidates {
+			if entry == strconv.FormatInt(c.ID, 10) || entry == strings.ToLower(c.Name) {
+				return c, true
+			}
+		}
+	}
+
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.Name > best.Name {
+			best = c
+		}
+	}
+	return best, true
+}
+
+// SetCountry заполняет страну адреса по кандидатам. При нескольких кандидатах адрес помечается
+// как неоднозначный и перечисляет всех кандидатов, даже если выбор сделан по списку приоритета.
+func (a *Address) SetCountry(candidates []CountryCandidate, priority CountryPriority) {
+	country, _ := priority.Resolve(candidates)
+	a.Country = country.Name
+	a.CountryAmbiguous = len(candidates) > 1
+	a.CountryCandidates = nil
+	if a.CountryAmbiguous {
+		a.CountryCandidates = candidates
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCountryPriority(t *testing.T) {
+	priority, err := ParseCountryPriority([]string{"Spain", " 1311341 "})
+	assert.NoError(t, err)
+	assert.Equal(t, CountryPriority{"spain", "1311341"}, priority)
+
+	_, err = ParseCountryPriority([]string{"Spain", "spain"})
+	assert.Error(t, err)
+
+	_, err = ParseCountryPriority([]string{" "})
+	assert.Error(t, err)
+}
+
+func TestAddressSetCountry(t *testing.T) {
+	spain := CountryCandidate{ID: -1311341, Name: "España"}
+	andorra := CountryCandidate{ID: -9407, Name: "Andorra"}
+
+	t.Run("single country is not ambiguous", func(t *testing.T) {
+		var addr Address
+		addr.SetCountry([]CountryCandidate{spain}, nil)
+
+		assert.Equal(t, "España", addr.Country)
+		assert.False(t, addr.CountryAmbiguous)
+		assert.Nil(t, addr.CountryCandidates)
+	})
+
+	t.Run("no country", func(t *testing.T) {
+		var addr Address
+		addr.SetCountry(nil, nil)
+
+		assert.Empty(t, addr.Country)
+		assert.False(t, addr.CountryAmbiguous)
+	})
+
+	t.Run("without priority the greatest name wins", func(t *testing.T) {
+		var addr Address
+		addr.SetCountry([]CountryCandidate{andorra, spain}, nil)
+
+		assert.Equal(t, "España", addr.Country)
+		assert.True(t, addr.CountryAmbiguous)
+		assert.Equal(t, []CountryCandidate{andorra, spain}, addr.CountryCandidates)
+	})
+
+	t.Run("priority by name or osm_id", func(t *testing.T) {
+		byName, _ := ParseCountryPriority([]string{"France", "ANDORRA"})
+		var addr Address
+		addr.SetCountry([]CountryCandidate{andorra, spain}, byName)
+		assert.Equal(t, "Andorra", addr.Country)
+		assert.True(t, addr.CountryAmbiguous, "candidates are surfaced even when priority decides")
+
+		byID, _ := ParseCountryPriority([]string{"-9407"})
+		addr.SetCountry([]CountryCandidate{spain, andorra}, byID)
+		assert.Equal(t, "Andorra", addr.Country)
+	})
+}
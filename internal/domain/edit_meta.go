@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Типы объектов, для которых отдаются метаданные правки OSM
+const (
+	EditFeatureBoundary = "boundary"
+	EditFeaturePOI      = "poi"
+	EditFeatureStation  = "station"
+)
+
+// EditMeta - метаданные последней правки объекта в OSM. Колонки osm_version/osm_timestamp/osm_user
+// есть только в импортах osm2pgsql с --extra-attributes; без них Available=false и поля пусты.
+type EditMeta struct {
+	Available bool       `json:"available"`
+	Version   *int64     `json:"osm_version,omitempty"`
+	Timestamp *time.Time `json:"osm_timestamp,omitempty"`
+	User      *string    `json:"osm_user,omitempty"`
+}
+
+// IsValidEditFeature проверяет тип объекта для метаданных правки
+func IsValidEditFeature(feature string) bool {
+	switch feature {
+	case EditFeatureBoundary, EditFeaturePOI, EditFeatureStation:
+		return true
+	}
+	return false
+}
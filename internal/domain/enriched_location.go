@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EnrichedLocationLevels - admin_level, для которых в EnrichedLocation есть поле, от страны до квартала.
+// Совпадает с уровнями Address из обратного геокодирования (2, 4, 6, 7, 8, 9, 10, 11).
+var EnrichedLocationLevels = []int{2, 4, 6, 7, 8, 9, 10, 11}
+
+// Boundary возвращает границу уровня admin_level или nil, если уровень не заполнен или у него нет поля
+func (l *EnrichedLocation) Boundary(level int) *BoundaryInfo {
+	switch level {
+	case 2:
+		return l.Country
+	case 4:
+		return l.Region
+	case 6:
+		return l.Province
+	case 7:
+		return l.Subprovince
+	case 8:
+		return l.City
+	case 9:
+		return l.District
+	case 10:
+		return l.Subdistrict
+	case 11:
+		return l.Neighborhood
+	}
+	return nil
+}
+
+// SetBoundary записывает границу в поле уровня admin_level; false - у уровня нет поля (3, 5, 12, ...)
+func (l *EnrichedLocation) SetBoundary(level int, info *BoundaryInfo) bool {
+	switch level {
+	case 2:
+		l.Country = info
+	case 4:
+		l.Region = info
+	case 6:
+		l.Province = info
+	case 7:
+		l.Subprovince = info
+	case 8:
+		l.City = info
+	case 9:
+		l.District = info
+	case 10:
+		l.Subdistrict = info
+	case 11:
+		l.Neighborhood = info
+	default:
+		return false
+	}
+	return true
+}
+
+// ParseEnrichedLocationLevels разбирает список admin_level из конфига. Допустимы только уровни
+// EnrichedLocationLevels; нечисловой или неизвестный уровень - ошибка конфигурации.
+func ParseEnrichedLocationLevels(raw []string) ([]int, error) {
+	levels := make([]int, 0, len(raw))
+	for _, r := range raw {
+		level, err := strconv.Atoi(strings.TrimSpace(r))
+		if err != nil {
+			return nil, fmt.Errorf("invalid admin level %q", r)
+		}
+		if !slices.Contains(EnrichedLocationLevels, level) {
+			return nil, fmt.Errorf("admin level %d has no enriched location field", level)
+		}
+		if !slices.Contains(levels, level) {
+			levels = append(levels, level)
+		}
+	}
+	return levels, nil
+}
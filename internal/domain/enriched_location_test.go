@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrichedLocation_SetBoundary(t *testing.T) {
+	loc := &EnrichedLocation{}
+	for _, level := range EnrichedLocationLevels {
+		info := &BoundaryInfo{ID: int64(level)}
+		assert.True(t, loc.SetBoundary(level, info))
+		assert.Same(t, info, loc.Boundary(level))
+	}
+	assert.Equal(t, int64(7), loc.Subprovince.ID)
+	assert.Equal(t, int64(10), loc.Subdistrict.ID)
+	assert.Equal(t, int64(11), loc.Neighborhood.ID)
+
+	assert.False(t, loc.SetBoundary(5, &BoundaryInfo{ID: 5}))
+	assert.Nil(t, loc.Boundary(5))
+}
+
+func TestParseEnrichedLocationLevels(t *testing.T) {
+	levels, err := ParseEnrichedLocationLevels(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, levels)
+
+	levels, err = ParseEnrichedLocationLevels([]string{" 7", "11", "7"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{7, 11}, levels)
+
+	_, err = ParseEnrichedLocationLevels([]string{"5"})
+	assert.Error(t, err)
+
+	_, err = ParseEnrichedLocationLevels([]string{"subprovince"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnrichmentFeature - блок данных, который может быть получен при обогащении локации
+type EnrichmentFeature string
+
+const (
+	EnrichmentFeatureAdmin       EnrichmentFeature = "admin"       // административная иерархия
+	EnrichmentFeatureTransport   EnrichmentFeature = "transport"   // ближайшие станции транспорта
+	EnrichmentFeatureEnvironment EnrichmentFeature = "environment" // парки, водоемы, пляжи рядом
+	EnrichmentFeaturePOI         EnrichmentFeature = "poi"         // ближайшие точки интереса
+)
+
+// Встроенные профили обогащения
+const (
+	EnrichmentProfileMinimal = "minimal" // только административная иерархия
+	EnrichmentProfileFull    = "full"    // admin + transport + environment + poi
+)
+
+// IsValidEnrichmentFeature проверяет, что feature известен сервису
+func IsValidEnrichmentFeature(f EnrichmentFeature) bool {
+	switch f {
+	case EnrichmentFeatureAdmin, EnrichmentFeatureTransport, EnrichmentFeatureEnvironment, EnrichmentFeaturePOI:
+		return true
+	}
+	return false
+}
+
+// EnrichmentProfile - именованный набор блоков данных для обогащения
+type EnrichmentProfile struct {
+	Name     string              `json:"name"`
+	Features []EnrichmentFeature `json:"features"`
+}
+
+// Has возвращает true, если профиль включает feature
+func (p EnrichmentProfile) Has(f EnrichmentFeature) bool {
+	for _, feature := range p.Features {
+		if feature == f {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultEnrichmentProfiles возвращает встроенные профили
+func DefaultEnrichmentProfiles() map[string]EnrichmentProfile {
+	return map[string]EnrichmentProfile{
+		EnrichmentProfileMinimal: {
+			Name:     EnrichmentProfileMinimal,
+			Features: []EnrichmentFeature{EnrichmentFeatureAdmin},
+		},
+		EnrichmentProfileFull: {
+			Name: EnrichmentProfileFull,
+			Features: []EnrichmentFeature{
+				EnrichmentFeatureAdmin,
+				EnrichmentFeatureTransport,
+				EnrichmentFeatureEnvironment,
+				EnrichmentFeaturePOI,
+			},
+		},
+	}
+}
+
+// BuildEnrichmentProfiles объединяет встроенные профили с профилями из конфига (name -> features).
+// Профили из конфига переопределяют встроенные с тем же именем.
+func BuildEnrichmentProfiles(custom map[string][]string) (map[string]EnrichmentProfile, error) {
+	profiles := DefaultEnrichmentProfiles()
+
+	for name, rawFeatures := range custom {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		features := make([]EnrichmentFeature, 0, len(rawFeatures))
+		for _, raw := range rawFeatures {
+			f := EnrichmentFeature(strings.ToLower(strings.TrimSpace(raw)))
+			if !IsValidEnrichmentFeature(f) {
+				return nil, fmt.Errorf("enrichment profile %q: unknown feature %q", name, raw)
+			}
+			features = append(features, f)
+		}
+		if len(features) == 0 {
+			return nil, fmt.Errorf("enrichment profile %q has no features", name)
+		}
+
+		profiles[name] = EnrichmentProfile{Name: name, Features: features}
+	}
+
+	return profiles, nil
+}
+
+// SortedEnrichmentProfiles возвращает профили, отсортированные по имени
+func SortedEnrichmentProfiles(profiles map[string]EnrichmentProfile) []EnrichmentProfile {
+	result := make([]EnrichmentProfile, 0, len(profiles))
+	for _, p := range profiles {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// ResolutionStrategy - способ определения административной иерархии при обогащении
+type ResolutionStrategy string
+
+const (
+	ResolutionStrategyName        ResolutionStrategy = "name"        // по названиям из события, от детального уровня к общему
+	ResolutionStrategyCoordinates ResolutionStrategy = "coordinates" // reverse geocoding по координатам
+	ResolutionStrategyCountry     ResolutionStrategy = "country"     // только страна
+)
+
+// DefaultResolutionOrder возвращает встроенный порядок стратегий: названия, координаты, страна
+func DefaultResolutionOrder() []ResolutionStrategy {
+	return []ResolutionStrategy{
+		ResolutionStrategyName,
+		ResolutionStrategyCoordinates,
+		ResolutionStrategyCountry,
+	}
+}
+
+// ParseResolutionOrder разбирает порядок стратегий из конфига. Пустой список - встроенный порядок;
+// неизвестная или повторяющаяся стратегия - ошибка конфигурации.
+func ParseResolutionOrder(raw []string) ([]ResolutionStrategy, error) {
+	if len(raw) == 0 {
+		return DefaultResolutionOrder(), nil
+	}
+
+	order := make([]ResolutionStrategy, 0, len(raw))
+	seen := make(map[ResolutionStrategy]bool, len(raw))
+	for _, r := range raw {
+		s := ResolutionStrategy(strings.ToLower(strings.TrimSpace(r)))
+		switch s {
+		case ResolutionStrategyName, ResolutionStrategyCoordinates, ResolutionStrategyCountry:
+		default:
+			return nil, fmt.Errorf("unknown resolution strategy %q", r)
+		}
+		if seen[s] {
+			return nil, fmt.Errorf("duplicate resolution strategy %q", r)
+		}
+		seen[s] = true
+		order = append(order, s)
+	}
+
+	return order, nil
+}
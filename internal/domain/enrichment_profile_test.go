@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildEnrichmentProfiles(t *testing.T) {
+	profiles, err := BuildEnrichmentProfiles(map[string][]string{
+		"Eco":     {"admin", " Environment "},
+		"minimal": {"admin", "poi"},
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, profiles["full"].Has(EnrichmentFeatureTransport))
+	assert.True(t, profiles["eco"].Has(EnrichmentFeatureEnvironment))
+	assert.False(t, profiles["eco"].Has(EnrichmentFeatureTransport))
+	assert.True(t, profiles["minimal"].Has(EnrichmentFeaturePOI), "config should override built-in profile")
+
+	_, err = BuildEnrichmentProfiles(map[string][]string{"bad": {"weather"}})
+	assert.Error(t, err)
+
+	_, err = BuildEnrichmentProfiles(map[string][]string{"empty": nil})
+	assert.Error(t, err)
+}
+
+func TestSortedEnrichmentProfiles(t *testing.T) {
+	sorted := SortedEnrichmentProfiles(DefaultEnrichmentProfiles())
+	assert.Equal(t, []string{"full", "minimal"}, []string{sorted[0].Name, sorted[1].Name})
+}
+
+func TestParseResolutionOrder(t *testing.T) {
+	order, err := ParseResolutionOrder(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultResolutionOrder(), order)
+
+	order, err = ParseResolutionOrder([]string{" Coordinates", "name"})
+	assert.NoError(t, err)
+	assert.Equal(t, []ResolutionStrategy{ResolutionStrategyCoordinates, ResolutionStrategyName}, order)
+
+	_, err = ParseResolutionOrder([]string{"name", "geoip"})
+	assert.Error(t, err)
+
+	_, err = ParseResolutionOrder([]string{"name", "name"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EnrichmentResult - результат обогащения объекта недвижимости, сохраняемый воркером в основную БД
+// для повторных запросов и аудита без переобработки стрима
+type EnrichmentResult struct {
+	PropertyID       uuid.UUID
+	CountryID        *int64
+	RegionID         *int64
+	ProvinceID       *int64
+	CityID           *int64
+	DistrictID       *int64
+	SubdistrictID    *int64
+	NeighborhoodID   *int64
+	NearestTransport []NearestStation
+	Error            string
+	EnrichedAt       time.Time
+}
+
+// NewEnrichmentResult собирает результат из события stream:location:done
+func NewEnrichmentResult(event *LocationDoneEvent, enrichedAt time.Time) *EnrichmentResult {
+	result := &EnrichmentResult{
+		PropertyID:       event.PropertyID,
+		NearestTransport: event.NearestTransport,
+		Error:            event.Error,
+		EnrichedAt:       enrichedAt,
+	}
+
+	if loc := event.EnrichedLocation; loc != nil {
+		result.CountryID = loc.Country.boundaryID()
+		result.RegionID = loc.Region.boundaryID()
+		result.ProvinceID = loc.Province.boundaryID()
+		result.CityID = loc.City.boundaryID()
+		result.DistrictID = loc.District.boundaryID()
+		result.SubdistrictID = loc.Subdistrict.boundaryID()
+		result.NeighborhoodID = loc.Neighborhood.boundaryID()
+	}
+
+	return result
+}
+
+// boundaryID возвращает ID границы или nil, если уровень не определен
+func (b *BoundaryInfo) boundaryID() *int64 {
+	if b == nil {
+		return nil
+	}
+	id := b.ID
+	return &id
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEnrichmentResult(t *testing.T) {
+	propertyID := uuid.New()
+	enrichedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	result := NewEnrichmentResult(&LocationDoneEvent{
+		PropertyID: propertyID,
+		EnrichedLocation: &EnrichedLocation{
+			Country:      &BoundaryInfo{ID: 1, Name: "España"},
+			City:         &BoundaryInfo{ID: 8, Name: "Barcelona"},
+			Subdistrict:  &BoundaryInfo{ID: 10, Name: "Dreta de l'Eixample"},
+			Neighborhood: &BoundaryInfo{ID: 11, Name: "Sagrada Família"},
+		},
+		NearestTransport: []NearestStation{{StationID: 42, Name: "Catalunya"}},
+	}, enrichedAt)
+
+	assert.Equal(t, propertyID, result.PropertyID)
+	assert.Equal(t, int64(1), *result.CountryID)
+	assert.Equal(t, int64(8), *result.CityID)
+	assert.Nil(t, result.RegionID)
+	assert.Equal(t, int64(10), *result.SubdistrictID)
+	assert.Equal(t, int64(11), *result.NeighborhoodID)
+	assert.Len(t, result.NearestTransport, 1)
+	assert.Equal(t, enrichedAt, result.EnrichedAt)
+
+	failed := NewEnrichmentResult(&LocationDoneEvent{PropertyID: propertyID, Error: "no boundaries"}, enrichedAt)
+	assert.Equal(t, "no boundaries", failed.Error)
+	assert.Nil(t, failed.CountryID)
+}
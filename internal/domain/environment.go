@@ -4,37 +4,51 @@ import "time"
 
 // GreenSpace представляет зеленую зону
 type GreenSpace struct {
-	ID        int64     `json:"id" db:"id"`
-	OSMId     int64     `json:"osm_id" db:"osm_id"`
-	Type      string    `json:"type" db:"type"`
-	Name      *string   `json:"name,omitempty" db:"name"`
-	NameEn    *string   `json:"name_en,omitempty" db:"name_en"`
-	AreaSqM   float64   `json:"area_sq_m" db:"area_sq_m"`
-	Geometry  []byte    `json:"-" db:"geometry"`
-	CenterLat float64   `json:"center_lat" db:"center_lat"`
-	CenterLon float64   `json:"center_lon" db:"center_lon"`
-	Access    *string   `json:"access,omitempty" db:"access"`
-	Tags      *JSONBMap `json:"tags,omitempty" db:"tags"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID        int64    `json:"id" db:"id"`
+	OSMId     int64    `json:"osm_id" db:"osm_id"`
+	Type      string   `json:"type" db:"type"`
+	Name      *string  `json:"name,omitempty" db:"name"`
+	NameEn    *string  `json:"name_en,omitempty" db:"name_en"`
+	AreaSqM   float64  `json:"area_sq_m" db:"area_sq_m"`
+	Geometry  []byte   `json:"-" db:"geometry"`
+	CenterLat float64  `json:"center_lat" db:"center_lat"`
+	CenterLon float64  `json:"center_lon" db:"center_lon"`
+	Access    *string  `json:"access,omitempty" db:"access"`
+	DistanceM *float64 `json:"distance,omitempty" db:"distance"`     // meters
+	OrderBy   string   `json:"order_by,omitempty" db:"order_by"`     // ключ сортировки: distance, area, score
+	SortValue *float64 `json:"sort_value,omitempty" db:"sort_value"` // значение ключа сортировки
+	// IntersectionAreaSqM - площадь части зоны внутри границы, только для зон, пересекающих край границы
+	IntersectionAreaSqM *float64  `json:"intersection_area_sq_m,omitempty" db:"intersection_area_sq_m"`
+	Tags                *JSONBMap `json:"tags,omitempty" db:"tags"`
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
 }
 
-// WaterBody представляет водный объект
+// WaterBody представляет водный объект: площадной (озеро, море, русло реки) или линейный (река, ручей, канал)
 type WaterBody struct {
-	ID        int64     `json:"id" db:"id"`
-	OSMId     int64     `json:"osm_id" db:"osm_id"`
-	Type      string    `json:"type" db:"type"`
-	Name      *string   `json:"name,omitempty" db:"name"`
-	NameEn    *string   `json:"name_en,omitempty" db:"name_en"`
-	Geometry  []byte    `json:"-" db:"geometry"`
-	Length    *float64  `json:"length,omitempty" db:"length"`
-	AreaSqM   *float64  `json:"area_sq_m,omitempty" db:"area_sq_m"`
-	Tags      *JSONBMap `json:"tags,omitempty" db:"tags"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID           int64     `json:"id" db:"id"`
+	OSMId        int64     `json:"osm_id" db:"osm_id"`
+	Type         string    `json:"type" db:"type"`
+	GeometryType string    `json:"geometry_type" db:"geometry_type"` // GeometryType(way): POLYGON/MULTIPOLYGON - озеро, море; LINESTRING - река, ручей
+	Name         *string   `json:"name,omitempty" db:"name"`
+	NameEn       *string   `json:"name_en,omitempty" db:"name_en"`
+	Geometry     []byte    `json:"-" db:"geometry"`
+	Length       *float64  `json:"length,omitempty" db:"length"`
+	AreaSqM      *float64  `json:"area_sq_m,omitempty" db:"area_sq_m"` // у линий не заполняется
+	Navigable    *bool     `json:"navigable,omitempty" db:"navigable"` // по тегам ship/boat/canoe, nil - не указано
+	Tidal        *bool     `json:"tidal,omitempty" db:"tidal"`         // тег tidal, nil - не указан
+	DistanceM    *float64  `json:"distance,omitempty" db:"distance"`   // meters
+	Tags         *JSONBMap `json:"tags,omitempty" db:"tags"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
 
-// Beach представляет пляж
+// Beach представляет пляж.
+// Access - тег access как есть: yes/public/permissive - открыт, customers - только для гостей отеля
+// или клуба, private/no - закрыт (в выдачу рядом с точкой по умолчанию не попадает), nil - не указан, открыт.
+// Seasonal - тег seasonal: true - открыт только в сезон (Season - сам сезон, если он указан вместо yes:
+// "summer", "Jun-Sep"), false - круглый год, nil - не указан.
+// BlueFlag - тег blue_flag; в OSM он редок, nil - неизвестно, а не "флага нет".
 type Beach struct {
 	ID        int64     `json:"id" db:"id"`
 	OSMId     int64     `json:"osm_id" db:"osm_id"`
@@ -46,6 +60,10 @@ type Beach struct {
 	Geometry  []byte    `json:"-" db:"geometry"`
 	Length    *float64  `json:"length,omitempty" db:"length"`
 	BlueFlag  *bool     `json:"blue_flag,omitempty" db:"blue_flag"`
+	Access    *string   `json:"access,omitempty" db:"access"`
+	Seasonal  *bool     `json:"seasonal,omitempty" db:"seasonal"`
+	Season    *string   `json:"season,omitempty" db:"season"`
+	DistanceM *float64  `json:"distance,omitempty" db:"distance"` // meters
 	Tags      *JSONBMap `json:"tags,omitempty" db:"tags"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
@@ -61,6 +79,7 @@ type NoiseSource struct {
 	Lon       float64   `json:"lon" db:"lon"`
 	Geometry  []byte    `json:"-" db:"geometry"`
 	Intensity *string   `json:"intensity,omitempty" db:"intensity"`
+	DistanceM *float64  `json:"distance,omitempty" db:"distance"` // meters
 	Tags      *JSONBMap `json:"tags,omitempty" db:"tags"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
@@ -68,28 +87,98 @@ type NoiseSource struct {
 
 // TouristZone представляет туристическую зону
 type TouristZone struct {
-	ID              int64     `json:"id" db:"id"`
-	OSMId           int64     `json:"osm_id" db:"osm_id"`
-	Type            string    `json:"type" db:"type"`
-	Name            string    `json:"name" db:"name"`
-	NameEn          string    `json:"name_en" db:"name_en"`
-	NameEs          string    `json:"name_es" db:"name_es"`
-	NameCa          string    `json:"name_ca" db:"name_ca"`
-	NameRu          string    `json:"name_ru" db:"name_ru"`
-	NameUk          string    `json:"name_uk" db:"name_uk"`
-	NameFr          string    `json:"name_fr" db:"name_fr"`
-	NamePt          string    `json:"name_pt" db:"name_pt"`
-	NameIt          string    `json:"name_it" db:"name_it"`
-	NameDe          string    `json:"name_de" db:"name_de"`
-	Lat             float64   `json:"lat" db:"lat"`
-	Lon             float64   `json:"lon" db:"lon"`
-	Geometry        []byte    `json:"-" db:"geometry"`
-	VisitorsPerYear *int      `json:"visitors_per_year,omitempty" db:"visitors_per_year"`
-	Fee             *bool     `json:"fee,omitempty" db:"fee"`
-	OpeningHours    *string   `json:"opening_hours,omitempty" db:"opening_hours"`
-	Website         *string   `json:"website,omitempty" db:"website"`
-	Tags            *JSONBMap `json:"tags,omitempty" db:"tags"`
-	SearchVector    string    `json:"-" db:"search_vector"`
-	CreatedAt       time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+	ID              int64             `json:"id" db:"id"`
+	OSMId           int64             `json:"osm_id" db:"osm_id"`
+	Type            string            `json:"type" db:"type"`
+	Name            string            `json:"name" db:"name"`
+	NameEn          string            `json:"name_en" db:"name_en"`
+	NameEs          string            `json:"name_es" db:"name_es"`
+	NameCa          string            `json:"name_ca" db:"name_ca"`
+	NameRu          string            `json:"name_ru" db:"name_ru"`
+	NameUk          string            `json:"name_uk" db:"name_uk"`
+	NameFr          string            `json:"name_fr" db:"name_fr"`
+	NamePt          string            `json:"name_pt" db:"name_pt"`
+	NameIt          string            `json:"name_it" db:"name_it"`
+	NameDe          string            `json:"name_de" db:"name_de"`
+	Names           map[string]string `json:"names,omitempty" db:"-"` // переводы для языков из конфига (NAME_LANGUAGES)
+	Lat             float64           `json:"lat" db:"lat"`
+	Lon             float64           `json:"lon" db:"lon"`
+	Geometry        []byte            `json:"-" db:"geometry"`
+	VisitorsPerYear *int              `json:"visitors_per_year,omitempty" db:"visitors_per_year"`
+	Fee             *bool             `json:"fee,omitempty" db:"fee"`
+	OpeningHours    *string           `json:"opening_hours,omitempty" db:"opening_hours"`
+	Website         *string           `json:"website,omitempty" db:"website"`
+	DistanceM       *float64          `json:"distance,omitempty" db:"distance"` // meters
+	Tags            *JSONBMap         `json:"tags,omitempty" db:"tags"`
+	SearchVector    string            `json:"-" db:"search_vector"`
+	CreatedAt       time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// EnvironmentOrderBy - ключ сортировки результатов поиска экологических объектов
+type EnvironmentOrderBy string
+
+const (
+	EnvironmentOrderByDistance EnvironmentOrderBy = "distance" // ближайшие первыми (по умолчанию)
+	EnvironmentOrderByArea     EnvironmentOrderBy = "area"     // крупнейшие первыми
+	EnvironmentOrderByScore    EnvironmentOrderBy = "score"    // взвешенная комбинация близости и площади
+)
+
+// Веса по умолчанию для сортировки по score
+const (
+	DefaultScoreDistanceWeight = 0.5
+	DefaultScoreAreaWeight     = 0.5
+)
+
+// EnvironmentOrderOptions - параметры сортировки для поиска экологических объектов
+// Score = DistanceWeight * (1 - distance/radius) + AreaWeight * (area/max_area),
+// обе компоненты нормализованы в [0, 1]
+type EnvironmentOrderOptions struct {
+	OrderBy        EnvironmentOrderBy
+	DistanceWeight float64
+	AreaWeight     float64
+}
+
+// Normalize возвращает опции с примененными значениями по умолчанию
+func (o EnvironmentOrderOptions) Normalize() EnvironmentOrderOptions {
+	switch o.OrderBy {
+	case EnvironmentOrderByArea, EnvironmentOrderByScore:
+	default:
+		o.OrderBy = EnvironmentOrderByDistance
+	}
+	if o.DistanceWeight < 0 {
+		o.DistanceWeight = 0
+	}
+	if o.AreaWeight < 0 {
+		o.AreaWeight = 0
+	}
+	if o.DistanceWeight == 0 && o.AreaWeight == 0 {
+		o.DistanceWeight = DefaultScoreDistanceWeight
+		o.AreaWeight = DefaultScoreAreaWeight
+	}
+	return o
+}
+
+// ElevationFeature - объект OSM с тегом ele (вершина, геодезический пункт, горизонталь)
+type ElevationFeature struct {
+	OSMId     int64   `json:"osm_id"`
+	Type      string  `json:"type"` // peak, survey_point, contour, ...
+	Name      string  `json:"name,omitempty"`
+	EleM      float64 `json:"ele"` // высота над уровнем моря, метры
+	Lat       float64 `json:"lat"`
+	Lon       float64 `json:"lon"`
+	DistanceM float64 `json:"distance"` // метры
+}
+
+// ElevationContext - приблизительная высота точки по тегам ele объектов OSM в радиусе (без DEM).
+// HasData=false означает, что в радиусе нет объектов с ele, остальные поля при этом пусты.
+type ElevationContext struct {
+	Lat          float64           `json:"lat"`
+	Lon          float64           `json:"lon"`
+	RadiusKm     float64           `json:"radius_km"`
+	HasData      bool              `json:"has_data"`
+	Nearest      *ElevationFeature `json:"nearest,omitempty"`
+	MinEleM      *float64          `json:"min_ele,omitempty"`
+	MaxEleM      *float64          `json:"max_ele,omitempty"`
+	FeatureCount int               `json:"feature_count"`
 }
@@ -0,0 +1,62 @@
+package domain
+
+import "math"
+
+// Точность результата прямого геокодирования: найден дом, улица или только граница-область
+const (
+	GeocodePrecisionHouse    = "house"
+	GeocodePrecisionStreet   = "street"
+	GeocodePrecisionBoundary = "boundary"
+)
+
+// AddressLevelCity - уровень границы, начиная с которого внутри нее ищутся улицы и дома:
+// внутри провинции или страны одноименных улиц слишком много
+const AddressLevelCity = 8
+
+// AddressLookup - поиск точки адреса внутри границы-области для пакетного прямого геокодирования.
+// Адреса одного города ищутся с одним ScopeID, полигон области читается один раз.
+type AddressLookup struct {
+	Index       int    // индекс для маппинга результатов
+	ScopeID     int64  // osm_id границы, внутри которой ищется адрес
+	Street      string // "" - только точка внутри границы
+	HouseNumber string // "" - только улица
+	PostalCode  string // из нескольких домов с одним номером предпочитается дом с этим индексом
+}
+
+// AddressLookupResult - найденная точка адреса (EPSG:4326) и ее точность (GeocodePrecision*)
+type AddressLookupResult struct {
+	Index     int
+	Lat       float64
+	Lon       float64
+	Precision string
+}
+
+// ForwardGeocodeConfidence - достоверность прямого геокодирования в диапазоне [0, 1]:
+// базовая оценка по точности (дом, улица или граница уровня scopeLevel), умноженная на долю
+// уровней адреса, найденных по названию (matchedLevels из requestedLevels)
+func ForwardGeocodeConfidence(precision string, scopeLevel, matchedLevels, requestedLevels int) float64 {
+	if matchedLevels <= 0 || requestedLevels <= 0 {
+		return 0
+	}
+
+	var base float64
+	switch {
+	case precision == GeocodePrecisionHouse:
+		base = 0.95
+	case precision == GeocodePrecisionStreet:
+		base = 0.75
+	case scopeLevel >= 9:
+		base = 0.5
+	case scopeLevel >= AddressLevelCity:
+		base = 0.4
+	case scopeLevel >= 6:
+		base = 0.25
+	case scopeLevel >= 4:
+		base = 0.15
+	default:
+		base = 0.05
+	}
+
+	ratio := math.Min(float64(matchedLevels)/float64(requestedLevels), 1)
+	return math.Round(base*ratio*1000) / 1000
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForwardGeocodeConfidence(t *testing.T) {
+	assert.Equal(t, 0.95, ForwardGeocodeConfidence(GeocodePrecisionHouse, 8, 3, 3))
+	assert.Equal(t, 0.75, ForwardGeocodeConfidence(GeocodePrecisionStreet, 8, 2, 2))
+	assert.Equal(t, 0.4, ForwardGeocodeConfidence(GeocodePrecisionBoundary, 8, 2, 2))
+	assert.Equal(t, 0.05, ForwardGeocodeConfidence(GeocodePrecisionBoundary, 2, 1, 1))
+
+	assert.Equal(t, 0.2, ForwardGeocodeConfidence(GeocodePrecisionBoundary, 8, 1, 2), "city found, district not")
+	assert.Equal(t, 0.0, ForwardGeocodeConfidence(GeocodePrecisionBoundary, 0, 0, 2), "nothing found")
+}
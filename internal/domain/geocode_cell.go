@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GeocodeCell - самый детальный полигон, содержащий точку, внутри которого результат
+// обратного геокодирования одинаков для любой точки: в него не заходят другие
+// административные границы, а все содержащие границы покрывают его целиком.
+// Ключ кеша обратного геокодирования: точка внутри ячейки получает адрес без запроса к БД.
+type GeocodeCell struct {
+	BoundaryID int64
+	AdminLevel int
+	BBox       BoundingBox
+	rings      [][]LatLon // кольца всех полигонов (внешние и дыры)
+}
+
+// NewGeocodeCell создает ячейку из GeoJSON геометрии (Polygon или MultiPolygon, EPSG:4326)
+func NewGeocodeCell(boundaryID int64, adminLevel int, geoJSON string) (*GeocodeCell, error) {
+	var geom struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal([]byte(geoJSON), &geom); err != nil {
+		return nil, fmt.Errorf("invalid geometry: %w", err)
+	}
+
+	var polygons [][][][2]float64
+	switch geom.Type {
+	case "Polygon":
+		var polygon [][][2]float64
+		if err := json.Unmarshal(geom.Coordinates, &polygon); err != nil {
+			return nil, fmt.Errorf("invalid polygon: %w", err)
+		}
+		polygons = [][][][2]float64{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(geom.Coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("invalid multipolygon: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q", geom.Type)
+	}
+
+	cell := &GeocodeCell{BoundaryID: boundaryID, AdminLevel: adminLevel}
+	first := true
+	for _, polygon := range polygons {
+		for _, coords := range polygon {
+			if len(coords) < 4 {
+				return nil, fmt.Errorf("ring with %d points", len(coords))
+			}
+			ring := make([]LatLon, len(coords))
+			for i, c := range coords {
+				ring[i] = LatLon{Lat: c[1], Lon: c[0]}
+				if first {
+					cell.BBox = BoundingBox{MinLat: c[1], MinLon: c[0], MaxLat: c[1], MaxLon: c[0]}
+					first = false
+					continue
+				}
+				cell.BBox.MinLat = min(cell.BBox.MinLat, c[1])
+				cell.BBox.MinLon = min(cell.BBox.MinLon, c[0])
+				cell.BBox.MaxLat = max(cell.BBox.MaxLat, c[1])
+				cell.BBox.MaxLon = max(cell.BBox.MaxLon, c[0])
+			}
+			cell.rings = append(cell.rings, ring)
+		}
+	}
+	if len(cell.rings) == 0 {
+		return nil, fmt.Errorf("empty geometry")
+	}
+	return cell, nil
+}
+
+// Vertices возвращает число вершин ячейки (оценка занимаемой памяти)
+func (c *GeocodeCell) Vertices() int {
+	n := 0
+	for _, ring := range c.rings {
+		n += len(ring)
+	}
+	return n
+}
+
+// Contains проверяет, лежит ли точка внутри ячейки: bbox, затем правило четности по всем кольцам
+// (дыры и части мультиполигона учитываются без разбора, какое кольцо внешнее)
+func (c *GeocodeCell) Contains(lat, lon float64) bool {
+	if lat < c.BBox.MinLat || lat > c.BBox.MaxLat || lon < c.BBox.MinLon || lon > c.BBox.MaxLon {
+		return false
+	}
+
+	inside := false
+	for _, ring := range c.rings {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			a, b := ring[i], ring[j]
+			if (a.Lat > lat) != (b.Lat > lat) &&
+				lon < (b.Lon-a.Lon)*(lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
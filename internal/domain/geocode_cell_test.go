@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeocodeCell_Contains(t *testing.T) {
+	// Квадрат 0..10 с дырой 4..6
+	cell, err := NewGeocodeCell(-1, 10, `{"type":"Polygon","coordinates":[
+		[[0,0],[10,0],[10,10],[0,10],[0,0]],
+		[[4,4],[6,4],[6,6],[4,6],[4,4]]
+	]}`)
+	require.NoError(t, err)
+
+	assert.Equal(t, BoundingBox{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}, cell.BBox)
+	assert.Equal(t, 10, cell.Vertices())
+	assert.True(t, cell.Contains(2, 2))
+	assert.True(t, cell.Contains(9.5, 0.5))
+	assert.False(t, cell.Contains(5, 5), "point in the hole")
+	assert.False(t, cell.Contains(11, 5), "point outside bbox")
+}
+
+func TestGeocodeCell_MultiPolygon(t *testing.T) {
+	cell, err := NewGeocodeCell(-2, 8, `{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[1,0],[1,1],[0,1],[0,0]]],
+		[[[5,5],[6,5],[6,6],[5,6],[5,5]]]
+	]}`)
+	require.NoError(t, err)
+
+	assert.True(t, cell.Contains(0.5, 0.5))
+	assert.True(t, cell.Contains(5.5, 5.5))
+	assert.False(t, cell.Contains(3, 3), "inside bbox, between parts")
+}
+
+func TestNewGeocodeCell_Invalid(t *testing.T) {
+	for _, geoJSON := range []string{
+		`not json`,
+		`{"type":"Point","coordinates":[0,0]}`,
+		`{"type":"Polygon","coordinates":[]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,1]]]}`,
+	} {
+		_, err := NewGeocodeCell(1, 8, geoJSON)
+		assert.Error(t, err, geoJSON)
+	}
+}
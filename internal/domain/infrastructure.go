@@ -1,5 +1,7 @@
 package domain
 
+import "sort"
+
 // InfrastructureResult - результат обогащения инфраструктурой
 type InfrastructureResult struct {
 	Transport        []TransportWithDistance `json:"transport,omitempty"`
@@ -39,6 +41,35 @@ type TransportPriority struct {
 	Limit int
 }
 
+// StationsOrder - порядок станций в ответе GetNearestStationsGrouped
+type StationsOrder int
+
+const (
+	// StationsOrderByPriority - блоками по типам в порядке priorities, внутри блока - по расстоянию:
+	// дальнее метро идет раньше ближнего автобуса, если метро в приоритетах первое
+	StationsOrderByPriority StationsOrder = iota
+	// StationsOrderByDistance - все станции по расстоянию; при равном расстоянии - в порядке priorities
+	StationsOrderByDistance
+)
+
+// NearestStationsOptions - необязательные параметры GetNearestStationsGrouped; нулевое значение -
+// порядок по приоритету типов
+type NearestStationsOptions struct {
+	Order StationsOrder
+}
+
+// SortStationsByDistance сортирует станции по расстоянию. Сортировка устойчивая: при равном
+// расстоянии сохраняется исходный порядок (приоритет типа), станции без расстояния - в конце.
+func SortStationsByDistance(stations []*TransportStation) {
+	sort.SliceStable(stations, func(i, j int) bool {
+		a, b := stations[i].DistanceM, stations[j].DistanceM
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+}
+
 // POICategoryConfig - конфигурация категории POI
 type POICategoryConfig struct {
 	Category    string
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortStationsByDistance(t *testing.T) {
+	dist := func(v float64) *float64 { return &v }
+	stations := []*TransportStation{
+		{Name: "Metro far", Type: "metro", DistanceM: dist(900)},
+		{Name: "Metro tie", Type: "metro", DistanceM: dist(300)},
+		{Name: "Unknown", Type: "train"},
+		{Name: "Bus tie", Type: "bus", DistanceM: dist(300)},
+		{Name: "Bus near", Type: "bus", DistanceM: dist(50)},
+	}
+
+	SortStationsByDistance(stations)
+
+	names := make([]string, len(stations))
+	for i, s := range stations {
+		names[i] = s.Name
+	}
+	// При равном расстоянии сохраняется порядок приоритетов (метро раньше автобуса)
+	assert.Equal(t, []string{"Bus near", "Metro tie", "Bus tie", "Metro far", "Unknown"}, names)
+}
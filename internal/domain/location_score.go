@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LocationScoreComponent - составляющая композитной оценки локации
+type LocationScoreComponent string
+
+const (
+	LocationScoreTransport  LocationScoreComponent = "transport"   // транспортная доступность
+	LocationScoreGreenSpace LocationScoreComponent = "green_space" // близость зеленых зон
+	LocationScoreNoise      LocationScoreComponent = "noise"       // тишина (обратная к источникам шума)
+	LocationScorePOIDensity LocationScoreComponent = "poi_density" // плотность инфраструктуры
+)
+
+// LocationScoreComponents - составляющие оценки в порядке вывода
+var LocationScoreComponents = []LocationScoreComponent{
+	LocationScoreTransport, LocationScoreGreenSpace, LocationScoreNoise, LocationScorePOIDensity,
+}
+
+// LocationScoreWeights - веса составляющих в композитной оценке.
+// Веса не обязаны давать в сумме 1: композит нормируется на сумму весов доступных составляющих.
+type LocationScoreWeights map[LocationScoreComponent]float64
+
+// DefaultLocationScoreWeights возвращает веса по умолчанию
+func DefaultLocationScoreWeights() LocationScoreWeights {
+	return LocationScoreWeights{
+		LocationScoreTransport:  0.35,
+		LocationScoreGreenSpace: 0.25,
+		LocationScoreNoise:      0.2,
+		LocationScorePOIDensity: 0.2,
+	}
+}
+
+// ParseLocationScoreWeights разбирает веса вида "transport=0.4,green_space=0.2,noise=0.2,poi_density=0.2".
+// Незаданные составляющие получают вес по умолчанию, пустая строка - веса по умолчанию.
+func ParseLocationScoreWeights(s string) (LocationScoreWeights, error) {
+	weights := DefaultLocationScoreWeights()
+	if strings.TrimSpace(s) == "" {
+		return weights, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, raw, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("location score weight %q: expected component=weight", entry)
+		}
+		component := LocationScoreComponent(strings.ToLower(strings.TrimSpace(name)))
+		if _, known := weights[component]; !known {
+			return nil, fmt.Errorf("location score weight %q: unknown component %q", entry, name)
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("location score weight %q: invalid weight %q", entry, raw)
+		}
+		weights[component] = w
+	}
+
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("location score weights must not all be zero")
+	}
+
+	return weights, nil
+}
+
+// LocationScoreFeature - объект, повлиявший на оценку составляющей
+type LocationScoreFeature struct {
+	Type         string   `json:"type"`               // тип объекта: metro, park, motorway, категория POI...
+	Name         string   `json:"name,omitempty"`     // название объекта
+	DistanceM    *float64 `json:"distance,omitempty"` // расстояние до точки, метры
+	Count        int      `json:"count,omitempty"`    // количество объектов (для агрегатов по категориям)
+	Contribution float64  `json:"contribution"`       // вклад в оценку составляющей, отрицательный - штраф
+}
+
+// LocationSubScore - оценка одной составляющей с объяснением
+type LocationSubScore struct {
+	Component LocationScoreComponent `json:"component"`
+	Score     float64                `json:"score"`           // 0-100
+	Weight    float64                `json:"weight"`          // вес в композите
+	Available bool                   `json:"available"`       // false - данные не получены, составляющая не учтена
+	Error     string                 `json:"error,omitempty"` // причина недоступности
+	Features  []LocationScoreFeature `json:"features"`        // объекты, сформировавшие оценку
+}
+
+// LocationScore - композитная оценка локации: взвешенное среднее доступных составляющих
+type LocationScore struct {
+	Lat        float64            `json:"lat"`
+	Lon        float64            `json:"lon"`
+	Score      float64            `json:"score"` // 0-100
+	Components []LocationSubScore `json:"components"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocationScoreWeights(t *testing.T) {
+	w, err := ParseLocationScoreWeights(" Transport=0.5 , noise=0")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, w[LocationScoreTransport])
+	assert.Equal(t, 0.0, w[LocationScoreNoise])
+	assert.Equal(t, DefaultLocationScoreWeights()[LocationScoreGreenSpace], w[LocationScoreGreenSpace])
+
+	empty, err := ParseLocationScoreWeights("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultLocationScoreWeights(), empty)
+}
+
+func TestParseLocationScoreWeightsErrors(t *testing.T) {
+	for name, raw := range map[string]string{
+		"unknown component": "walkability=1",
+		"missing weight":    "transport",
+		"negative weight":   "transport=-1",
+		"not a number":      "transport=high",
+		"all zero":          "transport=0,green_space=0,noise=0,poi_density=0",
+	} {
+		_, err := ParseLocationScoreWeights(raw)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultNameLanguages - языки name:<lang>, возвращаемые в многоязычных полях по умолчанию
+var DefaultNameLanguages = []string{"en", "es", "ca", "ru", "uk", "fr", "pt", "it", "de"}
+
+// MaxNameLanguages - предел числа языков (json_build_object принимает до 100 аргументов)
+const MaxNameLanguages = 50
+
+var nameLanguagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})?$`)
+
+// ParseNameLanguages проверяет список языков из конфига (ISO 639 код с необязательным суффиксом:
+// sv, no, zh-Hans) и убирает дубли. Пустой список - DefaultNameLanguages.
+func ParseNameLanguages(langs []string) ([]string, error) {
+	if len(langs) == 0 {
+		return DefaultNameLanguages, nil
+	}
+
+	result := make([]string, 0, len(langs))
+	seen := make(map[string]bool, len(langs))
+	for _, lang := range langs {
+		if !nameLanguagePattern.MatchString(lang) {
+			return nil, fmt.Errorf("invalid name language %q", lang)
+		}
+		if seen[lang] {
+			continue
+		}
+		seen[lang] = true
+		result = append(result, lang)
+	}
+	if len(result) > MaxNameLanguages {
+		return nil, fmt.Errorf("too many name languages: %d (max %d)", len(result), MaxNameLanguages)
+	}
+
+	return result, nil
+}
+
+// SetNames задает переводы названия (язык -> название) и синхронизирует поля name_<lang>
+func (b *AdminBoundary) SetNames(names map[string]string) {
+	b.Names = names
+	b.NameEn, b.NameEs, b.NameCa = names["en"], names["es"], names["ca"]
+	b.NameRu, b.NameUk, b.NameFr = names["ru"], names["uk"], names["fr"]
+	b.NamePt, b.NameIt, b.NameDe = names["pt"], names["it"], names["de"]
+}
+
+// Translations возвращает переводы названия для translate_names (nil - переводов нет).
+// Без Names (граница собрана не репозиторием) переводы берутся из полей name_<lang>.
+func (b *AdminBoundary) Translations() map[string]string {
+	if b.Names != nil {
+		return copyNames(b.Names)
+	}
+
+	return copyNames(map[string]string{
+		"en": b.NameEn, "es": b.NameEs, "ca": b.NameCa,
+		"ru": b.NameRu, "uk": b.NameUk, "fr": b.NameFr,
+		"pt": b.NamePt, "it": b.NameIt, "de": b.NameDe,
+	})
+}
+
+// LocalizedName возвращает название на языке lang, если перевод есть, иначе основное название
+func (b *AdminBoundary) LocalizedName(lang string) string {
+	if name := b.Translations()[lang]; name != "" {
+		return name
+	}
+	return b.Name
+}
+
+// SetNames задает переводы названия (язык -> название) и синхронизирует поля name_<lang>
+func (z *TouristZone) SetNames(names map[string]string) {
+	z.Names = names
+	z.NameEn, z.NameEs, z.NameCa = names["en"], names["es"], names["ca"]
+	z.NameRu, z.NameUk, z.NameFr = names["ru"], names["uk"], names["fr"]
+	z.NamePt, z.NameIt, z.NameDe = names["pt"], names["it"], names["de"]
+}
+
+// copyNames копирует непустые переводы; nil, если переводов нет
+func copyNames(names map[string]string) map[string]string {
+	result := make(map[string]string, len(names))
+	for lang, name := range names {
+		if name != "" {
+			result[lang] = name
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNameLanguages(t *testing.T) {
+	langs, err := ParseNameLanguages([]string{"sv", "no", "fi", "sv", "zh-Hans"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sv", "no", "fi", "zh-Hans"}, langs)
+
+	langs, err = ParseNameLanguages(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultNameLanguages, langs)
+
+	_, err = ParseNameLanguages([]string{"en'; DROP TABLE x"})
+	assert.Error(t, err)
+}
+
+func TestAdminBoundaryTranslations(t *testing.T) {
+	b := &AdminBoundary{Name: "Stockholm"}
+	b.SetNames(map[string]string{"sv": "Stockholm", "en": "Stockholm", "fi": "Tukholma"})
+
+	assert.Equal(t, "Stockholm", b.NameEn, "legacy fields are filled for built-in languages")
+	assert.Empty(t, b.NameEs)
+	assert.Equal(t, map[string]string{"sv": "Stockholm", "en": "Stockholm", "fi": "Tukholma"}, b.Translations())
+
+	legacy := &AdminBoundary{NameEn: "Barcelona", NameCa: "Barcelona"}
+	assert.Equal(t, map[string]string{"en": "Barcelona", "ca": "Barcelona"}, legacy.Translations())
+
+	assert.Nil(t, (&AdminBoundary{}).Translations())
+}
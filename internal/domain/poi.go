@@ -1,6 +1,11 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
 
 // POI представляет точку интереса
 type POI struct {
@@ -28,6 +33,15 @@ type POI struct {
 	OpeningHours *string `json:"opening_hours,omitempty" db:"opening_hours"`
 	Wheelchair   *bool   `json:"wheelchair,omitempty" db:"wheelchair"`
 
+	// Структурированный адрес из тегов addr:*
+	AddressDetails *POIAddress `json:"address_details,omitempty" db:"-"`
+	// Расстояние от точки запроса в метрах (только для поиска рядом с точкой)
+	DistanceM *float64 `json:"distance,omitempty" db:"distance"`
+	// Позиция проекции POI на маршрут от его начала в метрах (только для поиска вдоль маршрута)
+	PathOffsetM *float64 `json:"path_offset,omitempty" db:"-"`
+	// Оценка значимости 0..MaxPOIImportance (только если запрошен отбор или порядок по значимости)
+	Importance *int `json:"importance,omitempty" db:"-"`
+
 	// Дополнительная информация
 	Description *string `json:"description,omitempty" db:"description"`
 	Brand       *string `json:"brand,omitempty" db:"brand"`
@@ -80,6 +94,131 @@ type POI struct {
 	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
 }
 
+// POIAddress - адрес POI, собранный из тегов addr:*
+type POIAddress struct {
+	Street      string `json:"street,omitempty"`
+	HouseNumber string `json:"housenumber,omitempty"`
+	Postcode    string `json:"postcode,omitempty"`
+	City        string `json:"city,omitempty"`
+	Display     string `json:"display,omitempty"` // "Carrer de Mallorca 401, 08013 Barcelona"
+}
+
+// NewPOIAddress собирает адрес из компонентов addr:*. Возвращает nil, если все компоненты пустые.
+func NewPOIAddress(street, houseNumber, postcode, city string) *POIAddress {
+	addr := &POIAddress{
+		Street:      strings.TrimSpace(street),
+		HouseNumber: strings.TrimSpace(houseNumber),
+		Postcode:    strings.TrimSpace(postcode),
+		City:        strings.TrimSpace(city),
+	}
+	if addr.Street == "" && addr.HouseNumber == "" && addr.Postcode == "" && addr.City == "" {
+		return nil
+	}
+
+	var parts []string
+	if line := strings.TrimSpace(addr.Street + " " + addr.HouseNumber); line != "" && addr.Street != "" {
+		parts = append(parts, line)
+	}
+	if locality := strings.TrimSpace(addr.Postcode + " " + addr.City); locality != "" {
+		parts = append(parts, locality)
+	}
+	addr.Display = strings.Join(parts, ", ")
+
+	return addr
+}
+
+// Ограничения фильтра по тегам POI
+const (
+	MaxPOITagFilters     = 10  // суммарно ключей в has_tags и require_tags
+	maxPOITagValueLength = 255 // предел длины значения тега в OSM
+)
+
+// poiTagKeyRe - допустимый ключ тега OSM: website, addr:street, diet:vegan, contact:phone
+var poiTagKeyRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_:.\-]{0,63}$`)
+
+// POITagFilter - фильтр POI по тегам OSM: HasTags требует наличие ключа (tags ? key),
+// RequireTags - точное значение (tags->key = value). Значения передаются в SQL параметрами.
+type POITagFilter struct {
+	HasTags     []string
+	RequireTags map[string]string
+}
+
+// IsEmpty возвращает true, если фильтр ничего не ограничивает
+func (f POITagFilter) IsEmpty() bool {
+	return len(f.HasTags) == 0 && len(f.RequireTags) == 0
+}
+
+// Validate проверяет ключи и значения фильтра
+func (f POITagFilter) Validate() error {
+	if len(f.HasTags)+len(f.RequireTags) > MaxPOITagFilters {
+		return fmt.Errorf("too many tag filters: %d, max %d", len(f.HasTags)+len(f.RequireTags), MaxPOITagFilters)
+	}
+	for _, key := range f.HasTags {
+		if !poiTagKeyRe.MatchString(key) {
+			return fmt.Errorf("invalid tag key %q", key)
+		}
+	}
+	for key, value := range f.RequireTags {
+		if !poiTagKeyRe.MatchString(key) {
+			return fmt.Errorf("invalid tag key %q", key)
+		}
+		if value == "" || len(value) > maxPOITagValueLength {
+			return fmt.Errorf("invalid value for tag %q: must be 1-%d characters", key, maxPOITagValueLength)
+		}
+	}
+	return nil
+}
+
+// MaxPOIImportance - максимальная оценка значимости POI: wikidata (4) + wikipedia (2) +
+// название (1) + вес подкатегории (до 3)
+const MaxPOIImportance = 10
+
+// Порядок результатов поиска POI
+const (
+	POIOrderDistance   = "distance"   // ближайшие сначала (по умолчанию)
+	POIOrderImportance = "importance" // значимые сначала, при равной оценке - ближайшие
+)
+
+// POINearbyOptions - необязательные параметры поиска POI в радиусе (POIRepository.GetNearby)
+type POINearbyOptions struct {
+	TagFilter      POITagFilter
+	SurfaceOnly    bool    // исключить объекты с location=underground и indoor=yes
+	IncludeAddress bool    // заполнить адрес POI
+	ExcludeWithinM float64 // > 0 - исключить POI ближе к точке (сам исходный объект и его дубли)
+
+	// CategoryPriority - категории по убыванию важности: сначала POI первой категории, затем второй и т.д.,
+	// категории вне списка - последними; внутри уровня - по расстоянию. Пусто - только по расстоянию.
+	CategoryPriority []string
+	// Importance - отбор и порядок по оценке значимости (внутри уровня CategoryPriority)
+	Importance POIImportanceFilter
+}
+
+// POIImportanceFilter - отбор и порядок POI по оценке значимости. OSM не хранит единой оценки,
+// она приближается по тегам: wikidata, wikipedia, название, вес подкатегории. Оценка считается
+// в SQL, только если фильтр не пустой.
+type POIImportanceFilter struct {
+	MinImportance     int  // не возвращать POI с оценкой ниже, 0 - без отбора
+	OrderByImportance bool // сортировать по убыванию оценки
+}
+
+// NewPOIImportanceFilter собирает фильтр из параметров запроса min_importance и order_by
+func NewPOIImportanceFilter(minImportance int, orderBy string) (POIImportanceFilter, error) {
+	if minImportance < 0 || minImportance > MaxPOIImportance {
+		return POIImportanceFilter{}, fmt.Errorf("min_importance must be between 0 and %d", MaxPOIImportance)
+	}
+	switch orderBy {
+	case "", POIOrderDistance, POIOrderImportance:
+	default:
+		return POIImportanceFilter{}, fmt.Errorf("order_by must be %q or %q", POIOrderDistance, POIOrderImportance)
+	}
+	return POIImportanceFilter{MinImportance: minImportance, OrderByImportance: orderBy == POIOrderImportance}, nil
+}
+
+// IsEmpty возвращает true, если оценка значимости не нужна
+func (f POIImportanceFilter) IsEmpty() bool {
+	return f.MinImportance <= 0 && !f.OrderByImportance
+}
+
 // POICategory представляет категорию POI
 type POICategory struct {
 	ID        int64     `json:"id" db:"id"`
@@ -113,7 +252,35 @@ type POISubcategory struct {
 	NamePt     string    `json:"name_pt" db:"name_pt"`
 	NameIt     string    `json:"name_it" db:"name_it"`
 	NameDe     string    `json:"name_de" db:"name_de"`
+	Name       string    `json:"name,omitempty" db:"-"` // подпись на запрошенном языке
+	POICount   int       `json:"poi_count" db:"poi_count"`
 	Icon       *string   `json:"icon,omitempty" db:"icon"`
 	SortOrder  int       `json:"sort_order" db:"sort_order"`
 	CreatedAt  time.Time `json:"created_at" db:"created_at"`
 }
+
+// CategoryNode - категория POI с подкатегориями для дерева фильтра на клиенте.
+// POICount категории - сумма по ее подкатегориям.
+type CategoryNode struct {
+	ID            int64             `json:"id"`
+	Code          string            `json:"code"`
+	Name          string            `json:"name,omitempty"` // подпись на запрошенном языке
+	POICount      int               `json:"poi_count"`
+	Subcategories []SubcategoryNode `json:"subcategories"`
+}
+
+// SubcategoryNode - подкатегория в дереве категорий
+type SubcategoryNode struct {
+	ID       int64  `json:"id"`
+	Code     string `json:"code"`
+	Name     string `json:"name,omitempty"` // подпись на запрошенном языке
+	POICount int    `json:"poi_count"`
+}
+
+// ActivityCenter - центр активности области: средневзвешенные координаты POI в bbox.
+// POICount - число POI, по которым посчитан центр (0 - в области нет POI, координаты не заданы).
+type ActivityCenter struct {
+	Lat      float64 `json:"lat" db:"lat"`
+	Lon      float64 `json:"lon" db:"lon"`
+	POICount int     `json:"poi_count" db:"poi_count"`
+}
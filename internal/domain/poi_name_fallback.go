@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// POINameFallback - что отдавать в name для POI без name в OSM
+type POINameFallback string
+
+const (
+	POINameFallbackSynthesize POINameFallback = "synthesize" // "<подпись категории> <osm_id>" (по умолчанию)
+	POINameFallbackCategory   POINameFallback = "category"   // только подпись категории
+	POINameFallbackEmpty      POINameFallback = "empty"      // пустое имя, подпись подставляет клиент
+)
+
+// poiCategoryLabels - локализованные подписи категорий приложения: код -> язык -> подпись
+var poiCategoryLabels = map[string]map[string]string{
+	POICategoryHealthcare: {
+		"en": "Healthcare", "es": "Salud", "ca": "Salut", "ru": "Здоровье", "uk": "Здоров'я",
+		"fr": "Santé", "pt": "Saúde", "it": "Salute", "de": "Gesundheit",
+	},
+	POICategoryShopping: {
+		"en": "Shop", "es": "Tienda", "ca": "Botiga", "ru": "Магазин", "uk": "Магазин",
+		"fr": "Magasin", "pt": "Loja", "it": "Negozio", "de": "Geschäft",
+	},
+	POICategoryEducation: {
+		"en": "Education", "es": "Educación", "ca": "Educació", "ru": "Образование", "uk": "Освіта",
+		"fr": "Éducation", "pt": "Educação", "it": "Istruzione", "de": "Bildung",
+	},
+	POICategoryLeisure: {
+		"en": "Leisure", "es": "Ocio", "ca": "Lleure", "ru": "Досуг", "uk": "Дозвілля",
+		"fr": "Loisirs", "pt": "Lazer", "it": "Tempo libero", "de": "Freizeit",
+	},
+	POICategoryFoodDrink: {
+		"en": "Food & drink", "es": "Comida y bebida", "ca": "Menjar i beguda", "ru": "Еда и напитки", "uk": "Їжа та напої",
+		"fr": "Restauration", "pt": "Comida e bebida", "it": "Cibo e bevande", "de": "Essen & Trinken",
+	},
+}
+
+// ParsePOINameFallback разбирает режим из конфига; пусто - synthesize
+func ParsePOINameFallback(raw string) (POINameFallback, error) {
+	mode := POINameFallback(strings.ToLower(strings.TrimSpace(raw)))
+	switch mode {
+	case "":
+		return POINameFallbackSynthesize, nil
+	case POINameFallbackSynthesize, POINameFallbackCategory, POINameFallbackEmpty:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown poi name fallback %q (synthesize, category, empty)", raw)
+}
+
+// POICategoryLabel возвращает подпись категории POI на языке lang. Категория ищется среди
+// категорий приложения, затем среди подкатегорий (категорией POI бывает и значение OSM тега:
+// restaurant, pharmacy). Нет перевода - английская подпись, нет в таксономии - код с заглавной
+// буквы и пробелами вместо "_".
+func POICategoryLabel(code, lang string) string {
+	labels, ok := poiCategoryLabels[code]
+	if !ok {
+		labels, ok = poiSubcategoryLabels[code]
+	}
+	if ok {
+		if label := labels[lang]; label != "" {
+			return label
+		}
+		return labels["en"]
+	}
+
+	label := strings.ReplaceAll(code, "_", " ")
+	if label == "" {
+		return ""
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// Name возвращает name как есть, а для пустого - имя по режиму fallback с подписью категории на языке lang
+func (f POINameFallback) Name(name, category string, osmID int64, lang string) string {
+	if strings.TrimSpace(name) != "" {
+		return name
+	}
+
+	switch f {
+	case POINameFallbackEmpty:
+		return ""
+	case POINameFallbackCategory:
+		return POICategoryLabel(category, lang)
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %d", POICategoryLabel(category, lang), osmID))
+}
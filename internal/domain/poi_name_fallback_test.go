@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePOINameFallback(t *testing.T) {
+	mode, err := ParsePOINameFallback("")
+	assert.NoError(t, err)
+	assert.Equal(t, POINameFallbackSynthesize, mode)
+
+	mode, err = ParsePOINameFallback(" Empty ")
+	assert.NoError(t, err)
+	assert.Equal(t, POINameFallbackEmpty, mode)
+
+	_, err = ParsePOINameFallback("osm_id")
+	assert.Error(t, err)
+}
+
+func TestPOINameFallback_Name(t *testing.T) {
+	cases := []struct {
+		name     string
+		poiName  string
+		mode     POINameFallback
+		category string
+		lang     string
+		want     string
+	}{
+		{"explicit name kept", "Farmacia Central", POINameFallbackEmpty, "pharmacy", "en", "Farmacia Central"},
+		{"subcategory label", "", POINameFallbackSynthesize, "pharmacy", "es", "Farmacia 42"},
+		{"app category label", "", POINameFallbackSynthesize, POICategoryFoodDrink, "en", "Food & drink 42"},
+		{"missing translation falls back to en", "", POINameFallbackSynthesize, "hospital", "sv", "Hospital 42"},
+		{"unknown category humanized", "", POINameFallbackSynthesize, "charging_station", "ru", "Charging station 42"},
+		{"category only", "", POINameFallbackCategory, "cafe", "ca", POISubcategoryLabel("cafe", "ca")},
+		{"empty", "", POINameFallbackEmpty, "cafe", "en", ""},
+		{"no category", "", POINameFallbackSynthesize, "", "en", "42"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.mode.Name(tc.poiName, tc.category, 42, tc.lang))
+		})
+	}
+}
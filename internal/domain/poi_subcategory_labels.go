@@ -0,0 +1,190 @@
+package domain
+
+// POISubcategoryGeneral - подкатегория POI, не попавшая в таксономию
+const POISubcategoryGeneral = "general"
+
+// poiSubcategoryLabels - локализованные подписи подкатегорий: код -> язык -> подпись
+var poiSubcategoryLabels = map[string]map[string]string{
+	// Healthcare
+	POISubcategoryPharmacy: {
+		"en": "Pharmacy", "es": "Farmacia", "ca": "Farmàcia", "ru": "Аптека", "uk": "Аптека",
+		"fr": "Pharmacie", "pt": "Farmácia", "it": "Farmacia", "de": "Apotheke",
+	},
+	POISubcategoryHospital: {
+		"en": "Hospital", "es": "Hospital", "ca": "Hospital", "ru": "Больница", "uk": "Лікарня",
+		"fr": "Hôpital", "pt": "Hospital", "it": "Ospedale", "de": "Krankenhaus",
+	},
+	POISubcategoryClinic: {
+		"en": "Clinic", "es": "Clínica", "ca": "Clínica", "ru": "Клиника", "uk": "Клініка",
+		"fr": "Clinique", "pt": "Clínica", "it": "Clinica", "de": "Klinik",
+	},
+	POISubcategoryDoctors: {
+		"en": "Doctor", "es": "Médico", "ca": "Metge", "ru": "Врач", "uk": "Лікар",
+		"fr": "Médecin", "pt": "Médico", "it": "Medico", "de": "Arztpraxis",
+	},
+	POISubcategoryDentist: {
+		"en": "Dentist", "es": "Dentista", "ca": "Dentista", "ru": "Стоматология", "uk": "Стоматологія",
+		"fr": "Dentiste", "pt": "Dentista", "it": "Dentista", "de": "Zahnarzt",
+	},
+	POISubcategoryVeterinary: {
+		"en": "Veterinary", "es": "Veterinario", "ca": "Veterinari", "ru": "Ветеринарная клиника", "uk": "Ветеринарна клініка",
+		"fr": "Vétérinaire", "pt": "Veterinário", "it": "Veterinario", "de": "Tierarzt",
+	},
+
+	// Shopping
+	POISubcategorySupermarket: {
+		"en": "Supermarket", "es": "Supermercado", "ca": "Supermercat", "ru": "Супермаркет", "uk": "Супермаркет",
+		"fr": "Supermarché", "pt": "Supermercado", "it": "Supermercato", "de": "Supermarkt",
+	},
+	POISubcategoryConvenience: {
+		"en": "Convenience store", "es": "Tienda de conveniencia", "ca": "Botiga de conveniència", "ru": "Магазин у дома", "uk": "Магазин біля дому",
+		"fr": "Supérette", "pt": "Loja de conveniência", "it": "Minimarket", "de": "Lebensmittelladen",
+	},
+	POISubcategoryMall: {
+		"en": "Shopping mall", "es": "Centro comercial", "ca": "Centre comercial", "ru": "Торговый центр", "uk": "Торговий центр",
+		"fr": "Centre commercial", "pt": "Centro comercial", "it": "Centro commerciale", "de": "Einkaufszentrum",
+	},
+	POISubcategoryGrocery: {
+		"en": "Grocery", "es": "Tienda de alimentación", "ca": "Botiga d'alimentació", "ru": "Продуктовый магазин", "uk": "Продуктовий магазин",
+		"fr": "Épicerie", "pt": "Mercearia", "it": "Alimentari", "de": "Lebensmittelgeschäft",
+	},
+	POISubcategoryDepartmentStore: {
+		"en": "Department store", "es": "Grandes almacenes", "ca": "Grans magatzems", "ru": "Универмаг", "uk": "Універмаг",
+		"fr": "Grand magasin", "pt": "Armazém", "it": "Grande magazzino", "de": "Kaufhaus",
+	},
+	POISubcategoryBakery: {
+		"en": "Bakery", "es": "Panadería", "ca": "Forn de pa", "ru": "Пекарня", "uk": "Пекарня",
+		"fr": "Boulangerie", "pt": "Padaria", "it": "Panetteria", "de": "Bäckerei",
+	},
+	POISubcategoryButcher: {
+		"en": "Butcher", "es": "Carnicería", "ca": "Carnisseria", "ru": "Мясная лавка", "uk": "М'ясна крамниця",
+		"fr": "Boucherie", "pt": "Talho", "it": "Macelleria", "de": "Metzgerei",
+	},
+	POISubcategoryGreengrocer: {
+		"en": "Greengrocer", "es": "Frutería", "ca": "Fruiteria", "ru": "Овощной магазин", "uk": "Овочевий магазин",
+		"fr": "Primeur", "pt": "Frutaria", "it": "Fruttivendolo", "de": "Obst- und Gemüsehändler",
+	},
+
+	// Education
+	POISubcategorySchool: {
+		"en": "School", "es": "Escuela", "ca": "Escola", "ru": "Школа", "uk": "Школа",
+		"fr": "École", "pt": "Escola", "it": "Scuola", "de": "Schule",
+	},
+	POISubcategoryKindergarten: {
+		"en": "Kindergarten", "es": "Guardería", "ca": "Llar d'infants", "ru": "Детский сад", "uk": "Дитячий садок",
+		"fr": "Maternelle", "pt": "Jardim de infância", "it": "Scuola dell'infanzia", "de": "Kindergarten",
+	},
+	POISubcategoryCollege: {
+		"en": "College", "es": "Instituto", "ca": "Institut", "ru": "Колледж", "uk": "Коледж",
+		"fr": "Lycée", "pt": "Colégio", "it": "Istituto superiore", "de": "Fachschule",
+	},
+	POISubcategoryUniversity: {
+		"en": "University", "es": "Universidad", "ca": "Universitat", "ru": "Университет", "uk": "Університет",
+		"fr": "Université", "pt": "Universidade", "it": "Università", "de": "Universität",
+	},
+	POISubcategoryLibrary: {
+		"en": "Library", "es": "Biblioteca", "ca": "Biblioteca", "ru": "Библиотека", "uk": "Бібліотека",
+		"fr": "Bibliothèque", "pt": "Biblioteca", "it": "Biblioteca", "de": "Bibliothek",
+	},
+	POISubcategoryLanguageSchool: {
+		"en": "Language school", "es": "Escuela de idiomas", "ca": "Escola d'idiomes", "ru": "Языковая школа", "uk": "Мовна школа",
+		"fr": "École de langues", "pt": "Escola de línguas", "it": "Scuola di lingue", "de": "Sprachschule",
+	},
+
+	// Leisure
+	POISubcategoryPark: {
+		"en": "Park", "es": "Parque", "ca": "Parc", "ru": "Парк", "uk": "Парк",
+		"fr": "Parc", "pt": "Parque", "it": "Parco", "de": "Park",
+	},
+	POISubcategoryGarden: {
+		"en": "Garden", "es": "Jardín", "ca": "Jardí", "ru": "Сад", "uk": "Сад",
+		"fr": "Jardin", "pt": "Jardim", "it": "Giardino", "de": "Garten",
+	},
+	POISubcategoryPlayground: {
+		"en": "Playground", "es": "Parque infantil", "ca": "Parc infantil", "ru": "Детская площадка", "uk": "Дитячий майданчик",
+		"fr": "Aire de jeux", "pt": "Parque infantil", "it": "Parco giochi", "de": "Spielplatz",
+	},
+	POISubcategorySportsCentre: {
+		"en": "Sports centre", "es": "Centro deportivo", "ca": "Centre esportiu", "ru": "Спортивный центр", "uk": "Спортивний центр",
+		"fr": "Centre sportif", "pt": "Centro desportivo", "it": "Centro sportivo", "de": "Sportzentrum",
+	},
+	POISubcategoryAttraction: {
+		"en": "Attraction", "es": "Atracción turística", "ca": "Atracció turística", "ru": "Достопримечательность", "uk": "Пам'ятка",
+		"fr": "Attraction", "pt": "Atração", "it": "Attrazione", "de": "Sehenswürdigkeit",
+	},
+	POISubcategoryViewpoint: {
+		"en": "Viewpoint", "es": "Mirador", "ca": "Mirador", "ru": "Смотровая площадка", "uk": "Оглядовий майданчик",
+		"fr": "Point de vue", "pt": "Miradouro", "it": "Punto panoramico", "de": "Aussichtspunkt",
+	},
+	POISubcategoryMuseum: {
+		"en": "Museum", "es": "Museo", "ca": "Museu", "ru": "Музей", "uk": "Музей",
+		"fr": "Musée", "pt": "Museu", "it": "Museo", "de": "Museum",
+	},
+	POISubcategoryMonument: {
+		"en": "Monument", "es": "Monumento", "ca": "Monument", "ru": "Памятник", "uk": "Пам'ятник",
+		"fr": "Monument", "pt": "Monumento", "it": "Monumento", "de": "Denkmal",
+	},
+	POISubcategoryCastle: {
+		"en": "Castle", "es": "Castillo", "ca": "Castell", "ru": "Замок", "uk": "Замок",
+		"fr": "Château", "pt": "Castelo", "it": "Castello", "de": "Burg",
+	},
+
+	// Food & Drink
+	POISubcategoryRestaurant: {
+		"en": "Restaurant", "es": "Restaurante", "ca": "Restaurant", "ru": "Ресторан", "uk": "Ресторан",
+		"fr": "Restaurant", "pt": "Restaurante", "it": "Ristorante", "de": "Restaurant",
+	},
+	POISubcategoryCafe: {
+		"en": "Cafe", "es": "Cafetería", "ca": "Cafeteria", "ru": "Кафе", "uk": "Кафе",
+		"fr": "Café", "pt": "Café", "it": "Caffè", "de": "Café",
+	},
+	POISubcategoryBar: {
+		"en": "Bar", "es": "Bar", "ca": "Bar", "ru": "Бар", "uk": "Бар",
+		"fr": "Bar", "pt": "Bar", "it": "Bar", "de": "Bar",
+	},
+	POISubcategoryFastFood: {
+		"en": "Fast food", "es": "Comida rápida", "ca": "Menjar ràpid", "ru": "Фастфуд", "uk": "Фастфуд",
+		"fr": "Restauration rapide", "pt": "Comida rápida", "it": "Fast food", "de": "Schnellimbiss",
+	},
+
+	POISubcategoryGeneral: {
+		"en": "Other", "es": "Otros", "ca": "Altres", "ru": "Другое", "uk": "Інше",
+		"fr": "Autre", "pt": "Outros", "it": "Altro", "de": "Sonstiges",
+	},
+}
+
+// POISubcategoryLabel возвращает подпись подкатегории на языке lang.
+// Нет перевода - английская подпись, подкатегории нет в таксономии - сам код.
+func POISubcategoryLabel(code, lang string) string {
+	labels, ok := poiSubcategoryLabels[code]
+	if !ok {
+		return code
+	}
+	if label := labels[lang]; label != "" {
+		return label
+	}
+	return labels["en"]
+}
+
+// ApplyTaxonomyLabels заполняет подписи категории и ее подкатегорий на языке lang из таксономии
+func (n *CategoryNode) ApplyTaxonomyLabels(lang string) {
+	n.Name = POICategoryLabel(n.Code, lang)
+	for i := range n.Subcategories {
+		n.Subcategories[i].Name = POISubcategoryLabel(n.Subcategories[i].Code, lang)
+	}
+}
+
+// ApplyTaxonomyLabels заполняет названия подкатегории на всех языках из таксономии
+// и Name - на запрошенном языке
+func (s *POISubcategory) ApplyTaxonomyLabels(lang string) {
+	s.NameEn = POISubcategoryLabel(s.Code, "en")
+	s.NameEs = POISubcategoryLabel(s.Code, "es")
+	s.NameCa = POISubcategoryLabel(s.Code, "ca")
+	s.NameRu = POISubcategoryLabel(s.Code, "ru")
+	s.NameUk = POISubcategoryLabel(s.Code, "uk")
+	s.NameFr = POISubcategoryLabel(s.Code, "fr")
+	s.NamePt = POISubcategoryLabel(s.Code, "pt")
+	s.NameIt = POISubcategoryLabel(s.Code, "it")
+	s.NameDe = POISubcategoryLabel(s.Code, "de")
+	s.Name = POISubcategoryLabel(s.Code, lang)
+}
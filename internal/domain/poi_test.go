@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPOITagFilterValidate(t *testing.T) {
+	valid := POITagFilter{
+		HasTags:     []string{"website", "contact:phone"},
+		RequireTags: map[string]string{"outdoor_seating": "yes", "diet:vegan": "only"},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.False(t, valid.IsEmpty())
+	assert.True(t, POITagFilter{}.IsEmpty())
+
+	tooMany := POITagFilter{}
+	for i := 0; i <= MaxPOITagFilters; i++ {
+		tooMany.HasTags = append(tooMany.HasTags, "website")
+	}
+
+	for name, f := range map[string]POITagFilter{
+		"injection in key": {HasTags: []string{"name' OR '1'='1"}},
+		"empty key":        {HasTags: []string{""}},
+		"operator in key":  {RequireTags: map[string]string{"a=>b": "yes"}},
+		"empty value":      {RequireTags: map[string]string{"wheelchair": ""}},
+		"value too long":   {RequireTags: map[string]string{"note": strings.Repeat("x", 256)}},
+		"too many filters": tooMany,
+	} {
+		assert.Error(t, f.Validate(), name)
+	}
+}
+
+func TestNewPOIImportanceFilter(t *testing.T) {
+	f, err := NewPOIImportanceFilter(0, "")
+	assert.NoError(t, err)
+	assert.True(t, f.IsEmpty())
+
+	f, err = NewPOIImportanceFilter(0, POIOrderDistance)
+	assert.NoError(t, err)
+	assert.True(t, f.IsEmpty())
+
+	f, err = NewPOIImportanceFilter(3, POIOrderImportance)
+	assert.NoError(t, err)
+	assert.Equal(t, POIImportanceFilter{MinImportance: 3, OrderByImportance: true}, f)
+	assert.False(t, f.IsEmpty())
+
+	_, err = NewPOIImportanceFilter(-1, "")
+	assert.Error(t, err)
+	_, err = NewPOIImportanceFilter(MaxPOIImportance+1, "")
+	assert.Error(t, err)
+	_, err = NewPOIImportanceFilter(0, "name")
+	assert.Error(t, err)
+}
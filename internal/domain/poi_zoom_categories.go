@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// POIZoomCategoryStep - подкатегории POI, которые появляются в тайлах начиная с MinZoom
+type POIZoomCategoryStep struct {
+	MinZoom       int
+	Subcategories []string
+}
+
+// POIZoomCategories - какие подкатегории POI видны в тайлах на каждом зуме. На мелком масштабе
+// остаются только значимые объекты (больницы, достопримечательности), с ростом зума открываются
+// остальные. Набор накопительный: подкатегория, видимая с зума z, видна и на всех более крупных.
+// Это не кластеризация: политика решает, какие объекты уместны на масштабе, а порядок важности
+// внутри тайла по-прежнему задает репозиторий.
+type POIZoomCategories struct {
+	Steps       []POIZoomCategoryStep // по возрастанию MinZoom
+	AllFromZoom int                   // с этого зума видны все POI; больше TileMaxZoom - фильтр на всех зумах
+}
+
+var poiSubcategoryPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// DefaultPOIZoomCategories возвращает политику по умолчанию: до z11 больницы, университеты
+// и достопримечательности, с z12 парки, школы, крупные магазины, с z14 все POI.
+func DefaultPOIZoomCategories() POIZoomCategories {
+	return POIZoomCategories{
+		Steps: []POIZoomCategoryStep{
+			{MinZoom: 0, Subcategories: []string{"hospital", "university", "attraction", "museum", "castle"}},
+			{MinZoom: 12, Subcategories: []string{"mall", "viewpoint", "monument", "park", "school", "college",
+				"library", "supermarket", "department_store"}},
+		},
+		AllFromZoom: 14,
+	}
+}
+
+// SubcategoriesFor возвращает подкатегории, видимые на зуме z. filtered=false - фильтра нет,
+// видны все POI; пустой список при filtered=true - на зуме не видно ни одного POI.
+func (p POIZoomCategories) SubcategoriesFor(z int) (subcategories []string, filtered bool) {
+	if z >= p.AllFromZoom {
+		return nil, false
+	}
+
+	subcategories = []string{}
+	for _, step := range p.Steps {
+		if step.MinZoom > z {
+			break
+		}
+		subcategories = append(subcategories, step.Subcategories...)
+	}
+	return subcategories, true
+}
+
+// ParsePOIZoomCategories разбирает политику вида
+// "0|hospital,attraction,museum;12|park,school;14|*", где "*" открывает все POI с указанного зума.
+// Пустая строка - политика по умолчанию.
+func ParsePOIZoomCategories(s string) (POIZoomCategories, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultPOIZoomCategories(), nil
+	}
+
+	policy := POIZoomCategories{AllFromZoom: TileMaxZoom + 1}
+	seen := make(map[int]bool)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		zoomRaw, list, ok := strings.Cut(entry, "|")
+		if !ok {
+			return POIZoomCategories{}, fmt.Errorf("poi zoom categories %q: expected zoom|subcategories", entry)
+		}
+		zoom, err := strconv.Atoi(strings.TrimSpace(zoomRaw))
+		if err != nil || zoom < 0 || zoom > TileMaxZoom {
+			return POIZoomCategories{}, fmt.Errorf("poi zoom categories %q: zoom must be 0-%d", entry, TileMaxZoom)
+		}
+		if seen[zoom] {
+			return POIZoomCategories{}, fmt.Errorf("poi zoom categories: zoom %d listed twice", zoom)
+		}
+		seen[zoom] = true
+
+		if strings.TrimSpace(list) == "*" {
+			policy.AllFromZoom = min(policy.AllFromZoom, zoom)
+			continue
+		}
+
+		step := POIZoomCategoryStep{MinZoom: zoom}
+		for _, raw := range strings.Split(list, ",") {
+			subcategory := strings.ToLower(strings.TrimSpace(raw))
+			if subcategory == "" {
+				continue
+			}
+			if !poiSubcategoryPattern.MatchString(subcategory) {
+				return POIZoomCategories{}, fmt.Errorf("poi zoom categories %q: invalid subcategory %q", entry, raw)
+			}
+			step.Subcategories = append(step.Subcategories, subcategory)
+		}
+		if len(step.Subcategories) == 0 {
+			return POIZoomCategories{}, fmt.Errorf("poi zoom categories %q: no subcategories", entry)
+		}
+		policy.Steps = append(policy.Steps, step)
+	}
+
+	sort.Slice(policy.Steps, func(i, j int) bool { return policy.Steps[i].MinZoom < policy.Steps[j].MinZoom })
+	return policy, nil
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultPOIZoomCategories(t *testing.T) {
+	p := DefaultPOIZoomCategories()
+
+	low, filtered := p.SubcategoriesFor(5)
+	assert.True(t, filtered)
+	assert.Contains(t, low, "hospital")
+	assert.NotContains(t, low, "school")
+
+	mid, filtered := p.SubcategoriesFor(12)
+	assert.True(t, filtered)
+	assert.Contains(t, mid, "hospital", "major categories stay visible at larger zooms")
+	assert.Contains(t, mid, "school")
+	assert.NotContains(t, mid, "cafe")
+
+	_, filtered = p.SubcategoriesFor(14)
+	assert.False(t, filtered)
+}
+
+func TestParsePOIZoomCategories(t *testing.T) {
+	p, err := ParsePOIZoomCategories("10|Park, school ; 3|aerodrome;15|*")
+	assert.NoError(t, err)
+
+	none, filtered := p.SubcategoriesFor(2)
+	assert.True(t, filtered)
+	assert.Empty(t, none)
+
+	subcats, _ := p.SubcategoriesFor(11)
+	assert.Equal(t, []string{"aerodrome", "park", "school"}, subcats)
+
+	_, filtered = p.SubcategoriesFor(15)
+	assert.False(t, filtered)
+
+	noWildcard, err := ParsePOIZoomCategories("0|hospital")
+	assert.NoError(t, err)
+	_, filtered = noWildcard.SubcategoriesFor(TileMaxZoom)
+	assert.True(t, filtered, "without * the filter applies at every zoom")
+
+	empty, err := ParsePOIZoomCategories("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPOIZoomCategories(), empty)
+
+	for _, bad := range []string{"hospital", "23|hospital", "0|hospital;0|park", "0|bad-name", "5|"} {
+		_, err := ParsePOIZoomCategories(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -11,22 +11,50 @@ type BoundaryRepository interface {
 	// GetByID возвращает административную границу по ID
 	GetByID(ctx context.Context, id int64) (*domain.AdminBoundary, error)
 
+	// GetBoundaryBBox возвращает bbox и центроид границы без полной геометрии
+	GetBoundaryBBox(ctx context.Context, id int64) (*domain.BoundaryBBox, error)
+
+	// GetBoundariesIntersectingPolygon возвращает границы указанных уровней (пусто - всех), пересекающие
+	// полигон GeoJSON, с площадью и долей пересечения
+	GetBoundariesIntersectingPolygon(ctx context.Context, polygonGeoJSON string, levels []int) ([]domain.BoundaryOverlap, error)
+
 	// SearchByText выполняет текстовый поиск по названиям границ с поддержкой языков и фильтрации
-	SearchByText(ctx context.Context, query string, lang string, adminLevels []int, limit int) ([]*domain.AdminBoundary, error)
+	// по уровням, населению и площади (opts)
+	SearchByText(ctx context.Context, query string, lang string, adminLevels []int, limit int, opts domain.BoundarySearchOptions) ([]*domain.AdminBoundary, error)
+
+	// SearchWithinParent выполняет текстовый поиск границ, центроид которых лежит внутри родительской границы.
+	// Без levels возвращаются границы более детального уровня, чем родитель.
+	SearchWithinParent(ctx context.Context, query string, parentID int64, levels []int, limit int) ([]*domain.AdminBoundary, error)
 
 	// SearchByTextBatch выполняет батчевый текстовый поиск для нескольких запросов одним SQL
 	SearchByTextBatch(ctx context.Context, requests []domain.BoundarySearchRequest) ([]domain.BoundarySearchResult, error)
 
+	// LookupAddresses находит точки адресов (дом, улица или точка внутри границы) внутри границ-областей
+	// одним запросом; адреса с ненайденной областью в результат не попадают
+	LookupAddresses(ctx context.Context, lookups []domain.AddressLookup) ([]domain.AddressLookupResult, error)
+
 	// ReverseGeocode возвращает адрес по координатам
 	ReverseGeocode(ctx context.Context, lat, lon float64) (*domain.Address, error)
 
+	// GetGeocodeCell возвращает ячейку кеша обратного геокодирования для точки - самый детальный
+	// содержащий полигон, если адрес внутри него одинаков; nil - ячейки нет (полигон пересекают
+	// другие границы, он слишком детальный или точка вне границ)
+	GetGeocodeCell(ctx context.Context, lat, lon float64) (*domain.GeocodeCell, error)
+
+	// ReverseGeocodeWithDepth возвращает адрес по координатам вместе с количеством совпавших уровней
+	// и расстоянием до края самого детального содержащего полигона
+	ReverseGeocodeWithDepth(ctx context.Context, lat, lon float64) (*domain.ReverseGeocodeMatch, error)
+
 	// ReverseGeocodeBatch возвращает адреса для нескольких точек одним запросом
 	ReverseGeocodeBatch(ctx context.Context, points []domain.LatLon) ([]*domain.Address, error)
 
-	// GetTile генерирует MVT тайл для заданных координат
-	GetTile(ctx context.Context, z, x, y int) ([]byte, error)
+	// GetTile генерирует MVT тайл для заданных координат.
+	// Полигоны площадью меньше minAreaSqKm отбрасываются; при minAreaSqKm <= 0 порог зависит от зума.
+	GetTile(ctx context.Context, z, x, y int, minAreaSqKm float64) ([]byte, error)
 
-	// GetByPoint возвращает административные границы для точки (reverse geocoding)
+	// GetByPoint возвращает административные границы для точки (reverse geocoding).
+	// Дыры мультиполигонов учитываются: точка внутри анклава относится к анклаву, а не к окружающей границе.
+	// Пересекающиеся границы одного уровня возвращаются все, от меньшей площади к большей.
 	GetByPoint(ctx context.Context, lat, lon float64) ([]*domain.AdminBoundary, error)
 
 	// GetByPointBatch возвращает административные границы для нескольких точек одним запросом
@@ -36,15 +64,36 @@ type BoundaryRepository interface {
 	// Search выполняет текстовый поиск по названиям границ
 	Search(ctx context.Context, query string, limit int) ([]*domain.AdminBoundary, error)
 
-	// GetChildren возвращает дочерние границы для родительской
+	// GetChildren возвращает дочерние границы для родительской.
+	// Анклав, вырезанный из родителя внутренним кольцом, дочерней границей не считается.
 	GetChildren(ctx context.Context, parentID int64) ([]*domain.AdminBoundary, error)
 
 	// GetByAdminLevel возвращает границы определенного уровня
 	GetByAdminLevel(ctx context.Context, level int, limit int) ([]*domain.AdminBoundary, error)
 
+	// GetByAdminLevels возвращает границы нескольких уровней, отсортированные по уровню, затем по имени
+	GetByAdminLevels(ctx context.Context, levels []int, limit int) ([]*domain.AdminBoundary, error)
+
 	// GetBoundariesInRadius возвращает границы в радиусе от точки (для использования в коде)
 	GetBoundariesInRadius(ctx context.Context, lat, lon, radiusKm float64) ([]*domain.AdminBoundary, error)
 
+	// GetNearestBoundaries возвращает до limit границ уровня level, ближайших к точке, с расстоянием до полигона.
+	// Содержащие точку границы идут первыми с расстоянием 0, за ними - соседние по возрастанию расстояния.
+	GetNearestBoundaries(ctx context.Context, lat, lon float64, level int, limit int) ([]domain.NearestBoundary, error)
+
+	// GetNearestPlace возвращает ближайшую к точке точку населенного пункта (place=city|town|village|hamlet)
+	// с расстоянием; nil, если таких точек нет
+	GetNearestPlace(ctx context.Context, lat, lon float64) (*domain.Place, error)
+
 	// GetBoundariesRadiusTile генерирует MVT тайл с границами в радиусе от точки
 	GetBoundariesRadiusTile(ctx context.Context, lat, lon, radiusKm float64) ([]byte, error)
+
+	// GetDataCoverage возвращает охват импортированных данных и количество границ по уровням.
+	// gridSize > 0 - дополнительно сетка gridSize x gridSize с количеством объектов в ячейках.
+	// Запрос сканирует таблицы целиком, результат нужно кешировать.
+	GetDataCoverage(ctx context.Context, gridSize int) (*domain.DataCoverage, error)
+
+	// GetAdminLevels возвращает admin_level, присутствующие в данных, по возрастанию уровня
+	// с количеством границ и примером названия. Запрос группирует все границы, результат нужно кешировать.
+	GetAdminLevels(ctx context.Context) ([]domain.AdminLevelInfo, error)
 }
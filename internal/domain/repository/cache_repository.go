@@ -18,6 +18,10 @@ type CacheRepository interface {
 	// Delete удаляет значение из кеша
 	Delete(ctx context.Context, key string) error
 
+	// DeleteByPattern удаляет ключи, найденные SCAN по шаблону pattern (без FLUSHDB).
+	// match != nil дополнительно отбирает ключи. Возвращает число удаленных ключей.
+	DeleteByPattern(ctx context.Context, pattern string, match func(key string) bool) (int, error)
+
 	// Exists проверяет существование ключа
 	Exists(ctx context.Context, key string) (bool, error)
 
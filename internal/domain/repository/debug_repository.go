@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// DebugRepository интерфейс для диагностики SQL запросов (только для dev окружения)
+type DebugRepository interface {
+	// ExplainQuery выполняет EXPLAIN (ANALYZE, BUFFERS) для именованного шаблона запроса
+	// с тестовыми параметрами и возвращает план в формате JSON
+	ExplainQuery(ctx context.Context, name string, lat, lon float64) (json.RawMessage, error)
+
+	// ListExplainQueries возвращает список доступных шаблонов запросов
+	ListExplainQueries() []string
+}
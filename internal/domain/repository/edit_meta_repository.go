@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/location-microservice/internal/domain"
+)
+
+// EditMetaRepository - метаданные правок OSM (osm_version, osm_timestamp, osm_user)
+type EditMetaRepository interface {
+	// GetEditMeta возвращает метаданные правки объекта feature (domain.EditFeature*) по OSM ID.
+	// ErrLocationNotFound - объекта нет; без колонок метаданных в схеме - EditMeta{Available: false}.
+	GetEditMeta(ctx context.Context, feature string, osmID int64) (*domain.EditMeta, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/location-microservice/internal/domain"
+)
+
+// EnrichmentResultRepository - хранение результатов обогащения в основной БД
+type EnrichmentResultRepository interface {
+	// UpsertResults сохраняет результаты: по property_id побеждает результат с более поздним EnrichedAt
+	UpsertResults(ctx context.Context, results []*domain.EnrichmentResult) error
+}
@@ -8,10 +8,17 @@ import (
 
 // EnvironmentRepository определяет методы для работы с экологическими объектами
 type EnvironmentRepository interface {
-	// GetGreenSpacesNearby возвращает зеленые зоны в радиусе
-	GetGreenSpacesNearby(ctx context.Context, lat, lon float64, radiusKm float64) ([]*domain.GreenSpace, error)
+	// GetGreenSpacesNearby возвращает зеленые зоны в радиусе, отсортированные согласно opts
+	// (по умолчанию - по расстоянию). Зоны площадью меньше minAreaSqM отбрасываются (0 - без фильтра).
+	GetGreenSpacesNearby(ctx context.Context, lat, lon float64, radiusKm float64, minAreaSqM float64, opts domain.EnvironmentOrderOptions) ([]*domain.GreenSpace, error)
 
-	// GetWaterBodiesNearby возвращает водные объекты в радиусе
+	// GetGreenSpacesInBoundary возвращает зеленые зоны, пересекающие административную границу,
+	// по убыванию площади. Для неизвестной границы возвращает ErrLocationNotFound.
+	GetGreenSpacesInBoundary(ctx context.Context, boundaryID int64, limit int) ([]*domain.GreenSpace, error)
+
+	// GetWaterBodiesNearby возвращает водные объекты в радиусе: полигоны и реки, ручьи и каналы,
+	// заданные только линией (у линий geometry_type *LINESTRING и нет площади). Отрезки одной именованной
+	// реки в радиусе возвращаются одной записью
 	GetWaterBodiesNearby(ctx context.Context, lat, lon float64, radiusKm float64) ([]*domain.WaterBody, error)
 
 	// GetBeachesNearby возвращает пляжи в радиусе
@@ -23,6 +30,10 @@ type EnvironmentRepository interface {
 	// GetTouristZonesNearby возвращает туристические зоны в радиусе
 	GetTouristZonesNearby(ctx context.Context, lat, lon float64, radiusKm float64) ([]*domain.TouristZone, error)
 
+	// GetElevationContext возвращает ближайший объект с тегом ele и min/max ele в радиусе.
+	// Без объектов с ele возвращает ElevationContext с HasData=false.
+	GetElevationContext(ctx context.Context, lat, lon float64, radiusKm float64) (*domain.ElevationContext, error)
+
 	// GetGreenSpaceByID возвращает зеленую зону по ID
 	GetGreenSpaceByID(ctx context.Context, id int64) (*domain.GreenSpace, error)
 
@@ -11,11 +11,19 @@ type POIRepository interface {
 	// GetByID возвращает POI по ID
 	GetByID(ctx context.Context, id int64) (*domain.POI, error)
 
-	// GetNearby возвращает POI в радиусе от точки
-	GetNearby(ctx context.Context, lat, lon float64, radiusKm float64, categories []string) ([]*domain.POI, error)
+	// GetPOIBuilding возвращает контур здания, в котором находится POI, или ближайшее здание
+	// в небольшом радиусе. ErrLocationNotFound - POI не найден или зданий рядом нет.
+	GetPOIBuilding(ctx context.Context, poiID int64) (*domain.Building, error)
 
-	// Search выполняет текстовый поиск POI
-	Search(ctx context.Context, query string, categories []string, limit int) ([]*domain.POI, error)
+	// GetNearby возвращает POI в радиусе от точки.
+	// opts.TagFilter ограничивает выдачу наличием или значением тегов OSM (пустой - без фильтра).
+	// Остальные фильтры и порядок - тоже в opts; при непустом opts.Importance у POI заполняется Importance.
+	// limit <= 0 - LimitPOIs; вызывающий код запрашивает limit+1, чтобы по лишней строке узнать,
+	// есть ли следующая страница.
+	GetNearby(ctx context.Context, lat, lon float64, radiusKm float64, categories []string, opts domain.POINearbyOptions, limit int) ([]*domain.POI, error)
+
+	// Search выполняет текстовый поиск POI; tagFilter - как в GetNearby
+	Search(ctx context.Context, query string, categories []string, tagFilter domain.POITagFilter, limit int) ([]*domain.POI, error)
 
 	// GetByCategory возвращает POI определенной категории
 	GetByCategory(ctx context.Context, category string, limit int) ([]*domain.POI, error)
@@ -23,10 +31,16 @@ type POIRepository interface {
 	// GetCategories возвращает все категории POI
 	GetCategories(ctx context.Context) ([]*domain.POICategory, error)
 
-	// GetSubcategories возвращает подкатегории для категории
-	GetSubcategories(ctx context.Context, categoryID int64) ([]*domain.POISubcategory, error)
+	// GetSubcategories возвращает подкатегории для категории с количеством POI в каждой.
+	// bbox != nil - считаются только POI в прямоугольнике.
+	GetSubcategories(ctx context.Context, categoryID int64, bbox *domain.BoundingBox) ([]*domain.POISubcategory, error)
+
+	// GetCategoryTree возвращает все категории с подкатегориями и количеством POI одним сгруппированным
+	// запросом (без подписей). Категории и подкатегории упорядочены по коду.
+	GetCategoryTree(ctx context.Context) ([]domain.CategoryNode, error)
 
-	// GetPOITile генерирует MVT тайл с POI для заданных координат тайла
+	// GetPOITile генерирует MVT тайл с POI для заданных координат тайла.
+	// На мелких зумах в тайл попадают только подкатегории из domain.POIZoomCategories.
 	GetPOITile(ctx context.Context, z, x, y int, categories []string) ([]byte, error)
 
 	// GetPOIRadiusTile генерирует MVT тайл с POI в радиусе от точки
@@ -35,12 +49,29 @@ type POIRepository interface {
 	// GetPOIByBoundaryTile генерирует MVT тайл с POI внутри административной границы
 	GetPOIByBoundaryTile(ctx context.Context, boundaryID int64, categories []string) ([]byte, error)
 
-	// GetPOITileByCategories генерирует MVT тайл с POI по координатам тайла с фильтрацией по категориям и подкатегориям
-	GetPOITileByCategories(ctx context.Context, z, x, y int, categories, subcategories []string) ([]byte, error)
+	// GetPOITileByCategories генерирует MVT тайл с POI по координатам тайла с фильтрацией по категориям и подкатегориям.
+	// surfaceOnly исключает объекты с location=underground и indoor=yes.
+	// На зумах с кластеризацией (политика тайлов) точки объединяются в кластеры с атрибутом point_count.
+	// Фильтр domain.POIZoomCategories сужает выбранные категории на мелких зумах.
+	// categoryLimit - предел POI одной категории в тайле; 0 - при нескольких категориях лимит тайла делится поровну.
+	GetPOITileByCategories(ctx context.Context, z, x, y int, categories, subcategories []string, surfaceOnly, withLabels bool, categoryLimit int) ([]byte, error)
 
 	// GetPOIInBBox возвращает POI в видимой области карты (bbox) с фильтрацией по категориям.
-	GetPOIInBBox(ctx context.Context, swLat, swLon, neLat, neLon float64, categories, subcategories []string, limit, offset int) ([]*domain.POI, int, error)
+	// importance - отбор и порядок по оценке значимости, как в GetNearby; total учитывает отбор.
+	GetPOIInBBox(ctx context.Context, swLat, swLon, neLat, neLon float64, categories, subcategories []string, importance domain.POIImportanceFilter, limit, offset int) ([]*domain.POI, int, error)
+
+	// GetPOIsAlongPath возвращает POI в буфере bufferM метров вокруг маршрута (path - не менее двух точек)
+	// в порядке движения по маршруту (ST_LineLocatePoint), при равенстве - ближе к маршруту.
+	GetPOIsAlongPath(ctx context.Context, path []domain.Coordinate, bufferM float64, categories []string, limit int) ([]*domain.POI, error)
 
 	// CountByCategories возвращает количество POI по категориям в заданном радиусе от точки
 	CountByCategories(ctx context.Context, lat, lon float64, radiusMeters int) (map[string]int, error)
+
+	// GetNearestPerCategoryBatch возвращает для каждой точки ближайший POI каждой категории в радиусе
+	// maxRadiusKm одним запросом. Результат - по индексу точки; категории без POI в радиусе в карте нет.
+	GetNearestPerCategoryBatch(ctx context.Context, points []domain.LatLon, categories []string, maxRadiusKm float64) ([]map[string]*domain.POIWithDistance, error)
+
+	// GetActivityCenter возвращает центр активности bbox - среднее координат POI категорий categories
+	// (пусто - все категории). weighted взвешивает POI по значимости (ранг подписи в тайле).
+	GetActivityCenter(ctx context.Context, bbox domain.BoundingBox, categories []string, weighted bool) (*domain.ActivityCenter, error)
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/location-microservice/internal/domain"
+)
+
+// TileArchiveRepository интерфейс для предрассчитанных тайлов (MBTiles) статичных регионов
+type TileArchiveRepository interface {
+	// GetTile возвращает тайл слоя из архива. found=false - тайла в архиве нет
+	// (или для слоя архив не настроен), тайл нужно генерировать из PostGIS.
+	GetTile(ctx context.Context, layer domain.TileLayer, z, x, y int) (tile []byte, found bool, err error)
+}
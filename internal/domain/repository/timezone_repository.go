@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// TimezoneRepository - определение часового пояса IANA по координате
+type TimezoneRepository interface {
+	// GetTimezone возвращает идентификатор IANA (Europe/Madrid) часового пояса точки.
+	// ErrTimezoneUnavailable - ни таблица часовых поясов, ни границы OSM не дают пояса для точки.
+	GetTimezone(ctx context.Context, lat, lon float64) (string, error)
+}
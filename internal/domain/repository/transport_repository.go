@@ -8,22 +8,49 @@ import (
 
 // TransportRepository определяет методы для работы с транспортом
 type TransportRepository interface {
-	// GetNearestStations возвращает ближайшие станции
-	GetNearestStations(ctx context.Context, lat, lon float64, types []string, maxDistance float64, limit int) ([]*domain.TransportStation, error)
+	// GetNearestStations возвращает ближайшие станции.
+	// surfaceOnly исключает станции с location=underground и indoor=yes (например, платформы метро).
+	// excludeWithinM > 0 исключает станции ближе excludeWithinM метров к точке (поиск от самой станции).
+	GetNearestStations(ctx context.Context, lat, lon float64, types []string, maxDistance float64, limit int, surfaceOnly bool, excludeWithinM float64) ([]*domain.TransportStation, error)
+
+	// CountStationsNearby возвращает число станций указанных типов в радиусе maxDistance (км) от точки.
+	// Используется для оценки плотности транспорта перед выбором радиуса поиска.
+	CountStationsNearby(ctx context.Context, lat, lon float64, types []string, maxDistance float64) (int, error)
 
 	// GetNearestStationsGrouped возвращает ближайшие станции транспорта с группировкой
 	// по нормализованному имени. Это исключает дубли выходов метро (считается как одна станция).
-	GetNearestStationsGrouped(ctx context.Context, lat, lon float64, priorities []domain.TransportPriority, maxDistance float64) ([]*domain.TransportStation, error)
+	// opts.Order задает порядок результата: блоками по типам в порядке priorities (StationsOrderByPriority)
+	// или по расстоянию для всех типов сразу (StationsOrderByDistance).
+	GetNearestStationsGrouped(ctx context.Context, lat, lon float64, priorities []domain.TransportPriority, maxDistance float64, opts domain.NearestStationsOptions) ([]*domain.TransportStation, error)
+
+	// GetLinesInBBox возвращает линии маршрутов, пересекающие bbox, с GeoJSON геометрией,
+	// обрезанной по bbox. Направления линии схлопываются по ref; modes - типы транспорта API (пусто - все).
+	GetLinesInBBox(ctx context.Context, bbox domain.BoundingBox, modes []string) ([]domain.TransportLineGeometry, error)
 
 	// GetLineByID возвращает линию по ID
 	GetLineByID(ctx context.Context, id int64) (*domain.TransportLine, error)
 
+	// GetStationByID возвращает станцию по ID
+	GetStationByID(ctx context.Context, id int64) (*domain.TransportStation, error)
+
 	// GetLinesByIDs возвращает линии по списку ID
 	GetLinesByIDs(ctx context.Context, ids []int64) ([]*domain.TransportLine, error)
 
+	// GetLinesGeometry возвращает GeoJSON геометрии нескольких линий одним запросом (map[line_id] -> геометрия).
+	// При dedupeByRef линии обоих направлений с одинаковым ref возвращаются один раз.
+	GetLinesGeometry(ctx context.Context, ids []int64, dedupeByRef bool) (map[int64]*domain.TransportLineGeometry, error)
+
 	// GetStationsByLineID возвращает все станции для линии
 	GetStationsByLineID(ctx context.Context, lineID int64) ([]*domain.TransportStation, error)
 
+	// GetStationsAlongLine возвращает станции в пределах bufferM метров от геометрии линии в порядке следования.
+	// Геометрическое приближение до разбора relation members.
+	GetStationsAlongLine(ctx context.Context, lineID int64, bufferM float64) ([]*domain.TransportStation, error)
+
+	// GetStationsInBoundary возвращает станции внутри административной границы, дедуплицированные
+	// по нормализованному имени внутри типа. Пустой types - все типы. Неизвестная граница - ErrLocationNotFound.
+	GetStationsInBoundary(ctx context.Context, boundaryID int64, types []string) ([]*domain.TransportStation, error)
+
 	// GetTransportTile генерирует MVT тайл для транспорта
 	GetTransportTile(ctx context.Context, z, x, y int) ([]byte, error)
 
@@ -42,8 +69,9 @@ type TransportRepository interface {
 	// GetTransportRadiusTile генерирует MVT тайл с транспортом в радиусе от точки
 	GetTransportRadiusTile(ctx context.Context, lat, lon, radiusKm float64) ([]byte, error)
 
-	// GetTransportTileByTypes генерирует MVT тайл для транспорта с фильтрацией по типам
-	GetTransportTileByTypes(ctx context.Context, z, x, y int, types []string) ([]byte, error)
+	// GetTransportTileByTypes генерирует MVT тайл для транспорта с фильтрацией по типам.
+	// surfaceOnly исключает из слоя станций объекты с location=underground и indoor=yes.
+	GetTransportTileByTypes(ctx context.Context, z, x, y int, types []string, surfaceOnly bool) ([]byte, error)
 
 	// GetLinesByStationID возвращает линии для станции (для hover логики)
 	GetLinesByStationID(ctx context.Context, stationID int64) ([]*domain.TransportLine, error)
@@ -58,13 +86,33 @@ type TransportRepository interface {
 
 	// GetNearestTransportByPriority возвращает ближайший транспорт с приоритетом по типу и расстоянию.
 	// Приоритет: metro/train -> bus/tram. Включает информацию о линиях.
-	GetNearestTransportByPriority(ctx context.Context, lat, lon float64, radiusM float64, limit int) ([]domain.NearestTransportWithLines, error)
+	// minPerMode резервирует слоты под виды транспорта (metro, train, tram, bus): ближайшие станции вида
+	// попадают в результат вне приоритета, остальные слоты заполняются по расстоянию; пустой - строгий приоритет.
+	GetNearestTransportByPriority(ctx context.Context, lat, lon float64, radiusM float64, limit int, minPerMode map[string]int) ([]domain.NearestTransportWithLines, error)
+
+	// GetSnappedDistances привязывает точку и станции (osm_id) к ближайшим пешеходным дорогам и возвращает
+	// составляющие расстояния до каждой станции. Станции дальше domain.SnappedDistanceMaxOffsetM от дорог
+	// в результат не попадают; пустой результат - точка запроса вне сети.
+	GetSnappedDistances(ctx context.Context, lat, lon float64, stationIDs []int64) (map[int64]domain.SnappedDistance, error)
+
+	// GetStationEntrances возвращает входы в метро (railway=subway_entrance) для станций по их osm_id.
+	// Вход привязывается к одной станции: с совпадающим названием, иначе к ближайшей в maxDistanceM.
+	GetStationEntrances(ctx context.Context, stationIDs []int64, maxDistanceM float64) (map[int64][]domain.StationEntrance, error)
 
 	// GetNearestTransportByPriorityBatch возвращает ближайший транспорт с приоритетом для множества точек.
-	// Один SQL запрос для всех точек с применением логики приоритизации.
+	// Один SQL запрос для всех точек с применением логики приоритизации; RadiusM точки перекрывает radiusM.
 	GetNearestTransportByPriorityBatch(ctx context.Context, points []domain.TransportSearchPoint, radiusM float64, limitPerPoint int) ([]domain.BatchTransportResult, error)
 
+	// GetTransportCoverage возвращает количество станций по типам (metro, train, tram, bus, ferry)
+	// для каждого радиуса из bands (метры) одним запросом.
+	GetTransportCoverage(ctx context.Context, lat, lon float64, bands []float64) (*domain.TransportCoverage, error)
+
 	// GetStationsInBBox возвращает станции транспорта в видимой области карты (bbox).
 	// Включает информацию о линиях.
 	GetStationsInBBox(ctx context.Context, swLat, swLon, neLat, neLon float64, types []string, limit, offset int) ([]domain.TransportStationWithLines, int, error)
+
+	// GetClusteredStations возвращает станции (metro, train, tram, bus) в радиусе radiusM с линиями и номером
+	// пространственного кластера: станции на расстоянии до clusterM друг от друга (по цепочке) - один кластер.
+	// Возвращаются все станции limit ближайших кластеров (<= 0 - 100 кластеров), упорядоченные по расстоянию.
+	GetClusteredStations(ctx context.Context, lat, lon, radiusM, clusterM float64, limit int) ([]domain.ClusteredStation, error)
 }
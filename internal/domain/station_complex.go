@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ClusteredStation - станция с номером пространственного кластера: станции ближе ClusterDistance
+// друг к другу (по цепочке) попадают в один кластер, как в ST_ClusterWithin
+type ClusteredStation struct {
+	NearestTransportWithLines
+	Cluster int `json:"-"`
+}
+
+// StationComplex - пересадочный узел: станции одного кластера с похожими названиями,
+// сгруппированные по виду транспорта, и объединение их линий
+type StationComplex struct {
+	ID             int64                                  `json:"id"`   // station_id главной станции
+	Name           string                                 `json:"name"` // название главной станции
+	Lat            float64                                `json:"lat"`  // центр станций комплекса
+	Lon            float64                                `json:"lon"`
+	DistanceM      float64                                `json:"distance"` // до ближайшей станции комплекса, метры
+	Modes          []string                               `json:"modes"`    // виды транспорта по приоритету (metro, train, tram, bus)
+	StationsByMode map[string][]NearestTransportWithLines `json:"stations_by_mode"`
+	Lines          []TransportLineInfo                    `json:"lines,omitempty"` // линии всех станций без повторов
+}
+
+// stationNameStopWords - служебные слова названий станций, не влияющие на сходство
+var stationNameStopWords = map[string]bool{
+	"station": true, "stop": true, "metro": true, "bus": true, "tram": true, "train": true,
+	"estació": true, "estacio": true, "estación": true, "estacion": true, "parada": true,
+	"rodalies": true, "cercanías": true, "cercanias": true, "renfe": true, "fgc": true, "adif": true,
+	"de": true, "del": true, "la": true, "el": true, "les": true, "los": true, "las": true, "d": true, "l": true,
+	"i": true, "y": true, "and": true, "the": true, "of": true,
+}
+
+// stationNameTokens - значимые слова названия в нижнем регистре
+func stationNameTokens(name string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make(map[string]bool, len(words))
+	for _, w := range words {
+		if !stationNameStopWords[w] {
+			tokens[w] = true
+		}
+	}
+	return tokens
+}
+
+// SimilarStationNames - названия одного узла: не меньше половины значимых слов более короткого
+// названия есть в другом ("Catalunya" и "Plaça Catalunya", "Passeig de Gràcia" и "Estació Passeig de Gràcia")
+func SimilarStationNames(a, b string) bool {
+	ta, tb := stationNameTokens(a), stationNameTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return false
+	}
+	if len(ta) > len(tb) {
+		ta, tb = tb, ta
+	}
+	common := 0
+	for w := range ta {
+		if tb[w] {
+			common++
+		}
+	}
+	return common*2 >= len(ta)
+}
+
+// transportModeRank - порядок вида в PriorityTransportModes, неизвестные виды - в конце
+func transportModeRank(mode string) int {
+	for i, m := range PriorityTransportModes() {
+		if m == mode {
+			return i
+		}
+	}
+	return len(PriorityTransportModes())
+}
+
+// BuildStationComplexes собирает комплексы: внутри пространственного кластера станции объединяются,
+// если их названия похожи (в том числе через цепочку). Главная станция - высшего вида, затем ближайшая.
+// Комплексы упорядочены по расстоянию до ближайшей станции.
+func BuildStationComplexes(stations []ClusteredStation) []StationComplex {
+	parent := make([]int, len(stations))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range stations {
+		for j := i + 1; j < len(stations); j++ {
+			if stations[i].Cluster == stations[j].Cluster && SimilarStationNames(stations[i].Name, stations[j].Name) {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	groups := make(map[int][]NearestTransportWithLines)
+	var roots []int
+	for i, s := range stations {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], s.NearestTransportWithLines)
+	}
+
+	complexes := make([]StationComplex, 0, len(roots))
+	for _, root := range roots {
+		complexes = append(complexes, newStationComplex(groups[root]))
+	}
+	sort.SliceStable(complexes, func(a, b int) bool {
+		return complexes[a].DistanceM < complexes[b].DistanceM
+	})
+	return complexes
+}
+
+// newStationComplex собирает комплекс из станций одной группы
+func newStationComplex(members []NearestTransportWithLines) StationComplex {
+	sort.SliceStable(members, func(a, b int) bool {
+		ra, rb := transportModeRank(members[a].Type), transportModeRank(members[b].Type)
+		if ra != rb {
+			return ra < rb
+		}
+		return members[a].DistanceM < members[b].DistanceM
+	})
+
+	main := members[0]
+	c := StationComplex{
+		ID:             main.StationID,
+		Name:           main.Name,
+		DistanceM:      main.DistanceM,
+		StationsByMode: make(map[string][]NearestTransportWithLines),
+	}
+
+	seenLines := make(map[int64]bool)
+	for _, s := range members {
+		c.Lat += s.Lat / float64(len(members))
+		c.Lon += s.Lon / float64(len(members))
+		if s.DistanceM < c.DistanceM {
+			c.DistanceM = s.DistanceM
+		}
+		if _, ok := c.StationsByMode[s.Type]; !ok {
+			c.Modes = append(c.Modes, s.Type)
+		}
+		c.StationsByMode[s.Type] = append(c.StationsByMode[s.Type], s)
+		for _, line := range s.Lines {
+			if !seenLines[line.ID] {
+				seenLines[line.ID] = true
+				c.Lines = append(c.Lines, line)
+			}
+		}
+	}
+	return c
+}
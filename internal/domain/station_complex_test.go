@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimilarStationNames(t *testing.T) {
+	assert.True(t, SimilarStationNames("Catalunya", "Plaça Catalunya"))
+	assert.True(t, SimilarStationNames("Passeig de Gràcia", "Estació Passeig de Gràcia"))
+	assert.True(t, SimilarStationNames("Sants Estació", "Estació de Sants"))
+	assert.False(t, SimilarStationNames("Catalunya", "Pelai"))
+	assert.False(t, SimilarStationNames("Metro", "Estació"), "only stop words")
+}
+
+func TestBuildStationComplexes(t *testing.T) {
+	station := func(id int64, name, mode string, distance float64, cluster int, lines ...int64) ClusteredStation {
+		s := ClusteredStation{Cluster: cluster}
+		s.StationID, s.Name, s.Type, s.DistanceM = id, name, mode, distance
+		s.Lat, s.Lon = 41.38, 2.17
+		for _, l := range lines {
+			s.Lines = append(s.Lines, TransportLineInfo{ID: l})
+		}
+		return s
+	}
+
+	complexes := BuildStationComplexes([]ClusteredStation{
+		station(1, "Catalunya", "bus", 80, 0, 100),
+		station(2, "Plaça Catalunya", "metro", 120, 0, 10, 11),
+		station(3, "Catalunya", "train", 150, 0, 20),
+		station(4, "Pelai", "bus", 90, 0, 100, 101),
+		station(5, "Catalunya", "bus", 700, 1),
+	})
+
+	require.Len(t, complexes, 3)
+
+	hub := complexes[0]
+	assert.Equal(t, int64(2), hub.ID, "metro station is the main one")
+	assert.Equal(t, "Plaça Catalunya", hub.Name)
+	assert.Equal(t, 80.0, hub.DistanceM)
+	assert.Equal(t, []string{"metro", "train", "bus"}, hub.Modes)
+	assert.Len(t, hub.StationsByMode["bus"], 1)
+	assert.Len(t, hub.Lines, 4)
+
+	assert.Equal(t, int64(4), complexes[1].ID, "same cluster, different name")
+	assert.Equal(t, int64(5), complexes[2].ID, "same name, different cluster")
+}
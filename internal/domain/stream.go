@@ -33,10 +33,20 @@ func (e *LocationEnrichEvent) HasStreetAddress() bool {
 
 // LocationDoneEvent - результат обогащения
 type LocationDoneEvent struct {
-	PropertyID       uuid.UUID         `json:"property_id"`
-	EnrichedLocation *EnrichedLocation `json:"enriched_location,omitempty"`
-	NearestTransport []NearestStation  `json:"nearest_transport,omitempty"`
-	Error            string            `json:"error,omitempty"`
+	PropertyID       uuid.UUID           `json:"property_id"`
+	Profile          string              `json:"profile,omitempty"` // профиль обогащения, определивший набор данных
+	EnrichedLocation *EnrichedLocation   `json:"enriched_location,omitempty"`
+	NearestTransport []NearestStation    `json:"nearest_transport,omitempty"`
+	Environment      *EnvironmentSummary `json:"environment,omitempty"`
+	NearestPOIs      []POIWithDistance   `json:"nearest_pois,omitempty"`
+	Error            string              `json:"error,omitempty"`
+}
+
+// EnvironmentSummary - экологические объекты рядом с локацией
+type EnvironmentSummary struct {
+	GreenSpaces []*GreenSpace `json:"green_spaces,omitempty"`
+	WaterBodies []*WaterBody  `json:"water_bodies,omitempty"`
+	Beaches     []*Beach      `json:"beaches,omitempty"`
 }
 
 // EnrichedLocation - обогащённые данные локации
@@ -44,9 +54,11 @@ type EnrichedLocation struct {
 	Country          *BoundaryInfo `json:"country,omitempty"`
 	Region           *BoundaryInfo `json:"region,omitempty"`
 	Province         *BoundaryInfo `json:"province,omitempty"`
+	Subprovince      *BoundaryInfo `json:"subprovince,omitempty"` // admin_level 7 (comarca), как Address.Subprovince
 	City             *BoundaryInfo `json:"city,omitempty"`
 	District         *BoundaryInfo `json:"district,omitempty"`
-	Neighborhood     *BoundaryInfo `json:"neighborhood,omitempty"`
+	Subdistrict      *BoundaryInfo `json:"subdistrict,omitempty"`  // admin_level 10, как Address.Subdistrict
+	Neighborhood     *BoundaryInfo `json:"neighborhood,omitempty"` // admin_level 11, как Address.Neighborhood
 	Street           *string       `json:"street,omitempty"`
 	HouseNumber      *string       `json:"house_number,omitempty"`
 	Latitude         *float64      `json:"latitude,omitempty"`
@@ -92,7 +104,7 @@ type NearestTransportWithLines struct {
 	Type      string              `json:"type"` // metro, train, tram, bus, ferry
 	Lat       float64             `json:"lat"`
 	Lon       float64             `json:"lon"`
-	Distance  float64             `json:"distance"` // метры
+	DistanceM float64             `json:"distance"` // метры
 	Lines     []TransportLineInfo `json:"lines,omitempty"`
 }
 
@@ -0,0 +1,70 @@
+package domain
+
+import "math"
+
+// TileMercatorMaxLat - широта края карты Web Mercator: тайлы покрывают только [-85.0511, 85.0511]
+const TileMercatorMaxLat = 85.0511287798066
+
+// TileCoord - координаты тайла z/x/y по схеме slippy map (y растет на юг)
+type TileCoord struct {
+	Z int `json:"z"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// TileRange - прямоугольник тайлов одного зума, границы включительно
+type TileRange struct {
+	Z    int
+	MinX int
+	MaxX int
+	MinY int
+	MaxY int
+}
+
+// Count возвращает число тайлов в прямоугольнике. На зуме 22 прямоугольник может содержать
+// до 2^44 тайлов: перед Tiles вызывающий код ограничивает ширину и высоту.
+func (r TileRange) Count() int {
+	return (r.MaxX - r.MinX + 1) * (r.MaxY - r.MinY + 1)
+}
+
+// Tiles перечисляет тайлы прямоугольника построчно: с севера на юг, с запада на восток
+func (r TileRange) Tiles() []TileCoord {
+	tiles := make([]TileCoord, 0, r.Count())
+	for y := r.MinY; y <= r.MaxY; y++ {
+		for x := r.MinX; x <= r.MaxX; x++ {
+			tiles = append(tiles, TileCoord{Z: r.Z, X: x, Y: y})
+		}
+	}
+	return tiles
+}
+
+// TileCover возвращает тайлы зума z, покрывающие bbox (EPSG:4326). Широты за краем
+// Web Mercator прижимаются к TileMercatorMaxLat. Граница bbox, совпадающая с границей тайла,
+// не добавляет соседний тайл: bbox тайла покрывается ровно этим тайлом. Координаты должны быть
+// конечными числами: NaN не приводится к номеру тайла.
+func TileCover(bbox BoundingBox, z int) TileRange {
+	n := math.Exp2(float64(z))
+	tileX := func(lon float64) float64 {
+		return (lon + 180) / 360 * n
+	}
+	tileY := func(lat float64) float64 {
+		lat = math.Max(math.Min(lat, TileMercatorMaxLat), -TileMercatorMaxLat)
+		rad := lat * math.Pi / 180
+		return (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n
+	}
+	clamp := func(v float64) int {
+		return int(math.Max(math.Min(v, n-1), 0))
+	}
+
+	r := TileRange{
+		Z:    z,
+		MinX: clamp(math.Floor(tileX(bbox.MinLon))),
+		MaxX: clamp(math.Ceil(tileX(bbox.MaxLon)) - 1),
+		MinY: clamp(math.Floor(tileY(bbox.MaxLat))),
+		MaxY: clamp(math.Ceil(tileY(bbox.MinLat)) - 1),
+	}
+	// Вырожденный bbox (точка или линия на границе тайлов) - один тайл
+	r.MaxX = max(r.MaxX, r.MinX)
+	r.MaxY = max(r.MaxY, r.MinY)
+	return r
+}
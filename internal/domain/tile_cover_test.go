@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileCover(t *testing.T) {
+	t.Run("whole world at zoom 0 and 1", func(t *testing.T) {
+		world := BoundingBox{MinLat: -90, MinLon: -180, MaxLat: 90, MaxLon: 180}
+
+		assert.Equal(t, TileRange{Z: 0}, TileCover(world, 0))
+		assert.Equal(t, TileRange{Z: 1, MaxX: 1, MaxY: 1}, TileCover(world, 1))
+	})
+
+	t.Run("barcelona at zoom 14", func(t *testing.T) {
+		r := TileCover(BoundingBox{MinLat: 41.38, MinLon: 2.15, MaxLat: 41.40, MaxLon: 2.18}, 14)
+
+		assert.Equal(t, TileRange{Z: 14, MinX: 8289, MaxX: 8291, MinY: 6118, MaxY: 6119}, r)
+		assert.Equal(t, 6, r.Count())
+	})
+
+	t.Run("tile edges do not add neighbours", func(t *testing.T) {
+		// Тайл 1/1/0 - северо-восточная четверть карты
+		r := TileCover(BoundingBox{MinLat: 0, MinLon: 0, MaxLat: TileMercatorMaxLat, MaxLon: 180}, 1)
+
+		assert.Equal(t, []TileCoord{{Z: 1, X: 1, Y: 0}}, r.Tiles())
+	})
+
+	t.Run("point is one tile", func(t *testing.T) {
+		r := TileCover(BoundingBox{MinLat: 41.39, MinLon: 2.16, MaxLat: 41.39, MaxLon: 2.16}, 14)
+
+		assert.Equal(t, 1, r.Count())
+		assert.Equal(t, TileCoord{Z: 14, X: 8290, Y: 6119}, r.Tiles()[0])
+	})
+
+	t.Run("tiles listed north to south, west to east", func(t *testing.T) {
+		r := TileRange{Z: 3, MinX: 1, MaxX: 2, MinY: 4, MaxY: 5}
+
+		assert.Equal(t, []TileCoord{
+			{Z: 3, X: 1, Y: 4}, {Z: 3, X: 2, Y: 4},
+			{Z: 3, X: 1, Y: 5}, {Z: 3, X: 2, Y: 5},
+		}, r.Tiles())
+	})
+}
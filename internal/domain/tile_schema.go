@@ -0,0 +1,32 @@
+package domain
+
+// TileAttributeType - тип значения атрибута объекта в MVT
+type TileAttributeType string
+
+const (
+	TileAttributeString  TileAttributeType = "string"
+	TileAttributeInteger TileAttributeType = "integer"
+	TileAttributeNumber  TileAttributeType = "number"
+)
+
+// Условия, при которых атрибут есть в тайле (пусто - всегда)
+const (
+	TileConditionWithLabels = "with_labels" // запрос с with_labels=true
+	TileConditionClustered  = "clustered"   // зум, на котором политика тайлов кластеризует точки
+)
+
+// TileAttributeSchema - атрибут объектов слоя MVT
+type TileAttributeSchema struct {
+	Name        string            `json:"name"`
+	Type        TileAttributeType `json:"type"`
+	Description string            `json:"description"`
+	Condition   string            `json:"condition,omitempty"`
+}
+
+// TileLayerSchema - слой MVT: имя слоя в тайле, тип геометрии (point, line, polygon) и атрибуты
+type TileLayerSchema struct {
+	Name       string                `json:"name"`
+	Geometry   string                `json:"geometry"`
+	MinZoom    *int                  `json:"min_zoom,omitempty"` // первый зум показа слоя по TileZoomPolicy; нет - слой вне политики
+	Attributes []TileAttributeSchema `json:"attributes"`
+}
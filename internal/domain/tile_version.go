@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TileETag возвращает ETag тайла. С версией данных - "v<version>-<md5>": по версии из ETag клиента
+// сервер отвечает 304, не генерируя тайл. Без версии - только хеш содержимого.
+func TileETag(dataVersion string, tile []byte) string {
+	if dataVersion == "" {
+		return fmt.Sprintf(`"%x"`, md5.Sum(tile))
+	}
+	return fmt.Sprintf(`"v%s-%x"`, dataVersion, md5.Sum(tile))
+}
+
+// TileETagVersion возвращает версию данных из ETag тайла (слабый W/ префикс допускается);
+// "" - ETag без версии или не от тайла
+func TileETagVersion(etag string) string {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return ""
+	}
+	etag = etag[1 : len(etag)-1]
+	if !strings.HasPrefix(etag, "v") {
+		return ""
+	}
+	sep := strings.LastIndexByte(etag, '-')
+	if sep <= 1 {
+		return ""
+	}
+	return etag[1:sep]
+}
+
+// TileDeltaStatus - результат сравнения версии тайла клиента с текущей
+type TileDeltaStatus string
+
+const (
+	// TileDeltaUnchanged - данные не менялись, закешированный тайл клиента актуален
+	TileDeltaUnchanged TileDeltaStatus = "unchanged"
+	// TileDeltaFull - дельта недоступна, тайл нужно запросить целиком
+	TileDeltaFull TileDeltaStatus = "full"
+	// TileDeltaPatch - в ответе изменения объектов (changes), которые клиент применяет к своему тайлу.
+	// Зарезервировано под пообъектный diff, сейчас не возвращается.
+	TileDeltaPatch TileDeltaStatus = "patch"
+)
+
+// TileFeatureChange - изменение объекта тайла для статуса patch: op - added, modified или removed;
+// для removed передается только слой и ID, для остальных - объект целиком в GeoJSON
+type TileFeatureChange struct {
+	Op        string          `json:"op"`
+	Layer     string          `json:"layer"`
+	FeatureID int64           `json:"feature_id"`
+	Feature   json.RawMessage `json:"feature,omitempty"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileETag(t *testing.T) {
+	tile := []byte("tile")
+
+	assert.NotContains(t, TileETag("", tile), "-")
+	assert.Regexp(t, `^"v2026-10-01-[0-9a-f]{32}"$`, TileETag("2026-10-01", tile))
+	assert.NotEqual(t, TileETag("2026-10-01", tile), TileETag("2026-10-01", []byte("other")))
+}
+
+func TestTileETagVersion(t *testing.T) {
+	etag := TileETag("2026-10-01", []byte("tile"))
+
+	assert.Equal(t, "2026-10-01", TileETagVersion(etag))
+	assert.Equal(t, "2026-10-01", TileETagVersion(" W/"+etag))
+	assert.Equal(t, "", TileETagVersion(TileETag("", []byte("tile"))))
+	assert.Equal(t, "", TileETagVersion(`"v-abc"`))
+	assert.Equal(t, "", TileETagVersion("v1-abc"), "unquoted")
+	assert.Equal(t, "", TileETagVersion(""))
+}
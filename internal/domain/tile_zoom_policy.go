@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TileLayer - слой векторных тайлов, видимость которого управляется TileZoomPolicy
+type TileLayer string
+
+const (
+	TileLayerBoundaries   TileLayer = "boundaries"
+	TileLayerGreenSpaces  TileLayer = "green_spaces"
+	TileLayerWater        TileLayer = "water"
+	TileLayerBeaches      TileLayer = "beaches"
+	TileLayerNoiseSources TileLayer = "noise_sources"
+	TileLayerTouristZones TileLayer = "tourist_zones"
+	TileLayerPOI          TileLayer = "pois"
+)
+
+// TileMaxZoom - максимальный зум, который покрывает политика
+const TileMaxZoom = 22
+
+// IsValidTileLayer проверяет, что слой известен сервису
+func IsValidTileLayer(l TileLayer) bool {
+	switch l {
+	case TileLayerBoundaries, TileLayerGreenSpaces, TileLayerWater, TileLayerBeaches,
+		TileLayerNoiseSources, TileLayerTouristZones, TileLayerPOI:
+		return true
+	}
+	return false
+}
+
+// TileZoomRule - содержимое тайлов для диапазона зумов [MinZoom, MaxZoom]
+type TileZoomRule struct {
+	MinZoom       int
+	MaxZoom       int
+	Layers        []TileLayer
+	ClusterPoints bool    // точечные слои группируются в кластеры
+	SimplifyPx    float64 // допуск упрощения геометрий в пикселях 256px тайла, 0 - без упрощения
+}
+
+// HasLayer возвращает true, если слой входит в правило
+func (r TileZoomRule) HasLayer(l TileLayer) bool {
+	for _, layer := range r.Layers {
+		if layer == l {
+			return true
+		}
+	}
+	return false
+}
+
+// TileZoomPolicy - единая политика содержимого тайлов по зумам: какие слои видны,
+// кластеризуются ли точки и с каким допуском упрощаются геометрии.
+// Правила покрывают зумы 0..TileMaxZoom без пропусков и пересечений.
+type TileZoomPolicy struct {
+	Rules []TileZoomRule
+}
+
+// DefaultTileZoomPolicy возвращает политику по умолчанию: шум с z10, туристические зоны с z11,
+// пляжи с z12; до z9 точки кластеризуются, до z11 полигоны упрощаются до пикселя.
+func DefaultTileZoomPolicy() TileZoomPolicy {
+	base := []TileLayer{TileLayerBoundaries, TileLayerGreenSpaces, TileLayerWater, TileLayerPOI}
+	withNoise := append(append([]TileLayer{}, base...), TileLayerNoiseSources)
+	withTourist := append(append([]TileLayer{}, withNoise...), TileLayerTouristZones)
+	all := append(append([]TileLayer{}, withTourist...), TileLayerBeaches)
+
+	return TileZoomPolicy{Rules: []TileZoomRule{
+		{MinZoom: 0, MaxZoom: 9, Layers: base, ClusterPoints: true, SimplifyPx: 1},
+		{MinZoom: 10, MaxZoom: 10, Layers: withNoise, SimplifyPx: 1},
+		{MinZoom: 11, MaxZoom: 11, Layers: withTourist, SimplifyPx: 1},
+		{MinZoom: 12, MaxZoom: TileMaxZoom, Layers: all},
+	}}
+}
+
+// RuleFor возвращает правило для зума. Для зума вне 0..TileMaxZoom возвращает false
+func (p TileZoomPolicy) RuleFor(z int) (TileZoomRule, bool) {
+	for _, rule := range p.Rules {
+		if z >= rule.MinZoom && z <= rule.MaxZoom {
+			return rule, true
+		}
+	}
+	return TileZoomRule{}, false
+}
+
+// LayerVisible возвращает true, если слой показывается на зуме z
+func (p TileZoomPolicy) LayerVisible(l TileLayer, z int) bool {
+	rule, ok := p.RuleFor(z)
+	return ok && rule.HasLayer(l)
+}
+
+// ClusterPoints возвращает true, если точки на зуме z кластеризуются
+func (p TileZoomPolicy) ClusterPoints(z int) bool {
+	rule, ok := p.RuleFor(z)
+	return ok && rule.ClusterPoints
+}
+
+// SimplifyPx возвращает допуск упрощения геометрий на зуме z в пикселях
+func (p TileZoomPolicy) SimplifyPx(z int) float64 {
+	rule, _ := p.RuleFor(z)
+	return rule.SimplifyPx
+}
+
+// LayerMinZoom возвращает первый зум, на котором слой показывается; false - слой не показывается
+func (p TileZoomPolicy) LayerMinZoom(l TileLayer) (int, bool) {
+	for _, rule := range p.Rules {
+		if rule.HasLayer(l) {
+			return rule.MinZoom, true
+		}
+	}
+	return 0, false
+}
+
+// WithLayerMinZooms возвращает копию политики, в которой каждый слой из minZooms показывается
+// ровно с зума minZooms[слой] и выше. Правило, внутри которого начинается слой, делится на два.
+func (p TileZoomPolicy) WithLayerMinZooms(minZooms map[TileLayer]int) TileZoomPolicy {
+	rules := make([]TileZoomRule, 0, len(p.Rules))
+	for _, rule := range p.Rules {
+		rule.Layers = append([]TileLayer(nil), rule.Layers...)
+		rules = append(rules, rule)
+	}
+
+	for layer, minZoom := range minZooms {
+		split := make([]TileZoomRule, 0, len(rules)+1)
+		for _, rule := range rules {
+			if rule.MinZoom < minZoom && minZoom <= rule.MaxZoom {
+				upper := rule
+				upper.MinZoom = minZoom
+				upper.Layers = append([]TileLayer(nil), rule.Layers...)
+				rule.MaxZoom = minZoom - 1
+				split = append(split, rule, upper)
+				continue
+			}
+			split = append(split, rule)
+		}
+
+		for i := range split {
+			layers := split[i].Layers[:0]
+			for _, l := range split[i].Layers {
+				if l != layer {
+					layers = append(layers, l)
+				}
+			}
+			if split[i].MinZoom >= minZoom {
+				layers = append(layers, layer)
+			}
+			split[i].Layers = layers
+		}
+		rules = split
+	}
+
+	return TileZoomPolicy{Rules: rules}
+}
+
+// ParseTileLayerMinZooms разбирает минимальные зумы слоев из конфига: слой -> зум 0..TileMaxZoom
+func ParseTileLayerMinZooms(raw map[string]string) (map[TileLayer]int, error) {
+	result := make(map[TileLayer]int, len(raw))
+	for name, value := range raw {
+		layer := TileLayer(strings.ToLower(strings.TrimSpace(name)))
+		if !IsValidTileLayer(layer) {
+			return nil, fmt.Errorf("tile layer min zoom: unknown layer %q", name)
+		}
+		zoom, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || zoom < 0 || zoom > TileMaxZoom {
+			return nil, fmt.Errorf("tile layer min zoom: invalid zoom %q for layer %q", value, name)
+		}
+		result[layer] = zoom
+	}
+	return result, nil
+}
+
+// ParseTileZoomPolicy разбирает политику вида
+// "0-9|boundaries,water,pois|cluster|simplify=1;10-22|boundaries,water,pois,beaches".
+// Пустая строка - политика по умолчанию.
+func ParseTileZoomPolicy(s string) (TileZoomPolicy, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultTileZoomPolicy(), nil
+	}
+
+	var rules []TileZoomRule
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, "|")
+		rule, err := parseTileZoomRange(parts[0])
+		if err != nil {
+			return TileZoomPolicy{}, err
+		}
+
+		if len(parts) > 1 {
+			for _, raw := range strings.Split(parts[1], ",") {
+				layer := TileLayer(strings.ToLower(strings.TrimSpace(raw)))
+				if layer == "" {
+					continue
+				}
+				if !IsValidTileLayer(layer) {
+					return TileZoomPolicy{}, fmt.Errorf("tile zoom policy %q: unknown layer %q", entry, raw)
+				}
+				rule.Layers = append(rule.Layers, layer)
+			}
+		}
+
+		for _, opt := range parts[min(len(parts), 2):] {
+			opt = strings.ToLower(strings.TrimSpace(opt))
+			switch {
+			case opt == "cluster":
+				rule.ClusterPoints = true
+			case strings.HasPrefix(opt, "simplify="):
+				px, err := strconv.ParseFloat(strings.TrimPrefix(opt, "simplify="), 64)
+				if err != nil || px < 0 {
+					return TileZoomPolicy{}, fmt.Errorf("tile zoom policy %q: invalid simplify %q", entry, opt)
+				}
+				rule.SimplifyPx = px
+			default:
+				return TileZoomPolicy{}, fmt.Errorf("tile zoom policy %q: unknown option %q", entry, opt)
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].MinZoom < rules[j].MinZoom })
+
+	// Правила должны покрывать 0..TileMaxZoom без пропусков и пересечений
+	next := 0
+	for _, rule := range rules {
+		if rule.MinZoom != next {
+			return TileZoomPolicy{}, fmt.Errorf("tile zoom policy: zoom %d is not covered or covered twice", next)
+		}
+		next = rule.MaxZoom + 1
+	}
+	if next != TileMaxZoom+1 {
+		return TileZoomPolicy{}, fmt.Errorf("tile zoom policy must cover zoom 0-%d", TileMaxZoom)
+	}
+
+	return TileZoomPolicy{Rules: rules}, nil
+}
+
+// parseTileZoomRange разбирает диапазон "min-max" или одиночный зум "z"
+func parseTileZoomRange(s string) (TileZoomRule, error) {
+	minRaw, maxRaw, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	if !isRange {
+		maxRaw = minRaw
+	}
+
+	minZoom, err := strconv.Atoi(strings.TrimSpace(minRaw))
+	if err != nil {
+		return TileZoomRule{}, fmt.Errorf("tile zoom policy: invalid zoom range %q", s)
+	}
+	maxZoom, err := strconv.Atoi(strings.TrimSpace(maxRaw))
+	if err != nil {
+		return TileZoomRule{}, fmt.Errorf("tile zoom policy: invalid zoom range %q", s)
+	}
+	if minZoom < 0 || maxZoom > TileMaxZoom || minZoom > maxZoom {
+		return TileZoomRule{}, fmt.Errorf("tile zoom policy: zoom range %q out of 0-%d", s, TileMaxZoom)
+	}
+
+	return TileZoomRule{MinZoom: minZoom, MaxZoom: maxZoom}, nil
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultTileZoomPolicy(t *testing.T) {
+	p := DefaultTileZoomPolicy()
+
+	assert.False(t, p.LayerVisible(TileLayerBeaches, 11))
+	assert.True(t, p.LayerVisible(TileLayerBeaches, 12))
+	assert.False(t, p.LayerVisible(TileLayerNoiseSources, 9))
+	assert.True(t, p.LayerVisible(TileLayerNoiseSources, 10))
+	assert.False(t, p.LayerVisible(TileLayerTouristZones, 10))
+	assert.True(t, p.LayerVisible(TileLayerTouristZones, 11))
+	assert.True(t, p.LayerVisible(TileLayerBoundaries, 0))
+	assert.False(t, p.LayerVisible(TileLayerBoundaries, TileMaxZoom+1))
+
+	assert.True(t, p.ClusterPoints(5))
+	assert.False(t, p.ClusterPoints(14))
+	assert.Equal(t, 1.0, p.SimplifyPx(8))
+	assert.Equal(t, 0.0, p.SimplifyPx(15))
+}
+
+func TestParseTileZoomPolicy(t *testing.T) {
+	p, err := ParseTileZoomPolicy("13-22|pois,Beaches ; 0-12|boundaries,pois|cluster|simplify=2.5")
+	assert.NoError(t, err)
+
+	assert.Len(t, p.Rules, 2)
+	assert.Equal(t, 0, p.Rules[0].MinZoom, "rules should be sorted by zoom")
+	assert.True(t, p.ClusterPoints(12))
+	assert.Equal(t, 2.5, p.SimplifyPx(3))
+	assert.True(t, p.LayerVisible(TileLayerBeaches, 13))
+	assert.False(t, p.LayerVisible(TileLayerBoundaries, 13))
+
+	empty, err := ParseTileZoomPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultTileZoomPolicy(), empty)
+}
+
+func TestParseTileZoomPolicyErrors(t *testing.T) {
+	for name, raw := range map[string]string{
+		"unknown layer":  "0-22|roads",
+		"gap":            "0-9|pois;11-22|pois",
+		"overlap":        "0-10|pois;10-22|pois",
+		"not to max":     "0-18|pois",
+		"bad range":      "9-3|pois;0-22|pois",
+		"bad simplify":   "0-22|pois|simplify=-1",
+		"unknown option": "0-22|pois|fast",
+	} {
+		_, err := ParseTileZoomPolicy(raw)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestTileZoomPolicyWithLayerMinZooms(t *testing.T) {
+	p := DefaultTileZoomPolicy().WithLayerMinZooms(map[TileLayer]int{
+		TileLayerBeaches:      14,
+		TileLayerNoiseSources: 5,
+	})
+
+	assert.False(t, p.LayerVisible(TileLayerBeaches, 13))
+	assert.True(t, p.LayerVisible(TileLayerBeaches, 14))
+	assert.False(t, p.LayerVisible(TileLayerNoiseSources, 4))
+	assert.True(t, p.LayerVisible(TileLayerNoiseSources, 5))
+	assert.True(t, p.LayerVisible(TileLayerNoiseSources, 9))
+
+	// Остальное содержимое разделенных правил сохраняется
+	assert.True(t, p.ClusterPoints(4))
+	assert.True(t, p.ClusterPoints(5))
+	assert.False(t, p.ClusterPoints(10))
+	assert.True(t, p.LayerVisible(TileLayerTouristZones, 11))
+	assert.True(t, p.LayerVisible(TileLayerPOI, 13))
+
+	minZoom, ok := p.LayerMinZoom(TileLayerBeaches)
+	assert.True(t, ok)
+	assert.Equal(t, 14, minZoom)
+
+	// Исходная политика не меняется
+	assert.True(t, DefaultTileZoomPolicy().LayerVisible(TileLayerBeaches, 12))
+}
+
+func TestParseTileLayerMinZooms(t *testing.T) {
+	minZooms, err := ParseTileLayerMinZooms(map[string]string{"Beaches": "13", "noise_sources": " 9"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[TileLayer]int{TileLayerBeaches: 13, TileLayerNoiseSources: 9}, minZooms)
+
+	for name, raw := range map[string]map[string]string{
+		"unknown layer": {"roads": "10"},
+		"not a number":  {"beaches": "high"},
+		"out of range":  {"beaches": "23"},
+	} {
+		_, err := ParseTileLayerMinZooms(raw)
+		assert.Error(t, err, name)
+	}
+}
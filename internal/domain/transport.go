@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type TransportStation struct {
 	ID         int64             `json:"id" db:"id"`
@@ -10,7 +13,7 @@ type TransportStation struct {
 	Type       string            `json:"type" db:"type"`
 	Lat        float64           `json:"lat" db:"lat"`
 	Lon        float64           `json:"lon" db:"lon"`
-	Distance   *float64          `json:"distance,omitempty" db:"distance"` // Дистанция в метрах от точки запроса
+	DistanceM  *float64          `json:"distance,omitempty" db:"distance"` // Дистанция в метрах от точки запроса
 	Geometry   []byte            `json:"-" db:"geometry"`
 	LineIDs    []int64           `json:"line_ids" db:"line_ids"`
 	Operator   *string           `json:"operator,omitempty" db:"operator"`
@@ -40,6 +43,16 @@ type TransportLine struct {
 	UpdatedAt   time.Time         `json:"updated_at" db:"updated_at"`
 }
 
+// TransportLineGeometry - геометрия транспортной линии в формате GeoJSON (EPSG:4326)
+type TransportLineGeometry struct {
+	LineID   int64           `json:"line_id" db:"line_id"`
+	Name     string          `json:"name" db:"name"`
+	Ref      string          `json:"ref" db:"ref"`
+	Type     string          `json:"type" db:"type"`
+	Color    *string         `json:"color,omitempty" db:"color"`
+	Geometry json.RawMessage `json:"geometry" db:"geometry"`
+}
+
 // TransportStationWithLines - станция транспорта с информацией о линиях
 // Используется для batch-запросов обогащения
 type TransportStationWithLines struct {
@@ -48,7 +61,7 @@ type TransportStationWithLines struct {
 	Type      string              `json:"type" db:"type"`
 	Lat       float64             `json:"lat" db:"lat"`
 	Lon       float64             `json:"lon" db:"lon"`
-	Distance  float64             `json:"distance" db:"distance"`   // расстояние от точки запроса в метрах
+	DistanceM float64             `json:"distance" db:"distance"`   // расстояние от точки запроса в метрах
 	PointIdx  int                 `json:"point_idx" db:"point_idx"` // индекс точки из batch запроса
 	Lines     []TransportLineInfo `json:"lines,omitempty"`          // TransportLineInfo определён в stream.go
 }
@@ -65,4 +78,58 @@ type TransportSearchPoint struct {
 	Lon   float64  `json:"lon"`
 	Types []string `json:"types"`
 	Limit int      `json:"limit"`
+	// RadiusM - радиус поиска для точки в метрах; 0 - общий радиус запроса
+	RadiusM float64 `json:"radius_m,omitempty"`
+}
+
+// TransportCoverage - покрытие транспортом вокруг точки по вложенным радиусам
+type TransportCoverage struct {
+	Lat   float64                 `json:"lat"`
+	Lon   float64                 `json:"lon"`
+	Bands []TransportCoverageBand `json:"bands"` // по возрастанию радиуса
+}
+
+// TransportCoverageBand - количество станций по типам (metro, train, tram, bus, ferry) в пределах радиуса
+type TransportCoverageBand struct {
+	RadiusM float64        `json:"radius_m"`
+	Counts  map[string]int `json:"counts"`
+	Total   int            `json:"total"`
+}
+
+// DistanceMode - способ расчета пешего расстояния до станции
+type DistanceMode string
+
+const (
+	// DistanceModeStraight - по прямой с поправкой на обход (по умолчанию)
+	DistanceModeStraight DistanceMode = "straight"
+	// DistanceModeSnapped - точка и станция привязываются к ближайшим пешеходным дорогам:
+	// подход к дороге + путь между точками привязки + отход от дороги к станции
+	DistanceModeSnapped DistanceMode = "snapped"
+)
+
+// SnappedDistanceMaxOffsetM - дальше этого от пешеходной дороги точка не привязывается к сети,
+// расстояние до станции остается прямым
+const SnappedDistanceMaxOffsetM = 300.0
+
+// SnappedDistance - расстояние до станции через привязку к пешеходной сети дорог
+type SnappedDistance struct {
+	OriginOffsetM  float64 // от точки запроса до ближайшей пешеходной дороги
+	NetworkM       float64 // между точками привязки: вдоль общей дороги или по прямой
+	StationOffsetM float64 // от ближайшей к станции пешеходной дороги до станции
+	// AlongNetwork - NetworkM измерен вдоль общей дороги или прямая идет по коридору пешеходных дорог;
+	// иначе это прямая, и обход кварталов не учтен
+	AlongNetwork bool
+}
+
+// StationEntranceMaxDistanceM - максимальное расстояние от станции метро до ее входа (railway=subway_entrance)
+const StationEntranceMaxDistanceM = 300.0
+
+// StationEntrance - вход в метро (railway=subway_entrance), привязанный к станции
+type StationEntrance struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name,omitempty"`
+	Ref       string  `json:"ref,omitempty"` // номер/буква выхода из тега ref
+	Lat       float64 `json:"lat"`
+	Lon       float64 `json:"lon"`
+	DistanceM float64 `json:"distance"` // метры от станции
 }
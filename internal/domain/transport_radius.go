@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// AdaptiveTransportRadius - радиус поиска ближайшего транспорта по плотности станций.
+// В центре города станций много, и фиксированный радиус дает шумный результат, в пригороде -
+// слишком мало станций. Радиус подбирается так, чтобы при равномерной плотности, измеренной
+// в пробном радиусе, в него попало около TargetCount станций.
+type AdaptiveTransportRadius struct {
+	ProbeRadiusM float64 // радиус пробного подсчета станций, метры
+	TargetCount  int     // желаемое число станций в результате
+	MinRadiusM   float64
+	MaxRadiusM   float64
+}
+
+// Validate проверяет, что параметры согласованы
+func (a AdaptiveTransportRadius) Validate() error {
+	if a.ProbeRadiusM <= 0 {
+		return fmt.Errorf("adaptive transport radius: probe radius must be positive")
+	}
+	if a.TargetCount <= 0 {
+		return fmt.Errorf("adaptive transport radius: target count must be positive")
+	}
+	if a.MinRadiusM <= 0 || a.MaxRadiusM < a.MinRadiusM {
+		return fmt.Errorf("adaptive transport radius: need 0 < min radius <= max radius")
+	}
+	return nil
+}
+
+// RadiusFor возвращает радиус в метрах по числу станций в пробном радиусе.
+// Число станций растет с площадью круга, поэтому радиус масштабируется как корень
+// из отношения целевого числа к найденному; без станций - максимальный радиус.
+func (a AdaptiveTransportRadius) RadiusFor(probeCount int) float64 {
+	if probeCount <= 0 {
+		return a.MaxRadiusM
+	}
+	radius := a.ProbeRadiusM * math.Sqrt(float64(a.TargetCount)/float64(probeCount))
+	return math.Min(math.Max(radius, a.MinRadiusM), a.MaxRadiusM)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveTransportRadius(t *testing.T) {
+	policy := AdaptiveTransportRadius{ProbeRadiusM: 1000, TargetCount: 10, MinRadiusM: 400, MaxRadiusM: 3000}
+	assert.NoError(t, policy.Validate())
+
+	assert.InDelta(t, 1000, policy.RadiusFor(10), 1e-9)
+	assert.InDelta(t, 500, policy.RadiusFor(40), 1e-9)
+	assert.Equal(t, 400.0, policy.RadiusFor(1000), "dense center clamps to min")
+	assert.Equal(t, 3000.0, policy.RadiusFor(1), "sparse suburb clamps to max")
+	assert.Equal(t, 3000.0, policy.RadiusFor(0))
+
+	assert.Error(t, AdaptiveTransportRadius{ProbeRadiusM: 1000, TargetCount: 10, MinRadiusM: 500, MaxRadiusM: 400}.Validate())
+	assert.Error(t, AdaptiveTransportRadius{ProbeRadiusM: 1000, MinRadiusM: 400, MaxRadiusM: 3000}.Validate())
+}
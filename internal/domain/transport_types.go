@@ -59,6 +59,16 @@ func ValidTransportTypes() []string {
 	}
 }
 
+// PriorityTransportModes returns transport modes ranked by priority transport search (metro > train > tram > bus)
+func PriorityTransportModes() []string {
+	return []string{
+		TransportTypeMetro,
+		TransportTypeTrain,
+		TransportTypeTram,
+		TransportTypeBus,
+	}
+}
+
 // IsValidTransportType checks if transport type is valid
 func IsValidTransportType(transportType string) bool {
 	validTypes := ValidTransportTypes()
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypeLabels - подписи внутренних кодов типов (вид станции metro/bus/tram, категория и подкатегория POI)
+// на языках клиентов: язык -> код -> подпись
+type TypeLabels map[string]map[string]string
+
+// ParseTypeLabels разбирает подписи из конфига: язык -> записи "код:подпись"
+// ("es" -> ["metro:Metro", "bus:Autobús"]). Язык проверяется как в ParseNameLanguages.
+func ParseTypeLabels(raw map[string][]string) (TypeLabels, error) {
+	labels := make(TypeLabels, len(raw))
+	for lang, entries := range raw {
+		if !nameLanguagePattern.MatchString(lang) {
+			return nil, fmt.Errorf("invalid type label language %q", lang)
+		}
+		byCode := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			code, label, ok := strings.Cut(entry, ":")
+			code, label = strings.TrimSpace(code), strings.TrimSpace(label)
+			if !ok || code == "" || label == "" {
+				return nil, fmt.Errorf("type label for %s must be code:label, got %q", lang, entry)
+			}
+			byCode[code] = label
+		}
+		labels[lang] = byCode
+	}
+	return labels, nil
+}
+
+// Label возвращает подпись кода на языке lang; без подписи - сам код
+func (l TypeLabels) Label(lang, code string) string {
+	if label := l[lang][code]; label != "" {
+		return label
+	}
+	return code
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypeLabels(t *testing.T) {
+	labels, err := ParseTypeLabels(map[string][]string{
+		"es": {"metro:Metro", "bus: Autobús", "healthcare:Salud"},
+		"ru": {"metro:Метро"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Autobús", labels.Label("es", "bus"))
+	assert.Equal(t, "Метро", labels.Label("ru", "metro"))
+	assert.Equal(t, "bus", labels.Label("ru", "bus"), "no label for code")
+	assert.Equal(t, "tram", labels.Label("de", "tram"), "no labels for language")
+	assert.Equal(t, "metro", TypeLabels(nil).Label("es", "metro"))
+}
+
+func TestParseTypeLabels_Invalid(t *testing.T) {
+	for _, raw := range []map[string][]string{
+		{"Spanish": {"metro:Metro"}},
+		{"es": {"metro"}},
+		{"es": {"metro:"}},
+		{"es": {":Metro"}},
+	} {
+		_, err := ParseTypeLabels(raw)
+		assert.Error(t, err, raw)
+	}
+}
@@ -0,0 +1,205 @@
+// Package addressparser разбирает адрес одной строкой ("Carrer de Mallorca 401, 08013 Barcelona, España")
+// на структурированные поля для обогащения. Разбор эвристический: части разделяются запятыми,
+// почтовый индекс и номер дома узнаются по цифрам, улица - по номеру дома или типу улицы,
+// остальные части считаются административными уровнями от детального к стране.
+package addressparser
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Address - результат разбора; пустое поле - часть не распознана
+type Address struct {
+	Street       string `json:"street,omitempty"`
+	HouseNumber  string `json:"house_number,omitempty"`
+	PostalCode   string `json:"postal_code,omitempty"`
+	Neighborhood string `json:"neighborhood,omitempty"`
+	District     string `json:"district,omitempty"`
+	City         string `json:"city,omitempty"`
+	Province     string `json:"province,omitempty"`
+	Country      string `json:"country,omitempty"`
+}
+
+var (
+	// postalCodeRe - индекс отдельной частью или рядом с городом: "08013", "08013 Barcelona", "Madrid 28001"
+	postalCodeRe = regexp.MustCompile(`^(?:(\d{4,5})\s+(.+)|(.+?)\s+(\d{4,5})|(\d{4,5}))$`)
+	// houseNumberRe - номер дома: "401", "12B", "12-14", "s/n"
+	houseNumberRe = regexp.MustCompile(`^(?i:\d{1,4}(?:\s?[a-z])?(?:\s?-\s?\d{1,4})?|s/?n)$`)
+	// streetNumberEndRe, streetNumberStartRe - улица с номером в конце ("Calle Mayor 5") или в начале ("221B Baker Street")
+	streetNumberEndRe   = regexp.MustCompile(`^(.*\D)\s+(\d{1,4}(?:\s?[a-zA-Z])?(?:\s?-\s?\d{1,4})?)$`)
+	streetNumberStartRe = regexp.MustCompile(`^(\d{1,4}[a-zA-Z]?)\s+(\D.*)$`)
+)
+
+// streetTypes - типы улиц, по которым часть без номера узнается как улица
+var streetTypes = map[string]bool{
+	"calle": true, "c/": true, "avenida": true, "avda": true, "av": true, "plaza": true, "paseo": true,
+	"camino": true, "carretera": true, "ronda": true, "travesia": true, "travesía": true,
+	"carrer": true, "avinguda": true, "plaça": true, "passeig": true, "rambla": true, "via": true,
+	"street": true, "st": true, "avenue": true, "ave": true, "road": true, "rd": true, "lane": true,
+	"boulevard": true, "blvd": true, "square": true, "drive": true,
+	"rue": true, "place": true, "allée": true,
+	"rua": true, "praça": true, "viale": true, "piazza": true, "corso": true,
+	"straße": true, "strasse": true, "platz": true, "weg": true,
+	"улица": true, "ул": true, "проспект": true, "пр-т": true, "площадь": true, "переулок": true,
+	"вулиця": true, "вул": true,
+}
+
+// Parse разбирает адрес. Административные части после улицы распределяются с конца: последняя -
+// страна, перед ней провинция, затем город; если индекс указан рядом с названием ("08013 Barcelona"),
+// это название - город, части между улицей и городом - район и квартал.
+func Parse(raw string) Address {
+	return ParseWithCountry(raw, "")
+}
+
+// ParseWithCountry разбирает адрес с подсказкой страны. Если последняя часть не совпадает
+// с подсказкой (без учета регистра), страны в строке нет: последняя часть - город или регион,
+// Country - подсказка. Пустая подсказка - как Parse.
+func ParseWithCountry(raw, country string) Address {
+	var addr Address
+
+	var parts []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.Join(strings.Fields(part), " ")
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	var admin []string
+	cityIndex := -1
+	for i, part := range parts {
+		if addr.PostalCode == "" {
+			if code, name, ok := splitPostalCode(part); ok {
+				addr.PostalCode = code
+				if name == "" {
+					continue
+				}
+				part = name
+				cityIndex = len(admin)
+			}
+		}
+
+		if addr.Street == "" && i <= 1 && cityIndex < 0 {
+			if street, number, ok := splitStreet(part); ok {
+				addr.Street, addr.HouseNumber = street, number
+				continue
+			}
+		}
+		if addr.Street != "" && addr.HouseNumber == "" && len(admin) == 0 && houseNumberRe.MatchString(part) {
+			addr.HouseNumber = part
+			continue
+		}
+
+		admin = append(admin, part)
+	}
+
+	country = strings.Join(strings.Fields(country), " ")
+	if country != "" && (len(admin) == 0 || !strings.EqualFold(admin[len(admin)-1], country)) {
+		if len(admin) == 0 {
+			addr.Country = country
+			return addr
+		}
+		// Страна из подсказки занимает место последней части: части строки сдвигаются к городу
+		admin = append(admin, country)
+	}
+
+	assignAdmin(&addr, admin, cityIndex)
+	return addr
+}
+
+// splitPostalCode выделяет индекс из части; name - название рядом с индексом (обычно город)
+func splitPostalCode(part string) (code, name string, ok bool) {
+	m := postalCodeRe.FindStringSubmatch(part)
+	switch {
+	case m == nil:
+		return "", "", false
+	case m[1] != "" && hasLetters(m[2]):
+		return m[1], m[2], true
+	case m[4] != "" && hasLetters(m[3]) && !isStreet(m[3]):
+		return m[4], m[3], true
+	case m[5] != "":
+		return m[5], "", true
+	}
+	return "", "", false
+}
+
+// splitStreet узнает улицу: с номером дома в начале или в конце, либо по типу улицы без номера
+func splitStreet(part string) (street, number string, ok bool) {
+	if m := streetNumberEndRe.FindStringSubmatch(part); m != nil && hasLetters(m[1]) {
+		return strings.TrimSpace(strings.TrimRight(m[1], " ,")), m[2], true
+	}
+	if m := streetNumberStartRe.FindStringSubmatch(part); m != nil && hasLetters(m[2]) {
+		return m[2], m[1], true
+	}
+	if isStreet(part) {
+		return part, "", true
+	}
+	return "", "", false
+}
+
+// isStreet проверяет, начинается или заканчивается ли часть типом улицы ("Calle Mayor", "Baker Street")
+func isStreet(part string) bool {
+	words := strings.Fields(strings.ToLower(part))
+	if len(words) < 2 {
+		return false
+	}
+	first := strings.TrimSuffix(words[0], ".")
+	last := strings.TrimSuffix(words[len(words)-1], ".")
+	return streetTypes[first] || streetTypes[last]
+}
+
+// assignAdmin распределяет административные части. С известным городом (cityIndex) части до него -
+// квартал и район, после - провинция и страна; без него части назначаются с конца.
+func assignAdmin(addr *Address, admin []string, cityIndex int) {
+	if cityIndex >= 0 && cityIndex < len(admin) {
+		addr.City = admin[cityIndex]
+		assignBefore(addr, admin[:cityIndex])
+
+		after := admin[cityIndex+1:]
+		switch len(after) {
+		case 0:
+		case 1:
+			addr.Country = after[0]
+		default:
+			addr.Province = after[len(after)-2]
+			addr.Country = after[len(after)-1]
+		}
+		return
+	}
+
+	switch n := len(admin); n {
+	case 0:
+	case 1:
+		addr.City = admin[0]
+	case 2:
+		addr.City, addr.Country = admin[0], admin[1]
+	default:
+		addr.Country = admin[n-1]
+		addr.Province = admin[n-2]
+		addr.City = admin[n-3]
+		assignBefore(addr, admin[:n-3])
+	}
+}
+
+// assignBefore назначает части между улицей и городом: ближайшая к городу - район, перед ней - квартал
+func assignBefore(addr *Address, parts []string) {
+	switch n := len(parts); n {
+	case 0:
+	case 1:
+		addr.District = parts[0]
+	default:
+		addr.District = parts[n-1]
+		addr.Neighborhood = parts[n-2]
+	}
+}
+
+func hasLetters(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
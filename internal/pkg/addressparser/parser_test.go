@@ -0,0 +1,128 @@
+package addressparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want Address
+	}{
+		{
+			name: "street with number, postcode next to city",
+			raw:  "Carrer de Mallorca 401, 08013 Barcelona, España",
+			want: Address{Street: "Carrer de Mallorca", HouseNumber: "401", PostalCode: "08013", City: "Barcelona", Country: "España"},
+		},
+		{
+			name: "house number as separate part, province after city",
+			raw:  "Calle Mayor, 5, 28013 Madrid, Comunidad de Madrid, Spain",
+			want: Address{Street: "Calle Mayor", HouseNumber: "5", PostalCode: "28013", City: "Madrid", Province: "Comunidad de Madrid", Country: "Spain"},
+		},
+		{
+			name: "postcode after city, district before it",
+			raw:  "Sant Gervasi, Barcelona 08022",
+			want: Address{PostalCode: "08022", District: "Sant Gervasi", City: "Barcelona"},
+		},
+		{
+			name: "standalone postcode",
+			raw:  "Avinguda Diagonal 640, 08017, Barcelona, Spain",
+			want: Address{Street: "Avinguda Diagonal", HouseNumber: "640", PostalCode: "08017", City: "Barcelona", Country: "Spain"},
+		},
+		{
+			name: "number before street",
+			raw:  "221B Baker Street, London, United Kingdom",
+			want: Address{Street: "Baker Street", HouseNumber: "221B", City: "London", Country: "United Kingdom"},
+		},
+		{
+			name: "no street, admin parts from the end",
+			raw:  "Vila de Gràcia, Gràcia, Barcelona, Barcelona, Spain",
+			want: Address{Neighborhood: "Vila de Gràcia", District: "Gràcia", City: "Barcelona", Province: "Barcelona", Country: "Spain"},
+		},
+		{
+			name: "street without number recognized by street type",
+			raw:  "Plaza Mayor, Salamanca, Spain",
+			want: Address{Street: "Plaza Mayor", City: "Salamanca", Country: "Spain"},
+		},
+		{
+			name: "four digit street number is not a postcode",
+			raw:  "Gran Via 1234, Madrid, Spain",
+			want: Address{Street: "Gran Via", HouseNumber: "1234", City: "Madrid", Country: "Spain"},
+		},
+		{
+			name: "extra whitespace and empty parts",
+			raw:  "  Calle  Mayor   5 ,, Madrid ,  ",
+			want: Address{Street: "Calle Mayor", HouseNumber: "5", City: "Madrid"},
+		},
+		{
+			name: "single city",
+			raw:  "Valencia",
+			want: Address{City: "Valencia"},
+		},
+		{
+			name: "empty",
+			raw:  " , ",
+			want: Address{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.raw))
+		})
+	}
+}
+
+func TestParseWithCountry(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		country string
+		want    Address
+	}{
+		{
+			name:    "last part is not a country when the hint differs",
+			raw:     "Calle Mayor 5, Chamberí, Madrid",
+			country: "Spain",
+			want:    Address{Street: "Calle Mayor", HouseNumber: "5", City: "Chamberí", Province: "Madrid", Country: "Spain"},
+		},
+		{
+			name:    "single admin part is the city",
+			raw:     "Calle Mayor 5, Madrid",
+			country: "Spain",
+			want:    Address{Street: "Calle Mayor", HouseNumber: "5", City: "Madrid", Country: "Spain"},
+		},
+		{
+			name:    "province after postcode city",
+			raw:     "Calle Mayor 5, 28013 Madrid, Comunidad de Madrid",
+			country: "Spain",
+			want:    Address{Street: "Calle Mayor", HouseNumber: "5", PostalCode: "28013", City: "Madrid", Province: "Comunidad de Madrid", Country: "Spain"},
+		},
+		{
+			name:    "country in the string matches the hint",
+			raw:     "Calle Mayor 5, Madrid, spain",
+			country: "Spain",
+			want:    Address{Street: "Calle Mayor", HouseNumber: "5", City: "Madrid", Country: "spain"},
+		},
+		{
+			name:    "street only",
+			raw:     "Calle Mayor 5",
+			country: "Spain",
+			want:    Address{Street: "Calle Mayor", HouseNumber: "5", Country: "Spain"},
+		},
+		{
+			name: "empty hint parses as Parse",
+			raw:  "Calle Mayor 5, Madrid, Spain",
+			want: Address{Street: "Calle Mayor", HouseNumber: "5", City: "Madrid", Country: "Spain"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseWithCountry(tt.raw, tt.country))
+		})
+	}
+}
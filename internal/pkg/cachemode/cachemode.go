@@ -0,0 +1,47 @@
+// Package cachemode передает через context режим кеширования запроса: middleware Cache определяет его
+// по настройке эндпоинта и заголовку Cache-Control, а кеш (Redis и in-process) его соблюдает.
+package cachemode
+
+import "context"
+
+// Mode - режим кеширования запроса
+type Mode int
+
+const (
+	// Default - кеш читается и пополняется
+	Default Mode = iota
+	// Refresh - кеш не читается, но пополняется свежим результатом (Cache-Control: no-cache)
+	Refresh
+	// Disabled - кеш не читается и не пополняется (кеш эндпоинта выключен конфигом)
+	Disabled
+)
+
+type contextKey struct{}
+
+// ContextKey - ключ режима в context; fiber middleware кладет режим в user value запроса
+// (c.Context().SetUserValue), и он виден через context, который handler передает в use case
+var ContextKey = contextKey{}
+
+// WithMode возвращает context с режимом кеширования
+func WithMode(ctx context.Context, mode Mode) context.Context {
+	return context.WithValue(ctx, ContextKey, mode)
+}
+
+// FromContext возвращает режим кеширования запроса; без режима - Default
+func FromContext(ctx context.Context) Mode {
+	if ctx == nil {
+		return Default
+	}
+	mode, _ := ctx.Value(ContextKey).(Mode)
+	return mode
+}
+
+// CanRead сообщает, можно ли отдать ответ из кеша
+func CanRead(ctx context.Context) bool {
+	return FromContext(ctx) == Default
+}
+
+// CanWrite сообщает, можно ли сохранить результат в кеш
+func CanWrite(ctx context.Context) bool {
+	return FromContext(ctx) != Disabled
+}
@@ -68,6 +68,42 @@ var (
 		"Invalid transport type",
 		http.StatusBadRequest,
 	)
+
+	ErrBatchTooLarge = New(
+		"BATCH_TOO_LARGE",
+		"Batch size exceeds the allowed maximum",
+		http.StatusRequestEntityTooLarge,
+	)
+
+	ErrRateLimited = New(
+		"RATE_LIMITED",
+		"Too many requests, retry after the interval in Retry-After",
+		http.StatusTooManyRequests,
+	)
+
+	ErrTileLayerDisabled = New(
+		"TILE_LAYER_DISABLED",
+		"Tile layer is not shown at this zoom level",
+		http.StatusNotFound,
+	)
+
+	ErrPayloadTooLarge = New(
+		"PAYLOAD_TOO_LARGE",
+		"Request body exceeds the allowed size",
+		http.StatusRequestEntityTooLarge,
+	)
+
+	ErrJSONTooDeep = New(
+		"JSON_TOO_DEEP",
+		"Request body JSON is nested too deeply",
+		http.StatusBadRequest,
+	)
+
+	ErrTimezoneUnavailable = New(
+		"TIMEZONE_UNAVAILABLE",
+		"No timezone data for the coordinate",
+		http.StatusNotFound,
+	)
 )
 
 const (
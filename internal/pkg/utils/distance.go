@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// DistanceUnit - единица расстояний в ответах (параметр distance_unit)
+type DistanceUnit string
+
+const (
+	DistanceUnitMeters     DistanceUnit = "m"
+	DistanceUnitKilometers DistanceUnit = "km"
+)
+
+// distanceScales - множители округления расстояний по единицам, задаются ConfigureDistancePrecision.
+// По умолчанию: метры - 2 знака (1 см), километры - 3 знака (1 м).
+var distanceScales = map[DistanceUnit]float64{
+	DistanceUnitMeters:     math.Pow10(2),
+	DistanceUnitKilometers: math.Pow10(3),
+}
+
+// ConfigureDistancePrecision задает число знаков после запятой для расстояний по единицам:
+// "m" и "km" -> 0-6. Единицы без значения сохраняют точность по умолчанию.
+func ConfigureDistancePrecision(decimals map[string]string) error {
+	for unit, raw := range decimals {
+		u, ok := ParseDistanceUnit(unit)
+		if !ok || unit == "" {
+			return fmt.Errorf("unknown distance unit %q", unit)
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 || n > 6 {
+			return fmt.Errorf("distance precision for %s must be between 0 and 6, got %q", unit, raw)
+		}
+		distanceScales[u] = math.Pow10(n)
+	}
+	return nil
+}
+
+// ParseDistanceUnit разбирает distance_unit; пусто - метры
+func ParseDistanceUnit(s string) (DistanceUnit, bool) {
+	switch DistanceUnit(s) {
+	case "", DistanceUnitMeters:
+		return DistanceUnitMeters, true
+	case DistanceUnitKilometers:
+		return DistanceUnitKilometers, true
+	}
+	return "", false
+}
+
+// FromMeters переводит расстояние в метрах в единицу u и округляет до настроенной точности.
+// Применяется только при формировании ответа: расчеты идут в метрах на полной точности.
+func (u DistanceUnit) FromMeters(meters float64) float64 {
+	if u == DistanceUnitKilometers {
+		meters /= 1000
+	} else {
+		u = DistanceUnitMeters
+	}
+	scale := distanceScales[u]
+	return math.Round(meters*scale) / scale
+}
@@ -1,9 +1,33 @@
 package utils
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 const earthRadiusKm = 6371.0
 
+// DefaultCoordinatePrecision - знаков после запятой в координатах ответов (6 ≈ 10 см)
+const DefaultCoordinatePrecision = 6
+
+// coordinateScale - множитель округления координат, задается при старте ConfigureCoordinatePrecision
+var coordinateScale = math.Pow10(DefaultCoordinatePrecision)
+
+// ConfigureCoordinatePrecision задает число знаков после запятой для RoundCoordinate (1-15)
+func ConfigureCoordinatePrecision(decimals int) error {
+	if decimals < 1 || decimals > 15 {
+		return fmt.Errorf("coordinate precision must be between 1 and 15, got %d", decimals)
+	}
+	coordinateScale = math.Pow10(decimals)
+	return nil
+}
+
+// RoundCoordinate округляет координату до настроенной точности.
+// Применяется только при формировании ответа: расчеты расстояний идут на полной точности.
+func RoundCoordinate(v float64) float64 {
+	return math.Round(v*coordinateScale) / coordinateScale
+}
+
 // HaversineDistance вычисляет расстояние между двумя точками в километрах
 func HaversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	dLat := (lat2 - lat1) * math.Pi / 180.0
@@ -19,6 +43,27 @@ func HaversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	return earthRadiusKm * c
 }
 
+// RingAreaSqKm возвращает площадь замкнутого кольца GeoJSON ([lon, lat] в градусах) на сфере в км².
+// Направление обхода не важно.
+func RingAreaSqKm(ring [][]float64) float64 {
+	area := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		lon1, lat1 := ring[i][0]*math.Pi/180.0, ring[i][1]*math.Pi/180.0
+		lon2, lat2 := ring[i+1][0]*math.Pi/180.0, ring[i+1][1]*math.Pi/180.0
+		area += (lon2 - lon1) * (2 + math.Sin(lat1) + math.Sin(lat2))
+	}
+	return math.Abs(area) * earthRadiusKm * earthRadiusKm / 2
+}
+
+// DistanceMeters возвращает расстояние в метрах: посчитанное в БД (distanceM), если оно есть,
+// иначе - по формуле Haversine между точками
+func DistanceMeters(distanceM *float64, lat1, lon1, lat2, lon2 float64) float64 {
+	if distanceM != nil {
+		return *distanceM
+	}
+	return HaversineDistance(lat1, lon1, lat2, lon2) * 1000
+}
+
 // ValidateCoordinates проверяет валидность координат
 func ValidateCoordinates(lat, lon float64) bool {
 	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/location-microservice/internal/pkg/errors"
 )
@@ -15,10 +17,51 @@ type ErrorResponse struct {
 }
 
 type Meta struct {
-	Total    int     `json:"total,omitempty"`
-	Page     int     `json:"page,omitempty"`
-	Limit    int     `json:"limit,omitempty"`
-	TimeMSec float64 `json:"time_ms,omitempty"`
+	Total      int    `json:"total,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    *bool  `json:"has_more,omitempty"`
+	// DistanceUnit - единица расстояний в data (m или km), только у эндпоинтов с distance_unit
+	DistanceUnit string  `json:"distance_unit,omitempty"`
+	TimeMSec     float64 `json:"time_ms,omitempty"`
+}
+
+// Pagination - пагинация списочного ответа, заполняется в use case и переносится в Meta.
+// HasMore - за пределами страницы есть еще результаты; NextCursor - значение offset следующей
+// страницы, только для эндпоинтов с offset (для выдачи только с limit - пусто, нужно увеличить limit).
+type Pagination struct {
+	Limit      int
+	Offset     int
+	NextCursor string
+	HasMore    bool
+}
+
+// OffsetPagination - пагинация по limit/offset при известном общем числе результатов
+func OffsetPagination(limit, offset, total int) Pagination {
+	p := Pagination{Limit: limit, Offset: offset, HasMore: offset+limit < total}
+	if p.HasMore {
+		p.NextCursor = strconv.Itoa(offset + limit)
+	}
+	return p
+}
+
+// LimitPagination - пагинация выдачи только с limit: hasMore обычно определяется запросом limit+1 строк
+func LimitPagination(limit int, hasMore bool) Pagination {
+	return Pagination{Limit: limit, HasMore: hasMore}
+}
+
+// Meta возвращает метаданные списочного ответа с total и пагинацией
+func (p Pagination) Meta(total int) *Meta {
+	hasMore := p.HasMore
+	return &Meta{
+		Total:      total,
+		Limit:      p.Limit,
+		Offset:     p.Offset,
+		NextCursor: p.NextCursor,
+		HasMore:    &hasMore,
+	}
 }
 
 func SendSuccess(c *fiber.Ctx, data interface{}, meta *Meta) error {
@@ -4,27 +4,55 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/cachemode"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
 type cacheRepository struct {
-	client *redis.Client
-	logger *zap.Logger
+	client           *redis.Client
+	logger           *zap.Logger
+	ttlJitterPercent float64
 }
 
-func NewCacheRepository(redis *Redis) repository.CacheRepository {
+// NewCacheRepository создает репозиторий кеша.
+// ttlJitterPercent - случайный разброс (±%) каждого TTL, 0 - без разброса.
+func NewCacheRepository(redis *Redis, ttlJitterPercent float64) repository.CacheRepository {
 	return &cacheRepository{
-		client: redis.Client(),
-		logger: redis.logger,
+		client:           redis.Client(),
+		logger:           redis.logger,
+		ttlJitterPercent: ttlJitterPercent,
 	}
 }
 
+// jitterTTL сдвигает TTL на случайную величину в пределах ±percent%.
+// rnd возвращает число в [0, 1). TTL <= 0 (без истечения) не изменяется.
+func jitterTTL(ttl time.Duration, percent float64, rnd func() float64) time.Duration {
+	if ttl <= 0 || percent <= 0 {
+		return ttl
+	}
+
+	spread := float64(ttl) * percent / 100
+	jittered := ttl + time.Duration((rnd()*2-1)*spread)
+	if jittered <= 0 {
+		return ttl
+	}
+	return jittered
+}
+
+// Get возвращает значение ключа; nil без ошибки - промах. Режим запроса, запрещающий чтение
+// кеша (cachemode), дает промах без обращения к Redis
 func (r *cacheRepository) Get(ctx context.Context, key string) ([]byte, error) {
+	if !cachemode.CanRead(ctx) {
+		r.logger.Debug("Cache read bypassed", zap.String("key", key))
+		return nil, nil
+	}
+
 	val, err := r.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
 		return nil, nil // Cache miss
@@ -38,7 +66,15 @@ func (r *cacheRepository) Get(ctx context.Context, key string) ([]byte, error) {
 	return val, nil
 }
 
+// Set сохраняет значение с TTL; в режиме запроса с выключенным кешем (cachemode) не пишет ничего
 func (r *cacheRepository) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if !cachemode.CanWrite(ctx) {
+		r.logger.Debug("Cache write bypassed", zap.String("key", key))
+		return nil
+	}
+
+	ttl = jitterTTL(ttl, r.ttlJitterPercent, rand.Float64)
+
 	err := r.client.Set(ctx, key, value, ttl).Err()
 	if err != nil {
 		r.logger.Error("Failed to set cache", zap.String("key", key), zap.Error(err))
@@ -60,7 +96,53 @@ func (r *cacheRepository) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// deleteScanCount - размер страницы SCAN и пачки DEL при удалении по шаблону
+const deleteScanCount = 500
+
+func (r *cacheRepository) DeleteByPattern(ctx context.Context, pattern string, match func(key string) bool) (int, error) {
+	deleted := 0
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, deleteScanCount).Result()
+		if err != nil {
+			r.logger.Error("Failed to scan cache keys", zap.String("pattern", pattern), zap.Error(err))
+			return deleted, fmt.Errorf("cache scan error: %w", err)
+		}
+
+		if match != nil {
+			selected := keys[:0]
+			for _, key := range keys {
+				if match(key) {
+					selected = append(selected, key)
+				}
+			}
+			keys = selected
+		}
+
+		if len(keys) > 0 {
+			n, err := r.client.Del(ctx, keys...).Result()
+			if err != nil {
+				r.logger.Error("Failed to delete cache keys", zap.String("pattern", pattern), zap.Error(err))
+				return deleted, fmt.Errorf("cache delete error: %w", err)
+			}
+			deleted += int(n)
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	r.logger.Debug("Cache keys deleted by pattern", zap.String("pattern", pattern), zap.Int("deleted", deleted))
+	return deleted, nil
+}
+
 func (r *cacheRepository) Exists(ctx context.Context, key string) (bool, error) {
+	if !cachemode.CanRead(ctx) {
+		return false, nil
+	}
+
 	val, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
 		r.logger.Error("Failed to check cache existence", zap.String("key", key), zap.Error(err))
@@ -71,18 +153,18 @@ func (r *cacheRepository) Exists(ctx context.Context, key string) (bool, error)
 }
 
 func (r *cacheRepository) GetTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	key := fmt.Sprintf("tile:%d:%d:%d", z, x, y)
+	key := fmt.Sprintf("%s:%d:%d:%d", domain.CacheNamespaceTiles, z, x, y)
 	return r.Get(ctx, key)
 }
 
 func (r *cacheRepository) SetTile(ctx context.Context, z, x, y int, data []byte, ttl time.Duration) error {
-	key := fmt.Sprintf("tile:%d:%d:%d", z, x, y)
+	key := fmt.Sprintf("%s:%d:%d:%d", domain.CacheNamespaceTiles, z, x, y)
 	return r.Set(ctx, key, data, ttl)
 }
 
 // GetStats получает статистику из кеша
 func (r *cacheRepository) GetStats(ctx context.Context) (*domain.Statistics, error) {
-	key := "stats:current"
+	key := domain.CacheNamespaceStats + ":current"
 	data, err := r.Get(ctx, key)
 	if err != nil {
 		return nil, err
@@ -102,7 +184,7 @@ func (r *cacheRepository) GetStats(ctx context.Context) (*domain.Statistics, err
 
 // SetStats сохраняет статистику в кеше
 func (r *cacheRepository) SetStats(ctx context.Context, stats *domain.Statistics, ttl time.Duration) error {
-	key := "stats:current"
+	key := domain.CacheNamespaceStats + ":current"
 	data, err := json.Marshal(stats)
 	if err != nil {
 		r.logger.Error("Failed to marshal stats", zap.Error(err))
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitterTTL(t *testing.T) {
+	ttl := time.Hour
+
+	if got := jitterTTL(ttl, 0, func() float64 { return 0.9 }); got != ttl {
+		t.Errorf("expected unchanged TTL without jitter, got %v", got)
+	}
+	if got := jitterTTL(0, 10, func() float64 { return 0.9 }); got != 0 {
+		t.Errorf("expected zero TTL to stay zero, got %v", got)
+	}
+
+	// rnd=0 -> -10%, rnd=0.5 -> без сдвига, rnd->1 -> почти +10%
+	if got := jitterTTL(ttl, 10, func() float64 { return 0 }); got != 54*time.Minute {
+		t.Errorf("expected lower bound 54m, got %v", got)
+	}
+	if got := jitterTTL(ttl, 10, func() float64 { return 0.5 }); got != ttl {
+		t.Errorf("expected unchanged TTL at midpoint, got %v", got)
+	}
+	if got := jitterTTL(ttl, 10, func() float64 { return 0.999999 }); got > 66*time.Minute || got < 65*time.Minute {
+		t.Errorf("expected TTL close to upper bound 66m, got %v", got)
+	}
+}
@@ -0,0 +1,123 @@
+package mbtiles
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/location-microservice/internal/domain"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite" // драйвер SQLite без cgo
+)
+
+// Repository - предрассчитанные тайлы из локальных MBTiles архивов, по архиву на слой
+type Repository struct {
+	archives map[domain.TileLayer]*sqlx.DB
+	logger   *zap.Logger
+}
+
+// NewRepository открывает MBTiles архивы (SQLite) только на чтение: слой -> путь к файлу.
+// Неизвестный слой, отсутствующий файл или файл без таблицы tiles - ошибка конфигурации.
+func NewRepository(paths map[string]string, logger *zap.Logger) (*Repository, error) {
+	r := &Repository{
+		archives: make(map[domain.TileLayer]*sqlx.DB, len(paths)),
+		logger:   logger,
+	}
+
+	for name, path := range paths {
+		layer := domain.TileLayer(name)
+		if !domain.IsValidTileLayer(layer) {
+			r.Close()
+			return nil, fmt.Errorf("mbtiles: unknown tile layer %q", name)
+		}
+		if _, err := os.Stat(path); err != nil {
+			r.Close()
+			return nil, fmt.Errorf("mbtiles: layer %q: %w", name, err)
+		}
+
+		db, err := sqlx.Open("sqlite", fmt.Sprintf("file:%s?mode=ro&_pragma=query_only(1)", path))
+		if err != nil {
+			r.Close()
+			return nil, fmt.Errorf("mbtiles: layer %q: %w", name, err)
+		}
+		var tables int
+		if err := db.Get(&tables, `SELECT COUNT(*) FROM sqlite_master WHERE name = 'tiles'`); err != nil || tables == 0 {
+			db.Close()
+			r.Close()
+			return nil, fmt.Errorf("mbtiles: layer %q: %s is not an MBTiles archive", name, path)
+		}
+
+		r.archives[layer] = db
+		logger.Info("MBTiles archive attached", zap.String("layer", name), zap.String("path", path))
+	}
+
+	return r, nil
+}
+
+// GetTile ищет тайл в архиве слоя. MBTiles хранит строки в схеме TMS (ось y снизу вверх),
+// сжатые gzip тайлы распаковываются, чтобы ответ не отличался от сгенерированного в PostGIS.
+func (r *Repository) GetTile(ctx context.Context, layer domain.TileLayer, z, x, y int) ([]byte, bool, error) {
+	db, ok := r.archives[layer]
+	if !ok {
+		return nil, false, nil
+	}
+
+	tmsY := (1 << uint(z)) - 1 - y
+	var data []byte
+	err := db.QueryRowContext(ctx,
+		`SELECT tile_data FROM tiles WHERE zoom_level = ? AND tile_column = ? AND tile_row = ?`,
+		z, x, tmsY,
+	).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to read MBTiles tile",
+			zap.String("layer", string(layer)),
+			zap.Int("z", z), zap.Int("x", x), zap.Int("y", y),
+			zap.Error(err))
+		return nil, false, err
+	}
+
+	tile, err := gunzipTile(data)
+	if err != nil {
+		r.logger.Error("failed to decompress MBTiles tile",
+			zap.String("layer", string(layer)),
+			zap.Int("z", z), zap.Int("x", x), zap.Int("y", y),
+			zap.Error(err))
+		return nil, false, err
+	}
+
+	return tile, true, nil
+}
+
+// Close закрывает все архивы
+func (r *Repository) Close() error {
+	var firstErr error
+	for _, db := range r.archives {
+		if err := db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// gunzipTile распаковывает тайл, если он сжат gzip (типично для MBTiles с pbf)
+func gunzipTile(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return io.ReadAll(zr)
+}
@@ -0,0 +1,82 @@
+package mbtiles
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/location-microservice/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// createArchive создает минимальный MBTiles архив с одним gzip тайлом z/x/y (y в схеме XYZ)
+func createArchive(t *testing.T, z, x, y int, tile []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.mbtiles")
+	db, err := sqlx.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, err = zw.Write(tile)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	db.MustExec(`CREATE TABLE tiles (zoom_level INTEGER, tile_column INTEGER, tile_row INTEGER, tile_data BLOB)`)
+	db.MustExec(`INSERT INTO tiles VALUES (?, ?, ?, ?)`, z, x, (1<<uint(z))-1-y, gz.Bytes())
+
+	return path
+}
+
+func TestRepository_GetTile(t *testing.T) {
+	ctx := context.Background()
+	tile := []byte("mvt-payload")
+	path := createArchive(t, 10, 517, 381, tile)
+
+	repo, err := NewRepository(map[string]string{"water": path}, zap.NewNop())
+	require.NoError(t, err)
+	defer repo.Close()
+
+	t.Run("tile found and decompressed", func(t *testing.T) {
+		got, found, err := repo.GetTile(ctx, domain.TileLayerWater, 10, 517, 381)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, tile, got)
+	})
+
+	t.Run("missing tile falls through", func(t *testing.T) {
+		_, found, err := repo.GetTile(ctx, domain.TileLayerWater, 10, 517, 382)
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("layer without archive falls through", func(t *testing.T) {
+		_, found, err := repo.GetTile(ctx, domain.TileLayerBeaches, 10, 517, 381)
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestNewRepository_InvalidConfig(t *testing.T) {
+	path := createArchive(t, 0, 0, 0, []byte("x"))
+
+	_, err := NewRepository(map[string]string{"roads": path}, zap.NewNop())
+	assert.Error(t, err, "unknown layer")
+
+	_, err = NewRepository(map[string]string{"water": filepath.Join(t.TempDir(), "missing.mbtiles")}, zap.NewNop())
+	assert.Error(t, err, "missing file")
+}
+
+func TestGunzipTile(t *testing.T) {
+	plain := []byte{0x1a, 0x05, 0x0a}
+	got, err := gunzipTile(plain)
+	assert.NoError(t, err)
+	assert.Equal(t, plain, got)
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+	"github.com/location-microservice/internal/config"
+	"go.uber.org/zap"
+)
+
+// DB представляет подключение к основной PostgreSQL базе сервиса
+// (таблицы из migrations/, в отличие от planet_osm_* в OSM базе)
+type DB struct {
+	*sqlx.DB
+	logger *zap.Logger
+}
+
+// New создает новое подключение к основной базе данных
+func New(cfg *config.DatabaseConfig, logger *zap.Logger) (*DB, error) {
+	dsn := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
+	)
+
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config: %w", err)
+	}
+	// statement_timeout сессии: запрос дольше лимита прерывает сам Postgres
+	if cfg.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	db := sqlx.NewDb(stdlib.OpenDB(*connConfig), "pgx")
+
+	db.SetMaxOpenConns(cfg.MaxConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	logger.Info("PostgreSQL connected",
+		zap.String("host", cfg.Host),
+		zap.Int("port", cfg.Port),
+		zap.String("database", cfg.DBName),
+		zap.Duration("statement_timeout", cfg.StatementTimeout),
+	)
+
+	return &DB{DB: db, logger: logger}, nil
+}
+
+// Close закрывает соединение с БД
+func (db *DB) Close() error {
+	db.logger.Info("Closing PostgreSQL connection")
+	return db.DB.Close()
+}
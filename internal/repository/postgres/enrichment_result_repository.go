@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// upsertEnrichmentResultQuery - при конфликте по property_id строка перезаписывается, только если
+// новый результат не старше сохраненного: переобработка старого сообщения стрима не затирает свежий
+const upsertEnrichmentResultQuery = `
+	INSERT INTO enrichment_results (
+		property_id, country_id, region_id, province_id, city_id, district_id, subdistrict_id,
+		neighborhood_id, nearest_transport, error, enriched_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11)
+	ON CONFLICT (property_id) DO UPDATE SET
+		country_id = EXCLUDED.country_id,
+		region_id = EXCLUDED.region_id,
+		province_id = EXCLUDED.province_id,
+		city_id = EXCLUDED.city_id,
+		district_id = EXCLUDED.district_id,
+		subdistrict_id = EXCLUDED.subdistrict_id,
+		neighborhood_id = EXCLUDED.neighborhood_id,
+		nearest_transport = EXCLUDED.nearest_transport,
+		error = EXCLUDED.error,
+		enriched_at = EXCLUDED.enriched_at
+	WHERE enrichment_results.enriched_at <= EXCLUDED.enriched_at
+`
+
+type enrichmentResultRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewEnrichmentResultRepository создает репозиторий результатов обогащения
+func NewEnrichmentResultRepository(db *DB) repository.EnrichmentResultRepository {
+	return &enrichmentResultRepository{
+		db:     db.DB,
+		logger: db.logger,
+	}
+}
+
+// UpsertResults сохраняет пакет результатов в одной транзакции
+func (r *enrichmentResultRepository) UpsertResults(ctx context.Context, results []*domain.EnrichmentResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // после Commit - no-op
+
+	stmt, err := tx.PreparexContext(ctx, upsertEnrichmentResultQuery)
+	if err != nil {
+		return fmt.Errorf("failed to prepare upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, result := range results {
+		transport := result.NearestTransport
+		if transport == nil {
+			transport = []domain.NearestStation{}
+		}
+		transportJSON, err := json.Marshal(transport)
+		if err != nil {
+			return fmt.Errorf("failed to marshal nearest transport: %w", err)
+		}
+
+		if _, err := stmt.ExecContext(ctx,
+			result.PropertyID,
+			result.CountryID,
+			result.RegionID,
+			result.ProvinceID,
+			result.CityID,
+			result.DistrictID,
+			result.SubdistrictID,
+			result.NeighborhoodID,
+			string(transportJSON),
+			result.Error,
+			result.EnrichedAt,
+		); err != nil {
+			return fmt.Errorf("failed to upsert enrichment result %s: %w", result.PropertyID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit enrichment results: %w", err)
+	}
+
+	r.logger.Debug("Enrichment results persisted", zap.Int("count", len(results)))
+	return nil
+}
@@ -0,0 +1,57 @@
+package postgresosm
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// defaultSimplifiedMaxLevel - по умолчанию упрощенная геометрия используется для стран и регионов
+	defaultSimplifiedMaxLevel = 4
+	// simplifiedBuiltMaxLevel - way_simplified строится scripts/post-import.sql только для уровней 2-6
+	simplifiedBuiltMaxLevel = 6
+)
+
+// CheckBoundarySimplifiedGeometry проверяет, что колонка way_simplified построена: без нее
+// с BOUNDARY_SIMPLIFIED_CONTAINS=true падал бы каждый запрос обратного геокодирования.
+// Вызывается при старте, если упрощенная геометрия включена.
+func CheckBoundarySimplifiedGeometry(ctx context.Context, db *DB) error {
+	var exists bool
+	err := db.DB.QueryRowxContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.columns
+			WHERE table_schema = ANY(current_schemas(false))
+			  AND table_name = $1
+			  AND column_name = 'way_simplified'
+		)
+	`, planetPolygonTable).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to detect %s.way_simplified column: %w", planetPolygonTable, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s.way_simplified is missing: run scripts/post-import.sql or disable BOUNDARY_SIMPLIFIED_CONTAINS",
+			planetPolygonTable)
+	}
+	return nil
+}
+
+// boundaryContainsGeom возвращает SQL выражение геометрии границы для ST_Contains, ST_Centroid и ST_Area.
+// С включенной упрощенной геометрией для уровней <= SimplifiedMaxLevel это way_simplified, если она
+// построена (иначе way), для остальных - way. alias - алиас таблицы planet_osm_polygon ("" - без алиаса).
+// Bbox-префильтр (way && ...) остается на way: его обслуживает индекс, а вершины упрощенной
+// геометрии - подмножество вершин way.
+func (o QueryOptions) boundaryContainsGeom(alias string) string {
+	col := func(name string) string {
+		if alias == "" {
+			return name
+		}
+		return alias + "." + name
+	}
+
+	if !o.SimplifiedContains {
+		return col("way")
+	}
+	return fmt.Sprintf("(CASE WHEN (%s)::integer <= %d THEN COALESCE(%s, %s) ELSE %s END)",
+		col("admin_level"), o.simplifiedMaxLevel(), col("way_simplified"), col("way"), col("way"))
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
 	pkgerrors "github.com/location-microservice/internal/pkg/errors"
@@ -14,15 +16,19 @@ import (
 )
 
 type boundaryRepository struct {
-	db     *sqlx.DB
-	logger *zap.Logger
+	db        *sqlx.DB
+	readDB    *sqlx.DB // тайлы и аналитика: реплика, если настроена
+	logger    *zap.Logger
+	queryOpts QueryOptions
 }
 
 // NewBoundaryRepository создает репозиторий административных границ для OSM базы данных
-func NewBoundaryRepository(db *DB) repository.BoundaryRepository {
+func NewBoundaryRepository(db *DB, queryOpts QueryOptions) repository.BoundaryRepository {
 	return &boundaryRepository{
-		db:     db.DB,
-		logger: db.logger,
+		db:        db.DB,
+		readDB:    db.Reader(ReadReplica),
+		logger:    db.logger,
+		queryOpts: queryOpts,
 	}
 }
 
@@ -32,15 +38,7 @@ func (r *boundaryRepository) GetByID(ctx context.Context, id int64) (*domain.Adm
 		SELECT 
 			osm_id,
 			COALESCE(name, '') AS name,
-			COALESCE(NULLIF(tags->'name:en', ''), '') AS name_en,
-			COALESCE(NULLIF(tags->'name:es', ''), '') AS name_es,
-			COALESCE(NULLIF(tags->'name:ca', ''), '') AS name_ca,
-			COALESCE(NULLIF(tags->'name:ru', ''), '') AS name_ru,
-			COALESCE(NULLIF(tags->'name:uk', ''), '') AS name_uk,
-			COALESCE(NULLIF(tags->'name:fr', ''), '') AS name_fr,
-			COALESCE(NULLIF(tags->'name:pt', ''), '') AS name_pt,
-			COALESCE(NULLIF(tags->'name:it', ''), '') AS name_it,
-			COALESCE(NULLIF(tags->'name:de', ''), '') AS name_de,
+			%s AS names,
 			COALESCE(boundary, 'administrative') AS type,
 			COALESCE(admin_level::integer, 0) AS admin_level,
 			ST_Y(ST_Centroid(ST_Transform(way, %d))) AS center_lat,
@@ -52,17 +50,16 @@ func (r *boundaryRepository) GetByID(ctx context.Context, id int64) (*domain.Adm
 		  AND boundary = 'administrative'
 		  AND admin_level IS NOT NULL
 		LIMIT 1
-	`, SRID4326, SRID4326, SRID4326, planetPolygonTable)
+	`, nameTranslationsExpr(""), SRID4326, SRID4326, SRID4326, planetPolygonTable)
 
 	var b domain.AdminBoundary
 	var population int64
 	var adminLevelInt int
+	var names []byte
 
 	err := r.db.QueryRowxContext(ctx, query, id).Scan(
 		&b.OSMId, &b.Name,
-		&b.NameEn, &b.NameEs, &b.NameCa,
-		&b.NameRu, &b.NameUk, &b.NameFr,
-		&b.NamePt, &b.NameIt, &b.NameDe,
+		&names,
 		&b.Type, &adminLevelInt,
 		&b.CenterLat, &b.CenterLon,
 		&population, &b.AreaSqKm,
@@ -77,6 +74,7 @@ func (r *boundaryRepository) GetByID(ctx context.Context, id int64) (*domain.Adm
 	}
 
 	b.ID = b.OSMId
+	b.SetNames(parseNames(names))
 	b.AdminLevel = adminLevelInt
 	if population > 0 {
 		populationInt := int(population)
@@ -86,43 +84,175 @@ func (r *boundaryRepository) GetByID(ctx context.Context, id int64) (*domain.Adm
 	return &b, nil
 }
 
-// SearchByText выполняет текстовый поиск по названиям границ
+// GetBoundaryBBox возвращает ограничивающий прямоугольник и центроид границы в WGS84
+func (r *boundaryRepository) GetBoundaryBBox(ctx context.Context, id int64) (*domain.BoundaryBBox, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			osm_id,
+			ST_YMin(env) AS min_lat,
+			ST_XMin(env) AS min_lon,
+			ST_YMax(env) AS max_lat,
+			ST_XMax(env) AS max_lon,
+			ST_Y(ST_Centroid(geom)) AS center_lat,
+			ST_X(ST_Centroid(geom)) AS center_lon
+		FROM (
+			SELECT osm_id, ST_Transform(way, %d) AS geom, ST_Envelope(ST_Transform(way, %d)) AS env
+			FROM %s
+			WHERE osm_id = $1
+			  AND boundary = 'administrative'
+			  AND admin_level IS NOT NULL
+			LIMIT 1
+		) b
+	`, SRID4326, SRID4326, planetPolygonTable)
+
+	var bbox domain.BoundaryBBox
+	err := r.db.QueryRowxContext(ctx, query, id).Scan(
+		&bbox.ID,
+		&bbox.BBox.MinLat, &bbox.BBox.MinLon, &bbox.BBox.MaxLat, &bbox.BBox.MaxLon,
+		&bbox.CenterLat, &bbox.CenterLon,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, pkgerrors.ErrLocationNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get osm boundary bbox", zap.Int64("osm_id", id), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return &bbox, nil
+}
+
+// GetBoundariesIntersectingPolygon возвращает границы, пересекающие полигон GeoJSON (EPSG:4326).
+// Полигон исправляется ST_MakeValid, от результата остаются только полигональные части.
+// Границы, лишь касающиеся полигона, в выдачу не попадают.
+func (r *boundaryRepository) GetBoundariesIntersectingPolygon(ctx context.Context, polygonGeoJSON string, levels []int) ([]domain.BoundaryOverlap, error) {
+	args := []interface{}{polygonGeoJSON}
+	levelFilter := ""
+	if len(levels) > 0 {
+		placeholders := make([]string, len(levels))
+		for i, level := range levels {
+			args = append(args, level)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		levelFilter = fmt.Sprintf("AND (p.admin_level)::integer IN (%s)", strings.Join(placeholders, ","))
+	}
+
+	query := fmt.Sprintf(`
+		WITH input AS (
+			SELECT ST_CollectionExtract(ST_MakeValid(ST_SetSRID(ST_GeomFromGeoJSON($1), %d)), 3) AS geom
+		),
+		area AS (
+			SELECT geom, ST_Transform(geom, %d) AS way, ST_Area(geom::geography) AS area_sq_m
+			FROM input
+			WHERE NOT ST_IsEmpty(geom)
+		)
+		SELECT osm_id, name, admin_level, area_sq_km, overlap_sq_km, overlap_sq_km * 1000000 / NULLIF(input_sq_m, 0) * 100 AS overlap_percent
+		FROM (
+			SELECT
+				p.osm_id,
+				COALESCE(p.name, '') AS name,
+				(p.admin_level)::integer AS admin_level,
+				ST_Area(ST_Transform(p.way, %d)::geography) / 1000000 AS area_sq_km,
+				ST_Area(ST_Intersection(ST_Transform(p.way, %d), a.geom)::geography) / 1000000 AS overlap_sq_km,
+				a.area_sq_m AS input_sq_m
+			FROM %s p, area a
+			WHERE p.boundary = 'administrative'
+			  AND p.admin_level ~ '^[0-9]+$'
+			  AND p.way && a.way
+			  AND ST_Intersects(p.way, a.way)
+			  %s
+		) o
+		WHERE overlap_sq_km > 0
+		ORDER BY admin_level ASC, overlap_sq_km DESC
+		LIMIT %d
+	`, SRID4326, SRID3857, SRID4326, SRID4326, planetPolygonTable, levelFilter, LimitBoundaries)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("failed to get osm boundaries intersecting polygon", zap.Ints("levels", levels), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	overlaps := []domain.BoundaryOverlap{}
+	for rows.Next() {
+		var o domain.BoundaryOverlap
+		var percent sql.NullFloat64
+		if err := rows.Scan(&o.ID, &o.Name, &o.AdminLevel, &o.AreaSqKm, &o.OverlapAreaSqKm, &percent); err != nil {
+			r.logger.Error("failed to scan boundary overlap row", zap.Error(err))
+			continue
+		}
+		o.OverlapPercent = percent.Float64
+		overlaps = append(overlaps, o)
+	}
+
+	return overlaps, nil
+}
+
+// SearchByText выполняет текстовый поиск по названиям границ.
+// opts задает фильтры по населению и площади и сортировку по населению;
+// границы без тега population отсекаются только при MinPopulation > 0.
+// Запрос сверяется с name и переводом name:<lang>; какое из них попадает в Name, решает
+// opts.NameDisplay (domain.BoundaryNameMatch), совпавшее поле возвращается в MatchedField.
 func (r *boundaryRepository) SearchByText(
 	ctx context.Context,
 	searchQuery string,
 	lang string,
 	adminLevels []int,
 	limit int,
+	opts domain.BoundarySearchOptions,
 ) ([]*domain.AdminBoundary, error) {
-	if limit <= 0 || limit > LimitBoundaries {
-		limit = LimitBoundaries
-	}
+	limit = boundarySearchLimit(limit)
 
 	// Определяем поле для поиска в зависимости от языка
 	nameField := "name"
+	translationField := "''"
 	if lang != "" {
 		nameField = fmt.Sprintf("COALESCE(NULLIF(tags->'name:%s', ''), name)", lang)
+		translationField = fmt.Sprintf("COALESCE(tags->'name:%s', '')", lang)
 	}
 
-	// Базовый запрос
+	// Базовый запрос; площадь и население считаются в подзапросе, чтобы фильтровать по ним
 	sqlQuery := fmt.Sprintf(`
-		SELECT 
-			osm_id,
-			%s AS name,
-			COALESCE(boundary, 'administrative') AS type,
-			COALESCE((admin_level)::integer, 0) AS admin_level,
-			ST_Y(ST_Centroid(ST_Transform(way, %d))) AS center_lat,
-			ST_X(ST_Centroid(ST_Transform(way, %d))) AS center_lon,
-			ST_Area(ST_Transform(way, %d)::geography) / 1000000 AS area_sq_km
-		FROM %s
-		WHERE boundary = 'administrative'
-		  AND admin_level IS NOT NULL
-		  AND (%s ILIKE '%%' || $1 || '%%' OR name ILIKE '%%' || $1 || '%%')
-	`, nameField, SRID4326, SRID4326, SRID4326, planetPolygonTable, nameField)
+		SELECT osm_id, name, type, admin_level, center_lat, center_lon, population, area_sq_km,
+			name_original, name_translated, name_matched, translation_matched
+		FROM (
+			SELECT 
+				osm_id,
+				%s AS name,
+				COALESCE(name, '') AS name_original,
+				%s AS name_translated,
+				COALESCE(name ILIKE '%%' || $1 || '%%', false) AS name_matched,
+				%s ILIKE '%%' || $1 || '%%' AS translation_matched,
+				COALESCE(boundary, 'administrative') AS type,
+				COALESCE((admin_level)::integer, 0) AS admin_level,
+				ST_Y(ST_Centroid(ST_Transform(way, %d))) AS center_lat,
+				ST_X(ST_Centroid(ST_Transform(way, %d))) AS center_lon,
+				%s AS population,
+				ST_Area(ST_Transform(way, %d)::geography) / 1000000 AS area_sq_km
+			FROM %s
+			WHERE boundary = 'administrative'
+			  AND admin_level IS NOT NULL
+			  AND (%s ILIKE '%%' || $1 || '%%' OR name ILIKE '%%' || $1 || '%%')
+		) b
+		WHERE TRUE
+	`, nameField, translationField, translationField, SRID4326, SRID4326, boundaryPopulationExpr, SRID4326, planetPolygonTable, nameField)
 
 	args := []interface{}{searchQuery}
 	argIndex := 2
 
+	if opts.MinPopulation > 0 {
+		sqlQuery += fmt.Sprintf(" AND population >= $%d", argIndex)
+		args = append(args, opts.MinPopulation)
+		argIndex++
+	}
+	if opts.MinAreaSqKm > 0 {
+		sqlQuery += fmt.Sprintf(" AND area_sq_km >= $%d", argIndex)
+		args = append(args, opts.MinAreaSqKm)
+		argIndex++
+	}
+
 	// Фильтр по административным уровням
 	if len(adminLevels) > 0 {
 		placeholders := make([]string, len(adminLevels))
@@ -131,10 +261,14 @@ func (r *boundaryRepository) SearchByText(
 			args = append(args, level)
 			argIndex++
 		}
-		sqlQuery += fmt.Sprintf(" AND (admin_level)::integer IN (%s)", strings.Join(placeholders, ","))
+		sqlQuery += fmt.Sprintf(" AND admin_level IN (%s)", strings.Join(placeholders, ","))
 	}
 
-	sqlQuery += fmt.Sprintf(" ORDER BY (admin_level)::integer ASC, name ASC LIMIT $%d", argIndex)
+	orderBy := "admin_level ASC, name ASC"
+	if opts.OrderBy == domain.BoundarySearchOrderByPopulation {
+		orderBy = "population DESC NULLS LAST, admin_level ASC, name ASC"
+	}
+	sqlQuery += fmt.Sprintf(" ORDER BY %s LIMIT $%d", orderBy, argIndex)
 	args = append(args, limit)
 
 	rows, err := r.db.QueryxContext(ctx, sqlQuery, args...)
@@ -144,6 +278,139 @@ func (r *boundaryRepository) SearchByText(
 	}
 	defer rows.Close()
 
+	var boundaries []*domain.AdminBoundary
+	for rows.Next() {
+		var b domain.AdminBoundary
+		var adminLevelInt int
+		var population sql.NullInt64
+		match := domain.BoundaryNameMatch{Lang: lang}
+
+		err := rows.Scan(
+			&b.OSMId, &b.Name, &b.Type, &adminLevelInt,
+			&b.CenterLat, &b.CenterLon, &population, &b.AreaSqKm,
+			&match.Name, &match.Translation, &match.NameMatched, &match.TranslationMatched,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan boundary row", zap.Error(err))
+			continue
+		}
+
+		b.ID = b.OSMId
+		b.Name, b.MatchedField = match.Resolve(opts.NameDisplay)
+		if b.Name != match.Name {
+			b.OriginalName = match.Name
+		}
+		b.AdminLevel = adminLevelInt
+		if population.Valid && population.Int64 > 0 {
+			populationInt := int(population.Int64)
+			b.Population = &populationInt
+		}
+
+		boundaries = append(boundaries, &b)
+	}
+
+	return boundaries, nil
+}
+
+// boundarySearchLimit ограничивает выдачу текстового поиска. Вызывающий код запрашивает limit+1,
+// чтобы по лишней строке узнать о следующей странице, поэтому при максимальном limit (LimitBoundaries)
+// допускается одна строка сверх него; limit <= 0 - LimitBoundaries.
+func boundarySearchLimit(limit int) int {
+	if limit <= 0 {
+		return LimitBoundaries
+	}
+	return min(limit, LimitBoundaries+1)
+}
+
+// SearchWithinParent ищет границы по названию внутри родительской границы.
+// Кандидат подходит, если его точка на поверхности (ST_PointOnSurface) лежит внутри полигона родителя,
+// как и в GetChildren - центроид границы с дырой может оказаться вне ее самой.
+// Без levels возвращаются только границы более детального уровня, чем родитель.
+func (r *boundaryRepository) SearchWithinParent(
+	ctx context.Context,
+	searchQuery string,
+	parentID int64,
+	levels []int,
+	limit int,
+) ([]*domain.AdminBoundary, error) {
+	limit = boundarySearchLimit(limit)
+
+	// Сначала проверяем родителя: отсутствующий родитель - это 404, а не пустой результат
+	parentQuery := fmt.Sprintf(`
+		SELECT COALESCE((admin_level)::integer, 0)
+		FROM %s
+		WHERE osm_id = $1
+		  AND boundary = 'administrative'
+		  AND admin_level IS NOT NULL
+		LIMIT 1
+	`, planetPolygonTable)
+
+	var parentLevel int
+	err := r.db.QueryRowxContext(ctx, parentQuery, parentID).Scan(&parentLevel)
+	if err == sql.ErrNoRows {
+		return nil, pkgerrors.ErrLocationNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get parent boundary", zap.Int64("parent_id", parentID), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		WITH parent AS (
+			SELECT way FROM %s
+			WHERE osm_id = $2 AND boundary = 'administrative'
+			LIMIT 1
+		)
+		SELECT
+			child.osm_id,
+			child.name,
+			COALESCE(child.boundary, 'administrative') AS type,
+			COALESCE((child.admin_level)::integer, 0) AS admin_level,
+			ST_Y(ST_Centroid(ST_Transform(child.way, %d))) AS center_lat,
+			ST_X(ST_Centroid(ST_Transform(child.way, %d))) AS center_lon,
+			ST_Area(ST_Transform(child.way, %d)::geography) / 1000000 AS area_sq_km
+		FROM %s child, parent
+		WHERE child.boundary = 'administrative'
+		  AND child.admin_level IS NOT NULL
+		  AND child.osm_id <> $2
+		  AND child.name ILIKE '%%' || $1 || '%%'
+		  AND child.way && parent.way
+		  AND ST_Within(ST_PointOnSurface(child.way), parent.way)
+	`, planetPolygonTable, SRID4326, SRID4326, SRID4326, planetPolygonTable)
+
+	args := []interface{}{searchQuery, parentID}
+	argIndex := 3
+
+	if len(levels) > 0 {
+		levels64 := make([]int64, len(levels))
+		for i, level := range levels {
+			levels64[i] = int64(level)
+		}
+		sqlQuery += fmt.Sprintf(" AND (child.admin_level)::integer = ANY($%d::int[])", argIndex)
+		args = append(args, pq.Int64Array(levels64))
+		argIndex++
+	} else {
+		sqlQuery += fmt.Sprintf(" AND (child.admin_level)::integer > $%d", argIndex)
+		args = append(args, parentLevel)
+		argIndex++
+	}
+
+	// Совпадения с начала названия выше - для автодополнения
+	sqlQuery += fmt.Sprintf(`
+		ORDER BY (child.name ILIKE $1 || '%%') DESC, (child.admin_level)::integer ASC, child.name ASC
+		LIMIT $%d`, argIndex)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryxContext(ctx, sqlQuery, args...)
+	if err != nil {
+		r.logger.Error("failed to search osm boundaries within parent",
+			zap.String("query", searchQuery),
+			zap.Int64("parent_id", parentID),
+			zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
 	var boundaries []*domain.AdminBoundary
 	for rows.Next() {
 		var b domain.AdminBoundary
@@ -169,7 +436,44 @@ func (r *boundaryRepository) SearchByText(
 
 // Search выполняет простой текстовый поиск по названиям границ
 func (r *boundaryRepository) Search(ctx context.Context, query string, limit int) ([]*domain.AdminBoundary, error) {
-	return r.SearchByText(ctx, query, "", nil, limit)
+	return r.SearchByText(ctx, query, "", nil, limit, domain.BoundarySearchOptions{})
+}
+
+// addressLevels - уровни адреса, которые обратное геокодирование собирает в колонки по названию поля
+var addressLevels = []struct {
+	level  int
+	column string
+}{
+	{4, "region"}, {6, "province"}, {7, "subprovince"}, {8, "city"},
+	{9, "district"}, {10, "subdistrict"}, {11, "neighborhood"},
+}
+
+// addressLevelColumns возвращает колонки region ... neighborhood: название границы каждого уровня
+// из строк с колонками osm_id, name, admin_level (integer) и level_area (levelAreaExpr). Если границ
+// уровня несколько, берется та же, что выбирает domain.PickBoundaryPerLevel: с меньшей площадью,
+// затем с меньшим osm_id. alias - алиас строк с границами ("" - без алиаса).
+func addressLevelColumns(alias string) string {
+	col := func(name string) string {
+		if alias == "" {
+			return name
+		}
+		return alias + "." + name
+	}
+
+	columns := make([]string, 0, len(addressLevels))
+	for _, l := range addressLevels {
+		columns = append(columns, fmt.Sprintf("(ARRAY_AGG(%s ORDER BY %s ASC NULLS LAST, %s ASC) FILTER (WHERE %s = %d))[1] AS %s",
+			col("name"), col("level_area"), col("osm_id"), col("admin_level"), l.level, l.column))
+	}
+	return strings.Join(columns, ",\n\t\t\t")
+}
+
+// levelAreaExpr возвращает колонку level_area - площадь границы (м²) для выбора одной границы на уровень.
+// Площадь считается, только если у точки несколько границ этого уровня (partition - ключ точки и уровня),
+// чтобы не вычислять площадь стран и регионов на каждом запросе.
+func levelAreaExpr(partition, way string) string {
+	return fmt.Sprintf("CASE WHEN COUNT(*) OVER (PARTITION BY %s) > 1 THEN ST_Area(ST_Transform(%s, %d)::geography) END AS level_area",
+		partition, way, SRID4326)
 }
 
 // ReverseGeocode возвращает адрес по координатам (поддержка admin_level 2, 4, 6, 7, 8, 9, 10, 11)
@@ -180,30 +484,32 @@ func (r *boundaryRepository) ReverseGeocode(
 	query := fmt.Sprintf(`
 		WITH point AS (
 			SELECT ST_Transform(ST_SetSRID(ST_MakePoint($1, $2), %d), %d) AS geom
+		),
+		matched AS (
+			SELECT osm_id, name, (admin_level)::integer AS admin_level,
+				%s
+			FROM %s, point
+			WHERE boundary = 'administrative'
+			  AND admin_level IS NOT NULL
+			  AND way && ST_Expand(point.geom, $3)
+			  AND ST_Contains(way, point.geom)
 		)
 		SELECT 
-			MAX(CASE WHEN (admin_level)::integer = 2 THEN name END) AS country,
-			MAX(CASE WHEN (admin_level)::integer = 4 THEN name END) AS region,
-			MAX(CASE WHEN (admin_level)::integer = 6 THEN name END) AS province,
-			MAX(CASE WHEN (admin_level)::integer = 7 THEN name END) AS subprovince,
-			MAX(CASE WHEN (admin_level)::integer = 8 THEN name END) AS city,
-			MAX(CASE WHEN (admin_level)::integer = 9 THEN name END) AS district,
-			MAX(CASE WHEN (admin_level)::integer = 10 THEN name END) AS subdistrict,
-			MAX(CASE WHEN (admin_level)::integer = 11 THEN name END) AS neighborhood
-		FROM %s, point
-		WHERE boundary = 'administrative'
-		  AND admin_level IS NOT NULL
-		  AND way && ST_Expand(point.geom, $3)
-		  AND ST_Contains(way, point.geom)
-	`, SRID4326, SRID3857, planetPolygonTable)
+			%s,
+			%s
+		FROM matched
+	`, SRID4326, SRID3857, levelAreaExpr("(admin_level)::integer", "way"), planetPolygonTable,
+		countryCandidatesColumns(""), addressLevelColumns(""))
 
-	var country, region, province, subprovince, city, district, subdistrict, neighborhood sql.NullString
+	var region, province, subprovince, city, district, subdistrict, neighborhood sql.NullString
+	var countryIDs pq.Int64Array
+	var countryNames pq.StringArray
 
 	err := r.db.QueryRowContext(ctx, query, lon, lat, BoundaryExpansionDegrees).Scan(
-		&country, &region, &province, &subprovince, &city, &district, &subdistrict, &neighborhood,
+		&countryIDs, &countryNames, &region, &province, &subprovince, &city, &district, &subdistrict, &neighborhood,
 	)
 
-	if err == sql.ErrNoRows || (!country.Valid && !region.Valid && !province.Valid && !city.Valid) {
+	if err == sql.ErrNoRows || (err == nil && len(countryNames) == 0 && !region.Valid && !province.Valid && !city.Valid) {
 		return nil, pkgerrors.ErrLocationNotFound
 	}
 	if err != nil {
@@ -216,11 +522,11 @@ func (r *boundaryRepository) ReverseGeocode(
 	}
 
 	addr := &domain.Address{
-		Country:  country.String,
 		Region:   region.String,
 		Province: province.String,
 		City:     city.String,
 	}
+	addr.SetCountry(countryCandidates(countryIDs, countryNames), countryPriority)
 
 	if subprovince.Valid && subprovince.String != "" {
 		addr.Subprovince = &subprovince.String
@@ -238,6 +544,164 @@ func (r *boundaryRepository) ReverseGeocode(
 	return addr, nil
 }
 
+// maxGeocodeCellVertices - полигоны с большим числом вершин не кешируются ячейкой (память процесса)
+const maxGeocodeCellVertices = 20000
+
+// GetGeocodeCell возвращает самый детальный полигон, содержащий точку, если адрес одинаков для
+// любой его точки: его покрывают все содержащие точку границы и ни одна другая административная
+// граница не заходит внутрь (ST_Relate '2********' - пересечение внутренностей, соседи по краю
+// не мешают). Иначе, например у города с районами только в части территории, - nil.
+func (r *boundaryRepository) GetGeocodeCell(ctx context.Context, lat, lon float64) (*domain.GeocodeCell, error) {
+	query := fmt.Sprintf(`
+		WITH point AS (
+			SELECT ST_Transform(ST_SetSRID(ST_MakePoint($1, $2), %d), %d) AS geom
+		),
+		containing AS (
+			SELECT osm_id, (admin_level)::integer AS level, way
+			FROM %s, point
+			WHERE boundary = 'administrative'
+			  AND admin_level IS NOT NULL
+			  AND way && ST_Expand(point.geom, $3)
+			  AND ST_Contains(way, point.geom)
+		),
+		cell AS (
+			SELECT osm_id, level, way
+			FROM containing
+			ORDER BY level DESC, ST_Area(way), osm_id
+			LIMIT 1
+		)
+		SELECT
+			cell.osm_id,
+			cell.level,
+			ST_AsGeoJSON(ST_Transform(cell.way, %d), 7) AS geometry
+		FROM cell
+		WHERE ST_NPoints(cell.way) <= $4
+		  AND NOT EXISTS (
+			SELECT 1 FROM containing c
+			WHERE c.osm_id <> cell.osm_id
+			  AND NOT ST_Covers(c.way, cell.way)
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM %s o
+			WHERE o.boundary = 'administrative'
+			  AND o.admin_level IS NOT NULL
+			  AND o.way && cell.way
+			  AND o.osm_id NOT IN (SELECT osm_id FROM containing)
+			  AND ST_Relate(o.way, cell.way, '2********')
+		  )
+	`, SRID4326, SRID3857, planetPolygonTable, SRID4326, planetPolygonTable)
+
+	var (
+		osmID    int64
+		level    int
+		geometry string
+	)
+	err := r.db.QueryRowContext(ctx, query, lon, lat, BoundaryExpansionDegrees, maxGeocodeCellVertices).
+		Scan(&osmID, &level, &geometry)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to get geocode cell",
+			zap.Float64("lat", lat),
+			zap.Float64("lon", lon),
+			zap.Error(err),
+		)
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	cell, err := domain.NewGeocodeCell(osmID, level, geometry)
+	if err != nil {
+		r.logger.Warn("invalid geocode cell geometry", zap.Int64("osm_id", osmID), zap.Error(err))
+		return nil, nil
+	}
+	return cell, nil
+}
+
+// ReverseGeocodeWithDepth возвращает адрес по координатам, количество совпавших уровней
+// и расстояние (в метрах) от точки до края самого детального содержащего полигона
+func (r *boundaryRepository) ReverseGeocodeWithDepth(
+	ctx context.Context,
+	lat, lon float64,
+) (*domain.ReverseGeocodeMatch, error) {
+	query := fmt.Sprintf(`
+		WITH point AS (
+			SELECT ST_Transform(ST_SetSRID(ST_MakePoint($1, $2), %d), %d) AS geom
+		),
+		matched AS (
+			SELECT osm_id, (admin_level)::integer AS admin_level, name, way,
+				%s
+			FROM %s, point
+			WHERE boundary = 'administrative'
+			  AND admin_level IS NOT NULL
+			  AND way && ST_Expand(point.geom, $3)
+			  AND ST_Contains(way, point.geom)
+		),
+		smallest AS (
+			SELECT
+				matched.admin_level,
+				ST_Distance(
+					ST_Transform(point.geom, %d)::geography,
+					ST_Transform(ST_Boundary(matched.way), %d)::geography
+				) AS edge_distance
+			FROM matched, point
+			ORDER BY matched.admin_level DESC, ST_Area(matched.way) ASC
+			LIMIT 1
+		)
+		SELECT 
+			%s,
+			%s,
+			COUNT(DISTINCT m.admin_level) AS matched_levels,
+			COALESCE(MAX(s.admin_level), 0) AS smallest_level,
+			COALESCE(MAX(s.edge_distance), 0) AS edge_distance
+		FROM matched m
+		LEFT JOIN smallest s ON true
+	`, SRID4326, SRID3857, levelAreaExpr("(admin_level)::integer", "way"), planetPolygonTable, SRID4326, SRID4326,
+		countryCandidatesColumns("m"), addressLevelColumns("m"))
+
+	var region, province, subprovince, city, district, subdistrict, neighborhood sql.NullString
+	var countryIDs pq.Int64Array
+	var countryNames pq.StringArray
+	var match domain.ReverseGeocodeMatch
+
+	err := r.db.QueryRowContext(ctx, query, lon, lat, BoundaryExpansionDegrees).Scan(
+		&countryIDs, &countryNames, &region, &province, &subprovince, &city, &district, &subdistrict, &neighborhood,
+		&match.MatchedLevels, &match.SmallestAdminLevel, &match.EdgeDistanceMeters,
+	)
+	if err == sql.ErrNoRows || (err == nil && match.MatchedLevels == 0) {
+		return nil, pkgerrors.ErrLocationNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to reverse geocode with depth from osm",
+			zap.Float64("lat", lat),
+			zap.Float64("lon", lon),
+			zap.Error(err),
+		)
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	match.Address = domain.Address{
+		Region:   region.String,
+		Province: province.String,
+		City:     city.String,
+	}
+	match.Address.SetCountry(countryCandidates(countryIDs, countryNames), countryPriority)
+	if subprovince.Valid && subprovince.String != "" {
+		match.Address.Subprovince = &subprovince.String
+	}
+	if district.Valid && district.String != "" {
+		match.Address.District = &district.String
+	}
+	if subdistrict.Valid && subdistrict.String != "" {
+		match.Address.Subdistrict = &subdistrict.String
+	}
+	if neighborhood.Valid && neighborhood.String != "" {
+		match.Address.Neighborhood = &neighborhood.String
+	}
+
+	return &match, nil
+}
+
 // ReverseGeocodeBatch возвращает адреса для нескольких точек одним запросом (производительный батчевый метод)
 func (r *boundaryRepository) ReverseGeocodeBatch(
 	ctx context.Context,
@@ -267,8 +731,10 @@ func (r *boundaryRepository) ReverseGeocodeBatch(
 		boundaries_per_point AS (
 			SELECT 
 				ip.point_id,
+				b.osm_id,
 				(b.admin_level)::integer AS admin_level,
-				b.name
+				b.name,
+				%s
 			FROM input_points ip
 			JOIN %s b ON b.boundary = 'administrative'
 				AND b.admin_level IS NOT NULL
@@ -277,18 +743,14 @@ func (r *boundaryRepository) ReverseGeocodeBatch(
 		)
 		SELECT 
 			point_id,
-			MAX(CASE WHEN admin_level = 2 THEN name END) AS country,
-			MAX(CASE WHEN admin_level = 4 THEN name END) AS region,
-			MAX(CASE WHEN admin_level = 6 THEN name END) AS province,
-			MAX(CASE WHEN admin_level = 7 THEN name END) AS subprovince,
-			MAX(CASE WHEN admin_level = 8 THEN name END) AS city,
-			MAX(CASE WHEN admin_level = 9 THEN name END) AS district,
-			MAX(CASE WHEN admin_level = 10 THEN name END) AS subdistrict,
-			MAX(CASE WHEN admin_level = 11 THEN name END) AS neighborhood
+			%s,
+			%s
 		FROM boundaries_per_point
 		GROUP BY point_id
 		ORDER BY point_id
-	`, SRID4326, SRID4326, SRID3857, strings.Join(valueStrings, ","), planetPolygonTable, len(valueArgs)+1, SRID3857)
+	`, SRID4326, SRID4326, SRID3857, strings.Join(valueStrings, ","),
+		levelAreaExpr("ip.point_id, (b.admin_level)::integer", "b.way"), planetPolygonTable, len(valueArgs)+1, SRID3857,
+		countryCandidatesColumns(""), addressLevelColumns(""))
 
 	valueArgs = append(valueArgs, BoundaryExpansionDegrees)
 
@@ -304,10 +766,12 @@ func (r *boundaryRepository) ReverseGeocodeBatch(
 
 	for rows.Next() {
 		var pointID int
-		var country, region, province, subprovince, city, district, subdistrict, neighborhood sql.NullString
+		var region, province, subprovince, city, district, subdistrict, neighborhood sql.NullString
+		var countryIDs pq.Int64Array
+		var countryNames pq.StringArray
 
 		err := rows.Scan(
-			&pointID, &country, &region, &province, &subprovince, &city, &district, &subdistrict, &neighborhood,
+			&pointID, &countryIDs, &countryNames, &region, &province, &subprovince, &city, &district, &subdistrict, &neighborhood,
 		)
 		if err != nil {
 			r.logger.Error("failed to scan batch reverse geocode row", zap.Error(err))
@@ -318,11 +782,11 @@ func (r *boundaryRepository) ReverseGeocodeBatch(
 		idx := pointID - 1
 		if idx >= 0 && idx < len(results) {
 			addr := &domain.Address{
-				Country:  country.String,
 				Region:   region.String,
 				Province: province.String,
 				City:     city.String,
 			}
+			addr.SetCountry(countryCandidates(countryIDs, countryNames), countryPriority)
 
 			if subprovince.Valid && subprovince.String != "" {
 				addr.Subprovince = &subprovince.String
@@ -344,36 +808,39 @@ func (r *boundaryRepository) ReverseGeocodeBatch(
 	return results, nil
 }
 
-// GetByPoint возвращает административные границы для точки
-func (r *boundaryRepository) GetByPoint(ctx context.Context, lat, lon float64) ([]*domain.AdminBoundary, error) {
-	query := fmt.Sprintf(`
+// boundariesByPointQuery строит запрос GetByPoint. Параметры: $1 lon, $2 lat, $3 BoundaryExpansionDegrees.
+func boundariesByPointQuery(q QueryOptions) string {
+	return fmt.Sprintf(`
 		WITH point AS (
-			SELECT ST_Transform(ST_SetSRID(ST_MakePoint($1, $2), %d), %d) AS geom
+			SELECT ST_Transform(ST_SetSRID(ST_MakePoint($1, $2), %[1]d), %[2]d) AS geom
 		)
 		SELECT 
 			osm_id,
 			COALESCE(name, '') AS name,
-			COALESCE(NULLIF(tags->'name:en', ''), '') AS name_en,
-			COALESCE(NULLIF(tags->'name:es', ''), '') AS name_es,
-			COALESCE(NULLIF(tags->'name:ca', ''), '') AS name_ca,
-			COALESCE(NULLIF(tags->'name:ru', ''), '') AS name_ru,
-			COALESCE(NULLIF(tags->'name:uk', ''), '') AS name_uk,
-			COALESCE(NULLIF(tags->'name:fr', ''), '') AS name_fr,
-			COALESCE(NULLIF(tags->'name:pt', ''), '') AS name_pt,
-			COALESCE(NULLIF(tags->'name:it', ''), '') AS name_it,
-			COALESCE(NULLIF(tags->'name:de', ''), '') AS name_de,
+			%[3]s AS names,
 			COALESCE(boundary, 'administrative') AS type,
 			COALESCE((admin_level)::integer, 0) AS admin_level,
-			ST_Y(ST_Centroid(ST_Transform(way, %d))) AS center_lat,
-			ST_X(ST_Centroid(ST_Transform(way, %d))) AS center_lon,
-			ST_Area(ST_Transform(way, %d)::geography) / 1000000 AS area_sq_km
-		FROM %s, point
+			ST_Y(ST_Centroid(ST_Transform(%[4]s, %[1]d))) AS center_lat,
+			ST_X(ST_Centroid(ST_Transform(%[4]s, %[1]d))) AS center_lon,
+			ST_Area(ST_Transform(%[4]s, %[1]d)::geography) / 1000000 AS area_sq_km
+		FROM %[5]s, point
 		WHERE boundary = 'administrative'
 		  AND admin_level IS NOT NULL
 		  AND way && ST_Expand(point.geom, $3)
-		  AND ST_Contains(way, point.geom)
-		ORDER BY (admin_level)::integer ASC
-	`, SRID4326, SRID3857, SRID4326, SRID4326, SRID4326, planetPolygonTable)
+		  AND ST_Contains(%[4]s, point.geom)
+		ORDER BY (admin_level)::integer ASC, area_sq_km ASC, osm_id ASC
+	`, SRID4326, SRID3857, nameTranslationsExpr(""), q.boundaryContainsGeom(""), planetPolygonTable)
+}
+
+// GetByPoint возвращает административные границы для точки.
+// ST_Contains учитывает дыры мультиполигонов: точка внутри анклава относится к анклаву,
+// а не к окружающей его границе (у которой анклав вырезан внутренним кольцом).
+// Условие way && ST_Expand - только bbox-префильтр для индекса, принадлежность решает ST_Contains
+// (для крупных уровней - по упрощенной геометрии, если включено, см. QueryOptions.SimplifiedContains).
+// Пересекающиеся границы одного уровня возвращаются все, от меньшей площади к большей (затем по osm_id),
+// чтобы порядок не зависел от плана запроса; одну на уровень выбирает domain.PickBoundaryPerLevel.
+func (r *boundaryRepository) GetByPoint(ctx context.Context, lat, lon float64) ([]*domain.AdminBoundary, error) {
+	query := boundariesByPointQuery(r.queryOpts)
 
 	rows, err := r.db.QueryxContext(ctx, query, lon, lat, BoundaryExpansionDegrees)
 	if err != nil {
@@ -390,12 +857,11 @@ func (r *boundaryRepository) GetByPoint(ctx context.Context, lat, lon float64) (
 	for rows.Next() {
 		var b domain.AdminBoundary
 		var adminLevelInt int
+		var names []byte
 
 		err := rows.Scan(
 			&b.OSMId, &b.Name,
-			&b.NameEn, &b.NameEs, &b.NameCa,
-			&b.NameRu, &b.NameUk, &b.NameFr,
-			&b.NamePt, &b.NameIt, &b.NameDe,
+			&names,
 			&b.Type, &adminLevelInt,
 			&b.CenterLat, &b.CenterLon, &b.AreaSqKm,
 		)
@@ -405,6 +871,7 @@ func (r *boundaryRepository) GetByPoint(ctx context.Context, lat, lon float64) (
 		}
 
 		b.ID = b.OSMId
+		b.SetNames(parseNames(names))
 		b.AdminLevel = adminLevelInt
 
 		boundaries = append(boundaries, &b)
@@ -436,24 +903,16 @@ func (r *boundaryRepository) GetByPointBatch(ctx context.Context, points []domai
 				%d AS point_id,
 				osm_id,
 				COALESCE(name, '') AS name,
-				COALESCE(NULLIF(tags->'name:en', ''), '') AS name_en,
-				COALESCE(NULLIF(tags->'name:es', ''), '') AS name_es,
-				COALESCE(NULLIF(tags->'name:ca', ''), '') AS name_ca,
-				COALESCE(NULLIF(tags->'name:ru', ''), '') AS name_ru,
-				COALESCE(NULLIF(tags->'name:uk', ''), '') AS name_uk,
-				COALESCE(NULLIF(tags->'name:fr', ''), '') AS name_fr,
-				COALESCE(NULLIF(tags->'name:pt', ''), '') AS name_pt,
-				COALESCE(NULLIF(tags->'name:it', ''), '') AS name_it,
-				COALESCE(NULLIF(tags->'name:de', ''), '') AS name_de,
+				%s AS names,
 				COALESCE(boundary, 'administrative') AS type,
 				COALESCE((admin_level)::integer, 0) AS admin_level
 			FROM %s
 			WHERE boundary = 'administrative'
 			  AND admin_level IS NOT NULL
 			  AND (admin_level)::integer IN (2, 4, 6, 8, 9, 10)
-			  AND ST_Contains(way, ST_Transform(ST_SetSRID(ST_MakePoint($%d, $%d), %d), %d))
-		`, i+1, planetPolygonTable,
-			argIndex, argIndex+1, SRID4326, SRID3857)
+			  AND ST_Contains(%s, ST_Transform(ST_SetSRID(ST_MakePoint($%d, $%d), %d), %d))
+		`, i+1, nameTranslationsExpr(""), planetPolygonTable,
+			r.queryOpts.boundaryContainsGeom(""), argIndex, argIndex+1, SRID4326, SRID3857)
 
 		queryParts = append(queryParts, part)
 		args = append(args, point.Lon, point.Lat)
@@ -475,12 +934,11 @@ func (r *boundaryRepository) GetByPointBatch(ctx context.Context, points []domai
 		var pointID int
 		var b domain.AdminBoundary
 		var adminLevelInt int
+		var names []byte
 
 		err := rows.Scan(
 			&pointID, &b.OSMId, &b.Name,
-			&b.NameEn, &b.NameEs, &b.NameCa,
-			&b.NameRu, &b.NameUk, &b.NameFr,
-			&b.NamePt, &b.NameIt, &b.NameDe,
+			&names,
 			&b.Type, &adminLevelInt,
 		)
 		if err != nil {
@@ -489,6 +947,7 @@ func (r *boundaryRepository) GetByPointBatch(ctx context.Context, points []domai
 		}
 
 		b.ID = b.OSMId
+		b.SetNames(parseNames(names))
 		b.AdminLevel = adminLevelInt
 
 		// pointID начинается с 1, индексы массива с 0
@@ -503,7 +962,9 @@ func (r *boundaryRepository) GetByPointBatch(ctx context.Context, points []domai
 
 // SearchByTextBatch выполняет батчевый текстовый поиск для нескольких запросов одним SQL
 // Оптимизированная версия: использует exact match с индексами вместо медленного ILIKE
-// и пропускает дорогие вычисления (centroid, area) для максимальной скорости
+// и пропускает дорогие вычисления (centroid, area) для максимальной скорости.
+// Запрос с ParentID ищет только внутри родительской границы (ST_PointOnSurface, как GetChildren):
+// одноименные города других стран не подходят.
 func (r *boundaryRepository) SearchByTextBatch(ctx context.Context, requests []domain.BoundarySearchRequest) ([]domain.BoundarySearchResult, error) {
 	if len(requests) == 0 {
 		return []domain.BoundarySearchResult{}, nil
@@ -516,6 +977,18 @@ func (r *boundaryRepository) SearchByTextBatch(ctx context.Context, requests []d
 	argIndex := 1
 
 	for _, req := range requests {
+		parentFilter := ""
+		if req.ParentID != 0 {
+			parentFilter = fmt.Sprintf(`
+			  AND EXISTS (
+				SELECT 1 FROM %s p
+				WHERE p.osm_id = $%d
+				  AND p.boundary = 'administrative'
+				  AND p.way && b.way
+				  AND ST_Within(ST_PointOnSurface(b.way), p.way)
+			  )`, planetPolygonTable, argIndex+2)
+		}
+
 		// Оптимизация: сначала точное совпадение по name или переводам (использует индексы)
 		// Убраны ST_Centroid и ST_Area - они очень дорогие и не критичны для результата
 		part := fmt.Sprintf(`
@@ -523,18 +996,10 @@ func (r *boundaryRepository) SearchByTextBatch(ctx context.Context, requests []d
 				%d AS req_index,
 				osm_id,
 				COALESCE(name, '') AS name,
-				COALESCE(NULLIF(tags->'name:en', ''), '') AS name_en,
-				COALESCE(NULLIF(tags->'name:es', ''), '') AS name_es,
-				COALESCE(NULLIF(tags->'name:ca', ''), '') AS name_ca,
-				COALESCE(NULLIF(tags->'name:ru', ''), '') AS name_ru,
-				COALESCE(NULLIF(tags->'name:uk', ''), '') AS name_uk,
-				COALESCE(NULLIF(tags->'name:fr', ''), '') AS name_fr,
-				COALESCE(NULLIF(tags->'name:pt', ''), '') AS name_pt,
-				COALESCE(NULLIF(tags->'name:it', ''), '') AS name_it,
-				COALESCE(NULLIF(tags->'name:de', ''), '') AS name_de,
+				%s AS names,
 				COALESCE(boundary, 'administrative') AS type,
 				COALESCE((admin_level)::integer, 0) AS admin_level
-			FROM %s
+			FROM %s b
 			WHERE boundary = 'administrative'
 			  AND admin_level IS NOT NULL
 			  AND (admin_level)::integer = $%d
@@ -545,19 +1010,23 @@ func (r *boundaryRepository) SearchByTextBatch(ctx context.Context, requests []d
 				OR tags->'name:ru' = $%d
 				OR tags->'name:ca' = $%d
 				OR LOWER(name) = LOWER($%d)
-			  )
+			  )%s
 			ORDER BY 
 				CASE WHEN name = $%d THEN 0
 				     WHEN LOWER(name) = LOWER($%d) THEN 1
 				     ELSE 2 END,
 				name ASC
 			LIMIT 1)
-		`, req.Index, planetPolygonTable,
-			argIndex, argIndex+1, argIndex+1, argIndex+1, argIndex+1, argIndex+1, argIndex+1, argIndex+1, argIndex+1)
+		`, req.Index, nameTranslationsExpr(""), planetPolygonTable,
+			argIndex, argIndex+1, argIndex+1, argIndex+1, argIndex+1, argIndex+1, argIndex+1, parentFilter, argIndex+1, argIndex+1)
 
 		queryParts = append(queryParts, part)
 		args = append(args, req.AdminLevel, req.Name)
 		argIndex += 2
+		if req.ParentID != 0 {
+			args = append(args, req.ParentID)
+			argIndex++
+		}
 	}
 
 	query := strings.Join(queryParts, " UNION ALL ")
@@ -589,12 +1058,11 @@ func (r *boundaryRepository) SearchByTextBatch(ctx context.Context, requests []d
 		var reqIndex int
 		var b domain.AdminBoundary
 		var adminLevelInt int
+		var names []byte
 
 		err := rows.Scan(
 			&reqIndex, &b.OSMId, &b.Name,
-			&b.NameEn, &b.NameEs, &b.NameCa,
-			&b.NameRu, &b.NameUk, &b.NameFr,
-			&b.NamePt, &b.NameIt, &b.NameDe,
+			&names,
 			&b.Type, &adminLevelInt,
 		)
 		if err != nil {
@@ -603,6 +1071,7 @@ func (r *boundaryRepository) SearchByTextBatch(ctx context.Context, requests []d
 		}
 
 		b.ID = b.OSMId
+		b.SetNames(parseNames(names))
 		b.AdminLevel = adminLevelInt
 
 		// Обновляем результат
@@ -615,7 +1084,107 @@ func (r *boundaryRepository) SearchByTextBatch(ctx context.Context, requests []d
 	return results, nil
 }
 
-// GetChildren возвращает дочерние границы для родительской (в OSM данных связи parent-child могут отсутствовать)
+// LookupAddresses находит точки адресов внутри границ-областей одним запросом.
+// Полигон каждой области читается один раз (CTE scope), для каждого адреса по очереди:
+// дом (точка или полигон с addr:street и addr:housenumber, при совпавшем addr:postcode - он),
+// улица (точка на именованной дороге ближе всего к ее центру), иначе ST_PointOnSurface области.
+// Адреса с ненайденной областью в результат не попадают.
+func (r *boundaryRepository) LookupAddresses(ctx context.Context, lookups []domain.AddressLookup) ([]domain.AddressLookupResult, error) {
+	if len(lookups) == 0 {
+		return []domain.AddressLookupResult{}, nil
+	}
+
+	indices := make([]int64, len(lookups))
+	scopeIDs := make([]int64, len(lookups))
+	streets := make([]string, len(lookups))
+	houseNumbers := make([]string, len(lookups))
+	postalCodes := make([]string, len(lookups))
+	for i, l := range lookups {
+		indices[i] = int64(l.Index)
+		scopeIDs[i] = l.ScopeID
+		streets[i] = l.Street
+		houseNumbers[i] = l.HouseNumber
+		postalCodes[i] = l.PostalCode
+	}
+
+	query := fmt.Sprintf(`
+		WITH q AS (
+			SELECT * FROM unnest($1::int[], $2::bigint[], $3::text[], $4::text[], $5::text[])
+				AS q(idx, scope_id, street, housenumber, postcode)
+		),
+		scope AS (
+			SELECT DISTINCT ON (osm_id) osm_id, way
+			FROM %[3]s
+			WHERE osm_id IN (SELECT DISTINCT scope_id FROM q)
+			  AND boundary = 'administrative'
+			ORDER BY osm_id, ST_Area(way) DESC
+		)
+		SELECT
+			q.idx,
+			ST_Y(ST_Transform(COALESCE(h.geom, st.geom, ST_PointOnSurface(scope.way)), %[4]d)) AS lat,
+			ST_X(ST_Transform(COALESCE(h.geom, st.geom, ST_PointOnSurface(scope.way)), %[4]d)) AS lon,
+			CASE WHEN h.geom IS NOT NULL THEN '%[5]s'
+			     WHEN st.geom IS NOT NULL THEN '%[6]s'
+			     ELSE '%[7]s' END AS precision
+		FROM q
+		JOIN scope ON scope.osm_id = q.scope_id
+		LEFT JOIN LATERAL (
+			SELECT ST_PointOnSurface(a.way) AS geom
+			FROM (
+				SELECT way, tags FROM %[1]s
+				WHERE tags->'addr:housenumber' = q.housenumber AND way && scope.way
+				UNION ALL
+				SELECT way, tags FROM %[3]s
+				WHERE tags->'addr:housenumber' = q.housenumber AND way && scope.way
+			) a
+			WHERE q.street <> '' AND q.housenumber <> ''
+			  AND LOWER(a.tags->'addr:street') = LOWER(q.street)
+			  AND ST_Intersects(a.way, scope.way)
+			ORDER BY COALESCE(a.tags->'addr:postcode' = q.postcode, FALSE) DESC
+			LIMIT 1
+		) h ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT ST_ClosestPoint(ST_Collect(l.way), ST_Centroid(ST_Collect(l.way))) AS geom
+			FROM %[2]s l
+			WHERE q.street <> '' AND h.geom IS NULL
+			  AND l.highway IS NOT NULL
+			  AND LOWER(l.name) = LOWER(q.street)
+			  AND l.way && scope.way
+			  AND ST_Intersects(l.way, scope.way)
+			HAVING COUNT(*) > 0
+		) st ON TRUE
+		ORDER BY q.idx
+	`, planetPointTable, planetLineTable, planetPolygonTable, SRID4326,
+		domain.GeocodePrecisionHouse, domain.GeocodePrecisionStreet, domain.GeocodePrecisionBoundary)
+
+	rows, err := r.db.QueryxContext(ctx, query,
+		pq.Array(indices), pq.Array(scopeIDs), pq.Array(streets), pq.Array(houseNumbers), pq.Array(postalCodes))
+	if err != nil {
+		r.logger.Error("failed to lookup addresses", zap.Int("lookups_count", len(lookups)), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	results := make([]domain.AddressLookupResult, 0, len(lookups))
+	for rows.Next() {
+		var res domain.AddressLookupResult
+		if err := rows.Scan(&res.Index, &res.Lat, &res.Lon, &res.Precision); err != nil {
+			r.logger.Error("failed to scan address lookup row", zap.Error(err))
+			continue
+		}
+		results = append(results, res)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to iterate address lookup rows", zap.Int("lookups_count", len(lookups)), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return results, nil
+}
+
+// GetChildren возвращает дочерние границы для родительской (в OSM данных связи parent-child могут отсутствовать).
+// Принадлежность проверяется по ST_PointOnSurface: в отличие от центроида эта точка всегда лежит
+// внутри полигона, поэтому граница-«бублик» не теряется, когда ее центроид попадает в дыру (анклав).
 func (r *boundaryRepository) GetChildren(ctx context.Context, parentID int64) ([]*domain.AdminBoundary, error) {
 	// В OSM данных нет явной связи parent_id, нужно искать через геометрию
 	// Ищем границы следующего уровня, которые содержатся в родительской
@@ -641,7 +1210,8 @@ func (r *boundaryRepository) GetChildren(ctx context.Context, parentID int64) ([
 		  AND b.admin_level IS NOT NULL
 		  AND b.osm_id != $1
 		  AND (b.admin_level)::integer > parent.parent_level
-		  AND ST_Within(ST_Centroid(b.way), parent.way)
+		  AND b.way && parent.way
+		  AND ST_Within(ST_PointOnSurface(b.way), parent.way)
 		ORDER BY (b.admin_level)::integer ASC, b.name ASC
 		LIMIT $2
 	`, planetPolygonTable, SRID4326, SRID4326, SRID4326, planetPolygonTable)
@@ -681,9 +1251,26 @@ func (r *boundaryRepository) GetChildren(ctx context.Context, parentID int64) ([
 
 // GetByAdminLevel возвращает границы определенного административного уровня
 func (r *boundaryRepository) GetByAdminLevel(ctx context.Context, level int, limit int) ([]*domain.AdminBoundary, error) {
+	return r.GetByAdminLevels(ctx, []int{level}, limit)
+}
+
+// GetByAdminLevels возвращает границы нескольких административных уровней одним запросом,
+// отсортированные по уровню, затем по имени
+func (r *boundaryRepository) GetByAdminLevels(ctx context.Context, levels []int, limit int) ([]*domain.AdminBoundary, error) {
 	if limit <= 0 || limit > LimitBoundaries {
 		limit = LimitBoundaries
 	}
+	if len(levels) == 0 {
+		return []*domain.AdminBoundary{}, nil
+	}
+
+	args := make([]interface{}, 0, len(levels)+1)
+	placeholders := make([]string, len(levels))
+	for i, level := range levels {
+		args = append(args, level)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+	args = append(args, limit)
 
 	query := fmt.Sprintf(`
 		SELECT 
@@ -698,15 +1285,15 @@ func (r *boundaryRepository) GetByAdminLevel(ctx context.Context, level int, lim
 		FROM %s
 		WHERE boundary = 'administrative'
 		  AND admin_level IS NOT NULL
-		  AND (admin_level)::integer = $1
-		ORDER BY name ASC
-		LIMIT $2
-	`, SRID4326, SRID4326, SRID4326, planetPolygonTable)
+		  AND (admin_level)::integer IN (%s)
+		ORDER BY (admin_level)::integer ASC, name ASC
+		LIMIT $%d
+	`, SRID4326, SRID4326, SRID4326, planetPolygonTable, strings.Join(placeholders, ","), len(args))
 
-	rows, err := r.db.QueryxContext(ctx, query, level, limit)
+	rows, err := r.db.QueryxContext(ctx, query, args...)
 	if err != nil {
-		r.logger.Error("failed to get osm boundaries by admin level",
-			zap.Int("level", level),
+		r.logger.Error("failed to get osm boundaries by admin levels",
+			zap.Ints("levels", levels),
 			zap.Error(err),
 		)
 		return nil, pkgerrors.ErrDatabaseError
@@ -802,13 +1389,161 @@ func (r *boundaryRepository) GetBoundariesInRadius(ctx context.Context, lat, lon
 	return boundaries, nil
 }
 
+// GetNearestBoundaries возвращает ближайшие границы уровня без требования, чтобы полигон содержал точку.
+// Кандидаты выбираются KNN по индексу (way <-> точка), расстояние считается по geography.
+func (r *boundaryRepository) GetNearestBoundaries(ctx context.Context, lat, lon float64, level int, limit int) ([]domain.NearestBoundary, error) {
+	query := fmt.Sprintf(`
+		WITH point AS (
+			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d) AS geom
+		),
+		candidates AS (
+			SELECT osm_id, name, tags, boundary, admin_level, way
+			FROM %s
+			WHERE boundary = 'administrative'
+			  AND admin_level = $3
+			ORDER BY way <-> (SELECT ST_Transform(geom, %d) FROM point)
+			LIMIT $4
+		)
+		SELECT
+			osm_id,
+			COALESCE(name, '') AS name,
+			%s AS names,
+			COALESCE(boundary, 'administrative') AS type,
+			(admin_level)::integer AS admin_level,
+			ST_Y(ST_Centroid(ST_Transform(way, %d))) AS center_lat,
+			ST_X(ST_Centroid(ST_Transform(way, %d))) AS center_lon,
+			COALESCE((tags->'population')::bigint, 0) AS population,
+			ST_Area(ST_Transform(way, %d)::geography) / 1000000 AS area_sq_km,
+			ST_Distance(ST_Transform(way, %d)::geography, point.geom::geography) AS distance_m,
+			ST_Intersects(way, ST_Transform(point.geom, %d)) AS contains
+		FROM candidates, point
+		ORDER BY distance_m, area_sq_km
+	`, SRID4326, planetPolygonTable, SRID3857, nameTranslationsExpr(""),
+		SRID4326, SRID4326, SRID4326, SRID4326, SRID3857)
+
+	rows, err := r.db.QueryxContext(ctx, query, lon, lat, strconv.Itoa(level), limit)
+	if err != nil {
+		r.logger.Error("failed to get nearest osm boundaries",
+			zap.Float64("lat", lat),
+			zap.Float64("lon", lon),
+			zap.Int("level", level),
+			zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	boundaries := make([]domain.NearestBoundary, 0, limit)
+	for rows.Next() {
+		var nb domain.NearestBoundary
+		var population int64
+		var names []byte
+
+		if err := rows.Scan(
+			&nb.OSMId, &nb.Name, &names, &nb.Type, &nb.AdminLevel,
+			&nb.CenterLat, &nb.CenterLon, &population, &nb.AreaSqKm,
+			&nb.DistanceM, &nb.Contains,
+		); err != nil {
+			r.logger.Error("failed to scan nearest boundary row", zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+
+		nb.ID = nb.OSMId
+		nb.SetNames(parseNames(names))
+		if population > 0 {
+			populationInt := int(population)
+			nb.Population = &populationInt
+		}
+		boundaries = append(boundaries, nb)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to iterate nearest boundaries", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return boundaries, nil
+}
+
+// nearestPlaceCandidates - число ближайших по KNN (в проекции 3857) точек place, среди которых
+// ближайшая выбирается по geography: порядок в Меркаторе искажен по широте
+const nearestPlaceCandidates = 10
+
+// GetNearestPlace возвращает ближайшую точку населенного пункта (place=city|town|village|hamlet).
+// Кандидаты выбираются KNN по индексу, расстояние считается по geography.
+func (r *boundaryRepository) GetNearestPlace(ctx context.Context, lat, lon float64) (*domain.Place, error) {
+	query := fmt.Sprintf(`
+		WITH point AS (
+			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d) AS geom
+		),
+		candidates AS (
+			SELECT osm_id, name, place, tags, way
+			FROM %s
+			WHERE place = ANY($3)
+			  AND name IS NOT NULL AND name != ''
+			  AND %s
+			ORDER BY way <-> (SELECT ST_Transform(geom, %d) FROM point)
+			LIMIT $4
+		)
+		SELECT
+			osm_id,
+			name,
+			place,
+			%s AS population,
+			ST_Y(ST_Transform(way, %d)) AS lat,
+			ST_X(ST_Transform(way, %d)) AS lon,
+			ST_Distance(ST_Transform(way, %d)::geography, point.geom::geography) AS distance_m
+		FROM candidates, point
+		ORDER BY distance_m
+		LIMIT 1
+	`, SRID4326, planetPointTable, r.queryOpts.activeFeatureCondition(""), SRID3857, boundaryPopulationExpr,
+		SRID4326, SRID4326, SRID4326)
+
+	var place domain.Place
+	var population sql.NullInt64
+	err := r.db.QueryRowxContext(ctx, query, lon, lat, pq.Array(domain.PlaceTypes), nearestPlaceCandidates).Scan(
+		&place.ID, &place.Name, &place.Type, &population, &place.Lat, &place.Lon, &place.DistanceM,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to get nearest place",
+			zap.Float64("lat", lat),
+			zap.Float64("lon", lon),
+			zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	if population.Valid && population.Int64 > 0 {
+		populationInt := int(population.Int64)
+		place.Population = &populationInt
+	}
+
+	return &place, nil
+}
+
 // GetTile - генерация MVT тайла с полигонами административных границ
-func (r *boundaryRepository) GetTile(ctx context.Context, z, x, y int) ([]byte, error) {
+func (r *boundaryRepository) GetTile(ctx context.Context, z, x, y int, minAreaSqKm float64) ([]byte, error) {
 	// Валидация уровня зума
 	if z < 0 || z > 18 {
 		r.logger.Warn("Invalid zoom level for boundary tile", zap.Int("z", z))
 		return []byte{}, nil
 	}
+	if !tileZoomPolicy.LayerVisible(domain.TileLayerBoundaries, z) {
+		return []byte{}, nil
+	}
+	geomExpr := tileGeomExpr("way", z)
+
+	// Отсекаем полигоны-«осколки», невидимые на текущем зуме: планарная площадь way (3857)
+	// сравнивается с порогом, пересчитанным на широту тайла
+	if minAreaSqKm <= 0 {
+		minAreaSqKm = boundaryMinAreaSqKmByZoom(z)
+	}
+	args := []interface{}{z, x, y}
+	areaFilter := "TRUE"
+	if minAreaSqKm > 0 {
+		args = append(args, boundaryPlanarMinArea(z, y, minAreaSqKm))
+		areaFilter = "ST_Area(way) >= $4::float8"
+	}
 
 	// Динамический фильтр уровней границ в зависимости от зума
 	// До z=12: показываем только ОДИН уровень без наложений
@@ -844,18 +1579,9 @@ func (r *boundaryRepository) GetTile(ctx context.Context, z, x, y int) ([]byte,
 			),
 			mvt_geom AS (
 				SELECT
-					osm_id,
-					COALESCE(name, '') AS name,
-					COALESCE(NULLIF(tags->'name:en', ''), '') AS name_en,
-					COALESCE(NULLIF(tags->'name:es', ''), '') AS name_es,
-					COALESCE(NULLIF(tags->'name:ca', ''), '') AS name_ca,
-					COALESCE(NULLIF(tags->'name:ru', ''), '') AS name_ru,
-					COALESCE(NULLIF(tags->'wikidata', ''), '') AS wikidata,
-					COALESCE(boundary, 'administrative') AS boundary_type,
-					(admin_level)::integer AS admin_level,
-					COALESCE((tags->'population')::bigint, 0) AS population,
+					%s
 					ST_AsMVTGeom(
-						ST_Transform(way, %d),
+						ST_Transform(%s, %d),
 						tile_bounds.geom,
 						%d,
 						%d,
@@ -866,11 +1592,13 @@ func (r *boundaryRepository) GetTile(ctx context.Context, z, x, y int) ([]byte,
 				  AND admin_level IS NOT NULL
 				  AND %s
 				  AND ST_Intersects(way, ST_Transform(tile_bounds.geom, %d))
+				  AND %s
 			)
-			SELECT ST_AsMVT(mvt_geom.*, 'boundaries', %d, 'geom')
+			SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'boundaries', %d, 'geom'), %s)
 			FROM mvt_geom
 			WHERE geom IS NOT NULL
-		`, SRID3857, MVTExtent, MVTBuffer, planetPolygonTable, adminLevelFilter, SRID3857, MVTExtent)
+		`, tileAttributeColumns(string(domain.TileLayerBoundaries)), geomExpr, SRID3857, MVTExtent, MVTBuffer, planetPolygonTable,
+			adminLevelFilter, SRID3857, areaFilter, MVTExtent, emptyTileSQL)
 	} else {
 		// После зума 12 - используем ST_Difference для вырезания
 		query = fmt.Sprintf(`
@@ -880,36 +1608,19 @@ func (r *boundaryRepository) GetTile(ctx context.Context, z, x, y int) ([]byte,
 			-- Выбираем все границы нужных уровней
 			all_boundaries AS (
 				SELECT
-					osm_id,
-					COALESCE(name, '') AS name,
-					COALESCE(NULLIF(tags->'name:en', ''), '') AS name_en,
-					COALESCE(NULLIF(tags->'name:es', ''), '') AS name_es,
-					COALESCE(NULLIF(tags->'name:ca', ''), '') AS name_ca,
-					COALESCE(NULLIF(tags->'name:ru', ''), '') AS name_ru,
-					COALESCE(NULLIF(tags->'wikidata', ''), '') AS wikidata,
-					COALESCE(boundary, 'administrative') AS boundary_type,
-					(admin_level)::integer AS admin_level,
-					COALESCE((tags->'population')::bigint, 0) AS population,
+					%s
 					way
 				FROM %s, tile_bounds
 				WHERE boundary = 'administrative'
 				  AND admin_level IS NOT NULL
 				  AND (admin_level)::integer IN (8, 9, 10)
 				  AND ST_Intersects(way, ST_Transform(tile_bounds.geom, %d))
+				  AND %s
 			),
 			-- Вырезаем более детальные границы из крупных
 			boundaries_with_holes AS (
 				SELECT
-					b1.osm_id,
-					b1.name,
-					b1.name_en,
-					b1.name_es,
-					b1.name_ca,
-					b1.name_ru,
-					b1.wikidata,
-					b1.boundary_type,
-					b1.admin_level,
-					b1.population,
+					%s
 					CASE 
 						-- Для уровня 8: вырезаем все районы (9) и кварталы (10)
 						WHEN b1.admin_level = 8 THEN
@@ -940,18 +1651,9 @@ func (r *boundaryRepository) GetTile(ctx context.Context, z, x, y int) ([]byte,
 			),
 			mvt_geom AS (
 				SELECT
-					osm_id,
-					name,
-					name_en,
-					name_es,
-					name_ca,
-					name_ru,
-					wikidata,
-					boundary_type,
-					admin_level,
-					population,
+					%s
 					ST_AsMVTGeom(
-						ST_Transform(way, %d),
+						ST_Transform(%s, %d),
 						(SELECT geom FROM tile_bounds),
 						%d,
 						%d,
@@ -960,24 +1662,17 @@ func (r *boundaryRepository) GetTile(ctx context.Context, z, x, y int) ([]byte,
 				FROM boundaries_with_holes
 				WHERE NOT ST_IsEmpty(way)
 			)
-			SELECT ST_AsMVT(mvt_geom.*, 'boundaries', %d, 'geom')
+			SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'boundaries', %d, 'geom'), %s)
 			FROM mvt_geom
 			WHERE geom IS NOT NULL
-		`, planetPolygonTable, SRID3857, SRID3857, MVTExtent, MVTBuffer, MVTExtent)
+		`, tileAttributeColumns(string(domain.TileLayerBoundaries)), planetPolygonTable, SRID3857, areaFilter,
+			tileAttributeNames(string(domain.TileLayerBoundaries), "b1"), tileAttributeNames(string(domain.TileLayerBoundaries), ""),
+			geomExpr, SRID3857, MVTExtent, MVTBuffer, MVTExtent, emptyTileSQL)
 	}
 
 	var tile []byte
-	err := r.db.QueryRowxContext(ctx, query, z, x, y).Scan(&tile)
-
-	if err == sql.ErrNoRows || len(tile) == 0 {
-		r.logger.Debug("Empty boundary tile",
-			zap.Int("z", z),
-			zap.Int("x", x),
-			zap.Int("y", y))
-		return []byte{}, nil
-	}
-
-	if err != nil {
+	err := r.readDB.QueryRowxContext(ctx, query, args...).Scan(&tile)
+	if err != nil && err != sql.ErrNoRows {
 		r.logger.Error("failed to generate boundary tile",
 			zap.Int("z", z),
 			zap.Int("x", x),
@@ -986,6 +1681,15 @@ func (r *boundaryRepository) GetTile(ctx context.Context, z, x, y int) ([]byte,
 		return nil, pkgerrors.ErrDatabaseError
 	}
 
+	// Пустой тайл - валидный MVT без слоев, а не ошибка
+	if len(tile) == 0 {
+		r.logger.Debug("Empty boundary tile",
+			zap.Int("z", z),
+			zap.Int("x", x),
+			zap.Int("y", y))
+		return []byte{}, nil
+	}
+
 	r.logger.Debug("Boundary tile generated successfully",
 		zap.Int("z", z),
 		zap.Int("x", x),
@@ -1000,3 +1704,159 @@ func (r *boundaryRepository) GetBoundariesRadiusTile(ctx context.Context, lat, l
 	r.logger.Warn("GetBoundariesRadiusTile not implemented for OSM boundary repository")
 	return []byte{}, nil
 }
+
+// GetAdminLevels возвращает admin_level, присутствующие в planet_osm_polygon, с количеством границ.
+// Пример названия - самая большая по площади именованная граница уровня (страна, крупнейший город),
+// чтобы по нему было понятно, что хранится на уровне в этом импорте.
+func (r *boundaryRepository) GetAdminLevels(ctx context.Context) ([]domain.AdminLevelInfo, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			admin_level::integer AS level,
+			COUNT(*) AS count,
+			COALESCE((array_agg(name ORDER BY ST_Area(way) DESC, osm_id) FILTER (WHERE name <> ''))[1], '') AS example_name
+		FROM %s
+		WHERE boundary = 'administrative'
+		  AND admin_level ~ '^[0-9]+$'
+		GROUP BY 1
+		ORDER BY 1
+	`, planetPolygonTable)
+
+	var levels []domain.AdminLevelInfo
+	if err := r.readDB.SelectContext(ctx, &levels, query); err != nil {
+		r.logger.Error("failed to get osm admin levels", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	return levels, nil
+}
+
+// GetDataCoverage возвращает охват данных: bbox точечной таблицы (ST_Extent), количество
+// административных границ по уровням и, при gridSize > 0, сетку с количеством точек в ячейках.
+// Пустая таблица точек дает ErrLocationNotFound.
+func (r *boundaryRepository) GetDataCoverage(ctx context.Context, gridSize int) (*domain.DataCoverage, error) {
+	extentQuery := fmt.Sprintf(`
+		SELECT
+			ST_YMin(e) AS min_lat,
+			ST_XMin(e) AS min_lon,
+			ST_YMax(e) AS max_lat,
+			ST_XMax(e) AS max_lon,
+			total
+		FROM (
+			SELECT ST_Transform(ST_SetSRID(ST_Extent(way)::geometry, %d), %d) AS e, COUNT(*) AS total
+			FROM %s
+		) s
+		WHERE e IS NOT NULL
+	`, SRID3857, SRID4326, planetPointTable)
+
+	coverage := &domain.DataCoverage{ByAdminLevel: make(map[int]int)}
+	err := r.readDB.QueryRowxContext(ctx, extentQuery).Scan(
+		&coverage.BBox.MinLat, &coverage.BBox.MinLon, &coverage.BBox.MaxLat, &coverage.BBox.MaxLon,
+		&coverage.TotalPoints,
+	)
+	if err == sql.ErrNoRows {
+		return nil, pkgerrors.ErrLocationNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get osm data extent", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	coverage.CenterLat = (coverage.BBox.MinLat + coverage.BBox.MaxLat) / 2
+	coverage.CenterLon = (coverage.BBox.MinLon + coverage.BBox.MaxLon) / 2
+
+	levelsQuery := fmt.Sprintf(`
+		SELECT admin_level::integer AS level, COUNT(*) AS cnt
+		FROM %s
+		WHERE boundary = 'administrative'
+		  AND admin_level ~ '^[0-9]+$'
+		GROUP BY 1
+	`, planetPolygonTable)
+
+	levelRows, err := r.readDB.QueryxContext(ctx, levelsQuery)
+	if err != nil {
+		r.logger.Error("failed to count osm boundaries by level", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer levelRows.Close()
+
+	for levelRows.Next() {
+		var level, count int
+		if err := levelRows.Scan(&level, &count); err != nil {
+			r.logger.Error("failed to scan boundary level count", zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+		coverage.ByAdminLevel[level] = count
+	}
+	if err := levelRows.Err(); err != nil {
+		r.logger.Error("failed to iterate boundary level counts", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	bbox := coverage.BBox
+	// Вырожденный охват (одна точка или линия) сеткой не делится
+	if gridSize <= 0 || bbox.MinLat == bbox.MaxLat || bbox.MinLon == bbox.MaxLon {
+		return coverage, nil
+	}
+
+	grid := &domain.CoverageGrid{
+		Size:       gridSize,
+		CellWidth:  (bbox.MaxLon - bbox.MinLon) / float64(gridSize),
+		CellHeight: (bbox.MaxLat - bbox.MinLat) / float64(gridSize),
+	}
+
+	// width_bucket относит точку на верхней границе к ячейке gridSize+1, LEAST возвращает ее в последнюю
+	gridQuery := fmt.Sprintf(`
+		SELECT
+			LEAST(width_bucket(ST_Y(geom), $1, $2, $5), $5) - 1 AS row_idx,
+			LEAST(width_bucket(ST_X(geom), $3, $4, $5), $5) - 1 AS col_idx,
+			COUNT(*) AS cnt
+		FROM (SELECT ST_Transform(way, %d) AS geom FROM %s) p
+		GROUP BY 1, 2
+	`, SRID4326, planetPointTable)
+
+	counts := make(map[[2]int]int64)
+	gridRows, err := r.readDB.QueryxContext(ctx, gridQuery, bbox.MinLat, bbox.MaxLat, bbox.MinLon, bbox.MaxLon, gridSize)
+	if err != nil {
+		r.logger.Error("failed to get osm coverage grid", zap.Int("grid_size", gridSize), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer gridRows.Close()
+
+	for gridRows.Next() {
+		var row, col int
+		var count int64
+		if err := gridRows.Scan(&row, &col, &count); err != nil {
+			r.logger.Error("failed to scan coverage grid cell", zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+		counts[[2]int{row, col}] = count
+	}
+	if err := gridRows.Err(); err != nil {
+		r.logger.Error("failed to iterate coverage grid cells", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	grid.Cells = make([]domain.CoverageCell, 0, gridSize*gridSize)
+	for row := 0; row < gridSize; row++ {
+		for col := 0; col < gridSize; col++ {
+			count := counts[[2]int{row, col}]
+			cell := domain.CoverageCell{
+				Row: row,
+				Col: col,
+				BBox: domain.BoundingBox{
+					MinLat: bbox.MinLat + float64(row)*grid.CellHeight,
+					MinLon: bbox.MinLon + float64(col)*grid.CellWidth,
+					MaxLat: bbox.MinLat + float64(row+1)*grid.CellHeight,
+					MaxLon: bbox.MinLon + float64(col+1)*grid.CellWidth,
+				},
+				Points: count,
+				Empty:  count == 0,
+			}
+			if cell.Empty {
+				grid.EmptyCells++
+			}
+			grid.Cells = append(grid.Cells, cell)
+		}
+	}
+	coverage.Grid = grid
+
+	return coverage, nil
+}
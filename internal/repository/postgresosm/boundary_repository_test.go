@@ -2,6 +2,7 @@ package postgresosm
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/location-microservice/internal/domain"
@@ -13,7 +14,7 @@ func TestBoundaryRepository_GetByID(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get existing boundary by ID", func(t *testing.T) {
@@ -64,12 +65,163 @@ func TestBoundaryRepository_GetByID(t *testing.T) {
 	})
 }
 
+func TestBoundaryRepository_GetAdminLevels(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	levels, err := repo.GetAdminLevels(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get admin levels: %v", err)
+	}
+	if len(levels) == 0 {
+		t.Fatal("Expected at least one admin level")
+	}
+
+	coverage, err := repo.GetDataCoverage(ctx, 0)
+	if err != nil {
+		t.Fatalf("Failed to get data coverage: %v", err)
+	}
+	for i, level := range levels {
+		if i > 0 && levels[i-1].Level >= level.Level {
+			t.Errorf("Expected levels in ascending order, got %d after %d", level.Level, levels[i-1].Level)
+		}
+		if level.Count != coverage.ByAdminLevel[level.Level] {
+			t.Errorf("Level %d: expected count %d, got %d", level.Level, coverage.ByAdminLevel[level.Level], level.Count)
+		}
+	}
+}
+
+func TestBoundaryRepository_GetDataCoverage(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	coverage, err := repo.GetDataCoverage(ctx, 4)
+	if err != nil {
+		t.Fatalf("Failed to get data coverage: %v", err)
+	}
+
+	assertValidCoordinates(t, coverage.BBox.MinLat, coverage.BBox.MinLon)
+	assertValidCoordinates(t, coverage.BBox.MaxLat, coverage.BBox.MaxLon)
+	if coverage.TotalPoints <= 0 {
+		t.Errorf("Expected positive point count, got %d", coverage.TotalPoints)
+	}
+	if coverage.Grid == nil {
+		return
+	}
+
+	if len(coverage.Grid.Cells) != 16 {
+		t.Fatalf("Expected 16 grid cells, got %d", len(coverage.Grid.Cells))
+	}
+	var points int64
+	empty := 0
+	for _, cell := range coverage.Grid.Cells {
+		points += cell.Points
+		if cell.Empty {
+			empty++
+		}
+	}
+	if points != coverage.TotalPoints {
+		t.Errorf("Expected grid points %d to equal total %d", points, coverage.TotalPoints)
+	}
+	if empty != coverage.Grid.EmptyCells {
+		t.Errorf("Expected %d empty cells, got %d", coverage.Grid.EmptyCells, empty)
+	}
+}
+
+func TestBoundaryRepository_GetBoundaryBBox(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("BBox contains centroid", func(t *testing.T) {
+		var osmID int64
+		query := `SELECT osm_id FROM planet_osm_polygon 
+				  WHERE boundary = 'administrative' 
+				  AND admin_level IS NOT NULL 
+				  LIMIT 1`
+		err := db.QueryRowContext(ctx, query).Scan(&osmID)
+		if err != nil {
+			t.Skipf("No boundaries found in database: %v", err)
+		}
+
+		bbox, err := repo.GetBoundaryBBox(ctx, osmID)
+		if err != nil {
+			t.Fatalf("Failed to get boundary bbox: %v", err)
+		}
+
+		assertValidCoordinates(t, bbox.BBox.MinLat, bbox.BBox.MinLon)
+		assertValidCoordinates(t, bbox.BBox.MaxLat, bbox.BBox.MaxLon)
+		if bbox.BBox.MinLat > bbox.BBox.MaxLat || bbox.BBox.MinLon > bbox.BBox.MaxLon {
+			t.Errorf("Invalid bbox: %+v", bbox.BBox)
+		}
+		if bbox.CenterLat < bbox.BBox.MinLat || bbox.CenterLat > bbox.BBox.MaxLat {
+			t.Errorf("Centroid latitude %f outside bbox %+v", bbox.CenterLat, bbox.BBox)
+		}
+	})
+
+	t.Run("Non-existing boundary", func(t *testing.T) {
+		_, err := repo.GetBoundaryBBox(ctx, -99999999)
+		if err != pkgerrors.ErrLocationNotFound {
+			t.Errorf("Expected ErrLocationNotFound, got %v", err)
+		}
+	})
+}
+
+func TestBoundaryRepository_GetBoundariesIntersectingPolygon(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("Polygon inside Barcelona", func(t *testing.T) {
+		polygon := `{"type":"Polygon","coordinates":[[[2.16,41.38],[2.18,41.38],[2.18,41.40],[2.16,41.40],[2.16,41.38]]]}`
+
+		overlaps, err := repo.GetBoundariesIntersectingPolygon(ctx, polygon, []int{2, 4, 8})
+		if err != nil {
+			t.Fatalf("Failed to get boundaries intersecting polygon: %v", err)
+		}
+		if len(overlaps) == 0 {
+			t.Skip("No boundaries found for test polygon")
+		}
+
+		for _, o := range overlaps {
+			if o.AdminLevel != 2 && o.AdminLevel != 4 && o.AdminLevel != 8 {
+				t.Errorf("Unexpected admin level %d", o.AdminLevel)
+			}
+			if o.OverlapAreaSqKm <= 0 || o.OverlapPercent <= 0 || o.OverlapPercent > 100.01 {
+				t.Errorf("Invalid overlap for %s: %+v", o.Name, o)
+			}
+		}
+	})
+
+	t.Run("Self-intersecting polygon is repaired", func(t *testing.T) {
+		bowtie := `{"type":"Polygon","coordinates":[[[2.16,41.38],[2.18,41.40],[2.18,41.38],[2.16,41.40],[2.16,41.38]]]}`
+
+		if _, err := repo.GetBoundariesIntersectingPolygon(ctx, bowtie, nil); err != nil {
+			t.Fatalf("Expected invalid polygon to be repaired, got %v", err)
+		}
+	})
+}
+
 func TestBoundaryRepository_SearchByText(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Search boundaries by text", func(t *testing.T) {
@@ -88,7 +240,7 @@ func TestBoundaryRepository_SearchByText(t *testing.T) {
 
 		// Search for part of the name
 		searchQuery := searchName[:3]
-		boundaries, err := repo.SearchByText(ctx, searchQuery, "", nil, 10)
+		boundaries, err := repo.SearchByText(ctx, searchQuery, "", nil, 10, domain.BoundarySearchOptions{})
 		if err != nil {
 			t.Fatalf("Failed to search boundaries: %v", err)
 		}
@@ -109,7 +261,7 @@ func TestBoundaryRepository_SearchByText(t *testing.T) {
 	})
 
 	t.Run("Search boundaries with admin level filter", func(t *testing.T) {
-		boundaries, err := repo.SearchByText(ctx, "", "", []int{2, 4}, 10)
+		boundaries, err := repo.SearchByText(ctx, "", "", []int{2, 4}, 10, domain.BoundarySearchOptions{})
 		if err != nil {
 			t.Fatalf("Failed to search boundaries with filter: %v", err)
 		}
@@ -122,7 +274,7 @@ func TestBoundaryRepository_SearchByText(t *testing.T) {
 	})
 
 	t.Run("Search boundaries with language preference", func(t *testing.T) {
-		boundaries, err := repo.SearchByText(ctx, "a", "en", nil, 5)
+		boundaries, err := repo.SearchByText(ctx, "a", "en", nil, 5, domain.BoundarySearchOptions{})
 		if err != nil {
 			t.Fatalf("Failed to search boundaries with language: %v", err)
 		}
@@ -132,14 +284,103 @@ func TestBoundaryRepository_SearchByText(t *testing.T) {
 			t.Error("Expected some results")
 		}
 	})
+
+	t.Run("Search boundaries with population filter ordered by population", func(t *testing.T) {
+		boundaries, err := repo.SearchByText(ctx, "a", "", nil, 10, domain.BoundarySearchOptions{
+			MinPopulation: 1000,
+			OrderBy:       domain.BoundarySearchOrderByPopulation,
+		})
+		if err != nil {
+			t.Fatalf("Failed to search boundaries with population filter: %v", err)
+		}
+
+		prev := -1
+		for _, b := range boundaries {
+			if b.Population == nil || *b.Population < 1000 {
+				t.Fatalf("Expected population >= 1000 for %s", b.Name)
+			}
+			if prev >= 0 && *b.Population > prev {
+				t.Errorf("Expected boundaries ordered by population desc, got %d after %d", *b.Population, prev)
+			}
+			prev = *b.Population
+		}
+	})
+
+	t.Run("Search boundaries with primary name display", func(t *testing.T) {
+		boundaries, err := repo.SearchByText(ctx, "a", "es", nil, 10, domain.BoundarySearchOptions{
+			NameDisplay: domain.BoundaryNameDisplayPrimary,
+		})
+		if err != nil {
+			t.Fatalf("Failed to search boundaries with primary name display: %v", err)
+		}
+
+		for _, b := range boundaries {
+			if b.OriginalName != "" {
+				t.Errorf("Expected no original_name when primary name is shown, got %q for %s", b.OriginalName, b.Name)
+			}
+			if b.MatchedField != "name" && b.MatchedField != "name:es" {
+				t.Errorf("Expected matched field name or name:es, got %q for %s", b.MatchedField, b.Name)
+			}
+		}
+	})
 }
 
+func TestBoundaryRepository_SearchWithinParent(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("Search cities within region", func(t *testing.T) {
+		// Берем регион (admin_level 4) и название любого города внутри него
+		var parentID int64
+		var cityName string
+		query := `SELECT p.osm_id, c.name
+				  FROM planet_osm_polygon p
+				  JOIN planet_osm_polygon c
+				    ON c.way && p.way AND ST_Within(ST_Centroid(c.way), p.way)
+				  WHERE p.boundary = 'administrative' AND p.admin_level = '4'
+				    AND c.boundary = 'administrative' AND c.admin_level = '8'
+				    AND c.name IS NOT NULL AND length(c.name) >= 3
+				  LIMIT 1`
+		if err := db.QueryRowContext(ctx, query).Scan(&parentID, &cityName); err != nil {
+			t.Skipf("No region with cities found in database")
+		}
+
+		boundaries, err := repo.SearchWithinParent(ctx, cityName[:3], parentID, []int{8}, 10)
+		if err != nil {
+			t.Fatalf("Failed to search within parent: %v", err)
+		}
+
+		if len(boundaries) == 0 {
+			t.Errorf("Expected at least one city for query '%s'", cityName[:3])
+		}
+
+		for _, b := range boundaries {
+			if b.AdminLevel != 8 {
+				t.Errorf("Expected admin level 8, got %d", b.AdminLevel)
+			}
+			if b.OSMId == parentID {
+				t.Error("Parent boundary must not be returned")
+			}
+		}
+	})
+
+	t.Run("Unknown parent returns not found", func(t *testing.T) {
+		_, err := repo.SearchWithinParent(ctx, "San", 999999999999, nil, 10)
+		if err != pkgerrors.ErrLocationNotFound {
+			t.Errorf("Expected ErrLocationNotFound, got %v", err)
+		}
+	})
+}
 func TestBoundaryRepository_Search(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Simple search", func(t *testing.T) {
@@ -159,7 +400,7 @@ func TestBoundaryRepository_ReverseGeocode(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Reverse geocode valid location", func(t *testing.T) {
@@ -194,6 +435,45 @@ func TestBoundaryRepository_ReverseGeocode(t *testing.T) {
 		}
 	})
 
+	t.Run("Subprovince matches level 7 used by enrichment", func(t *testing.T) {
+		var lat, lon float64
+		query := `SELECT
+					ST_Y(ST_Transform(ST_PointOnSurface(way), 4326)) AS lat,
+					ST_X(ST_Transform(ST_PointOnSurface(way), 4326)) AS lon
+				  FROM planet_osm_polygon
+				  WHERE boundary = 'administrative'
+				  AND admin_level = '7'
+				  AND name IS NOT NULL
+				  LIMIT 1`
+		if err := db.QueryRowContext(ctx, query).Scan(&lat, &lon); err != nil {
+			t.Skipf("No level 7 boundaries found")
+		}
+
+		addr, err := repo.ReverseGeocode(ctx, lat, lon)
+		if err != nil {
+			t.Fatalf("Failed to reverse geocode: %v", err)
+		}
+		boundaries, err := repo.GetByPoint(ctx, lat, lon)
+		if err != nil {
+			t.Fatalf("Failed to get boundaries by point: %v", err)
+		}
+
+		// Обогащение берет уровень 7 из GetByPoint - в адресе должна быть та же граница
+		boundaries, _ = domain.PickBoundaryPerLevel(boundaries)
+		var level7 *domain.AdminBoundary
+		for _, b := range boundaries {
+			if b.AdminLevel == 7 {
+				level7 = b
+			}
+		}
+		if level7 == nil {
+			t.Fatal("Expected level 7 boundary from GetByPoint")
+		}
+		if addr.Subprovince == nil || *addr.Subprovince != level7.Name {
+			t.Errorf("Expected address subprovince %q, got %v", level7.Name, addr.Subprovince)
+		}
+	})
+
 	t.Run("Reverse geocode invalid location", func(t *testing.T) {
 		// Middle of the ocean
 		_, err := repo.ReverseGeocode(ctx, 0.0, 0.0)
@@ -203,12 +483,90 @@ func TestBoundaryRepository_ReverseGeocode(t *testing.T) {
 	})
 }
 
+func TestBoundaryRepository_GetGeocodeCell(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	// Барселона
+	lat, lon := 41.3851, 2.1734
+	cell, err := repo.GetGeocodeCell(ctx, lat, lon)
+	if err != nil {
+		t.Fatalf("Failed to get geocode cell: %v", err)
+	}
+	if cell == nil {
+		t.Skip("Smallest boundary at the point is cut by other boundaries, no cell")
+	}
+	if !cell.Contains(lat, lon) {
+		t.Errorf("Expected cell %d to contain the point", cell.BoundaryID)
+	}
+
+	// Ячейка - самый детальный содержащий полигон
+	match, err := repo.ReverseGeocodeWithDepth(ctx, lat, lon)
+	if err != nil {
+		t.Fatalf("Failed to reverse geocode with depth: %v", err)
+	}
+	if cell.AdminLevel != match.SmallestAdminLevel {
+		t.Errorf("Expected cell at admin_level %d, got %d", match.SmallestAdminLevel, cell.AdminLevel)
+	}
+
+	// Океан - ячейки нет
+	ocean, err := repo.GetGeocodeCell(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to get geocode cell in the ocean: %v", err)
+	}
+	if ocean != nil {
+		t.Errorf("Expected no cell in the ocean, got %d", ocean.BoundaryID)
+	}
+}
+
+func TestBoundaryRepository_ReverseGeocodeWithDepth(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("Reverse geocode with depth", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734 // Barcelona
+
+		match, err := repo.ReverseGeocodeWithDepth(ctx, lat, lon)
+		if err == pkgerrors.ErrLocationNotFound {
+			t.Skip("No boundaries found for test point")
+		}
+		if err != nil {
+			t.Fatalf("Failed to reverse geocode with depth: %v", err)
+		}
+
+		if match.MatchedLevels <= 0 {
+			t.Errorf("Expected positive matched levels, got %d", match.MatchedLevels)
+		}
+		if match.SmallestAdminLevel < 2 {
+			t.Errorf("Expected smallest admin level >= 2, got %d", match.SmallestAdminLevel)
+		}
+		if match.EdgeDistanceMeters < 0 {
+			t.Errorf("Expected non-negative edge distance, got %f", match.EdgeDistanceMeters)
+		}
+	})
+
+	t.Run("Reverse geocode with depth in ocean", func(t *testing.T) {
+		_, err := repo.ReverseGeocodeWithDepth(ctx, 0.0, 0.0)
+		if err != pkgerrors.ErrLocationNotFound {
+			t.Logf("Expected ErrLocationNotFound for ocean coordinates, got %v", err)
+		}
+	})
+}
+
 func TestBoundaryRepository_ReverseGeocodeBatch(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Reverse geocode multiple points", func(t *testing.T) {
@@ -263,12 +621,89 @@ func TestBoundaryRepository_ReverseGeocodeBatch(t *testing.T) {
 	})
 }
 
+func TestBoundaryRepository_LookupAddresses(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	var cityID int64
+	err := db.QueryRowContext(ctx, `
+		SELECT osm_id FROM planet_osm_polygon
+		WHERE boundary = 'administrative' AND admin_level = '8'
+		LIMIT 1`).Scan(&cityID)
+	if err != nil {
+		t.Skipf("No city boundaries found: %v", err)
+	}
+
+	results, err := repo.LookupAddresses(ctx, []domain.AddressLookup{
+		{Index: 0, ScopeID: cityID},
+		{Index: 1, ScopeID: cityID, Street: "Nonexistent Street 12345", HouseNumber: "1"},
+		{Index: 2, ScopeID: -1},
+	})
+	if err != nil {
+		t.Fatalf("Failed to lookup addresses: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results (unknown scope skipped), got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Precision != domain.GeocodePrecisionBoundary {
+			t.Errorf("Expected boundary precision for index %d, got %q", r.Index, r.Precision)
+		}
+		if r.Lat < -90 || r.Lat > 90 || r.Lon < -180 || r.Lon > 180 {
+			t.Errorf("Invalid point for index %d: %f, %f", r.Index, r.Lat, r.Lon)
+		}
+	}
+}
+
+func TestBoundaryRepository_SearchByTextBatch_Parent(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	// Город и страна, в которой он лежит
+	var cityName string
+	var countryID int64
+	err := db.QueryRowContext(ctx, `
+		SELECT c.name, p.osm_id
+		FROM planet_osm_polygon c
+		JOIN planet_osm_polygon p ON p.boundary = 'administrative' AND p.admin_level = '2'
+			AND p.way && c.way AND ST_Within(ST_PointOnSurface(c.way), p.way)
+		WHERE c.boundary = 'administrative' AND c.admin_level = '8' AND c.name IS NOT NULL
+		LIMIT 1`).Scan(&cityName, &countryID)
+	if err != nil {
+		t.Skipf("No city inside a country found: %v", err)
+	}
+
+	results, err := repo.SearchByTextBatch(ctx, []domain.BoundarySearchRequest{
+		{Index: 0, Name: cityName, AdminLevel: 8, ParentID: countryID},
+		{Index: 1, Name: cityName, AdminLevel: 8, ParentID: -1}, // несуществующий родитель
+	})
+	if err != nil {
+		t.Fatalf("Failed to search boundaries: %v", err)
+	}
+
+	if !results[0].Found {
+		t.Errorf("Expected %q to be found inside its country %d", cityName, countryID)
+	}
+	if results[1].Found {
+		t.Errorf("Expected %q not to be found inside an unknown parent", cityName)
+	}
+}
+
 func TestBoundaryRepository_GetByPoint(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get boundaries by point", func(t *testing.T) {
@@ -308,7 +743,7 @@ func TestBoundaryRepository_GetChildren(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get children boundaries", func(t *testing.T) {
@@ -346,7 +781,7 @@ func TestBoundaryRepository_GetByAdminLevel(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get boundaries by admin level", func(t *testing.T) {
@@ -376,6 +811,24 @@ func TestBoundaryRepository_GetByAdminLevel(t *testing.T) {
 			t.Errorf("Expected at most %d boundaries, got %d", LimitBoundaries, len(boundaries))
 		}
 	})
+
+	t.Run("Get boundaries of several levels ordered by level", func(t *testing.T) {
+		boundaries, err := repo.GetByAdminLevels(ctx, []int{6, 4}, 50)
+		if err != nil {
+			t.Fatalf("Failed to get boundaries by admin levels: %v", err)
+		}
+
+		prevLevel := 0
+		for _, b := range boundaries {
+			if b.AdminLevel != 4 && b.AdminLevel != 6 {
+				t.Errorf("Expected admin level 4 or 6, got %d", b.AdminLevel)
+			}
+			if b.AdminLevel < prevLevel {
+				t.Errorf("Expected boundaries ordered by admin level, got %d after %d", b.AdminLevel, prevLevel)
+			}
+			prevLevel = b.AdminLevel
+		}
+	})
 }
 
 func TestBoundaryRepository_GetBoundariesInRadius(t *testing.T) {
@@ -383,7 +836,7 @@ func TestBoundaryRepository_GetBoundariesInRadius(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get boundaries in radius", func(t *testing.T) {
@@ -422,18 +875,87 @@ func TestBoundaryRepository_GetBoundariesInRadius(t *testing.T) {
 	})
 }
 
+func TestBoundaryRepository_GetNearestBoundaries(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("Point at sea returns nearest cities", func(t *testing.T) {
+		// Точка в море у побережья Барселоны: ни один город ее не содержит
+		lat, lon := 41.37, 2.25
+
+		boundaries, err := repo.GetNearestBoundaries(ctx, lat, lon, 8, 3)
+		if err != nil {
+			t.Fatalf("Failed to get nearest boundaries: %v", err)
+		}
+		if len(boundaries) > 3 {
+			t.Errorf("Expected at most 3 boundaries, got %d", len(boundaries))
+		}
+
+		for i, b := range boundaries {
+			if b.AdminLevel != 8 {
+				t.Errorf("Expected admin level 8, got %d", b.AdminLevel)
+			}
+			if b.Contains && b.DistanceM != 0 {
+				t.Errorf("Expected zero distance for containing boundary %d, got %f", b.ID, b.DistanceM)
+			}
+			if i > 0 && b.DistanceM < boundaries[i-1].DistanceM {
+				t.Errorf("Expected boundaries ordered by distance, got %f after %f", b.DistanceM, boundaries[i-1].DistanceM)
+			}
+		}
+	})
+}
+
+func TestBoundaryRepository_GetNearestPlace(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	// Побережье между Sitges и Vilanova i la Geltrú
+	place, err := repo.GetNearestPlace(ctx, 41.225, 1.78)
+	if err != nil {
+		t.Fatalf("Failed to get nearest place: %v", err)
+	}
+	if place == nil {
+		t.Skip("No place nodes in test data")
+	}
+
+	if place.Name == "" {
+		t.Error("Expected named place")
+	}
+	validType := false
+	for _, placeType := range domain.PlaceTypes {
+		validType = validType || place.Type == placeType
+	}
+	if !validType {
+		t.Errorf("Unexpected place type %q", place.Type)
+	}
+	if place.DistanceM < 0 || place.DistanceM > 50000 {
+		t.Errorf("Unexpected distance to nearest place: %f", place.DistanceM)
+	}
+	if place.Population != nil && *place.Population <= 0 {
+		t.Errorf("Expected positive population, got %d", *place.Population)
+	}
+}
+
 func TestBoundaryRepository_GetTile(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get tile for valid coordinates", func(t *testing.T) {
 		// Use tile coordinates that should contain some data
 		// z=10, x=512, y=384 should be in the Mediterranean area
-		tile, err := repo.GetTile(ctx, 10, 512, 384)
+		tile, err := repo.GetTile(ctx, 10, 512, 384, 0)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -449,7 +971,7 @@ func TestBoundaryRepository_GetBoundariesRadiusTile(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewBoundaryRepository(db)
+	repo := NewBoundaryRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get boundaries radius tile not implemented", func(t *testing.T) {
@@ -463,3 +985,106 @@ func TestBoundaryRepository_GetBoundariesRadiusTile(t *testing.T) {
 		}
 	})
 }
+
+// setupEnclaveFixture создает временную таблицу planet_osm_polygon (перекрывает основную в рамках соединения)
+// с границами-«бубликами» и анклавами:
+//
+//	Region Outer (4)  - квадрат 2.00..2.10 x 41.00..41.10 с дырой 2.04..2.06 x 41.04..41.06
+//	Region Enclave (4) - дыра Region Outer
+//	Town Outer (8)    - квадрат 2.02..2.08 x 41.02..41.08 с той же дырой; центроид лежит в дыре
+//	Town Enclave (8)  - дыра Town Outer
+func setupEnclaveFixture(t *testing.T, db *DB) {
+	t.Helper()
+
+	// Временная таблица видна только своему соединению
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	hole := "(2.04 41.04, 2.06 41.04, 2.06 41.06, 2.04 41.06, 2.04 41.04)"
+	stmts := []string{
+		`CREATE EXTENSION IF NOT EXISTS hstore`,
+		`CREATE TEMP TABLE planet_osm_polygon (
+			osm_id bigint, name text, boundary text, admin_level text, tags hstore,
+			way geometry(Geometry, 3857)
+		)`,
+		fmt.Sprintf(`INSERT INTO planet_osm_polygon VALUES
+			(-1, 'Region Outer', 'administrative', '4', ''::hstore,
+			 ST_Transform(ST_GeomFromText('POLYGON((2.00 41.00, 2.10 41.00, 2.10 41.10, 2.00 41.10, 2.00 41.00), %[1]s)', 4326), 3857)),
+			(-2, 'Region Enclave', 'administrative', '4', ''::hstore,
+			 ST_Transform(ST_GeomFromText('POLYGON(%[1]s)', 4326), 3857)),
+			(-3, 'Town Outer', 'administrative', '8', ''::hstore,
+			 ST_Transform(ST_GeomFromText('POLYGON((2.02 41.02, 2.08 41.02, 2.08 41.08, 2.02 41.08, 2.02 41.02), %[1]s)', 4326), 3857)),
+			(-4, 'Town Enclave', 'administrative', '8', ''::hstore,
+			 ST_Transform(ST_GeomFromText('POLYGON(%[1]s)', 4326), 3857))`, hole),
+	}
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("Failed to create enclave fixture: %v", err)
+		}
+	}
+}
+
+func boundaryNames(boundaries []*domain.AdminBoundary) []string {
+	names := make([]string, 0, len(boundaries))
+	for _, b := range boundaries {
+		names = append(names, b.Name)
+	}
+	return names
+}
+
+func TestBoundaryRepository_Enclaves(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	setupEnclaveFixture(t, db)
+
+	repo := NewBoundaryRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("Point inside enclave belongs to enclave only", func(t *testing.T) {
+		boundaries, err := repo.GetByPoint(ctx, 41.05, 2.05)
+		if err != nil {
+			t.Fatalf("Failed to get boundaries by point: %v", err)
+		}
+
+		names := boundaryNames(boundaries)
+		if len(names) != 2 || names[0] != "Region Enclave" || names[1] != "Town Enclave" {
+			t.Errorf("Expected [Region Enclave Town Enclave], got %v", names)
+		}
+	})
+
+	t.Run("Point in surrounding ring belongs to outer boundaries", func(t *testing.T) {
+		boundaries, err := repo.GetByPoint(ctx, 41.03, 2.03)
+		if err != nil {
+			t.Fatalf("Failed to get boundaries by point: %v", err)
+		}
+
+		names := boundaryNames(boundaries)
+		if len(names) != 2 || names[0] != "Region Outer" || names[1] != "Town Outer" {
+			t.Errorf("Expected [Region Outer Town Outer], got %v", names)
+		}
+	})
+
+	t.Run("Donut child is found although its centroid is in the hole", func(t *testing.T) {
+		children, err := repo.GetChildren(ctx, -1)
+		if err != nil {
+			t.Fatalf("Failed to get children: %v", err)
+		}
+
+		names := boundaryNames(children)
+		if len(names) != 1 || names[0] != "Town Outer" {
+			t.Errorf("Expected [Town Outer], got %v", names)
+		}
+	})
+
+	t.Run("Enclave child belongs to enclave parent", func(t *testing.T) {
+		children, err := repo.GetChildren(ctx, -2)
+		if err != nil {
+			t.Fatalf("Failed to get children: %v", err)
+		}
+
+		names := boundaryNames(children)
+		if len(names) != 1 || names[0] != "Town Enclave" {
+			t.Errorf("Expected [Town Enclave], got %v", names)
+		}
+	})
+}
@@ -10,7 +10,9 @@ const (
 	LimitPOIsRadius       = 200
 	LimitPOIsCategory     = 1000
 	LimitStations         = 100
+	LimitBoundaryStations = 1000
 	LimitLines            = 50
+	LimitLinesInBBox      = 300
 	LimitGreenSpaces      = 50
 	LimitWaterBodies      = 50
 	LimitBeaches          = 20
@@ -18,11 +20,20 @@ const (
 	LimitTouristZones     = 50
 	LimitBoundaries       = 100
 	LimitBoundariesRadius = 50
+	LimitBatchPoints      = 500 // точек в одном batch-запросе станций (5 параметров на точку)
+
+	// webMercatorMetersPerPixelZ0 - размер пикселя 256px тайла на зуме 0 (на экваторе), метры
+	webMercatorMetersPerPixelZ0 = 156543.03392
 
 	// BoundaryExpansionDegrees - расширение для поиска границ (~11км на экваторе)
 	BoundaryExpansionDegrees = 0.1
 )
 
+// emptyTileSQL - значение тайла без объектов для COALESCE(ST_AsMVT(...), emptyTileSQL): bytea нулевой
+// длины, валидный MVT без слоев. Литерал без обратного слеша не зависит от standard_conforming_strings:
+// '\\x'::bytea при standard_conforming_strings=on давал два байта `\x`, которые MVT парсеры отвергают.
+const emptyTileSQL = "''::bytea"
+
 const (
 	planetPointTable   = "planet_osm_point"
 	planetLineTable    = "planet_osm_line"
@@ -32,14 +43,37 @@ const (
 
 // expressions для повторного использования в SQL
 const (
-	// categoryExpr - определяет категорию POI на основе OSM тегов (приоритет слева направо)
-	categoryExpr = `COALESCE(NULLIF(amenity,''), NULLIF(shop,''), NULLIF(tourism,''), NULLIF(leisure,''), NULLIF(historic,''), NULLIF(office,''), NULLIF(man_made,''), NULLIF("natural",''), NULLIF(highway,''), NULLIF(public_transport,''), NULLIF(railway,''), NULLIF(aeroway,''), NULLIF(military,''), NULLIF(place,''), 'other')`
+	// defaultCategoryExpr - определяет категорию POI на основе OSM тегов (приоритет слева направо).
+	// Категории из конфига проверяются раньше, см. ConfigurePOICategories
+	defaultCategoryExpr = `COALESCE(NULLIF(amenity,''), NULLIF(shop,''), NULLIF(tourism,''), NULLIF(leisure,''), NULLIF(historic,''), NULLIF(office,''), NULLIF(man_made,''), NULLIF("natural",''), NULLIF(highway,''), NULLIF(public_transport,''), NULLIF(railway,''), NULLIF(aeroway,''), NULLIF(military,''), NULLIF(place,''), 'other')`
+
+	// surfaceOnlyCondition - исключает подземные и indoor объекты (платформы метро, магазины внутри ТЦ).
+	// Требует колонку tags в области видимости.
+	surfaceOnlyCondition = "(tags->'location' IS DISTINCT FROM 'underground') AND (tags->'indoor' IS DISTINCT FROM 'yes')"
 
-	// subcategoryExpr - определяет подкатегорию из дополнительных тегов
-	subcategoryExpr = "COALESCE(NULLIF(tags->'cuisine',''), NULLIF(tags->'sport',''), NULLIF(tags->'religion',''), NULLIF(tags->'denomination',''), NULLIF(tags->'building',''), NULLIF(shop,''), NULLIF(tourism,''), 'general')"
+	// boundaryPopulationExpr - население из тега population; нечисловые значения ("ca. 5000") дают NULL
+	boundaryPopulationExpr = "CASE WHEN tags->'population' ~ '^[0-9]{1,18}$' THEN (tags->'population')::bigint END"
 
-	// tileCategoryExpr - маппинг OSM тегов в категории приложения (для тайлов и фильтрации)
-	tileCategoryExpr = `CASE
+	// eleValueExpr - высота из тега ele по числовому префиксу ("312", "312.5 m"); нечисловые значения дают NULL
+	eleValueExpr = `(substring(tags->'ele' from '^\s*(-?[0-9]{1,5}(?:\.[0-9]+)?)'))::float8`
+
+	// defaultSubcategoryExpr - определяет подкатегорию из дополнительных тегов
+	defaultSubcategoryExpr = "COALESCE(NULLIF(tags->'cuisine',''), NULLIF(tags->'sport',''), NULLIF(tags->'religion',''), NULLIF(tags->'denomination',''), NULLIF(tags->'building',''), NULLIF(shop,''), NULLIF(tourism,''), 'general')"
+
+	// transportModeExpr - определяет тип транспорта станции (metro, train, tram, bus, ferry, other).
+	// Используется в приоритетном поиске и подсчете покрытия
+	transportModeExpr = `CASE
+		WHEN railway = 'station' AND (tags->'station' = 'subway' OR tags->'subway' = 'yes') THEN 'metro'
+		WHEN railway IN ('station', 'halt') AND (tags->'station' IS NULL OR tags->'station' NOT IN ('subway', 'light_rail')) THEN 'train'
+		WHEN railway = 'tram_stop' OR (railway = 'station' AND tags->'station' = 'light_rail') THEN 'tram'
+		WHEN highway = 'bus_stop' OR (public_transport IN ('platform', 'stop_position') AND tags->'bus' = 'yes') THEN 'bus'
+		WHEN amenity = 'ferry_terminal' THEN 'ferry'
+		ELSE 'other'
+	END`
+
+	// defaultTileCategoryExpr - маппинг OSM тегов в категории приложения (для тайлов и фильтрации).
+	// Категории из конфига проверяются раньше, см. ConfigurePOICategories
+	defaultTileCategoryExpr = `CASE
 		WHEN amenity IN ('pharmacy','hospital','clinic','doctors','dentist','veterinary') THEN 'healthcare'
 		WHEN amenity IN ('school','kindergarten','college','university','library','language_school') THEN 'education'
 		WHEN amenity IN ('restaurant','cafe','bar','fast_food') THEN 'food_drink'
@@ -50,8 +84,37 @@ const (
 		ELSE 'other'
 	END`
 
-	// tileSubcategoryExpr - подкатегория = значение OSM тега (pharmacy, hospital, supermarket, etc.)
-	tileSubcategoryExpr = `CASE
+	// poiTileRankExpr - приоритет подписи POI в тайле (больше - важнее):
+	// наличие wikidata/wikipedia, туристическая значимость и короткое название
+	poiTileRankExpr = `(
+		CASE WHEN tags ? 'wikidata' THEN 3 ELSE 0 END
+		+ CASE WHEN tags ? 'wikipedia' THEN 1 ELSE 0 END
+		+ CASE
+			WHEN subcategory IN ('attraction','museum','castle') THEN 2
+			WHEN subcategory IN ('viewpoint','monument','hospital','university','mall') THEN 1
+			ELSE 0
+		END
+		+ CASE WHEN name <> '' AND length(name) <= 20 THEN 1 ELSE 0 END
+	)`
+
+	// poiTileImportanceOrder - порядок POI в тайле: при обрезке лимитом остаются самые значимые
+	// (wikidata/wikipedia, достопримечательности), затем именованные, затем детерминированно
+	poiTileImportanceOrder = poiTileRankExpr + ` DESC, (name <> '') DESC, category, name, osm_id`
+
+	// poiTileMinZoomExpr - минимальный zoom, с которого POI показывается на карте.
+	// Объекты с wikidata появляются на один уровень раньше.
+	poiTileMinZoomExpr = `(
+		CASE
+			WHEN subcategory IN ('attraction','museum','castle','hospital','university','mall') THEN 13
+			WHEN subcategory IN ('park','monument','viewpoint','school','college','library','supermarket','department_store') THEN 14
+			WHEN subcategory IN ('playground','convenience','fast_food','bakery','butcher','greengrocer') THEN 16
+			ELSE 15
+		END
+		- CASE WHEN tags ? 'wikidata' THEN 1 ELSE 0 END
+	)`
+
+	// defaultTileSubcategoryExpr - подкатегория = значение OSM тега (pharmacy, hospital, supermarket, etc.)
+	defaultTileSubcategoryExpr = `CASE
 		WHEN amenity IN ('pharmacy','hospital','clinic','doctors','dentist','veterinary',
 			'school','kindergarten','college','university','library','language_school',
 			'restaurant','cafe','bar','fast_food') THEN amenity
@@ -0,0 +1,55 @@
+package postgresosm
+
+import (
+	"fmt"
+
+	"github.com/location-microservice/internal/domain"
+)
+
+// countryPriority - приоритет стран для точек на спорных границах.
+// Задается при старте через ConfigureCountryPriority.
+var countryPriority domain.CountryPriority
+
+// ConfigureCountryPriority задает порядок предпочтения стран (названия или osm_id) для точек,
+// попавших в несколько стран. Вызывается один раз при старте, до создания репозиториев.
+func ConfigureCountryPriority(entries []string) error {
+	parsed, err := domain.ParseCountryPriority(entries)
+	if err != nil {
+		return err
+	}
+
+	countryPriority = parsed
+	return nil
+}
+
+// countryCandidatesColumns возвращает колонки country_ids и country_names с osm_id и названиями всех
+// стран (admin_level 2), содержащих точку, в стабильном порядке (по названию, затем osm_id).
+// alias - алиас строк с границами ("" - без алиаса). Разбираются через countryCandidates.
+func countryCandidatesColumns(alias string) string {
+	col := func(name string) string {
+		if alias == "" {
+			return name
+		}
+		return alias + "." + name
+	}
+
+	filter := fmt.Sprintf("FILTER (WHERE (%s)::integer = 2 AND %s IS NOT NULL)", col("admin_level"), col("name"))
+	order := fmt.Sprintf("ORDER BY %s, %s", col("name"), col("osm_id"))
+	return fmt.Sprintf("ARRAY_AGG(%s %s) %s AS country_ids, ARRAY_AGG(%s %s) %s AS country_names",
+		col("osm_id"), order, filter, col("name"), order, filter)
+}
+
+// countryCandidates собирает кандидатов из колонок countryCandidatesColumns. Полигоны с одинаковым
+// названием (например, граница по суше и по морю) считаются одной страной - остается первый.
+func countryCandidates(ids []int64, names []string) []domain.CountryCandidate {
+	candidates := make([]domain.CountryCandidate, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		if i >= len(ids) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		candidates = append(candidates, domain.CountryCandidate{ID: ids[i], Name: name})
+	}
+	return candidates
+}
@@ -3,9 +3,11 @@ package postgresosm
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 	"github.com/location-microservice/internal/config"
 	"go.uber.org/zap"
@@ -15,20 +17,30 @@ import (
 // (planet_osm_* таблицы, загруженные через osm2pgsql)
 type DB struct {
 	*sqlx.DB
-	logger *zap.Logger
+	replica *sqlx.DB // read-replica для тяжелых read-only запросов, nil - не настроена
+	logger  *zap.Logger
 }
 
+// ReadPreference определяет, какое подключение использует запрос на чтение
+type ReadPreference int
+
+const (
+	// ReadPrimary - основная БД: точечные запросы и обогащение, которым нужны актуальные данные
+	ReadPrimary ReadPreference = iota
+	// ReadReplica - реплика для тайлов и аналитики; без реплики - основная БД
+	ReadReplica
+)
+
 // New создает новое подключение к OSM базе данных
 func New(cfg *config.DatabaseConfig, logger *zap.Logger) (*DB, error) {
-	dsn := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
-	)
-
-	db, err := sqlx.Connect("pgx", dsn)
+	connConfig, err := newConnConfig(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to osm database: %w", err)
+		return nil, fmt.Errorf("failed to parse osm database config: %w", err)
 	}
+	// Медленные запросы - WARN с методом репозитория и длительностью
+	connConfig.Tracer = newSlowQueryTracer(cfg.SlowQueryThreshold, logger)
+
+	db := sqlx.NewDb(stdlib.OpenDB(*connConfig), "pgx")
 
 	db.SetMaxOpenConns(cfg.MaxConns)
 	db.SetMaxIdleConns(cfg.MaxIdleConns)
@@ -39,27 +51,80 @@ func New(cfg *config.DatabaseConfig, logger *zap.Logger) (*DB, error) {
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping osm database: %w", err)
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to osm database: %w", err)
 	}
 
 	logger.Info("OSM PostgreSQL connected",
 		zap.String("host", cfg.Host),
 		zap.Int("port", cfg.Port),
 		zap.String("database", cfg.DBName),
+		zap.Duration("slow_query_threshold", cfg.SlowQueryThreshold),
+		zap.Duration("statement_timeout", cfg.StatementTimeout),
 	)
 
 	return &DB{DB: db, logger: logger}, nil
 }
 
-// Close закрывает соединение с БД
+// newConnConfig собирает параметры подключения pgx. statement_timeout передается в startup-пакете
+// и действует на каждую сессию пула: зависший запрос (например, ST_Difference в тайле) прерывает
+// сам Postgres, даже если отмена по context не дошла до сервера.
+func newConnConfig(cfg *config.DatabaseConfig) (*pgx.ConnConfig, error) {
+	dsn := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
+	)
+
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+	return connConfig, nil
+}
+
+// SetReplica подключает read-replica. Репозитории получают подключения при создании,
+// поэтому реплика подключается до NewXxxRepository.
+func (db *DB) SetReplica(replica *DB) {
+	if replica == nil {
+		db.replica = nil
+		return
+	}
+	db.replica = replica.DB
+}
+
+// Reader возвращает подключение для read preference с fallback на основную БД
+func (db *DB) Reader(pref ReadPreference) *sqlx.DB {
+	if pref == ReadReplica && db.replica != nil {
+		return db.replica
+	}
+	return db.DB
+}
+
+// Close закрывает соединение с БД (и с репликой, если подключена)
 func (db *DB) Close() error {
 	db.logger.Info("Closing OSM PostgreSQL connection")
+	if db.replica != nil {
+		if err := db.replica.Close(); err != nil {
+			db.logger.Error("Failed to close OSM PostgreSQL replica connection", zap.Error(err))
+		}
+	}
 	return db.DB.Close()
 }
 
-// Health выполняет health-check соединения
+// Health выполняет health-check соединения (и реплики, если подключена)
 func (db *DB) Health(ctx context.Context) error {
-	return db.PingContext(ctx)
+	if err := db.PingContext(ctx); err != nil {
+		return err
+	}
+	if db.replica != nil {
+		if err := db.replica.PingContext(ctx); err != nil {
+			return fmt.Errorf("replica: %w", err)
+		}
+	}
+	return nil
 }
 
 // NewDBForTest создает экземпляр DB для тестов
@@ -0,0 +1,64 @@
+package postgresosm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/location-microservice/internal/config"
+)
+
+func TestDBReaderFallsBackToPrimary(t *testing.T) {
+	// sqlx.Open не подключается к БД, соединения создаются лениво
+	primary, err := sqlx.Open("pgx", "host=primary.invalid")
+	if err != nil {
+		t.Fatalf("open primary: %v", err)
+	}
+	replica, err := sqlx.Open("pgx", "host=replica.invalid")
+	if err != nil {
+		t.Fatalf("open replica: %v", err)
+	}
+
+	db := NewDBForTest(primary, nil)
+	if db.Reader(ReadReplica) != primary {
+		t.Fatal("expected primary without configured replica")
+	}
+
+	db.SetReplica(NewDBForTest(replica, nil))
+	if db.Reader(ReadReplica) != replica {
+		t.Fatal("expected replica for ReadReplica")
+	}
+	if db.Reader(ReadPrimary) != primary {
+		t.Fatal("expected primary for ReadPrimary")
+	}
+
+	if repo := NewPOIRepository(db, QueryOptions{}).(*poiRepository); repo.readDB != replica || repo.db != primary {
+		t.Fatal("expected repository to route tiles to replica and lookups to primary")
+	}
+
+	db.SetReplica(nil)
+	if db.Reader(ReadReplica) != primary {
+		t.Fatal("expected primary after replica is detached")
+	}
+}
+
+func TestNewConnConfigStatementTimeout(t *testing.T) {
+	cfg := &config.DatabaseConfig{Host: "localhost", Port: 5432, User: "osm", DBName: "osm", SSLMode: "disable"}
+
+	connConfig, err := newConnConfig(cfg)
+	if err != nil {
+		t.Fatalf("newConnConfig: %v", err)
+	}
+	if _, ok := connConfig.RuntimeParams["statement_timeout"]; ok {
+		t.Error("expected no statement_timeout when it is not configured")
+	}
+
+	cfg.StatementTimeout = 30 * time.Second
+	connConfig, err = newConnConfig(cfg)
+	if err != nil {
+		t.Fatalf("newConnConfig: %v", err)
+	}
+	if got := connConfig.RuntimeParams["statement_timeout"]; got != "30000" {
+		t.Errorf("expected statement_timeout 30000, got %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package postgresosm
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/domain/repository"
+	pkgerrors "github.com/location-microservice/internal/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// explainTemplate - запрос для EXPLAIN с функцией построения тестовых параметров.
+// query строит запрос теми же функциями, что и репозитории, с настройками репозитория диагностики.
+type explainTemplate struct {
+	query func(q QueryOptions) string
+	args  func(lat, lon float64) []interface{}
+}
+
+// explainRadiusMeters - радиус поиска для тестовых параметров
+const explainRadiusMeters = 1000.0
+
+// explainTemplates - "горячие" запросы репозиториев для проверки использования GIST индексов
+var explainTemplates = map[string]explainTemplate{
+	"boundaries_by_point": {
+		query: boundariesByPointQuery,
+		args: func(lat, lon float64) []interface{} {
+			return []interface{}{lon, lat, BoundaryExpansionDegrees}
+		},
+	},
+	"stations_in_radius": {
+		query: stationsInRadiusQuery,
+		args: func(lat, lon float64) []interface{} {
+			return []interface{}{lon, lat, explainRadiusMeters, LimitStations}
+		},
+	},
+	"lines_in_radius": {
+		query: linesInRadiusQuery,
+		args: func(lat, lon float64) []interface{} {
+			return []interface{}{lon, lat, explainRadiusMeters, LimitLines}
+		},
+	},
+	"green_spaces_nearby": {
+		query: func(q QueryOptions) string {
+			return greenSpacesNearbyQuery(q, domain.EnvironmentOrderOptions{}.Normalize(), false)
+		},
+		args: func(lat, lon float64) []interface{} {
+			return []interface{}{lon, lat, explainRadiusMeters, LimitGreenSpaces}
+		},
+	},
+}
+
+type debugRepository struct {
+	db        *sqlx.DB
+	logger    *zap.Logger
+	queryOpts QueryOptions // те же настройки, что у репозиториев, чьи запросы проверяются
+}
+
+// NewDebugRepository создает репозиторий диагностики запросов для OSM базы данных
+func NewDebugRepository(db *DB, queryOpts QueryOptions) repository.DebugRepository {
+	return &debugRepository{
+		db:        db.DB,
+		logger:    db.logger,
+		queryOpts: queryOpts,
+	}
+}
+
+// ExplainQuery выполняет EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) в read-only транзакции,
+// которая всегда откатывается - ANALYZE реально выполняет запрос
+func (r *debugRepository) ExplainQuery(ctx context.Context, name string, lat, lon float64) (json.RawMessage, error) {
+	tmpl, ok := explainTemplates[name]
+	if !ok {
+		return nil, pkgerrors.New("UNKNOWN_EXPLAIN_QUERY", fmt.Sprintf("Unknown query template: %s", name), 400)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		r.logger.Error("failed to begin explain transaction", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var plan []byte
+	query := "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) " + tmpl.query(r.queryOpts)
+	if err := tx.QueryRowxContext(ctx, query, tmpl.args(lat, lon)...).Scan(&plan); err != nil {
+		r.logger.Error("failed to explain query", zap.String("name", name), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return json.RawMessage(plan), nil
+}
+
+// ListExplainQueries возвращает отсортированный список доступных шаблонов
+func (r *debugRepository) ListExplainQueries() []string {
+	names := make([]string, 0, len(explainTemplates))
+	for name := range explainTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
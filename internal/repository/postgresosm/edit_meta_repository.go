@@ -0,0 +1,156 @@
+package postgresosm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/domain/repository"
+	pkgerrors "github.com/location-microservice/internal/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Колонки метаданных правки, которые osm2pgsql добавляет при импорте с --extra-attributes
+const (
+	editMetaVersionColumn   = "osm_version"
+	editMetaTimestampColumn = "osm_timestamp"
+	editMetaUserColumn      = "osm_user"
+)
+
+// editMetaSource - таблица и условие, по которым ищется объект каждого типа
+type editMetaSource struct {
+	table     string
+	condition string
+}
+
+var editMetaSources = map[string]editMetaSource{
+	domain.EditFeatureBoundary: {table: planetPolygonTable, condition: "boundary IS NOT NULL"},
+	domain.EditFeaturePOI:      {table: planetPointTable, condition: "TRUE"},
+	domain.EditFeatureStation: {
+		table:     planetPointTable,
+		condition: "(public_transport IS NOT NULL OR railway IN ('station', 'halt', 'stop'))",
+	},
+}
+
+type editMetaRepository struct {
+	db *sqlx.DB
+	// columns - присутствующие колонки метаданных по таблицам, определяются один раз при старте
+	columns map[string]map[string]bool
+	logger  *zap.Logger
+}
+
+// NewEditMetaRepository создает репозиторий метаданных правок и проверяет, какие колонки
+// osm_version/osm_timestamp/osm_user есть в таблицах planet_osm_*. Отсутствующие колонки
+// не ошибка: метаданные по ним просто не возвращаются.
+func NewEditMetaRepository(ctx context.Context, db *DB) (repository.EditMetaRepository, error) {
+	r := &editMetaRepository{
+		db:      db.DB,
+		columns: make(map[string]map[string]bool),
+		logger:  db.logger,
+	}
+
+	tables := []string{planetPointTable, planetPolygonTable}
+	rows, err := r.db.QueryxContext(ctx, `
+		SELECT table_name, column_name
+		FROM information_schema.columns
+		WHERE table_schema = ANY(current_schemas(false))
+		  AND table_name = ANY($1)
+		  AND column_name = ANY($2)
+	`, pq.Array(tables), pq.Array([]string{editMetaVersionColumn, editMetaTimestampColumn, editMetaUserColumn}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect osm edit metadata columns: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, fmt.Errorf("failed to scan osm edit metadata column: %w", err)
+		}
+		if r.columns[table] == nil {
+			r.columns[table] = make(map[string]bool)
+		}
+		r.columns[table][column] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to detect osm edit metadata columns: %w", err)
+	}
+
+	for _, table := range tables {
+		r.logger.Info("OSM edit metadata columns",
+			zap.String("table", table),
+			zap.Bool(editMetaVersionColumn, r.columns[table][editMetaVersionColumn]),
+			zap.Bool(editMetaTimestampColumn, r.columns[table][editMetaTimestampColumn]),
+			zap.Bool(editMetaUserColumn, r.columns[table][editMetaUserColumn]),
+		)
+	}
+
+	return r, nil
+}
+
+// editMetaQuery строит запрос метаданных объекта; отсутствующие в таблице колонки заменяются NULL
+func editMetaQuery(source editMetaSource, columns map[string]bool) string {
+	column := func(name, cast string) string {
+		if columns[name] {
+			return fmt.Sprintf("(%s)::%s AS %s", name, cast, name)
+		}
+		return fmt.Sprintf("NULL::%s AS %s", cast, name)
+	}
+
+	return fmt.Sprintf(`
+		SELECT
+			%s,
+			%s,
+			%s
+		FROM %s
+		WHERE osm_id = $1 AND %s
+		LIMIT 1
+	`,
+		column(editMetaVersionColumn, "bigint"),
+		column(editMetaTimestampColumn, "timestamptz"),
+		column(editMetaUserColumn, "text"),
+		source.table, source.condition)
+}
+
+func (r *editMetaRepository) GetEditMeta(ctx context.Context, feature string, osmID int64) (*domain.EditMeta, error) {
+	source, ok := editMetaSources[feature]
+	if !ok {
+		return nil, pkgerrors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"feature": feature,
+		})
+	}
+
+	columns := r.columns[source.table]
+
+	var version sql.NullInt64
+	var timestamp sql.NullTime
+	var user sql.NullString
+	err := r.db.QueryRowxContext(ctx, editMetaQuery(source, columns), osmID).Scan(&version, &timestamp, &user)
+	if err == sql.ErrNoRows {
+		return nil, pkgerrors.ErrLocationNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get osm edit metadata",
+			zap.String("feature", feature),
+			zap.Int64("osm_id", osmID),
+			zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	meta := &domain.EditMeta{Available: len(columns) > 0}
+	if version.Valid {
+		meta.Version = &version.Int64
+	}
+	if timestamp.Valid {
+		ts := timestamp.Time.In(time.UTC)
+		meta.Timestamp = &ts
+	}
+	if user.Valid && user.String != "" {
+		meta.User = &user.String
+	}
+	return meta, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/location-microservice/internal/domain"
@@ -12,51 +13,164 @@ import (
 	"go.uber.org/zap"
 )
 
+// beachAccessCondition возвращает SQL условие, отсекающее пляжи без доступа (access=private|no).
+// Пляжи без тега access и с access=customers остаются: доступ у них есть, ограничения видны в Access.
+// С IncludeRestrictedBeaches возвращает TRUE.
+func (o QueryOptions) beachAccessCondition() string {
+	if o.IncludeRestrictedBeaches {
+		return "TRUE"
+	}
+	return "COALESCE(tags->'access', '') NOT IN ('private', 'no')"
+}
+
+// applyBeachTags заполняет доступ, сезонность и голубой флаг пляжа из тегов access, seasonal и blue_flag
+func applyBeachTags(b *domain.Beach, access, seasonal, blueFlag string) {
+	if access != "" {
+		b.Access = &access
+	}
+
+	if seasonal != "" {
+		isSeasonal, ok := parseYesNo(seasonal)
+		if !ok {
+			// seasonal=summer, seasonal=Jun-Sep: сезон указан вместо yes
+			isSeasonal = true
+			b.Season = &seasonal
+		}
+		b.Seasonal = &isSeasonal
+	}
+
+	if flag, ok := parseYesNo(blueFlag); ok {
+		b.BlueFlag = &flag
+	}
+}
+
+// applyWaterTags заполняет судоходность по тегам доступа ship, boat и canoe (хотя бы один разрешает -
+// судоходен, все указанные запрещают - нет, ни одного - неизвестно) и приливность по тегу tidal
+func applyWaterTags(w *domain.WaterBody, ship, boat, canoe, tidal string) {
+	specified := false
+	for _, access := range []string{ship, boat, canoe} {
+		switch strings.ToLower(strings.TrimSpace(access)) {
+		case "":
+		case "yes", "designated", "permissive", "destination", "permit":
+			navigable := true
+			w.Navigable = &navigable
+		default:
+			specified = true
+		}
+	}
+	if w.Navigable == nil && specified {
+		navigable := false
+		w.Navigable = &navigable
+	}
+
+	if isTidal, ok := parseYesNo(tidal); ok {
+		w.Tidal = &isTidal
+	}
+}
+
 type environmentRepository struct {
-	db     *sqlx.DB
-	logger *zap.Logger
+	db        *sqlx.DB
+	readDB    *sqlx.DB // тайлы и аналитика: реплика, если настроена
+	logger    *zap.Logger
+	queryOpts QueryOptions
 }
 
 // NewEnvironmentRepository создает репозиторий окружающей среды для OSM базы данных
-func NewEnvironmentRepository(db *DB) repository.EnvironmentRepository {
+func NewEnvironmentRepository(db *DB, queryOpts QueryOptions) repository.EnvironmentRepository {
 	return &environmentRepository{
-		db:     db.DB,
-		logger: db.logger,
+		db:        db.DB,
+		readDB:    db.Reader(ReadReplica),
+		logger:    db.logger,
+		queryOpts: queryOpts,
+	}
+}
+
+// greenSpacesNearbyQuery строит запрос GetGreenSpacesNearby. Параметры: $1 lon, $2 lat, $3 радиус в метрах,
+// $4 лимит; для сортировки по score - $5 и $6 веса расстояния и площади; при withMinArea - следующий
+// параметр минимальная площадь в м².
+func greenSpacesNearbyQuery(q QueryOptions, opts domain.EnvironmentOrderOptions, withMinArea bool) string {
+	// Отсекаем мелкие полигоны (газоны, клумбы) до нормализации score
+	areaFilter := ""
+	if withMinArea {
+		minAreaParam := 5
+		if opts.OrderBy == domain.EnvironmentOrderByScore {
+			minAreaParam = 7
+		}
+		areaFilter = fmt.Sprintf("WHERE area_sq_m >= $%d", minAreaParam)
+	}
+
+	var sortExpr string
+	switch opts.OrderBy {
+	case domain.EnvironmentOrderByArea:
+		sortExpr = "area_sq_m"
+	case domain.EnvironmentOrderByScore:
+		// Нормализуем расстояние по радиусу, площадь - по максимальной площади среди кандидатов
+		sortExpr = "$5 * (1 - LEAST(distance / NULLIF($3, 0), 1)) + $6 * COALESCE(area_sq_m / NULLIF(MAX(area_sq_m) OVER (), 0), 0)"
+	default:
+		sortExpr = "distance"
 	}
+
+	sortDirection := "DESC"
+	if opts.OrderBy == domain.EnvironmentOrderByDistance {
+		sortDirection = "ASC"
+	}
+
+	return fmt.Sprintf(`
+		WITH point AS (
+			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d)::geography AS geom
+		),
+		candidates AS (
+			SELECT 
+				osm_id,
+				COALESCE(name, '') AS name,
+				COALESCE(NULLIF(name, ''), NULLIF(tags->'name:en', ''), '') AS name_en,
+				COALESCE(NULLIF(leisure, ''), NULLIF(landuse, ''), 'park') AS type,
+				ST_Area(ST_Transform(way, %d)::geography) AS area_sq_m,
+				ST_Y(ST_Centroid(ST_Transform(way, %d))) AS center_lat,
+				ST_X(ST_Centroid(ST_Transform(way, %d))) AS center_lon,
+				COALESCE(tags->'access', '') AS access,
+				ST_Distance(ST_Transform(way, %d)::geography, point.geom) AS distance
+			FROM %s, point
+			WHERE (leisure IN ('park', 'garden', 'nature_reserve', 'playground', 'pitch')
+			   OR landuse IN ('forest', 'meadow', 'grass', 'recreation_ground'))
+			  AND ST_DWithin(ST_Transform(way, %d)::geography, point.geom, $3)
+			  AND %s
+		),
+		scored AS (
+			SELECT *, (%s)::float8 AS sort_value
+			FROM candidates
+			%s
+		)
+		SELECT osm_id, name, name_en, type, area_sq_m, center_lat, center_lon, access, distance, sort_value
+		FROM scored
+		ORDER BY sort_value %s, distance ASC
+		LIMIT $4
+	`, SRID4326, SRID4326, SRID4326, SRID4326, SRID4326, planetPolygonTable, SRID4326, q.activeFeatureCondition(""), sortExpr, areaFilter, sortDirection)
 }
 
-// GetGreenSpacesNearby возвращает зеленые зоны рядом с точкой
+// GetGreenSpacesNearby возвращает зеленые зоны рядом с точкой с сортировкой по distance, area или score
 func (r *environmentRepository) GetGreenSpacesNearby(
 	ctx context.Context,
 	lat, lon, radiusKm float64,
+	minAreaSqM float64,
+	opts domain.EnvironmentOrderOptions,
 ) ([]*domain.GreenSpace, error) {
 	radiusMeters := radiusKm * 1000
+	opts = opts.Normalize()
 
-	query := fmt.Sprintf(`
-		WITH point AS (
-			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d)::geography AS geom
-		)
-		SELECT 
-			osm_id,
-			COALESCE(name, '') AS name,
-			COALESCE(NULLIF(name, ''), NULLIF(tags->'name:en', ''), '') AS name_en,
-			COALESCE(NULLIF(leisure, ''), NULLIF(landuse, ''), 'park') AS type,
-			ST_Area(ST_Transform(way, %d)::geography) AS area_sq_m,
-			ST_Y(ST_Centroid(ST_Transform(way, %d))) AS center_lat,
-			ST_X(ST_Centroid(ST_Transform(way, %d))) AS center_lon,
-			COALESCE(tags->'access', '') AS access,
-			ST_Distance(ST_Transform(way, %d)::geography, point.geom) AS distance
-		FROM %s, point
-		WHERE (leisure IN ('park', 'garden', 'nature_reserve', 'playground', 'pitch')
-		   OR landuse IN ('forest', 'meadow', 'grass', 'recreation_ground'))
-		  AND ST_DWithin(ST_Transform(way, %d)::geography, point.geom, $3)
-		ORDER BY distance
-		LIMIT $4
-	`, SRID4326, SRID4326, SRID4326, SRID4326, SRID4326, planetPolygonTable, SRID4326)
+	args := []interface{}{lon, lat, radiusMeters, LimitGreenSpaces}
+	if opts.OrderBy == domain.EnvironmentOrderByScore {
+		args = append(args, opts.DistanceWeight, opts.AreaWeight)
+	}
+	if minAreaSqM > 0 {
+		args = append(args, minAreaSqM)
+	}
+
+	query := greenSpacesNearbyQuery(r.queryOpts, opts, minAreaSqM > 0)
 
-	rows, err := r.db.QueryxContext(ctx, query, lon, lat, radiusMeters, LimitGreenSpaces)
+	rows, err := r.db.QueryxContext(ctx, query, args...)
 	if err != nil {
-		r.logger.Error("failed to get osm green spaces", zap.Error(err))
+		r.logger.Error("failed to get osm green spaces", zap.String("order_by", string(opts.OrderBy)), zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
 	}
 	defer rows.Close()
@@ -64,11 +178,11 @@ func (r *environmentRepository) GetGreenSpacesNearby(
 	var spaces []*domain.GreenSpace
 	for rows.Next() {
 		var g domain.GreenSpace
-		var distance float64
+		var distance, sortValue float64
 		var access string
 
 		err := rows.Scan(&g.OSMId, &g.Name, &g.NameEn, &g.Type, &g.AreaSqM,
-			&g.CenterLat, &g.CenterLon, &access, &distance)
+			&g.CenterLat, &g.CenterLon, &access, &distance, &sortValue)
 		if err != nil {
 			r.logger.Error("failed to scan green space row", zap.Error(err))
 			continue
@@ -78,6 +192,9 @@ func (r *environmentRepository) GetGreenSpacesNearby(
 		if access != "" {
 			g.Access = &access
 		}
+		g.DistanceM = &distance
+		g.OrderBy = string(opts.OrderBy)
+		g.SortValue = &sortValue
 
 		spaces = append(spaces, &g)
 	}
@@ -85,30 +202,154 @@ func (r *environmentRepository) GetGreenSpacesNearby(
 	return spaces, nil
 }
 
-// GetWaterBodiesNearby возвращает водные объекты рядом с точкой
+// GetGreenSpacesInBoundary возвращает зеленые зоны, пересекающие границу, по убыванию полной площади.
+// Отбор limit крупнейших идет по плоской площади ST_Area(way) (без перевода каждого полигона в geography:
+// у границы уровня страны это десятки тысяч зон), площадь на сфере и площадь пересечения с границей
+// для зон, выходящих за ее край, считаются только для отобранных.
+// Пустой результат перепроверяется на существование границы, чтобы отличить 404 от города без парков.
+func (r *environmentRepository) GetGreenSpacesInBoundary(ctx context.Context, boundaryID int64, limit int) ([]*domain.GreenSpace, error) {
+	query := fmt.Sprintf(`
+		WITH boundary AS (
+			SELECT way
+			FROM %[2]s
+			WHERE osm_id = $1
+			  AND boundary = 'administrative'
+			  AND admin_level IS NOT NULL
+			LIMIT 1
+		),
+		largest AS (
+			SELECT g.osm_id, g.name, g.tags, g.leisure, g.landuse, g.way
+			FROM %[2]s g, boundary b
+			WHERE (g.leisure IN ('park', 'garden', 'nature_reserve', 'playground', 'pitch')
+			   OR g.landuse IN ('forest', 'meadow', 'grass', 'recreation_ground'))
+			  AND ST_Intersects(g.way, b.way)
+			  AND %[3]s
+			ORDER BY ST_Area(g.way) DESC, g.osm_id
+			LIMIT $2
+		)
+		SELECT
+			g.osm_id,
+			COALESCE(g.name, '') AS name,
+			COALESCE(NULLIF(g.name, ''), NULLIF(g.tags->'name:en', ''), '') AS name_en,
+			COALESCE(NULLIF(g.leisure, ''), NULLIF(g.landuse, ''), 'park') AS type,
+			ST_Area(ST_Transform(g.way, %[1]d)::geography) AS area_sq_m,
+			ST_Y(ST_Centroid(ST_Transform(g.way, %[1]d))) AS center_lat,
+			ST_X(ST_Centroid(ST_Transform(g.way, %[1]d))) AS center_lon,
+			COALESCE(g.tags->'access', '') AS access,
+			CASE WHEN ST_CoveredBy(g.way, b.way) THEN NULL
+				ELSE ST_Area(ST_Transform(ST_Intersection(g.way, b.way), %[1]d)::geography)
+			END AS intersection_area_sq_m
+		FROM largest g, boundary b
+		ORDER BY area_sq_m DESC, g.osm_id
+	`, SRID4326, planetPolygonTable, r.queryOpts.activeFeatureCondition("g"))
+
+	rows, err := r.readDB.QueryxContext(ctx, query, boundaryID, limit)
+	if err != nil {
+		r.logger.Error("failed to get osm green spaces in boundary", zap.Int64("boundary_id", boundaryID), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	var spaces []*domain.GreenSpace
+	for rows.Next() {
+		var g domain.GreenSpace
+		var access string
+		var intersection sql.NullFloat64
+
+		err := rows.Scan(&g.OSMId, &g.Name, &g.NameEn, &g.Type, &g.AreaSqM,
+			&g.CenterLat, &g.CenterLon, &access, &intersection)
+		if err != nil {
+			r.logger.Error("failed to scan green space row", zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+
+		g.ID = g.OSMId
+		if access != "" {
+			g.Access = &access
+		}
+		if intersection.Valid {
+			g.IntersectionAreaSqM = &intersection.Float64
+		}
+		g.OrderBy = string(domain.EnvironmentOrderByArea)
+
+		spaces = append(spaces, &g)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to iterate osm green spaces in boundary", zap.Int64("boundary_id", boundaryID), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	if len(spaces) == 0 {
+		exists, err := adminBoundaryExists(ctx, r.readDB, boundaryID)
+		if err != nil {
+			r.logger.Error("failed to check osm boundary", zap.Int64("boundary_id", boundaryID), zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+		if !exists {
+			return nil, pkgerrors.ErrLocationNotFound
+		}
+	}
+
+	return spaces, nil
+}
+
+// GetWaterBodiesNearby возвращает водные объекты рядом с точкой: площадные из planet_osm_polygon
+// и реки, ручьи и каналы, заданные линией, из planet_osm_line (geometry_type различает их).
+// Линия в OSM - десятки отрезков одной реки: отрезки в радиусе с одним именем и waterway сливаются
+// в одну запись (ST_LineMerge) с osm_id и тегами ближайшего отрезка; безымянные не сливаются.
+// Судоходность и приливность - из тегов ship/boat/canoe и tidal (applyWaterTags).
 func (r *environmentRepository) GetWaterBodiesNearby(ctx context.Context, lat, lon, radiusKm float64) ([]*domain.WaterBody, error) {
 	radiusMeters := radiusKm * 1000
 
 	query := fmt.Sprintf(`
 		WITH point AS (
-			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d)::geography AS geom
+			SELECT
+				ST_SetSRID(ST_MakePoint($1, $2), %[1]d)::geography AS geom,
+				ST_Transform(ST_SetSRID(ST_MakePoint($1, $2), %[1]d), %[5]d) AS geom_3857
+		),
+		water AS (
+			SELECT
+				osm_id, name, tags, way,
+				COALESCE(NULLIF("natural", ''), NULLIF(waterway, ''), NULLIF("water", ''), 'water') AS type
+			FROM %[2]s, point
+			WHERE ("natural" IN ('water', 'bay', 'coastline')
+			   OR waterway IN ('river', 'stream', 'canal', 'drain')
+			   OR "water" IS NOT NULL)
+			  AND %[4]s
+			  -- окно в метрах Меркатора растянуто на 1/cos(lat), чтобы покрыть радиус и использовать индекс по way
+			  AND way && ST_Expand(point.geom_3857, $3 / cos(radians($2)))
+			UNION ALL
+			SELECT
+				(array_agg(osm_id ORDER BY way <-> point.geom_3857))[1] AS osm_id,
+				MAX(name) AS name,
+				(array_agg(tags ORDER BY way <-> point.geom_3857))[1] AS tags,
+				ST_LineMerge(ST_Collect(way)) AS way,
+				waterway AS type
+			FROM %[3]s, point
+			WHERE waterway IN ('river', 'stream', 'canal', 'drain')
+			  AND %[4]s
+			  AND way && ST_Expand(point.geom_3857, $3 / cos(radians($2)))
+			  AND ST_DWithin(ST_Transform(way, %[1]d)::geography, point.geom, $3)
+			GROUP BY COALESCE(NULLIF(name, ''), osm_id::text), waterway
 		)
 		SELECT 
 			osm_id,
 			COALESCE(name, '') AS name,
 			COALESCE(NULLIF(name, ''), NULLIF(tags->'name:en', ''), '') AS name_en,
-			COALESCE(NULLIF("natural", ''), NULLIF(waterway, ''), NULLIF("water", ''), 'water') AS type,
-			ST_Area(ST_Transform(way, %d)::geography) AS area_sq_m,
-			ST_Length(ST_Transform(way, %d)::geography) AS length,
-			ST_Distance(ST_Transform(way, %d)::geography, point.geom) AS distance
-		FROM %s, point
-		WHERE ("natural" IN ('water', 'bay', 'coastline')
-		   OR waterway IN ('river', 'stream', 'canal', 'drain')
-		   OR "water" IS NOT NULL)
-		  AND ST_DWithin(ST_Transform(way, %d)::geography, point.geom, $3)
+			type,
+			GeometryType(way) AS geometry_type,
+			CASE WHEN GeometryType(way) LIKE '%%POLYGON' THEN ST_Area(ST_Transform(way, %[1]d)::geography) END AS area_sq_m,
+			ST_Length(ST_Transform(way, %[1]d)::geography) AS length,
+			ST_Distance(ST_Transform(way, %[1]d)::geography, point.geom) AS distance,
+			COALESCE(tags->'ship', '') AS ship,
+			COALESCE(tags->'boat', '') AS boat,
+			COALESCE(tags->'canoe', '') AS canoe,
+			COALESCE(tags->'tidal', '') AS tidal
+		FROM water, point
+		WHERE ST_DWithin(ST_Transform(way, %[1]d)::geography, point.geom, $3)
 		ORDER BY distance
 		LIMIT $4
-	`, SRID4326, SRID4326, SRID4326, SRID4326, planetPolygonTable, SRID4326)
+	`, SRID4326, planetPolygonTable, planetLineTable, r.queryOpts.activeFeatureCondition(""), SRID3857)
 
 	rows, err := r.db.QueryxContext(ctx, query, lon, lat, radiusMeters, LimitWaterBodies)
 	if err != nil {
@@ -121,14 +362,18 @@ func (r *environmentRepository) GetWaterBodiesNearby(ctx context.Context, lat, l
 	for rows.Next() {
 		var w domain.WaterBody
 		var distance float64
+		var ship, boat, canoe, tidal string
 
-		err := rows.Scan(&w.OSMId, &w.Name, &w.NameEn, &w.Type, &w.AreaSqM, &w.Length, &distance)
+		err := rows.Scan(&w.OSMId, &w.Name, &w.NameEn, &w.Type, &w.GeometryType, &w.AreaSqM, &w.Length, &distance,
+			&ship, &boat, &canoe, &tidal)
 		if err != nil {
 			r.logger.Error("failed to scan water body row", zap.Error(err))
 			continue
 		}
 
 		w.ID = w.OSMId
+		w.DistanceM = &distance
+		applyWaterTags(&w, ship, boat, canoe, tidal)
 
 		waterBodies = append(waterBodies, &w)
 	}
@@ -152,13 +397,18 @@ func (r *environmentRepository) GetBeachesNearby(ctx context.Context, lat, lon,
 			ST_Y(ST_Centroid(ST_Transform(way, %d))) AS lat,
 			ST_X(ST_Centroid(ST_Transform(way, %d))) AS lon,
 			ST_Length(ST_Transform(way, %d)::geography) AS length,
-			ST_Distance(ST_Transform(way, %d)::geography, point.geom) AS distance
+			ST_Distance(ST_Transform(way, %d)::geography, point.geom) AS distance,
+			COALESCE(tags->'access', '') AS access,
+			COALESCE(tags->'seasonal', '') AS seasonal,
+			COALESCE(tags->'blue_flag', '') AS blue_flag
 		FROM %s, point
 		WHERE "natural" = 'beach'
 		  AND ST_DWithin(ST_Transform(way, %d)::geography, point.geom, $3)
+		  AND %s
+		  AND %s
 		ORDER BY distance
 		LIMIT $4
-	`, SRID4326, SRID4326, SRID4326, SRID4326, SRID4326, planetPolygonTable, SRID4326)
+	`, SRID4326, SRID4326, SRID4326, SRID4326, SRID4326, planetPolygonTable, SRID4326, r.queryOpts.activeFeatureCondition(""), r.queryOpts.beachAccessCondition())
 
 	rows, err := r.db.QueryxContext(ctx, query, lon, lat, radiusMeters, LimitBeaches)
 	if err != nil {
@@ -171,18 +421,18 @@ func (r *environmentRepository) GetBeachesNearby(ctx context.Context, lat, lon,
 	for rows.Next() {
 		var b domain.Beach
 		var distance float64
-		var surface string
+		var surface, access, seasonal, blueFlag string
 
-		err := rows.Scan(&b.OSMId, &b.Name, &b.NameEn, &surface, &b.Lat, &b.Lon, &b.Length, &distance)
+		err := rows.Scan(&b.OSMId, &b.Name, &b.NameEn, &surface, &b.Lat, &b.Lon, &b.Length, &distance, &access, &seasonal, &blueFlag)
 		if err != nil {
 			r.logger.Error("failed to scan beach row", zap.Error(err))
 			continue
 		}
 
 		b.ID = b.OSMId
+		b.DistanceM = &distance
 		b.Surface = surface
-		blueFlag := false // OSM не содержит информацию о голубом флаге напрямую
-		b.BlueFlag = &blueFlag
+		applyBeachTags(&b, access, seasonal, blueFlag)
 
 		beaches = append(beaches, &b)
 	}
@@ -225,9 +475,10 @@ func (r *environmentRepository) GetNoiseSourcesNearby(ctx context.Context, lat,
 		   OR highway IN ('motorway', 'trunk', 'primary')
 		   OR railway IN ('rail', 'light_rail', 'subway'))
 		  AND ST_DWithin(ST_Transform(way, %d)::geography, point.geom, $3)
+		  AND %s
 		ORDER BY distance
 		LIMIT $4
-	`, SRID4326, SRID4326, SRID4326, SRID4326, planetPolygonTable, SRID4326)
+	`, SRID4326, SRID4326, SRID4326, SRID4326, planetPolygonTable, SRID4326, r.queryOpts.activeFeatureCondition(""))
 
 	rows, err := r.db.QueryxContext(ctx, query, lon, lat, radiusMeters, LimitNoiseSources)
 	if err != nil {
@@ -249,6 +500,7 @@ func (r *environmentRepository) GetNoiseSourcesNearby(ctx context.Context, lat,
 		}
 
 		n.ID = n.OSMId
+		n.DistanceM = &distance
 		if intensity != "" {
 			n.Intensity = &intensity
 		}
@@ -270,15 +522,7 @@ func (r *environmentRepository) GetTouristZonesNearby(ctx context.Context, lat,
 		SELECT 
 			osm_id,
 			COALESCE(name, '') AS name,
-			COALESCE(NULLIF(tags->'name:en', ''), '') AS name_en,
-			COALESCE(NULLIF(tags->'name:es', ''), '') AS name_es,
-			COALESCE(NULLIF(tags->'name:ca', ''), '') AS name_ca,
-			COALESCE(NULLIF(tags->'name:ru', ''), '') AS name_ru,
-			COALESCE(NULLIF(tags->'name:uk', ''), '') AS name_uk,
-			COALESCE(NULLIF(tags->'name:fr', ''), '') AS name_fr,
-			COALESCE(NULLIF(tags->'name:pt', ''), '') AS name_pt,
-			COALESCE(NULLIF(tags->'name:it', ''), '') AS name_it,
-			COALESCE(NULLIF(tags->'name:de', ''), '') AS name_de,
+			%s AS names,
 			COALESCE(tourism, '') AS type,
 			ST_Y(ST_Centroid(ST_Transform(way, %d))) AS lat,
 			ST_X(ST_Centroid(ST_Transform(way, %d))) AS lon,
@@ -289,9 +533,10 @@ func (r *environmentRepository) GetTouristZonesNearby(ctx context.Context, lat,
 		FROM %s, point
 		WHERE tourism IN ('attraction', 'museum', 'theme_park', 'zoo', 'aquarium', 'viewpoint')
 		  AND ST_DWithin(ST_Transform(way, %d)::geography, point.geom, $3)
+		  AND %s
 		ORDER BY distance
 		LIMIT $4
-	`, SRID4326, SRID4326, SRID4326, SRID4326, planetPolygonTable, SRID4326)
+	`, SRID4326, nameTranslationsExpr(""), SRID4326, SRID4326, SRID4326, planetPolygonTable, SRID4326, r.queryOpts.activeFeatureCondition(""))
 
 	rows, err := r.db.QueryxContext(ctx, query, lon, lat, radiusMeters, LimitTouristZones)
 	if err != nil {
@@ -305,9 +550,9 @@ func (r *environmentRepository) GetTouristZonesNearby(ctx context.Context, lat,
 		var z domain.TouristZone
 		var distance float64
 		var fee, openingHours, website string
+		var names []byte
 
-		err := rows.Scan(&z.OSMId, &z.Name, &z.NameEn, &z.NameEs, &z.NameCa,
-			&z.NameRu, &z.NameUk, &z.NameFr, &z.NamePt, &z.NameIt, &z.NameDe,
+		err := rows.Scan(&z.OSMId, &z.Name, &names,
 			&z.Type, &z.Lat, &z.Lon, &fee, &openingHours, &website, &distance)
 		if err != nil {
 			r.logger.Error("failed to scan tourist zone row", zap.Error(err))
@@ -315,6 +560,8 @@ func (r *environmentRepository) GetTouristZonesNearby(ctx context.Context, lat,
 		}
 
 		z.ID = z.OSMId
+		z.SetNames(parseNames(names))
+		z.DistanceM = &distance
 		if fee != "" {
 			// Конвертируем yes/no в bool
 			feeBool := fee == "yes" || fee == "true" || fee == "1"
@@ -333,6 +580,79 @@ func (r *environmentRepository) GetTouristZonesNearby(ctx context.Context, lat,
 	return zones, nil
 }
 
+// GetElevationContext возвращает высотный контекст точки по тегам ele: точки (вершины, геодезические
+// пункты, любые узлы с ele) и горизонтали (contour). Значение ele берется числовым префиксом тега
+// ("123", "123.5 m"), нечисловые значения игнорируются. min/max считаются оконными агрегатами до LIMIT.
+func (r *environmentRepository) GetElevationContext(ctx context.Context, lat, lon, radiusKm float64) (*domain.ElevationContext, error) {
+	radiusMeters := radiusKm * 1000
+
+	query := fmt.Sprintf(`
+		WITH point AS (
+			SELECT ST_SetSRID(ST_MakePoint($1, $2), %[1]d)::geography AS geom
+		),
+		features AS (
+			SELECT
+				osm_id,
+				COALESCE(name, '') AS name,
+				COALESCE(NULLIF("natural", ''), NULLIF(man_made, ''), NULLIF(place, ''), 'node') AS type,
+				%[2]s AS ele,
+				ST_Y(ST_Transform(way, %[1]d)) AS lat,
+				ST_X(ST_Transform(way, %[1]d)) AS lon,
+				ST_Distance(way_geog, point.geom) AS distance
+			FROM %[3]s, point
+			WHERE tags ? 'ele'
+			  AND ST_DWithin(way_geog, point.geom, $3)
+			  AND %[5]s
+			UNION ALL
+			SELECT
+				osm_id,
+				COALESCE(name, '') AS name,
+				'contour' AS type,
+				%[2]s AS ele,
+				ST_Y(ST_ClosestPoint(ST_Transform(way, %[1]d), point.geom::geometry)) AS lat,
+				ST_X(ST_ClosestPoint(ST_Transform(way, %[1]d), point.geom::geometry)) AS lon,
+				ST_Distance(ST_Transform(way, %[1]d)::geography, point.geom) AS distance
+			FROM %[4]s, point
+			WHERE tags ? 'ele'
+			  AND tags ? 'contour'
+			  AND ST_DWithin(ST_Transform(way, %[1]d)::geography, point.geom, $3)
+			  AND %[5]s
+		)
+		SELECT
+			osm_id, name, type, ele, lat, lon, distance,
+			MIN(ele) OVER () AS min_ele,
+			MAX(ele) OVER () AS max_ele,
+			COUNT(*) OVER () AS feature_count
+		FROM features
+		WHERE ele IS NOT NULL
+		ORDER BY distance
+		LIMIT 1
+	`, SRID4326, eleValueExpr, planetPointTable, planetLineTable, r.queryOpts.activeFeatureCondition(""))
+
+	result := &domain.ElevationContext{Lat: lat, Lon: lon, RadiusKm: radiusKm}
+
+	var nearest domain.ElevationFeature
+	var minEle, maxEle float64
+	err := r.db.QueryRowxContext(ctx, query, lon, lat, radiusMeters).Scan(
+		&nearest.OSMId, &nearest.Name, &nearest.Type, &nearest.EleM, &nearest.Lat, &nearest.Lon, &nearest.DistanceM,
+		&minEle, &maxEle, &result.FeatureCount,
+	)
+	if err == sql.ErrNoRows {
+		return result, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to get osm elevation context", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	result.HasData = true
+	result.Nearest = &nearest
+	result.MinEleM = &minEle
+	result.MaxEleM = &maxEle
+
+	return result, nil
+}
+
 // GetGreenSpaceByID возвращает зеленую зону по ID
 func (r *environmentRepository) GetGreenSpaceByID(ctx context.Context, id int64) (*domain.GreenSpace, error) {
 	query := fmt.Sprintf(`
@@ -386,7 +706,10 @@ func (r *environmentRepository) GetBeachByID(ctx context.Context, id int64) (*do
 			COALESCE(tags->'surface', '') AS surface,
 			ST_Y(ST_Centroid(ST_Transform(way, %d))) AS lat,
 			ST_X(ST_Centroid(ST_Transform(way, %d))) AS lon,
-			ST_Length(ST_Transform(way, %d)::geography) AS length
+			ST_Length(ST_Transform(way, %d)::geography) AS length,
+			COALESCE(tags->'access', '') AS access,
+			COALESCE(tags->'seasonal', '') AS seasonal,
+			COALESCE(tags->'blue_flag', '') AS blue_flag
 		FROM %s
 		WHERE osm_id = $1
 		  AND "natural" = 'beach'
@@ -394,10 +717,10 @@ func (r *environmentRepository) GetBeachByID(ctx context.Context, id int64) (*do
 	`, SRID4326, SRID4326, SRID4326, planetPolygonTable)
 
 	var b domain.Beach
-	var surface string
+	var surface, access, seasonal, blueFlag string
 
 	err := r.db.QueryRowxContext(ctx, query, id).Scan(
-		&b.OSMId, &b.Name, &b.NameEn, &surface, &b.Lat, &b.Lon, &b.Length,
+		&b.OSMId, &b.Name, &b.NameEn, &surface, &b.Lat, &b.Lon, &b.Length, &access, &seasonal, &blueFlag,
 	)
 
 	if err == sql.ErrNoRows {
@@ -410,8 +733,7 @@ func (r *environmentRepository) GetBeachByID(ctx context.Context, id int64) (*do
 
 	b.ID = b.OSMId
 	b.Surface = surface
-	blueFlag := false
-	b.BlueFlag = &blueFlag
+	applyBeachTags(&b, access, seasonal, blueFlag)
 
 	return &b, nil
 }
@@ -422,15 +744,7 @@ func (r *environmentRepository) GetTouristZoneByID(ctx context.Context, id int64
 		SELECT 
 			osm_id,
 			COALESCE(name, '') AS name,
-			COALESCE(NULLIF(tags->'name:en', ''), '') AS name_en,
-			COALESCE(NULLIF(tags->'name:es', ''), '') AS name_es,
-			COALESCE(NULLIF(tags->'name:ca', ''), '') AS name_ca,
-			COALESCE(NULLIF(tags->'name:ru', ''), '') AS name_ru,
-			COALESCE(NULLIF(tags->'name:uk', ''), '') AS name_uk,
-			COALESCE(NULLIF(tags->'name:fr', ''), '') AS name_fr,
-			COALESCE(NULLIF(tags->'name:pt', ''), '') AS name_pt,
-			COALESCE(NULLIF(tags->'name:it', ''), '') AS name_it,
-			COALESCE(NULLIF(tags->'name:de', ''), '') AS name_de,
+			%s AS names,
 			COALESCE(tourism, '') AS type,
 			ST_Y(ST_Centroid(ST_Transform(way, %d))) AS lat,
 			ST_X(ST_Centroid(ST_Transform(way, %d))) AS lon,
@@ -441,14 +755,14 @@ func (r *environmentRepository) GetTouristZoneByID(ctx context.Context, id int64
 		WHERE osm_id = $1
 		  AND tourism IN ('attraction', 'museum', 'theme_park', 'zoo', 'aquarium', 'viewpoint')
 		LIMIT 1
-	`, SRID4326, SRID4326, planetPolygonTable)
+	`, nameTranslationsExpr(""), SRID4326, SRID4326, planetPolygonTable)
 
 	var z domain.TouristZone
 	var fee, openingHours, website string
+	var names []byte
 
 	err := r.db.QueryRowxContext(ctx, query, id).Scan(
-		&z.OSMId, &z.Name, &z.NameEn, &z.NameEs, &z.NameCa,
-		&z.NameRu, &z.NameUk, &z.NameFr, &z.NamePt, &z.NameIt, &z.NameDe,
+		&z.OSMId, &z.Name, &names,
 		&z.Type, &z.Lat, &z.Lon, &fee, &openingHours, &website,
 	)
 
@@ -461,6 +775,7 @@ func (r *environmentRepository) GetTouristZoneByID(ctx context.Context, id int64
 	}
 
 	z.ID = z.OSMId
+	z.SetNames(parseNames(names))
 	if fee != "" {
 		// Конвертируем yes/no в bool
 		feeBool := fee == "yes" || fee == "true" || fee == "1"
@@ -478,29 +793,33 @@ func (r *environmentRepository) GetTouristZoneByID(ctx context.Context, id int64
 
 // GetGreenSpacesTile генерирует MVT тайл с зелеными зонами
 func (r *environmentRepository) GetGreenSpacesTile(ctx context.Context, z, x, y int) ([]byte, error) {
+	if !tileZoomPolicy.LayerVisible(domain.TileLayerGreenSpaces, z) {
+		return []byte{}, nil
+	}
+
+	geom, minArea := areaLayerTileGeom(domain.TileLayerGreenSpaces, "way", z)
 	query := fmt.Sprintf(`
 		WITH bounds AS (
 			SELECT ST_TileEnvelope($1, $2, $3) AS geom
 		),
 		green_data AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(NULLIF(leisure, ''), NULLIF(landuse, ''), 'park') AS type,
-				ST_Area(ST_Transform(way, %d)::geography) AS area_sq_m,
-				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
-			FROM %s, bounds
+				%s
+				ST_AsMVTGeom(simplified.geom, bounds.geom, $4, $5, true) AS geom
+			FROM %s, bounds, LATERAL (SELECT %s AS geom) simplified
 			WHERE (leisure IN ('park', 'garden', 'nature_reserve', 'playground', 'pitch')
 			   OR landuse IN ('forest', 'meadow', 'grass', 'recreation_ground'))
 			  AND way && bounds.geom
+			  AND %s
+			  AND (ST_Dimension(simplified.geom) < 2 OR ST_Area(simplified.geom) >= %g)
 		)
-		SELECT COALESCE(ST_AsMVT(green_data.*, 'green_spaces'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(green_data.*, 'green_spaces'), %s) AS tile
 		FROM green_data
 		WHERE geom IS NOT NULL
-	`, SRID4326, planetPolygonTable)
+	`, tileAttributeColumns(string(domain.TileLayerGreenSpaces)), planetPolygonTable, geom, r.queryOpts.activeFeatureCondition(""), minArea, emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -514,30 +833,34 @@ func (r *environmentRepository) GetGreenSpacesTile(ctx context.Context, z, x, y
 
 // GetWaterTile генерирует MVT тайл с водными объектами
 func (r *environmentRepository) GetWaterTile(ctx context.Context, z, x, y int) ([]byte, error) {
+	if !tileZoomPolicy.LayerVisible(domain.TileLayerWater, z) {
+		return []byte{}, nil
+	}
+
+	geom, minArea := areaLayerTileGeom(domain.TileLayerWater, "way", z)
 	query := fmt.Sprintf(`
 		WITH bounds AS (
 			SELECT ST_TileEnvelope($1, $2, $3) AS geom
 		),
 		water_data AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(NULLIF("natural", ''), NULLIF(waterway, ''), NULLIF("water", ''), 'water') AS type,
-				ST_Area(ST_Transform(way, %d)::geography) AS area_sq_m,
-				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
-			FROM %s, bounds
+				%s
+				ST_AsMVTGeom(simplified.geom, bounds.geom, $4, $5, true) AS geom
+			FROM %s, bounds, LATERAL (SELECT %s AS geom) simplified
 			WHERE ("natural" IN ('water', 'bay', 'coastline')
 			   OR waterway IN ('river', 'stream', 'canal', 'drain')
 			   OR "water" IS NOT NULL)
 			  AND way && bounds.geom
+			  AND %s
+			  AND (ST_Dimension(simplified.geom) < 2 OR ST_Area(simplified.geom) >= %g)
 		)
-		SELECT COALESCE(ST_AsMVT(water_data.*, 'water'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(water_data.*, 'water'), %s) AS tile
 		FROM water_data
 		WHERE geom IS NOT NULL
-	`, SRID4326, planetPolygonTable)
+	`, tileAttributeColumns(string(domain.TileLayerWater)), planetPolygonTable, geom, r.queryOpts.activeFeatureCondition(""), minArea, emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -551,8 +874,7 @@ func (r *environmentRepository) GetWaterTile(ctx context.Context, z, x, y int) (
 
 // GetBeachesTile генерирует MVT тайл с пляжами
 func (r *environmentRepository) GetBeachesTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	// Пляжи видны с zoom >= 12
-	if z < 12 {
+	if !tileZoomPolicy.LayerVisible(domain.TileLayerBeaches, z) {
 		return []byte{}, nil
 	}
 
@@ -562,22 +884,20 @@ func (r *environmentRepository) GetBeachesTile(ctx context.Context, z, x, y int)
 		),
 		beach_data AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(tags->'surface', '') AS surface,
-				ST_Length(ST_Transform(way, %d)::geography) AS width_m,
-				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
+				%s
+				ST_AsMVTGeom(%s, bounds.geom, $4, $5, true) AS geom
 			FROM %s, bounds
 			WHERE "natural" = 'beach'
 			  AND way && bounds.geom
+			  AND %s
 		)
-		SELECT COALESCE(ST_AsMVT(beach_data.*, 'beaches'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(beach_data.*, 'beaches'), %s) AS tile
 		FROM beach_data
 		WHERE geom IS NOT NULL
-	`, SRID4326, planetPolygonTable)
+	`, tileAttributeColumns(string(domain.TileLayerBeaches)), tileGeomExpr("way", z), planetPolygonTable, r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -591,8 +911,7 @@ func (r *environmentRepository) GetBeachesTile(ctx context.Context, z, x, y int)
 
 // GetNoiseSourcesTile генерирует MVT тайл с источниками шума
 func (r *environmentRepository) GetNoiseSourcesTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	// Источники шума видны с zoom >= 10
-	if z < 10 {
+	if !tileZoomPolicy.LayerVisible(domain.TileLayerNoiseSources, z) {
 		return []byte{}, nil
 	}
 
@@ -602,38 +921,23 @@ func (r *environmentRepository) GetNoiseSourcesTile(ctx context.Context, z, x, y
 		),
 		noise_data AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				CASE 
-					WHEN aeroway IS NOT NULL THEN 'airport'
-					WHEN landuse = 'industrial' THEN 'industrial'
-					WHEN highway IN ('motorway', 'trunk', 'primary') THEN 'highway'
-					WHEN railway IS NOT NULL THEN 'railway'
-					ELSE 'other'
-				END AS type,
-				CASE 
-					WHEN aeroway IS NOT NULL THEN 'high'
-					WHEN landuse = 'industrial' THEN 'medium'
-					WHEN highway IN ('motorway', 'trunk') THEN 'high'
-					WHEN highway = 'primary' THEN 'medium'
-					WHEN railway IS NOT NULL THEN 'medium'
-					ELSE 'low'
-				END AS noise_level,
-				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
+				%s
+				ST_AsMVTGeom(%s, bounds.geom, $4, $5, true) AS geom
 			FROM %s, bounds
 			WHERE (aeroway IN ('aerodrome', 'heliport')
 			   OR landuse = 'industrial'
 			   OR highway IN ('motorway', 'trunk', 'primary')
 			   OR railway IN ('rail', 'light_rail', 'subway'))
 			  AND way && bounds.geom
+			  AND %s
 		)
-		SELECT COALESCE(ST_AsMVT(noise_data.*, 'noise_sources'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(noise_data.*, 'noise_sources'), %s) AS tile
 		FROM noise_data
 		WHERE geom IS NOT NULL
-	`, planetPolygonTable)
+	`, tileAttributeColumns(string(domain.TileLayerNoiseSources)), tileGeomExpr("way", z), planetPolygonTable, r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -647,8 +951,7 @@ func (r *environmentRepository) GetNoiseSourcesTile(ctx context.Context, z, x, y
 
 // GetTouristZonesTile генерирует MVT тайл с туристическими зонами
 func (r *environmentRepository) GetTouristZonesTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	// Туристические зоны видны с zoom >= 11
-	if z < 11 {
+	if !tileZoomPolicy.LayerVisible(domain.TileLayerTouristZones, z) {
 		return []byte{}, nil
 	}
 
@@ -658,21 +961,20 @@ func (r *environmentRepository) GetTouristZonesTile(ctx context.Context, z, x, y
 		),
 		tourist_data AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(tourism, '') AS type,
-				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
+				%s
+				ST_AsMVTGeom(%s, bounds.geom, $4, $5, true) AS geom
 			FROM %s, bounds
 			WHERE tourism IN ('attraction', 'museum', 'theme_park', 'zoo', 'aquarium', 'viewpoint')
 			  AND way && bounds.geom
+			  AND %s
 		)
-		SELECT COALESCE(ST_AsMVT(tourist_data.*, 'tourist_zones'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(tourist_data.*, 'tourist_zones'), %s) AS tile
 		FROM tourist_data
 		WHERE geom IS NOT NULL
-	`, planetPolygonTable)
+	`, tileAttributeColumns(string(domain.TileLayerTouristZones)), tileGeomExpr("way", z), planetPolygonTable, r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, z, x, y, MVTExtent, MVTBuffer).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -699,26 +1001,24 @@ func (r *environmentRepository) GetEnvironmentRadiusTile(ctx context.Context, la
 		),
 		green_data AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(NULLIF(leisure, ''), NULLIF(landuse, ''), 'park') AS type,
-				ST_Area(ST_Transform(way, %d)::geography) AS area_sq_m,
+				%s
 				ST_AsMVTGeom(way, circle.geom, $4, $5, true) AS geom
 			FROM %s, circle
 			WHERE (leisure IN ('park', 'garden', 'nature_reserve', 'playground', 'pitch')
 			   OR landuse IN ('forest', 'meadow', 'grass', 'recreation_ground'))
 			  AND way && circle.geom
 			  AND ST_Intersects(way, circle.geom)
+			  AND %s
 			ORDER BY area_sq_m DESC
 			LIMIT $6
 		)
-		SELECT COALESCE(ST_AsMVT(green_data.*, 'green_spaces'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(green_data.*, 'green_spaces'), %s) AS tile
 		FROM green_data
 		WHERE geom IS NOT NULL
-	`, SRID4326, SRID4326, planetPolygonTable)
+	`, SRID4326, tileAttributeColumns(string(domain.TileLayerGreenSpaces)), planetPolygonTable, r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var greenTile []byte
-	err := r.db.QueryRowContext(ctx, greenQuery, lon, lat, radiusMeters, MVTExtent, MVTBuffer, LimitGreenSpaces).Scan(&greenTile)
+	err := r.readDB.QueryRowContext(ctx, greenQuery, lon, lat, radiusMeters, MVTExtent, MVTBuffer, LimitGreenSpaces).Scan(&greenTile)
 	if err != nil && err != sql.ErrNoRows {
 		r.logger.Error("failed to build osm green spaces radius tile", zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -735,24 +1035,23 @@ func (r *environmentRepository) GetEnvironmentRadiusTile(ctx context.Context, la
 		),
 		beach_data AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(tags->'surface', '') AS surface,
+				%s
 				ST_AsMVTGeom(way, circle.geom, $4, $5, true) AS geom
 			FROM %s, circle
 			WHERE "natural" = 'beach'
 			  AND way && circle.geom
 			  AND ST_Intersects(way, circle.geom)
+			  AND %s
 			ORDER BY name
 			LIMIT $6
 		)
-		SELECT COALESCE(ST_AsMVT(beach_data.*, 'beaches'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(beach_data.*, 'beaches'), %s) AS tile
 		FROM beach_data
 		WHERE geom IS NOT NULL
-	`, SRID4326, planetPolygonTable)
+	`, SRID4326, tileAttributeColumns(string(domain.TileLayerBeaches)), planetPolygonTable, r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var beachesTile []byte
-	err = r.db.QueryRowContext(ctx, beachesQuery, lon, lat, radiusMeters, MVTExtent, MVTBuffer, LimitBeaches).Scan(&beachesTile)
+	err = r.readDB.QueryRowContext(ctx, beachesQuery, lon, lat, radiusMeters, MVTExtent, MVTBuffer, LimitBeaches).Scan(&beachesTile)
 	if err != nil && err != sql.ErrNoRows {
 		r.logger.Error("failed to build osm beaches radius tile", zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -2,8 +2,10 @@ package postgresosm
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/location-microservice/internal/domain"
 	pkgerrors "github.com/location-microservice/internal/pkg/errors"
 )
 
@@ -12,14 +14,14 @@ func TestEnvironmentRepository_GetGreenSpacesNearby(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get green spaces nearby", func(t *testing.T) {
 		lat, lon := 41.3851, 2.1734 // Barcelona
 		radiusKm := 5.0
 
-		spaces, err := repo.GetGreenSpacesNearby(ctx, lat, lon, radiusKm)
+		spaces, err := repo.GetGreenSpacesNearby(ctx, lat, lon, radiusKm, 0, domain.EnvironmentOrderOptions{})
 		if err != nil {
 			t.Fatalf("Failed to get green spaces: %v", err)
 		}
@@ -49,7 +51,7 @@ func TestEnvironmentRepository_GetGreenSpacesNearby(t *testing.T) {
 		lat, lon := 41.3851, 2.1734
 		radiusKm := 1.0
 
-		spaces, err := repo.GetGreenSpacesNearby(ctx, lat, lon, radiusKm)
+		spaces, err := repo.GetGreenSpacesNearby(ctx, lat, lon, radiusKm, 0, domain.EnvironmentOrderOptions{})
 		if err != nil {
 			t.Fatalf("Failed to get green spaces: %v", err)
 		}
@@ -57,6 +59,112 @@ func TestEnvironmentRepository_GetGreenSpacesNearby(t *testing.T) {
 		// Small radius might have fewer or no spaces
 		_ = spaces
 	})
+
+	t.Run("Order green spaces by area", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+		radiusKm := 3.0
+
+		spaces, err := repo.GetGreenSpacesNearby(ctx, lat, lon, radiusKm, 0, domain.EnvironmentOrderOptions{
+			OrderBy: domain.EnvironmentOrderByArea,
+		})
+		if err != nil {
+			t.Fatalf("Failed to get green spaces: %v", err)
+		}
+
+		for i := 1; i < len(spaces); i++ {
+			if spaces[i].AreaSqM > spaces[i-1].AreaSqM {
+				t.Errorf("Expected green spaces ordered by area desc, got %f after %f", spaces[i].AreaSqM, spaces[i-1].AreaSqM)
+			}
+		}
+	})
+
+	t.Run("Order green spaces by score", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+		radiusKm := 3.0
+
+		spaces, err := repo.GetGreenSpacesNearby(ctx, lat, lon, radiusKm, 0, domain.EnvironmentOrderOptions{
+			OrderBy:        domain.EnvironmentOrderByScore,
+			DistanceWeight: 0.3,
+			AreaWeight:     0.7,
+		})
+		if err != nil {
+			t.Fatalf("Failed to get green spaces: %v", err)
+		}
+
+		for i, space := range spaces {
+			if space.SortValue == nil || space.OrderBy != string(domain.EnvironmentOrderByScore) {
+				t.Fatalf("Expected score sort value to be returned")
+			}
+			if *space.SortValue < 0 || *space.SortValue > 1 {
+				t.Errorf("Expected score in [0, 1], got %f", *space.SortValue)
+			}
+			if i > 0 && *space.SortValue > *spaces[i-1].SortValue {
+				t.Errorf("Expected green spaces ordered by score desc")
+			}
+		}
+	})
+
+	t.Run("Filter green spaces by min area", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+		radiusKm := 3.0
+		minAreaSqM := 5000.0
+
+		spaces, err := repo.GetGreenSpacesNearby(ctx, lat, lon, radiusKm, minAreaSqM, domain.EnvironmentOrderOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get green spaces: %v", err)
+		}
+
+		for _, space := range spaces {
+			if space.AreaSqM < minAreaSqM {
+				t.Errorf("Expected area >= %f, got %f", minAreaSqM, space.AreaSqM)
+			}
+		}
+	})
+}
+
+func TestEnvironmentRepository_GetGreenSpacesInBoundary(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewEnvironmentRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("Unknown boundary", func(t *testing.T) {
+		_, err := repo.GetGreenSpacesInBoundary(ctx, 0, 10)
+		if err != pkgerrors.ErrLocationNotFound {
+			t.Errorf("Expected ErrLocationNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Ordered by area", func(t *testing.T) {
+		var boundaryID int64
+		query := `SELECT osm_id FROM planet_osm_polygon
+				  WHERE boundary = 'administrative' AND admin_level = '8'
+				  LIMIT 1`
+		err := db.QueryRowContext(ctx, query).Scan(&boundaryID)
+		if err != nil {
+			t.Skipf("No level 8 boundaries found in database: %v", err)
+		}
+
+		spaces, err := repo.GetGreenSpacesInBoundary(ctx, boundaryID, 10)
+		if err != nil {
+			t.Fatalf("Failed to get green spaces in boundary: %v", err)
+		}
+		if len(spaces) > 10 {
+			t.Errorf("Expected at most 10 green spaces, got %d", len(spaces))
+		}
+
+		for i, space := range spaces {
+			if i > 0 && space.AreaSqM > spaces[i-1].AreaSqM {
+				t.Errorf("Expected descending area, got %f after %f", space.AreaSqM, spaces[i-1].AreaSqM)
+			}
+			if space.IntersectionAreaSqM != nil && *space.IntersectionAreaSqM > space.AreaSqM+1 {
+				t.Errorf("Expected intersection area %f <= area %f", *space.IntersectionAreaSqM, space.AreaSqM)
+			}
+			assertValidCoordinates(t, space.CenterLat, space.CenterLon)
+		}
+	})
 }
 
 func TestEnvironmentRepository_GetWaterBodiesNearby(t *testing.T) {
@@ -64,7 +172,7 @@ func TestEnvironmentRepository_GetWaterBodiesNearby(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get water bodies nearby", func(t *testing.T) {
@@ -91,6 +199,53 @@ func TestEnvironmentRepository_GetWaterBodiesNearby(t *testing.T) {
 			if water.Type == "" {
 				t.Error("Expected non-empty type")
 			}
+			if water.GeometryType == "" {
+				t.Error("Expected non-empty geometry type")
+			}
+			if strings.HasSuffix(water.GeometryType, "LINESTRING") && water.AreaSqM != nil {
+				t.Errorf("Expected no area for line %d, got %f", water.OSMId, *water.AreaSqM)
+			}
+			if water.DistanceM == nil || *water.DistanceM > radiusKm*1000 {
+				t.Errorf("Expected water body %d within %.0f km", water.OSMId, radiusKm)
+			}
+		}
+	})
+
+	t.Run("Lines are only waterways", func(t *testing.T) {
+		// Llobregat и Besòs в радиусе 15 км от центра Барселоны
+		waterBodies, err := repo.GetWaterBodiesNearby(ctx, 41.3851, 2.1734, 15)
+		if err != nil {
+			t.Fatalf("Failed to get water bodies: %v", err)
+		}
+
+		for _, water := range waterBodies {
+			if !strings.HasSuffix(water.GeometryType, "LINESTRING") {
+				continue
+			}
+			switch water.Type {
+			case "river", "stream", "canal", "drain":
+			default:
+				t.Errorf("Expected waterway type for line %d, got %q", water.OSMId, water.Type)
+			}
+		}
+	})
+
+	t.Run("Named line segments merged", func(t *testing.T) {
+		waterBodies, err := repo.GetWaterBodiesNearby(ctx, 41.3851, 2.1734, 15)
+		if err != nil {
+			t.Fatalf("Failed to get water bodies: %v", err)
+		}
+
+		seen := make(map[string]int64)
+		for _, water := range waterBodies {
+			if !strings.HasSuffix(water.GeometryType, "LINESTRING") || water.Name == nil || *water.Name == "" {
+				continue
+			}
+			key := *water.Name + "/" + water.Type
+			if id, ok := seen[key]; ok {
+				t.Errorf("Expected one record for %s, got %d and %d", key, id, water.OSMId)
+			}
+			seen[key] = water.OSMId
 		}
 	})
 
@@ -114,7 +269,7 @@ func TestEnvironmentRepository_GetBeachesNearby(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get beaches nearby coastal city", func(t *testing.T) {
@@ -139,8 +294,11 @@ func TestEnvironmentRepository_GetBeachesNearby(t *testing.T) {
 			}
 			assertValidCoordinates(t, beach.Lat, beach.Lon)
 
-			if beach.BlueFlag == nil {
-				t.Error("Expected BlueFlag to be initialized")
+			if beach.Access != nil && (*beach.Access == "private" || *beach.Access == "no") {
+				t.Errorf("Expected restricted beach %d to be excluded, got access=%s", beach.ID, *beach.Access)
+			}
+			if beach.Season != nil && (beach.Seasonal == nil || !*beach.Seasonal) {
+				t.Errorf("Expected beach %d with season %q to be seasonal", beach.ID, *beach.Season)
 			}
 		}
 	})
@@ -167,7 +325,7 @@ func TestEnvironmentRepository_GetNoiseSourcesNearby(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get noise sources nearby", func(t *testing.T) {
@@ -224,7 +382,7 @@ func TestEnvironmentRepository_GetTouristZonesNearby(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get tourist zones nearby", func(t *testing.T) {
@@ -268,12 +426,48 @@ func TestEnvironmentRepository_GetTouristZonesNearby(t *testing.T) {
 	})
 }
 
+func TestEnvironmentRepository_GetElevationContext(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewEnvironmentRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	lat, lon := 41.4183, 2.1195 // Collserola, Barcelona
+	result, err := repo.GetElevationContext(ctx, lat, lon, 5.0)
+	if err != nil {
+		t.Fatalf("Failed to get elevation context: %v", err)
+	}
+
+	if !result.HasData {
+		if result.Nearest != nil || result.MinEleM != nil || result.MaxEleM != nil {
+			t.Error("Expected empty elevation context without data")
+		}
+		return
+	}
+
+	if result.Nearest == nil || result.MinEleM == nil || result.MaxEleM == nil {
+		t.Fatal("Expected nearest feature and min/max elevation")
+	}
+	if *result.MinEleM > *result.MaxEleM {
+		t.Errorf("Expected min elevation %f <= max %f", *result.MinEleM, *result.MaxEleM)
+	}
+	if result.Nearest.EleM < *result.MinEleM || result.Nearest.EleM > *result.MaxEleM {
+		t.Errorf("Expected nearest elevation %f within [%f, %f]", result.Nearest.EleM, *result.MinEleM, *result.MaxEleM)
+	}
+	if result.FeatureCount < 1 {
+		t.Errorf("Expected positive feature count, got %d", result.FeatureCount)
+	}
+	assertValidCoordinates(t, result.Nearest.Lat, result.Nearest.Lon)
+}
+
 func TestEnvironmentRepository_GetGreenSpaceByID(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get existing green space by ID", func(t *testing.T) {
@@ -321,7 +515,7 @@ func TestEnvironmentRepository_GetBeachByID(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get existing beach by ID", func(t *testing.T) {
@@ -349,10 +543,6 @@ func TestEnvironmentRepository_GetBeachByID(t *testing.T) {
 		}
 
 		assertValidCoordinates(t, beach.Lat, beach.Lon)
-
-		if beach.BlueFlag == nil {
-			t.Error("Expected BlueFlag to be initialized")
-		}
 	})
 
 	t.Run("Get non-existing beach", func(t *testing.T) {
@@ -368,7 +558,7 @@ func TestEnvironmentRepository_GetTouristZoneByID(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get existing tourist zone by ID", func(t *testing.T) {
@@ -415,7 +605,7 @@ func TestEnvironmentRepository_GetGreenSpacesTile(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get green spaces tile", func(t *testing.T) {
@@ -447,12 +637,53 @@ func TestEnvironmentRepository_GetGreenSpacesTile(t *testing.T) {
 	})
 }
 
+func TestEnvironmentRepository_TileIsValidMVT(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewEnvironmentRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("Empty tile is a valid MVT without layers", func(t *testing.T) {
+		// Атлантика, объектов нет
+		tile, err := repo.GetGreenSpacesTile(ctx, 14, 6826, 6203)
+		if err != nil {
+			t.Fatalf("Failed to get green spaces tile: %v", err)
+		}
+		if len(tile) != 0 {
+			t.Errorf("Expected zero-length tile, got %d bytes", len(tile))
+		}
+		if names, err := mvtLayerNames(tile); err != nil || len(names) != 0 {
+			t.Errorf("Expected valid empty MVT, got layers %v, err %v", names, err)
+		}
+	})
+
+	t.Run("Non-empty tile decodes with its layer", func(t *testing.T) {
+		// Barcelona area tile
+		tile, err := repo.GetGreenSpacesTile(ctx, 14, 8311, 6143)
+		if err != nil {
+			t.Fatalf("Failed to get green spaces tile: %v", err)
+		}
+		if len(tile) == 0 {
+			t.Skip("No green spaces in test tile")
+		}
+		names, err := mvtLayerNames(tile)
+		if err != nil {
+			t.Fatalf("Tile is not a valid MVT: %v", err)
+		}
+		if len(names) != 1 || names[0] != "green_spaces" {
+			t.Errorf("Expected [green_spaces] layer, got %v", names)
+		}
+	})
+}
+
 func TestEnvironmentRepository_GetWaterTile(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get water tile", func(t *testing.T) {
@@ -470,12 +701,61 @@ func TestEnvironmentRepository_GetWaterTile(t *testing.T) {
 	})
 }
 
+func TestEnvironmentRepository_AreaTileSizeRegression(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+	defer ConfigureTileZoomPolicy(domain.DefaultTileZoomPolicy())
+	defer func() { areaLayerSimplifications = defaultAreaLayerSimplifications() }()
+
+	repo := NewEnvironmentRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	// Barcelona area tile на мелком масштабе
+	z, x, y := 8, 8311>>6, 6143>>6
+	tiles := map[string]func(context.Context, int, int, int) ([]byte, error){
+		"green_spaces": repo.GetGreenSpacesTile,
+		"water":        repo.GetWaterTile,
+	}
+
+	for layer, getTile := range tiles {
+		t.Run(layer, func(t *testing.T) {
+			// Полное разрешение: без упрощения и без порога площади
+			ConfigureTileZoomPolicy(domain.TileZoomPolicy{Rules: []domain.TileZoomRule{
+				{MinZoom: 0, MaxZoom: domain.TileMaxZoom, Layers: []domain.TileLayer{domain.TileLayerGreenSpaces, domain.TileLayerWater}},
+			}})
+			if err := ConfigureAreaLayerSimplification(nil, map[string]string{layer: "0"}); err != nil {
+				t.Fatalf("Failed to configure simplification: %v", err)
+			}
+			full, err := getTile(ctx, z, x, y)
+			if err != nil {
+				t.Fatalf("Failed to get full resolution tile: %v", err)
+			}
+			if len(full) == 0 {
+				t.Skip("No features in test tile")
+			}
+
+			ConfigureTileZoomPolicy(domain.DefaultTileZoomPolicy())
+			areaLayerSimplifications = defaultAreaLayerSimplifications()
+			simplified, err := getTile(ctx, z, x, y)
+			if err != nil {
+				t.Fatalf("Failed to get simplified tile: %v", err)
+			}
+
+			if len(simplified) > len(full) {
+				t.Errorf("Expected simplified tile not larger than full resolution: %d > %d bytes", len(simplified), len(full))
+			}
+			t.Logf("%s z%d: %d -> %d bytes", layer, z, len(full), len(simplified))
+		})
+	}
+}
+
 func TestEnvironmentRepository_GetBeachesTile(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get beaches tile at high zoom", func(t *testing.T) {
@@ -515,7 +795,7 @@ func TestEnvironmentRepository_GetNoiseSourcesTile(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get noise sources tile at high zoom", func(t *testing.T) {
@@ -555,7 +835,7 @@ func TestEnvironmentRepository_GetTouristZonesTile(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get tourist zones tile at high zoom", func(t *testing.T) {
@@ -595,7 +875,7 @@ func TestEnvironmentRepository_GetEnvironmentRadiusTile(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewEnvironmentRepository(db)
+	repo := NewEnvironmentRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get environment radius tile", func(t *testing.T) {
@@ -1,11 +1,15 @@
 package postgresosm
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"hash/fnv"
+	"math"
 	"strconv"
 	"strings"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/location-microservice/internal/domain"
 )
 
@@ -15,11 +19,35 @@ func hashCategory(code string) int64 {
 	return int64(h.Sum64())
 }
 
-func ensureName(name string, category string, osmID int64) string {
-	if strings.TrimSpace(name) != "" {
-		return name
+// poiNameFallback и poiNameFallbackLang - имя POI без name в OSM (POI_NAME_FALLBACK, POI_NAME_FALLBACK_LANG).
+// Задаются при старте через ConfigurePOINameFallback.
+var (
+	poiNameFallback     = domain.POINameFallbackSynthesize
+	poiNameFallbackLang = "en"
+)
+
+// ConfigurePOINameFallback задает режим имени для безымянных POI и язык подписи категории (пусто - en).
+// Вызывается один раз при старте, до создания репозиториев.
+func ConfigurePOINameFallback(mode, lang string) error {
+	fallback, err := domain.ParsePOINameFallback(mode)
+	if err != nil {
+		return err
 	}
-	return strings.Title(category) + " " + strconv.FormatInt(osmID, 10)
+	if lang == "" {
+		lang = "en"
+	}
+	if _, err := domain.ParseNameLanguages([]string{lang}); err != nil {
+		return fmt.Errorf("poi name fallback language: %w", err)
+	}
+
+	poiNameFallback = fallback
+	poiNameFallbackLang = lang
+	return nil
+}
+
+// ensureName возвращает name, а для безымянного POI - имя по настроенному режиму fallback
+func ensureName(name string, category string, osmID int64) string {
+	return poiNameFallback.Name(name, category, osmID, poiNameFallbackLang)
 }
 
 func parseTags(raw []byte) map[string]string {
@@ -28,11 +56,36 @@ func parseTags(raw []byte) map[string]string {
 	}
 
 	var tmp map[string]string
-	if err := json.Unmarshal(raw, &tmp); err != nil {
+	if err := json.Unmarshal(raw, &tmp); err == nil {
+		if tmp == nil {
+			return map[string]string{}
+		}
+		return tmp
+	}
+
+	// Fallback: не теряем все теги из-за одного нестрокового значения
+	var loose map[string]interface{}
+	if err := json.Unmarshal(raw, &loose); err != nil {
 		return map[string]string{}
 	}
 
-	return tmp
+	tags := make(map[string]string, len(loose))
+	for key, val := range loose {
+		switch v := val.(type) {
+		case nil:
+			continue
+		case string:
+			tags[key] = v
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			tags[key] = string(encoded)
+		}
+	}
+
+	return tags
 }
 
 func pickTag(tags map[string]string, keys ...string) *string {
@@ -104,6 +157,7 @@ func parsePOIFromRow(row *poiRow) *domain.POI {
 	poi.NameDe = pickTag(tags, "name:de")
 
 	poi.Address = pickTag(tags, "addr:full", "addr:street", "addr:place")
+	poi.AddressDetails = parsePOIAddress(tags)
 	poi.Phone = pickTag(tags, "phone", "contact:phone")
 	poi.Website = pickTag(tags, "website", "contact:website", "url")
 	poi.Email = pickTag(tags, "email", "contact:email")
@@ -149,6 +203,71 @@ func parsePOIFromRow(row *poiRow) *domain.POI {
 	return poi
 }
 
+// parsePOIAddress собирает структурированный адрес из тегов addr:*
+func parsePOIAddress(tags map[string]string) *domain.POIAddress {
+	return domain.NewPOIAddress(
+		tags["addr:street"],
+		tags["addr:housenumber"],
+		tags["addr:postcode"],
+		tags["addr:city"],
+	)
+}
+
+// poiTileMaxFeatures - верхний предел POI в одном тайле из конфига (POI_TILE_MAX_FEATURES)
+var poiTileMaxFeatures = 1000
+
+// ConfigurePOITileMaxFeatures задает верхний предел POI в тайле; значения <= 0 оставляют текущий
+func ConfigurePOITileMaxFeatures(maxFeatures int) {
+	if maxFeatures > 0 {
+		poiTileMaxFeatures = maxFeatures
+	}
+}
+
+// poiTileFeatureLimit - эффективный лимит POI в тайле: меньший из лимита зума (getPOILimitByZoom)
+// и предела из конфига. Конфиг только урезает лимиты зумов, но не поднимает их.
+func poiTileFeatureLimit(zoom int) int {
+	return min(getPOILimitByZoom(zoom), poiTileMaxFeatures)
+}
+
+// poiTileCategoryLimit - предел POI одной категории в тайле. requested > 0 задан клиентом и не больше
+// общего лимита limit; иначе при нескольких категориях лимит делится между ними поровну, чтобы
+// плотная категория (скамейки) не вытесняла редкие (больницы). 0 - без предела по категориям.
+func poiTileCategoryLimit(limit, categoryCount, requested int) int {
+	if requested > 0 {
+		return min(requested, limit)
+	}
+	if categoryCount > 1 {
+		return (limit + categoryCount - 1) / categoryCount
+	}
+	return 0
+}
+
+// poiTileDataSQL возвращает тело CTE data тайла POI: объекты src в границах тайла с фильтром filterClause.
+// categoryLimit > 0 оставляет в каждой категории не больше categoryLimit самых значимых POI
+// (ROW_NUMBER() OVER (PARTITION BY category)) до общего LIMIT и ST_AsMVT.
+func poiTileDataSQL(src, filterClause string, categoryLimit int) string {
+	if categoryLimit <= 0 {
+		return fmt.Sprintf(`
+			SELECT osm_id, name, category, subcategory, tags, way
+			FROM (
+				%s
+			) src
+			WHERE way && (SELECT geom FROM bounds)%s`, src, filterClause)
+	}
+
+	return fmt.Sprintf(`
+			SELECT osm_id, name, category, subcategory, tags, way
+			FROM (
+				SELECT osm_id, name, category, subcategory, tags, way,
+					ROW_NUMBER() OVER (PARTITION BY category ORDER BY %s) AS category_rank
+				FROM (
+					%s
+				) src
+				WHERE way && (SELECT geom FROM bounds)%s
+			) ranked
+			WHERE category_rank <= %d`, poiTileImportanceOrder, src, filterClause, categoryLimit)
+}
+
 func getPOILimitByZoom(zoom int) int {
 	switch {
 	case zoom < 10:
@@ -162,6 +281,23 @@ func getPOILimitByZoom(zoom int) int {
 	}
 }
 
+// boundaryMinAreaSqKmByZoom возвращает порог площади полигона (км²) для тайла границ:
+// примерно один пиксель 256px тайла на экваторе. Более мелкие полигоны на этом зуме не видны.
+func boundaryMinAreaSqKmByZoom(zoom int) float64 {
+	metersPerPixel := tileMetersPerPixel(zoom)
+	return metersPerPixel * metersPerPixel / 1e6
+}
+
+// boundaryPlanarMinArea переводит порог площади (км²) в площадь Web Mercator (м²) для тайла z/y:
+// проекция растягивает площади в 1/cos²(lat), масштаб берется по широте центра тайла.
+// Сравнение с ST_Area(way) обходится без ST_Transform и geography на каждом полигоне.
+func boundaryPlanarMinArea(z, y int, minAreaSqKm float64) float64 {
+	n := math.Exp2(float64(z))
+	centerLat := math.Atan(math.Sinh(math.Pi * (1 - 2*(float64(y)+0.5)/n)))
+	cos := math.Cos(centerLat)
+	return minAreaSqKm * 1e6 / (cos * cos)
+}
+
 // scanInt64Array парсит PostgreSQL array bigint[] в []int64
 func scanInt64Array(src interface{}) ([]int64, error) {
 	if src == nil {
@@ -212,3 +348,18 @@ func int64ArrayToString(arr []int64) string {
 	}
 	return "{" + strings.Join(strs, ",") + "}"
 }
+
+// adminBoundaryExists проверяет существование административной границы.
+// Нужна, чтобы отличить неизвестную границу (404) от границы без объектов внутри.
+func adminBoundaryExists(ctx context.Context, db *sqlx.DB, boundaryID int64) (bool, error) {
+	query := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1 FROM %s
+			WHERE osm_id = $1 AND boundary = 'administrative' AND admin_level IS NOT NULL
+		)
+	`, planetPolygonTable)
+
+	var exists bool
+	err := db.GetContext(ctx, &exists, query, boundaryID)
+	return exists, err
+}
@@ -1,6 +1,15 @@
 package postgresosm
 
-import "testing"
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/location-microservice/internal/domain"
+)
 
 func TestParseYesNo(t *testing.T) {
 	tests := []struct {
@@ -37,6 +46,49 @@ func TestEnsureName(t *testing.T) {
 	}
 }
 
+func TestEnsureNameFallbackModes(t *testing.T) {
+	defer func() {
+		if err := ConfigurePOINameFallback("", ""); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	if got := ensureName("", "pharmacy", 7); got != "Pharmacy 7" {
+		t.Errorf("default fallback: got %q", got)
+	}
+
+	if err := ConfigurePOINameFallback("synthesize", "es"); err != nil {
+		t.Fatal(err)
+	}
+	if got := ensureName("", "pharmacy", 7); got != "Farmacia 7" {
+		t.Errorf("localized fallback: got %q", got)
+	}
+
+	if err := ConfigurePOINameFallback("category", "es"); err != nil {
+		t.Fatal(err)
+	}
+	if got := ensureName(" ", "healthcare", 7); got != "Salud" {
+		t.Errorf("category fallback: got %q", got)
+	}
+
+	if err := ConfigurePOINameFallback("empty", ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := ensureName("", "restaurant", 7); got != "" {
+		t.Errorf("empty fallback: got %q", got)
+	}
+	if got := ensureName("Can Culleretes", "restaurant", 7); got != "Can Culleretes" {
+		t.Errorf("explicit name: got %q", got)
+	}
+
+	if err := ConfigurePOINameFallback("osm_id", ""); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+	if err := ConfigurePOINameFallback("category", "Spanish!"); err == nil {
+		t.Error("expected error for invalid language")
+	}
+}
+
 func TestHashCategoryDeterministic(t *testing.T) {
 	a := hashCategory("tourism")
 	b := hashCategory("tourism")
@@ -49,3 +101,694 @@ func TestHashCategoryDeterministic(t *testing.T) {
 		t.Fatalf("hashCategory should differ for different inputs")
 	}
 }
+
+func TestParseTags(t *testing.T) {
+	raw := []byte(`{"name":"Café \"Sol\"","addr:street":"Carrer d'Aragó","contact:phone":"+34 93 000 00 00"}`)
+
+	tags := parseTags(raw)
+	if tags["name"] != `Café "Sol"` {
+		t.Fatalf("parseTags should unescape quoted values, got %q", tags["name"])
+	}
+	if tags["addr:street"] != "Carrer d'Aragó" {
+		t.Fatalf("parseTags should keep keys with special characters")
+	}
+
+	mixed := parseTags([]byte(`{"name":"Cafe","levels":3}`))
+	if mixed["name"] != "Cafe" || mixed["levels"] != "3" {
+		t.Fatalf("parseTags should not drop tags on non-string values, got %v", mixed)
+	}
+
+	if len(parseTags([]byte("null"))) != 0 || len(parseTags([]byte("{broken"))) != 0 {
+		t.Fatalf("parseTags should return empty map for invalid input")
+	}
+}
+
+func TestParsePOIAddress(t *testing.T) {
+	addr := parsePOIAddress(map[string]string{
+		"addr:street":      "Carrer de Mallorca",
+		"addr:housenumber": "401",
+		"addr:postcode":    "08013",
+		"addr:city":        "Barcelona",
+	})
+	if addr == nil {
+		t.Fatal("expected address")
+	}
+	if addr.Display != "Carrer de Mallorca 401, 08013 Barcelona" {
+		t.Errorf("unexpected display %q", addr.Display)
+	}
+
+	addr = parsePOIAddress(map[string]string{"addr:housenumber": "12", "addr:city": "Girona"})
+	if addr == nil || addr.Display != "Girona" {
+		t.Errorf("house number without street should not be displayed, got %+v", addr)
+	}
+
+	if addr := parsePOIAddress(map[string]string{"amenity": "cafe"}); addr != nil {
+		t.Errorf("expected nil address without addr:* tags, got %+v", addr)
+	}
+}
+
+func TestBoundaryMinAreaSqKmByZoom(t *testing.T) {
+	z0 := boundaryMinAreaSqKmByZoom(0)
+	if z0 < 24000 || z0 > 25000 {
+		t.Fatalf("expected ~24500 km² at z0, got %f", z0)
+	}
+
+	for z := 1; z <= 18; z++ {
+		prev, cur := boundaryMinAreaSqKmByZoom(z-1), boundaryMinAreaSqKmByZoom(z)
+		if cur >= prev {
+			t.Fatalf("threshold should decrease with zoom: z%d=%f, z%d=%f", z-1, prev, z, cur)
+		}
+	}
+}
+
+func TestBoundaryPlanarMinArea(t *testing.T) {
+	// Центр тайла 0/0/0 на экваторе - масштаб 1
+	if got := boundaryPlanarMinArea(0, 0, 1); math.Abs(got-1e6) > 1 {
+		t.Fatalf("expected 1e6 m² at the equator, got %f", got)
+	}
+
+	// Тайл 14/8290/6119 - Барселона (~41.4°): площадь в Меркаторе больше в 1/cos² ≈ 1.78 раза
+	got := boundaryPlanarMinArea(14, 6119, 1)
+	want := 1e6 / math.Pow(math.Cos(41.39*math.Pi/180), 2)
+	if math.Abs(got-want)/want > 0.001 {
+		t.Fatalf("expected ~%f m² for Barcelona tile, got %f", want, got)
+	}
+}
+
+func TestParsePOICategoryRules(t *testing.T) {
+	rules, err := ParsePOICategoryRules(map[string][]string{
+		"coworking": {"office:coworking", "amenity:coworking_space"},
+		"charging":  {"amenity:charging_station", "disused:amenity:fuel"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Category != "charging" || rules[1].Category != "coworking" {
+		t.Fatalf("expected rules sorted by category, got %+v", rules)
+	}
+	if got := rules[0].Tags[1]; got.Key != "disused:amenity" || got.Value != "fuel" {
+		t.Fatalf("expected key with ':' to be kept, got %+v", got)
+	}
+
+	category, subcategory := buildPOICategoryExprs(rules)
+	for _, want := range []string{
+		`WHEN "office" = 'coworking' OR "amenity" = 'coworking_space' THEN 'coworking'`,
+		`OR tags->'disused:amenity' = 'fuel' THEN 'charging'`,
+	} {
+		if !strings.Contains(category, want) {
+			t.Errorf("category expr missing %q:\n%s", want, category)
+		}
+	}
+	if !strings.Contains(subcategory, `WHEN "amenity" = 'coworking_space' THEN 'coworking_space'`) {
+		t.Errorf("subcategory expr should return matched tag value:\n%s", subcategory)
+	}
+}
+
+func TestParsePOICategoryRulesRejectsUnsafeInput(t *testing.T) {
+	for name, raw := range map[string]map[string][]string{
+		"quote in value":   {"x": {"amenity:cafe' OR 1=1 --"}},
+		"quote in key":     {"x": {"amen'ity:cafe"}},
+		"bad category":     {"drop table;": {"amenity:cafe"}},
+		"missing value":    {"x": {"amenity"}},
+		"empty tag list":   {"x": {}},
+		"uppercase in key": {"x": {"Amenity:cafe"}},
+	} {
+		if _, err := ParsePOICategoryRules(raw); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestConfigurePOICategoriesRebuildsTileExprs(t *testing.T) {
+	defer func() {
+		if err := ConfigurePOICategories(nil); err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+	}()
+
+	if err := ConfigurePOICategories(map[string][]string{"coworking": {"office:coworking"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `WHEN "office" = 'coworking' THEN 'coworking'`
+	if !strings.Contains(tileCategoryExpr, want) || !strings.Contains(poiTileSelect, want) {
+		t.Errorf("tile category expr and select must include config rules:\n%s", poiTileSelect)
+	}
+	if !strings.Contains(tileCategoryExpr, "ELSE "+defaultTileCategoryExpr) {
+		t.Errorf("tile category expr must fall back to the built-in mapping:\n%s", tileCategoryExpr)
+	}
+
+	if err := ConfigurePOICategories(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tileCategoryExpr != defaultTileCategoryExpr || tileSubcategoryExpr != defaultTileSubcategoryExpr {
+		t.Fatal("expected built-in tile expressions without rules")
+	}
+}
+
+func TestParsePOICategoryRulesEmptyKeepsDefaults(t *testing.T) {
+	rules, err := ParsePOICategoryRules(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	category, subcategory := buildPOICategoryExprs(rules)
+	if category != defaultCategoryExpr || subcategory != defaultSubcategoryExpr {
+		t.Fatal("expected built-in expressions without rules")
+	}
+}
+
+func TestTileGeomExpr(t *testing.T) {
+	defer ConfigureTileZoomPolicy(domain.DefaultTileZoomPolicy())
+
+	ConfigureTileZoomPolicy(domain.TileZoomPolicy{Rules: []domain.TileZoomRule{
+		{MinZoom: 0, MaxZoom: 11, SimplifyPx: 2},
+		{MinZoom: 12, MaxZoom: domain.TileMaxZoom},
+	}})
+
+	if got := tileGeomExpr("way", 14); got != "way" {
+		t.Fatalf("expected no simplification at z14, got %q", got)
+	}
+	if got := tileGeomExpr("way", 0); !strings.HasPrefix(got, "ST_SimplifyPreserveTopology(way, 313086") {
+		t.Fatalf("expected 2px tolerance in meters at z0, got %q", got)
+	}
+}
+
+func TestTileAreaLayerGeom(t *testing.T) {
+	defer ConfigureTileZoomPolicy(domain.DefaultTileZoomPolicy())
+	defer func() { areaLayerSimplifications = defaultAreaLayerSimplifications() }()
+
+	ConfigureTileZoomPolicy(domain.TileZoomPolicy{Rules: []domain.TileZoomRule{
+		{MinZoom: 0, MaxZoom: 11, SimplifyPx: 2},
+		{MinZoom: 12, MaxZoom: domain.TileMaxZoom},
+	}})
+
+	geom, minArea := areaLayerTileGeom(domain.TileLayerWater, "way", 14)
+	if geom != "way" {
+		t.Fatalf("expected policy without simplification at z14, got %q", geom)
+	}
+	if pixel := tileMetersPerPixel(14); minArea != pixel*pixel {
+		t.Errorf("expected one square pixel at z14, got %g", minArea)
+	}
+
+	// Порог площади растет вчетверо с каждым уменьшением зума: мелкие полигоны не раздувают тайлы
+	_, lowZoomArea := areaLayerTileGeom(domain.TileLayerWater, "way", 13)
+	if lowZoomArea != 4*minArea {
+		t.Errorf("expected area threshold to quadruple one zoom out, got %g vs %g", lowZoomArea, minArea)
+	}
+
+	err := ConfigureAreaLayerSimplification(map[string]string{"green_spaces": "3"}, map[string]string{"water": "0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if geom, _ := areaLayerTileGeom(domain.TileLayerGreenSpaces, "way", 14); !strings.HasPrefix(geom, "ST_SimplifyPreserveTopology(way, 28.6") {
+		t.Errorf("expected 3px layer tolerance at z14, got %q", geom)
+	}
+	if geom, _ := areaLayerTileGeom(domain.TileLayerWater, "way", 0); !strings.HasPrefix(geom, "ST_SimplifyPreserveTopology(way, 313086") {
+		t.Errorf("expected policy tolerance for water without layer value, got %q", geom)
+	}
+	if _, minArea := areaLayerTileGeom(domain.TileLayerWater, "way", 10); minArea != 0 {
+		t.Errorf("expected no area threshold for water, got %g", minArea)
+	}
+
+	if err := ConfigureAreaLayerSimplification(map[string]string{"pois": "1"}, nil); err == nil {
+		t.Error("expected error for layer without area simplification")
+	}
+	if err := ConfigureAreaLayerSimplification(nil, map[string]string{"water": "-1"}); err == nil {
+		t.Error("expected error for negative threshold")
+	}
+}
+
+func TestActiveFeatureCondition(t *testing.T) {
+	cond := QueryOptions{}.activeFeatureCondition("p")
+	for _, want := range []string{"p.railway", "p.highway", "p.tags ?| ARRAY[", "'disused:railway'", "'proposed:route'", "'construction'"} {
+		if !strings.Contains(cond, want) {
+			t.Errorf("expected condition to contain %q, got %q", want, cond)
+		}
+	}
+	if strings.Contains(QueryOptions{}.activeFeatureCondition(""), ".railway") {
+		t.Error("expected unqualified columns without alias")
+	}
+
+	if got := (QueryOptions{IncludeInactiveFeatures: true}).activeFeatureCondition("p"); got != "TRUE" {
+		t.Fatalf("expected TRUE when inactive features are included, got %q", got)
+	}
+}
+
+func TestPOITagFilterCondition(t *testing.T) {
+	filter := domain.POITagFilter{
+		HasTags:     []string{"website"},
+		RequireTags: map[string]string{"wheelchair": "yes", "outdoor_seating": "yes"},
+	}
+
+	cond, args := poiTagFilterCondition(filter, []interface{}{2.17, 41.38})
+	if cond != "tags ?& $3::text[] AND tags @> hstore($4::text[], $5::text[])" {
+		t.Fatalf("unexpected condition %q", cond)
+	}
+	if len(args) != 5 {
+		t.Fatalf("expected 5 args, got %d", len(args))
+	}
+	if keys, ok := args[3].(*pq.StringArray); !ok || strings.Join(*keys, ",") != "outdoor_seating,wheelchair" {
+		t.Errorf("expected sorted keys, got %v", args[3])
+	}
+
+	cond, args = poiTagFilterCondition(domain.POITagFilter{HasTags: []string{"website"}}, nil)
+	if cond != "tags ?& $1::text[]" || len(args) != 1 {
+		t.Errorf("unexpected presence-only condition %q with %d args", cond, len(args))
+	}
+}
+
+func TestPOITileFeatureLimit(t *testing.T) {
+	defer ConfigurePOITileMaxFeatures(1000)
+
+	if got := poiTileFeatureLimit(16); got != 1000 {
+		t.Fatalf("expected zoom limit 1000 at z16, got %d", got)
+	}
+
+	ConfigurePOITileMaxFeatures(300)
+	if got := poiTileFeatureLimit(16); got != 300 {
+		t.Errorf("expected configured cap 300 at z16, got %d", got)
+	}
+	if got := poiTileFeatureLimit(11); got != 200 {
+		t.Errorf("expected zoom limit 200 below configured cap at z11, got %d", got)
+	}
+
+	ConfigurePOITileMaxFeatures(0)
+	if got := poiTileFeatureLimit(16); got != 300 {
+		t.Errorf("expected non-positive value to keep cap 300, got %d", got)
+	}
+}
+
+func TestPOITileCategoryLimit(t *testing.T) {
+	if got := poiTileCategoryLimit(1000, 3, 0); got != 334 {
+		t.Errorf("expected tile limit split across 3 categories, got %d", got)
+	}
+	if got := poiTileCategoryLimit(1000, 1, 0); got != 0 {
+		t.Errorf("expected no per-category cap for a single category, got %d", got)
+	}
+	if got := poiTileCategoryLimit(1000, 0, 0); got != 0 {
+		t.Errorf("expected no per-category cap without category filter, got %d", got)
+	}
+	if got := poiTileCategoryLimit(1000, 3, 20); got != 20 {
+		t.Errorf("expected requested cap 20, got %d", got)
+	}
+	if got := poiTileCategoryLimit(200, 0, 500); got != 200 {
+		t.Errorf("expected requested cap clamped to tile limit 200, got %d", got)
+	}
+}
+
+func TestPOITileDataSQL(t *testing.T) {
+	plain := poiTileDataSQL("SELECT * FROM pois", " AND category = ANY($6)", 0)
+	if strings.Contains(plain, "ROW_NUMBER") {
+		t.Errorf("expected no window without category cap, got %q", plain)
+	}
+
+	capped := poiTileDataSQL("SELECT * FROM pois", " AND category = ANY($6)", 25)
+	for _, part := range []string{"PARTITION BY category ORDER BY " + poiTileImportanceOrder, "category_rank <= 25", "category = ANY($6)"} {
+		if !strings.Contains(capped, part) {
+			t.Errorf("expected %q in capped data SQL %q", part, capped)
+		}
+	}
+}
+
+func TestPOITileZoomCategoryFilter(t *testing.T) {
+	defer ConfigurePOIZoomCategories(domain.DefaultPOIZoomCategories())
+
+	ConfigurePOIZoomCategories(domain.POIZoomCategories{
+		Steps:       []domain.POIZoomCategoryStep{{MinZoom: 5, Subcategories: []string{"hospital"}}},
+		AllFromZoom: 14,
+	})
+
+	clause, args, visible := poiZoomCategoryFilter(8, []interface{}{8, 1, 2})
+	if !visible || clause != " AND subcategory = ANY($4)" || len(args) != 4 {
+		t.Fatalf("unexpected filter %q with %d args (visible=%v)", clause, len(args), visible)
+	}
+
+	if _, _, visible := poiZoomCategoryFilter(3, nil); visible {
+		t.Error("expected no POIs below the first step")
+	}
+
+	clause, args, visible = poiZoomCategoryFilter(15, []interface{}{15})
+	if !visible || clause != "" || len(args) != 1 {
+		t.Errorf("expected no filter from AllFromZoom, got %q", clause)
+	}
+}
+
+func TestNameTranslationsExpr(t *testing.T) {
+	defer ConfigureNameLanguages(nil)
+
+	if err := ConfigureNameLanguages([]string{"sv", "fi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := nameTranslationsExpr("b")
+	want := "json_strip_nulls(json_build_object('sv', NULLIF(b.tags->'name:sv', ''), 'fi', NULLIF(b.tags->'name:fi', '')))"
+	if got != want {
+		t.Fatalf("unexpected expression %q", got)
+	}
+
+	if err := ConfigureNameLanguages([]string{"sv'"}); err == nil {
+		t.Error("expected error for unsafe language code")
+	}
+	if names := parseNames([]byte(`{}`)); names != nil {
+		t.Errorf("expected nil names for empty object, got %v", names)
+	}
+}
+
+func TestCountryCandidatesUnit(t *testing.T) {
+	candidates := countryCandidates([]int64{-9407, -1311341, -1311342}, []string{"Andorra", "España", "España"})
+	expected := []domain.CountryCandidate{{ID: -9407, Name: "Andorra"}, {ID: -1311341, Name: "España"}}
+	if len(candidates) != len(expected) {
+		t.Fatalf("Expected %d candidates, got %v", len(expected), candidates)
+	}
+	for i := range expected {
+		if candidates[i] != expected[i] {
+			t.Errorf("Candidate %d: expected %v, got %v", i, expected[i], candidates[i])
+		}
+	}
+
+	if got := countryCandidates(nil, nil); len(got) != 0 {
+		t.Errorf("Expected no candidates, got %v", got)
+	}
+
+	columns := countryCandidatesColumns("m")
+	if !strings.Contains(columns, "FILTER (WHERE (m.admin_level)::integer = 2 AND m.name IS NOT NULL)") {
+		t.Errorf("Expected aliased filter, got %s", columns)
+	}
+}
+
+func TestAddressLevelColumnsUnit(t *testing.T) {
+	columns := addressLevelColumns("m")
+	// Порядок выбора совпадает с domain.PickBoundaryPerLevel: меньшая площадь, затем меньший osm_id
+	want := "(ARRAY_AGG(m.name ORDER BY m.level_area ASC NULLS LAST, m.osm_id ASC) FILTER (WHERE m.admin_level = 8))[1] AS city"
+	if !strings.Contains(columns, want) {
+		t.Errorf("Expected %q in %s", want, columns)
+	}
+	if strings.Contains(columns, "MAX(") {
+		t.Errorf("Expected no MAX(name) per level, got %s", columns)
+	}
+
+	area := levelAreaExpr("(admin_level)::integer", "way")
+	if !strings.HasPrefix(area, "CASE WHEN COUNT(*) OVER (PARTITION BY (admin_level)::integer) > 1") || !strings.HasSuffix(area, "AS level_area") {
+		t.Errorf("Unexpected level area expression: %s", area)
+	}
+}
+
+func TestEditMetaQueryUnit(t *testing.T) {
+	source := editMetaSources[domain.EditFeaturePOI]
+
+	withColumns := editMetaQuery(source, map[string]bool{editMetaVersionColumn: true, editMetaTimestampColumn: true})
+	for _, want := range []string{
+		"(osm_version)::bigint AS osm_version",
+		"(osm_timestamp)::timestamptz AS osm_timestamp",
+		"NULL::text AS osm_user",
+		"FROM " + planetPointTable,
+	} {
+		if !strings.Contains(withColumns, want) {
+			t.Errorf("Expected %q in query:\n%s", want, withColumns)
+		}
+	}
+
+	// Без колонок в схеме запрос все равно проверяет существование объекта
+	withoutColumns := editMetaQuery(editMetaSources[domain.EditFeatureBoundary], nil)
+	if strings.Contains(withoutColumns, "(osm_") {
+		t.Errorf("Expected no metadata columns in query:\n%s", withoutColumns)
+	}
+	if !strings.Contains(withoutColumns, "FROM "+planetPolygonTable) || !strings.Contains(withoutColumns, "osm_id = $1") {
+		t.Errorf("Expected lookup by osm_id in %s:\n%s", planetPolygonTable, withoutColumns)
+	}
+}
+
+func TestTimezoneTableQueryUnit(t *testing.T) {
+	query := timezoneTableQuery("wkb_geometry", 4326)
+	for _, part := range []string{"SELECT tzid", "FROM " + timezoneTable, `ST_Intersects("wkb_geometry"`, "ST_MakePoint($2, $1), 4326), 4326)"} {
+		if !strings.Contains(query, part) {
+			t.Errorf("expected %q in timezone query %q", part, query)
+		}
+	}
+}
+
+func TestNearestPerCategoryBatchQueryUnit(t *testing.T) {
+	query := nearestPerCategoryBatchQuery(QueryOptions{})
+	for _, want := range []string{
+		"FROM unnest($1::float8[], $2::float8[]) WITH ORDINALITY AS t(lon, lat, ord)",
+		"(ord - 1)::int AS point_idx",
+		"CROSS JOIN unnest($4::text[]) AS c(category)",
+		"DISTINCT ON (ip.point_idx, c.category)",
+		"ORDER BY ip.point_idx, c.category, distance",
+		"ST_DWithin(ST_Transform(d.way, 4326)::geography, ip.geog, $3)",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("Expected %q in query:\n%s", want, query)
+		}
+	}
+}
+
+func TestActivityCenterQueryUnit(t *testing.T) {
+	plain := activityCenterQuery(QueryOptions{}, false, false)
+	if strings.Contains(plain, "$5") {
+		t.Errorf("Expected no category argument without categories:\n%s", plain)
+	}
+	if !strings.Contains(plain, "SELECT geom, (1)::float8 AS w") {
+		t.Errorf("Expected unit weight without weighting:\n%s", plain)
+	}
+
+	weighted := activityCenterQuery(QueryOptions{}, true, true)
+	for _, want := range []string{
+		"AND category = ANY($5)",
+		"ST_MakeEnvelope($1, $2, $3, $4, 4326)",
+		"(1 + " + poiTileRankExpr + ")::float8 AS w",
+		"SUM(ST_Y(geom) * w) / NULLIF(SUM(w), 0)",
+	} {
+		if !strings.Contains(weighted, want) {
+			t.Errorf("Expected %q in query:\n%s", want, weighted)
+		}
+	}
+}
+
+func TestCategoryPriorityOrderUnit(t *testing.T) {
+	if got, want := categoryPriorityOrder(5), "COALESCE(array_position($5::text[], category), 2147483647)"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestPOIImportanceExprUnit(t *testing.T) {
+	expr := poiImportanceExpr("subcategory")
+	for _, want := range []string{
+		"CASE WHEN tags ? 'wikidata' THEN 4 ELSE 0 END",
+		"CASE WHEN tags ? 'wikipedia' THEN 2 ELSE 0 END",
+		"CASE WHEN COALESCE(name, '') <> '' THEN 1 ELSE 0 END",
+		"WHEN (subcategory) IN ('attraction','museum','castle') THEN 3",
+	} {
+		if !strings.Contains(expr, want) {
+			t.Errorf("Expected %q in expression:\n%s", want, expr)
+		}
+	}
+
+	if tile := poiImportanceExpr(tileSubcategoryExpr); !strings.Contains(tile, "WHEN ("+tileSubcategoryExpr+") IN") {
+		t.Errorf("Expected subcategory expression to be embedded:\n%s", tile)
+	}
+}
+
+func TestBuildPointsCTEUnit(t *testing.T) {
+	r := &transportRepository{}
+	malicious := "metro' AS transport_type, 1 AS limit_per_point; DROP TABLE planet_osm_point; --"
+	query, args := r.buildPointsCTE([]domain.TransportSearchPoint{
+		{Lat: 41.38, Lon: 2.17, Types: []string{malicious}, Limit: 2},
+		{Lat: 40.41, Lon: -3.70},
+	}, 2)
+
+	if strings.Contains(query, "DROP TABLE") || strings.Contains(query, "'") {
+		t.Errorf("Expected transport type to be passed as a parameter, got query:\n%s", query)
+	}
+	want := "SELECT * FROM (VALUES ($2::int, $3::float8, $4::float8, $5::text, $6::int), " +
+		"($7::int, $8::float8, $9::float8, $10::text, $11::int)) AS v(point_idx, lon, lat, transport_type, limit_per_point)"
+	if query != want {
+		t.Errorf("Expected query %q, got %q", want, query)
+	}
+
+	if len(args) != 10 {
+		t.Fatalf("Expected 10 args, got %d", len(args))
+	}
+	if args[3] != malicious {
+		t.Errorf("Expected transport type arg %q, got %v", malicious, args[3])
+	}
+	if args[9] != 3 {
+		t.Errorf("Expected default limit 3 for second point, got %v", args[9])
+	}
+}
+
+func TestPriorityTransportRankCTEUnit(t *testing.T) {
+	strict := priorityTransportRankCTE(false)
+	if strings.Contains(strict, "$5") || strings.Contains(strict, "reserved") {
+		t.Errorf("Expected strict priority without min per mode:\n%s", strict)
+	}
+	if !strings.Contains(strict, "ORDER BY priority_rank ASC, distance ASC") {
+		t.Errorf("Expected priority then distance order:\n%s", strict)
+	}
+
+	mixed := priorityTransportRankCTE(true)
+	for _, want := range []string{
+		"unnest($5::text[], $6::int[]) AS m(mode, min_count)",
+		"PARTITION BY s.transport_type ORDER BY s.distance) <= COALESCE(m.min_count, 0) AS reserved",
+		"ORDER BY reserved DESC, CASE WHEN reserved THEN priority_rank ELSE 0 END ASC, distance ASC",
+	} {
+		if !strings.Contains(mixed, want) {
+			t.Errorf("Expected %q in CTE:\n%s", want, mixed)
+		}
+	}
+}
+
+func TestApplyBeachTagsUnit(t *testing.T) {
+	var unknown domain.Beach
+	applyBeachTags(&unknown, "", "", "")
+	if unknown.Access != nil || unknown.Seasonal != nil || unknown.Season != nil || unknown.BlueFlag != nil {
+		t.Errorf("Expected unknown fields to stay nil, got %+v", unknown)
+	}
+
+	var hotel domain.Beach
+	applyBeachTags(&hotel, "customers", "summer", "yes")
+	if hotel.Access == nil || *hotel.Access != "customers" {
+		t.Errorf("Expected access customers, got %v", hotel.Access)
+	}
+	if hotel.Seasonal == nil || !*hotel.Seasonal || hotel.Season == nil || *hotel.Season != "summer" {
+		t.Errorf("Expected seasonal beach with season summer, got %v %v", hotel.Seasonal, hotel.Season)
+	}
+	if hotel.BlueFlag == nil || !*hotel.BlueFlag {
+		t.Errorf("Expected blue flag, got %v", hotel.BlueFlag)
+	}
+
+	var yearRound domain.Beach
+	applyBeachTags(&yearRound, "", "no", "")
+	if yearRound.Seasonal == nil || *yearRound.Seasonal || yearRound.Season != nil {
+		t.Errorf("Expected year-round beach, got %v %v", yearRound.Seasonal, yearRound.Season)
+	}
+}
+
+func TestApplyWaterTagsUnit(t *testing.T) {
+	var unknown domain.WaterBody
+	applyWaterTags(&unknown, "", "", "", "")
+	if unknown.Navigable != nil || unknown.Tidal != nil {
+		t.Errorf("Expected unknown fields to stay nil, got %v %v", unknown.Navigable, unknown.Tidal)
+	}
+
+	var river domain.WaterBody
+	applyWaterTags(&river, "no", "", "yes", "yes")
+	if river.Navigable == nil || !*river.Navigable {
+		t.Errorf("Expected navigable by canoe, got %v", river.Navigable)
+	}
+	if river.Tidal == nil || !*river.Tidal {
+		t.Errorf("Expected tidal, got %v", river.Tidal)
+	}
+
+	var reservoir domain.WaterBody
+	applyWaterTags(&reservoir, "", "private", "no", "no")
+	if reservoir.Navigable == nil || *reservoir.Navigable {
+		t.Errorf("Expected not navigable, got %v", reservoir.Navigable)
+	}
+	if reservoir.Tidal == nil || *reservoir.Tidal {
+		t.Errorf("Expected not tidal, got %v", reservoir.Tidal)
+	}
+}
+
+func TestBeachAccessConditionUnit(t *testing.T) {
+	if got := (QueryOptions{}).beachAccessCondition(); !strings.Contains(got, "NOT IN ('private', 'no')") {
+		t.Errorf("Expected restricted beaches excluded by default, got %q", got)
+	}
+
+	if got := (QueryOptions{IncludeRestrictedBeaches: true}).beachAccessCondition(); got != "TRUE" {
+		t.Errorf("Expected no access filter, got %q", got)
+	}
+}
+
+func TestBoundaryContainsGeomUnit(t *testing.T) {
+	if got := (QueryOptions{}).boundaryContainsGeom(""); got != "way" {
+		t.Errorf("Expected exact geometry by default, got %q", got)
+	}
+
+	opts, err := QueryOptions{SimplifiedContains: true}.Validate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := opts.boundaryContainsGeom("b")
+	if !strings.Contains(got, "(b.admin_level)::integer <= 4") || !strings.Contains(got, "COALESCE(b.way_simplified, b.way)") ||
+		!strings.Contains(got, "ELSE b.way END") {
+		t.Errorf("Expected simplified geometry up to level 4, got %q", got)
+	}
+
+	for _, level := range []int{1, 7} {
+		if _, err := (QueryOptions{SimplifiedContains: true, SimplifiedMaxLevel: level}).Validate(); err == nil {
+			t.Errorf("Expected error for max level %d", level)
+		}
+	}
+}
+
+func TestBoundarySearchLimitUnit(t *testing.T) {
+	cases := map[int]int{
+		0:                   LimitBoundaries,
+		10:                  10,
+		LimitBoundaries + 1: LimitBoundaries + 1, // limit+1 при максимальном limit не обрезается
+		LimitBoundaries * 5: LimitBoundaries + 1,
+	}
+	for limit, want := range cases {
+		if got := boundarySearchLimit(limit); got != want {
+			t.Errorf("boundarySearchLimit(%d) = %d, want %d", limit, got, want)
+		}
+	}
+}
+
+func TestSnappedDistancesQueryUnit(t *testing.T) {
+	query := snappedDistancesQuery()
+	for _, want := range []string{
+		"CASE WHEN s.line_id = o.line_id AND GeometryType(o.line) = 'LINESTRING' THEN",
+		"ST_LineSubstring(o.line,",
+		"ST_Buffer(ST_Collect(w.way), $6 / cos(radians($2)))",
+		">= $7 * ST_Length(g.segment) AS covered",
+		"WHERE origin_offset <= $5 AND station_offset <= $5",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("Expected %q in query:\n%s", want, query)
+		}
+	}
+}
+
+// maxPlaceholder возвращает наибольший номер параметра $N в запросе
+func maxPlaceholder(query string) int {
+	max := 0
+	for _, m := range regexp.MustCompile(`\$(\d+)`).FindAllStringSubmatch(query, -1) {
+		if n, _ := strconv.Atoi(m[1]); n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+func TestExplainTemplatesUnit(t *testing.T) {
+	for name, tmpl := range explainTemplates {
+		query := tmpl.query(QueryOptions{})
+		if got, want := maxPlaceholder(query), len(tmpl.args(41.387, 2.17)); got != want {
+			t.Errorf("%s: query uses %d parameters, args provide %d", name, got, want)
+		}
+	}
+
+	if explainTemplates["stations_in_radius"].query(QueryOptions{}) != stationsInRadiusQuery(QueryOptions{}) {
+		t.Error("Expected stations_in_radius to explain the GetStationsInRadius query")
+	}
+}
+
+func TestGreenSpacesNearbyQueryUnit(t *testing.T) {
+	tests := []struct {
+		orderBy     domain.EnvironmentOrderBy
+		withMinArea bool
+		params      int
+	}{
+		{domain.EnvironmentOrderByDistance, false, 4},
+		{domain.EnvironmentOrderByDistance, true, 5},
+		{domain.EnvironmentOrderByScore, false, 6},
+		{domain.EnvironmentOrderByScore, true, 7},
+	}
+
+	for _, tt := range tests {
+		query := greenSpacesNearbyQuery(QueryOptions{}, domain.EnvironmentOrderOptions{OrderBy: tt.orderBy}.Normalize(), tt.withMinArea)
+		if got := maxPlaceholder(query); got != tt.params {
+			t.Errorf("order_by=%s, withMinArea=%v: expected %d parameters, got %d", tt.orderBy, tt.withMinArea, tt.params, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package postgresosm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lifecyclePrefixes - префиксы жизненного цикла OSM (disused:amenity=..., abandoned:railway=...),
+// которыми помечают закрытые, снесенные или еще не построенные объекты
+var lifecyclePrefixes = []string{
+	"disused", "abandoned", "demolished", "removed", "razed", "destroyed", "was", "construction", "proposed",
+}
+
+// lifecycleKeys - основные ключи, которые встречаются с префиксом жизненного цикла
+// (disused:railway, abandoned:amenity, proposed:route, ...)
+var lifecycleKeys = []string{
+	"amenity", "shop", "railway", "highway", "public_transport", "route", "leisure", "tourism",
+	"office", "craft", "healthcare", "building", "landuse", "waterway", "aeroway", "natural",
+}
+
+// lifecycleTagsArray - SQL литерал ARRAY['disused:amenity', ...]: все сочетания
+// lifecyclePrefixes × lifecycleKeys для проверки оператором hstore ?|
+var lifecycleTagsArray = func() string {
+	keys := make([]string, 0, len(lifecyclePrefixes)*len(lifecycleKeys))
+	for _, prefix := range lifecyclePrefixes {
+		for _, key := range lifecycleKeys {
+			keys = append(keys, "'"+prefix+":"+key+"'")
+		}
+	}
+	return "ARRAY[" + strings.Join(keys, ", ") + "]"
+}()
+
+// activeFeatureCondition возвращает SQL условие, отсекающее неактивные объекты:
+// railway/highway=construction|proposed|disused|..., disused=yes, abandoned=yes
+// и теги с префиксом жизненного цикла у основных ключей (lifecycleKeys). Проверка одним
+// оператором ?| вместо перебора skeys: условие стоит в горячих запросах на каждой строке. alias - алиас таблицы planet_osm_* ("" - без алиаса).
+// Если неактивные объекты включены (IncludeInactiveFeatures), возвращает TRUE.
+func (o QueryOptions) activeFeatureCondition(alias string) string {
+	if o.IncludeInactiveFeatures {
+		return "TRUE"
+	}
+
+	col := func(name string) string {
+		if alias == "" {
+			return name
+		}
+		return alias + "." + name
+	}
+
+	return fmt.Sprintf(`NOT (
+		COALESCE(%[1]s, '') IN ('construction', 'proposed', 'disused', 'abandoned', 'razed')
+		OR COALESCE(%[2]s, '') IN ('construction', 'proposed')
+		OR COALESCE(%[3]s->'disused', '') = 'yes'
+		OR COALESCE(%[3]s->'abandoned', '') = 'yes'
+		OR COALESCE(%[3]s ?| %[4]s, false)
+	)`, col("railway"), col("highway"), col("tags"), lifecycleTagsArray)
+}
@@ -0,0 +1,136 @@
+package postgresosm
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mvtLayerNames разбирает тайл как protobuf сообщение vector_tile.Tile и возвращает имена слоев.
+// Пустой тайл - валидный Tile без слоев. Любое другое поле верхнего уровня, кроме layers (3), - ошибка.
+func mvtLayerNames(tile []byte) ([]string, error) {
+	var names []string
+	for len(tile) > 0 {
+		key, n := binary.Uvarint(tile)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid field key")
+		}
+		tile = tile[n:]
+		if key != 3<<3|2 {
+			return nil, fmt.Errorf("unexpected field %d wire type %d", key>>3, key&7)
+		}
+
+		size, n := binary.Uvarint(tile)
+		if n <= 0 || uint64(len(tile)-n) < size {
+			return nil, fmt.Errorf("invalid layer length")
+		}
+		layer := tile[n : n+int(size)]
+		tile = tile[n+int(size):]
+
+		// Имя слоя - поле 1 (string), по спецификации MVT обязательно
+		name, err := mvtLayerName(layer)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func mvtLayerName(layer []byte) (string, error) {
+	for len(layer) > 0 {
+		key, n := binary.Uvarint(layer)
+		if n <= 0 {
+			return "", fmt.Errorf("invalid layer field key")
+		}
+		layer = layer[n:]
+
+		switch key & 7 {
+		case 0: // varint
+			_, n = binary.Uvarint(layer)
+			if n <= 0 {
+				return "", fmt.Errorf("invalid varint")
+			}
+			layer = layer[n:]
+		case 2: // length-delimited
+			size, n := binary.Uvarint(layer)
+			if n <= 0 || uint64(len(layer)-n) < size {
+				return "", fmt.Errorf("invalid field length")
+			}
+			if key>>3 == 1 {
+				return string(layer[n : n+int(size)]), nil
+			}
+			layer = layer[n+int(size):]
+		default:
+			return "", fmt.Errorf("unexpected wire type %d", key&7)
+		}
+	}
+	return "", fmt.Errorf("layer without name")
+}
+
+func TestMVTLayerNames(t *testing.T) {
+	names, err := mvtLayerNames([]byte{})
+	if err != nil || len(names) != 0 {
+		t.Fatalf("empty tile must be a valid MVT without layers, got %v, %v", names, err)
+	}
+
+	// Tile{layers: [Layer{version: 2, name: "pois"}]}
+	layer := []byte{0x78, 0x02, 0x0a, 0x04, 'p', 'o', 'i', 's'}
+	tile := append([]byte{0x1a, byte(len(layer))}, layer...)
+	names, err = mvtLayerNames(tile)
+	if err != nil || len(names) != 1 || names[0] != "pois" {
+		t.Fatalf("expected [pois], got %v, %v", names, err)
+	}
+
+	// Литерал '\\x'::bytea давал два байта `\x` вместо пустого тайла - это не MVT
+	if _, err := mvtLayerNames([]byte(`\x`)); err == nil {
+		t.Error("expected backslash-x bytes to be rejected as MVT")
+	}
+}
+
+// TestMVTEmptyTileSQLIsShared проверяет, что тайловые запросы берут пустой тайл из emptyTileSQL,
+// а не из собственных литералов bytea
+func TestMVTEmptyTileSQLIsShared(t *testing.T) {
+	if strings.Contains(emptyTileSQL, `\`) {
+		t.Fatalf("empty tile literal must not contain backslashes, got %q", emptyTileSQL)
+	}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") || file == "constants.go" {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, line := range strings.Split(string(src), "\n") {
+			if strings.Contains(line, "ST_AsMVT(") && strings.Contains(line, "::bytea") {
+				t.Errorf("%s:%d: use emptyTileSQL instead of a bytea literal: %s", file, i+1, strings.TrimSpace(line))
+			}
+		}
+	}
+}
+
+func TestEmptyTileSQLDecodesAsMVT(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	var tile []byte
+	if err := db.QueryRowContext(context.Background(), "SELECT "+emptyTileSQL).Scan(&tile); err != nil {
+		t.Fatalf("Failed to select empty tile: %v", err)
+	}
+	if len(tile) != 0 {
+		t.Fatalf("Expected zero-length bytea, got %d bytes %q", len(tile), tile)
+	}
+	if names, err := mvtLayerNames(tile); err != nil || len(names) != 0 {
+		t.Errorf("Expected valid MVT without layers, got %v, %v", names, err)
+	}
+}
@@ -0,0 +1,48 @@
+package postgresosm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/location-microservice/internal/domain"
+)
+
+// nameLanguages - языки name:<lang>, выбираемые в многоязычные поля границ и туристических зон.
+// Задается при старте через ConfigureNameLanguages.
+var nameLanguages = domain.DefaultNameLanguages
+
+// ConfigureNameLanguages задает языки переводов (пусто - domain.DefaultNameLanguages).
+// Вызывается один раз при старте, до создания репозиториев.
+func ConfigureNameLanguages(langs []string) error {
+	parsed, err := domain.ParseNameLanguages(langs)
+	if err != nil {
+		return err
+	}
+
+	nameLanguages = parsed
+	return nil
+}
+
+// nameTranslationsExpr - JSON объект {"en": "...", "sv": "..."} с непустыми name:<lang> настроенных языков.
+// alias - алиас таблицы planet_osm_* ("" - без алиаса). Коды языков проверены в ConfigureNameLanguages.
+func nameTranslationsExpr(alias string) string {
+	tags := "tags"
+	if alias != "" {
+		tags = alias + ".tags"
+	}
+
+	pairs := make([]string, 0, len(nameLanguages))
+	for _, lang := range nameLanguages {
+		pairs = append(pairs, fmt.Sprintf("'%s', NULLIF(%s->'name:%s', '')", lang, tags, lang))
+	}
+	return fmt.Sprintf("json_strip_nulls(json_build_object(%s))", strings.Join(pairs, ", "))
+}
+
+// parseNames разбирает результат nameTranslationsExpr
+func parseNames(raw []byte) map[string]string {
+	names := parseTags(raw)
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
@@ -0,0 +1,139 @@
+package postgresosm
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Выражения категории и подкатегории POI для ответов API и для тайлов. По умолчанию - встроенный
+// маппинг, ConfigurePOICategories добавляет перед ним категории из конфига.
+var (
+	categoryExpr        = defaultCategoryExpr
+	subcategoryExpr     = defaultSubcategoryExpr
+	tileCategoryExpr    = defaultTileCategoryExpr
+	tileSubcategoryExpr = defaultTileSubcategoryExpr
+)
+
+var (
+	poiCategoryNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	osmTagKeyRe       = regexp.MustCompile(`^[a-z][a-z0-9_:]*$`)
+	osmTagValueRe     = regexp.MustCompile(`^[a-z0-9_\-.]+$`)
+)
+
+// planetPointColumns - теги, которые osm2pgsql хранит в отдельных колонках planet_osm_point.
+// Остальные ключи читаются из hstore tags.
+var planetPointColumns = map[string]bool{
+	"amenity": true, "shop": true, "tourism": true, "leisure": true, "historic": true,
+	"office": true, "man_made": true, "natural": true, "highway": true, "public_transport": true,
+	"railway": true, "aeroway": true, "military": true, "place": true,
+}
+
+// OSMTag - пара key=value OSM тега
+type OSMTag struct {
+	Key   string
+	Value string
+}
+
+// POICategoryRule - логическая категория POI, объединяющая несколько OSM тегов
+// (например coworking = office:coworking + amenity:coworking_space)
+type POICategoryRule struct {
+	Category string
+	Tags     []OSMTag
+}
+
+// ParsePOICategoryRules разбирает правила вида category -> ["key:value", ...].
+// Имена категорий, ключи и значения проверяются по белому списку символов, так как
+// попадают в SQL как литералы. Правила сортируются по имени категории.
+func ParsePOICategoryRules(raw map[string][]string) ([]POICategoryRule, error) {
+	rules := make([]POICategoryRule, 0, len(raw))
+	for category, rawTags := range raw {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if !poiCategoryNameRe.MatchString(category) {
+			return nil, fmt.Errorf("poi category %q: invalid name", category)
+		}
+
+		tags := make([]OSMTag, 0, len(rawTags))
+		for _, rawTag := range rawTags {
+			// Ключ может содержать ':' (например disused:amenity), значение - после последнего ':'
+			idx := strings.LastIndex(rawTag, ":")
+			if idx < 0 {
+				return nil, fmt.Errorf("poi category %q: invalid tag %q, expected key:value", category, rawTag)
+			}
+			key, value := strings.TrimSpace(rawTag[:idx]), strings.TrimSpace(rawTag[idx+1:])
+			if !osmTagKeyRe.MatchString(key) || !osmTagValueRe.MatchString(value) {
+				return nil, fmt.Errorf("poi category %q: invalid tag %q, expected key:value", category, rawTag)
+			}
+			tags = append(tags, OSMTag{Key: key, Value: value})
+		}
+		if len(tags) == 0 {
+			return nil, fmt.Errorf("poi category %q has no tags", category)
+		}
+
+		rules = append(rules, POICategoryRule{Category: category, Tags: tags})
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Category < rules[j].Category })
+	return rules, nil
+}
+
+// osmTagColumnExpr возвращает SQL выражение для значения тега: колонку или tags->'key'
+func osmTagColumnExpr(key string) string {
+	if planetPointColumns[key] {
+		return fmt.Sprintf("%q", key)
+	}
+	return fmt.Sprintf("tags->'%s'", key)
+}
+
+// buildPOICategoryExprs компилирует правила в CASE выражения категории и подкатегории.
+// Правила проверяются раньше встроенного маппинга; подкатегория - значение совпавшего тега.
+func buildPOICategoryExprs(rules []POICategoryRule) (category, subcategory string) {
+	return prependPOICategoryRules(rules, defaultCategoryExpr, defaultSubcategoryExpr)
+}
+
+// buildTilePOICategoryExprs - то же для маппинга тайлов: POI категорий из конфига попадают
+// в тайлы со своей категорией, а не отсекаются как 'other'
+func buildTilePOICategoryExprs(rules []POICategoryRule) (category, subcategory string) {
+	return prependPOICategoryRules(rules, defaultTileCategoryExpr, defaultTileSubcategoryExpr)
+}
+
+// prependPOICategoryRules собирает CASE по правилам с выражениями fallbackCategory и
+// fallbackSubcategory в ELSE; без правил возвращает их как есть
+func prependPOICategoryRules(rules []POICategoryRule, fallbackCategory, fallbackSubcategory string) (category, subcategory string) {
+	if len(rules) == 0 {
+		return fallbackCategory, fallbackSubcategory
+	}
+
+	var cat, subcat strings.Builder
+	cat.WriteString("CASE")
+	subcat.WriteString("CASE")
+	for _, rule := range rules {
+		conditions := make([]string, 0, len(rule.Tags))
+		for _, tag := range rule.Tags {
+			cond := fmt.Sprintf("%s = '%s'", osmTagColumnExpr(tag.Key), tag.Value)
+			conditions = append(conditions, cond)
+			fmt.Fprintf(&subcat, "\n\t\tWHEN %s THEN '%s'", cond, tag.Value)
+		}
+		fmt.Fprintf(&cat, "\n\t\tWHEN %s THEN '%s'", strings.Join(conditions, " OR "), rule.Category)
+	}
+	fmt.Fprintf(&cat, "\n\t\tELSE %s\n\tEND", fallbackCategory)
+	fmt.Fprintf(&subcat, "\n\t\tELSE %s\n\tEND", fallbackSubcategory)
+
+	return cat.String(), subcat.String()
+}
+
+// ConfigurePOICategories подключает категории POI из конфига (category -> ["key:value", ...]).
+// Вызывается один раз при старте, до создания репозиториев.
+func ConfigurePOICategories(raw map[string][]string) error {
+	rules, err := ParsePOICategoryRules(raw)
+	if err != nil {
+		return err
+	}
+
+	categoryExpr, subcategoryExpr = buildPOICategoryExprs(rules)
+	poiSelectFull, poiSelectLite, poiSelectLiteWithAddress = buildPOISelects(categoryExpr, subcategoryExpr)
+	tileCategoryExpr, tileSubcategoryExpr = buildTilePOICategoryExprs(rules)
+	poiTileSelect = buildPOITileSelect(tileCategoryExpr, tileSubcategoryExpr)
+	return nil
+}
@@ -3,7 +3,9 @@ package postgresosm
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
@@ -15,7 +17,65 @@ import (
 )
 
 var (
-	poiSelectFull = fmt.Sprintf(`
+	// poiSelectFull, poiSelectLite, poiSelectLiteWithAddress зависят от маппинга категорий
+	// и пересобираются в ConfigurePOICategories
+	poiSelectFull, poiSelectLite, poiSelectLiteWithAddress = buildPOISelects(categoryExpr, subcategoryExpr)
+
+	// poiTileSelect - для тайлов с маппингом категорий, пересобирается в ConfigurePOICategories
+	poiTileSelect = buildPOITileSelect(tileCategoryExpr, tileSubcategoryExpr)
+)
+
+// buildPOITileSelect собирает выборку точек для тайлов: POI с категорией, отличной от 'other'
+func buildPOITileSelect(category, subcategory string) string {
+	return fmt.Sprintf(`
+		SELECT
+			osm_id,
+			COALESCE(name, '') AS name,
+			%s AS category,
+			%s AS subcategory,
+			tags,
+			way
+		FROM %s
+		WHERE (%s) != 'other'
+	`, category, subcategory, planetPointTable, category)
+}
+
+// poiTagFilterCondition собирает условие фильтра по тегам для таблицы точек (колонка tags в области видимости).
+// Ключи и значения передаются параметрами, плейсхолдеры продолжают нумерацию args.
+// Наличие ключей - tags ?& keys, точные значения - tags @> hstore(keys, values).
+func poiTagFilterCondition(filter domain.POITagFilter, args []interface{}) (string, []interface{}) {
+	var conditions []string
+
+	if len(filter.HasTags) > 0 {
+		args = append(args, pq.Array(filter.HasTags))
+		conditions = append(conditions, fmt.Sprintf("tags ?& $%d::text[]", len(args)))
+	}
+
+	if len(filter.RequireTags) > 0 {
+		keys := make([]string, 0, len(filter.RequireTags))
+		for key := range filter.RequireTags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]string, len(keys))
+		for i, key := range keys {
+			values[i] = filter.RequireTags[key]
+		}
+		args = append(args, pq.Array(keys), pq.Array(values))
+		conditions = append(conditions, fmt.Sprintf("tags @> hstore($%d::text[], $%d::text[])", len(args)-1, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// activePOISelect дополняет базовый SELECT фильтром неактивных объектов (см. activeFeatureCondition)
+func (o QueryOptions) activePOISelect(src string) string {
+	return src + " WHERE " + o.activeFeatureCondition("")
+}
+
+// buildPOISelects собирает базовые SELECT для POI с заданными выражениями категории и подкатегории
+func buildPOISelects(category, subcategory string) (full, lite, liteWithAddress string) {
+	full = fmt.Sprintf(`
 		SELECT
 			osm_id,
 			COALESCE(name, '') AS name,
@@ -26,9 +86,9 @@ var (
 			ST_AsBinary(ST_Transform(way, %d)) AS geometry,
 			COALESCE(hstore_to_json(tags), '{}'::json)::text AS tags_json
 		FROM %s
-	`, categoryExpr, subcategoryExpr, SRID4326, SRID4326, SRID4326, planetPointTable)
+	`, category, subcategory, SRID4326, SRID4326, SRID4326, planetPointTable)
 
-	poiSelectLite = fmt.Sprintf(`
+	lite = fmt.Sprintf(`
 		SELECT
 			osm_id,
 			COALESCE(name, '') AS name,
@@ -36,26 +96,37 @@ var (
 			%s AS subcategory,
 			ST_Y(ST_Transform(way, %d)) AS lat,
 			ST_X(ST_Transform(way, %d)) AS lon,
+			tags,
 			way
 		FROM %s
-	`, categoryExpr, subcategoryExpr, SRID4326, SRID4326, planetPointTable)
+	`, category, subcategory, SRID4326, SRID4326, planetPointTable)
 
-	// poiTileSelect - для тайлов с маппингом категорий
-	poiTileSelect = fmt.Sprintf(`
+	// liteWithAddress - lite с компонентами адреса addr:*
+	liteWithAddress = fmt.Sprintf(`
 		SELECT
 			osm_id,
 			COALESCE(name, '') AS name,
 			%s AS category,
 			%s AS subcategory,
+			ST_Y(ST_Transform(way, %d)) AS lat,
+			ST_X(ST_Transform(way, %d)) AS lon,
+			COALESCE(tags->'addr:street', '') AS addr_street,
+			COALESCE(tags->'addr:housenumber', '') AS addr_housenumber,
+			COALESCE(tags->'addr:postcode', '') AS addr_postcode,
+			COALESCE(tags->'addr:city', '') AS addr_city,
+			tags,
 			way
 		FROM %s
-		WHERE (%s) != 'other'
-	`, tileCategoryExpr, tileSubcategoryExpr, planetPointTable, tileCategoryExpr)
-)
+	`, category, subcategory, SRID4326, SRID4326, planetPointTable)
+
+	return full, lite, liteWithAddress
+}
 
 type poiRepository struct {
-	db     *sqlx.DB
-	logger *zap.Logger
+	db        *sqlx.DB
+	readDB    *sqlx.DB // тайлы и аналитика: реплика, если настроена
+	logger    *zap.Logger
+	queryOpts QueryOptions
 }
 
 type poiRow struct {
@@ -83,6 +154,22 @@ type poiDistanceRow struct {
 	Distance float64 `db:"distance"`
 }
 
+// poiAddressDistanceRow - строка GetNearby с компонентами адреса addr:* и оценкой значимости
+type poiAddressDistanceRow struct {
+	poiDistanceRow
+	AddrStreet      string `db:"addr_street"`
+	AddrHouseNumber string `db:"addr_housenumber"`
+	AddrPostcode    string `db:"addr_postcode"`
+	AddrCity        string `db:"addr_city"`
+	Importance      *int   `db:"importance"`
+}
+
+// poiPathRow - строка GetPOIsAlongPath с позицией на маршруте
+type poiPathRow struct {
+	poiDistanceRow
+	PathOffset float64 `db:"path_offset"`
+}
+
 func (r poiShortRow) toDomain() *domain.POI {
 	return &domain.POI{
 		ID:          r.OSMID,
@@ -96,10 +183,12 @@ func (r poiShortRow) toDomain() *domain.POI {
 }
 
 // NewPOIRepository создает репозиторий POI для OSM базы данных
-func NewPOIRepository(db *DB) repository.POIRepository {
+func NewPOIRepository(db *DB, queryOpts QueryOptions) repository.POIRepository {
 	return &poiRepository{
-		db:     db.DB,
-		logger: db.logger,
+		db:        db.DB,
+		readDB:    db.Reader(ReadReplica),
+		logger:    db.logger,
+		queryOpts: queryOpts,
 	}
 }
 
@@ -119,12 +208,86 @@ func (r *poiRepository) GetByID(ctx context.Context, id int64) (*domain.POI, err
 	return parsePOIFromRow(&row), nil
 }
 
-func (r *poiRepository) GetNearby(ctx context.Context, lat, lon, radiusKm float64, categories []string) ([]*domain.POI, error) {
+// poiBuildingSearchRadiusM - радиус поиска ближайшего здания, если POI не лежит ни в одном контуре
+const poiBuildingSearchRadiusM = 30
+
+// GetPOIBuilding возвращает здание, в контуре которого находится POI (наименьшее по площади из
+// вложенных), иначе ближайшее здание в радиусе poiBuildingSearchRadiusM.
+// ErrLocationNotFound - нет POI с таким ID или здания рядом с ним.
+func (r *poiRepository) GetPOIBuilding(ctx context.Context, poiID int64) (*domain.Building, error) {
+	query := fmt.Sprintf(`
+		WITH poi AS (
+			SELECT way, ST_Transform(way, %d)::geography AS geog
+			FROM %s
+			WHERE osm_id = $1
+			LIMIT 1
+		)
+		SELECT
+			b.osm_id,
+			b.building,
+			COALESCE(b.name, '') AS name,
+			COALESCE(b.tags->'building:levels', '') AS levels,
+			COALESCE(b.tags->'height', '') AS height,
+			ST_Contains(b.way, poi.way) AS contains,
+			ST_Distance(ST_Transform(b.way, %d)::geography, poi.geog) AS distance,
+			ST_AsGeoJSON(ST_Transform(b.way, %d), 7) AS geometry
+		FROM %s b, poi
+		WHERE b.building IS NOT NULL
+		  AND b.way && ST_Expand(poi.way, $2 / cos(radians(ST_Y(ST_Transform(poi.way, %d)))))
+		  AND ST_DWithin(ST_Transform(b.way, %d)::geography, poi.geog, $2)
+		ORDER BY contains DESC, distance, ST_Area(b.way)
+		LIMIT 1
+	`, SRID4326, planetPointTable, SRID4326, SRID4326, planetPolygonTable, SRID4326, SRID4326)
+
+	var b domain.Building
+	var levels, height, geometry string
+	err := r.db.QueryRowxContext(ctx, query, poiID, float64(poiBuildingSearchRadiusM)).Scan(
+		&b.ID, &b.Type, &b.Name, &levels, &height, &b.Contains, &b.DistanceM, &geometry,
+	)
+	if err == sql.ErrNoRows {
+		return nil, pkgerrors.ErrLocationNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get osm poi building", zap.Int64("poi_id", poiID), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	b.Levels = domain.ParseOSMNumber(levels)
+	b.Height = domain.ParseOSMNumber(height)
+	b.Geometry = json.RawMessage(geometry)
+	if b.Contains {
+		b.DistanceM = 0
+	}
+	return &b, nil
+}
+
+func (r *poiRepository) GetNearby(ctx context.Context, lat, lon, radiusKm float64, categories []string, opts domain.POINearbyOptions, limit int) ([]*domain.POI, error) {
 	if radiusKm <= 0 {
 		radiusKm = 1
 	}
 
 	radiusMeters := radiusKm * 1000
+	args := []interface{}{lon, lat, radiusMeters}
+
+	src := poiSelectLite
+	addrColumns := ""
+	if opts.IncludeAddress {
+		src = poiSelectLiteWithAddress
+		addrColumns = "addr_street, addr_housenumber, addr_postcode, addr_city,"
+	}
+	importanceColumn := ""
+	if !opts.Importance.IsEmpty() {
+		importanceColumn = poiImportanceExpr("subcategory") + " AS importance,"
+	}
+	src += " WHERE " + r.queryOpts.activeFeatureCondition("")
+	if opts.SurfaceOnly {
+		src += " AND " + surfaceOnlyCondition
+	}
+	if !opts.TagFilter.IsEmpty() {
+		var tagCondition string
+		tagCondition, args = poiTagFilterCondition(opts.TagFilter, args)
+		src += " AND " + tagCondition
+	}
 
 	base := fmt.Sprintf(`
 		WITH point AS (
@@ -141,13 +304,14 @@ func (r *poiRepository) GetNearby(ctx context.Context, lat, lon, radiusKm float6
 			subcategory,
 			ST_Y(w4326) AS lat,
 			ST_X(w4326) AS lon,
+			%s
+			%s
 			ST_Distance(w4326::geography, point.geom) AS distance
 		FROM data, point
 		WHERE ST_DWithin(w4326::geography, point.geom, $3)
-	`, SRID4326, SRID4326, poiSelectLite)
+	`, SRID4326, SRID4326, src, addrColumns, importanceColumn)
 
-	args := []interface{}{lon, lat, radiusMeters}
-	argIdx := 4
+	argIdx := len(args) + 1
 
 	if len(categories) > 0 {
 		base += fmt.Sprintf(" AND category = ANY($%d)", argIdx)
@@ -155,8 +319,38 @@ func (r *poiRepository) GetNearby(ctx context.Context, lat, lon, radiusKm float6
 		argIdx++
 	}
 
-	base += fmt.Sprintf(" ORDER BY distance LIMIT $%d", argIdx)
-	args = append(args, LimitPOIs)
+	if opts.ExcludeWithinM > 0 {
+		base += fmt.Sprintf(" AND ST_Distance(w4326::geography, point.geom) >= $%d", argIdx)
+		args = append(args, opts.ExcludeWithinM)
+		argIdx++
+	}
+
+	if opts.Importance.MinImportance > 0 {
+		base += fmt.Sprintf(" AND %s >= $%d", poiImportanceExpr("subcategory"), argIdx)
+		args = append(args, opts.Importance.MinImportance)
+		argIdx++
+	}
+
+	// Уровень приоритета и значимость считаются в SQL до LIMIT: далекий POI важной категории
+	// или значимый POI не вытесняется лимитом ближними менее важными
+	orderBy := "distance"
+	if opts.Importance.OrderByImportance {
+		orderBy = "importance DESC, distance"
+	}
+	if len(opts.CategoryPriority) > 0 {
+		orderBy = fmt.Sprintf("%s, %s", categoryPriorityOrder(argIdx), orderBy)
+		args = append(args, pq.Array(opts.CategoryPriority))
+		argIdx++
+	}
+
+	if limit <= 0 {
+		limit = LimitPOIs
+	}
+	if limit > LimitPOIsCategory {
+		limit = LimitPOIsCategory
+	}
+	base += fmt.Sprintf(" ORDER BY %s LIMIT $%d", orderBy, argIdx)
+	args = append(args, limit)
 
 	rows, err := r.db.QueryxContext(ctx, base, args...)
 	if err != nil {
@@ -167,18 +361,133 @@ func (r *poiRepository) GetNearby(ctx context.Context, lat, lon, radiusKm float6
 
 	var result []*domain.POI
 	for rows.Next() {
-		var row poiDistanceRow
+		var row poiAddressDistanceRow
 		if err := rows.StructScan(&row); err != nil {
 			r.logger.Error("failed to scan poi row", zap.Error(err))
 			continue
 		}
-		result = append(result, row.poiShortRow.toDomain())
+		poi := row.poiShortRow.toDomain()
+		distance := row.Distance
+		poi.DistanceM = &distance
+		if opts.IncludeAddress {
+			poi.AddressDetails = domain.NewPOIAddress(row.AddrStreet, row.AddrHouseNumber, row.AddrPostcode, row.AddrCity)
+		}
+		poi.Importance = row.Importance
+		result = append(result, poi)
+	}
+
+	return result, nil
+}
+
+// categoryPriorityOrder возвращает выражение уровня приоритета категории: позиция category в массиве
+// $argIdx (1 - самая важная), категории вне массива - после всех перечисленных
+func categoryPriorityOrder(argIdx int) string {
+	return fmt.Sprintf("COALESCE(array_position($%d::text[], category), 2147483647)", argIdx)
+}
+
+// poiImportanceExpr - оценка значимости POI от 0 до domain.MaxPOIImportance по тегам OSM:
+// wikidata (4), wikipedia (2), непустое название (1) и вес подкатегории (3 - достопримечательности,
+// 2 - крупные объекты, 1 - заметные). subcategory - SQL-выражение подкатегории (колонка или
+// tileSubcategoryExpr), колонки name и tags таблицы точек должны быть в области видимости.
+func poiImportanceExpr(subcategory string) string {
+	return fmt.Sprintf(`(
+		CASE WHEN tags ? 'wikidata' THEN 4 ELSE 0 END
+		+ CASE WHEN tags ? 'wikipedia' THEN 2 ELSE 0 END
+		+ CASE WHEN COALESCE(name, '') <> '' THEN 1 ELSE 0 END
+		+ CASE
+			WHEN (%[1]s) IN ('attraction','museum','castle') THEN 3
+			WHEN (%[1]s) IN ('viewpoint','monument','hospital','university','mall') THEN 2
+			WHEN (%[1]s) IN ('park','college','library','department_store') THEN 1
+			ELSE 0
+		END
+	)`, subcategory)
+}
+
+// GetPOIsAlongPath возвращает POI в коридоре bufferM метров вокруг маршрута в порядке движения.
+// Маршрут строится из path как LineString в EPSG:4326, расстояния считаются по geography.
+func (r *poiRepository) GetPOIsAlongPath(ctx context.Context, path []domain.Coordinate, bufferM float64, categories []string, limit int) ([]*domain.POI, error) {
+	if len(path) < 2 {
+		return []*domain.POI{}, nil
+	}
+
+	lons := make([]float64, len(path))
+	lats := make([]float64, len(path))
+	for i, p := range path {
+		lons[i], lats[i] = p.Lon, p.Lat
+	}
+
+	query := fmt.Sprintf(`
+		WITH line AS (
+			SELECT ST_SetSRID(ST_MakeLine(ARRAY(
+				SELECT ST_MakePoint(t.lon, t.lat)
+				FROM unnest($1::float8[], $2::float8[]) WITH ORDINALITY AS t(lon, lat, ord)
+				ORDER BY t.ord
+			)), %d) AS geom
+		), corridor AS (
+			SELECT
+				geom,
+				ST_Length(geom::geography) AS length_m,
+				ST_Transform(ST_Buffer(geom::geography, $3)::geometry, %d) AS bbox
+			FROM line
+		), data AS (
+			SELECT *, ST_Transform(way, %d) AS w4326 FROM (
+				%s
+			) src
+		)
+		SELECT
+			osm_id,
+			name,
+			category,
+			subcategory,
+			ST_Y(w4326) AS lat,
+			ST_X(w4326) AS lon,
+			ST_Distance(w4326::geography, corridor.geom::geography) AS distance,
+			ST_LineLocatePoint(corridor.geom, w4326) * corridor.length_m AS path_offset
+		FROM data, corridor
+		WHERE way && corridor.bbox
+		  AND ST_DWithin(w4326::geography, corridor.geom::geography, $3)
+	`, SRID4326, SRID3857, SRID4326, r.queryOpts.activePOISelect(poiSelectLite))
+
+	args := []interface{}{pq.Array(lons), pq.Array(lats), bufferM}
+	argIdx := 4
+
+	if len(categories) > 0 {
+		query += fmt.Sprintf(" AND category = ANY($%d)", argIdx)
+		args = append(args, pq.Array(categories))
+		argIdx++
+	}
+
+	query += fmt.Sprintf(" ORDER BY path_offset, distance LIMIT $%d", argIdx)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("failed to query osm pois along path",
+			zap.Int("points", len(path)),
+			zap.Float64("buffer_m", bufferM),
+			zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	result := make([]*domain.POI, 0)
+	for rows.Next() {
+		var row poiPathRow
+		if err := rows.StructScan(&row); err != nil {
+			r.logger.Error("failed to scan poi path row", zap.Error(err))
+			continue
+		}
+		poi := row.poiShortRow.toDomain()
+		distance, offset := row.Distance, row.PathOffset
+		poi.DistanceM = &distance
+		poi.PathOffsetM = &offset
+		result = append(result, poi)
 	}
 
 	return result, nil
 }
 
-func (r *poiRepository) Search(ctx context.Context, query string, categories []string, limit int) ([]*domain.POI, error) {
+func (r *poiRepository) Search(ctx context.Context, query string, categories []string, tagFilter domain.POITagFilter, limit int) ([]*domain.POI, error) {
 	if limit <= 0 {
 		limit = LimitPOIs
 	}
@@ -186,6 +495,14 @@ func (r *poiRepository) Search(ctx context.Context, query string, categories []s
 		limit = LimitPOIsCategory
 	}
 
+	args := []interface{}{query}
+	src := r.queryOpts.activePOISelect(poiSelectLite)
+	if !tagFilter.IsEmpty() {
+		var tagCondition string
+		tagCondition, args = poiTagFilterCondition(tagFilter, args)
+		src += " AND " + tagCondition
+	}
+
 	searchSQL := fmt.Sprintf(`
 		SELECT
 			osm_id,
@@ -210,11 +527,10 @@ func (r *poiRepository) Search(ctx context.Context, query string, categories []s
 				%s
 			) data
 		) ranked
-		WHERE (ranked.document @@ ranked.query_ts) OR ranked.name ILIKE '%%' || $1 || '%%'
-	`, poiSelectLite)
+		WHERE ((ranked.document @@ ranked.query_ts) OR ranked.name ILIKE '%%' || $1 || '%%')
+	`, src)
 
-	args := []interface{}{query}
-	argIdx := 2
+	argIdx := len(args) + 1
 
 	if len(categories) > 0 {
 		searchSQL += fmt.Sprintf(" AND ranked.category = ANY($%d)", argIdx)
@@ -267,7 +583,7 @@ func (r *poiRepository) GetByCategory(ctx context.Context, category string, limi
 		WHERE category = $1
 		ORDER BY name
 		LIMIT $2
-	`, poiSelectLite)
+	`, r.queryOpts.activePOISelect(poiSelectLite))
 
 	rows, err := r.db.QueryxContext(ctx, query, category, limit)
 	if err != nil {
@@ -296,7 +612,7 @@ func (r *poiRepository) GetCategories(ctx context.Context) ([]*domain.POICategor
 		) data
 		WHERE category IS NOT NULL AND category <> ''
 		ORDER BY category
-	`, poiSelectLite)
+	`, r.queryOpts.activePOISelect(poiSelectLite))
 
 	rows, err := r.db.QueryxContext(ctx, query)
 	if err != nil {
@@ -331,22 +647,33 @@ func (r *poiRepository) GetCategories(ctx context.Context) ([]*domain.POICategor
 	return categories, nil
 }
 
-func (r *poiRepository) GetSubcategories(ctx context.Context, categoryID int64) ([]*domain.POISubcategory, error) {
+func (r *poiRepository) GetSubcategories(ctx context.Context, categoryID int64, bbox *domain.BoundingBox) ([]*domain.POISubcategory, error) {
 	code, err := r.resolveCategoryCode(ctx, categoryID)
 	if err != nil {
 		return nil, err
 	}
 
+	bboxFilter := ""
+	args := []interface{}{code}
+	if bbox != nil {
+		bboxFilter = fmt.Sprintf(
+			" AND way && ST_Transform(ST_MakeEnvelope($2, $3, $4, $5, %d), %d)",
+			SRID4326, SRID3857,
+		)
+		args = append(args, bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat)
+	}
+
 	query := fmt.Sprintf(`
-		SELECT DISTINCT subcategory
+		SELECT subcategory, COUNT(*) AS poi_count
 		FROM (
 			%s
 		) data
-		WHERE category = $1
+		WHERE category = $1%s
+		GROUP BY subcategory
 		ORDER BY subcategory
-	`, poiSelectLite)
+	`, r.queryOpts.activePOISelect(poiSelectLite), bboxFilter)
 
-	rows, err := r.db.QueryxContext(ctx, query, code)
+	rows, err := r.db.QueryxContext(ctx, query, args...)
 	if err != nil {
 		r.logger.Error("failed to list osm subcategories", zap.String("category", code), zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -357,7 +684,8 @@ func (r *poiRepository) GetSubcategories(ctx context.Context, categoryID int64)
 	idx := 1
 	for rows.Next() {
 		var subcode string
-		if err := rows.Scan(&subcode); err != nil {
+		var count int
+		if err := rows.Scan(&subcode, &count); err != nil {
 			continue
 		}
 		subcategories = append(subcategories, &domain.POISubcategory{
@@ -373,6 +701,7 @@ func (r *poiRepository) GetSubcategories(ctx context.Context, categoryID int64)
 			NamePt:     subcode,
 			NameIt:     subcode,
 			NameDe:     subcode,
+			POICount:   count,
 			SortOrder:  idx,
 		})
 		idx++
@@ -381,8 +710,61 @@ func (r *poiRepository) GetSubcategories(ctx context.Context, categoryID int64)
 	return subcategories, nil
 }
 
+// GetCategoryTree строит дерево категория -> подкатегории одним GROUP BY по обеим колонкам вместо
+// GetCategories и N вызовов GetSubcategories. Полный проход по таблице точек - запрос идет в реплику.
+func (r *poiRepository) GetCategoryTree(ctx context.Context) ([]domain.CategoryNode, error) {
+	query := fmt.Sprintf(`
+		SELECT category, COALESCE(subcategory, '') AS subcategory, COUNT(*) AS poi_count
+		FROM (
+			%s
+		) data
+		WHERE category IS NOT NULL AND category <> ''
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`, r.queryOpts.activePOISelect(poiSelectLite))
+
+	rows, err := r.readDB.QueryxContext(ctx, query)
+	if err != nil {
+		r.logger.Error("failed to build osm category tree", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	tree := []domain.CategoryNode{}
+	for rows.Next() {
+		var code, subcode string
+		var count int
+		// Частичное дерево не возвращается: use case кеширует его на сутки
+		if err := rows.Scan(&code, &subcode, &count); err != nil {
+			r.logger.Error("failed to scan category tree row", zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+		if len(tree) == 0 || tree[len(tree)-1].Code != code {
+			tree = append(tree, domain.CategoryNode{ID: hashCategory(code), Code: code})
+		}
+		node := &tree[len(tree)-1]
+		node.POICount += count
+		node.Subcategories = append(node.Subcategories, domain.SubcategoryNode{
+			ID:       hashCategory(code + ":" + subcode),
+			Code:     subcode,
+			POICount: count,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to iterate category tree", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return tree, nil
+}
+
 func (r *poiRepository) GetPOITile(ctx context.Context, z, x, y int, categories []string) ([]byte, error) {
-	limit := getPOILimitByZoom(z)
+	if !tileZoomPolicy.LayerVisible(domain.TileLayerPOI, z) {
+		return []byte{}, nil
+	}
+
+	limit := poiTileFeatureLimit(z)
+	categoryLimit := poiTileCategoryLimit(limit, len(categories), 0)
 	categoryFilter := ""
 	argOffset := 6
 	args := []interface{}{z, x, y, MVTExtent, MVTBuffer}
@@ -391,36 +773,40 @@ func (r *poiRepository) GetPOITile(ctx context.Context, z, x, y int, categories
 		args = append(args, pq.Array(categories))
 	}
 
+	// На мелком масштабе в тайл попадают только значимые подкатегории
+	zoomFilter, args, visible := poiZoomCategoryFilter(z, args)
+	if !visible {
+		return []byte{}, nil
+	}
+	categoryFilter += zoomFilter
+
+	features := fmt.Sprintf(`
+			SELECT
+				%s
+				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
+			FROM data, bounds
+			WHERE way && bounds.geom
+			ORDER BY %s
+			LIMIT %d`, tileAttributeColumns(string(domain.TileLayerPOI)), poiTileImportanceOrder, limit)
+	if tileZoomPolicy.ClusterPoints(z) {
+		features = poiClusterFeaturesSQL(z, false, limit)
+	}
+
 	query := fmt.Sprintf(`
 		WITH bounds AS (
 			SELECT ST_TileEnvelope($1, $2, $3) AS geom
 		),
-		data AS (
-			SELECT osm_id, name, category, subcategory, way
-			FROM (
-				%s
-			) src
-			WHERE way && (SELECT geom FROM bounds)%s
+		data AS (%s
 		),
-		mvt_geom AS (
-			SELECT
-				osm_id AS id,
-				name,
-				category,
-				subcategory,
-				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
-			FROM data, bounds
-			WHERE way && bounds.geom
-			ORDER BY category, name
-			LIMIT %d
+		mvt_geom AS (%s
 		)
-		SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'pois'), '\\x') AS tile
+		SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'pois'), %s) AS tile
 		FROM mvt_geom
 		WHERE geom IS NOT NULL
-	`, poiTileSelect, categoryFilter, limit)
+	`, poiTileDataSQL(poiTileSelect+" AND "+r.queryOpts.activeFeatureCondition(""), categoryFilter, categoryLimit), features, emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, args...).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -459,10 +845,7 @@ func (r *poiRepository) GetPOIRadiusTile(ctx context.Context, lat, lon, radiusKm
 			) src
 		), mvt_geom AS (
 			SELECT
-				osm_id AS id,
-				name,
-				category,
-				subcategory,
+				%s
 				ST_AsMVTGeom(
 					way,
 					ST_Transform(circle.geom, %d),
@@ -475,13 +858,14 @@ func (r *poiRepository) GetPOIRadiusTile(ctx context.Context, lat, lon, radiusKm
 			ORDER BY category, name
 			LIMIT %d
 		)
-		SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'pois'), '\\x') AS tile
+		SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'pois'), %s) AS tile
 		FROM mvt_geom
 		WHERE geom IS NOT NULL
-	`, SRID4326, poiSelectLite, SRID3857, SRID4326, categoryFilter, LimitPOIsRadius)
+	`, SRID4326, r.queryOpts.activePOISelect(poiSelectLite), tileAttributeColumns(string(domain.TileLayerPOI)), SRID3857, SRID4326, categoryFilter,
+		LimitPOIsRadius, emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, args...).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -511,10 +895,7 @@ func (r *poiRepository) GetPOIByBoundaryTile(ctx context.Context, boundaryID int
 			) src
 		), mvt_geom AS (
 			SELECT
-				data.osm_id AS id,
-				data.name,
-				data.category,
-				data.subcategory,
+				%s
 				ST_AsMVTGeom(data.way, boundary.way, $2, $3, true) AS geom
 			FROM data, boundary
 			WHERE boundary.way IS NOT NULL
@@ -522,13 +903,14 @@ func (r *poiRepository) GetPOIByBoundaryTile(ctx context.Context, boundaryID int
 			ORDER BY data.category, data.name
 			LIMIT %d
 		)
-		SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'pois'), '\\x') AS tile
+		SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'pois'), %s) AS tile
 		FROM mvt_geom
 		WHERE geom IS NOT NULL
-	`, planetPolygonTable, poiSelectLite, categoryFilter, LimitPOIsCategory)
+	`, planetPolygonTable, r.queryOpts.activePOISelect(poiSelectLite), tileAttributeColumns(string(domain.TileLayerPOI)), categoryFilter,
+		LimitPOIsCategory, emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, args...).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -554,9 +936,17 @@ func (r *poiRepository) resolveCategoryCode(ctx context.Context, categoryID int6
 	return "", pkgerrors.ErrLocationNotFound
 }
 
-// GetPOITileByCategories генерирует MVT тайл с POI по координатам тайла с фильтрацией по категориям и подкатегориям
-func (r *poiRepository) GetPOITileByCategories(ctx context.Context, z, x, y int, categories, subcategories []string) ([]byte, error) {
-	limit := getPOILimitByZoom(z)
+// GetPOITileByCategories генерирует MVT тайл с POI по координатам тайла с фильтрацией по категориям и подкатегориям.
+// withLabels добавляет атрибуты rank и min_zoom для расстановки подписей на клиенте.
+// На зумах, где политика тайлов включает кластеризацию, точки объединяются в кластеры с атрибутом point_count.
+// categoryLimit ограничивает число POI одной категории (см. poiTileCategoryLimit), 0 - предел по умолчанию.
+func (r *poiRepository) GetPOITileByCategories(ctx context.Context, z, x, y int, categories, subcategories []string, surfaceOnly, withLabels bool, categoryLimit int) ([]byte, error) {
+	if !tileZoomPolicy.LayerVisible(domain.TileLayerPOI, z) {
+		return []byte{}, nil
+	}
+
+	limit := poiTileFeatureLimit(z)
+	categoryLimit = poiTileCategoryLimit(limit, len(categories), categoryLimit)
 	args := []interface{}{z, x, y, MVTExtent, MVTBuffer}
 	argOffset := 6
 
@@ -577,36 +967,51 @@ func (r *poiRepository) GetPOITileByCategories(ctx context.Context, z, x, y int,
 		filterClause = " AND (" + strings.Join(filters, " OR ") + ")"
 	}
 
+	// Фильтр зума сужает выбранные клиентом категории: мелкие POI не видны на мелком масштабе
+	zoomFilter, args, visible := poiZoomCategoryFilter(z, args)
+	if !visible {
+		return []byte{}, nil
+	}
+	filterClause += zoomFilter
+
+	src := poiTileSelect + " AND " + r.queryOpts.activeFeatureCondition("")
+	if surfaceOnly {
+		src += " AND " + surfaceOnlyCondition
+	}
+
+	// В тайл попадают сначала самые значимые POI, withLabels отдает ранг и min_zoom атрибутами
+	var conditions []string
+	if withLabels {
+		conditions = append(conditions, domain.TileConditionWithLabels)
+	}
+
+	features := fmt.Sprintf(`
+			SELECT
+				%s
+				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
+			FROM data, bounds
+			WHERE way && bounds.geom
+			ORDER BY %s
+			LIMIT %d`, tileAttributeColumns(string(domain.TileLayerPOI), conditions...), poiTileImportanceOrder, limit)
+	if tileZoomPolicy.ClusterPoints(z) {
+		features = poiClusterFeaturesSQL(z, withLabels, limit)
+	}
+
 	query := fmt.Sprintf(`
 		WITH bounds AS (
 			SELECT ST_TileEnvelope($1, $2, $3) AS geom
 		),
-		data AS (
-			SELECT osm_id, name, category, subcategory, way
-			FROM (
-				%s
-			) src
-			WHERE way && (SELECT geom FROM bounds)%s
+		data AS (%s
 		),
-		mvt_geom AS (
-			SELECT
-				osm_id AS id,
-				name,
-				category,
-				subcategory,
-				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
-			FROM data, bounds
-			WHERE way && bounds.geom
-			ORDER BY category, name
-			LIMIT %d
+		mvt_geom AS (%s
 		)
-		SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'pois'), '\\x') AS tile
+		SELECT COALESCE(ST_AsMVT(mvt_geom.*, 'pois'), %s) AS tile
 		FROM mvt_geom
 		WHERE geom IS NOT NULL
-	`, poiTileSelect, filterClause, limit)
+	`, poiTileDataSQL(src, filterClause, categoryLimit), features, emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, args...).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -622,11 +1027,13 @@ func (r *poiRepository) GetPOITileByCategories(ctx context.Context, z, x, y int,
 	return tile, nil
 }
 
-// GetPOIInBBox возвращает POI в видимой области карты (bbox) с фильтрацией по категориям.
+// GetPOIInBBox возвращает POI в видимой области карты (bbox) с фильтрацией по категориям
+// и, если задано, по оценке значимости (total считается с учетом отбора по значимости).
 func (r *poiRepository) GetPOIInBBox(
 	ctx context.Context,
 	swLat, swLon, neLat, neLon float64,
 	categories, subcategories []string,
+	importance domain.POIImportanceFilter,
 	limit, offset int,
 ) ([]*domain.POI, int, error) {
 	if limit <= 0 || limit > 100 {
@@ -642,7 +1049,7 @@ func (r *poiRepository) GetPOIInBBox(
 	)
 
 	// Фильтр по категориям/подкатегориям
-	filterClause := ""
+	filterClause := " AND " + r.queryOpts.activeFeatureCondition("")
 	args := []interface{}{swLon, swLat, neLon, neLat}
 
 	if len(categories) > 0 || len(subcategories) > 0 {
@@ -663,7 +1070,20 @@ func (r *poiRepository) GetPOIInBBox(
 			}
 			conditions = append(conditions, fmt.Sprintf("(%s) IN (%s)", tileSubcategoryExpr, strings.Join(subPlaceholders, ",")))
 		}
-		filterClause = " AND (" + strings.Join(conditions, " OR ") + ")"
+		filterClause += " AND (" + strings.Join(conditions, " OR ") + ")"
+	}
+
+	importanceColumn := "NULL::int AS importance"
+	orderBy := "(CASE WHEN name IS NOT NULL AND name != '' THEN 0 ELSE 1 END), category, name, osm_id"
+	if !importance.IsEmpty() {
+		importanceColumn = poiImportanceExpr(tileSubcategoryExpr) + " AS importance"
+	}
+	if importance.MinImportance > 0 {
+		args = append(args, importance.MinImportance)
+		filterClause += fmt.Sprintf(" AND %s >= $%d", poiImportanceExpr(tileSubcategoryExpr), len(args))
+	}
+	if importance.OrderByImportance {
+		orderBy = "importance DESC, " + orderBy
 	}
 
 	// Считаем total
@@ -675,7 +1095,7 @@ func (r *poiRepository) GetPOIInBBox(
 	`, planetPointTable, tileCategoryExpr, filterClause, bboxEnvelope)
 
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err := r.readDB.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		r.logger.Error("failed to count POI in bbox", zap.Error(err))
 		return nil, 0, pkgerrors.ErrDatabaseError
@@ -704,17 +1124,18 @@ func (r *poiRepository) GetPOIInBBox(
 			COALESCE(tags->'operator', '') AS operator,
 			COALESCE(tags->'cuisine', '') AS cuisine,
 			COALESCE(tags->'stars', '') AS stars_str,
-			COALESCE(tags->'description', '') AS description
+			COALESCE(tags->'description', '') AS description,
+			%s
 		FROM %s
 		WHERE (%s) != 'other'%s
 		  AND way && %s
-		ORDER BY (CASE WHEN name IS NOT NULL AND name != '' THEN 0 ELSE 1 END), category, name, osm_id
+		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, tileCategoryExpr, tileSubcategoryExpr, SRID4326, SRID4326,
-		planetPointTable, tileCategoryExpr, filterClause, bboxEnvelope,
+	`, tileCategoryExpr, tileSubcategoryExpr, SRID4326, SRID4326, importanceColumn,
+		planetPointTable, tileCategoryExpr, filterClause, bboxEnvelope, orderBy,
 		len(dataArgs)-1, len(dataArgs))
 
-	rows, err := r.db.QueryxContext(ctx, dataQuery, dataArgs...)
+	rows, err := r.readDB.QueryxContext(ctx, dataQuery, dataArgs...)
 	if err != nil {
 		r.logger.Error("failed to get POI in bbox", zap.Error(err))
 		return nil, 0, pkgerrors.ErrDatabaseError
@@ -729,7 +1150,7 @@ func (r *poiRepository) GetPOIInBBox(
 			&p.OSMId, &p.Name, &nameEn, &p.Category, &p.Subcategory,
 			&p.Lat, &p.Lon,
 			&address, &phone, &website, &openingHours, &wheelchairStr,
-			&brand, &operator, &cuisine, &starsStr, &description,
+			&brand, &operator, &cuisine, &starsStr, &description, &p.Importance,
 		)
 		if err != nil {
 			r.logger.Error("failed to scan POI bbox row", zap.Error(err))
@@ -795,11 +1216,12 @@ func (r *poiRepository) CountByCategories(ctx context.Context, lat, lon float64,
 		FROM %s, point
 		WHERE ST_DWithin(ST_Transform(way, %d)::geography, point.geom, $3)
 		  AND (%s) != 'other'
+		  AND %s
 		GROUP BY category
 		ORDER BY cnt DESC
-	`, SRID4326, tileCategoryExpr, planetPointTable, SRID4326, tileCategoryExpr)
+	`, SRID4326, tileCategoryExpr, planetPointTable, SRID4326, tileCategoryExpr, r.queryOpts.activeFeatureCondition(""))
 
-	rows, err := r.db.QueryxContext(ctx, query, lon, lat, radiusMeters)
+	rows, err := r.readDB.QueryxContext(ctx, query, lon, lat, radiusMeters)
 	if err != nil {
 		r.logger.Error("failed to count POI by categories", zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -819,3 +1241,150 @@ func (r *poiRepository) CountByCategories(ctx context.Context, lat, lon float64,
 
 	return result, nil
 }
+
+// activityCenterQuery строит запрос центра активности bbox ($1..$4 - minLon, minLat, maxLon, maxLat).
+// С фильтром категорий массив категорий передается в $5. weighted задает вес POI 1 + ранг подписи
+// (wikidata, достопримечательности), без него все POI равнозначны.
+func activityCenterQuery(q QueryOptions, withCategories, weighted bool) string {
+	weight := "1"
+	if weighted {
+		weight = "1 + " + poiTileRankExpr
+	}
+
+	categoryFilter := ""
+	if withCategories {
+		categoryFilter = " AND category = ANY($5)"
+	}
+
+	return fmt.Sprintf(`
+		WITH pois AS (
+			SELECT
+				ST_Transform(way, %d) AS geom,
+				tags,
+				COALESCE(name, '') AS name,
+				%s AS category,
+				%s AS subcategory
+			FROM %s
+			WHERE way && ST_Transform(ST_MakeEnvelope($1, $2, $3, $4, %d), %d)
+			  AND %s
+		),
+		weighted AS (
+			SELECT geom, (%s)::float8 AS w
+			FROM pois
+			WHERE category != 'other'%s
+		)
+		SELECT
+			COUNT(*) AS poi_count,
+			COALESCE(SUM(ST_Y(geom) * w) / NULLIF(SUM(w), 0), 0) AS lat,
+			COALESCE(SUM(ST_X(geom) * w) / NULLIF(SUM(w), 0), 0) AS lon
+		FROM weighted
+	`, SRID4326, tileCategoryExpr, tileSubcategoryExpr, planetPointTable, SRID4326, SRID3857,
+		q.activeFeatureCondition(""), weight, categoryFilter)
+}
+
+// GetActivityCenter возвращает центр активности bbox - среднее (взвешенное) координат POI
+func (r *poiRepository) GetActivityCenter(ctx context.Context, bbox domain.BoundingBox, categories []string, weighted bool) (*domain.ActivityCenter, error) {
+	args := []interface{}{bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat}
+	if len(categories) > 0 {
+		args = append(args, pq.Array(categories))
+	}
+
+	var center domain.ActivityCenter
+	query := activityCenterQuery(r.queryOpts, len(categories) > 0, weighted)
+	if err := r.readDB.GetContext(ctx, &center, query, args...); err != nil {
+		r.logger.Error("failed to get activity center",
+			zap.Any("bbox", bbox),
+			zap.Strings("categories", categories),
+			zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return &center, nil
+}
+
+// nearestPerCategoryBatchQuery строит запрос ближайшего POI каждой категории для набора точек.
+// Точки передаются двумя массивами $1 (lon) и $2 (lat) - число параметров не зависит от числа точек,
+// point_idx - порядковый номер точки в массивах с нуля. $3 - радиус в метрах, $4 - массив категорий.
+// Точки CROSS JOIN категории дают пары (point_idx, category), DISTINCT ON оставляет ближайший POI пары.
+func nearestPerCategoryBatchQuery(q QueryOptions) string {
+	return fmt.Sprintf(`
+		WITH input_points AS (
+			SELECT
+				(ord - 1)::int AS point_idx,
+				lat,
+				ST_SetSRID(ST_MakePoint(lon, lat), %d)::geography AS geog,
+				ST_Transform(ST_SetSRID(ST_MakePoint(lon, lat), %d), %d) AS geom_3857
+			FROM unnest($1::float8[], $2::float8[]) WITH ORDINALITY AS t(lon, lat, ord)
+		), data AS (
+			%s WHERE %s
+		)
+		SELECT DISTINCT ON (ip.point_idx, c.category)
+			ip.point_idx,
+			d.osm_id,
+			d.name,
+			c.category,
+			d.subcategory,
+			d.lat,
+			d.lon,
+			ST_Distance(ST_Transform(d.way, %d)::geography, ip.geog) AS distance
+		FROM input_points ip
+		CROSS JOIN unnest($4::text[]) AS c(category)
+		JOIN data d ON d.category = c.category
+			-- окно в метрах Меркатора растянуто на 1/cos(lat), чтобы покрыть радиус и использовать индекс по way
+			AND d.way && ST_Expand(ip.geom_3857, $3 / cos(radians(ip.lat)))
+			AND ST_DWithin(ST_Transform(d.way, %d)::geography, ip.geog, $3)
+		ORDER BY ip.point_idx, c.category, distance, d.osm_id
+	`, SRID4326, SRID4326, SRID3857, poiSelectLite, q.activeFeatureCondition(""), SRID4326, SRID4326)
+}
+
+// GetNearestPerCategoryBatch возвращает ближайший POI каждой категории для каждой точки одним запросом
+// вместо points×categories отдельных запросов
+func (r *poiRepository) GetNearestPerCategoryBatch(ctx context.Context, points []domain.LatLon, categories []string, maxRadiusKm float64) ([]map[string]*domain.POIWithDistance, error) {
+	results := make([]map[string]*domain.POIWithDistance, len(points))
+	for i := range results {
+		results[i] = make(map[string]*domain.POIWithDistance)
+	}
+	if len(points) == 0 || len(categories) == 0 {
+		return results, nil
+	}
+	if maxRadiusKm <= 0 {
+		maxRadiusKm = 1
+	}
+
+	lons := make([]float64, len(points))
+	lats := make([]float64, len(points))
+	for i, p := range points {
+		lons[i], lats[i] = p.Lon, p.Lat
+	}
+
+	rows, err := r.db.QueryxContext(ctx, nearestPerCategoryBatchQuery(r.queryOpts),
+		pq.Array(lons), pq.Array(lats), maxRadiusKm*1000, pq.Array(categories))
+	if err != nil {
+		r.logger.Error("failed to batch query nearest poi per category",
+			zap.Int("points_count", len(points)),
+			zap.Strings("categories", categories),
+			zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pointIdx int
+		var poi domain.POIWithDistance
+		if err := rows.Scan(&pointIdx, &poi.ID, &poi.Name, &poi.Category, &poi.Subcategory, &poi.Lat, &poi.Lon, &poi.LinearDistance); err != nil {
+			r.logger.Error("failed to scan nearest poi per category", zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+		if pointIdx < 0 || pointIdx >= len(points) {
+			continue
+		}
+		poi.Name = ensureName(poi.Name, poi.Category, poi.ID)
+		results[pointIdx][poi.Category] = &poi
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to iterate nearest poi per category", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return results, nil
+}
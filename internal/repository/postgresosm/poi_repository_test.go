@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/location-microservice/internal/domain"
 	pkgerrors "github.com/location-microservice/internal/pkg/errors"
 )
 
@@ -12,7 +13,7 @@ func TestPOIRepository_GetByID(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewPOIRepository(db)
+	repo := NewPOIRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get existing POI by ID", func(t *testing.T) {
@@ -66,12 +67,50 @@ func TestPOIRepository_GetByID(t *testing.T) {
 	})
 }
 
+func TestPOIRepository_GetPOIBuilding(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewPOIRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("POI inside building returns containing footprint", func(t *testing.T) {
+		var osmID int64
+		query := `SELECT p.osm_id FROM planet_osm_point p
+				  JOIN planet_osm_polygon b ON b.building IS NOT NULL AND ST_Contains(b.way, p.way)
+				  WHERE p.amenity IS NOT NULL
+				  LIMIT 1`
+		if err := db.QueryRowContext(ctx, query).Scan(&osmID); err != nil {
+			t.Skipf("No POI inside a building found: %v", err)
+		}
+
+		building, err := repo.GetPOIBuilding(ctx, osmID)
+		if err != nil {
+			t.Fatalf("Failed to get POI building: %v", err)
+		}
+		if !building.Contains || building.DistanceM != 0 {
+			t.Errorf("Expected containing building, got contains=%v distance=%v", building.Contains, building.DistanceM)
+		}
+		if building.Type == "" || len(building.Geometry) == 0 {
+			t.Errorf("Expected building type and GeoJSON geometry, got %+v", building)
+		}
+	})
+
+	t.Run("Unknown POI returns not found", func(t *testing.T) {
+		_, err := repo.GetPOIBuilding(ctx, -1)
+		if err != pkgerrors.ErrLocationNotFound {
+			t.Errorf("Expected ErrLocationNotFound, got %v", err)
+		}
+	})
+}
+
 func TestPOIRepository_GetNearby(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewPOIRepository(db)
+	repo := NewPOIRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get nearby POIs without category filter", func(t *testing.T) {
@@ -79,7 +118,7 @@ func TestPOIRepository_GetNearby(t *testing.T) {
 		lat, lon := 41.3851, 2.1734
 		radiusKm := 1.0
 
-		pois, err := repo.GetNearby(ctx, lat, lon, radiusKm, nil)
+		pois, err := repo.GetNearby(ctx, lat, lon, radiusKm, nil, domain.POINearbyOptions{}, 0)
 		if err != nil {
 			t.Fatalf("Failed to get nearby POIs: %v", err)
 		}
@@ -96,6 +135,9 @@ func TestPOIRepository_GetNearby(t *testing.T) {
 				t.Error("Expected non-empty category")
 			}
 			assertValidCoordinates(t, poi.Lat, poi.Lon)
+			if poi.DistanceM == nil || *poi.DistanceM > radiusKm*1000 {
+				t.Errorf("Expected distance within %.0fm, got %v", radiusKm*1000, poi.DistanceM)
+			}
 		}
 	})
 
@@ -104,7 +146,7 @@ func TestPOIRepository_GetNearby(t *testing.T) {
 		radiusKm := 5.0
 		categories := []string{"restaurant", "cafe", "bar"}
 
-		pois, err := repo.GetNearby(ctx, lat, lon, radiusKm, categories)
+		pois, err := repo.GetNearby(ctx, lat, lon, radiusKm, categories, domain.POINearbyOptions{}, 0)
 		if err != nil {
 			t.Fatalf("Failed to get nearby POIs with filter: %v", err)
 		}
@@ -126,7 +168,7 @@ func TestPOIRepository_GetNearby(t *testing.T) {
 	t.Run("Get nearby POIs with zero radius uses default", func(t *testing.T) {
 		lat, lon := 41.3851, 2.1734
 
-		pois, err := repo.GetNearby(ctx, lat, lon, 0, nil)
+		pois, err := repo.GetNearby(ctx, lat, lon, 0, nil, domain.POINearbyOptions{}, 0)
 		if err != nil {
 			t.Fatalf("Failed to get nearby POIs: %v", err)
 		}
@@ -134,6 +176,109 @@ func TestPOIRepository_GetNearby(t *testing.T) {
 		// Should use default radius of 1km
 		_ = pois
 	})
+
+	t.Run("Get nearby POIs with address", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+
+		pois, err := repo.GetNearby(ctx, lat, lon, 0.5, nil, domain.POINearbyOptions{IncludeAddress: true}, 0)
+		if err != nil {
+			t.Fatalf("Failed to get nearby POIs with address: %v", err)
+		}
+
+		for _, poi := range pois {
+			if poi.AddressDetails != nil && poi.AddressDetails.Display == "" &&
+				(poi.AddressDetails.Street != "" || poi.AddressDetails.City != "") {
+				t.Errorf("POI %d has address components but empty display", poi.ID)
+			}
+		}
+	})
+
+	t.Run("Get nearby POIs filtered by tags", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+
+		all, err := repo.GetNearby(ctx, lat, lon, 1.0, nil, domain.POINearbyOptions{}, 0)
+		if err != nil {
+			t.Fatalf("Failed to get nearby POIs: %v", err)
+		}
+		filtered, err := repo.GetNearby(ctx, lat, lon, 1.0, nil, domain.POINearbyOptions{
+			TagFilter: domain.POITagFilter{
+				HasTags:     []string{"website"},
+				RequireTags: map[string]string{"wheelchair": "yes"},
+			},
+		}, 0)
+		if err != nil {
+			t.Fatalf("Failed to get nearby POIs by tags: %v", err)
+		}
+
+		if len(filtered) > len(all) {
+			t.Errorf("Expected tag filter to narrow results, got %d of %d", len(filtered), len(all))
+		}
+	})
+
+	t.Run("Get nearby POIs excluding the query point", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+		excludeM := 50.0
+
+		pois, err := repo.GetNearby(ctx, lat, lon, 1.0, nil, domain.POINearbyOptions{ExcludeWithinM: excludeM}, 0)
+		if err != nil {
+			t.Fatalf("Failed to get nearby POIs with exclusion radius: %v", err)
+		}
+
+		for _, poi := range pois {
+			if poi.DistanceM != nil && *poi.DistanceM < excludeM {
+				t.Errorf("POI %d at %.1fm should be excluded (exclude_within_m=%.0f)", poi.OSMId, *poi.DistanceM, excludeM)
+			}
+		}
+	})
+
+	t.Run("Get nearby POIs ordered by category priority", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+		priority := []string{"healthcare", "education"}
+
+		pois, err := repo.GetNearby(ctx, lat, lon, 1.0, nil, domain.POINearbyOptions{CategoryPriority: priority}, 0)
+		if err != nil {
+			t.Fatalf("Failed to get nearby POIs with category priority: %v", err)
+		}
+
+		tier := func(category string) int {
+			for i, c := range priority {
+				if c == category {
+					return i
+				}
+			}
+			return len(priority)
+		}
+		for i := 1; i < len(pois); i++ {
+			prev, cur := pois[i-1], pois[i]
+			if tier(prev.Category) > tier(cur.Category) {
+				t.Fatalf("POI %d (%s) ranked after lower priority %s", cur.OSMId, cur.Category, prev.Category)
+			}
+			if tier(prev.Category) == tier(cur.Category) && *prev.DistanceM > *cur.DistanceM+0.01 {
+				t.Errorf("POI %d not ordered by distance within tier %s", cur.OSMId, cur.Category)
+			}
+		}
+	})
+	t.Run("Get nearby POIs filtered and ordered by importance", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+		filter := domain.POIImportanceFilter{MinImportance: 2, OrderByImportance: true}
+
+		pois, err := repo.GetNearby(ctx, lat, lon, 1.0, nil, domain.POINearbyOptions{Importance: filter}, 0)
+		if err != nil {
+			t.Fatalf("Failed to get nearby POIs by importance: %v", err)
+		}
+
+		for i, poi := range pois {
+			if poi.Importance == nil {
+				t.Fatalf("POI %d has no importance", poi.OSMId)
+			}
+			if *poi.Importance < filter.MinImportance || *poi.Importance > domain.MaxPOIImportance {
+				t.Errorf("POI %d importance %d out of range", poi.OSMId, *poi.Importance)
+			}
+			if i > 0 && *pois[i-1].Importance < *poi.Importance {
+				t.Errorf("POI %d not ordered by importance", poi.OSMId)
+			}
+		}
+	})
 }
 
 func TestPOIRepository_Search(t *testing.T) {
@@ -141,7 +286,7 @@ func TestPOIRepository_Search(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewPOIRepository(db)
+	repo := NewPOIRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Search POIs by text", func(t *testing.T) {
@@ -158,7 +303,7 @@ func TestPOIRepository_Search(t *testing.T) {
 
 		// Search for part of the name
 		searchQuery := searchName[:3]
-		pois, err := repo.Search(ctx, searchQuery, nil, 10)
+		pois, err := repo.Search(ctx, searchQuery, nil, domain.POITagFilter{}, 10)
 		if err != nil {
 			t.Fatalf("Failed to search POIs: %v", err)
 		}
@@ -173,7 +318,7 @@ func TestPOIRepository_Search(t *testing.T) {
 
 	t.Run("Search POIs with category filter", func(t *testing.T) {
 		categories := []string{"restaurant", "cafe"}
-		pois, err := repo.Search(ctx, "a", categories, 5)
+		pois, err := repo.Search(ctx, "a", categories, domain.POITagFilter{}, 5)
 		if err != nil {
 			t.Fatalf("Failed to search POIs with filter: %v", err)
 		}
@@ -184,7 +329,7 @@ func TestPOIRepository_Search(t *testing.T) {
 	})
 
 	t.Run("Search POIs respects limit", func(t *testing.T) {
-		pois, err := repo.Search(ctx, "a", nil, 3)
+		pois, err := repo.Search(ctx, "a", nil, domain.POITagFilter{}, 3)
 		if err != nil {
 			t.Fatalf("Failed to search POIs: %v", err)
 		}
@@ -195,7 +340,7 @@ func TestPOIRepository_Search(t *testing.T) {
 	})
 
 	t.Run("Search POIs with default limit", func(t *testing.T) {
-		pois, err := repo.Search(ctx, "a", nil, 0)
+		pois, err := repo.Search(ctx, "a", nil, domain.POITagFilter{}, 0)
 		if err != nil {
 			t.Fatalf("Failed to search POIs: %v", err)
 		}
@@ -211,7 +356,7 @@ func TestPOIRepository_GetByCategory(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewPOIRepository(db)
+	repo := NewPOIRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get POIs by category", func(t *testing.T) {
@@ -259,7 +404,7 @@ func TestPOIRepository_GetCategories(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewPOIRepository(db)
+	repo := NewPOIRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get all categories", func(t *testing.T) {
@@ -302,12 +447,59 @@ func TestPOIRepository_GetCategories(t *testing.T) {
 	})
 }
 
+func TestPOIRepository_GetCategoryTree(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewPOIRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	tree, err := repo.GetCategoryTree(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get category tree: %v", err)
+	}
+
+	categories, err := repo.GetCategories(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get categories: %v", err)
+	}
+	if len(tree) != len(categories) {
+		t.Fatalf("Expected %d categories in tree, got %d", len(categories), len(tree))
+	}
+
+	for i, node := range tree {
+		if node.Code != categories[i].Code || node.ID != categories[i].ID {
+			t.Errorf("Expected category %s (%d), got %s (%d)", categories[i].Code, categories[i].ID, node.Code, node.ID)
+		}
+
+		subcategories, err := repo.GetSubcategories(ctx, node.ID, nil)
+		if err != nil {
+			t.Fatalf("Failed to get subcategories: %v", err)
+		}
+		if len(node.Subcategories) != len(subcategories) {
+			t.Fatalf("Expected %d subcategories of %s, got %d", len(subcategories), node.Code, len(node.Subcategories))
+		}
+
+		sum := 0
+		for j, sub := range node.Subcategories {
+			if sub.Code != subcategories[j].Code || sub.POICount != subcategories[j].POICount {
+				t.Errorf("Subcategory %s/%s does not match GetSubcategories", node.Code, sub.Code)
+			}
+			sum += sub.POICount
+		}
+		if node.POICount != sum {
+			t.Errorf("Expected %s count %d, got %d", node.Code, sum, node.POICount)
+		}
+	}
+}
+
 func TestPOIRepository_GetSubcategories(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewPOIRepository(db)
+	repo := NewPOIRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get subcategories for category", func(t *testing.T) {
@@ -318,7 +510,7 @@ func TestPOIRepository_GetSubcategories(t *testing.T) {
 		}
 
 		categoryID := categories[0].ID
-		subcategories, err := repo.GetSubcategories(ctx, categoryID)
+		subcategories, err := repo.GetSubcategories(ctx, categoryID, nil)
 		if err != nil {
 			t.Fatalf("Failed to get subcategories: %v", err)
 		}
@@ -334,11 +526,42 @@ func TestPOIRepository_GetSubcategories(t *testing.T) {
 			if subcat.Code == "" {
 				t.Error("Expected non-empty subcategory code")
 			}
+			if subcat.POICount <= 0 {
+				t.Errorf("Expected positive POI count for %s, got %d", subcat.Code, subcat.POICount)
+			}
+		}
+	})
+
+	t.Run("Get subcategories counted within bbox", func(t *testing.T) {
+		categories, err := repo.GetCategories(ctx)
+		if err != nil || len(categories) == 0 {
+			t.Skipf("No categories available")
+		}
+
+		categoryID := categories[0].ID
+		all, err := repo.GetSubcategories(ctx, categoryID, nil)
+		if err != nil {
+			t.Fatalf("Failed to get subcategories: %v", err)
+		}
+		totals := make(map[string]int, len(all))
+		for _, subcat := range all {
+			totals[subcat.Code] = subcat.POICount
+		}
+
+		bbox := &domain.BoundingBox{MinLat: 41.3, MinLon: 2.0, MaxLat: 41.5, MaxLon: 2.3}
+		scoped, err := repo.GetSubcategories(ctx, categoryID, bbox)
+		if err != nil {
+			t.Fatalf("Failed to get subcategories in bbox: %v", err)
+		}
+		for _, subcat := range scoped {
+			if subcat.POICount > totals[subcat.Code] {
+				t.Errorf("Expected bbox count for %s <= %d, got %d", subcat.Code, totals[subcat.Code], subcat.POICount)
+			}
 		}
 	})
 
 	t.Run("Get subcategories for non-existing category", func(t *testing.T) {
-		_, err := repo.GetSubcategories(ctx, -99999999)
+		_, err := repo.GetSubcategories(ctx, -99999999, nil)
 		if err == nil {
 			t.Error("Expected error for non-existing category")
 		}
@@ -350,7 +573,7 @@ func TestPOIRepository_GetPOITile(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewPOIRepository(db)
+	repo := NewPOIRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get POI tile without category filter", func(t *testing.T) {
@@ -397,12 +620,64 @@ func TestPOIRepository_GetPOITile(t *testing.T) {
 	})
 }
 
+func TestPOIRepository_GetPOITileByCategories(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewPOIRepository(db, QueryOptions{})
+	ctx := context.Background()
+	z, x, y := 14, 8311, 6143
+
+	t.Run("Get POI tile with label attributes", func(t *testing.T) {
+		tile, err := repo.GetPOITileByCategories(ctx, z, x, y, []string{"leisure"}, nil, false, true, 0)
+		if err != nil {
+			t.Fatalf("Failed to get POI tile with labels: %v", err)
+		}
+
+		if tile == nil {
+			t.Error("Expected non-nil tile")
+		}
+	})
+}
+
+func TestPOIRepository_GetPOIsAlongPath(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewPOIRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("POIs are returned in travel order within buffer", func(t *testing.T) {
+		// Passeig de Gràcia: Plaça de Catalunya -> Diagonal
+		path := []domain.Coordinate{{Lat: 41.3870, Lon: 2.1700}, {Lat: 41.3960, Lon: 2.1610}}
+		bufferM := 150.0
+
+		pois, err := repo.GetPOIsAlongPath(ctx, path, bufferM, nil, 200)
+		if err != nil {
+			t.Fatalf("Failed to get POIs along path: %v", err)
+		}
+
+		prev := -1.0
+		for _, poi := range pois {
+			if poi.DistanceM == nil || *poi.DistanceM > bufferM {
+				t.Errorf("Expected distance within %.0fm, got %v", bufferM, poi.DistanceM)
+			}
+			if poi.PathOffsetM == nil || *poi.PathOffsetM < prev {
+				t.Fatalf("Expected non-decreasing path offset, got %v after %.1f", poi.PathOffsetM, prev)
+			}
+			prev = *poi.PathOffsetM
+		}
+	})
+}
+
 func TestPOIRepository_GetPOIRadiusTile(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewPOIRepository(db)
+	repo := NewPOIRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get POI radius tile", func(t *testing.T) {
@@ -453,7 +728,7 @@ func TestPOIRepository_GetPOIByBoundaryTile(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewPOIRepository(db)
+	repo := NewPOIRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get POI by boundary tile", func(t *testing.T) {
@@ -511,3 +786,98 @@ func TestPOIRepository_GetPOIByBoundaryTile(t *testing.T) {
 		}
 	})
 }
+
+func TestPOIRepository_GetNearestPerCategoryBatch(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewPOIRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	points := []domain.LatLon{
+		{Lat: 41.3851, Lon: 2.1734}, // Barcelona
+		{Lat: 0.0, Lon: 0.0},        // Ocean
+		{Lat: 41.4036, Lon: 2.1744}, // Sagrada Familia
+	}
+	categories := []string{"healthcare", "shopping"}
+
+	results, err := repo.GetNearestPerCategoryBatch(ctx, points, categories, 2)
+	if err != nil {
+		t.Fatalf("Failed to get nearest POI per category: %v", err)
+	}
+	if len(results) != len(points) {
+		t.Fatalf("Expected %d results, got %d", len(points), len(results))
+	}
+	if len(results[1]) != 0 {
+		t.Errorf("Expected no POIs in the ocean, got %d", len(results[1]))
+	}
+
+	for i, byCategory := range results {
+		for category, poi := range byCategory {
+			if poi.Category != category {
+				t.Errorf("Point %d: expected category %q, got %q", i, category, poi.Category)
+			}
+			if poi.LinearDistance > 2000 {
+				t.Errorf("Point %d: POI %d is %.0fm away, outside radius", i, poi.ID, poi.LinearDistance)
+			}
+
+			// Ближайший в батче совпадает с ближайшим по одиночному запросу
+			nearby, err := repo.GetNearby(ctx, points[i].Lat, points[i].Lon, 2, []string{category}, domain.POINearbyOptions{}, 0)
+			if err != nil || len(nearby) == 0 {
+				t.Fatalf("Point %d: expected nearby %s POIs, err=%v", i, category, err)
+			}
+			if nearby[0].DistanceM != nil && *nearby[0].DistanceM+0.01 < poi.LinearDistance {
+				t.Errorf("Point %d: batch %s POI at %.1fm, single query found %.1fm",
+					i, category, poi.LinearDistance, *nearby[0].DistanceM)
+			}
+		}
+	}
+}
+
+func TestPOIRepository_GetActivityCenter(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewPOIRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	// Центр Барселоны
+	bbox := domain.BoundingBox{MinLat: 41.37, MinLon: 2.15, MaxLat: 41.41, MaxLon: 2.19}
+
+	for _, weighted := range []bool{false, true} {
+		center, err := repo.GetActivityCenter(ctx, bbox, nil, weighted)
+		if err != nil {
+			t.Fatalf("Failed to get activity center (weighted=%v): %v", weighted, err)
+		}
+		if center.POICount == 0 {
+			t.Fatalf("Expected POIs in central Barcelona (weighted=%v)", weighted)
+		}
+		if center.Lat < bbox.MinLat || center.Lat > bbox.MaxLat || center.Lon < bbox.MinLon || center.Lon > bbox.MaxLon {
+			t.Errorf("Center %.5f,%.5f outside bbox (weighted=%v)", center.Lat, center.Lon, weighted)
+		}
+	}
+
+	// Фильтр категорий уменьшает число POI
+	all, err := repo.GetActivityCenter(ctx, bbox, nil, false)
+	if err != nil {
+		t.Fatalf("Failed to get activity center: %v", err)
+	}
+	healthcare, err := repo.GetActivityCenter(ctx, bbox, []string{"healthcare"}, false)
+	if err != nil {
+		t.Fatalf("Failed to get healthcare activity center: %v", err)
+	}
+	if healthcare.POICount > all.POICount {
+		t.Errorf("Expected healthcare POIs (%d) <= all POIs (%d)", healthcare.POICount, all.POICount)
+	}
+
+	// Океан - POI нет
+	empty, err := repo.GetActivityCenter(ctx, domain.BoundingBox{MinLat: -0.1, MinLon: -0.1, MaxLat: 0.1, MaxLon: 0.1}, nil, false)
+	if err != nil {
+		t.Fatalf("Failed to get activity center in the ocean: %v", err)
+	}
+	if empty.POICount != 0 {
+		t.Errorf("Expected no POIs in the ocean, got %d", empty.POICount)
+	}
+}
@@ -0,0 +1,40 @@
+package postgresosm
+
+import "fmt"
+
+// QueryOptions - настройки построения SQL репозиториев OSM из конфига. Передаются в конструкторы
+// репозиториев: разные репозитории (и тесты) могут работать с разными настройками.
+// Нулевое значение - настройки по умолчанию.
+type QueryOptions struct {
+	// IncludeInactiveFeatures включает в выдачу закрытые, заброшенные и строящиеся объекты
+	IncludeInactiveFeatures bool
+	// IncludeRestrictedBeaches включает в выдачу пляжей рядом с точкой частные и закрытые пляжи
+	IncludeRestrictedBeaches bool
+
+	// SimplifiedContains включает проверку принадлежности точки крупным границам
+	// (admin_level <= SimplifiedMaxLevel) по упрощенной геометрии way_simplified (scripts/post-import.sql):
+	// ST_Contains по полигону страны с сотнями тысяч вершин - самая дорогая часть обратного геокодирования.
+	// Более мелкие уровни всегда проверяются по точной геометрии.
+	SimplifiedContains bool
+	SimplifiedMaxLevel int // 0 - по умолчанию (4), допустимо 2-6
+}
+
+// Validate проверяет настройки и подставляет значения по умолчанию
+func (o QueryOptions) Validate() (QueryOptions, error) {
+	if o.SimplifiedMaxLevel == 0 {
+		o.SimplifiedMaxLevel = defaultSimplifiedMaxLevel
+	}
+	if o.SimplifiedMaxLevel < 2 || o.SimplifiedMaxLevel > simplifiedBuiltMaxLevel {
+		return o, fmt.Errorf("simplified geometry max admin level must be between 2 and %d, got %d",
+			simplifiedBuiltMaxLevel, o.SimplifiedMaxLevel)
+	}
+	return o, nil
+}
+
+// simplifiedMaxLevel возвращает максимальный уровень границ для упрощенной геометрии
+func (o QueryOptions) simplifiedMaxLevel() int {
+	if o.SimplifiedMaxLevel == 0 {
+		return defaultSimplifiedMaxLevel
+	}
+	return o.SimplifiedMaxLevel
+}
@@ -0,0 +1,121 @@
+package postgresosm
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Ограничения сводки запроса в логе
+const (
+	slowQueryMaxSQLLen  = 300 // символов SQL
+	slowQueryMaxArgs    = 10  // параметров
+	slowQueryMaxArgLen  = 64  // символов одного параметра
+	slowQueryStackDepth = 32  // кадров стека при поиске метода репозитория
+)
+
+// slowQueryTracer логирует запросы дольше порога на уровне WARN с методом репозитория,
+// сводкой параметров и длительностью. Быстрые запросы - DEBUG, если он включен; threshold <= 0 - все DEBUG.
+type slowQueryTracer struct {
+	threshold time.Duration
+	logger    *zap.Logger
+}
+
+type slowQueryStartKey struct{}
+
+type slowQueryStart struct {
+	at   time.Time
+	sql  string
+	args []any
+}
+
+func newSlowQueryTracer(threshold time.Duration, logger *zap.Logger) *slowQueryTracer {
+	return &slowQueryTracer{threshold: threshold, logger: logger}
+}
+
+func (t *slowQueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, slowQueryStartKey{}, slowQueryStart{at: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+// TraceQueryEnd вызывается в той же горутине, что и метод репозитория (для Query - при rows.Close),
+// поэтому метод определяется по стеку, и только когда запрос будет залогирован.
+func (t *slowQueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(slowQueryStartKey{}).(slowQueryStart)
+	if !ok {
+		return
+	}
+	duration := time.Since(start.at)
+
+	level, msg := zapcore.DebugLevel, "OSM query finished"
+	if t.threshold > 0 && duration >= t.threshold {
+		level, msg = zapcore.WarnLevel, "Slow OSM query"
+	}
+	ce := t.logger.Check(level, msg)
+	if ce == nil {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.String("method", repositoryMethod()),
+		zap.Duration("duration", duration),
+		zap.String("sql", summarizeSQL(start.sql)),
+		zap.Strings("args", summarizeArgs(start.args)),
+	}
+	if data.Err != nil {
+		fields = append(fields, zap.Error(data.Err))
+	}
+	ce.Write(fields...)
+}
+
+// repositoryMethod возвращает первый метод репозитория postgresosm в стеке вызовов,
+// например "(*poiRepository).GetPOITile"
+func repositoryMethod() string {
+	pcs := make([]uintptr, slowQueryStackDepth)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if i := strings.Index(frame.Function, "/repository/postgresosm.(*"); i >= 0 {
+			return frame.Function[i+len("/repository/postgresosm."):]
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// summarizeSQL схлопывает пробелы и обрезает SQL до slowQueryMaxSQLLen символов
+func summarizeSQL(sql string) string {
+	s := strings.Join(strings.Fields(sql), " ")
+	if r := []rune(s); len(r) > slowQueryMaxSQLLen {
+		s = string(r[:slowQueryMaxSQLLen]) + "..."
+	}
+	return s
+}
+
+// summarizeArgs форматирует первые slowQueryMaxArgs параметров, обрезая длинные значения
+func summarizeArgs(args []any) []string {
+	n := len(args)
+	if n > slowQueryMaxArgs {
+		n = slowQueryMaxArgs
+	}
+
+	result := make([]string, 0, n+1)
+	for _, arg := range args[:n] {
+		s := fmt.Sprintf("%v", arg)
+		if r := []rune(s); len(r) > slowQueryMaxArgLen {
+			s = string(r[:slowQueryMaxArgLen]) + "..."
+		}
+		result = append(result, s)
+	}
+	if len(args) > n {
+		result = append(result, fmt.Sprintf("... +%d more", len(args)-n))
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package postgresosm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// traceQuery прогоняет запрос через трейсер, сдвигая время старта на duration назад
+func traceQuery(tracer *slowQueryTracer, duration time.Duration, sql string, args ...any) {
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql, Args: args})
+	start := ctx.Value(slowQueryStartKey{}).(slowQueryStart)
+	start.at = start.at.Add(-duration)
+	ctx = context.WithValue(ctx, slowQueryStartKey{}, start)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+}
+
+func TestSlowQueryTracer(t *testing.T) {
+	t.Run("slow query logged at warn", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		tracer := newSlowQueryTracer(100*time.Millisecond, zap.New(core))
+
+		traceQuery(tracer, 200*time.Millisecond, "SELECT  *\n FROM planet_osm_point WHERE osm_id = $1", int64(42))
+
+		require.Equal(t, 1, logs.Len())
+		entry := logs.All()[0]
+		assert.Equal(t, zapcore.WarnLevel, entry.Level)
+		fields := entry.ContextMap()
+		assert.Equal(t, "SELECT * FROM planet_osm_point WHERE osm_id = $1", fields["sql"])
+		assert.Equal(t, []interface{}{"42"}, fields["args"])
+		assert.NotEmpty(t, fields["method"])
+		assert.GreaterOrEqual(t, fields["duration"], 200*time.Millisecond)
+	})
+
+	t.Run("fast query not logged above debug", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		tracer := newSlowQueryTracer(time.Second, zap.New(core))
+
+		traceQuery(tracer, time.Millisecond, "SELECT 1")
+
+		assert.Zero(t, logs.Len())
+	})
+
+	t.Run("fast query logged at debug", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		tracer := newSlowQueryTracer(time.Second, zap.New(core))
+
+		traceQuery(tracer, time.Millisecond, "SELECT 1")
+
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, zapcore.DebugLevel, logs.All()[0].Level)
+	})
+
+	t.Run("negative threshold disables warn", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		tracer := newSlowQueryTracer(-1, zap.New(core))
+
+		traceQuery(tracer, time.Minute, "SELECT 1")
+
+		assert.Zero(t, logs.Len())
+	})
+}
+
+func TestSlowQuerySummaries(t *testing.T) {
+	long := strings.Repeat("x", slowQueryMaxSQLLen+10)
+	assert.Equal(t, slowQueryMaxSQLLen+3, len(summarizeSQL(long)))
+
+	args := make([]any, slowQueryMaxArgs+2)
+	for i := range args {
+		args[i] = strings.Repeat("a", slowQueryMaxArgLen+1)
+	}
+	summary := summarizeArgs(args)
+	require.Len(t, summary, slowQueryMaxArgs+1)
+	assert.Equal(t, strings.Repeat("a", slowQueryMaxArgLen)+"...", summary[0])
+	assert.Equal(t, "... +2 more", summary[slowQueryMaxArgs])
+}
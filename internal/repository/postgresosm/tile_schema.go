@@ -0,0 +1,210 @@
+package postgresosm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/location-microservice/internal/domain"
+)
+
+// Слои тайлов транспорта; остальные слои - domain.TileLayer*
+const (
+	tileLayerStations          = "stations"
+	tileLayerTransportStations = "transport_stations"
+	tileLayerLines             = "lines"
+	tileLayerLine              = "line"
+	tileLayerTransportLines    = "transport_lines"
+)
+
+// tileAttribute - атрибут слоя MVT вместе с SQL выражением над строкой таблицы planet_osm_* слоя
+type tileAttribute struct {
+	domain.TileAttributeSchema
+	Expr string
+}
+
+// tileLayer - описание слоя MVT. Запросы тайлов берут списки колонок из tileLayers через
+// tileAttributeColumns/tileAttributeNames, а GET /api/v1/schema отдает ту же схему клиентам,
+// поэтому атрибуты тайлов и схема не расходятся.
+type tileLayer struct {
+	Name       string
+	Geometry   string
+	Attributes []tileAttribute
+}
+
+func tileAttr(name string, typ domain.TileAttributeType, expr, description string) tileAttribute {
+	return tileAttribute{
+		TileAttributeSchema: domain.TileAttributeSchema{Name: name, Type: typ, Description: description},
+		Expr:                expr,
+	}
+}
+
+func conditionalTileAttr(condition, name string, typ domain.TileAttributeType, expr, description string) tileAttribute {
+	a := tileAttr(name, typ, expr, description)
+	a.Condition = condition
+	return a
+}
+
+func nameTagAttr(lang string) tileAttribute {
+	return tileAttr("name_"+lang, domain.TileAttributeString,
+		fmt.Sprintf("COALESCE(NULLIF(tags->'name:%s', ''), '')", lang), "Название на языке "+lang+", пусто - нет перевода")
+}
+
+var (
+	stationTileAttributes = []tileAttribute{
+		tileAttr("id", domain.TileAttributeInteger, "osm_id", "osm_id остановки"),
+		tileAttr("name", domain.TileAttributeString, "COALESCE(name, '')", "Название"),
+		tileAttr("type", domain.TileAttributeString, `CASE
+					WHEN railway = 'station' AND (tags->'station' = 'subway' OR tags->'subway' = 'yes') THEN 'subway'
+					WHEN railway = 'tram_stop' OR (railway = 'station' AND tags->'station' = 'light_rail') THEN 'tram_stop'
+					WHEN highway = 'bus_stop' OR (public_transport IN ('platform', 'stop_position') AND tags->'bus' = 'yes') THEN 'bus_stop'
+					WHEN railway IN ('station', 'halt') THEN 'station'
+					WHEN public_transport IS NOT NULL THEN COALESCE(NULLIF(public_transport, ''), 'stop')
+					ELSE 'station'
+				END`, "subway, tram_stop, bus_stop, station или значение public_transport"),
+	}
+
+	// transportStationTileAttributes - слой тайла радиуса GetTransportRadiusTile: type остается сырым
+	// значением public_transport/railway, как до появления общей схемы, чтобы не сломать клиентов слоя
+	transportStationTileAttributes = []tileAttribute{
+		tileAttr("id", domain.TileAttributeInteger, "osm_id", "osm_id остановки"),
+		tileAttr("name", domain.TileAttributeString, "COALESCE(name, '')", "Название"),
+		tileAttr("type", domain.TileAttributeString, "COALESCE(NULLIF(public_transport, ''), NULLIF(railway, ''), 'station')",
+			"Значение public_transport или railway: station, platform, stop_position, halt, ..."),
+	}
+
+	lineTileAttributes = []tileAttribute{
+		tileAttr("id", domain.TileAttributeInteger, "osm_id", "osm_id маршрута"),
+		tileAttr("name", domain.TileAttributeString, "COALESCE(name, '')", "Название"),
+		tileAttr("ref", domain.TileAttributeString, "COALESCE(ref, '')", "Номер линии (L1, 24, ...)"),
+		tileAttr("type", domain.TileAttributeString, "COALESCE(route, '')", "Значение тега route: subway, bus, tram, train, ..."),
+		tileAttr("color", domain.TileAttributeString, "COALESCE(tags->'colour', '')", "Цвет линии из тега colour, пусто - не задан"),
+	}
+
+	tileLayers = []tileLayer{
+		{Name: string(domain.TileLayerBoundaries), Geometry: "polygon", Attributes: []tileAttribute{
+			tileAttr("osm_id", domain.TileAttributeInteger, "osm_id", "osm_id границы"),
+			tileAttr("name", domain.TileAttributeString, "COALESCE(name, '')", "Название"),
+			nameTagAttr("en"),
+			nameTagAttr("es"),
+			nameTagAttr("ca"),
+			nameTagAttr("ru"),
+			tileAttr("wikidata", domain.TileAttributeString, "COALESCE(NULLIF(tags->'wikidata', ''), '')", "Идентификатор Wikidata, пусто - нет"),
+			tileAttr("boundary_type", domain.TileAttributeString, "COALESCE(boundary, 'administrative')", "Значение тега boundary"),
+			tileAttr("admin_level", domain.TileAttributeInteger, "(admin_level)::integer", "Административный уровень OSM (2 - страна, 8 - город)"),
+			tileAttr("population", domain.TileAttributeInteger, "COALESCE((tags->'population')::bigint, 0)", "Население, 0 - неизвестно"),
+		}},
+		{Name: string(domain.TileLayerGreenSpaces), Geometry: "polygon", Attributes: []tileAttribute{
+			tileAttr("id", domain.TileAttributeInteger, "osm_id", "osm_id объекта"),
+			tileAttr("name", domain.TileAttributeString, "COALESCE(name, '')", "Название"),
+			tileAttr("type", domain.TileAttributeString, "COALESCE(NULLIF(leisure, ''), NULLIF(landuse, ''), 'park')", "Значение leisure или landuse: park, garden, forest, ..."),
+			tileAttr("area_sq_m", domain.TileAttributeNumber, fmt.Sprintf("ST_Area(ST_Transform(way, %d)::geography)", SRID4326), "Площадь, м²"),
+		}},
+		{Name: string(domain.TileLayerWater), Geometry: "polygon", Attributes: []tileAttribute{
+			tileAttr("id", domain.TileAttributeInteger, "osm_id", "osm_id объекта"),
+			tileAttr("name", domain.TileAttributeString, "COALESCE(name, '')", "Название"),
+			tileAttr("type", domain.TileAttributeString, `COALESCE(NULLIF("natural", ''), NULLIF(waterway, ''), NULLIF("water", ''), 'water')`, "Значение natural, waterway или water"),
+			tileAttr("area_sq_m", domain.TileAttributeNumber, fmt.Sprintf("ST_Area(ST_Transform(way, %d)::geography)", SRID4326), "Площадь, м²"),
+		}},
+		{Name: string(domain.TileLayerBeaches), Geometry: "polygon", Attributes: []tileAttribute{
+			tileAttr("id", domain.TileAttributeInteger, "osm_id", "osm_id пляжа"),
+			tileAttr("name", domain.TileAttributeString, "COALESCE(name, '')", "Название"),
+			tileAttr("surface", domain.TileAttributeString, "COALESCE(tags->'surface', '')", "Покрытие: sand, pebbles, ..."),
+			tileAttr("width_m", domain.TileAttributeNumber, fmt.Sprintf("ST_Length(ST_Transform(way, %d)::geography)", SRID4326), "Длина контура, м"),
+		}},
+		{Name: string(domain.TileLayerNoiseSources), Geometry: "polygon", Attributes: []tileAttribute{
+			tileAttr("id", domain.TileAttributeInteger, "osm_id", "osm_id объекта"),
+			tileAttr("name", domain.TileAttributeString, "COALESCE(name, '')", "Название"),
+			tileAttr("type", domain.TileAttributeString, `CASE
+					WHEN aeroway IS NOT NULL THEN 'airport'
+					WHEN landuse = 'industrial' THEN 'industrial'
+					WHEN highway IN ('motorway', 'trunk', 'primary') THEN 'highway'
+					WHEN railway IS NOT NULL THEN 'railway'
+					ELSE 'other'
+				END`, "airport, industrial, highway, railway, other"),
+			tileAttr("noise_level", domain.TileAttributeString, `CASE
+					WHEN aeroway IS NOT NULL THEN 'high'
+					WHEN landuse = 'industrial' THEN 'medium'
+					WHEN highway IN ('motorway', 'trunk') THEN 'high'
+					WHEN highway = 'primary' THEN 'medium'
+					WHEN railway IS NOT NULL THEN 'medium'
+					ELSE 'low'
+				END`, "high, medium, low"),
+		}},
+		{Name: string(domain.TileLayerTouristZones), Geometry: "polygon", Attributes: []tileAttribute{
+			tileAttr("id", domain.TileAttributeInteger, "osm_id", "osm_id объекта"),
+			tileAttr("name", domain.TileAttributeString, "COALESCE(name, '')", "Название"),
+			tileAttr("type", domain.TileAttributeString, "COALESCE(tourism, '')", "Значение tourism: attraction, museum, zoo, ..."),
+		}},
+		{Name: string(domain.TileLayerPOI), Geometry: "point", Attributes: []tileAttribute{
+			tileAttr("id", domain.TileAttributeInteger, "osm_id", "osm_id POI; у кластера - минимальный osm_id"),
+			tileAttr("name", domain.TileAttributeString, "name", "Название; у кластера из нескольких POI пусто"),
+			tileAttr("category", domain.TileAttributeString, "category", "Категория; у кластера - самая частая"),
+			tileAttr("subcategory", domain.TileAttributeString, "subcategory", "Подкатегория; у кластера из нескольких POI пусто"),
+			conditionalTileAttr(domain.TileConditionWithLabels, "rank", domain.TileAttributeInteger, poiTileRankExpr, "Приоритет подписи, больше - важнее"),
+			conditionalTileAttr(domain.TileConditionWithLabels, "min_zoom", domain.TileAttributeInteger, poiTileMinZoomExpr, "Минимальный зум показа подписи"),
+			conditionalTileAttr(domain.TileConditionClustered, "point_count", domain.TileAttributeInteger, "COUNT(*)", "Число POI в кластере"),
+		}},
+		{Name: tileLayerStations, Geometry: "point", Attributes: stationTileAttributes},
+		{Name: tileLayerTransportStations, Geometry: "point", Attributes: transportStationTileAttributes},
+		{Name: tileLayerLines, Geometry: "line", Attributes: lineTileAttributes},
+		{Name: tileLayerLine, Geometry: "line", Attributes: lineTileAttributes},
+		{Name: tileLayerTransportLines, Geometry: "line", Attributes: lineTileAttributes},
+	}
+)
+
+// tileLayerAttributes возвращает атрибуты слоя, присутствующие при условиях conditions.
+// У неизвестного слоя атрибутов нет: запросы тайлов ссылаются только на слои из tileLayers
+// (TestMVTLayersHaveSchema), а тайл без атрибутов лучше паники в обработчике запроса.
+func tileLayerAttributes(layer string, conditions []string) []tileAttribute {
+	for _, l := range tileLayers {
+		if l.Name != layer {
+			continue
+		}
+
+		attrs := make([]tileAttribute, 0, len(l.Attributes))
+		for _, a := range l.Attributes {
+			if a.Condition == "" || slices.Contains(conditions, a.Condition) {
+				attrs = append(attrs, a)
+			}
+		}
+		return attrs
+	}
+	return nil
+}
+
+// tileAttributeColumns возвращает колонки "выражение AS имя," атрибутов слоя для SELECT
+// над строками planet_osm_*; список заканчивается запятой перед колонкой геометрии.
+func tileAttributeColumns(layer string, conditions ...string) string {
+	var b strings.Builder
+	for _, a := range tileLayerAttributes(layer, conditions) {
+		fmt.Fprintf(&b, "%s AS %s,\n\t\t\t\t", a.Expr, a.Name)
+	}
+	return b.String()
+}
+
+// tileAttributeNames возвращает имена атрибутов слоя через запятую (с префиксом alias, если задан)
+// для CTE, которые передают уже вычисленные атрибуты дальше; список заканчивается запятой.
+func tileAttributeNames(layer, alias string, conditions ...string) string {
+	var b strings.Builder
+	for _, a := range tileLayerAttributes(layer, conditions) {
+		if alias != "" {
+			b.WriteString(alias + ".")
+		}
+		b.WriteString(a.Name + ",\n\t\t\t\t")
+	}
+	return b.String()
+}
+
+// TileLayerSchemas возвращает схему всех слоев MVT для GET /api/v1/schema
+func TileLayerSchemas() []domain.TileLayerSchema {
+	schemas := make([]domain.TileLayerSchema, 0, len(tileLayers))
+	for _, l := range tileLayers {
+		attrs := make([]domain.TileAttributeSchema, 0, len(l.Attributes))
+		for _, a := range l.Attributes {
+			attrs = append(attrs, a.TileAttributeSchema)
+		}
+		schemas = append(schemas, domain.TileLayerSchema{Name: l.Name, Geometry: l.Geometry, Attributes: attrs})
+	}
+	return schemas
+}
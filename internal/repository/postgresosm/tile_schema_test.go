@@ -0,0 +1,79 @@
+package postgresosm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/location-microservice/internal/domain"
+)
+
+func TestTileSchemaAttributes(t *testing.T) {
+	for _, layer := range TileLayerSchemas() {
+		if layer.Geometry == "" || len(layer.Attributes) == 0 {
+			t.Errorf("layer %s: expected geometry and attributes", layer.Name)
+		}
+		seen := make(map[string]bool)
+		for _, a := range layer.Attributes {
+			if seen[a.Name] {
+				t.Errorf("layer %s: attribute %s listed twice", layer.Name, a.Name)
+			}
+			seen[a.Name] = true
+			if a.Type == "" || a.Description == "" {
+				t.Errorf("layer %s: attribute %s without type or description", layer.Name, a.Name)
+			}
+		}
+	}
+
+	columns := tileAttributeColumns(string(domain.TileLayerPOI))
+	if !strings.Contains(columns, "osm_id AS id,") || strings.Contains(columns, "AS rank") || strings.Contains(columns, "point_count") {
+		t.Errorf("unexpected default poi columns: %s", columns)
+	}
+	if columns := tileAttributeColumns(string(domain.TileLayerPOI), domain.TileConditionWithLabels); !strings.Contains(columns, "AS min_zoom,") {
+		t.Errorf("expected label columns with labels: %s", columns)
+	}
+	if columns := tileAttributeColumns("transport_stations"); !strings.Contains(columns, "NULLIF(railway, ''), 'station') AS type,") {
+		t.Errorf("transport_stations must keep the raw public_transport/railway type: %s", columns)
+	}
+	if names := tileAttributeNames("line", "ld"); !strings.HasPrefix(names, "ld.id,") || !strings.Contains(names, "ld.color,") {
+		t.Errorf("unexpected aliased names: %s", names)
+	}
+}
+
+// TestTilePOIClusterColumnsMatchSchema - кластеры POI собираются агрегатами, а не из выражений схемы,
+// поэтому набор их колонок сверяется со схемой слоя pois отдельно
+func TestTilePOIClusterColumnsMatchSchema(t *testing.T) {
+	clusterSQL := poiClusterFeaturesSQL(8, true, 100)
+	for _, a := range tileLayerAttributes(string(domain.TileLayerPOI), []string{domain.TileConditionWithLabels, domain.TileConditionClustered}) {
+		if !strings.Contains(clusterSQL, " AS "+a.Name+",") {
+			t.Errorf("cluster features miss attribute %s", a.Name)
+		}
+	}
+}
+
+// TestMVTLayersHaveSchema проверяет, что каждый слой, который собирают запросы тайлов, описан в схеме
+func TestMVTLayersHaveSchema(t *testing.T) {
+	layers := []string{tileLayerStations, tileLayerTransportStations, tileLayerLines, tileLayerLine, tileLayerTransportLines}
+	for _, l := range []domain.TileLayer{
+		domain.TileLayerBoundaries, domain.TileLayerGreenSpaces, domain.TileLayerWater, domain.TileLayerBeaches,
+		domain.TileLayerNoiseSources, domain.TileLayerTouristZones, domain.TileLayerPOI,
+	} {
+		layers = append(layers, string(l))
+	}
+
+	schemas := make(map[string]domain.TileLayerSchema)
+	for _, schema := range TileLayerSchemas() {
+		schemas[schema.Name] = schema
+	}
+	for _, layer := range layers {
+		if _, ok := schemas[layer]; !ok {
+			t.Errorf("layer %q is not described in tileLayers", layer)
+		}
+		if tileAttributeColumns(layer) == "" {
+			t.Errorf("layer %q has no tile columns", layer)
+		}
+	}
+
+	if attrs := tileLayerAttributes("unknown", nil); len(attrs) != 0 {
+		t.Errorf("unknown layer must have no attributes, got %v", attrs)
+	}
+}
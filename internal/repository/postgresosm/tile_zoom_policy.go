@@ -0,0 +1,153 @@
+package postgresosm
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/lib/pq"
+	"github.com/location-microservice/internal/domain"
+)
+
+// poiClusterCellPx - размер ячейки кластеризации POI в пикселях 256px тайла
+const poiClusterCellPx = 64
+
+// tileZoomPolicy - политика содержимого тайлов по зумам, общая для всех тайловых запросов.
+// Задается при старте через ConfigureTileZoomPolicy.
+var tileZoomPolicy = domain.DefaultTileZoomPolicy()
+
+// ConfigureTileZoomPolicy подключает политику тайлов из конфига.
+// Вызывается один раз при старте, до создания репозиториев.
+func ConfigureTileZoomPolicy(policy domain.TileZoomPolicy) {
+	tileZoomPolicy = policy
+}
+
+// areaLayerSimplification - упрощение площадных слоев (зеленые зоны, вода) в тайлах
+type areaLayerSimplification struct {
+	simplifyPx float64 // допуск упрощения в пикселях; <= 0 - допуск политики tileZoomPolicy
+	minAreaPx  float64 // полигоны площадью меньше minAreaPx квадратных пикселей после упрощения отбрасываются
+}
+
+// areaLayerSimplifications - упрощение по слоям. Задается при старте через ConfigureAreaLayerSimplification.
+var areaLayerSimplifications = defaultAreaLayerSimplifications()
+
+// defaultAreaLayerSimplifications - допуск политики, полигоны меньше пикселя не попадают в тайл
+func defaultAreaLayerSimplifications() map[domain.TileLayer]areaLayerSimplification {
+	return map[domain.TileLayer]areaLayerSimplification{
+		domain.TileLayerGreenSpaces: {minAreaPx: 1},
+		domain.TileLayerWater:       {minAreaPx: 1},
+	}
+}
+
+// ConfigureAreaLayerSimplification подключает допуски упрощения и пороги площади слоев из конфига:
+// слой -> пиксели. Слои без значения сохраняют значения по умолчанию.
+// Вызывается один раз при старте, до создания репозиториев.
+func ConfigureAreaLayerSimplification(simplifyPx, minAreaPx map[string]string) error {
+	result := defaultAreaLayerSimplifications()
+	apply := func(raw map[string]string, set func(*areaLayerSimplification, float64)) error {
+		for name, value := range raw {
+			layer := domain.TileLayer(name)
+			settings, ok := result[layer]
+			if !ok {
+				return fmt.Errorf("area layer simplification: unknown layer %q", name)
+			}
+			px, err := strconv.ParseFloat(value, 64)
+			if err != nil || px < 0 {
+				return fmt.Errorf("area layer simplification: invalid value %q for layer %q", value, name)
+			}
+			set(&settings, px)
+			result[layer] = settings
+		}
+		return nil
+	}
+
+	if err := apply(simplifyPx, func(s *areaLayerSimplification, px float64) { s.simplifyPx = px }); err != nil {
+		return err
+	}
+	if err := apply(minAreaPx, func(s *areaLayerSimplification, px float64) { s.minAreaPx = px }); err != nil {
+		return err
+	}
+
+	areaLayerSimplifications = result
+	return nil
+}
+
+// poiZoomCategories - какие подкатегории POI видны в тайлах на каждом зуме.
+// Задается при старте через ConfigurePOIZoomCategories.
+var poiZoomCategories = domain.DefaultPOIZoomCategories()
+
+// ConfigurePOIZoomCategories подключает политику подкатегорий POI по зумам из конфига.
+// Вызывается один раз при старте, до создания репозиториев.
+func ConfigurePOIZoomCategories(policy domain.POIZoomCategories) {
+	poiZoomCategories = policy
+}
+
+// poiZoomCategoryFilter добавляет в args подкатегории, видимые на зуме по poiZoomCategories,
+// и возвращает условие для CTE data. visible=false - на зуме не видно ни одного POI.
+func poiZoomCategoryFilter(z int, args []interface{}) (clause string, _ []interface{}, visible bool) {
+	subcategories, filtered := poiZoomCategories.SubcategoriesFor(z)
+	if !filtered {
+		return "", args, true
+	}
+	if len(subcategories) == 0 {
+		return "", args, false
+	}
+
+	args = append(args, pq.Array(subcategories))
+	return fmt.Sprintf(" AND subcategory = ANY($%d)", len(args)), args, true
+}
+
+// tileMetersPerPixel возвращает размер пикселя 256px тайла на зуме (метры EPSG:3857)
+func tileMetersPerPixel(zoom int) float64 {
+	return webMercatorMetersPerPixelZ0 / math.Exp2(float64(zoom))
+}
+
+// tileGeomExpr оборачивает колонку геометрии (EPSG:3857) в упрощение с допуском политики для зума
+func tileGeomExpr(column string, zoom int) string {
+	px := tileZoomPolicy.SimplifyPx(zoom)
+	if px <= 0 {
+		return column
+	}
+	return fmt.Sprintf("ST_SimplifyPreserveTopology(%s, %g)", column, px*tileMetersPerPixel(zoom))
+}
+
+// areaLayerTileGeom возвращает выражение упрощенной геометрии площадного слоя для зума и минимальную
+// площадь полигона после упрощения (м² EPSG:3857) - порог слоя в квадратных пикселях этого зума.
+// Упрощается вся геометрия до обрезки по тайлу, поэтому соседние тайлы получают одинаковые границы.
+// Порог площади к линиям (береговая линия, водотоки) не применяется, а ST_SimplifyPreserveTopology
+// сохраняет их концы - смежные участки береговой линии стыкуются без разрывов.
+func areaLayerTileGeom(layer domain.TileLayer, column string, zoom int) (geom string, minArea float64) {
+	settings := areaLayerSimplifications[layer]
+	metersPerPixel := tileMetersPerPixel(zoom)
+
+	geom = tileGeomExpr(column, zoom)
+	if settings.simplifyPx > 0 {
+		geom = fmt.Sprintf("ST_SimplifyPreserveTopology(%s, %g)", column, settings.simplifyPx*metersPerPixel)
+	}
+	return geom, max(settings.minAreaPx, 0) * metersPerPixel * metersPerPixel
+}
+
+// poiClusterFeaturesSQL возвращает SELECT для CTE mvt_geom тайла POI с кластеризацией:
+// точки из data группируются по сетке poiClusterCellPx пикселей, у кластера - число точек
+// и преобладающая категория, название и подкатегория - только у одиночных точек.
+func poiClusterFeaturesSQL(zoom int, withLabels bool, limit int) string {
+	labelColumns := ""
+	if withLabels {
+		labelColumns = fmt.Sprintf("MAX(%s) AS rank,\n\t\t\t\tMIN(%s) AS min_zoom,", poiTileRankExpr, poiTileMinZoomExpr)
+	}
+
+	return fmt.Sprintf(`
+			SELECT
+				MIN(osm_id) AS id,
+				CASE WHEN COUNT(*) = 1 THEN MIN(name) ELSE '' END AS name,
+				MODE() WITHIN GROUP (ORDER BY category) AS category,
+				CASE WHEN COUNT(*) = 1 THEN MIN(subcategory) ELSE '' END AS subcategory,
+				COUNT(*) AS point_count,
+				%s
+				ST_AsMVTGeom(ST_Centroid(ST_Collect(way)), bounds.geom, $4, $5, true) AS geom
+			FROM data, bounds
+			WHERE way && bounds.geom
+			GROUP BY ST_SnapToGrid(way, %g), bounds.geom
+			ORDER BY point_count DESC
+			LIMIT %d`, labelColumns, poiClusterCellPx*tileMetersPerPixel(zoom), limit)
+}
@@ -0,0 +1,108 @@
+package postgresosm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/location-microservice/internal/domain/repository"
+	pkgerrors "github.com/location-microservice/internal/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// timezoneTable - полигоны часовых поясов timezone-boundary-builder с колонкой tzid,
+// импортированные в OSM базу (например, shp2pgsql или ogr2ogr). Таблица необязательна.
+const timezoneTable = "timezones"
+
+type timezoneRepository struct {
+	db *sqlx.DB
+	// tableQuery - запрос к timezoneTable, пусто - таблица не импортирована
+	tableQuery string
+	logger     *zap.Logger
+}
+
+// NewTimezoneRepository создает репозиторий часовых поясов и определяет при старте колонку геометрии
+// и SRID таблицы timezones. Без таблицы пояс берется из тега timezone административных границ.
+func NewTimezoneRepository(ctx context.Context, db *DB) (repository.TimezoneRepository, error) {
+	r := &timezoneRepository{
+		db:     db.DB,
+		logger: db.logger,
+	}
+
+	var column string
+	var srid int
+	err := r.db.QueryRowxContext(ctx, `
+		SELECT f_geometry_column, srid
+		FROM geometry_columns
+		WHERE f_table_schema = ANY(current_schemas(false))
+		  AND f_table_name = $1
+		LIMIT 1
+	`, timezoneTable).Scan(&column, &srid)
+	switch {
+	case err == sql.ErrNoRows:
+		r.logger.Info("Timezone table not found, using timezone tags of OSM boundaries",
+			zap.String("table", timezoneTable))
+	case err != nil:
+		return nil, fmt.Errorf("failed to detect timezone table: %w", err)
+	default:
+		r.tableQuery = timezoneTableQuery(column, srid)
+		r.logger.Info("Timezone table detected",
+			zap.String("table", timezoneTable),
+			zap.String("geometry_column", column),
+			zap.Int("srid", srid))
+	}
+
+	return r, nil
+}
+
+// timezoneTableQuery строит point-in-polygon запрос к таблице часовых поясов с геометрией column в SRID srid
+func timezoneTableQuery(column string, srid int) string {
+	return fmt.Sprintf(`
+		SELECT tzid
+		FROM %s
+		WHERE ST_Intersects(%s, ST_Transform(ST_SetSRID(ST_MakePoint($2, $1), %d), %d))
+		LIMIT 1
+	`, timezoneTable, pq.QuoteIdentifier(column), SRID4326, srid)
+}
+
+// timezoneBoundaryQuery - тег timezone самой детальной административной границы, содержащей точку
+// (страна или регион с собственным поясом, например Канарские острова)
+var timezoneBoundaryQuery = fmt.Sprintf(`
+	SELECT tags->'timezone'
+	FROM %s
+	WHERE boundary = 'administrative'
+	  AND admin_level IS NOT NULL
+	  AND NULLIF(tags->'timezone', '') IS NOT NULL
+	  AND ST_Intersects(way, ST_Transform(ST_SetSRID(ST_MakePoint($2, $1), %d), %d))
+	ORDER BY admin_level::integer DESC
+	LIMIT 1
+`, planetPolygonTable, SRID4326, SRID3857)
+
+func (r *timezoneRepository) GetTimezone(ctx context.Context, lat, lon float64) (string, error) {
+	queries := []string{timezoneBoundaryQuery}
+	if r.tableQuery != "" {
+		queries = []string{r.tableQuery, timezoneBoundaryQuery}
+	}
+
+	for _, query := range queries {
+		var tz string
+		err := r.db.QueryRowxContext(ctx, query, lat, lon).Scan(&tz)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			r.logger.Error("failed to get timezone",
+				zap.Float64("lat", lat),
+				zap.Float64("lon", lon),
+				zap.Error(err))
+			return "", pkgerrors.ErrDatabaseError
+		}
+		if tz != "" {
+			return tz, nil
+		}
+	}
+
+	return "", pkgerrors.ErrTimezoneUnavailable
+}
@@ -3,10 +3,14 @@ package postgresosm
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
 	pkgerrors "github.com/location-microservice/internal/pkg/errors"
@@ -14,15 +18,19 @@ import (
 )
 
 type transportRepository struct {
-	db     *sqlx.DB
-	logger *zap.Logger
+	db        *sqlx.DB
+	readDB    *sqlx.DB // тайлы и аналитика: реплика, если настроена
+	logger    *zap.Logger
+	queryOpts QueryOptions
 }
 
 // NewTransportRepository создает репозиторий транспорта для OSM базы данных
-func NewTransportRepository(db *DB) repository.TransportRepository {
+func NewTransportRepository(db *DB, queryOpts QueryOptions) repository.TransportRepository {
 	return &transportRepository{
-		db:     db.DB,
-		logger: db.logger,
+		db:        db.DB,
+		readDB:    db.Reader(ReadReplica),
+		logger:    db.logger,
+		queryOpts: queryOpts,
 	}
 }
 
@@ -33,6 +41,8 @@ func (r *transportRepository) GetNearestStations(
 	types []string,
 	maxDistance float64,
 	limit int,
+	surfaceOnly bool,
+	excludeWithinM float64,
 ) ([]*domain.TransportStation, error) {
 	if limit <= 0 || limit > LimitStations {
 		limit = LimitStations
@@ -41,7 +51,7 @@ func (r *transportRepository) GetNearestStations(
 	radiusMeters := maxDistance * 1000
 
 	// Строим фильтр по типам транспорта
-	typeFilter := ""
+	typeFilter := " AND " + r.queryOpts.activeFeatureCondition("")
 	args := []interface{}{lon, lat, radiusMeters}
 	if len(types) > 0 {
 		placeholders := make([]string, len(types))
@@ -49,9 +59,16 @@ func (r *transportRepository) GetNearestStations(
 			placeholders[i] = fmt.Sprintf("$%d", len(args)+1)
 			args = append(args, t)
 		}
-		typeFilter = fmt.Sprintf(" AND (public_transport IN (%s) OR railway IN (%s))",
+		typeFilter += fmt.Sprintf(" AND (public_transport IN (%s) OR railway IN (%s))",
 			strings.Join(placeholders, ","), strings.Join(placeholders, ","))
 	}
+	if surfaceOnly {
+		typeFilter += " AND " + surfaceOnlyCondition
+	}
+	if excludeWithinM > 0 {
+		args = append(args, excludeWithinM)
+		typeFilter += fmt.Sprintf(" AND ST_Distance(ST_Transform(way, %d)::geography, point.geom) >= $%d", SRID4326, len(args))
+	}
 
 	args = append(args, limit)
 
@@ -120,6 +137,39 @@ func (r *transportRepository) GetNearestStations(
 	return stations, nil
 }
 
+// CountStationsNearby возвращает число станций в радиусе с тем же фильтром, что и GetNearestStations
+func (r *transportRepository) CountStationsNearby(
+	ctx context.Context,
+	lat, lon float64,
+	types []string,
+	maxDistance float64,
+) (int, error) {
+	typeFilter := " AND " + r.queryOpts.activeFeatureCondition("")
+	args := []interface{}{lon, lat, maxDistance * 1000}
+	if len(types) > 0 {
+		args = append(args, pq.Array(types))
+		typeFilter += fmt.Sprintf(" AND (public_transport = ANY($%d) OR railway = ANY($%d))", len(args), len(args))
+	}
+
+	query := fmt.Sprintf(`
+		WITH point AS (
+			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d)::geography AS geom
+		)
+		SELECT COUNT(DISTINCT osm_id)
+		FROM %s, point
+		WHERE (public_transport IS NOT NULL OR railway IN ('station', 'halt', 'stop'))%s
+		  AND ST_DWithin(ST_Transform(way, %d)::geography, point.geom, $3)
+	`, SRID4326, planetPointTable, typeFilter, SRID4326)
+
+	var count int
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&count); err != nil {
+		r.logger.Error("failed to count osm stations", zap.Error(err))
+		return 0, pkgerrors.ErrDatabaseError
+	}
+
+	return count, nil
+}
+
 // GetLineByID возвращает транспортную линию по ID
 func (r *transportRepository) GetLineByID(ctx context.Context, id int64) (*domain.TransportLine, error) {
 	query := fmt.Sprintf(`
@@ -180,6 +230,59 @@ func (r *transportRepository) GetLineByID(ctx context.Context, id int64) (*domai
 	return &line, nil
 }
 
+// GetStationByID возвращает станцию (точку остановки) по osm_id
+func (r *transportRepository) GetStationByID(ctx context.Context, id int64) (*domain.TransportStation, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			osm_id,
+			COALESCE(name, '') AS name,
+			COALESCE(NULLIF(tags->'name:en', ''), NULLIF(name, ''), '') AS name_en,
+			COALESCE(NULLIF(public_transport, ''), NULLIF(railway, ''), 'station') AS type,
+			ST_Y(ST_Transform(way, %d)) AS lat,
+			ST_X(ST_Transform(way, %d)) AS lon,
+			COALESCE(tags->'operator', '') AS operator,
+			COALESCE(tags->'network', '') AS network,
+			COALESCE(tags->'wheelchair', '') AS wheelchair
+		FROM %s
+		WHERE osm_id = $1
+		  AND (public_transport IS NOT NULL OR railway IN ('station', 'halt', 'stop'))
+		  AND %s
+		LIMIT 1
+	`, SRID4326, SRID4326, planetPointTable, r.queryOpts.activeFeatureCondition(""))
+
+	var s domain.TransportStation
+	var operator, network, wheelchair string
+
+	err := r.db.QueryRowxContext(ctx, query, id).Scan(
+		&s.OSMId, &s.Name, &s.NameEn, &s.Type,
+		&s.Lat, &s.Lon, &operator, &network, &wheelchair,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, pkgerrors.ErrLocationNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get osm station", zap.Int64("osm_id", id), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	s.ID = s.OSMId
+	if operator != "" {
+		s.Operator = &operator
+	}
+	if network != "" {
+		s.Network = &network
+	}
+	if wheelchair != "" {
+		wheelchairBool := wheelchair == "yes" || wheelchair == "true" || wheelchair == "1"
+		s.Wheelchair = &wheelchairBool
+	}
+	s.LineIDs = []int64{}
+	s.Tags = make(map[string]string)
+
+	return &s, nil
+}
+
 // GetLinesByIDs возвращает несколько линий по их ID
 func (r *transportRepository) GetLinesByIDs(ctx context.Context, ids []int64) ([]*domain.TransportLine, error) {
 	if len(ids) == 0 {
@@ -251,6 +354,73 @@ func (r *transportRepository) GetLinesByIDs(ctx context.Context, ids []int64) ([
 	return lines, nil
 }
 
+// GetLinesGeometry возвращает GeoJSON геометрии нескольких линий одним запросом.
+// Части маршрута (одна relation в planet_osm_line может быть разбита на несколько строк) склеиваются через ST_LineMerge.
+// При dedupeByRef направления "туда" и "обратно" с одинаковым ref схлопываются в одну линию (с минимальным osm_id)
+func (r *transportRepository) GetLinesGeometry(ctx context.Context, ids []int64, dedupeByRef bool) (map[int64]*domain.TransportLineGeometry, error) {
+	if len(ids) == 0 {
+		return map[int64]*domain.TransportLineGeometry{}, nil
+	}
+
+	dedupeClause := "DISTINCT ON (osm_id)"
+	orderClause := "osm_id"
+	if dedupeByRef {
+		dedupeClause = "DISTINCT ON (COALESCE(NULLIF(ref, ''), name), type)"
+		orderClause = "COALESCE(NULLIF(ref, ''), name), type, osm_id"
+	}
+
+	query := fmt.Sprintf(`
+		WITH merged AS (
+			SELECT
+				osm_id,
+				COALESCE(MAX(name), '') AS name,
+				COALESCE(MAX(ref), '') AS ref,
+				COALESCE(MAX(NULLIF(route, '')), MAX(NULLIF(railway, '')), 'route') AS type,
+				COALESCE(MAX(tags->'colour'), '') AS color,
+				ST_LineMerge(ST_Collect(way)) AS way
+			FROM %s
+			WHERE osm_id = ANY($1)
+			GROUP BY osm_id
+		)
+		SELECT %s
+			osm_id,
+			name,
+			ref,
+			type,
+			color,
+			ST_AsGeoJSON(ST_Transform(way, %d), 6) AS geometry
+		FROM merged
+		ORDER BY %s
+	`, planetLineTable, dedupeClause, SRID4326, orderClause)
+
+	rows, err := r.db.QueryxContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		r.logger.Error("failed to get osm lines geometry", zap.Int("ids_count", len(ids)), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	result := make(map[int64]*domain.TransportLineGeometry, len(ids))
+	for rows.Next() {
+		var g domain.TransportLineGeometry
+		var color, geometry string
+
+		if err := rows.Scan(&g.LineID, &g.Name, &g.Ref, &g.Type, &color, &geometry); err != nil {
+			r.logger.Error("failed to scan line geometry row", zap.Error(err))
+			continue
+		}
+
+		if color != "" {
+			g.Color = &color
+		}
+		g.Geometry = json.RawMessage(geometry)
+
+		result[g.LineID] = &g
+	}
+
+	return result, nil
+}
+
 // GetStationsByLineID возвращает станции для линии (заглушка для OSM)
 func (r *transportRepository) GetStationsByLineID(ctx context.Context, lineID int64) ([]*domain.TransportStation, error) {
 	// В OSM данных связь линий и станций требует дополнительной обработки relation members
@@ -259,6 +429,210 @@ func (r *transportRepository) GetStationsByLineID(ctx context.Context, lineID in
 	return []*domain.TransportStation{}, nil
 }
 
+// GetStationsAlongLine возвращает станции в пределах bufferM метров от геометрии линии,
+// упорядоченные по положению вдоль линии (ST_LineLocatePoint). Станции с одинаковым
+// нормализованным названием схлопываются в ближайшую к линии.
+// Это геометрическое приближение до появления разбора relation members: в выдачу могут
+// попасть станции других линий, проходящих рядом.
+func (r *transportRepository) GetStationsAlongLine(ctx context.Context, lineID int64, bufferM float64) ([]*domain.TransportStation, error) {
+	query := fmt.Sprintf(`
+		WITH merged AS (
+			SELECT ST_LineMerge(ST_Collect(way)) AS geom
+			FROM %s
+			WHERE osm_id = $1
+		),
+		line AS (
+			SELECT
+				geom,
+				-- для несвязной мультилинии порядок считаем по хорде от начала первой части до конца последней
+				CASE
+					WHEN GeometryType(geom) = 'LINESTRING' THEN geom
+					ELSE ST_MakeLine(
+						ST_StartPoint(ST_GeometryN(geom, 1)),
+						ST_EndPoint(ST_GeometryN(geom, ST_NumGeometries(geom)))
+					)
+				END AS path,
+				ST_Transform(geom, %d)::geography AS geog
+			FROM merged
+			WHERE geom IS NOT NULL
+		),
+		candidates AS (
+			SELECT DISTINCT ON (station_key)
+				p.osm_id,
+				COALESCE(p.name, '') AS name,
+				COALESCE(NULLIF(p.name, ''), NULLIF(p.tags->'name:en', ''), '') AS name_en,
+				COALESCE(NULLIF(p.public_transport, ''), NULLIF(p.railway, ''), NULLIF(p.highway, ''), 'station') AS type,
+				ST_Y(p.way_geog::geometry) AS lat,
+				ST_X(p.way_geog::geometry) AS lon,
+				COALESCE(p.tags->'operator', '') AS operator,
+				COALESCE(p.tags->'network', '') AS network,
+				ST_Distance(p.way_geog, line.geog) AS distance,
+				ST_LineLocatePoint(line.path, p.way) AS position
+			FROM (
+				SELECT
+					pt.*,
+					COALESCE(
+						NULLIF(LOWER(REGEXP_REPLACE(COALESCE(pt.name, ''), '[^a-zA-Zа-яА-Я0-9]', '', 'g')), ''),
+						pt.osm_id::text
+					) AS station_key
+				FROM %s pt, line
+				WHERE (pt.public_transport IS NOT NULL OR pt.railway IN ('station', 'halt', 'stop', 'tram_stop') OR pt.highway = 'bus_stop')
+				  AND ST_DWithin(pt.way_geog, line.geog, $2)
+				  AND %s
+			) p, line
+			ORDER BY station_key, distance
+		)
+		SELECT osm_id, name, name_en, type, lat, lon, operator, network, distance
+		FROM candidates
+		ORDER BY position, distance
+		LIMIT %d
+	`, planetLineTable, SRID4326, planetPointTable, r.queryOpts.activeFeatureCondition("pt"), LimitStations)
+
+	rows, err := r.db.QueryxContext(ctx, query, lineID, bufferM)
+	if err != nil {
+		r.logger.Error("failed to get osm stations along line",
+			zap.Int64("line_id", lineID), zap.Float64("buffer_m", bufferM), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	stations := []*domain.TransportStation{}
+	for rows.Next() {
+		var s domain.TransportStation
+		var distance float64
+		var operator, network string
+
+		if err := rows.Scan(
+			&s.OSMId, &s.Name, &s.NameEn, &s.Type,
+			&s.Lat, &s.Lon, &operator, &network, &distance,
+		); err != nil {
+			r.logger.Error("failed to scan station along line row", zap.Error(err))
+			continue
+		}
+
+		s.ID = s.OSMId
+		s.DistanceM = &distance
+		if operator != "" {
+			s.Operator = &operator
+		}
+		if network != "" {
+			s.Network = &network
+		}
+		s.LineIDs = []int64{lineID}
+		s.Tags = make(map[string]string)
+
+		stations = append(stations, &s)
+	}
+
+	return stations, nil
+}
+
+// GetStationsInBoundary возвращает станции внутри административной границы (ST_Within), отсортированные по названию.
+// Станции дедуплицируются по нормализованному имени внутри типа (выходы метро считаются одной станцией),
+// types фильтрует по типу транспорта (как в GetStationsInBBox). Пустой результат перепроверяется
+// на существование границы, чтобы отличить 404 от района без станций.
+func (r *transportRepository) GetStationsInBoundary(ctx context.Context, boundaryID int64, types []string) ([]*domain.TransportStation, error) {
+	stationTypeFilter := ""
+	if len(types) > 0 {
+		filters := make([]string, 0, len(types))
+		for _, t := range types {
+			filters = append(filters, buildTransportTypeFilter(t))
+		}
+		stationTypeFilter = " AND (" + strings.Join(filters, " OR ") + ")"
+	}
+
+	query := fmt.Sprintf(`
+		WITH boundary AS (
+			SELECT way AS geom
+			FROM %[2]s
+			WHERE osm_id = $1
+			  AND boundary = 'administrative'
+			  AND admin_level IS NOT NULL
+			LIMIT 1
+		),
+		candidates AS (
+			SELECT DISTINCT ON (type, station_key)
+				osm_id, name, name_en, type, lat, lon, operator, network
+			FROM (
+				SELECT
+					osm_id,
+					COALESCE(name, '') AS name,
+					COALESCE(NULLIF(tags->'name:en', ''), name, '') AS name_en,
+					%[4]s AS type,
+					ST_Y(ST_Transform(way, %[1]d)) AS lat,
+					ST_X(ST_Transform(way, %[1]d)) AS lon,
+					COALESCE(tags->'operator', '') AS operator,
+					COALESCE(tags->'network', '') AS network,
+					COALESCE(
+						NULLIF(LOWER(REGEXP_REPLACE(COALESCE(name, ''), '[^a-zA-Zа-яА-Я0-9]', '', 'g')), ''),
+						osm_id::text
+					) AS station_key
+				FROM %[3]s, boundary b
+				WHERE (public_transport IS NOT NULL OR railway IN ('station', 'halt', 'stop', 'tram_stop') OR highway = 'bus_stop' OR amenity = 'ferry_terminal')
+				  AND ST_Within(way, b.geom)
+				  AND %[5]s%[6]s
+			) s
+			ORDER BY type, station_key, osm_id
+		)
+		SELECT osm_id, name, name_en, type, lat, lon, operator, network
+		FROM candidates
+		ORDER BY name, type, osm_id
+		LIMIT %[7]d
+	`, SRID4326, planetPolygonTable, planetPointTable, transportModeExpr, r.queryOpts.activeFeatureCondition(""), stationTypeFilter, LimitBoundaryStations)
+
+	rows, err := r.readDB.QueryxContext(ctx, query, boundaryID)
+	if err != nil {
+		r.logger.Error("failed to get osm stations in boundary",
+			zap.Int64("boundary_id", boundaryID), zap.Strings("types", types), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	stations := []*domain.TransportStation{}
+	for rows.Next() {
+		var s domain.TransportStation
+		var operator, network string
+
+		if err := rows.Scan(
+			&s.OSMId, &s.Name, &s.NameEn, &s.Type,
+			&s.Lat, &s.Lon, &operator, &network,
+		); err != nil {
+			r.logger.Error("failed to scan station in boundary row", zap.Error(err))
+			continue
+		}
+
+		s.ID = s.OSMId
+		if operator != "" {
+			s.Operator = &operator
+		}
+		if network != "" {
+			s.Network = &network
+		}
+		s.LineIDs = []int64{}
+		s.Tags = make(map[string]string)
+
+		stations = append(stations, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("error iterating osm stations in boundary", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	if len(stations) == 0 {
+		exists, err := adminBoundaryExists(ctx, r.readDB, boundaryID)
+		if err != nil {
+			r.logger.Error("failed to check osm boundary", zap.Int64("boundary_id", boundaryID), zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+		if !exists {
+			return nil, pkgerrors.ErrLocationNotFound
+		}
+	}
+
+	return stations, nil
+}
+
 // GetTransportTile генерирует MVT тайл с транспортом
 func (r *transportRepository) GetTransportTile(ctx context.Context, z, x, y int) ([]byte, error) {
 	// Станции
@@ -268,28 +642,20 @@ func (r *transportRepository) GetTransportTile(ctx context.Context, z, x, y int)
 		),
 		stations AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				CASE
-					WHEN railway = 'station' AND (tags->'station' = 'subway' OR tags->'subway' = 'yes') THEN 'subway'
-					WHEN railway = 'tram_stop' OR (railway = 'station' AND tags->'station' = 'light_rail') THEN 'tram_stop'
-					WHEN highway = 'bus_stop' OR (public_transport IN ('platform', 'stop_position') AND tags->'bus' = 'yes') THEN 'bus_stop'
-					WHEN railway IN ('station', 'halt') THEN 'station'
-					WHEN public_transport IS NOT NULL THEN COALESCE(NULLIF(public_transport, ''), 'stop')
-					ELSE 'station'
-				END AS type,
+				%s
 				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
 			FROM %s, bounds
 			WHERE (public_transport IS NOT NULL OR railway IN ('station', 'halt', 'stop') OR highway = 'bus_stop')
 			  AND way && bounds.geom
+			  AND %s
 		)
-		SELECT COALESCE(ST_AsMVT(stations.*, 'stations'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(stations.*, 'stations'), %s) AS tile
 		FROM stations
 		WHERE geom IS NOT NULL
-	`, planetPointTable)
+	`, tileAttributeColumns(tileLayerStations), planetPointTable, r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var stationsTile []byte
-	err := r.db.QueryRowContext(ctx, stationsQuery, z, x, y, MVTExtent, MVTBuffer).Scan(&stationsTile)
+	err := r.readDB.QueryRowContext(ctx, stationsQuery, z, x, y, MVTExtent, MVTBuffer).Scan(&stationsTile)
 	if err != nil && err != sql.ErrNoRows {
 		r.logger.Error("failed to build osm stations tile", zap.Int("z", z), zap.Int("x", x), zap.Int("y", y), zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -302,23 +668,20 @@ func (r *transportRepository) GetTransportTile(ctx context.Context, z, x, y int)
 		),
 		lines AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(ref, '') AS ref,
-				COALESCE(route, '') AS type,
-				COALESCE(tags->'colour', '') AS color,
+				%s
 				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
 			FROM %s, bounds
 			WHERE route IS NOT NULL
 			  AND way && bounds.geom
+			  AND %s
 		)
-		SELECT COALESCE(ST_AsMVT(lines.*, 'lines'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(lines.*, 'lines'), %s) AS tile
 		FROM lines
 		WHERE geom IS NOT NULL
-	`, planetLineTable)
+	`, tileAttributeColumns(tileLayerLines), planetLineTable, r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var linesTile []byte
-	err = r.db.QueryRowContext(ctx, linesQuery, z, x, y, MVTExtent, MVTBuffer).Scan(&linesTile)
+	err = r.readDB.QueryRowContext(ctx, linesQuery, z, x, y, MVTExtent, MVTBuffer).Scan(&linesTile)
 	if err != nil && err != sql.ErrNoRows {
 		r.logger.Error("failed to build osm lines tile", zap.Int("z", z), zap.Int("x", x), zap.Int("y", y), zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -334,11 +697,7 @@ func (r *transportRepository) GetLineTile(ctx context.Context, lineID int64) ([]
 	query := fmt.Sprintf(`
 		WITH line_data AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(ref, '') AS ref,
-				COALESCE(route, '') AS type,
-				COALESCE(tags->'colour', '') AS color,
+				%s
 				way
 			FROM %s
 			WHERE osm_id = $1
@@ -349,17 +708,17 @@ func (r *transportRepository) GetLineTile(ctx context.Context, lineID int64) ([]
 		),
 		line_mvt AS (
 			SELECT 
-				ld.id, ld.name, ld.ref, ld.type, ld.color,
+				%s
 				ST_AsMVTGeom(ld.way, b.geom, $2, $3, true) AS geom
 			FROM line_data ld, bounds b
 		)
-		SELECT COALESCE(ST_AsMVT(line_mvt.*, 'line'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(line_mvt.*, 'line'), %s) AS tile
 		FROM line_mvt
 		WHERE geom IS NOT NULL
-	`, planetLineTable)
+	`, tileAttributeColumns(tileLayerLine), planetLineTable, tileAttributeNames(tileLayerLine, "ld"), emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, lineID, MVTExtent, MVTBuffer).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, lineID, MVTExtent, MVTBuffer).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -389,11 +748,7 @@ func (r *transportRepository) GetLinesTile(ctx context.Context, lineIDs []int64)
 	query := fmt.Sprintf(`
 		WITH lines_data AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(ref, '') AS ref,
-				COALESCE(route, '') AS type,
-				COALESCE(tags->'colour', '') AS color,
+				%s
 				way
 			FROM %s
 			WHERE osm_id IN (%s)
@@ -404,17 +759,18 @@ func (r *transportRepository) GetLinesTile(ctx context.Context, lineIDs []int64)
 		),
 		lines_mvt AS (
 			SELECT 
-				ld.id, ld.name, ld.ref, ld.type, ld.color,
+				%s
 				ST_AsMVTGeom(ld.way, b.geom, $%d, $%d, true) AS geom
 			FROM lines_data ld, bounds b
 		)
-		SELECT COALESCE(ST_AsMVT(lines_mvt.*, 'lines'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(lines_mvt.*, 'lines'), %s) AS tile
 		FROM lines_mvt
 		WHERE geom IS NOT NULL
-	`, planetLineTable, strings.Join(placeholders, ","), len(args)-1, len(args))
+	`, tileAttributeColumns(tileLayerLines), planetLineTable, strings.Join(placeholders, ","), tileAttributeNames(tileLayerLines, "ld"),
+		len(args)-1, len(args), emptyTileSQL)
 
 	var tile []byte
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&tile)
+	err := r.readDB.QueryRowContext(ctx, query, args...).Scan(&tile)
 	if err == sql.ErrNoRows {
 		return []byte{}, nil
 	}
@@ -426,11 +782,9 @@ func (r *transportRepository) GetLinesTile(ctx context.Context, lineIDs []int64)
 	return tile, nil
 }
 
-// GetStationsInRadius возвращает станции в радиусе от точки
-func (r *transportRepository) GetStationsInRadius(ctx context.Context, lat, lon, radiusKm float64) ([]*domain.TransportStation, error) {
-	radiusMeters := radiusKm * 1000
-
-	query := fmt.Sprintf(`
+// stationsInRadiusQuery строит запрос GetStationsInRadius. Параметры: $1 lon, $2 lat, $3 радиус в метрах, $4 лимит.
+func stationsInRadiusQuery(q QueryOptions) string {
+	return fmt.Sprintf(`
 		WITH point AS (
 			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d)::geography AS geom
 		)
@@ -448,9 +802,17 @@ func (r *transportRepository) GetStationsInRadius(ctx context.Context, lat, lon,
 		FROM %s, point
 		WHERE (public_transport IS NOT NULL OR railway IN ('station', 'halt', 'stop'))
 		  AND ST_DWithin(ST_Transform(way, %d)::geography, point.geom, $3)
+		  AND %s
 		ORDER BY distance
 		LIMIT $4
-	`, SRID4326, SRID4326, SRID4326, SRID4326, planetPointTable, SRID4326)
+	`, SRID4326, SRID4326, SRID4326, SRID4326, planetPointTable, SRID4326, q.activeFeatureCondition(""))
+}
+
+// GetStationsInRadius возвращает станции в радиусе от точки
+func (r *transportRepository) GetStationsInRadius(ctx context.Context, lat, lon, radiusKm float64) ([]*domain.TransportStation, error) {
+	radiusMeters := radiusKm * 1000
+
+	query := stationsInRadiusQuery(r.queryOpts)
 
 	rows, err := r.db.QueryxContext(ctx, query, lon, lat, radiusMeters, LimitStations)
 	if err != nil {
@@ -495,11 +857,9 @@ func (r *transportRepository) GetStationsInRadius(ctx context.Context, lat, lon,
 	return stations, nil
 }
 
-// GetLinesInRadius возвращает линии в радиусе от точки
-func (r *transportRepository) GetLinesInRadius(ctx context.Context, lat, lon, radiusKm float64) ([]*domain.TransportLine, error) {
-	radiusMeters := radiusKm * 1000
-
-	query := fmt.Sprintf(`
+// linesInRadiusQuery строит запрос GetLinesInRadius. Параметры: $1 lon, $2 lat, $3 радиус в метрах, $4 лимит.
+func linesInRadiusQuery(q QueryOptions) string {
+	return fmt.Sprintf(`
 		WITH point AS (
 			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d) AS geom
 		),
@@ -520,9 +880,17 @@ func (r *transportRepository) GetLinesInRadius(ctx context.Context, lat, lon, ra
 		WHERE route IS NOT NULL
 		  AND way && circle.geom
 		  AND ST_Intersects(way, circle.geom)
+		  AND %s
 		ORDER BY name
 		LIMIT $4
-	`, SRID4326, planetLineTable)
+	`, SRID4326, planetLineTable, q.activeFeatureCondition(""))
+}
+
+// GetLinesInRadius возвращает линии в радиусе от точки
+func (r *transportRepository) GetLinesInRadius(ctx context.Context, lat, lon, radiusKm float64) ([]*domain.TransportLine, error) {
+	radiusMeters := radiusKm * 1000
+
+	query := linesInRadiusQuery(r.queryOpts)
 
 	rows, err := r.db.QueryxContext(ctx, query, lon, lat, radiusMeters, LimitLines)
 	if err != nil {
@@ -582,9 +950,7 @@ func (r *transportRepository) GetTransportRadiusTile(ctx context.Context, lat, l
 		),
 		stations AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(NULLIF(public_transport, ''), NULLIF(railway, ''), 'station') AS type,
+				%s
 				ST_AsMVTGeom(
 					ST_Transform(way, %d),
 					circle.geom,
@@ -594,16 +960,18 @@ func (r *transportRepository) GetTransportRadiusTile(ctx context.Context, lat, l
 			WHERE (public_transport IS NOT NULL OR railway IN ('station', 'halt', 'stop'))
 			  AND ST_Transform(way, %d) && circle.geom
 			  AND ST_Contains(circle.geom, ST_Transform(way, %d))
+			  AND %s
 			ORDER BY name
 			LIMIT $6
 		)
-		SELECT COALESCE(ST_AsMVT(stations.*, 'transport_stations'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(stations.*, 'transport_stations'), %s) AS tile
 		FROM stations
 		WHERE geom IS NOT NULL
-	`, SRID4326, SRID3857, SRID3857, planetPointTable, SRID3857, SRID3857)
+	`, SRID4326, SRID3857, tileAttributeColumns(tileLayerTransportStations), SRID3857, planetPointTable, SRID3857, SRID3857,
+		r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var stationsTile []byte
-	err := r.db.QueryRowContext(ctx, stationsQuery, lon, lat, radiusMeters, MVTExtent, MVTBuffer, LimitStations).Scan(&stationsTile)
+	err := r.readDB.QueryRowContext(ctx, stationsQuery, lon, lat, radiusMeters, MVTExtent, MVTBuffer, LimitStations).Scan(&stationsTile)
 	if err != nil && err != sql.ErrNoRows {
 		r.logger.Error("failed to build osm stations radius tile", zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -620,11 +988,7 @@ func (r *transportRepository) GetTransportRadiusTile(ctx context.Context, lat, l
 		),
 		lines AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(ref, '') AS ref,
-				COALESCE(route, '') AS type,
-				COALESCE(tags->'colour', '') AS color,
+				%s
 				ST_AsMVTGeom(
 					ST_Intersection(ST_Transform(way, %d), circle.geom),
 					circle.geom,
@@ -634,16 +998,18 @@ func (r *transportRepository) GetTransportRadiusTile(ctx context.Context, lat, l
 			WHERE route IS NOT NULL
 			  AND ST_Transform(way, %d) && circle.geom
 			  AND ST_Intersects(ST_Transform(way, %d), circle.geom)
+			  AND %s
 			ORDER BY name
 			LIMIT $6
 		)
-		SELECT COALESCE(ST_AsMVT(lines.*, 'transport_lines'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(lines.*, 'transport_lines'), %s) AS tile
 		FROM lines
 		WHERE geom IS NOT NULL
-	`, SRID4326, SRID3857, SRID3857, planetLineTable, SRID3857, SRID3857)
+	`, SRID4326, SRID3857, tileAttributeColumns(tileLayerTransportLines), SRID3857, planetLineTable, SRID3857, SRID3857,
+		r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var linesTile []byte
-	err = r.db.QueryRowContext(ctx, linesQuery, lon, lat, radiusMeters, MVTExtent, MVTBuffer, LimitLines).Scan(&linesTile)
+	err = r.readDB.QueryRowContext(ctx, linesQuery, lon, lat, radiusMeters, MVTExtent, MVTBuffer, LimitLines).Scan(&linesTile)
 	if err != nil && err != sql.ErrNoRows {
 		r.logger.Error("failed to build osm lines radius tile", zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -655,40 +1021,26 @@ func (r *transportRepository) GetTransportRadiusTile(ctx context.Context, lat, l
 }
 
 // GetTransportTileByTypes генерирует MVT тайл для транспорта с фильтрацией по типам
-func (r *transportRepository) GetTransportTileByTypes(ctx context.Context, z, x, y int, types []string) ([]byte, error) {
+func (r *transportRepository) GetTransportTileByTypes(ctx context.Context, z, x, y int, types []string, surfaceOnly bool) ([]byte, error) {
 	args := []interface{}{z, x, y, MVTExtent, MVTBuffer}
 
 	// Построение фильтра станций из типов с использованием buildTransportTypeFilter
-	stationTypeFilter := ""
+	stationTypeFilter := " AND " + r.queryOpts.activeFeatureCondition("")
 	if len(types) > 0 {
 		filters := make([]string, 0, len(types))
 		for _, t := range types {
 			filters = append(filters, buildTransportTypeFilter(t))
 		}
-		stationTypeFilter = " AND (" + strings.Join(filters, " OR ") + ")"
+		stationTypeFilter += " AND (" + strings.Join(filters, " OR ") + ")"
+	}
+	if surfaceOnly {
+		stationTypeFilter += " AND " + surfaceOnlyCondition
 	}
 
 	// Построение фильтра линий (маппинг типов на route значения OSM)
 	lineTypeFilter := ""
-	if len(types) > 0 {
-		routeValues := make([]string, 0, len(types))
-		for _, t := range types {
-			switch t {
-			case "metro", "subway":
-				routeValues = append(routeValues, "'subway'")
-			case "bus":
-				routeValues = append(routeValues, "'bus'")
-			case "tram", "light_rail":
-				routeValues = append(routeValues, "'tram'", "'light_rail'")
-			case "train", "rail", "cercania", "long_distance":
-				routeValues = append(routeValues, "'train'")
-			case "ferry":
-				routeValues = append(routeValues, "'ferry'")
-			}
-		}
-		if len(routeValues) > 0 {
-			lineTypeFilter = fmt.Sprintf(" AND route IN (%s)", strings.Join(routeValues, ","))
-		}
+	if routeValues := transportRouteValues(types); len(routeValues) > 0 {
+		lineTypeFilter = fmt.Sprintf(" AND route IN ('%s')", strings.Join(routeValues, "','"))
 	}
 
 	// Станции
@@ -698,28 +1050,19 @@ func (r *transportRepository) GetTransportTileByTypes(ctx context.Context, z, x,
 		),
 		stations AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				CASE
-					WHEN railway = 'station' AND (tags->'station' = 'subway' OR tags->'subway' = 'yes') THEN 'subway'
-					WHEN railway = 'tram_stop' OR (railway = 'station' AND tags->'station' = 'light_rail') THEN 'tram_stop'
-					WHEN highway = 'bus_stop' OR (public_transport IN ('platform', 'stop_position') AND tags->'bus' = 'yes') THEN 'bus_stop'
-					WHEN railway IN ('station', 'halt') THEN 'station'
-					WHEN public_transport IS NOT NULL THEN COALESCE(NULLIF(public_transport, ''), 'stop')
-					ELSE 'station'
-				END AS type,
+				%s
 				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
 			FROM %s, bounds
 			WHERE (public_transport IS NOT NULL OR railway IN ('station', 'halt', 'stop') OR highway = 'bus_stop')
 			  AND way && bounds.geom%s
 		)
-		SELECT COALESCE(ST_AsMVT(stations.*, 'stations'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(stations.*, 'stations'), %s) AS tile
 		FROM stations
 		WHERE geom IS NOT NULL
-	`, planetPointTable, stationTypeFilter)
+	`, tileAttributeColumns(tileLayerStations), planetPointTable, stationTypeFilter, emptyTileSQL)
 
 	var stationsTile []byte
-	err := r.db.QueryRowContext(ctx, stationsQuery, args...).Scan(&stationsTile)
+	err := r.readDB.QueryRowContext(ctx, stationsQuery, args...).Scan(&stationsTile)
 	if err != nil && err != sql.ErrNoRows {
 		r.logger.Error("failed to build osm stations tile by types", zap.Int("z", z), zap.Int("x", x), zap.Int("y", y), zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -732,23 +1075,20 @@ func (r *transportRepository) GetTransportTileByTypes(ctx context.Context, z, x,
 		),
 		lines AS (
 			SELECT 
-				osm_id AS id,
-				COALESCE(name, '') AS name,
-				COALESCE(ref, '') AS ref,
-				COALESCE(route, '') AS type,
-				COALESCE(tags->'colour', '') AS color,
+				%s
 				ST_AsMVTGeom(way, bounds.geom, $4, $5, true) AS geom
 			FROM %s, bounds
 			WHERE route IS NOT NULL
 			  AND way && bounds.geom%s
+			  AND %s
 		)
-		SELECT COALESCE(ST_AsMVT(lines.*, 'lines'), '\\x'::bytea) AS tile
+		SELECT COALESCE(ST_AsMVT(lines.*, 'lines'), %s) AS tile
 		FROM lines
 		WHERE geom IS NOT NULL
-	`, planetLineTable, lineTypeFilter)
+	`, tileAttributeColumns(tileLayerLines), planetLineTable, lineTypeFilter, r.queryOpts.activeFeatureCondition(""), emptyTileSQL)
 
 	var linesTile []byte
-	err = r.db.QueryRowContext(ctx, linesQuery, args...).Scan(&linesTile)
+	err = r.readDB.QueryRowContext(ctx, linesQuery, args...).Scan(&linesTile)
 	if err != nil && err != sql.ErrNoRows {
 		r.logger.Error("failed to build osm lines tile by types", zap.Int("z", z), zap.Int("x", x), zap.Int("y", y), zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -758,6 +1098,100 @@ func (r *transportRepository) GetTransportTileByTypes(ctx context.Context, z, x,
 	return result, nil
 }
 
+// transportRouteValues переводит типы транспорта API в значения тега route OSM.
+// Неизвестные типы пропускаются; пустой результат - без фильтра по route.
+func transportRouteValues(types []string) []string {
+	routeValues := make([]string, 0, len(types))
+	for _, t := range types {
+		switch t {
+		case "metro", "subway":
+			routeValues = append(routeValues, "subway")
+		case "bus":
+			routeValues = append(routeValues, "bus")
+		case "tram", "light_rail":
+			routeValues = append(routeValues, "tram", "light_rail")
+		case "train", "rail", "cercania", "long_distance":
+			routeValues = append(routeValues, "train")
+		case "ferry":
+			routeValues = append(routeValues, "ferry")
+		}
+	}
+	return routeValues
+}
+
+// GetLinesInBBox возвращает линии маршрутов, пересекающие bbox, с геометрией, обрезанной по bbox.
+// Направления одной линии схлопываются по ref (без ref - по имени, без имени - не схлопываются)
+// и типу: остается вариант с наибольшей длиной внутри bbox. Неактивные маршруты не возвращаются.
+func (r *transportRepository) GetLinesInBBox(ctx context.Context, bbox domain.BoundingBox, modes []string) ([]domain.TransportLineGeometry, error) {
+	args := []interface{}{bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat}
+	routeFilter := ""
+	if routeValues := transportRouteValues(modes); len(routeValues) > 0 {
+		args = append(args, pq.Array(routeValues))
+		routeFilter = fmt.Sprintf(" AND route = ANY($%d)", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		WITH bounds AS (
+			SELECT ST_Transform(ST_MakeEnvelope($1, $2, $3, $4, %d), %d) AS geom
+		),
+		clipped AS (
+			SELECT
+				osm_id,
+				COALESCE(MAX(name), '') AS name,
+				COALESCE(MAX(ref), '') AS ref,
+				MAX(route) AS type,
+				COALESCE(MAX(tags->'colour'), '') AS color,
+				ST_LineMerge(ST_CollectionExtract(ST_Collect(ST_Intersection(way, bounds.geom)), 2)) AS way
+			FROM %s, bounds
+			WHERE route IS NOT NULL
+			  AND way && bounds.geom
+			  AND %s%s
+			GROUP BY osm_id
+		)
+		SELECT DISTINCT ON (COALESCE(NULLIF(ref, ''), NULLIF(name, ''), osm_id::text), type)
+			osm_id,
+			name,
+			ref,
+			type,
+			color,
+			ST_AsGeoJSON(ST_Transform(way, %d), 6) AS geometry
+		FROM clipped
+		WHERE NOT ST_IsEmpty(way)
+		ORDER BY COALESCE(NULLIF(ref, ''), NULLIF(name, ''), osm_id::text), type, ST_Length(way) DESC, osm_id
+		LIMIT %d
+	`, SRID4326, SRID3857, planetLineTable, r.queryOpts.activeFeatureCondition(""), routeFilter, SRID4326, LimitLinesInBBox)
+
+	rows, err := r.readDB.QueryxContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("failed to get osm lines in bbox", zap.Strings("modes", modes), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	lines := make([]domain.TransportLineGeometry, 0)
+	for rows.Next() {
+		var g domain.TransportLineGeometry
+		var color, geometry string
+
+		if err := rows.Scan(&g.LineID, &g.Name, &g.Ref, &g.Type, &color, &geometry); err != nil {
+			r.logger.Error("failed to scan line in bbox row", zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+
+		if color != "" {
+			g.Color = &color
+		}
+		g.Geometry = json.RawMessage(geometry)
+		lines = append(lines, g)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to iterate lines in bbox", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return lines, nil
+}
+
 // GetLinesByStationID возвращает линии метро/поезда для станции
 // Группирует по ref чтобы убрать дубли направлений (L3 туда и обратно = одна линия L3)
 // ОПТИМИЗАЦИЯ: использует way (SRID 3857) для быстрого пространственного поиска
@@ -782,6 +1216,7 @@ func (r *transportRepository) GetLinesByStationID(ctx context.Context, stationID
 			WHERE l.route IN ('subway', 'light_rail', 'train')
 			  AND l.ref IS NOT NULL AND l.ref != ''
 			  AND ST_DWithin(l.way, s.way, 100)
+			  AND %s
 			ORDER BY COALESCE(NULLIF(l.ref, ''), l.name), l.osm_id
 		)
 		SELECT osm_id, ref, color, route_type
@@ -797,7 +1232,7 @@ func (r *transportRepository) GetLinesByStationID(ctx context.Context, stationID
 			END,
 			ref
 		LIMIT %d
-	`, planetPointTable, planetLineTable, LimitLines)
+	`, planetPointTable, planetLineTable, r.queryOpts.activeFeatureCondition("l"), LimitLines)
 
 	rows, err := r.db.QueryxContext(ctx, query, stationID)
 	if err != nil {
@@ -828,11 +1263,14 @@ func (r *transportRepository) GetLinesByStationID(ctx context.Context, stationID
 
 // GetNearestStationsGrouped возвращает ближайшие станции транспорта с группировкой
 // по нормализованному имени. Это исключает дубли выходов метро (считается как одна станция).
+// Станции запрашиваются по типам, поэтому без StationsOrderByDistance результат - блоки типов
+// в порядке priorities, отсортированные по расстоянию только внутри блока.
 func (r *transportRepository) GetNearestStationsGrouped(
 	ctx context.Context,
 	lat, lon float64,
 	priorities []domain.TransportPriority,
 	maxDistance float64,
+	opts domain.NearestStationsOptions,
 ) ([]*domain.TransportStation, error) {
 	var allStations []*domain.TransportStation
 
@@ -848,6 +1286,10 @@ func (r *transportRepository) GetNearestStationsGrouped(
 		allStations = append(allStations, stations...)
 	}
 
+	if opts.Order == domain.StationsOrderByDistance {
+		domain.SortStationsByDistance(allStations)
+	}
+
 	return allStations, nil
 }
 
@@ -860,7 +1302,7 @@ func (r *transportRepository) getGroupedStationsByType(
 	limit int,
 ) ([]*domain.TransportStation, error) {
 	// Определяем условие фильтрации по типу транспорта
-	typeFilter := buildTransportTypeFilter(transportType)
+	typeFilter := buildTransportTypeFilter(transportType) + " AND " + r.queryOpts.activeFeatureCondition("")
 
 	// SQL запрос с группировкой по нормализованному имени
 	// Удаляет дубли выходов метро (например, разные выходы одной станции)
@@ -914,7 +1356,7 @@ func (r *transportRepository) getGroupedStationsByType(
 		// В OSM данных ID = OSM ID
 		s.ID = s.OSMId
 		// Сохраняем дистанцию из БД
-		s.Distance = &distance
+		s.DistanceM = &distance
 		// LineIDs в OSM не связаны напрямую, оставляем пустым
 		s.LineIDs = []int64{}
 		s.Tags = make(map[string]string)
@@ -1000,15 +1442,24 @@ func (r *transportRepository) GetNearestStationsBatch(
 	if len(req.Points) == 0 {
 		return []domain.TransportStationWithLines{}, nil
 	}
+	if len(req.Points) > LimitBatchPoints {
+		r.logger.Warn("batch stations request exceeds points limit",
+			zap.Int("points_count", len(req.Points)),
+			zap.Int("limit", LimitBatchPoints))
+		return nil, pkgerrors.ErrBatchTooLarge.WithDetails(map[string]interface{}{
+			"batch_size":     len(req.Points),
+			"max_batch_size": LimitBatchPoints,
+		})
+	}
 
 	maxDistance := req.MaxDistance
 	if maxDistance <= 0 {
 		maxDistance = 1500 // default 1.5km
 	}
 
-	// Шаг 1: Построить CTE для всех точек поиска
-	// Формат: point_idx, lon, lat, types[], limit
-	pointsCTE := r.buildPointsCTE(req.Points)
+	// Шаг 1: Построить CTE для всех точек поиска ($1 - радиус, точки - с $2)
+	// Формат: point_idx, lon, lat, transport_type, limit
+	pointsCTE, pointsArgs := r.buildPointsCTE(req.Points, 2)
 
 	// Шаг 2: Один запрос для получения ближайших станций для всех точек
 	stationsQuery := fmt.Sprintf(`
@@ -1033,10 +1484,11 @@ func (r *transportRepository) GetNearestStationsBatch(
 			FROM %s p
 			CROSS JOIN search_points sp
 			WHERE p.name IS NOT NULL AND p.name != ''
+			  AND %s
 			  AND ST_DWithin(
 				  ST_Transform(p.way, %d)::geography, 
 				  ST_SetSRID(ST_MakePoint(sp.lon, sp.lat), %d)::geography, 
-				  $1
+				  COALESCE(NULLIF(sp.radius_m, 0), $1)
 			  )
 			  AND (
 				  -- Фильтр по типу транспорта
@@ -1088,11 +1540,15 @@ func (r *transportRepository) GetNearestStationsBatch(
 		FROM ranked_stations
 		WHERE rn <= limit_per_point
 		ORDER BY point_idx, distance
-	`, pointsCTE, SRID4326, SRID4326, SRID4326, SRID4326, planetPointTable, SRID4326, SRID4326)
+	`, pointsCTE, SRID4326, SRID4326, SRID4326, SRID4326, planetPointTable, r.queryOpts.activeFeatureCondition("p"), SRID4326, SRID4326)
 
-	r.logger.Debug("Executing batch stations query", zap.Int("points_count", len(req.Points)))
+	r.logger.Debug("Executing batch stations query",
+		zap.Int("points_count", len(req.Points)),
+		zap.Int("args_count", len(pointsArgs)+1),
+		zap.Int("query_len", len(stationsQuery)))
 
-	rows, err := r.db.QueryxContext(ctx, stationsQuery, maxDistance)
+	args := append([]interface{}{maxDistance}, pointsArgs...)
+	rows, err := r.db.QueryxContext(ctx, stationsQuery, args...)
 	if err != nil {
 		r.logger.Error("failed to execute batch stations query", zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -1106,7 +1562,7 @@ func (r *transportRepository) GetNearestStationsBatch(
 
 	for rows.Next() {
 		var s domain.TransportStationWithLines
-		err := rows.Scan(&s.PointIdx, &s.StationID, &s.Name, &s.Type, &s.Lat, &s.Lon, &s.Distance)
+		err := rows.Scan(&s.PointIdx, &s.StationID, &s.Name, &s.Type, &s.Lat, &s.Lon, &s.DistanceM)
 		if err != nil {
 			r.logger.Error("failed to scan batch station row", zap.Error(err))
 			continue
@@ -1128,9 +1584,12 @@ func (r *transportRepository) GetNearestStationsBatch(
 	return stations, nil
 }
 
-// buildPointsCTE строит CTE с точками поиска для batch-запроса
-func (r *transportRepository) buildPointsCTE(points []domain.TransportSearchPoint) string {
-	var parts []string
+// buildPointsCTE строит CTE с точками поиска для batch-запроса: параметризованный VALUES
+// (5 параметров на точку, нумерация с firstArg) вместо UNION ALL с подставленными значениями -
+// тип транспорта приходит от клиента и в текст запроса не попадает.
+func (r *transportRepository) buildPointsCTE(points []domain.TransportSearchPoint, firstArg int) (string, []interface{}) {
+	rows := make([]string, 0, len(points))
+	args := make([]interface{}, 0, len(points)*5)
 	for i, p := range points {
 		transportType := ""
 		if len(p.Types) > 0 {
@@ -1140,23 +1599,27 @@ func (r *transportRepository) buildPointsCTE(points []domain.TransportSearchPoin
 		if limit <= 0 {
 			limit = 3
 		}
-		parts = append(parts, fmt.Sprintf(
-			"SELECT %d AS point_idx, %f AS lon, %f AS lat, '%s' AS transport_type, %d AS limit_per_point",
-			i, p.Lon, p.Lat, transportType, limit,
-		))
-	}
-	return strings.Join(parts, " UNION ALL ")
+		n := firstArg + len(args)
+		rows = append(rows, fmt.Sprintf("($%d::int, $%d::float8, $%d::float8, $%d::text, $%d::int)", n, n+1, n+2, n+3, n+4))
+		args = append(args, i, p.Lon, p.Lat, transportType, limit)
+	}
+	return fmt.Sprintf(
+		"SELECT * FROM (VALUES %s) AS v(point_idx, lon, lat, transport_type, limit_per_point)",
+		strings.Join(rows, ", "),
+	), args
 }
 
 // GetNearestTransportByPriority возвращает ближайший транспорт с приоритетом по типу и расстоянию.
 // Приоритет: 1) metro/train - высокий приоритет, 2) tram/bus - добавляются если высокоприоритетных < лимита.
 // Возвращает станции с информацией о линиях (для метро: L2, L4 и их цвета; для автобусов: номера маршрутов).
 // Использует предвычисленную колонку way_geog для оптимальной производительности.
+// minPerMode (вид -> число станций) резервирует слоты под виды транспорта, см. priorityTransportRankCTE.
 func (r *transportRepository) GetNearestTransportByPriority(
 	ctx context.Context,
 	lat, lon float64,
 	radiusM float64,
 	limit int,
+	minPerMode map[string]int,
 ) ([]domain.NearestTransportWithLines, error) {
 	if limit <= 0 || limit > LimitStations {
 		limit = LimitStations
@@ -1180,14 +1643,7 @@ func (r *transportRepository) GetNearestTransportByPriority(
 				osm_id AS station_id,
 				COALESCE(name, '') AS name,
 				COALESCE(NULLIF(tags->'name:en', ''), name, '') AS name_en,
-				CASE 
-					WHEN railway = 'station' AND (tags->'station' = 'subway' OR tags->'subway' = 'yes') THEN 'metro'
-					WHEN railway IN ('station', 'halt') AND (tags->'station' IS NULL OR tags->'station' NOT IN ('subway', 'light_rail')) THEN 'train'
-					WHEN railway = 'tram_stop' OR (railway = 'station' AND tags->'station' = 'light_rail') THEN 'tram'
-					WHEN highway = 'bus_stop' OR (public_transport IN ('platform', 'stop_position') AND tags->'bus' = 'yes') THEN 'bus'
-					WHEN amenity = 'ferry_terminal' THEN 'ferry'
-					ELSE 'other'
-				END AS transport_type,
+				%s AS transport_type,
 				ST_Y(way_geog::geometry) AS lat,
 				ST_X(way_geog::geometry) AS lon,
 				ST_Distance(way_geog, sp.geom) AS distance,
@@ -1202,6 +1658,7 @@ func (r *transportRepository) GetNearestTransportByPriority(
 			FROM %s, search_point sp
 			WHERE name IS NOT NULL AND name != ''
 			  AND ST_DWithin(way_geog, sp.geom, $3)
+			  AND %s
 			  AND (
 				  -- Metro stations
 				  (railway = 'station' AND (tags->'station' = 'subway' OR tags->'subway' = 'yes'))
@@ -1215,21 +1672,28 @@ func (r *transportRepository) GetNearestTransportByPriority(
 			  )
 			ORDER BY normalized_name, distance
 		),
-		-- Единый ранжированный подход: metro > train > tram > bus, внутри ранга по расстоянию
-		ranked_stations AS (
-			SELECT *,
-				ROW_NUMBER() OVER (
-					ORDER BY priority_rank ASC, distance ASC
-				) AS global_rank
-			FROM all_stations
-		)
+		%s
 		SELECT station_id, name, name_en, transport_type, lat, lon, distance
 		FROM ranked_stations
 		WHERE global_rank <= $4
 		ORDER BY priority_rank, distance
-	`, SRID4326, planetPointTable)
+	`, SRID4326, transportModeExpr, planetPointTable, r.queryOpts.activeFeatureCondition(""), priorityTransportRankCTE(len(minPerMode) > 0))
 
-	rows, err := r.db.QueryxContext(ctx, query, lon, lat, radiusM, limit)
+	args := []interface{}{lon, lat, radiusM, limit}
+	if len(minPerMode) > 0 {
+		modes := make([]string, 0, len(minPerMode))
+		for mode := range minPerMode {
+			modes = append(modes, mode)
+		}
+		sort.Strings(modes)
+		counts := make([]int64, len(modes))
+		for i, mode := range modes {
+			counts[i] = int64(minPerMode[mode])
+		}
+		args = append(args, pq.Array(modes), pq.Array(counts))
+	}
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
 	if err != nil {
 		r.logger.Error("failed to get nearest transport by priority", zap.Error(err))
 		return nil, pkgerrors.ErrDatabaseError
@@ -1243,7 +1707,7 @@ func (r *transportRepository) GetNearestTransportByPriority(
 	for rows.Next() {
 		var s domain.NearestTransportWithLines
 		var nameEn string
-		err := rows.Scan(&s.StationID, &s.Name, &nameEn, &s.Type, &s.Lat, &s.Lon, &s.Distance)
+		err := rows.Scan(&s.StationID, &s.Name, &nameEn, &s.Type, &s.Lat, &s.Lon, &s.DistanceM)
 		if err != nil {
 			r.logger.Error("failed to scan station row", zap.Error(err))
 			continue
@@ -1276,6 +1740,235 @@ func (r *transportRepository) GetNearestTransportByPriority(
 	return stations, nil
 }
 
+// walkableHighways - линейные значения highway, по которым можно идти пешком (без автомагистралей)
+var walkableHighways = []string{
+	"footway", "pedestrian", "path", "steps", "living_street", "residential", "service",
+	"unclassified", "tertiary", "tertiary_link", "secondary", "secondary_link", "primary", "primary_link",
+	"track", "cycleway",
+}
+
+const (
+	// snappedCorridorM - полуширина коридора вокруг пешеходных дорог, в котором отрезок между
+	// точками привязки считается проходимым
+	snappedCorridorM = 25.0
+	// snappedCorridorCoverage - доля отрезка, которая должна лежать в коридоре
+	snappedCorridorCoverage = 0.9
+)
+
+// snappedDistancesQuery строит запрос GetSnappedDistances. Параметры: $1 lon, $2 lat, $3 osm_id станций,
+// $4 walkableHighways, $5 SnappedDistanceMaxOffsetM, $6 snappedCorridorM, $7 snappedCorridorCoverage.
+// Средний участок между точками привязки:
+//   - обе точки на одной дороге - длина ее отрезка между ними (ST_LineLocatePoint/ST_LineSubstring);
+//   - на разных дорогах - прямая между точками; along_network, если прямая почти целиком проходит
+//     в коридоре пешеходных дорог (буфер $6 м в метрах Меркатора растянут на 1/cos(lat)).
+func snappedDistancesQuery() string {
+	return fmt.Sprintf(`
+		WITH origin AS (
+			SELECT ST_Transform(ST_SetSRID(ST_MakePoint($1, $2), %[1]d), %[2]d) AS way
+		),
+		origin_snap AS (
+			SELECT o.way, l.osm_id AS line_id, l.way AS line, ST_ClosestPoint(l.way, o.way) AS snap
+			FROM origin o
+			CROSS JOIN LATERAL (
+				SELECT osm_id, way FROM %[3]s
+				WHERE highway = ANY($4)
+				ORDER BY way <-> o.way
+				LIMIT 1
+			) l
+		),
+		station_snap AS (
+			SELECT p.osm_id, p.way, l.osm_id AS line_id, ST_ClosestPoint(l.way, p.way) AS snap
+			FROM %[4]s p
+			CROSS JOIN LATERAL (
+				SELECT osm_id, way FROM %[3]s
+				WHERE highway = ANY($4)
+				ORDER BY way <-> p.way
+				LIMIT 1
+			) l
+			WHERE p.osm_id = ANY($3)
+		),
+		legs AS (
+			SELECT
+				s.osm_id,
+				o.way AS origin,
+				o.snap AS origin_snap,
+				s.way AS station,
+				s.snap AS station_snap,
+				CASE WHEN s.line_id = o.line_id AND GeometryType(o.line) = 'LINESTRING' THEN
+					ST_LineSubstring(o.line,
+						LEAST(ST_LineLocatePoint(o.line, o.snap), ST_LineLocatePoint(o.line, s.snap)),
+						GREATEST(ST_LineLocatePoint(o.line, o.snap), ST_LineLocatePoint(o.line, s.snap)))
+				END AS along_way,
+				ST_MakeLine(o.snap, s.snap) AS segment
+			FROM station_snap s, origin_snap o
+		),
+		measured AS (
+			SELECT
+				g.osm_id,
+				ST_Distance(ST_Transform(g.origin, %[1]d)::geography, ST_Transform(g.origin_snap, %[1]d)::geography) AS origin_offset,
+				COALESCE(
+					ST_Length(ST_Transform(g.along_way, %[1]d)::geography),
+					ST_Distance(ST_Transform(g.origin_snap, %[1]d)::geography, ST_Transform(g.station_snap, %[1]d)::geography)
+				) AS network,
+				ST_Distance(ST_Transform(g.station_snap, %[1]d)::geography, ST_Transform(g.station, %[1]d)::geography) AS station_offset,
+				g.along_way IS NOT NULL OR COALESCE(c.covered, false) AS along_network
+			FROM legs g
+			CROSS JOIN LATERAL (
+				SELECT ST_Length(ST_Intersection(g.segment, ST_Buffer(ST_Collect(w.way), $6 / cos(radians($2)))))
+					>= $7 * ST_Length(g.segment) AS covered
+				FROM %[3]s w
+				WHERE g.along_way IS NULL
+				  AND w.highway = ANY($4)
+				  AND w.way && ST_Expand(g.segment, $6 / cos(radians($2)))
+			) c
+		)
+		SELECT osm_id, origin_offset, network, station_offset, along_network
+		FROM measured
+		WHERE origin_offset <= $5 AND station_offset <= $5
+	`, SRID4326, SRID3857, planetLineTable, planetPointTable)
+}
+
+// GetSnappedDistances привязывает точку запроса и станции к ближайшим (KNN по индексу way) пешеходным
+// дорогам и считает по geography подход к дороге, путь между точками привязки и отход к станции.
+// Это не маршрутизация по графу: путь вдоль сети известен, только когда обе точки привязаны к одной
+// дороге или прямая между ними идет по коридору пешеходных дорог (SnappedDistance.AlongNetwork).
+func (r *transportRepository) GetSnappedDistances(
+	ctx context.Context,
+	lat, lon float64,
+	stationIDs []int64,
+) (map[int64]domain.SnappedDistance, error) {
+	result := make(map[int64]domain.SnappedDistance)
+	if len(stationIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryxContext(ctx, snappedDistancesQuery(), lon, lat, pq.Array(stationIDs), pq.Array(walkableHighways),
+		domain.SnappedDistanceMaxOffsetM, snappedCorridorM, snappedCorridorCoverage)
+	if err != nil {
+		r.logger.Error("failed to get snapped distances", zap.Int("stations", len(stationIDs)), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var d domain.SnappedDistance
+		if err := rows.Scan(&id, &d.OriginOffsetM, &d.NetworkM, &d.StationOffsetM, &d.AlongNetwork); err != nil {
+			r.logger.Error("failed to scan snapped distance row", zap.Error(err))
+			return nil, pkgerrors.ErrDatabaseError
+		}
+		result[id] = d
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to iterate snapped distances", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return result, nil
+}
+
+// priorityTransportRankCTE строит CTE ranked_stations с global_rank - порядком заполнения слотов
+// GetNearestTransportByPriority. По умолчанию строгий приоритет: metro > train > tram > bus, внутри
+// ранга по расстоянию. С резервом по видам ($5 - виды, $6 - число станций) сначала идут ближайшие
+// станции каждого вида в пределах его минимума (между собой - по приоритету), остальные слоты
+// заполняются по расстоянию без учета приоритета.
+func priorityTransportRankCTE(withMinPerMode bool) string {
+	if !withMinPerMode {
+		return `-- Единый ранжированный подход: metro > train > tram > bus, внутри ранга по расстоянию
+		ranked_stations AS (
+			SELECT *,
+				ROW_NUMBER() OVER (
+					ORDER BY priority_rank ASC, distance ASC
+				) AS global_rank
+			FROM all_stations
+		)`
+	}
+	return `-- Минимум станций по видам транспорта
+		mode_minimums AS (
+			SELECT * FROM unnest($5::text[], $6::int[]) AS m(mode, min_count)
+		),
+		-- Станция зарезервирована, если входит в ближайшие min_count своего вида
+		reserved_stations AS (
+			SELECT s.*,
+				ROW_NUMBER() OVER (PARTITION BY s.transport_type ORDER BY s.distance) <= COALESCE(m.min_count, 0) AS reserved
+			FROM all_stations s
+			LEFT JOIN mode_minimums m ON m.mode = s.transport_type
+		),
+		-- Сначала резерв по приоритету, затем остальные по расстоянию
+		ranked_stations AS (
+			SELECT *,
+				ROW_NUMBER() OVER (
+					ORDER BY reserved DESC, CASE WHEN reserved THEN priority_rank ELSE 0 END ASC, distance ASC
+				) AS global_rank
+			FROM reserved_stations
+		)`
+}
+
+// GetStationEntrances возвращает входы в метро для станций. Запросы станций по умолчанию схлопывают входы
+// (DISTINCT ON normalized_name), здесь они, наоборот, отдаются дочерними точками станции.
+// Вход в радиусе нескольких станций достается одной: сначала станции, чье название входит
+// в название входа, затем ближайшей.
+func (r *transportRepository) GetStationEntrances(ctx context.Context, stationIDs []int64, maxDistanceM float64) (map[int64][]domain.StationEntrance, error) {
+	result := make(map[int64][]domain.StationEntrance)
+	if len(stationIDs) == 0 {
+		return result, nil
+	}
+	if maxDistanceM <= 0 {
+		maxDistanceM = domain.StationEntranceMaxDistanceM
+	}
+
+	query := fmt.Sprintf(`
+		WITH stations AS (
+			SELECT osm_id, LOWER(COALESCE(name, '')) AS name, way_geog
+			FROM %s
+			WHERE osm_id = ANY($1)
+		),
+		candidates AS (
+			SELECT DISTINCT ON (e.osm_id)
+				s.osm_id AS station_id,
+				e.osm_id AS entrance_id,
+				COALESCE(e.name, '') AS name,
+				COALESCE(e.tags->'ref', '') AS ref,
+				ST_Y(e.way_geog::geometry) AS lat,
+				ST_X(e.way_geog::geometry) AS lon,
+				ST_Distance(e.way_geog, s.way_geog) AS distance
+			FROM stations s
+			JOIN %s e ON e.railway = 'subway_entrance'
+				AND ST_DWithin(e.way_geog, s.way_geog, $2)
+				AND %s
+			ORDER BY e.osm_id,
+				(s.name != '' AND POSITION(s.name IN LOWER(COALESCE(e.name, ''))) > 0) DESC,
+				distance
+		)
+		SELECT station_id, entrance_id, name, ref, lat, lon, distance
+		FROM candidates
+		ORDER BY station_id, distance
+	`, planetPointTable, planetPointTable, r.queryOpts.activeFeatureCondition("e"))
+
+	rows, err := r.db.QueryxContext(ctx, query, pq.Array(stationIDs), maxDistanceM)
+	if err != nil {
+		r.logger.Error("failed to get subway entrances", zap.Int("stations_count", len(stationIDs)), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stationID int64
+		var e domain.StationEntrance
+		if err := rows.Scan(&stationID, &e.ID, &e.Name, &e.Ref, &e.Lat, &e.Lon, &e.DistanceM); err != nil {
+			r.logger.Error("failed to scan subway entrance", zap.Error(err))
+			continue
+		}
+		result[stationID] = append(result[stationID], e)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating subway entrances", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+
+	return result, nil
+}
+
 // GetNearestTransportByPriorityBatch возвращает ближайший транспорт с приоритетом для множества точек одним запросом.
 // Для каждой точки: сначала metro/train, потом добираем bus/tram до лимита.
 // Использует предвычисленную колонку way_geog для оптимальной производительности.
@@ -1295,15 +1988,15 @@ func (r *transportRepository) GetNearestTransportByPriorityBatch(
 		radiusM = 1500
 	}
 
-	// Строим VALUES для всех точек
+	// Строим VALUES для всех точек; radius_m = 0 - общий радиус $1
 	var valuesParts []string
 	for i, p := range points {
-		valuesParts = append(valuesParts, fmt.Sprintf("(%d, %f, %f)", i, p.Lon, p.Lat))
+		valuesParts = append(valuesParts, fmt.Sprintf("(%d, %f, %f, %f::float8)", i, p.Lon, p.Lat, math.Max(p.RadiusM, 0)))
 	}
 	valuesSQL := strings.Join(valuesParts, ", ")
 
 	query := fmt.Sprintf(`
-		WITH search_points(point_idx, lon, lat) AS (
+		WITH search_points(point_idx, lon, lat, radius_m) AS (
 			VALUES %s
 		),
 		-- Все станции в радиусе для каждой точки
@@ -1337,10 +2030,11 @@ func (r *transportRepository) GetNearestTransportByPriorityBatch(
 			FROM %s p
 			CROSS JOIN search_points sp
 			WHERE p.name IS NOT NULL AND p.name != ''
+			  AND %s
 			  AND ST_DWithin(
 				  p.way_geog, 
 				  ST_SetSRID(ST_MakePoint(sp.lon, sp.lat), %d)::geography, 
-				  $1
+				  COALESCE(NULLIF(sp.radius_m, 0), $1)
 			  )
 			  AND (
 				  (p.railway = 'station' AND (p.tags->'station' = 'subway' OR p.tags->'subway' = 'yes'))
@@ -1364,7 +2058,7 @@ func (r *transportRepository) GetNearestTransportByPriorityBatch(
 		FROM ranked_stations
 		WHERE global_rank <= $2
 		ORDER BY point_idx, priority_rank, distance
-	`, valuesSQL, SRID4326, planetPointTable, SRID4326)
+	`, valuesSQL, SRID4326, planetPointTable, r.queryOpts.activeFeatureCondition("p"), SRID4326)
 
 	rows, err := r.db.QueryxContext(ctx, query, radiusM, limitPerPoint)
 	if err != nil {
@@ -1383,7 +2077,7 @@ func (r *transportRepository) GetNearestTransportByPriorityBatch(
 		var s domain.NearestTransportWithLines
 		var nameEn string
 
-		err := rows.Scan(&pointIdx, &s.StationID, &s.Name, &nameEn, &s.Type, &s.Lat, &s.Lon, &s.Distance)
+		err := rows.Scan(&pointIdx, &s.StationID, &s.Name, &nameEn, &s.Type, &s.Lat, &s.Lon, &s.DistanceM)
 		if err != nil {
 			r.logger.Error("failed to scan batch station row", zap.Error(err))
 			continue
@@ -1467,6 +2161,7 @@ func (r *transportRepository) GetLinesByStationIDsBatch(
 			JOIN %s l ON ST_DWithin(l.way, sp.way, 100)
 			WHERE l.route IN ('subway', 'light_rail', 'train', 'tram', 'bus')
 			  AND l.ref IS NOT NULL AND l.ref != ''
+			  AND %s
 			ORDER BY sp.osm_id, l.ref, l.osm_id
 		)
 		SELECT station_id, line_id, name, ref, line_type, color
@@ -1481,7 +2176,7 @@ func (r *transportRepository) GetLinesByStationIDsBatch(
 				ELSE 6
 			END,
 			ref
-	`, planetPointTable, strings.Join(placeholders, ","), planetLineTable)
+	`, planetPointTable, strings.Join(placeholders, ","), planetLineTable, r.queryOpts.activeFeatureCondition("l"))
 
 	rows, err := r.db.QueryxContext(ctx, query, args...)
 	if err != nil {
@@ -1549,13 +2244,13 @@ func (r *transportRepository) GetStationsInBBox(
 	}
 
 	// Фильтр по типам транспорта
-	stationTypeFilter := ""
+	stationTypeFilter := " AND " + r.queryOpts.activeFeatureCondition("")
 	if len(types) > 0 {
 		filters := make([]string, 0, len(types))
 		for _, t := range types {
 			filters = append(filters, buildTransportTypeFilter(t))
 		}
-		stationTypeFilter = " AND (" + strings.Join(filters, " OR ") + ")"
+		stationTypeFilter += " AND (" + strings.Join(filters, " OR ") + ")"
 	}
 
 	// BBox envelope в SRID 3857
@@ -1573,7 +2268,7 @@ func (r *transportRepository) GetStationsInBBox(
 	`, planetPointTable, stationTypeFilter, bboxEnvelope)
 
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, swLon, swLat, neLon, neLat).Scan(&total)
+	err := r.readDB.QueryRowContext(ctx, countQuery, swLon, swLat, neLon, neLat).Scan(&total)
 	if err != nil {
 		r.logger.Error("failed to count stations in bbox", zap.Error(err))
 		return nil, 0, pkgerrors.ErrDatabaseError
@@ -1605,7 +2300,7 @@ func (r *transportRepository) GetStationsInBBox(
 		LIMIT $5 OFFSET $6
 	`, SRID4326, SRID4326, planetPointTable, stationTypeFilter, bboxEnvelope)
 
-	rows, err := r.db.QueryxContext(ctx, stationsQuery, swLon, swLat, neLon, neLat, limit, offset)
+	rows, err := r.readDB.QueryxContext(ctx, stationsQuery, swLon, swLat, neLon, neLat, limit, offset)
 	if err != nil {
 		r.logger.Error("failed to get stations in bbox", zap.Error(err))
 		return nil, 0, pkgerrors.ErrDatabaseError
@@ -1643,3 +2338,185 @@ func (r *transportRepository) GetStationsInBBox(
 
 	return stations, total, nil
 }
+
+// GetTransportCoverage возвращает количество станций по типам для каждого радиуса из bands (метры).
+// Станции дедуплицируются по нормализованному имени внутри типа (выходы метро считаются одной станцией).
+// Подсчет по всем радиусам выполняется одним запросом через условную агрегацию по расстоянию.
+func (r *transportRepository) GetTransportCoverage(ctx context.Context, lat, lon float64, bands []float64) (*domain.TransportCoverage, error) {
+	coverage := &domain.TransportCoverage{Lat: lat, Lon: lon, Bands: []domain.TransportCoverageBand{}}
+	if len(bands) == 0 {
+		return coverage, nil
+	}
+
+	maxRadius := 0.0
+	for _, b := range bands {
+		if b > maxRadius {
+			maxRadius = b
+		}
+	}
+
+	query := fmt.Sprintf(`
+		WITH search_point AS (
+			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d)::geography AS geom
+		),
+		stations AS (
+			SELECT DISTINCT ON (station_key, transport_type)
+				transport_type,
+				distance
+			FROM (
+				SELECT
+					%s AS transport_type,
+					COALESCE(
+						NULLIF(LOWER(REGEXP_REPLACE(COALESCE(name, ''), '[^a-zA-Zа-яА-Я0-9]', '', 'g')), ''),
+						osm_id::text
+					) AS station_key,
+					ST_Distance(way_geog, sp.geom) AS distance
+				FROM %s, search_point sp
+				WHERE ST_DWithin(way_geog, sp.geom, $3)
+				  AND (public_transport IS NOT NULL OR railway IN ('station', 'halt', 'tram_stop') OR highway = 'bus_stop' OR amenity = 'ferry_terminal')
+				  AND %s
+			) src
+			WHERE transport_type != 'other'
+			ORDER BY station_key, transport_type, distance
+		)
+		SELECT
+			b.radius AS radius_m,
+			s.transport_type,
+			COUNT(*) FILTER (WHERE s.distance <= b.radius) AS station_count
+		FROM unnest($4::float8[]) AS b(radius)
+		CROSS JOIN stations s
+		GROUP BY b.radius, s.transport_type
+	`, SRID4326, transportModeExpr, planetPointTable, r.queryOpts.activeFeatureCondition(""))
+
+	rows, err := r.db.QueryxContext(ctx, query, lon, lat, maxRadius, pq.Array(bands))
+	if err != nil {
+		r.logger.Error("failed to get transport coverage", zap.Float64s("bands", bands), zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	byRadius := make(map[float64]map[string]int, len(bands))
+	for rows.Next() {
+		var radius float64
+		var transportType string
+		var count int
+		if err := rows.Scan(&radius, &transportType, &count); err != nil {
+			r.logger.Error("failed to scan transport coverage row", zap.Error(err))
+			continue
+		}
+		if byRadius[radius] == nil {
+			byRadius[radius] = make(map[string]int)
+		}
+		if count > 0 {
+			byRadius[radius][transportType] = count
+		}
+	}
+
+	for _, radius := range bands {
+		band := domain.TransportCoverageBand{RadiusM: radius, Counts: map[string]int{}}
+		for transportType, count := range byRadius[radius] {
+			band.Counts[transportType] = count
+			band.Total += count
+		}
+		coverage.Bands = append(coverage.Bands, band)
+	}
+
+	return coverage, nil
+}
+
+// GetClusteredStations возвращает станции в радиусе с номером пространственного кластера.
+// ST_ClusterDBSCAN с minpoints = 1 дает то же разбиение, что ST_ClusterWithin, но как оконная функция
+// сохраняет станции строками. Кластеризация идет по way в метрах Меркатора, поэтому clusterM
+// растягивается на 1/cos(lat). Кластеры считаются по всем станциям радиуса, затем выбираются limit
+// кластеров с ближайшей станцией, и возвращаются все станции этих кластеров: иначе LIMIT по строкам
+// обрезал бы дальний кластер на части, и узел собрался бы из неполного набора станций.
+func (r *transportRepository) GetClusteredStations(
+	ctx context.Context,
+	lat, lon, radiusM, clusterM float64,
+	limit int,
+) ([]domain.ClusteredStation, error) {
+	if limit <= 0 || limit > LimitStations {
+		limit = LimitStations
+	}
+
+	query := fmt.Sprintf(`
+		WITH search_point AS (
+			SELECT ST_SetSRID(ST_MakePoint($1, $2), %d)::geography AS geom
+		),
+		stations AS (
+			SELECT
+				osm_id AS station_id,
+				name,
+				COALESCE(NULLIF(tags->'name:en', ''), name) AS name_en,
+				%s AS transport_type,
+				ST_Y(way_geog::geometry) AS lat,
+				ST_X(way_geog::geometry) AS lon,
+				ST_Distance(way_geog, sp.geom) AS distance,
+				way
+			FROM %s, search_point sp
+			WHERE name IS NOT NULL AND name != ''
+			  AND ST_DWithin(way_geog, sp.geom, $3)
+			  AND %s
+			  AND (
+				  (railway = 'station' AND (tags->'station' = 'subway' OR tags->'subway' = 'yes'))
+				  OR (railway IN ('station', 'halt') AND (tags->'station' IS NULL OR tags->'station' NOT IN ('subway', 'light_rail')))
+				  OR railway = 'tram_stop'
+				  OR highway = 'bus_stop'
+				  OR (public_transport IN ('platform', 'stop_position') AND tags->'bus' = 'yes')
+			  )
+		),
+		clustered AS (
+			SELECT
+				station_id, name, name_en, transport_type, lat, lon, distance,
+				ST_ClusterDBSCAN(way, eps => $4 / cos(radians($2)), minpoints => 1) OVER () AS cluster_id
+			FROM stations
+		),
+		nearest_clusters AS (
+			SELECT cluster_id
+			FROM clustered
+			GROUP BY cluster_id
+			ORDER BY MIN(distance)
+			LIMIT $5
+		)
+		SELECT station_id, name, name_en, transport_type, lat, lon, distance, cluster_id
+		FROM clustered
+		WHERE cluster_id IN (SELECT cluster_id FROM nearest_clusters)
+		ORDER BY distance
+	`, SRID4326, transportModeExpr, planetPointTable, r.queryOpts.activeFeatureCondition(""))
+
+	rows, err := r.readDB.QueryxContext(ctx, query, lon, lat, radiusM, clusterM, limit)
+	if err != nil {
+		r.logger.Error("failed to get clustered stations", zap.Error(err))
+		return nil, pkgerrors.ErrDatabaseError
+	}
+	defer rows.Close()
+
+	var stations []domain.ClusteredStation
+	var stationIDs []int64
+	for rows.Next() {
+		var s domain.ClusteredStation
+		var nameEn string
+		if err := rows.Scan(&s.StationID, &s.Name, &nameEn, &s.Type, &s.Lat, &s.Lon, &s.DistanceM, &s.Cluster); err != nil {
+			r.logger.Error("failed to scan clustered station row", zap.Error(err))
+			continue
+		}
+		if nameEn != s.Name {
+			s.NameEn = &nameEn
+		}
+		stations = append(stations, s)
+		stationIDs = append(stationIDs, s.StationID)
+	}
+
+	if len(stationIDs) > 0 {
+		linesMap, err := r.GetLinesByStationIDsBatch(ctx, stationIDs)
+		if err != nil {
+			r.logger.Warn("failed to get lines for clustered stations", zap.Error(err))
+		} else {
+			for i := range stations {
+				stations[i].Lines = linesMap[stations[i].StationID]
+			}
+		}
+	}
+
+	return stations, nil
+}
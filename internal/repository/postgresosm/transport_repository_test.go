@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/location-microservice/internal/domain"
 	pkgerrors "github.com/location-microservice/internal/pkg/errors"
 )
 
@@ -12,7 +13,7 @@ func TestTransportRepository_GetNearestStations(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get nearest stations without type filter", func(t *testing.T) {
@@ -20,7 +21,7 @@ func TestTransportRepository_GetNearestStations(t *testing.T) {
 		lat, lon := 41.3851, 2.1734
 		maxDistance := 2.0
 
-		stations, err := repo.GetNearestStations(ctx, lat, lon, nil, maxDistance, 10)
+		stations, err := repo.GetNearestStations(ctx, lat, lon, nil, maxDistance, 10, false, 0)
 		if err != nil {
 			t.Fatalf("Failed to get nearest stations: %v", err)
 		}
@@ -55,7 +56,7 @@ func TestTransportRepository_GetNearestStations(t *testing.T) {
 		maxDistance := 5.0
 		types := []string{"station", "stop"}
 
-		stations, err := repo.GetNearestStations(ctx, lat, lon, types, maxDistance, 20)
+		stations, err := repo.GetNearestStations(ctx, lat, lon, types, maxDistance, 20, false, 0)
 		if err != nil {
 			t.Fatalf("Failed to get nearest stations with filter: %v", err)
 		}
@@ -69,7 +70,7 @@ func TestTransportRepository_GetNearestStations(t *testing.T) {
 		lat, lon := 41.3851, 2.1734
 		maxDistance := 3.0
 
-		stations, err := repo.GetNearestStations(ctx, lat, lon, nil, maxDistance, 0)
+		stations, err := repo.GetNearestStations(ctx, lat, lon, nil, maxDistance, 0, false, 0)
 		if err != nil {
 			t.Fatalf("Failed to get nearest stations: %v", err)
 		}
@@ -84,7 +85,7 @@ func TestTransportRepository_GetNearestStations(t *testing.T) {
 		maxDistance := 10.0
 		limit := 5
 
-		stations, err := repo.GetNearestStations(ctx, lat, lon, nil, maxDistance, limit)
+		stations, err := repo.GetNearestStations(ctx, lat, lon, nil, maxDistance, limit, false, 0)
 		if err != nil {
 			t.Fatalf("Failed to get nearest stations: %v", err)
 		}
@@ -93,6 +94,102 @@ func TestTransportRepository_GetNearestStations(t *testing.T) {
 			t.Errorf("Expected at most %d stations, got %d", limit, len(stations))
 		}
 	})
+
+	t.Run("Get nearest stations surface only", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+		maxDistance := 1.0
+
+		all, err := repo.GetNearestStations(ctx, lat, lon, nil, maxDistance, 100, false, 0)
+		if err != nil {
+			t.Fatalf("Failed to get nearest stations: %v", err)
+		}
+
+		surface, err := repo.GetNearestStations(ctx, lat, lon, nil, maxDistance, 100, true, 0)
+		if err != nil {
+			t.Fatalf("Failed to get surface stations: %v", err)
+		}
+
+		if len(surface) > len(all) {
+			t.Errorf("Expected surface-only result (%d) to be a subset of all stations (%d)", len(surface), len(all))
+		}
+	})
+
+	t.Run("Get nearest stations excluding the query point", func(t *testing.T) {
+		lat, lon := 41.3851, 2.1734
+		excludeM := 100.0
+
+		stations, err := repo.GetNearestStations(ctx, lat, lon, nil, 1.0, 100, false, excludeM)
+		if err != nil {
+			t.Fatalf("Failed to get nearest stations with exclusion radius: %v", err)
+		}
+
+		for _, s := range stations {
+			if s.DistanceM != nil && *s.DistanceM < excludeM {
+				t.Errorf("Station %d at %.1fm should be excluded (exclude_within_m=%.0f)", s.OSMId, *s.DistanceM, excludeM)
+			}
+		}
+	})
+}
+
+func TestTransportRepository_GetTransportCoverage(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewTransportRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	bands := []float64{300, 600, 1000}
+	coverage, err := repo.GetTransportCoverage(ctx, 41.3851, 2.1734, bands)
+	if err != nil {
+		t.Fatalf("Failed to get transport coverage: %v", err)
+	}
+
+	if len(coverage.Bands) != len(bands) {
+		t.Fatalf("Expected %d bands, got %d", len(bands), len(coverage.Bands))
+	}
+
+	// Более широкий радиус не может содержать меньше станций
+	for i := 1; i < len(coverage.Bands); i++ {
+		if coverage.Bands[i].Total < coverage.Bands[i-1].Total {
+			t.Errorf("Band %.0fm total %d is less than band %.0fm total %d",
+				coverage.Bands[i].RadiusM, coverage.Bands[i].Total,
+				coverage.Bands[i-1].RadiusM, coverage.Bands[i-1].Total)
+		}
+	}
+}
+
+func TestTransportRepository_GetNearestStationsGroupedOrder(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewTransportRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	priorities := []domain.TransportPriority{
+		{Type: "metro", Limit: 3},
+		{Type: "bus", Limit: 3},
+	}
+
+	blocks, err := repo.GetNearestStationsGrouped(ctx, 41.3851, 2.1734, priorities, 1500, domain.NearestStationsOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get grouped stations: %v", err)
+	}
+	byDistance, err := repo.GetNearestStationsGrouped(ctx, 41.3851, 2.1734, priorities, 1500, domain.NearestStationsOptions{Order: domain.StationsOrderByDistance})
+	if err != nil {
+		t.Fatalf("Failed to get grouped stations by distance: %v", err)
+	}
+
+	if len(blocks) != len(byDistance) {
+		t.Fatalf("Expected the same stations in both orders, got %d and %d", len(blocks), len(byDistance))
+	}
+	for i := 1; i < len(byDistance); i++ {
+		if *byDistance[i].DistanceM < *byDistance[i-1].DistanceM {
+			t.Errorf("Station %q at %.0fm ranked after %q at %.0fm",
+				byDistance[i].Name, *byDistance[i].DistanceM, byDistance[i-1].Name, *byDistance[i-1].DistanceM)
+		}
+	}
 }
 
 func TestTransportRepository_GetLineByID(t *testing.T) {
@@ -100,7 +197,7 @@ func TestTransportRepository_GetLineByID(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get existing line by ID", func(t *testing.T) {
@@ -157,7 +254,7 @@ func TestTransportRepository_GetLinesByIDs(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get multiple lines by IDs", func(t *testing.T) {
@@ -238,7 +335,7 @@ func TestTransportRepository_GetStationsByLineID(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get stations by line ID returns empty for now", func(t *testing.T) {
@@ -264,12 +361,110 @@ func TestTransportRepository_GetStationsByLineID(t *testing.T) {
 	})
 }
 
+func TestTransportRepository_GetStationsAlongLine(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewTransportRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("Stations are unique by name within buffer", func(t *testing.T) {
+		var lineID int64
+		query := `SELECT osm_id FROM planet_osm_line 
+				  WHERE route IN ('subway', 'tram', 'train') 
+				  LIMIT 1`
+		err := db.QueryRowContext(ctx, query).Scan(&lineID)
+		if err != nil {
+			t.Skipf("No transport lines found")
+		}
+
+		stations, err := repo.GetStationsAlongLine(ctx, lineID, 50)
+		if err != nil {
+			t.Fatalf("Failed to get stations along line: %v", err)
+		}
+
+		seen := make(map[string]bool)
+		for _, s := range stations {
+			if s.DistanceM == nil || *s.DistanceM > 50.5 {
+				t.Errorf("Station %d is outside buffer: %v", s.OSMId, s.DistanceM)
+			}
+			if s.Name == "" {
+				continue
+			}
+			if seen[s.Name] {
+				t.Errorf("Duplicate station name %q", s.Name)
+			}
+			seen[s.Name] = true
+		}
+	})
+
+	t.Run("Unknown line returns empty", func(t *testing.T) {
+		stations, err := repo.GetStationsAlongLine(ctx, 0, 50)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(stations) != 0 {
+			t.Errorf("Expected no stations, got %d", len(stations))
+		}
+	})
+}
+
+func TestTransportRepository_GetStationsInBoundary(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewTransportRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	t.Run("Unknown boundary", func(t *testing.T) {
+		_, err := repo.GetStationsInBoundary(ctx, 0, nil)
+		if err != pkgerrors.ErrLocationNotFound {
+			t.Errorf("Expected ErrLocationNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Metro stations are unique by name", func(t *testing.T) {
+		var boundaryID int64
+		query := `SELECT osm_id FROM planet_osm_polygon
+				  WHERE boundary = 'administrative' AND admin_level = '8'
+				  LIMIT 1`
+		err := db.QueryRowContext(ctx, query).Scan(&boundaryID)
+		if err != nil {
+			t.Skipf("No level 8 boundaries found in database: %v", err)
+		}
+
+		stations, err := repo.GetStationsInBoundary(ctx, boundaryID, []string{"metro"})
+		if err != nil {
+			t.Fatalf("Failed to get stations in boundary: %v", err)
+		}
+		if len(stations) > LimitBoundaryStations {
+			t.Errorf("Expected at most %d stations, got %d", LimitBoundaryStations, len(stations))
+		}
+
+		seen := make(map[string]bool)
+		for _, s := range stations {
+			if s.Type != "metro" {
+				t.Errorf("Expected metro station, got %q", s.Type)
+			}
+			if s.Name == "" {
+				continue
+			}
+			if seen[s.Name] {
+				t.Errorf("Duplicate station name %q", s.Name)
+			}
+			seen[s.Name] = true
+		}
+	})
+}
+
 func TestTransportRepository_GetTransportTile(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get transport tile", func(t *testing.T) {
@@ -306,7 +501,7 @@ func TestTransportRepository_GetLineTile(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get line tile", func(t *testing.T) {
@@ -348,7 +543,7 @@ func TestTransportRepository_GetLinesTile(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get lines tile", func(t *testing.T) {
@@ -404,7 +599,7 @@ func TestTransportRepository_GetStationsInRadius(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get stations in radius", func(t *testing.T) {
@@ -447,7 +642,7 @@ func TestTransportRepository_GetLinesInRadius(t *testing.T) {
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get lines in radius", func(t *testing.T) {
@@ -487,12 +682,61 @@ func TestTransportRepository_GetLinesInRadius(t *testing.T) {
 	})
 }
 
+func TestTransportRepository_GetLinesInBBox(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewTransportRepository(db, QueryOptions{})
+	ctx := context.Background()
+	bbox := domain.BoundingBox{MinLat: 41.37, MinLon: 2.15, MaxLat: 41.40, MaxLon: 2.19} // Barcelona center
+
+	t.Run("Lines are deduplicated by ref", func(t *testing.T) {
+		lines, err := repo.GetLinesInBBox(ctx, bbox, nil)
+		if err != nil {
+			t.Fatalf("Failed to get lines in bbox: %v", err)
+		}
+
+		if len(lines) > LimitLinesInBBox {
+			t.Errorf("Expected at most %d lines, got %d", LimitLinesInBBox, len(lines))
+		}
+
+		seen := make(map[string]bool)
+		for _, line := range lines {
+			key := line.Type + "/" + line.Ref
+			if line.Ref == "" {
+				key = line.Type + "/name:" + line.Name
+			}
+			if seen[key] {
+				t.Errorf("Line %s returned more than once", key)
+			}
+			seen[key] = true
+			if len(line.Geometry) == 0 {
+				t.Errorf("Expected geometry for line %d", line.LineID)
+			}
+		}
+	})
+
+	t.Run("Filter by mode", func(t *testing.T) {
+		lines, err := repo.GetLinesInBBox(ctx, bbox, []string{"metro"})
+		if err != nil {
+			t.Fatalf("Failed to get metro lines in bbox: %v", err)
+		}
+
+		for _, line := range lines {
+			if line.Type != "subway" {
+				t.Errorf("Expected subway line, got %s", line.Type)
+			}
+		}
+	})
+}
+
 func TestTransportRepository_GetTransportRadiusTile(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
 	skipIfNoOSMData(t, db)
 
-	repo := NewTransportRepository(db)
+	repo := NewTransportRepository(db, QueryOptions{})
 	ctx := context.Background()
 
 	t.Run("Get transport radius tile", func(t *testing.T) {
@@ -537,3 +781,188 @@ func TestTransportRepository_GetTransportRadiusTile(t *testing.T) {
 		}
 	})
 }
+
+func TestTransportRepository_GetNearestTransportByPriorityMinPerMode(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewTransportRepository(db, QueryOptions{})
+	ctx := context.Background()
+	lat, lon := 41.3917, 2.1649 // Passeig de Gràcia
+
+	stations, err := repo.GetNearestTransportByPriority(ctx, lat, lon, 1500, 3, map[string]int{"bus": 1})
+	if err != nil {
+		t.Fatalf("Failed to get priority transport with min per mode: %v", err)
+	}
+	if len(stations) > 3 {
+		t.Errorf("Expected at most 3 stations, got %d", len(stations))
+	}
+
+	all, err := repo.GetNearestTransportByPriority(ctx, lat, lon, 1500, LimitStations, nil)
+	if err != nil {
+		t.Fatalf("Failed to get priority transport: %v", err)
+	}
+	hasBus := false
+	for _, s := range all {
+		hasBus = hasBus || s.Type == "bus"
+	}
+	if !hasBus {
+		t.Skip("No bus stops near test point")
+	}
+
+	busCount := 0
+	for _, s := range stations {
+		if s.Type == "bus" {
+			busCount++
+		}
+	}
+	if busCount == 0 {
+		t.Errorf("Expected a reserved bus stop among %d stations", len(stations))
+	}
+}
+
+func TestTransportRepository_GetSnappedDistances(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewTransportRepository(db, QueryOptions{})
+	ctx := context.Background()
+	lat, lon := 41.3917, 2.1649 // Passeig de Gràcia
+
+	stations, err := repo.GetNearestTransportByPriority(ctx, lat, lon, 1500, 10, nil)
+	if err != nil {
+		t.Fatalf("Failed to get priority transport: %v", err)
+	}
+	if len(stations) == 0 {
+		t.Skip("No stations near test point")
+	}
+	ids := make([]int64, len(stations))
+	straight := make(map[int64]float64, len(stations))
+	for i, s := range stations {
+		ids[i] = s.StationID
+		straight[s.StationID] = s.DistanceM
+	}
+
+	distances, err := repo.GetSnappedDistances(ctx, lat, lon, ids)
+	if err != nil {
+		t.Fatalf("Failed to get snapped distances: %v", err)
+	}
+
+	for id, d := range distances {
+		if d.OriginOffsetM > domain.SnappedDistanceMaxOffsetM || d.StationOffsetM > domain.SnappedDistanceMaxOffsetM {
+			t.Errorf("Station %d snapped farther than %.0fm: %+v", id, domain.SnappedDistanceMaxOffsetM, d)
+		}
+		// Путь через точки привязки не короче прямой (допуск на округление)
+		if total := d.OriginOffsetM + d.NetworkM + d.StationOffsetM; total+1 < straight[id] {
+			t.Errorf("Station %d snapped distance %.1f shorter than straight %.1f", id, total, straight[id])
+		}
+	}
+
+	empty, err := repo.GetSnappedDistances(ctx, lat, lon, nil)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Expected empty result without stations, got %v, %v", empty, err)
+	}
+}
+
+func TestTransportRepository_GetStationEntrances(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewTransportRepository(db, QueryOptions{})
+	ctx := context.Background()
+
+	stations, err := repo.GetNearestTransportByPriority(ctx, 41.3917, 2.1649, 1500, 10, nil) // Passeig de Gràcia
+	if err != nil {
+		t.Fatalf("Failed to get priority transport: %v", err)
+	}
+	var metroIDs []int64
+	for _, s := range stations {
+		if s.Type == "metro" {
+			metroIDs = append(metroIDs, s.StationID)
+		}
+	}
+	if len(metroIDs) == 0 {
+		t.Skip("No metro stations near test point")
+	}
+
+	entrances, err := repo.GetStationEntrances(ctx, metroIDs, 300)
+	if err != nil {
+		t.Fatalf("Failed to get station entrances: %v", err)
+	}
+
+	seen := make(map[int64]int64)
+	for stationID, list := range entrances {
+		for i, e := range list {
+			if e.DistanceM > 300 {
+				t.Errorf("Entrance %d is %.0fm from station %d, outside radius", e.ID, e.DistanceM, stationID)
+			}
+			if i > 0 && list[i-1].DistanceM > e.DistanceM {
+				t.Errorf("Entrances of station %d are not ordered by distance", stationID)
+			}
+			if other, ok := seen[e.ID]; ok {
+				t.Errorf("Entrance %d attached to stations %d and %d", e.ID, other, stationID)
+			}
+			seen[e.ID] = stationID
+		}
+	}
+}
+
+func TestTransportRepository_GetClusteredStations(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	skipIfNoOSMData(t, db)
+
+	repo := NewTransportRepository(db, QueryOptions{})
+	ctx := context.Background()
+	lat, lon := 41.3870, 2.1700 // Plaça de Catalunya
+
+	stations, err := repo.GetClusteredStations(ctx, lat, lon, 500, 150, 2)
+	if err != nil {
+		t.Fatalf("Failed to get clustered stations: %v", err)
+	}
+	clusters := make(map[int]bool)
+	for _, s := range stations {
+		clusters[s.Cluster] = true
+	}
+	if len(clusters) > 2 {
+		t.Errorf("Expected at most 2 clusters, got %d", len(clusters))
+	}
+
+	// Кластеры возвращаются целиком: лимит не обрезает станции кластера
+	all, err := repo.GetClusteredStations(ctx, lat, lon, 500, 150, 0)
+	if err != nil {
+		t.Fatalf("Failed to get clustered stations: %v", err)
+	}
+	returned := make(map[int64]bool, len(stations))
+	for _, s := range stations {
+		returned[s.StationID] = true
+	}
+	// Номера кластеров у разных запросов свои, поэтому кластеры сверяются по составу станций
+	members := make(map[int][]int64)
+	for _, s := range all {
+		members[s.Cluster] = append(members[s.Cluster], s.StationID)
+	}
+	for _, s := range all {
+		if !returned[s.StationID] {
+			continue
+		}
+		for _, id := range members[s.Cluster] {
+			if !returned[id] {
+				t.Errorf("Station %d of the cluster with station %d is missing", id, s.StationID)
+			}
+		}
+	}
+
+	for i, s := range stations {
+		if s.DistanceM > 500 {
+			t.Errorf("Station %d is outside radius: %f m", s.StationID, s.DistanceM)
+		}
+		if i > 0 && s.DistanceM < stations[i-1].DistanceM {
+			t.Error("Expected stations ordered by distance")
+		}
+		assertValidCoordinates(t, s.Lat, s.Lon)
+	}
+}
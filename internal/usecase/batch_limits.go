@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/location-microservice/internal/pkg/errors"
+)
+
+// Ограничения пакетных запросов по умолчанию
+const (
+	DefaultMaxBatchSize   = 100 // максимум точек/локаций в одном пакетном запросе
+	DefaultBatchChunkSize = 50  // размер под-пакета: больший пакет выполняется несколькими запросами к БД последовательно
+)
+
+var (
+	maxBatchSize   = DefaultMaxBatchSize
+	batchChunkSize = DefaultBatchChunkSize
+)
+
+// ConfigureBatchLimits задает максимальный размер пакетного запроса и размер под-пакета.
+// Вызывается один раз при старте, до обработки запросов; 0 - значение по умолчанию.
+func ConfigureBatchLimits(maxSize, chunkSize int) error {
+	if maxSize == 0 {
+		maxSize = DefaultMaxBatchSize
+	}
+	if chunkSize == 0 {
+		chunkSize = DefaultBatchChunkSize
+	}
+	if maxSize < 1 || chunkSize < 1 {
+		return fmt.Errorf("batch limits must be positive, got max=%d chunk=%d", maxSize, chunkSize)
+	}
+
+	maxBatchSize = maxSize
+	batchChunkSize = chunkSize
+	return nil
+}
+
+// validateBatchSize возвращает ErrBatchTooLarge (413) с лимитом, если пакет больше допустимого
+func validateBatchSize(size int) error {
+	if size > maxBatchSize {
+		return errors.ErrBatchTooLarge.WithDetails(map[string]interface{}{
+			"batch_size":     size,
+			"max_batch_size": maxBatchSize,
+		})
+	}
+	return nil
+}
+
+// batchChunks разбивает [0, size) на последовательные диапазоны [start, end) не больше batchChunkSize
+func batchChunks(size int) [][2]int {
+	chunks := make([][2]int, 0, (size+batchChunkSize-1)/batchChunkSize)
+	for start := 0; start < size; start += batchChunkSize {
+		end := start + batchChunkSize
+		if end > size {
+			end = size
+		}
+		chunks = append(chunks, [2]int{start, end})
+	}
+	return chunks
+}
@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
 	"github.com/location-microservice/internal/usecase"
 	"github.com/location-microservice/internal/usecase/dto"
 )
@@ -21,12 +23,13 @@ func TestPOIUseCase_GetPOIInBBox(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockPOI := &mockPOIRepository{}
-		uc := usecase.NewPOIUseCase(mockPOI, logger)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 		ctx := context.Background()
 
 		mockPOI.On("GetPOIInBBox", mock.Anything,
 			41.38, 2.17, 41.40, 2.19,
 			[]string{"healthcare"}, []string{"pharmacy"},
+			domain.POIImportanceFilter{},
 			10, 0,
 		).Return([]*domain.POI{
 			{
@@ -66,12 +69,36 @@ func TestPOIUseCase_GetPOIInBBox(t *testing.T) {
 		assert.Equal(t, 1, result.Total)
 		assert.Equal(t, 10, result.Limit)
 		assert.Equal(t, 0, result.Offset)
+		assert.False(t, result.Page.HasMore)
+		assert.Empty(t, result.Page.NextCursor)
+		mockPOI.AssertExpectations(t)
+	})
+
+	t.Run("next page cursor", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		mockPOI.On("GetPOIInBBox", mock.Anything,
+			41.38, 2.17, 41.40, 2.19,
+			[]string(nil), []string(nil),
+			domain.POIImportanceFilter{},
+			10, 10,
+		).Return([]*domain.POI{}, 25, nil)
+
+		result, err := uc.GetPOIInBBox(context.Background(), dto.BBoxPOIRequest{
+			SwLat: 41.38, SwLon: 2.17, NeLat: 41.40, NeLon: 2.19,
+			Limit: 10, Offset: 10,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, result.Page.HasMore)
+		assert.Equal(t, "20", result.Page.NextCursor)
 		mockPOI.AssertExpectations(t)
 	})
 
 	t.Run("invalid coordinates", func(t *testing.T) {
 		mockPOI := &mockPOIRepository{}
-		uc := usecase.NewPOIUseCase(mockPOI, logger)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 
 		req := dto.BBoxPOIRequest{
 			SwLat: 999,
@@ -89,13 +116,14 @@ func TestPOIUseCase_GetPOIInBBox(t *testing.T) {
 
 	t.Run("default limit applied when zero", func(t *testing.T) {
 		mockPOI := &mockPOIRepository{}
-		uc := usecase.NewPOIUseCase(mockPOI, logger)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 		ctx := context.Background()
 
 		// Expect default limit of 10
 		mockPOI.On("GetPOIInBBox", mock.Anything,
 			41.38, 2.17, 41.40, 2.19,
 			[]string(nil), []string(nil),
+			domain.POIImportanceFilter{},
 			10, 0,
 		).Return([]*domain.POI{}, 0, nil)
 
@@ -118,12 +146,13 @@ func TestPOIUseCase_GetPOIInBBox(t *testing.T) {
 
 	t.Run("limit capped at 100", func(t *testing.T) {
 		mockPOI := &mockPOIRepository{}
-		uc := usecase.NewPOIUseCase(mockPOI, logger)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 		ctx := context.Background()
 
 		mockPOI.On("GetPOIInBBox", mock.Anything,
 			41.38, 2.17, 41.40, 2.19,
 			[]string(nil), []string(nil),
+			domain.POIImportanceFilter{},
 			100, 0,
 		).Return([]*domain.POI{}, 0, nil)
 
@@ -143,6 +172,103 @@ func TestPOIUseCase_GetPOIInBBox(t *testing.T) {
 		assert.Equal(t, 100, result.Limit)
 		mockPOI.AssertExpectations(t)
 	})
+
+	t.Run("min importance and order by importance", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		mockPOI.On("GetPOIInBBox", mock.Anything,
+			41.38, 2.17, 41.40, 2.19,
+			[]string(nil), []string(nil),
+			domain.POIImportanceFilter{MinImportance: 5, OrderByImportance: true},
+			10, 0,
+		).Return([]*domain.POI{}, 3, nil)
+
+		result, err := uc.GetPOIInBBox(context.Background(), dto.BBoxPOIRequest{
+			SwLat: 41.38, SwLon: 2.17, NeLat: 41.40, NeLon: 2.19,
+			MinImportance: 5,
+			OrderBy:       "importance",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Total)
+		mockPOI.AssertExpectations(t)
+	})
+
+	t.Run("min importance out of range", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		_, err := uc.GetPOIInBBox(context.Background(), dto.BBoxPOIRequest{
+			SwLat: 41.38, SwLon: 2.17, NeLat: 41.40, NeLon: 2.19,
+			MinImportance: 11,
+		})
+
+		assert.Error(t, err)
+		mockPOI.AssertNotCalled(t, "GetPOIInBBox")
+	})
+}
+
+// TestPOIUseCase_GetActivityCenter tests the GetActivityCenter usecase method
+func TestPOIUseCase_GetActivityCenter(t *testing.T) {
+	logger := zap.NewNop()
+	bbox := domain.BoundingBox{MinLat: 41.38, MinLon: 2.17, MaxLat: 41.40, MaxLon: 2.19}
+
+	t.Run("weighted center", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		mockPOI.On("GetActivityCenter", mock.Anything, bbox, []string{"leisure"}, true).
+			Return(&domain.ActivityCenter{Lat: 41.391234567, Lon: 2.181234567, POICount: 42}, nil)
+
+		result, err := uc.GetActivityCenter(context.Background(), dto.ActivityCenterRequest{
+			SwLat: 41.38, SwLon: 2.17, NeLat: 41.40, NeLon: 2.19,
+			Categories: []string{"leisure"},
+			Weighted:   true,
+		})
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, result.Lat) && assert.NotNil(t, result.Lon) {
+			assert.InDelta(t, 41.391234567, *result.Lat, 1e-6)
+			assert.InDelta(t, 2.181234567, *result.Lon, 1e-6)
+		}
+		assert.Equal(t, 42, result.POICount)
+		assert.True(t, result.Weighted)
+		mockPOI.AssertExpectations(t)
+	})
+
+	t.Run("no POI in bbox", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		mockPOI.On("GetActivityCenter", mock.Anything, bbox, []string(nil), false).
+			Return(&domain.ActivityCenter{}, nil)
+
+		result, err := uc.GetActivityCenter(context.Background(), dto.ActivityCenterRequest{
+			SwLat: 41.38, SwLon: 2.17, NeLat: 41.40, NeLon: 2.19,
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Lat)
+		assert.Nil(t, result.Lon)
+		assert.Equal(t, 0, result.POICount)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		for name, req := range map[string]dto.ActivityCenterRequest{
+			"invalid coordinates": {SwLat: 999, SwLon: 2.17, NeLat: 41.40, NeLon: 2.19},
+			"inverted corners":    {SwLat: 41.40, SwLon: 2.19, NeLat: 41.38, NeLon: 2.17},
+			"bbox too wide":       {SwLat: 41.38, SwLon: 0.17, NeLat: 41.40, NeLon: 2.19},
+			"unknown category":    {SwLat: 41.38, SwLon: 2.17, NeLat: 41.40, NeLon: 2.19, Categories: []string{"nightlife"}},
+		} {
+			_, err := uc.GetActivityCenter(context.Background(), req)
+			assert.Error(t, err, name)
+		}
+		mockPOI.AssertNotCalled(t, "GetActivityCenter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 // TestTransportUseCase_GetTransportInBBox tests the GetTransportInBBox usecase method
@@ -270,3 +396,73 @@ func TestTransportUseCase_GetTransportInBBox(t *testing.T) {
 		mockTransport.AssertExpectations(t)
 	})
 }
+
+// TestPOIUseCase_GetCategoryTree tests the GetCategoryTree usecase method
+func TestPOIUseCase_GetCategoryTree(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	cacheKey := "stats:poi_category_tree"
+
+	tree := func() []domain.CategoryNode {
+		return []domain.CategoryNode{
+			{ID: 1, Code: "healthcare", POICount: 12, Subcategories: []domain.SubcategoryNode{
+				{ID: 2, Code: "hospital", POICount: 2},
+				{ID: 3, Code: "pharmacy", POICount: 10},
+			}},
+		}
+	}
+
+	t.Run("fetches from db, caches without labels", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		mockCache := &MockCacheRepository{}
+
+		unlabeled, _ := json.Marshal(tree())
+		mockCache.On("Get", ctx, cacheKey).Return(nil, errors.ErrCacheError)
+		mockPOI.On("GetCategoryTree", ctx).Return(tree(), nil)
+		mockCache.On("Set", ctx, cacheKey, unlabeled, mock.Anything).Return(nil)
+
+		uc := usecase.NewPOIUseCase(mockPOI, mockCache, logger)
+		result, err := uc.GetCategoryTree(ctx, "es")
+
+		assert.NoError(t, err)
+		if assert.Len(t, result, 1) && assert.Len(t, result[0].Subcategories, 2) {
+			assert.Equal(t, "Salud", result[0].Name)
+			assert.Equal(t, "Farmacia", result[0].Subcategories[1].Name)
+			assert.Equal(t, 12, result[0].POICount)
+		}
+		mockPOI.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("served from cache with labels of the requested language", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		mockCache := &MockCacheRepository{}
+
+		cached, _ := json.Marshal(tree())
+		mockCache.On("Get", ctx, cacheKey).Return(cached, nil)
+
+		uc := usecase.NewPOIUseCase(mockPOI, mockCache, logger)
+		result, err := uc.GetCategoryTree(ctx, "de")
+
+		assert.NoError(t, err)
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "Gesundheit", result[0].Name)
+			assert.Equal(t, "Krankenhaus", result[0].Subcategories[0].Name)
+		}
+		mockPOI.AssertNotCalled(t, "GetCategoryTree", mock.Anything)
+	})
+
+	t.Run("database error is not cached", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		mockCache := &MockCacheRepository{}
+
+		mockCache.On("Get", ctx, cacheKey).Return(nil, errors.ErrCacheError)
+		mockPOI.On("GetCategoryTree", ctx).Return(nil, errors.ErrDatabaseError)
+
+		uc := usecase.NewPOIUseCase(mockPOI, mockCache, logger)
+		_, err := uc.GetCategoryTree(ctx, "es")
+
+		assert.ErrorIs(t, err, errors.ErrDatabaseError)
+		mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
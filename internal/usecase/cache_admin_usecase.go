@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+// CacheAdminUseCase - точечный сброс кеша после переимпорта данных без FLUSHDB и перезапуска
+type CacheAdminUseCase struct {
+	cacheRepo repository.CacheRepository
+	searchUC  *SearchUseCase // владелец in-process кеша ячеек обратного геокодирования
+	logger    *zap.Logger
+}
+
+// NewCacheAdminUseCase создает новый экземпляр CacheAdminUseCase
+func NewCacheAdminUseCase(cacheRepo repository.CacheRepository, searchUC *SearchUseCase, logger *zap.Logger) *CacheAdminUseCase {
+	return &CacheAdminUseCase{
+		cacheRepo: cacheRepo,
+		searchUC:  searchUC,
+		logger:    logger,
+	}
+}
+
+// Flush удаляет ключи пространства имен, шаблона внутри пространства или диапазона тайлов.
+// Пространство geocode очищает in-process кеш ячеек обратного геокодирования этого процесса:
+// в Redis его ключей нет, воркер и другие экземпляры API обновят ячейки по TTL.
+func (uc *CacheAdminUseCase) Flush(ctx context.Context, req dto.CacheFlushRequest) (*dto.CacheFlushResponse, error) {
+	if req.Namespace == domain.CacheFlushGeocode && req.Pattern == "" && req.TileRange == nil {
+		return &dto.CacheFlushResponse{Deleted: uc.searchUC.FlushGeocodeCells()}, nil
+	}
+
+	pattern, match, err := cacheFlushPattern(req)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := uc.cacheRepo.DeleteByPattern(ctx, pattern, match)
+	if err != nil {
+		uc.logger.Error("Failed to flush cache", zap.String("pattern", pattern), zap.Error(err))
+		return nil, errors.ErrCacheError
+	}
+
+	return &dto.CacheFlushResponse{Pattern: pattern, Deleted: deleted}, nil
+}
+
+// cacheFlushPattern переводит запрос в шаблон SCAN и дополнительный фильтр ключей.
+// Шаблон всегда начинается с известного пространства имен, поэтому сброс не задевает чужие ключи.
+func cacheFlushPattern(req dto.CacheFlushRequest) (string, func(string) bool, error) {
+	if (req.Namespace == "") == (req.Pattern == "") && req.TileRange == nil {
+		return "", nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"namespace": "exactly one of namespace, pattern or tile_range is required",
+		})
+	}
+
+	if req.TileRange != nil {
+		if req.Pattern != "" || (req.Namespace != "" && req.Namespace != "tiles") {
+			return "", nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+				"tile_range": "can only be combined with namespace tiles",
+			})
+		}
+
+		tr := req.TileRange
+		maxCoord := 1<<min(max(tr.Z, 0), domain.TileMaxZoom) - 1
+		if tr.Z < 0 || tr.Z > domain.TileMaxZoom ||
+			tr.MinX < 0 || tr.MinX > tr.MaxX || tr.MaxX > maxCoord ||
+			tr.MinY < 0 || tr.MinY > tr.MaxY || tr.MaxY > maxCoord {
+			return "", nil, errors.ErrInvalidTileCoordinates.WithDetails(map[string]interface{}{
+				"tile_range": "z must be 0-22, 0 <= min <= max < 2^z for x and y",
+			})
+		}
+
+		r := domain.TileCacheRange{Layer: tr.Layer, Z: tr.Z, MinX: tr.MinX, MaxX: tr.MaxX, MinY: tr.MinY, MaxY: tr.MaxY}
+		return r.Pattern(), r.Contains, nil
+	}
+
+	if req.Pattern != "" {
+		for _, prefix := range domain.CacheNamespaces {
+			if strings.HasPrefix(req.Pattern, prefix+":") {
+				return req.Pattern, nil, nil
+			}
+		}
+		return "", nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"pattern": "must start with a cache namespace prefix: tile:, search:, stats:",
+		})
+	}
+
+	prefix, ok := domain.CacheNamespaces[req.Namespace]
+	if !ok {
+		return "", nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"namespace": "must be one of: tiles, search, geocode, stats",
+		})
+	}
+	return prefix + ":*", nil, nil
+}
@@ -0,0 +1,115 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase"
+	"github.com/location-microservice/internal/usecase/dto"
+)
+
+func TestCacheAdminUseCase_Flush(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("namespace", func(t *testing.T) {
+		cache := &MockCacheRepository{}
+		uc := usecase.NewCacheAdminUseCase(cache, nil, zap.NewNop())
+		cache.On("DeleteByPattern", ctx, "tile:*", mock.Anything).Return(42, nil)
+
+		result, err := uc.Flush(ctx, dto.CacheFlushRequest{Namespace: "tiles"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "tile:*", result.Pattern)
+		assert.Equal(t, 42, result.Deleted)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("pattern inside namespace", func(t *testing.T) {
+		cache := &MockCacheRepository{}
+		uc := usecase.NewCacheAdminUseCase(cache, nil, zap.NewNop())
+		cache.On("DeleteByPattern", ctx, "tile:water:*", mock.Anything).Return(3, nil)
+
+		result, err := uc.Flush(ctx, dto.CacheFlushRequest{Pattern: "tile:water:*"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Deleted)
+	})
+
+	t.Run("tile range filters keys", func(t *testing.T) {
+		cache := &MockCacheRepository{}
+		uc := usecase.NewCacheAdminUseCase(cache, nil, zap.NewNop())
+		cache.On("DeleteByPattern", ctx, "tile:poi:14:*", mock.MatchedBy(func(match func(string) bool) bool {
+			return match("tile:poi:14:8290:6119:abc") && !match("tile:poi:14:9000:6119")
+		})).Return(1, nil)
+
+		_, err := uc.Flush(ctx, dto.CacheFlushRequest{
+			Namespace: "tiles",
+			TileRange: &dto.CacheFlushTileRange{Layer: "poi", Z: 14, MinX: 8290, MaxX: 8291, MinY: 6119, MaxY: 6119},
+		})
+
+		assert.NoError(t, err)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		cache := &MockCacheRepository{}
+		uc := usecase.NewCacheAdminUseCase(cache, nil, zap.NewNop())
+
+		_, err := uc.Flush(ctx, dto.CacheFlushRequest{})
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+
+		_, err = uc.Flush(ctx, dto.CacheFlushRequest{Namespace: "everything"})
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+
+		_, err = uc.Flush(ctx, dto.CacheFlushRequest{Pattern: "*"})
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+
+		_, err = uc.Flush(ctx, dto.CacheFlushRequest{TileRange: &dto.CacheFlushTileRange{Z: 2, MaxX: 4, MaxY: 1}})
+		assert.ErrorIs(t, err, errors.ErrInvalidTileCoordinates)
+
+		cache.AssertNotCalled(t, "DeleteByPattern", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("geocode clears in-process cells", func(t *testing.T) {
+		cache := &MockCacheRepository{}
+		mockBoundary := &MockBoundaryRepository{}
+		searchUC := usecase.NewSearchUseCase(mockBoundary, cache, zap.NewNop(), time.Hour, usecase.PolygonLimits{})
+		uc := usecase.NewCacheAdminUseCase(cache, searchUC, zap.NewNop())
+
+		cell, err := domain.NewGeocodeCell(-1, 10,
+			`{"type":"Polygon","coordinates":[[[2,42],[2,43],[3,43],[3,42],[2,42]]]}`)
+		assert.NoError(t, err)
+		mockBoundary.On("ReverseGeocode", ctx, 42.5, 2.5).Return(&domain.Address{City: "Olot"}, nil).Twice()
+		mockBoundary.On("GetGeocodeCell", ctx, 42.5, 2.5).Return(cell, nil).Once()
+		for i := 0; i < 2; i++ {
+			_, err := searchUC.ReverseGeocode(ctx, dto.ReverseGeocodeRequest{Lat: 42.5, Lon: 2.5})
+			assert.NoError(t, err)
+		}
+
+		result, err := uc.Flush(ctx, dto.CacheFlushRequest{Namespace: "geocode"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Deleted)
+		cache.AssertNotCalled(t, "DeleteByPattern", mock.Anything, mock.Anything, mock.Anything)
+
+		_, err = uc.Flush(ctx, dto.CacheFlushRequest{Pattern: "geocode:*"})
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	})
+
+	t.Run("redis error", func(t *testing.T) {
+		cache := &MockCacheRepository{}
+		uc := usecase.NewCacheAdminUseCase(cache, nil, zap.NewNop())
+		cache.On("DeleteByPattern", ctx, "stats:*", mock.Anything).Return(0, errors.ErrCacheError)
+
+		_, err := uc.Flush(ctx, dto.CacheFlushRequest{Namespace: "stats"})
+
+		assert.ErrorIs(t, err, errors.ErrCacheError)
+	})
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+// Тестовая точка по умолчанию для EXPLAIN (центр Барселоны)
+const (
+	defaultExplainLat = 41.3874
+	defaultExplainLon = 2.1686
+)
+
+// DebugUseCase - диагностика планов SQL запросов (только для dev окружения)
+type DebugUseCase struct {
+	debugRepo repository.DebugRepository
+	logger    *zap.Logger
+}
+
+// NewDebugUseCase создает новый экземпляр DebugUseCase
+func NewDebugUseCase(debugRepo repository.DebugRepository, logger *zap.Logger) *DebugUseCase {
+	return &DebugUseCase{
+		debugRepo: debugRepo,
+		logger:    logger,
+	}
+}
+
+// ListQueries возвращает список доступных шаблонов запросов
+func (uc *DebugUseCase) ListQueries() []string {
+	return uc.debugRepo.ListExplainQueries()
+}
+
+// Explain выполняет EXPLAIN для шаблона и выделяет seq scan / index scan узлы плана
+func (uc *DebugUseCase) Explain(ctx context.Context, name string, lat, lon *float64) (*dto.ExplainResponse, error) {
+	pointLat, pointLon := defaultExplainLat, defaultExplainLon
+	if lat != nil && lon != nil {
+		pointLat, pointLon = *lat, *lon
+	}
+	if !utils.ValidateCoordinates(pointLat, pointLon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+
+	plan, err := uc.debugRepo.ExplainQuery(ctx, name, pointLat, pointLon)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := summarizeExplainPlan(plan)
+	if len(summary.SeqScans) > 0 {
+		uc.logger.Warn("Sequential scan detected in query plan",
+			zap.String("query", name),
+			zap.Strings("tables", summary.SeqScans),
+		)
+	}
+
+	return &dto.ExplainResponse{
+		Query:         name,
+		Lat:           pointLat,
+		Lon:           pointLon,
+		SeqScans:      summary.SeqScans,
+		IndexScans:    summary.IndexScans,
+		ExecutionTime: summary.ExecutionTime,
+		Plan:          plan,
+	}, nil
+}
+
+// explainPlanNode - узел плана в формате EXPLAIN (FORMAT JSON)
+type explainPlanNode struct {
+	NodeType     string            `json:"Node Type"`
+	RelationName string            `json:"Relation Name"`
+	IndexName    string            `json:"Index Name"`
+	Plans        []explainPlanNode `json:"Plans"`
+}
+
+// explainSummary - краткая сводка по плану запроса
+type explainSummary struct {
+	SeqScans      []string
+	IndexScans    []string
+	ExecutionTime float64
+}
+
+// summarizeExplainPlan обходит дерево плана и собирает таблицы с seq scan и использованные индексы
+func summarizeExplainPlan(raw json.RawMessage) explainSummary {
+	summary := explainSummary{
+		SeqScans:   []string{},
+		IndexScans: []string{},
+	}
+
+	var plans []struct {
+		Plan          explainPlanNode `json:"Plan"`
+		ExecutionTime float64         `json:"Execution Time"`
+	}
+	if err := json.Unmarshal(raw, &plans); err != nil || len(plans) == 0 {
+		return summary
+	}
+
+	seenSeq := make(map[string]bool)
+	seenIdx := make(map[string]bool)
+
+	var walk func(node explainPlanNode)
+	walk = func(node explainPlanNode) {
+		switch {
+		case node.NodeType == "Seq Scan" && node.RelationName != "":
+			if !seenSeq[node.RelationName] {
+				seenSeq[node.RelationName] = true
+				summary.SeqScans = append(summary.SeqScans, node.RelationName)
+			}
+		case node.IndexName != "":
+			if !seenIdx[node.IndexName] {
+				seenIdx[node.IndexName] = true
+				summary.IndexScans = append(summary.IndexScans, node.IndexName)
+			}
+		}
+		for _, child := range node.Plans {
+			walk(child)
+		}
+	}
+
+	walk(plans[0].Plan)
+	summary.ExecutionTime = plans[0].ExecutionTime
+
+	return summary
+}
@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/location-microservice/internal/usecase"
+)
+
+// ---- Mock Debug Repository ----
+
+type mockDebugRepository struct {
+	mock.Mock
+}
+
+func (m *mockDebugRepository) ExplainQuery(ctx context.Context, name string, lat, lon float64) (json.RawMessage, error) {
+	args := m.Called(ctx, name, lat, lon)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(json.RawMessage), args.Error(1)
+}
+
+func (m *mockDebugRepository) ListExplainQueries() []string {
+	args := m.Called()
+	return args.Get(0).([]string)
+}
+
+const samplePlan = `[{"Plan": {"Node Type": "Nested Loop", "Plans": [
+	{"Node Type": "Seq Scan", "Relation Name": "planet_osm_line"},
+	{"Node Type": "Bitmap Heap Scan", "Relation Name": "planet_osm_polygon", "Plans": [
+		{"Node Type": "Bitmap Index Scan", "Index Name": "planet_osm_polygon_way_idx"}
+	]}
+]}, "Execution Time": 12.5}]`
+
+func TestDebugUseCase_Explain_SummarizesPlan(t *testing.T) {
+	repo := new(mockDebugRepository)
+	uc := usecase.NewDebugUseCase(repo, zap.NewNop())
+
+	lat, lon := 41.4, 2.17
+	repo.On("ExplainQuery", mock.Anything, "lines_in_radius", lat, lon).
+		Return(json.RawMessage(samplePlan), nil)
+
+	result, err := uc.Explain(context.Background(), "lines_in_radius", &lat, &lon)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"planet_osm_line"}, result.SeqScans)
+	assert.Equal(t, []string{"planet_osm_polygon_way_idx"}, result.IndexScans)
+	assert.Equal(t, 12.5, result.ExecutionTime)
+	repo.AssertExpectations(t)
+}
+
+func TestDebugUseCase_Explain_InvalidCoordinates(t *testing.T) {
+	repo := new(mockDebugRepository)
+	uc := usecase.NewDebugUseCase(repo, zap.NewNop())
+
+	lat, lon := 120.0, 2.17
+	_, err := uc.Explain(context.Background(), "lines_in_radius", &lat, &lon)
+
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "ExplainQuery")
+}
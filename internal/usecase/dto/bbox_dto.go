@@ -8,10 +8,31 @@ type BBoxPOIRequest struct {
 	NeLon         float64  `json:"ne_lon"`
 	Categories    []string `json:"categories,omitempty"`
 	Subcategories []string `json:"subcategories,omitempty"`
+	MinImportance int      `json:"min_importance,omitempty"` // 0-10, 0 - без отбора по значимости
+	OrderBy       string   `json:"order_by,omitempty"`       // importance - сначала значимые
 	Limit         int      `json:"limit"`
 	Offset        int      `json:"offset"`
 }
 
+// ActivityCenterRequest — запрос центра активности видимой области карты (bbox)
+type ActivityCenterRequest struct {
+	SwLat      float64  `json:"sw_lat"`
+	SwLon      float64  `json:"sw_lon"`
+	NeLat      float64  `json:"ne_lat"`
+	NeLon      float64  `json:"ne_lon"`
+	Categories []string `json:"categories,omitempty"`
+	Weighted   bool     `json:"weighted"`
+}
+
+// BBoxTransportLinesRequest — запрос на получение линий транспорта в видимой области карты (bbox)
+type BBoxTransportLinesRequest struct {
+	SwLat float64  `json:"sw_lat"`
+	SwLon float64  `json:"sw_lon"`
+	NeLat float64  `json:"ne_lat"`
+	NeLon float64  `json:"ne_lon"`
+	Types []string `json:"types,omitempty"`
+}
+
 // BBoxTransportRequest — запрос на получение транспортных станций в видимой области карты (bbox)
 type BBoxTransportRequest struct {
 	SwLat  float64  `json:"sw_lat"`
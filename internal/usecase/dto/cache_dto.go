@@ -0,0 +1,26 @@
+package dto
+
+// CacheFlushRequest — запрос сброса кеша: пространство имен, шаблон ключей или диапазон тайлов.
+// Задается ровно одно из Namespace и Pattern; TileRange сужает пространство tiles до прямоугольника тайлов.
+type CacheFlushRequest struct {
+	Namespace string               `json:"namespace,omitempty"` // tiles, search, stats (Redis) или geocode (ячейки в памяти)
+	Pattern   string               `json:"pattern,omitempty"`   // шаблон Redis внутри пространства: tile:water:*
+	TileRange *CacheFlushTileRange `json:"tile_range,omitempty"`
+}
+
+// CacheFlushTileRange — тайлы одного зума z в диапазоне x и y включительно; пустой layer - все слои
+type CacheFlushTileRange struct {
+	Layer string `json:"layer,omitempty"`
+	Z     int    `json:"z"`
+	MinX  int    `json:"min_x"`
+	MaxX  int    `json:"max_x"`
+	MinY  int    `json:"min_y"`
+	MaxY  int    `json:"max_y"`
+}
+
+// CacheFlushResponse — шаблон, по которому удалялись ключи, и число удаленных ключей
+// (для geocode - число ячеек, шаблон пустой)
+type CacheFlushResponse struct {
+	Pattern string `json:"pattern"`
+	Deleted int    `json:"deleted"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/location-microservice/internal/domain"
+)
+
+// ExplainResponse — результат EXPLAIN (ANALYZE, BUFFERS) для шаблона запроса
+type ExplainResponse struct {
+	Query         string          `json:"query"`
+	Lat           float64         `json:"lat"`
+	Lon           float64         `json:"lon"`
+	SeqScans      []string        `json:"seq_scans"`   // таблицы, прочитанные последовательным сканированием
+	IndexScans    []string        `json:"index_scans"` // использованные индексы
+	ExecutionTime float64         `json:"execution_time_ms"`
+	Plan          json.RawMessage `json:"plan"`
+}
+
+// EnrichmentDebugResponse — результат обогащения с путем резолва и частичными ошибками
+type EnrichmentDebugResponse struct {
+	ResolutionStrategy  string                    `json:"resolution_strategy"` // name:level8, coordinates, fallback:country, ...
+	Warnings            []string                  `json:"warnings"`
+	DiscardedBoundaries []DiscardedBoundary       `json:"discarded_boundaries,omitempty"` // пересекающиеся границы одного уровня
+	TransportRadiusM    float64                   `json:"transport_radius_m,omitempty"`   // радиус поиска транспорта, фиксированный или по плотности
+	Result              *domain.LocationDoneEvent `json:"result"`
+}
+
+// DiscardedBoundary — граница, отброшенная при резолве по координатам: в точке нашлась другая
+// граница того же уровня с меньшей площадью (ошибка разметки OSM или спорная территория)
+type DiscardedBoundary struct {
+	ID         int64    `json:"id"`
+	Name       string   `json:"name"`
+	AdminLevel int      `json:"admin_level"`
+	AreaSqKm   *float64 `json:"area_sq_km,omitempty"`
+	KeptID     int64    `json:"kept_id"` // граница, выбранная на этом уровне
+}
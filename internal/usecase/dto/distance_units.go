@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/utils"
+)
+
+// Расстояния считаются и кешируются usecase в метрах; в единицу distance_unit запроса их переводят
+// обработчики одним вызовом ConvertDistances перед отправкой ответа. Перевод идет на месте, поэтому
+// ConvertDistances вызывается для ответа один раз. radius_m, path_length_m и distance_m - всегда метры.
+
+// ConvertDistances переводит расстояния станций в unit
+func (r *NearestTransportResponse) ConvertDistances(unit utils.DistanceUnit) {
+	convertStations(r.Stations, unit)
+}
+
+// ConvertDistances переводит расстояния станций всех точек в unit
+func (r *BatchNearestTransportResponse) ConvertDistances(unit utils.DistanceUnit) {
+	for _, stations := range r.Results {
+		convertStations(stations, unit)
+	}
+}
+
+// ConvertDistances переводит расстояния станций и их входов в unit
+func (r *PriorityTransportResponse) ConvertDistances(unit utils.DistanceUnit) {
+	convertPriorityStations(r.Stations, unit)
+	r.Meta.DistanceUnit = string(unit)
+}
+
+// ConvertDistances переводит расстояния станций всех точек в unit
+func (r *PriorityTransportBatchResponse) ConvertDistances(unit utils.DistanceUnit) {
+	for i := range r.Results {
+		convertPriorityStations(r.Results[i].Stations, unit)
+	}
+	r.Meta.DistanceUnit = string(unit)
+}
+
+// ConvertDistances переводит расстояния узлов и их станций в unit
+func (r *StationComplexesResponse) ConvertDistances(unit utils.DistanceUnit) {
+	for i := range r.Complexes {
+		c := &r.Complexes[i]
+		c.Distance = unit.FromMeters(c.Distance)
+		for _, stations := range c.StationsByMode {
+			convertPriorityStations(stations, unit)
+		}
+	}
+	r.Meta.DistanceUnit = string(unit)
+}
+
+// ConvertDistances переводит расстояния POI в unit
+func (r *RadiusPOIResponse) ConvertDistances(unit utils.DistanceUnit) {
+	for i := range r.POIs {
+		r.POIs[i].Distance = unit.FromMeters(r.POIs[i].Distance)
+	}
+}
+
+// ConvertDistances переводит distance и path_offset POI в unit
+func (r *PathPOIResponse) ConvertDistances(unit utils.DistanceUnit) {
+	for i := range r.POIs {
+		r.POIs[i].Distance = unit.FromMeters(r.POIs[i].Distance)
+		r.POIs[i].PathOffset = unit.FromMeters(r.POIs[i].PathOffset)
+	}
+}
+
+// ConvertDistances переводит расстояния удобств и станций в unit
+func (r *NeighborhoodContextResponse) ConvertDistances(unit utils.DistanceUnit) {
+	for category, poi := range r.Amenities {
+		poi.Distance = unit.FromMeters(poi.Distance)
+		r.Amenities[category] = poi
+	}
+	convertPriorityStations(r.Transport, unit)
+	r.Meta.DistanceUnit = string(unit)
+}
+
+// ConvertDistances переводит расстояния до объектов окружения в unit
+func (r *EnvironmentSummaryResponse) ConvertDistances(unit utils.DistanceUnit) {
+	for _, item := range []*EnvironmentNearestItem{r.GreenSpace, r.Beach, r.WaterBody, r.NoiseSource} {
+		if item != nil {
+			item.DistanceM = unit.FromMeters(item.DistanceM)
+		}
+	}
+}
+
+// ConvertNearestBoundaryDistances переводит distance границ в unit; distance_m остается в метрах
+func ConvertNearestBoundaryDistances(boundaries []domain.NearestBoundary, unit utils.DistanceUnit) {
+	for i := range boundaries {
+		boundaries[i].Distance = unit.FromMeters(boundaries[i].Distance)
+	}
+}
+
+// ConvertPlaceDistance переводит distance населенного пункта в unit; distance_m остается в метрах
+func ConvertPlaceDistance(place *domain.Place, unit utils.DistanceUnit) {
+	place.Distance = unit.FromMeters(place.Distance)
+}
+
+func convertStations(stations []TransportStationWithLines, unit utils.DistanceUnit) {
+	for i := range stations {
+		stations[i].Distance = unit.FromMeters(stations[i].Distance)
+	}
+}
+
+func convertPriorityStations(stations []PriorityTransportStation, unit utils.DistanceUnit) {
+	for i := range stations {
+		s := &stations[i]
+		s.LinearDistance = unit.FromMeters(s.LinearDistance)
+		s.WalkingDistance = unit.FromMeters(s.WalkingDistance)
+		for j := range s.Entrances {
+			s.Entrances[j].Distance = unit.FromMeters(s.Entrances[j].Distance)
+		}
+	}
+}
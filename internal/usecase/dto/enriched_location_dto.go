@@ -1,8 +1,13 @@
 package dto
 
+import (
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/addressparser"
+)
+
 // EnrichLocationBatchRequest - запрос на полное обогащение локаций
 type EnrichLocationBatchRequest struct {
-	Locations []LocationInput `json:"locations" validate:"required,min=1,max=100"`
+	Locations []LocationInput `json:"locations" validate:"required,min=1"`
 }
 
 // EnrichLocationBatchResponse - ответ с обогащёнными локациями
@@ -39,3 +44,24 @@ type EnrichSingleLocationRequest struct {
 	Longitude    *float64 `json:"longitude,omitempty"`
 	IsVisible    *bool    `json:"is_visible,omitempty"`
 }
+
+// EnrichAddressRequest - запрос на обогащение по адресу одной строкой
+type EnrichAddressRequest struct {
+	Address   string   `json:"address" validate:"required,max=500"`
+	Country   string   `json:"country,omitempty"` // подсказка страны, если в строке ее нет
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	IsVisible *bool    `json:"is_visible,omitempty"`
+}
+
+// EnrichAddressResponse - разобранный адрес и результат обогащения по нему
+type EnrichAddressResponse struct {
+	Parsed addressparser.Address     `json:"parsed"`
+	Result *domain.LocationDoneEvent `json:"result"`
+}
+
+// EnrichmentProfilesResponse - доступные профили обогащения
+type EnrichmentProfilesResponse struct {
+	DefaultProfile string                     `json:"default_profile"`
+	Profiles       []domain.EnrichmentProfile `json:"profiles"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// EnvironmentNearestItem — ближайший объект окружения одного вида
+type EnvironmentNearestItem struct {
+	ID        int64   `json:"id"`
+	Type      string  `json:"type"`
+	Name      string  `json:"name,omitempty"`
+	DistanceM float64 `json:"distance"` // метры; в ответе - в единицах distance_unit (ConvertDistances)
+}
+
+// EnvironmentSummaryResponse — ближайший объект каждого вида в радиусе.
+// nil - объекта в радиусе нет; Failed - виды, запрос которых завершился ошибкой.
+type EnvironmentSummaryResponse struct {
+	GreenSpace  *EnvironmentNearestItem `json:"green_space"`
+	Beach       *EnvironmentNearestItem `json:"beach"`
+	WaterBody   *EnvironmentNearestItem `json:"water_body"`
+	NoiseSource *EnvironmentNearestItem `json:"noise_source"`
+	RadiusKm    float64                 `json:"radius_km"`
+	Failed      []string                `json:"failed,omitempty"` // green_space, beach, water_body, noise_source
+}
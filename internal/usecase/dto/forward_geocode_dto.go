@@ -0,0 +1,49 @@
+package dto
+
+// ForwardGeocodeBatchRequest - пакетное прямое геокодирование: адреса в структурированном виде
+type ForwardGeocodeBatchRequest struct {
+	Addresses []ForwardGeocodeAddress `json:"addresses" validate:"required,min=1,dive"`
+}
+
+// ForwardGeocodeAddress - адрес для прямого геокодирования. Административные уровни ищутся
+// по названию, улица и дом - внутри найденного города
+type ForwardGeocodeAddress struct {
+	Country      string  `json:"country" validate:"required,min=2"` // страна (обязательно)
+	Region       *string `json:"region,omitempty"`
+	Province     *string `json:"province,omitempty"`
+	City         *string `json:"city,omitempty"`
+	District     *string `json:"district,omitempty"`
+	Neighborhood *string `json:"neighborhood,omitempty"`
+	Street       *string `json:"street,omitempty"`
+	HouseNumber  *string `json:"house_number,omitempty"`
+	PostalCode   *string `json:"postal_code,omitempty"`
+}
+
+// ForwardGeocodeBatchResponse - результаты в порядке адресов запроса
+type ForwardGeocodeBatchResponse struct {
+	Results []ForwardGeocodeResult  `json:"results"`
+	Meta    ForwardGeocodeBatchMeta `json:"meta"`
+}
+
+// ForwardGeocodeResult - лучшая точка для адреса. Lat/Lon = null и Error, если не найдено ни одного уровня
+// адреса или поиск не удался
+type ForwardGeocodeResult struct {
+	Index         int              `json:"index"` // позиция адреса в запросе
+	Lat           *float64         `json:"lat"`
+	Lon           *float64         `json:"lon"`
+	Precision     string           `json:"precision,omitempty"`   // house, street или boundary
+	Confidence    float64          `json:"confidence"`            // [0, 1]
+	Boundary      *BoundaryInfoDTO `json:"boundary,omitempty"`    // граница, внутри которой найдена точка
+	AdminLevel    int              `json:"admin_level,omitempty"` // уровень boundary
+	MatchedLevels int              `json:"matched_levels"`        // уровней адреса, найденных по названию
+	Error         string           `json:"error,omitempty"`
+}
+
+// ForwardGeocodeBatchMeta - метаданные пакетного прямого геокодирования
+type ForwardGeocodeBatchMeta struct {
+	TotalAddresses int `json:"total_addresses"`
+	SuccessCount   int `json:"success_count"`
+	ErrorCount     int `json:"error_count"`
+	ScopeCount     int `json:"scope_count"`      // различных границ, внутри которых искались адреса
+	DBQueriesCount int `json:"db_queries_count"` // запросов к БД (по одному на под-пакет)
+}
@@ -2,7 +2,7 @@ package dto
 
 // DetectLocationBatchRequest - запрос на детекцию локаций
 type DetectLocationBatchRequest struct {
-	Locations []LocationInput `json:"locations" validate:"required,min=1,max=100,dive"`
+	Locations []LocationInput `json:"locations" validate:"required,min=1,dive"`
 }
 
 // DetectLocationBatchResponse - ответ детекции локаций
@@ -15,3 +15,22 @@ type NearbyPOIResponse struct {
 	Items    []POISimple `json:"items"`
 	Total    int         `json:"total"`
 }
+
+// NearbyDeltaRequest — очередное положение центра для дельты POI поблизости
+type NearbyDeltaRequest struct {
+	SessionID string // пусто, истекшая сессия или сессия другой категории — начинается новая
+	Category  string // фронтенд-категория POI
+	Lat       float64
+	Lon       float64
+	RadiusKm  float64 // км, default 1
+	Limit     int     // default 20, не больше 100
+}
+
+// NearbyDelta — изменения набора POI относительно предыдущего обновления сессии
+type NearbyDelta struct {
+	SessionID string      `json:"session_id"`
+	Category  string      `json:"category"`
+	Entered   []POISimple `json:"entered"` // POI, вошедшие в радиус
+	Left      []string    `json:"left"`    // ID POI, покинувших радиус
+	Total     int         `json:"total"`   // POI в радиусе после обновления
+}
@@ -0,0 +1,29 @@
+package dto
+
+// NeighborhoodContextOptions — параметры блока "рядом с объектом" (удобства и транспорт одним запросом)
+type NeighborhoodContextOptions struct {
+	Categories      []string // категории POI (healthcare, shopping, education, leisure, food_drink), пусто — все
+	POIRadiusKm     float64  // км, default 1, не больше 5
+	TransportRadius float64  // метры, default 1500, 100-10000
+	TransportLimit  int      // default 5, не больше 20
+}
+
+// NeighborhoodContextResponse — ближайший POI каждой категории и ближайший транспорт с линиями
+type NeighborhoodContextResponse struct {
+	Amenities map[string]POISimple       `json:"amenities"` // категория -> ближайший POI; категорий без POI в радиусе нет
+	Transport []PriorityTransportStation `json:"transport"`
+	Meta      NeighborhoodContextMeta    `json:"meta"`
+}
+
+// NeighborhoodContextMeta — метаданные блока "рядом с объектом"
+type NeighborhoodContextMeta struct {
+	SearchPoint      Point    `json:"search_point"`
+	Categories       []string `json:"categories"`
+	POIRadiusKm      float64  `json:"poi_radius_km"`
+	TransportRadiusM float64  `json:"transport_radius_m"`
+	HasHighPriority  bool     `json:"has_high_priority"`
+	PriorityType     string   `json:"priority_type"`
+	DistanceUnit     string   `json:"distance_unit"`
+	// TransportError - транспорт не удалось получить: пустой transport не значит, что станций рядом нет
+	TransportError bool `json:"transport_error"`
+}
@@ -28,9 +28,11 @@ type EnrichedLocationDTO struct {
 	Country          *BoundaryInfoDTO `json:"country,omitempty"`
 	Region           *BoundaryInfoDTO `json:"region,omitempty"`
 	Province         *BoundaryInfoDTO `json:"province,omitempty"`
+	Subprovince      *BoundaryInfoDTO `json:"subprovince,omitempty"` // admin_level 7
 	City             *BoundaryInfoDTO `json:"city,omitempty"`
 	District         *BoundaryInfoDTO `json:"district,omitempty"`
-	Neighborhood     *BoundaryInfoDTO `json:"neighborhood,omitempty"`
+	Subdistrict      *BoundaryInfoDTO `json:"subdistrict,omitempty"`  // admin_level 10
+	Neighborhood     *BoundaryInfoDTO `json:"neighborhood,omitempty"` // admin_level 11
 	IsAddressVisible *bool            `json:"is_address_visible,omitempty"`
 }
 
@@ -59,19 +61,43 @@ type PriorityTransportRequest struct {
 	Lon    float64 `json:"lon" validate:"required,min=-180,max=180"`
 	Radius float64 `json:"radius,omitempty" validate:"omitempty,min=100,max=10000"` // метры, default 1500
 	Limit  int     `json:"limit,omitempty" validate:"omitempty,min=1,max=20"`       // default 5
+
+	GroupLinesByMode bool `json:"group_lines_by_mode,omitempty"` // линии станции в lines_by_mode вместо плоского lines
+	IncludeEntrances bool `json:"include_entrances,omitempty"`   // входы в метро (subway_entrance) в entrances станций
+
+	// MinPerMode - гарантированный минимум станций по видам (metro, train, tram, bus): "хотя бы 1 автобус,
+	// даже если 5 станций метро ближе". Остальные слоты заполняются по расстоянию; пусто - строгий приоритет
+	MinPerMode map[string]int `json:"min_per_mode,omitempty"`
+
+	// DistanceMode - расчет walking_distance: straight (по прямой с поправкой, по умолчанию) или snapped
+	// (через привязку точки и станции к пешеходным дорогам, см. domain.DistanceModeSnapped)
+	DistanceMode string `json:"distance_mode,omitempty"`
+
+	// DistanceUnit - единица linear_distance, walking_distance и расстояний до входов: m (по умолчанию) или km
+	DistanceUnit string `json:"distance_unit,omitempty" validate:"omitempty,oneof=m km"`
 }
 
 // PriorityTransportBatchRequest - batch-запрос на поиск транспорта с приоритетом
 type PriorityTransportBatchRequest struct {
-	Points []PriorityTransportPoint `json:"points" validate:"required,min=1,max=100,dive"`
+	Points []PriorityTransportPoint `json:"points" validate:"required,min=1,dive"`
 	Radius float64                  `json:"radius,omitempty" validate:"omitempty,min=100,max=10000"` // метры для всех точек
 	Limit  int                      `json:"limit,omitempty" validate:"omitempty,min=1,max=10"`       // лимит на точку
+
+	GroupLinesByMode bool `json:"group_lines_by_mode,omitempty"` // линии станции в lines_by_mode вместо плоского lines
+
+	// DistanceUnit - как в PriorityTransportRequest
+	DistanceUnit string `json:"distance_unit,omitempty" validate:"omitempty,oneof=m km"`
+
+	// DistanceMode - как в PriorityTransportRequest; snapped - отдельный запрос привязки на каждую точку
+	DistanceMode string `json:"distance_mode,omitempty" validate:"omitempty,oneof=straight snapped"`
 }
 
 // PriorityTransportPoint - точка для batch-запроса
 type PriorityTransportPoint struct {
 	Lat float64 `json:"lat" validate:"required,min=-90,max=90"`
 	Lon float64 `json:"lon" validate:"required,min=-180,max=180"`
+	// Radius - радиус поиска для точки в метрах (0 - Radius запроса); задается только внутри сервиса
+	Radius float64 `json:"-"`
 }
 
 // PriorityTransportResponse - ответ на запрос транспорта с приоритетом
@@ -98,13 +124,33 @@ type PriorityTransportStation struct {
 	StationID       int64                       `json:"station_id"`
 	Name            string                      `json:"name"`
 	NameEn          *string                     `json:"name_en,omitempty"`
-	Type            string                      `json:"type"` // metro, train, tram, bus
+	Type            string                      `json:"type"`                 // metro, train, tram, bus
+	TypeLabel       string                      `json:"type_label,omitempty"` // подпись type на языке запроса
 	Lat             float64                     `json:"lat"`
 	Lon             float64                     `json:"lon"`
-	LinearDistance  float64                     `json:"linear_distance"`  // метры
-	WalkingDistance float64                     `json:"walking_distance"` // метры (примерно)
-	WalkingTime     float64                     `json:"walking_time"`     // минуты
+	LinearDistance  float64                     `json:"linear_distance"`   // в единицах meta.distance_unit
+	WalkingDistance float64                     `json:"walking_distance"`  // в единицах meta.distance_unit (примерно)
+	WalkingTime     float64                     `json:"walking_time"`      // минуты
+	Snapped         bool                        `json:"snapped,omitempty"` // walking_distance посчитан через пешеходную сеть
 	Lines           []TransportLineInfoEnriched `json:"lines,omitempty"`
+
+	// При group_lines_by_mode: линии по виду транспорта (metro, train, tram, bus, ...)
+	// и порядок видов для отображения (по приоритету транспорта)
+	LinesByMode map[string][]TransportLineInfoEnriched `json:"lines_by_mode,omitempty"`
+	LineModes   []string                               `json:"line_modes,omitempty"`
+
+	// При include_entrances: входы станции метро, по расстоянию от станции
+	Entrances []StationEntranceDTO `json:"entrances,omitempty"`
+}
+
+// StationEntranceDTO - вход в метро, где пешеход физически попадает на станцию
+type StationEntranceDTO struct {
+	ID       int64   `json:"id"`
+	Name     string  `json:"name,omitempty"`
+	Ref      string  `json:"ref,omitempty"`
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	Distance float64 `json:"distance"` // от станции, в единицах meta.distance_unit
 }
 
 // PriorityTransportMeta - метаданные ответа
@@ -115,6 +161,8 @@ type PriorityTransportMeta struct {
 	HasHighPriority bool    `json:"has_high_priority"` // есть ли metro/train в радиусе
 	PriorityType    string  `json:"priority_type"`     // "metro/train" или "bus/tram"
 	WalkingSpeedKmH float64 `json:"walking_speed_kmh"` // скорость ходьбы для расчёта
+	DistanceMode    string  `json:"distance_mode"`     // straight или snapped
+	DistanceUnit    string  `json:"distance_unit"`     // m или km
 }
 
 // PriorityTransportBatchMeta - метаданные batch-ответа
@@ -123,4 +171,6 @@ type PriorityTransportBatchMeta struct {
 	TotalStations   int     `json:"total_stations"`
 	RadiusM         float64 `json:"radius_m"`
 	WalkingSpeedKmH float64 `json:"walking_speed_kmh"`
+	DistanceMode    string  `json:"distance_mode"` // straight или snapped
+	DistanceUnit    string  `json:"distance_unit"`
 }
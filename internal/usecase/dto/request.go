@@ -1,10 +1,26 @@
 package dto
 
+import "encoding/json"
+
 // SearchRequest - запрос на поиск границ по тексту
 type SearchRequest struct {
+	Query         string  `json:"query" validate:"required,min=2"`
+	Language      string  `json:"language" validate:"required,oneof=en es ca ru uk fr pt it de"`
+	AdminLevels   []int   `json:"admin_levels,omitempty" validate:"omitempty,dive,oneof=2 4 6 8 9"`
+	Limit         int     `json:"limit" validate:"omitempty,min=1,max=100"`
+	MinPopulation int     `json:"min_population,omitempty" validate:"omitempty,min=0"`
+	MinAreaSqKm   float64 `json:"min_area_sq_km,omitempty" validate:"omitempty,min=0"`
+	OrderBy       string  `json:"order_by,omitempty" validate:"omitempty,oneof=level population"`
+	// NameDisplay - какое название показывать: language - перевод на язык запроса (по умолчанию),
+	// matched - совпавшее с запросом (при совпадении обоих - перевод), primary - name из OSM
+	NameDisplay string `json:"name_display,omitempty" validate:"omitempty,oneof=language matched primary"`
+}
+
+// SearchWithinParentRequest - запрос на поиск границ внутри родительской границы
+type SearchWithinParentRequest struct {
 	Query       string `json:"query" validate:"required,min=2"`
-	Language    string `json:"language" validate:"required,oneof=en es ca ru uk fr pt it de"`
-	AdminLevels []int  `json:"admin_levels,omitempty" validate:"omitempty,dive,oneof=2 4 6 8 9"`
+	ParentID    int64  `json:"parent_id" validate:"required"`
+	AdminLevels []int  `json:"admin_levels,omitempty" validate:"omitempty,dive,min=2,max=11"`
 	Limit       int    `json:"limit" validate:"omitempty,min=1,max=100"`
 }
 
@@ -14,9 +30,20 @@ type ReverseGeocodeRequest struct {
 	Lon float64 `json:"lon" validate:"required,min=-180,max=180"`
 }
 
+// PolygonBoundariesRequest - запрос границ, пересекающих полигон (например, зону доставки)
+type PolygonBoundariesRequest struct {
+	Polygon     json.RawMessage `json:"polygon" validate:"required"` // GeoJSON геометрия Polygon или MultiPolygon (EPSG:4326)
+	AdminLevels []int           `json:"admin_levels,omitempty" validate:"omitempty,dive,min=2,max=11"`
+}
+
 // BatchReverseGeocodeRequest - пакетный запрос на обратное геокодирование
 type BatchReverseGeocodeRequest struct {
-	Points []Point `json:"points" validate:"required,min=1,max=100,dive"`
+	Points []Point `json:"points" validate:"required,min=1,dive"`
+}
+
+// StreamReverseGeocodeRequest - запрос на потоковое обратное геокодирование (ответ в NDJSON)
+type StreamReverseGeocodeRequest struct {
+	Points []Point `json:"points" validate:"required,min=1,max=5000,dive"`
 }
 
 // Point - координаты точки
@@ -31,22 +58,70 @@ type NearestTransportRequest struct {
 	Lon         float64  `json:"lon" validate:"required,min=-180,max=180"`
 	Types       []string `json:"types" validate:"required,min=1,dive,oneof=metro train tram bus"`
 	MaxDistance float64  `json:"max_distance" validate:"omitempty,min=100,max=10000"` // meters
+	SurfaceOnly bool     `json:"surface_only,omitempty"`                              // исключить location=underground и indoor=yes
+
+	// ExcludeWithinM - не возвращать станции ближе N метров к точке (поиск от самой станции и ее дублей), 0 - без исключения
+	ExcludeWithinM float64 `json:"exclude_within_m,omitempty" validate:"omitempty,min=0,max=1000"`
+
+	// DistanceUnit - единица расстояний в ответе: m (по умолчанию) или km
+	DistanceUnit string `json:"distance_unit,omitempty" validate:"omitempty,oneof=m km"`
 }
 
 // RadiusPOIRequest - запрос на поиск POI в радиусе
 type RadiusPOIRequest struct {
-	Lat        float64  `json:"lat" validate:"required,min=-90,max=90"`
-	Lon        float64  `json:"lon" validate:"required,min=-180,max=180"`
-	RadiusKm   float64  `json:"radius_km" validate:"required,min=0.1,max=100"`
+	Lat            float64  `json:"lat" validate:"required,min=-90,max=90"`
+	Lon            float64  `json:"lon" validate:"required,min=-180,max=180"`
+	RadiusKm       float64  `json:"radius_km" validate:"required,min=0.1,max=100"`
+	Categories     []string `json:"categories,omitempty"`
+	Limit          int      `json:"limit" validate:"omitempty,min=1,max=500"`
+	SurfaceOnly    bool     `json:"surface_only,omitempty"`    // исключить location=underground и indoor=yes
+	IncludeAddress bool     `json:"include_address,omitempty"` // добавить структурированный адрес из тегов addr:*
+
+	// ExcludeWithinM - не возвращать POI ближе N метров к точке: сам исходный POI и дубли узлов OSM
+	// в той же точке. 0 - без исключения
+	ExcludeWithinM float64 `json:"exclude_within_m,omitempty" validate:"omitempty,min=0,max=1000"`
+
+	// Фильтр по тегам OSM: has_tags - ключ присутствует (website), require_tags - точное значение (outdoor_seating=yes)
+	HasTags     []string          `json:"has_tags,omitempty"`
+	RequireTags map[string]string `json:"require_tags,omitempty"`
+
+	// CategoryPriority - категории по убыванию важности ("важное сначала, потом ближайшее"): POI
+	// сортируются по уровню категории, внутри уровня - по расстоянию, категории вне списка - в конце.
+	// Уровень учитывается до лимита, поэтому limit отсекает хвост наименее важных категорий.
+	CategoryPriority []string `json:"category_priority,omitempty" validate:"omitempty,max=20,dive,required"`
+
+	// MinImportance - не возвращать POI с оценкой значимости ниже (0-10: wikidata 4, wikipedia 2,
+	// название 1, вес подкатегории до 3), 0 - без отбора
+	MinImportance int `json:"min_importance,omitempty" validate:"omitempty,min=0,max=10"`
+	// OrderBy - порядок: distance (по умолчанию) или importance - сначала значимые, затем ближайшие
+	OrderBy string `json:"order_by,omitempty" validate:"omitempty,oneof=distance importance"`
+
+	// DistanceUnit - единица расстояний в ответе: m (по умолчанию) или km
+	DistanceUnit string `json:"distance_unit,omitempty" validate:"omitempty,oneof=m km"`
+}
+
+// PathPOIRequest - запрос на поиск POI вдоль маршрута (полилинии)
+type PathPOIRequest struct {
+	Path       []Point  `json:"path" validate:"required,min=2,max=1000,dive"`
+	BufferM    float64  `json:"buffer_m" validate:"omitempty,min=10,max=2000"` // ширина коридора от маршрута, по умолчанию 200 м
 	Categories []string `json:"categories,omitempty"`
 	Limit      int      `json:"limit" validate:"omitempty,min=1,max=500"`
+
+	// DistanceUnit - единица distance и path_offset в ответе: m (по умолчанию) или km; path_length_m - всегда метры
+	DistanceUnit string `json:"distance_unit,omitempty" validate:"omitempty,oneof=m km"`
 }
 
 // BatchNearestTransportRequest - пакетный запрос на поиск ближайших транспортных станций
 type BatchNearestTransportRequest struct {
-	Points      []Point  `json:"points" validate:"required,min=1,max=100,dive"`
+	Points      []Point  `json:"points" validate:"required,min=1,dive"`
 	Types       []string `json:"types" validate:"required,min=1,dive,oneof=metro train tram bus"`
 	MaxDistance float64  `json:"max_distance" validate:"omitempty,min=100,max=10000"` // meters
+
+	// ExcludeWithinM - как в NearestTransportRequest, для каждой точки
+	ExcludeWithinM float64 `json:"exclude_within_m,omitempty" validate:"omitempty,min=0,max=1000"`
+
+	// DistanceUnit - единица расстояний в ответе: m (по умолчанию) или km
+	DistanceUnit string `json:"distance_unit,omitempty" validate:"omitempty,oneof=m km"`
 }
 
 // TransportLinesRequest - запрос на получение данных нескольких транспортных линий
@@ -54,6 +129,12 @@ type TransportLinesRequest struct {
 	IDs []string `json:"ids" validate:"required,min=1,max=50"`
 }
 
+// TransportLinesGeometryRequest - запрос на получение геометрий нескольких линий
+type TransportLinesGeometryRequest struct {
+	IDs         []string `json:"ids" validate:"required,min=1,max=200"`
+	DedupeByRef bool     `json:"dedupe_by_ref"` // схлопывать направления линии с одинаковым ref
+}
+
 // RadiusTilesRequest - запрос на получение всех данных в радиусе в формате MVT
 type RadiusTilesRequest struct {
 	Lat      float64  `json:"lat" validate:"required,min=-90,max=90"`
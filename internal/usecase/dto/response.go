@@ -1,15 +1,18 @@
 package dto
 
 import (
+	"encoding/json"
 	"strconv"
 
 	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/utils"
 )
 
 // SearchResponse - ответ на поиск границ
 type SearchResponse struct {
-	Results []SearchResult `json:"results"`
-	Total   int            `json:"total"`
+	Results []SearchResult   `json:"results"`
+	Total   int              `json:"total"`
+	Page    utils.Pagination `json:"-"`
 }
 
 // SearchResult - результат поиска границы
@@ -21,6 +24,12 @@ type SearchResult struct {
 	CenterLat  float64  `json:"center_lat"`
 	CenterLon  float64  `json:"center_lon"`
 	AreaSqKm   *float64 `json:"area_sq_km,omitempty"`
+	Population *int     `json:"population,omitempty"`
+
+	// Только для текстового поиска: исходное name из OSM, если в name показано другое название,
+	// и поле, совпавшее с запросом (name или name:<lang>)
+	OriginalName string `json:"original_name,omitempty"`
+	MatchedField string `json:"matched_field,omitempty"`
 }
 
 // ReverseGeocodeResponse - ответ на обратное геокодирование
@@ -28,11 +37,43 @@ type ReverseGeocodeResponse struct {
 	Address domain.Address `json:"address"`
 }
 
+// BreadcrumbItem - уровень административного пути
+type BreadcrumbItem struct {
+	Level int    `json:"level"` // admin_level
+	Name  string `json:"name"`
+	ID    int64  `json:"id"`
+}
+
+// BreadcrumbResponse - административный путь точки от страны к самому детальному уровню
+type BreadcrumbResponse struct {
+	Items []BreadcrumbItem `json:"items"`
+	Text  string           `json:"text"` // названия, соединенные разделителем: "Spain › Catalonia › Barcelona"
+}
+
+// ReverseGeocodeConfidenceResponse - ответ на обратное геокодирование с оценкой достоверности
+type ReverseGeocodeConfidenceResponse struct {
+	Address            domain.Address `json:"address"`
+	Confidence         float64        `json:"confidence"`           // 0..1
+	MatchedLevels      int            `json:"matched_levels"`       // количество совпавших административных уровней
+	SmallestAdminLevel int            `json:"smallest_admin_level"` // admin_level самого детального полигона
+	EdgeDistanceMeters float64        `json:"edge_distance_meters"` // расстояние до края самого детального полигона
+}
+
 // BatchReverseGeocodeResponse - ответ на пакетное обратное геокодирование
 type BatchReverseGeocodeResponse struct {
 	Addresses []domain.Address `json:"addresses"`
 }
 
+// ReverseGeocodeStreamItem - строка NDJSON ответа потокового обратного геокодирования.
+// Address = null, если точка не попала ни в одну границу; Error - если под-пакет не удалось обработать.
+type ReverseGeocodeStreamItem struct {
+	Index   int             `json:"index"`
+	Lat     float64         `json:"lat"`
+	Lon     float64         `json:"lon"`
+	Address *domain.Address `json:"address"`
+	Error   string          `json:"error,omitempty"`
+}
+
 // NearestTransportResponse - ответ на поиск ближайших транспортных станций
 type NearestTransportResponse struct {
 	Stations []TransportStationWithLines `json:"stations"`
@@ -40,13 +81,14 @@ type NearestTransportResponse struct {
 
 // TransportStationWithLines - транспортная станция с линиями
 type TransportStationWithLines struct {
-	ID       string                `json:"id"` // Converted to string for frontend
-	Name     string                `json:"name"`
-	Type     string                `json:"type"`
-	Lat      float64               `json:"lat"`
-	Lon      float64               `json:"lon"`
-	Distance float64               `json:"distance"` // meters
-	Lines    []TransportLineSimple `json:"lines"`
+	ID        string                `json:"id"` // Converted to string for frontend
+	Name      string                `json:"name"`
+	Type      string                `json:"type"`
+	TypeLabel string                `json:"type_label,omitempty"` // подпись type на языке запроса
+	Lat       float64               `json:"lat"`
+	Lon       float64               `json:"lon"`
+	Distance  float64               `json:"distance"` // в единицах distance_unit (по умолчанию метры)
+	Lines     []TransportLineSimple `json:"lines"`
 }
 
 // TransportLineSimple - упрощенная информация о транспортной линии
@@ -57,10 +99,41 @@ type TransportLineSimple struct {
 	Color *string `json:"color,omitempty"`
 }
 
+// TransportLineGeometryDTO - геометрия транспортной линии (GeoJSON)
+type TransportLineGeometryDTO struct {
+	ID       string          `json:"id"` // Converted to string for frontend
+	Name     string          `json:"name"`
+	Ref      string          `json:"ref"`
+	Type     string          `json:"type"`
+	Color    *string         `json:"color,omitempty"`
+	Geometry json.RawMessage `json:"geometry"`
+}
+
+// TransportLinesGeometryResponse - ответ с геометриями нескольких линий
+type TransportLinesGeometryResponse struct {
+	Lines []TransportLineGeometryDTO `json:"lines"`
+	Total int                        `json:"total"`
+}
+
 // RadiusPOIResponse - ответ на поиск POI в радиусе
 type RadiusPOIResponse struct {
-	POIs  []POISimple `json:"pois"`
-	Total int         `json:"total"`
+	POIs  []POISimple      `json:"pois"`
+	Total int              `json:"total"`
+	Page  utils.Pagination `json:"-"`
+}
+
+// PathPOIResponse - ответ на поиск POI вдоль маршрута, POI в порядке движения по маршруту
+type PathPOIResponse struct {
+	POIs        []PathPOI        `json:"pois"`
+	Total       int              `json:"total"`
+	PathLengthM float64          `json:"path_length_m"`
+	Page        utils.Pagination `json:"-"`
+}
+
+// PathPOI - POI вдоль маршрута: distance - до маршрута, path_offset - позиция на маршруте от его начала
+type PathPOI struct {
+	POISimple
+	PathOffset float64 `json:"path_offset"` // в единицах distance_unit (по умолчанию метры)
 }
 
 // BatchNearestTransportResponse - ответ на пакетный поиск ближайших транспортных станций
@@ -76,7 +149,14 @@ type POISimple struct {
 	Subcategory string  `json:"subcategory"`
 	Lat         float64 `json:"lat"`
 	Lon         float64 `json:"lon"`
-	Distance    float64 `json:"distance,omitempty"` // meters
+	Distance    float64 `json:"distance,omitempty"`   // в единицах distance_unit (по умолчанию метры)
+	Importance  *int    `json:"importance,omitempty"` // оценка значимости 0-10, если запрошены min_importance или order_by=importance
+
+	// Подписи category и subcategory на языке запроса; сами коды остаются стабильными значениями
+	CategoryLabel    string `json:"category_label,omitempty"`
+	SubcategoryLabel string `json:"subcategory_label,omitempty"`
+
+	Address *domain.POIAddress `json:"address,omitempty"`
 }
 
 // Helper functions to convert domain models to DTOs with string IDs
@@ -84,13 +164,16 @@ type POISimple struct {
 // ConvertSearchResult converts domain boundary to SearchResult DTO
 func ConvertSearchResult(b *domain.AdminBoundary) SearchResult {
 	return SearchResult{
-		ID:         strconv.FormatInt(b.ID, 10),
-		Name:       b.Name,
-		Type:       b.Type,
-		AdminLevel: b.AdminLevel,
-		CenterLat:  b.CenterLat,
-		CenterLon:  b.CenterLon,
-		AreaSqKm:   b.AreaSqKm,
+		ID:           strconv.FormatInt(b.ID, 10),
+		Name:         b.Name,
+		Type:         b.Type,
+		AdminLevel:   b.AdminLevel,
+		CenterLat:    utils.RoundCoordinate(b.CenterLat),
+		CenterLon:    utils.RoundCoordinate(b.CenterLon),
+		AreaSqKm:     b.AreaSqKm,
+		Population:   b.Population,
+		OriginalName: b.OriginalName,
+		MatchedField: b.MatchedField,
 	}
 }
 
@@ -110,8 +193,8 @@ func ConvertTransportStation(station *domain.TransportStation, lines []*domain.T
 		ID:       strconv.FormatInt(station.ID, 10),
 		Name:     station.Name,
 		Type:     station.Type,
-		Lat:      station.Lat,
-		Lon:      station.Lon,
+		Lat:      utils.RoundCoordinate(station.Lat),
+		Lon:      utils.RoundCoordinate(station.Lon),
 		Distance: distance,
 		Lines:    linesDTOs,
 	}
@@ -124,49 +207,65 @@ func ConvertPOI(poi *domain.POI, distance float64) POISimple {
 		Name:        poi.Name,
 		Category:    poi.Category,
 		Subcategory: poi.Subcategory,
-		Lat:         poi.Lat,
-		Lon:         poi.Lon,
+		Lat:         utils.RoundCoordinate(poi.Lat),
+		Lon:         utils.RoundCoordinate(poi.Lon),
 		Distance:    distance,
+		Importance:  poi.Importance,
+		Address:     poi.AddressDetails,
 	}
 }
 
 // POIDetailed — расширенная информация о POI для bbox-ответа
 type POIDetailed struct {
-	ID           string  `json:"id"`
-	Name         string  `json:"name"`
-	Category     string  `json:"category"`
-	Subcategory  string  `json:"subcategory"`
-	Lat          float64 `json:"lat"`
-	Lon          float64 `json:"lon"`
-	NameEn       *string `json:"name_en,omitempty"`
-	Address      *string `json:"address,omitempty"`
-	Phone        *string `json:"phone,omitempty"`
-	Website      *string `json:"website,omitempty"`
-	OpeningHours *string `json:"opening_hours,omitempty"`
-	Brand        *string `json:"brand,omitempty"`
-	Operator     *string `json:"operator,omitempty"`
-	Cuisine      *string `json:"cuisine,omitempty"`
-	Stars        *int    `json:"stars,omitempty"`
-	Description  *string `json:"description,omitempty"`
-	Wheelchair   *bool   `json:"wheelchair,omitempty"`
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	Category         string  `json:"category"`
+	Subcategory      string  `json:"subcategory"`
+	CategoryLabel    string  `json:"category_label,omitempty"`    // подпись category на языке запроса
+	SubcategoryLabel string  `json:"subcategory_label,omitempty"` // подпись subcategory на языке запроса
+	Lat              float64 `json:"lat"`
+	Lon              float64 `json:"lon"`
+	NameEn           *string `json:"name_en,omitempty"`
+	Address          *string `json:"address,omitempty"`
+	Phone            *string `json:"phone,omitempty"`
+	Website          *string `json:"website,omitempty"`
+	OpeningHours     *string `json:"opening_hours,omitempty"`
+	Brand            *string `json:"brand,omitempty"`
+	Operator         *string `json:"operator,omitempty"`
+	Cuisine          *string `json:"cuisine,omitempty"`
+	Stars            *int    `json:"stars,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	Wheelchair       *bool   `json:"wheelchair,omitempty"`
+	Importance       *int    `json:"importance,omitempty"` // оценка значимости 0-10, если запрошены min_importance или order_by=importance
 }
 
 // BBoxPOIResponse — ответ на bbox-запрос POI
 type BBoxPOIResponse struct {
-	POIs   []POIDetailed `json:"pois"`
-	Total  int           `json:"total"`
-	Limit  int           `json:"limit"`
-	Offset int           `json:"offset"`
+	POIs   []POIDetailed    `json:"pois"`
+	Total  int              `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+	Page   utils.Pagination `json:"-"`
+}
+
+// ActivityCenterResponse — центр активности bbox: средние координаты POI и их число.
+// Если в области нет POI, lat и lon равны null, poi_count - 0.
+type ActivityCenterResponse struct {
+	Lat      *float64 `json:"lat"`
+	Lon      *float64 `json:"lon"`
+	POICount int      `json:"poi_count"`
+	Weighted bool     `json:"weighted"`
 }
 
 // BBoxTransportStation — станция транспорта для bbox-ответа
 type BBoxTransportStation struct {
-	ID    string                `json:"id"`
-	Name  string                `json:"name"`
-	Type  string                `json:"type"`
-	Lat   float64               `json:"lat"`
-	Lon   float64               `json:"lon"`
-	Lines []TransportLineSimple `json:"lines,omitempty"`
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	Type      string                `json:"type"`
+	TypeLabel string                `json:"type_label,omitempty"` // подпись type на языке запроса
+	Lat       float64               `json:"lat"`
+	Lon       float64               `json:"lon"`
+	Lines     []TransportLineSimple `json:"lines,omitempty"`
 }
 
 // BBoxTransportResponse — ответ на bbox-запрос транспорта
@@ -175,6 +274,7 @@ type BBoxTransportResponse struct {
 	Total    int                    `json:"total"`
 	Limit    int                    `json:"limit"`
 	Offset   int                    `json:"offset"`
+	Page     utils.Pagination       `json:"-"`
 }
 
 // ConvertPOIDetailed converts domain POI to POIDetailed DTO
@@ -184,8 +284,8 @@ func ConvertPOIDetailed(poi *domain.POI) POIDetailed {
 		Name:         poi.Name,
 		Category:     poi.Category,
 		Subcategory:  poi.Subcategory,
-		Lat:          poi.Lat,
-		Lon:          poi.Lon,
+		Lat:          utils.RoundCoordinate(poi.Lat),
+		Lon:          utils.RoundCoordinate(poi.Lon),
 		NameEn:       poi.NameEn,
 		Address:      poi.Address,
 		Phone:        poi.Phone,
@@ -197,6 +297,60 @@ func ConvertPOIDetailed(poi *domain.POI) POIDetailed {
 		Stars:        poi.Stars,
 		Description:  poi.Description,
 		Wheelchair:   poi.Wheelchair,
+		Importance:   poi.Importance,
+	}
+}
+
+// POIDetailsResponse — полная информация о POI со всеми OSM тегами
+type POIDetailsResponse struct {
+	ID           string             `json:"id"`
+	Name         string             `json:"name"`
+	Category     string             `json:"category"`
+	Subcategory  string             `json:"subcategory"`
+	Lat          float64            `json:"lat"`
+	Lon          float64            `json:"lon"`
+	Phone        *string            `json:"phone,omitempty"`
+	Website      *string            `json:"website,omitempty"`
+	OpeningHours *string            `json:"opening_hours,omitempty"`
+	Wheelchair   *bool              `json:"wheelchair,omitempty"`
+	Address      *domain.POIAddress `json:"address,omitempty"`
+	Tags         map[string]string  `json:"tags"`
+	EditMeta     *domain.EditMeta   `json:"edit_meta,omitempty"` // только с ?include_meta=true
+}
+
+// BoundaryDetailsResponse - административная граница для GET /boundaries/:id
+type BoundaryDetailsResponse struct {
+	*domain.AdminBoundary
+	EditMeta *domain.EditMeta `json:"edit_meta,omitempty"` // только с ?include_meta=true
+}
+
+// StationDetailsResponse - станция для GET /transport/station/:station_id
+type StationDetailsResponse struct {
+	*domain.TransportStation
+	Lines    []TransportLineInfo `json:"lines"`
+	EditMeta *domain.EditMeta    `json:"edit_meta,omitempty"` // только с ?include_meta=true
+}
+
+// ConvertPOIDetails converts domain POI to POIDetailsResponse DTO
+func ConvertPOIDetails(poi *domain.POI) POIDetailsResponse {
+	tags := poi.Tags
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	return POIDetailsResponse{
+		ID:           strconv.FormatInt(poi.OSMId, 10),
+		Name:         poi.Name,
+		Category:     poi.Category,
+		Subcategory:  poi.Subcategory,
+		Lat:          utils.RoundCoordinate(poi.Lat),
+		Lon:          utils.RoundCoordinate(poi.Lon),
+		Phone:        poi.Phone,
+		Website:      poi.Website,
+		OpeningHours: poi.OpeningHours,
+		Wheelchair:   poi.Wheelchair,
+		Address:      poi.AddressDetails,
+		Tags:         tags,
 	}
 }
 
@@ -216,8 +370,39 @@ func ConvertBBoxTransportStation(s domain.TransportStationWithLines) BBoxTranspo
 		ID:    strconv.FormatInt(s.StationID, 10),
 		Name:  s.Name,
 		Type:  s.Type,
-		Lat:   s.Lat,
-		Lon:   s.Lon,
+		Lat:   utils.RoundCoordinate(s.Lat),
+		Lon:   utils.RoundCoordinate(s.Lon),
 		Lines: lines,
 	}
 }
+
+// TileSchemaResponse - схема слоев векторных тайлов: атрибуты и их типы
+type TileSchemaResponse struct {
+	Layers []domain.TileLayerSchema `json:"layers"`
+}
+
+// TileDeltaResponse - изменения тайла относительно версии, закешированной клиентом.
+// status: unchanged - тайл клиента актуален, full - тайл нужно запросить целиком,
+// patch (зарезервирован) - changes содержит изменения объектов для применения к тайлу клиента.
+type TileDeltaResponse struct {
+	Layer       string                     `json:"layer"`
+	Z           int                        `json:"z"`
+	X           int                        `json:"x"`
+	Y           int                        `json:"y"`
+	Status      domain.TileDeltaStatus     `json:"status"`
+	DataVersion string                     `json:"data_version,omitempty"`
+	Since       string                     `json:"since,omitempty"`
+	Changes     []domain.TileFeatureChange `json:"changes,omitempty"`
+}
+
+// TileCoverResponse - тайлы зума zoom, покрывающие bbox: прямоугольник x/y (включительно) и его тайлы
+type TileCoverResponse struct {
+	Zoom  int                `json:"zoom"`
+	BBox  domain.BoundingBox `json:"bbox"`
+	MinX  int                `json:"min_x"`
+	MaxX  int                `json:"max_x"`
+	MinY  int                `json:"min_y"`
+	MaxY  int                `json:"max_y"`
+	Count int                `json:"count"`
+	Tiles []domain.TileCoord `json:"tiles"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+// StationComplexRequest - запрос пересадочных узлов рядом с точкой
+type StationComplexRequest struct {
+	Lat             float64 `json:"lat" validate:"required,min=-90,max=90"`
+	Lon             float64 `json:"lon" validate:"required,min=-180,max=180"`
+	Radius          float64 `json:"radius,omitempty"`           // метры, default 1000, 100-5000
+	ClusterDistance float64 `json:"cluster_distance,omitempty"` // метры, default 150, 20-500
+	Limit           int     `json:"limit,omitempty"`            // комплексов, default 10, не больше 50
+}
+
+// StationComplexesResponse - пересадочные узлы по расстоянию до ближайшей станции узла
+type StationComplexesResponse struct {
+	Complexes []StationComplexDTO  `json:"complexes"`
+	Meta      StationComplexesMeta `json:"meta"`
+}
+
+// StationComplexDTO - пересадочный узел: станции по видам транспорта и объединение их линий
+type StationComplexDTO struct {
+	ID             int64                                 `json:"id"` // station_id главной станции (высшего вида)
+	Name           string                                `json:"name"`
+	Lat            float64                               `json:"lat"` // центр станций узла
+	Lon            float64                               `json:"lon"`
+	Distance       float64                               `json:"distance"` // до ближайшей станции узла, в единицах meta.distance_unit
+	Modes          []string                              `json:"modes"`    // виды транспорта по приоритету
+	StationsByMode map[string][]PriorityTransportStation `json:"stations_by_mode"`
+	Lines          []TransportLineInfoEnriched           `json:"lines,omitempty"`
+}
+
+// StationComplexesMeta - метаданные поиска пересадочных узлов
+type StationComplexesMeta struct {
+	SearchPoint      Point   `json:"search_point"`
+	RadiusM          float64 `json:"radius_m"`
+	ClusterDistanceM float64 `json:"cluster_distance_m"`
+	TotalStations    int     `json:"total_stations"` // станций во всех возвращенных узлах
+	DistanceUnit     string  `json:"distance_unit"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// TimezoneResponse — часовой пояс координаты
+type TimezoneResponse struct {
+	Timezone  string `json:"timezone"`   // идентификатор IANA: Europe/Madrid
+	UTCOffset string `json:"utc_offset"` // текущее смещение от UTC: +02:00
+}
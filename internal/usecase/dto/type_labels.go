@@ -0,0 +1,104 @@
+package dto
+
+import "github.com/location-microservice/internal/domain"
+
+// Подписи (TYPE_LABELS) проставляются обработчиками после usecase, поэтому закешированные ответы
+// не зависят от языка. Без подписей type_label и *_label совпадают с кодами.
+// Коды type, category и subcategory не меняются.
+
+// LocalizeTypes проставляет type_label станций на языке lang
+func (r *NearestTransportResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	localizeStations(labels, r.Stations, lang)
+}
+
+// LocalizeTypes проставляет type_label станций всех точек на языке lang
+func (r *BatchNearestTransportResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	for _, stations := range r.Results {
+		localizeStations(labels, stations, lang)
+	}
+}
+
+// LocalizeTypes проставляет type_label станций на языке lang
+func (r *PriorityTransportResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	localizePriorityStations(labels, r.Stations, lang)
+}
+
+// LocalizeTypes проставляет type_label станций всех точек на языке lang
+func (r *PriorityTransportBatchResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	for i := range r.Results {
+		localizePriorityStations(labels, r.Results[i].Stations, lang)
+	}
+}
+
+// LocalizeTypes проставляет type_label станций на языке lang
+func (r *BBoxTransportResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	for i := range r.Stations {
+		r.Stations[i].TypeLabel = labels.Label(lang, r.Stations[i].Type)
+	}
+}
+
+// LocalizeTypes проставляет type_label станций всех узлов на языке lang
+func (r *StationComplexesResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	for _, c := range r.Complexes {
+		for _, stations := range c.StationsByMode {
+			localizePriorityStations(labels, stations, lang)
+		}
+	}
+}
+
+// LocalizeTypes проставляет подписи категорий POI на языке lang
+func (r *RadiusPOIResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	localizePOIs(labels, r.POIs, lang)
+}
+
+// LocalizeTypes проставляет подписи категорий POI на языке lang
+func (r *PathPOIResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	for i := range r.POIs {
+		r.POIs[i].POISimple.localize(labels, lang)
+	}
+}
+
+// LocalizeTypes проставляет подписи категорий POI на языке lang
+func (r *NearbyPOIResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	localizePOIs(labels, r.Items, lang)
+}
+
+// LocalizeTypes проставляет подписи категорий POI на языке lang
+func (r *BBoxPOIResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	for i := range r.POIs {
+		r.POIs[i].CategoryLabel = labels.Label(lang, r.POIs[i].Category)
+		r.POIs[i].SubcategoryLabel = labels.Label(lang, r.POIs[i].Subcategory)
+	}
+}
+
+// LocalizeTypes проставляет подписи категорий удобств и type_label станций на языке lang
+func (r *NeighborhoodContextResponse) LocalizeTypes(labels domain.TypeLabels, lang string) {
+	for category, poi := range r.Amenities {
+		poi.localize(labels, lang)
+		r.Amenities[category] = poi
+	}
+	localizePriorityStations(labels, r.Transport, lang)
+}
+
+func (p *POISimple) localize(labels domain.TypeLabels, lang string) {
+	p.CategoryLabel = labels.Label(lang, p.Category)
+	p.SubcategoryLabel = labels.Label(lang, p.Subcategory)
+}
+
+func localizePOIs(labels domain.TypeLabels, pois []POISimple, lang string) {
+	for i := range pois {
+		pois[i].localize(labels, lang)
+	}
+}
+
+func localizeStations(labels domain.TypeLabels, stations []TransportStationWithLines, lang string) {
+	for i := range stations {
+		stations[i].TypeLabel = labels.Label(lang, stations[i].Type)
+	}
+}
+
+func localizePriorityStations(labels domain.TypeLabels, stations []PriorityTransportStation, lang string) {
+	for i := range stations {
+		stations[i].TypeLabel = labels.Label(lang, stations[i].Type)
+	}
+}
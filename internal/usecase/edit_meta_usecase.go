@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// EditMetaUseCase - метаданные последней правки объектов OSM для разбора качества данных
+type EditMetaUseCase struct {
+	editMetaRepo repository.EditMetaRepository
+	logger       *zap.Logger
+}
+
+// NewEditMetaUseCase создает новый экземпляр EditMetaUseCase
+func NewEditMetaUseCase(editMetaRepo repository.EditMetaRepository, logger *zap.Logger) *EditMetaUseCase {
+	return &EditMetaUseCase{
+		editMetaRepo: editMetaRepo,
+		logger:       logger,
+	}
+}
+
+// GetEditMeta возвращает osm_version, osm_timestamp и osm_user объекта (boundary, poi, station)
+func (uc *EditMetaUseCase) GetEditMeta(ctx context.Context, feature string, osmID int64) (*domain.EditMeta, error) {
+	if !domain.IsValidEditFeature(feature) {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"feature": "must be one of: boundary, poi, station",
+		})
+	}
+
+	meta, err := uc.editMetaRepo.GetEditMeta(ctx, feature, osmID)
+	if err != nil {
+		if err != errors.ErrLocationNotFound {
+			uc.logger.Error("Failed to get edit metadata",
+				zap.String("feature", feature),
+				zap.Int64("osm_id", osmID),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return meta, nil
+}
@@ -0,0 +1,73 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase"
+)
+
+// ---- Mock Edit Meta Repository ----
+
+type mockEditMetaRepository struct {
+	mock.Mock
+}
+
+func (m *mockEditMetaRepository) GetEditMeta(ctx context.Context, feature string, osmID int64) (*domain.EditMeta, error) {
+	args := m.Called(ctx, feature, osmID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EditMeta), args.Error(1)
+}
+
+func TestEditMetaUseCase_GetEditMeta(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("metadata from repository", func(t *testing.T) {
+		repo := new(mockEditMetaRepository)
+		uc := usecase.NewEditMetaUseCase(repo, zap.NewNop())
+
+		version := int64(7)
+		ts := time.Date(2015, 3, 1, 12, 0, 0, 0, time.UTC)
+		repo.On("GetEditMeta", ctx, domain.EditFeatureStation, int64(42)).
+			Return(&domain.EditMeta{Available: true, Version: &version, Timestamp: &ts}, nil)
+
+		meta, err := uc.GetEditMeta(ctx, domain.EditFeatureStation, 42)
+
+		assert.NoError(t, err)
+		assert.True(t, meta.Available)
+		assert.Equal(t, int64(7), *meta.Version)
+		assert.Equal(t, ts, *meta.Timestamp)
+		assert.Nil(t, meta.User)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown feature", func(t *testing.T) {
+		repo := new(mockEditMetaRepository)
+		uc := usecase.NewEditMetaUseCase(repo, zap.NewNop())
+
+		_, err := uc.GetEditMeta(ctx, "line", 42)
+
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+		repo.AssertNotCalled(t, "GetEditMeta")
+	})
+
+	t.Run("feature not found", func(t *testing.T) {
+		repo := new(mockEditMetaRepository)
+		uc := usecase.NewEditMetaUseCase(repo, zap.NewNop())
+
+		repo.On("GetEditMeta", ctx, domain.EditFeaturePOI, int64(1)).Return(nil, errors.ErrLocationNotFound)
+
+		_, err := uc.GetEditMeta(ctx, domain.EditFeaturePOI, 1)
+
+		assert.Equal(t, errors.ErrLocationNotFound, err)
+	})
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import "github.com/location-microservice/internal/domain"
+
+// enrichedLevelsExcluded - admin_level, которые не попадают в обогащенную локацию (ENRICHMENT_EXCLUDE_LEVELS).
+// По умолчанию пусто: обогащение содержит те же уровни, что и Address обратного геокодирования.
+var enrichedLevelsExcluded = map[int]bool{}
+
+// ConfigureEnrichedLocationLevels задает уровни, исключаемые из EnrichedLocation (например, 7 и 11
+// для клиентов со старой схемой). Вызывается один раз при старте, до обработки запросов.
+func ConfigureEnrichedLocationLevels(exclude []int) {
+	excluded := make(map[int]bool, len(exclude))
+	for _, level := range exclude {
+		excluded[level] = true
+	}
+	enrichedLevelsExcluded = excluded
+}
+
+// setEnrichedBoundary записывает границу в поле уровня, если уровень не исключен конфигом
+func setEnrichedBoundary(loc *domain.EnrichedLocation, level int, info *domain.BoundaryInfo) bool {
+	if enrichedLevelsExcluded[level] {
+		return false
+	}
+	return loc.SetBoundary(level, info)
+}
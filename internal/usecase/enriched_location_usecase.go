@@ -19,21 +19,25 @@ const (
 
 // EnrichedLocationUseCase - usecase для полного обогащения локаций
 type EnrichedLocationUseCase struct {
-	searchUC    *SearchUseCase    // для DetectLocationBatch
-	transportUC *TransportUseCase // для GetNearestTransportByPriorityBatch
-	logger      *zap.Logger
+	searchUC       *SearchUseCase    // для DetectLocationBatch
+	transportUC    *TransportUseCase // для GetNearestTransportByPriorityBatch
+	adaptiveRadius *domain.AdaptiveTransportRadius
+	logger         *zap.Logger
 }
 
-// NewEnrichedLocationUseCase создает новый EnrichedLocationUseCase
+// NewEnrichedLocationUseCase создает новый EnrichedLocationUseCase.
+// adaptiveRadius - радиус поиска транспорта по плотности станций (nil - DefaultTransportRadius).
 func NewEnrichedLocationUseCase(
 	searchUC *SearchUseCase,
 	transportUC *TransportUseCase,
+	adaptiveRadius *domain.AdaptiveTransportRadius,
 	logger *zap.Logger,
 ) *EnrichedLocationUseCase {
 	return &EnrichedLocationUseCase{
-		searchUC:    searchUC,
-		transportUC: transportUC,
-		logger:      logger,
+		searchUC:       searchUC,
+		transportUC:    transportUC,
+		adaptiveRadius: adaptiveRadius,
+		logger:         logger,
 	}
 }
 
@@ -49,6 +53,9 @@ func (uc *EnrichedLocationUseCase) EnrichLocationBatch(
 	if len(req.Locations) == 0 {
 		return nil, errors.ErrInvalidRequest
 	}
+	if err := validateBatchSize(len(req.Locations)); err != nil {
+		return nil, err
+	}
 
 	uc.logger.Info("EnrichLocationBatch started",
 		zap.Int("total_locations", len(req.Locations)))
@@ -86,13 +93,17 @@ func (uc *EnrichedLocationUseCase) EnrichLocationBatch(
 		go func() {
 			defer wg.Done()
 
-			// Формируем точки для поиска транспорта
+			// Формируем точки для поиска транспорта; с adaptiveRadius радиус подбирается для каждой точки
 			points := make([]dto.PriorityTransportPoint, len(visibleLocations))
 			for i, loc := range visibleLocations {
 				points[i] = dto.PriorityTransportPoint{
 					Lat: *loc.Latitude,
 					Lon: *loc.Longitude,
 				}
+				if uc.adaptiveRadius != nil {
+					points[i].Radius = uc.transportUC.AdaptiveRadiusM(ctx, *loc.Latitude, *loc.Longitude,
+						*uc.adaptiveRadius, DefaultTransportRadius)
+				}
 			}
 
 			transportReq := dto.PriorityTransportBatchRequest{
@@ -255,6 +266,13 @@ func convertToEnrichedLocation(dto *dto.EnrichedLocationDTO) *domain.EnrichedLoc
 			TranslateNames: dto.Province.TranslateNames,
 		}
 	}
+	if dto.Subprovince != nil {
+		result.Subprovince = &domain.BoundaryInfo{
+			ID:             dto.Subprovince.ID,
+			Name:           dto.Subprovince.Name,
+			TranslateNames: dto.Subprovince.TranslateNames,
+		}
+	}
 	if dto.City != nil {
 		result.City = &domain.BoundaryInfo{
 			ID:             dto.City.ID,
@@ -269,6 +287,13 @@ func convertToEnrichedLocation(dto *dto.EnrichedLocationDTO) *domain.EnrichedLoc
 			TranslateNames: dto.District.TranslateNames,
 		}
 	}
+	if dto.Subdistrict != nil {
+		result.Subdistrict = &domain.BoundaryInfo{
+			ID:             dto.Subdistrict.ID,
+			Name:           dto.Subdistrict.Name,
+			TranslateNames: dto.Subdistrict.TranslateNames,
+		}
+	}
 	if dto.Neighborhood != nil {
 		result.Neighborhood = &domain.BoundaryInfo{
 			ID:             dto.Neighborhood.ID,
@@ -37,9 +37,9 @@ func TestEnrichedLocationUseCase_EnrichLocationBatch_EmptyLocations(t *testing.T
 	mockCache := &MockCacheRepository{}
 	mockTransport := &MockTransportRepository{}
 
-	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour)
+	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour, usecase.PolygonLimits{})
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, logger)
+	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, nil, logger)
 	ctx := context.Background()
 
 	req := dto.EnrichLocationBatchRequest{
@@ -62,9 +62,9 @@ func TestEnrichedLocationUseCase_EnrichLocationBatch_Success(t *testing.T) {
 	mockTransport := &MockTransportRepository{}
 	ctx := context.Background()
 
-	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour)
+	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour, usecase.PolygonLimits{})
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, logger)
+	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, nil, logger)
 
 	// Input: one visible location
 	locations := []dto.LocationInput{
@@ -108,7 +108,7 @@ func TestEnrichedLocationUseCase_EnrichLocationBatch_Success(t *testing.T) {
 					Type:      "metro",
 					Lat:       41.3950,
 					Lon:       2.1640,
-					Distance:  250.5,
+					DistanceM: 250.5,
 					Lines: []domain.TransportLineInfo{
 						{ID: 1, Name: "L1", Type: "metro"},
 					},
@@ -154,9 +154,9 @@ func TestEnrichedLocationUseCase_EnrichLocationBatch_WithoutVisibleLocations(t *
 	mockTransport := &MockTransportRepository{}
 	ctx := context.Background()
 
-	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour)
+	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour, usecase.PolygonLimits{})
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, logger)
+	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, nil, logger)
 
 	// Input: non-visible location (no coordinates or IsVisible=false)
 	locations := []dto.LocationInput{
@@ -212,9 +212,9 @@ func TestEnrichedLocationUseCase_EnrichLocationBatch_TransportError(t *testing.T
 	mockTransport := &MockTransportRepository{}
 	ctx := context.Background()
 
-	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour)
+	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour, usecase.PolygonLimits{})
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, logger)
+	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, nil, logger)
 
 	locations := []dto.LocationInput{
 		{
@@ -265,12 +265,49 @@ func TestEnrichedLocationUseCase_ImplementsInterfaces(t *testing.T) {
 	mockCache := &MockCacheRepository{}
 	mockTransport := &MockTransportRepository{}
 
-	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour)
+	searchUC := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour, usecase.PolygonLimits{})
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, logger)
+	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, nil, logger)
 
 	// Verify interfaces
 	var _ usecase.BatchLocationEnricher = uc
 	var _ usecase.LocationEnricher = uc
 	assert.NotNil(t, uc)
 }
+
+func TestEnrichedLocationUseCase_EnrichLocationBatch_AdaptiveRadius(t *testing.T) {
+	logger := zap.NewNop()
+	mockBoundary := &MockBoundaryRepository{}
+	mockTransport := &MockTransportRepository{}
+	ctx := context.Background()
+
+	adaptive := &domain.AdaptiveTransportRadius{ProbeRadiusM: 1000, TargetCount: 10, MinRadiusM: 400, MaxRadiusM: 3000}
+	searchUC := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
+	uc := usecase.NewEnrichedLocationUseCase(searchUC, transportUC, adaptive, logger)
+
+	mockBoundary.On("GetByPointBatch", ctx, mock.Anything).Return(map[int][]*domain.AdminBoundary{
+		0: {{ID: 1, AdminLevel: 2, Name: "Spain"}},
+		1: {{ID: 1, AdminLevel: 2, Name: "Spain"}},
+	}, nil)
+	// Центр города: 40 станций в пробном радиусе - радиус сжимается до 500 м;
+	// пригород: подсчет не удался - DefaultTransportRadius
+	mockTransport.On("CountStationsNearby", ctx, 41.3851, 2.1734, []string(nil), 1.0).Return(40, nil)
+	mockTransport.On("CountStationsNearby", ctx, 41.5, 2.0, []string(nil), 1.0).Return(0, assert.AnError)
+	mockTransport.On("GetNearestTransportByPriorityBatch", ctx,
+		mock.MatchedBy(func(points []domain.TransportSearchPoint) bool {
+			return len(points) == 2 && points[0].RadiusM == 500 && points[1].RadiusM == usecase.DefaultTransportRadius
+		}), float64(usecase.DefaultTransportRadius), usecase.DefaultTransportLimit).
+		Return([]domain.BatchTransportResult{{PointIndex: 0}, {PointIndex: 1}}, nil)
+
+	result, err := uc.EnrichLocationBatch(ctx, dto.EnrichLocationBatchRequest{
+		Locations: []dto.LocationInput{
+			{Index: 0, Country: "Spain", Latitude: ptrFloat64(41.3851), Longitude: ptrFloat64(2.1734), IsVisible: ptrBool(true)},
+			{Index: 1, Country: "Spain", Latitude: ptrFloat64(41.5), Longitude: ptrFloat64(2.0), IsVisible: ptrBool(true)},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Meta.WithTransport)
+	mockTransport.AssertExpectations(t)
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/addressparser"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase/dto"
+)
+
+// EnrichAddress разбирает адрес одной строкой (addressparser), раскладывает части по полям
+// LocationEnrichEvent и обогащает его как EnrichLocationWithProfile. С подсказкой req.Country
+// последняя часть строки считается страной, только если совпадает с подсказкой; без страны
+// и координат обогащать нечего.
+func (uc *EnrichmentUseCase) EnrichAddress(
+	ctx context.Context,
+	req dto.EnrichAddressRequest,
+	profileName string,
+) (*dto.EnrichAddressResponse, error) {
+	parsed := addressparser.ParseWithCountry(req.Address, req.Country)
+
+	event := &domain.LocationEnrichEvent{
+		Country:      parsed.Country,
+		Province:     optionalString(parsed.Province),
+		City:         optionalString(parsed.City),
+		District:     optionalString(parsed.District),
+		Neighborhood: optionalString(parsed.Neighborhood),
+		Street:       optionalString(parsed.Street),
+		HouseNumber:  optionalString(parsed.HouseNumber),
+		PostalCode:   optionalString(parsed.PostalCode),
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		IsVisible:    req.IsVisible,
+	}
+	if event.Country == "" && (event.Latitude == nil || event.Longitude == nil) {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"address": "country not recognized: end the address with it or pass country",
+			"parsed":  parsed,
+		})
+	}
+
+	result, err := uc.EnrichLocationWithProfile(ctx, event, profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.EnrichAddressResponse{
+		Parsed: parsed,
+		Result: result,
+	}, nil
+}
+
+// optionalString возвращает nil для пустой строки
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
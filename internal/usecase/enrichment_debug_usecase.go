@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+// Стратегии резолва административной иерархии (name:levelN и fallback:levelN формируются по уровню)
+const (
+	resolutionStrategyCoordinates         = "coordinates"
+	resolutionStrategyFallbackCoordinates = "fallback:coordinates"
+	resolutionStrategyCountry             = "fallback:country"
+	resolutionStrategyUnresolved          = "unresolved"
+	resolutionStrategySkipped             = "skipped" // профиль без блока admin
+)
+
+// resolutionTrace собирает путь резолва и частичные ошибки обогащения.
+// Блоки обогащения выполняются параллельно, поэтому запись под мьютексом; nil trace игнорируется.
+type resolutionTrace struct {
+	mu               sync.Mutex
+	strategy         string
+	warnings         []string
+	discarded        []dto.DiscardedBoundary
+	transportRadiusM float64
+}
+
+func (t *resolutionTrace) setStrategy(strategy string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.strategy = strategy
+}
+
+func (t *resolutionTrace) warn(format string, args ...interface{}) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.warnings = append(t.warnings, fmt.Sprintf(format, args...))
+}
+
+// setTransportRadius запоминает радиус, в котором искался транспорт
+func (t *resolutionTrace) setTransportRadius(radiusM float64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.transportRadiusM = radiusM
+}
+
+// discard запоминает границы, отброшенные при пересечении с выбранной границей того же уровня
+func (t *resolutionTrace) discard(picked, discarded []*domain.AdminBoundary) {
+	if t == nil {
+		return
+	}
+
+	keptByLevel := make(map[int]int64, len(picked))
+	for _, b := range picked {
+		keptByLevel[b.AdminLevel] = b.ID
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, b := range discarded {
+		t.discarded = append(t.discarded, dto.DiscardedBoundary{
+			ID:         b.ID,
+			Name:       b.Name,
+			AdminLevel: b.AdminLevel,
+			AreaSqKm:   b.AreaSqKm,
+			KeptID:     keptByLevel[b.AdminLevel],
+		})
+	}
+}
+
+// EnrichmentDebugUseCase - диагностика обогащения: какой путь резолва дал результат и что не удалось
+type EnrichmentDebugUseCase struct {
+	enrichmentUC *EnrichmentUseCase
+	logger       *zap.Logger
+}
+
+// NewEnrichmentDebugUseCase создает новый EnrichmentDebugUseCase
+func NewEnrichmentDebugUseCase(enrichmentUC *EnrichmentUseCase, logger *zap.Logger) *EnrichmentDebugUseCase {
+	return &EnrichmentDebugUseCase{
+		enrichmentUC: enrichmentUC,
+		logger:       logger,
+	}
+}
+
+// Enrich обогащает локацию так же, как EnrichLocationWithProfile, и дополнительно возвращает
+// стратегию резолва ("name:level8", "coordinates", "fallback:country", ...) и предупреждения
+// о частичных сбоях (граница не найдена, иерархия неполная, блок транспорта упал).
+func (uc *EnrichmentDebugUseCase) Enrich(ctx context.Context, event *domain.LocationEnrichEvent, profileName string) (*dto.EnrichmentDebugResponse, error) {
+	trace := &resolutionTrace{strategy: resolutionStrategySkipped}
+
+	result, err := uc.enrichmentUC.enrichWithTrace(ctx, event, profileName, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := trace.warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+
+	uc.logger.Debug("Enrichment debug",
+		zap.String("resolution_strategy", trace.strategy),
+		zap.Strings("warnings", warnings))
+
+	return &dto.EnrichmentDebugResponse{
+		ResolutionStrategy:  trace.strategy,
+		Warnings:            warnings,
+		DiscardedBoundaries: trace.discarded,
+		TransportRadiusM:    trace.transportRadiusM,
+		Result:              result,
+	}, nil
+}
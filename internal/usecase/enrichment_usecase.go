@@ -3,113 +3,280 @@ package usecase
 import (
 	"context"
 	"fmt"
-	"math"
+	"sync"
 
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
 	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
 	"go.uber.org/zap"
 )
 
+// Параметры дополнительных блоков обогащения (transport задается через transportRadius)
+const (
+	enrichmentEnvironmentRadiusKm = 1.0 // радиус поиска парков, воды и пляжей
+	enrichmentPOIRadiusKm         = 0.5 // радиус поиска ближайших POI
+	enrichmentPOILimit            = 10  // максимум POI в результате
+)
+
 // EnrichmentUseCase - use case для обогащения локаций
 type EnrichmentUseCase struct {
 	boundaryRepo    repository.BoundaryRepository
 	transportRepo   repository.TransportRepository
+	environmentRepo repository.EnvironmentRepository
+	poiRepo         repository.POIRepository
 	logger          *zap.Logger
 	transportTypes  []string
 	transportRadius float64
+	profiles        map[string]domain.EnrichmentProfile
+	defaultProfile  string
+	resolutionOrder []domain.ResolutionStrategy
+	adaptiveRadius  *domain.AdaptiveTransportRadius
 }
 
-// NewEnrichmentUseCase создает новый EnrichmentUseCase
+// NewEnrichmentUseCase создает новый EnrichmentUseCase.
+// profiles - доступные профили обогащения (nil - встроенные minimal/full),
+// defaultProfile используется, если профиль не указан в запросе,
+// resolutionOrder - порядок стратегий резолва иерархии (nil - domain.DefaultResolutionOrder),
+// adaptiveRadius - радиус поиска транспорта по плотности станций (nil - фиксированный transportRadius).
 func NewEnrichmentUseCase(
 	boundaryRepo repository.BoundaryRepository,
 	transportRepo repository.TransportRepository,
+	environmentRepo repository.EnvironmentRepository,
+	poiRepo repository.POIRepository,
 	logger *zap.Logger,
 	transportTypes []string,
 	transportRadius float64,
+	profiles map[string]domain.EnrichmentProfile,
+	defaultProfile string,
+	resolutionOrder []domain.ResolutionStrategy,
+	adaptiveRadius *domain.AdaptiveTransportRadius,
 ) *EnrichmentUseCase {
+	if profiles == nil {
+		profiles = domain.DefaultEnrichmentProfiles()
+	}
+	if _, ok := profiles[defaultProfile]; !ok {
+		defaultProfile = domain.EnrichmentProfileMinimal
+	}
+	if len(resolutionOrder) == 0 {
+		resolutionOrder = domain.DefaultResolutionOrder()
+	}
+
 	return &EnrichmentUseCase{
 		boundaryRepo:    boundaryRepo,
 		transportRepo:   transportRepo,
+		environmentRepo: environmentRepo,
+		poiRepo:         poiRepo,
 		logger:          logger,
 		transportTypes:  transportTypes,
 		transportRadius: transportRadius,
+		profiles:        profiles,
+		defaultProfile:  defaultProfile,
+		resolutionOrder: resolutionOrder,
+		adaptiveRadius:  adaptiveRadius,
 	}
 }
 
-// EnrichLocation обогащает локацию из события
+// Profiles возвращает доступные профили обогащения, отсортированные по имени
+func (uc *EnrichmentUseCase) Profiles() []domain.EnrichmentProfile {
+	return domain.SortedEnrichmentProfiles(uc.profiles)
+}
+
+// DefaultProfile возвращает имя профиля по умолчанию
+func (uc *EnrichmentUseCase) DefaultProfile() string {
+	return uc.defaultProfile
+}
+
+// EnrichLocation обогащает локацию из события с профилем по умолчанию
 func (uc *EnrichmentUseCase) EnrichLocation(ctx context.Context, event *domain.LocationEnrichEvent) (*domain.LocationDoneEvent, error) {
+	return uc.EnrichLocationWithProfile(ctx, event, "")
+}
+
+// EnrichLocationWithProfile обогащает локацию блоками данных из профиля.
+// Блоки выполняются параллельно; ошибка резолва иерархии записывается в Error,
+// ошибки остальных блоков не критичны и только логируются.
+func (uc *EnrichmentUseCase) EnrichLocationWithProfile(
+	ctx context.Context,
+	event *domain.LocationEnrichEvent,
+	profileName string,
+) (*domain.LocationDoneEvent, error) {
+	return uc.enrichWithTrace(ctx, event, profileName, nil)
+}
+
+// enrichWithTrace - реализация EnrichLocationWithProfile; trace (может быть nil) собирает
+// стратегию резолва и частичные ошибки для EnrichmentDebugUseCase
+func (uc *EnrichmentUseCase) enrichWithTrace(
+	ctx context.Context,
+	event *domain.LocationEnrichEvent,
+	profileName string,
+	trace *resolutionTrace,
+) (*domain.LocationDoneEvent, error) {
+	if profileName == "" {
+		profileName = uc.defaultProfile
+	}
+	profile, ok := uc.profiles[profileName]
+	if !ok {
+		return nil, errors.New("UNKNOWN_ENRICHMENT_PROFILE", fmt.Sprintf("unknown enrichment profile: %s", profileName), 400)
+	}
+
 	result := &domain.LocationDoneEvent{
 		PropertyID: event.PropertyID,
+		Profile:    profile.Name,
 	}
 
-	// Попытка резолвить локацию
-	enrichedLocation, err := uc.resolveLocation(ctx, event)
-	if err != nil {
-		uc.logger.Error("Failed to resolve location",
-			zap.String("property_id", event.PropertyID.String()),
-			zap.Error(err))
-		result.Error = fmt.Sprintf("failed to resolve location: %v", err)
-		return result, nil // Возвращаем результат с ошибкой, но не прерываем обработку
-	}
-
-	// Определяем видимость адреса
-	// isVisible := uc.isAddressVisible(event)
-	// enrichedLocation.IsAddressVisible = &isVisible
-
-	result.EnrichedLocation = enrichedLocation
-
-	// Покачто скрываем
-	// Поиск ближайшего транспорта, если есть координаты
-	// if event.Latitude != nil && event.Longitude != nil {
-	// 	nearestTransport, err := uc.findNearestTransport(ctx, *event.Latitude, *event.Longitude)
-	// 	if err != nil {
-	// 		uc.logger.Warn("Failed to find nearest transport",
-	// 			zap.String("property_id", event.PropertyID.String()),
-	// 			zap.Error(err))
-	// 		// Не считаем это критичной ошибкой
-	// 	} else {
-	// 		result.NearestTransport = nearestTransport
-	// 	}
-	// }
+	hasCoordinates := event.Latitude != nil && event.Longitude != nil
+	var wg sync.WaitGroup
+
+	if profile.Has(domain.EnrichmentFeatureAdmin) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			enrichedLocation, err := uc.resolveLocation(ctx, event, trace)
+			if err != nil {
+				uc.logger.Error("Failed to resolve location",
+					zap.String("property_id", event.PropertyID.String()),
+					zap.Error(err))
+				result.Error = fmt.Sprintf("failed to resolve location: %v", err)
+				trace.setStrategy(resolutionStrategyUnresolved)
+				trace.warn("location not resolved: %v", err)
+				return
+			}
+			result.EnrichedLocation = enrichedLocation
+		}()
+	}
+
+	if hasCoordinates && profile.Has(domain.EnrichmentFeatureTransport) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nearestTransport, err := uc.findNearestTransport(ctx, *event.Latitude, *event.Longitude, trace)
+			if err != nil {
+				uc.logger.Warn("Failed to find nearest transport",
+					zap.String("property_id", event.PropertyID.String()),
+					zap.Error(err))
+				trace.warn("transport not found: %v", err)
+				return
+			}
+			result.NearestTransport = nearestTransport
+		}()
+	}
+
+	if hasCoordinates && uc.environmentRepo != nil && profile.Has(domain.EnrichmentFeatureEnvironment) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result.Environment = uc.findEnvironment(ctx, *event.Latitude, *event.Longitude)
+		}()
+	}
+
+	if hasCoordinates && uc.poiRepo != nil && profile.Has(domain.EnrichmentFeaturePOI) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pois, err := uc.findNearestPOIs(ctx, *event.Latitude, *event.Longitude)
+			if err != nil {
+				uc.logger.Warn("Failed to find nearest POIs",
+					zap.String("property_id", event.PropertyID.String()),
+					zap.Error(err))
+				trace.warn("nearest POIs not found: %v", err)
+				return
+			}
+			result.NearestPOIs = pois
+		}()
+	}
+
+	wg.Wait()
 
 	return result, nil
 }
 
-// resolveLocation резолвит локацию из события
-func (uc *EnrichmentUseCase) resolveLocation(ctx context.Context, event *domain.LocationEnrichEvent) (*domain.EnrichedLocation, error) {
-	// Стратегия 1: Поиск от самого детального уровня к общему
-	if event.Neighborhood != nil && *event.Neighborhood != "" {
-		return uc.resolveFromLevel(ctx, *event.Neighborhood, 10, event)
+// locationResolver - одна стратегия резолва иерархии; ok=false, если в событии нет данных для нее
+type locationResolver func(ctx context.Context, event *domain.LocationEnrichEvent, trace *resolutionTrace) (result *domain.EnrichedLocation, ok bool, err error)
+
+// resolveLocation перебирает стратегии в порядке uc.resolutionOrder, побеждает первая успешная.
+// Стратегии без входных данных пропускаются, при неудаче всех возвращается последняя ошибка.
+func (uc *EnrichmentUseCase) resolveLocation(ctx context.Context, event *domain.LocationEnrichEvent, trace *resolutionTrace) (*domain.EnrichedLocation, error) {
+	var lastErr error
+	for _, strategy := range uc.resolutionOrder {
+		result, ok, err := uc.resolver(strategy)(ctx, event, trace)
+		if !ok {
+			continue
+		}
+		if err == nil {
+			return result, nil
+		}
+		trace.warn("strategy %s failed: %v", strategy, err)
+		lastErr = err
 	}
 
-	if event.District != nil && *event.District != "" {
-		return uc.resolveFromLevel(ctx, *event.District, 9, event)
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no resolution strategy applicable: event has no names, coordinates or country")
 	}
+	return nil, lastErr
+}
 
-	if event.City != nil && *event.City != "" {
-		return uc.resolveFromLevel(ctx, *event.City, 8, event)
+// resolver возвращает реализацию стратегии резолва
+func (uc *EnrichmentUseCase) resolver(strategy domain.ResolutionStrategy) locationResolver {
+	switch strategy {
+	case domain.ResolutionStrategyCoordinates:
+		return uc.resolveByCoordinates
+	case domain.ResolutionStrategyCountry:
+		return uc.resolveByCountry
+	default:
+		return uc.resolveByName
 	}
+}
 
-	if event.Province != nil && *event.Province != "" {
-		return uc.resolveFromLevel(ctx, *event.Province, 6, event)
+// resolveByName ищет границу по самому детальному из заполненных названий
+func (uc *EnrichmentUseCase) resolveByName(ctx context.Context, event *domain.LocationEnrichEvent, trace *resolutionTrace) (*domain.EnrichedLocation, bool, error) {
+	levels := []struct {
+		name  *string
+		level int
+	}{
+		{event.Neighborhood, 10},
+		{event.District, 9},
+		{event.City, 8},
+		{event.Province, 6},
+		{event.Region, 4},
+	}
+
+	for _, l := range levels {
+		if l.name != nil && *l.name != "" {
+			result, err := uc.resolveFromLevel(ctx, *l.name, l.level, event, trace)
+			return result, true, err
+		}
 	}
+	return nil, false, nil
+}
 
-	if event.Region != nil && *event.Region != "" {
-		return uc.resolveFromLevel(ctx, *event.Region, 4, event)
+// resolveByCoordinates - reverse geocoding по координатам события
+func (uc *EnrichmentUseCase) resolveByCoordinates(ctx context.Context, event *domain.LocationEnrichEvent, trace *resolutionTrace) (*domain.EnrichedLocation, bool, error) {
+	if event.Latitude == nil || event.Longitude == nil {
+		return nil, false, nil
 	}
 
-	// Стратегия 2: Reverse geocoding по координатам
-	if event.Latitude != nil && event.Longitude != nil {
-		return uc.resolveFromCoordinates(ctx, *event.Latitude, *event.Longitude)
+	result, err := uc.resolveFromCoordinates(ctx, *event.Latitude, *event.Longitude, trace)
+	if err == nil {
+		trace.setStrategy(resolutionStrategyCoordinates)
+	}
+	return result, true, err
+}
+
+// resolveByCountry ищет только страну
+func (uc *EnrichmentUseCase) resolveByCountry(ctx context.Context, event *domain.LocationEnrichEvent, trace *resolutionTrace) (*domain.EnrichedLocation, bool, error) {
+	if event.Country == "" {
+		return nil, false, nil
 	}
 
-	// Стратегия 3: Поиск только страны
-	return uc.resolveFromLevel(ctx, event.Country, 2, event)
+	result, err := uc.resolveFromLevel(ctx, event.Country, 2, event, trace)
+	if err == nil {
+		trace.setStrategy(resolutionStrategyCountry)
+	}
+	return result, true, err
 }
 
 // resolveFromLevel резолвит локацию начиная с определенного уровня
-func (uc *EnrichmentUseCase) resolveFromLevel(ctx context.Context, name string, adminLevel int, event *domain.LocationEnrichEvent) (*domain.EnrichedLocation, error) {
+func (uc *EnrichmentUseCase) resolveFromLevel(ctx context.Context, name string, adminLevel int, event *domain.LocationEnrichEvent, trace *resolutionTrace) (*domain.EnrichedLocation, error) {
 	// Ищем границу по названию
 	boundary, err := uc.findBoundaryByName(ctx, name, adminLevel)
 	if err != nil {
@@ -133,15 +300,18 @@ func (uc *EnrichmentUseCase) resolveFromLevel(ctx context.Context, name string,
 		uc.logger.Debug("Hierarchy incomplete, trying fallback strategies",
 			zap.String("name", name),
 			zap.Int("admin_level", adminLevel))
+		trace.warn("hierarchy of %q (level %d) incomplete: %v", name, adminLevel, err)
 
-		return uc.resolveWithFallback(ctx, boundary, event)
+		return uc.resolveWithFallback(ctx, boundary, event, trace)
 	}
 
+	trace.setStrategy(fmt.Sprintf("name:level%d", adminLevel))
 	return result, nil
 }
 
-// resolveFromCoordinates резолвит локацию по координатам
-func (uc *EnrichmentUseCase) resolveFromCoordinates(ctx context.Context, lat, lon float64) (*domain.EnrichedLocation, error) {
+// resolveFromCoordinates резолвит локацию по координатам. Пересекающиеся границы одного уровня
+// сводятся к одной (domain.PickBoundaryPerLevel), отброшенные попадают в trace.
+func (uc *EnrichmentUseCase) resolveFromCoordinates(ctx context.Context, lat, lon float64, trace *resolutionTrace) (*domain.EnrichedLocation, error) {
 	// Получаем границы для точки
 	boundaries, err := uc.boundaryRepo.GetByPoint(ctx, lat, lon)
 	if err != nil {
@@ -152,24 +322,19 @@ func (uc *EnrichmentUseCase) resolveFromCoordinates(ctx context.Context, lat, lo
 		return nil, errors.ErrLocationNotFound
 	}
 
-	// Создаем результат из всех найденных границ
+	boundaries, discarded := domain.PickBoundaryPerLevel(boundaries)
+	if len(discarded) > 0 {
+		uc.logger.Debug("Overlapping boundaries at the same admin level",
+			zap.Float64("lat", lat),
+			zap.Float64("lon", lon),
+			zap.Int("discarded", len(discarded)))
+		trace.discard(boundaries, discarded)
+	}
+
+	// Создаем результат из найденных границ
 	result := &domain.EnrichedLocation{}
 	for _, boundary := range boundaries {
-		info := uc.boundaryToInfo(boundary)
-		switch boundary.AdminLevel {
-		case 2:
-			result.Country = info
-		case 4:
-			result.Region = info
-		case 6:
-			result.Province = info
-		case 8:
-			result.City = info
-		case 9:
-			result.District = info
-		case 10:
-			result.Neighborhood = info
-		}
+		setEnrichedBoundary(result, boundary.AdminLevel, uc.boundaryToInfo(boundary))
 	}
 
 	// Проверяем, что найден хотя бы один уровень иерархии
@@ -183,47 +348,11 @@ func (uc *EnrichmentUseCase) resolveFromCoordinates(ctx context.Context, lat, lo
 
 // boundaryToInfo преобразует AdminBoundary в BoundaryInfo
 func (uc *EnrichmentUseCase) boundaryToInfo(boundary *domain.AdminBoundary) *domain.BoundaryInfo {
-	info := &domain.BoundaryInfo{
+	return &domain.BoundaryInfo{
 		ID:             boundary.ID,
 		Name:           boundary.Name,
-		TranslateNames: make(map[string]string),
-	}
-
-	// Собираем все переводы
-	if boundary.NameEn != "" {
-		info.TranslateNames["en"] = boundary.NameEn
-	}
-	if boundary.NameEs != "" {
-		info.TranslateNames["es"] = boundary.NameEs
-	}
-	if boundary.NameCa != "" {
-		info.TranslateNames["ca"] = boundary.NameCa
-	}
-	if boundary.NameRu != "" {
-		info.TranslateNames["ru"] = boundary.NameRu
-	}
-	if boundary.NameUk != "" {
-		info.TranslateNames["uk"] = boundary.NameUk
-	}
-	if boundary.NameFr != "" {
-		info.TranslateNames["fr"] = boundary.NameFr
-	}
-	if boundary.NamePt != "" {
-		info.TranslateNames["pt"] = boundary.NamePt
-	}
-	if boundary.NameIt != "" {
-		info.TranslateNames["it"] = boundary.NameIt
-	}
-	if boundary.NameDe != "" {
-		info.TranslateNames["de"] = boundary.NameDe
+		TranslateNames: boundary.Translations(), // языки из NAME_LANGUAGES, без переводов - nil
 	}
-
-	// Если нет переводов, не возвращаем пустую map
-	if len(info.TranslateNames) == 0 {
-		info.TranslateNames = nil
-	}
-
-	return info
 }
 
 // resolveLocationHierarchy получает всю иерархию локации через parent_id
@@ -250,28 +379,12 @@ func (uc *EnrichmentUseCase) resolveLocationHierarchy(ctx context.Context, bound
 			zap.Int64p("parent_id", boundary.ParentID))
 
 		// Заполняем соответствующее поле в зависимости от admin_level
-		info := uc.boundaryToInfo(boundary)
-		switch boundary.AdminLevel {
-		case 2:
-			result.Country = info
-			uc.logger.Debug("Set Country", zap.Int64("country_id", boundary.ID))
-		case 4:
-			result.Region = info
-			uc.logger.Debug("Set Region", zap.Int64("region_id", boundary.ID))
-		case 6:
-			result.Province = info
-			uc.logger.Debug("Set Province", zap.Int64("province_id", boundary.ID))
-		case 8:
-			result.City = info
-			uc.logger.Debug("Set City", zap.Int64("city_id", boundary.ID))
-		case 9:
-			result.District = info
-			uc.logger.Debug("Set District", zap.Int64("district_id", boundary.ID))
-		case 10:
-			result.Neighborhood = info
-			uc.logger.Debug("Set Neighborhood", zap.Int64("neighborhood_id", boundary.ID))
-		default:
-			uc.logger.Debug("Skipping unknown admin_level", zap.Int("admin_level", boundary.AdminLevel))
+		if setEnrichedBoundary(result, boundary.AdminLevel, uc.boundaryToInfo(boundary)) {
+			uc.logger.Debug("Set admin level",
+				zap.Int("admin_level", boundary.AdminLevel),
+				zap.Int64("boundary_id", boundary.ID))
+		} else {
+			uc.logger.Debug("Skipping unknown or excluded admin_level", zap.Int("admin_level", boundary.AdminLevel))
 		}
 
 		// Переходим к родительской границе
@@ -303,7 +416,7 @@ func (uc *EnrichmentUseCase) findBoundaryByName(ctx context.Context, name string
 		zap.Int("admin_level", adminLevel))
 
 	// Поиск по всем языковым полям
-	boundaries, err := uc.boundaryRepo.SearchByText(ctx, name, "", []int{adminLevel}, 1)
+	boundaries, err := uc.boundaryRepo.SearchByText(ctx, name, "", []int{adminLevel}, 1, domain.BoundarySearchOptions{})
 	if err != nil {
 		uc.logger.Error("SearchByText failed",
 			zap.String("name", name),
@@ -334,25 +447,12 @@ func (uc *EnrichmentUseCase) findBoundaryByName(ctx context.Context, name string
 }
 
 // resolveWithFallback пытается восстановить иерархию через альтернативные методы
-func (uc *EnrichmentUseCase) resolveWithFallback(ctx context.Context, boundary *domain.AdminBoundary, event *domain.LocationEnrichEvent) (*domain.EnrichedLocation, error) {
+func (uc *EnrichmentUseCase) resolveWithFallback(ctx context.Context, boundary *domain.AdminBoundary, event *domain.LocationEnrichEvent, trace *resolutionTrace) (*domain.EnrichedLocation, error) {
 	result := &domain.EnrichedLocation{}
+	strategy := fmt.Sprintf("fallback:level%d", boundary.AdminLevel)
 
 	// Сохраняем найденную границу
-	info := uc.boundaryToInfo(boundary)
-	switch boundary.AdminLevel {
-	case 2:
-		result.Country = info
-	case 4:
-		result.Region = info
-	case 6:
-		result.Province = info
-	case 8:
-		result.City = info
-	case 9:
-		result.District = info
-	case 10:
-		result.Neighborhood = info
-	}
+	setEnrichedBoundary(result, boundary.AdminLevel, uc.boundaryToInfo(boundary))
 
 	uc.logger.Debug("Fallback: saved initial boundary",
 		zap.Int("admin_level", boundary.AdminLevel),
@@ -364,31 +464,20 @@ func (uc *EnrichmentUseCase) resolveWithFallback(ctx context.Context, boundary *
 			zap.Float64("lat", *event.Latitude),
 			zap.Float64("lon", *event.Longitude))
 
-		coordResult, err := uc.resolveFromCoordinates(ctx, *event.Latitude, *event.Longitude)
+		coordResult, err := uc.resolveFromCoordinates(ctx, *event.Latitude, *event.Longitude, trace)
 		if err == nil {
 			// Объединяем результаты: берем недостающие уровни из координат
-			if result.Country == nil && coordResult.Country != nil {
-				result.Country = coordResult.Country
-			}
-			if result.Region == nil && coordResult.Region != nil {
-				result.Region = coordResult.Region
-			}
-			if result.Province == nil && coordResult.Province != nil {
-				result.Province = coordResult.Province
-			}
-			if result.City == nil && coordResult.City != nil {
-				result.City = coordResult.City
-			}
-			if result.District == nil && coordResult.District != nil {
-				result.District = coordResult.District
-			}
-			if result.Neighborhood == nil && coordResult.Neighborhood != nil {
-				result.Neighborhood = coordResult.Neighborhood
+			for _, level := range domain.EnrichedLocationLevels {
+				if result.Boundary(level) == nil && coordResult.Boundary(level) != nil {
+					result.SetBoundary(level, coordResult.Boundary(level))
+				}
 			}
 
 			uc.logger.Debug("Fallback: merged with coordinate results")
+			strategy = resolutionStrategyFallbackCoordinates
 		} else {
 			uc.logger.Debug("Fallback: coordinates resolution returned no extra data")
+			trace.warn("coordinates fallback failed: %v", err)
 		}
 	}
 
@@ -403,8 +492,12 @@ func (uc *EnrichmentUseCase) resolveWithFallback(ctx context.Context, boundary *
 			uc.logger.Debug("Fallback: found country",
 				zap.Int64("country_id", countryBoundary.ID),
 				zap.String("country_name", countryBoundary.Name))
+			if strategy != resolutionStrategyFallbackCoordinates {
+				strategy = resolutionStrategyCountry
+			}
 		} else {
 			uc.logger.Debug("Fallback: country not in OSM data (expected for partial imports)")
+			trace.warn("country %q not found", event.Country)
 		}
 	}
 
@@ -414,19 +507,47 @@ func (uc *EnrichmentUseCase) resolveWithFallback(ctx context.Context, boundary *
 	}
 
 	uc.logger.Debug("Fallback resolution successful")
+	trace.setStrategy(strategy)
 
 	return result, nil
 }
 
+// transportRadiusKm возвращает радиус поиска транспорта в точке: фиксированный transportRadius
+// или, при включенном adaptiveRadius, подобранный по числу станций в пробном радиусе.
+// Если подсчет не удался, используется фиксированный радиус.
+func (uc *EnrichmentUseCase) transportRadiusKm(ctx context.Context, lat, lon float64, trace *resolutionTrace) float64 {
+	if uc.adaptiveRadius == nil {
+		return uc.transportRadius
+	}
+
+	count, err := uc.transportRepo.CountStationsNearby(ctx, lat, lon, uc.transportTypes, uc.adaptiveRadius.ProbeRadiusM/1000)
+	if err != nil {
+		uc.logger.Warn("Failed to count stations for adaptive transport radius", zap.Error(err))
+		trace.warn("adaptive transport radius fell back to %.0fm: %v", uc.transportRadius*1000, err)
+		return uc.transportRadius
+	}
+
+	radiusM := uc.adaptiveRadius.RadiusFor(count)
+	uc.logger.Debug("Adaptive transport radius",
+		zap.Int("probe_count", count),
+		zap.Float64("radius_m", radiusM))
+	return radiusM / 1000
+}
+
 // findNearestTransport находит ближайшие станции транспорта
-func (uc *EnrichmentUseCase) findNearestTransport(ctx context.Context, lat, lon float64) ([]domain.NearestStation, error) {
+func (uc *EnrichmentUseCase) findNearestTransport(ctx context.Context, lat, lon float64, trace *resolutionTrace) ([]domain.NearestStation, error) {
+	radiusKm := uc.transportRadiusKm(ctx, lat, lon, trace)
+	trace.setTransportRadius(radiusKm * 1000)
+
 	stations, err := uc.transportRepo.GetNearestStations(
 		ctx,
 		lat,
 		lon,
 		uc.transportTypes,
-		uc.transportRadius,
+		radiusKm,
 		10, // максимум 10 станций
+		false,
+		0,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nearest stations: %w", err)
@@ -435,8 +556,8 @@ func (uc *EnrichmentUseCase) findNearestTransport(ctx context.Context, lat, lon
 	// Преобразуем в формат NearestStation и вычисляем точное расстояние
 	result := make([]domain.NearestStation, 0, len(stations))
 	for _, station := range stations {
-		// Вычисляем расстояние от координат свойства до станции
-		distance := uc.calculateDistance(lat, lon, station.Lat, station.Lon)
+		// Расстояние от координат свойства до станции (из БД, иначе Haversine)
+		distance := utils.DistanceMeters(station.DistanceM, lat, lon, station.Lat, station.Lon)
 
 		// Получаем линии для станции
 		lines, err := uc.transportRepo.GetLinesByStationID(ctx, station.ID)
@@ -457,8 +578,8 @@ func (uc *EnrichmentUseCase) findNearestTransport(ctx context.Context, lat, lon
 			StationID: station.ID,
 			Name:      station.Name,
 			Type:      station.Type,
-			Lat:       station.Lat,
-			Lon:       station.Lon,
+			Lat:       utils.RoundCoordinate(station.Lat),
+			Lon:       utils.RoundCoordinate(station.Lon),
 			Distance:  distance,
 			Lines:     lineInfos,
 		})
@@ -467,6 +588,67 @@ func (uc *EnrichmentUseCase) findNearestTransport(ctx context.Context, lat, lon
 	return result, nil
 }
 
+// findEnvironment собирает парки, водоемы и пляжи рядом с точкой (ошибки отдельных запросов не критичны)
+func (uc *EnrichmentUseCase) findEnvironment(ctx context.Context, lat, lon float64) *domain.EnvironmentSummary {
+	summary := &domain.EnvironmentSummary{}
+
+	greenSpaces, err := uc.environmentRepo.GetGreenSpacesNearby(ctx, lat, lon, enrichmentEnvironmentRadiusKm, 0, domain.EnvironmentOrderOptions{})
+	if err != nil {
+		uc.logger.Warn("Failed to get green spaces for enrichment", zap.Error(err))
+	} else {
+		for _, g := range greenSpaces {
+			g.CenterLat, g.CenterLon = utils.RoundCoordinate(g.CenterLat), utils.RoundCoordinate(g.CenterLon)
+		}
+		summary.GreenSpaces = greenSpaces
+	}
+
+	waterBodies, err := uc.environmentRepo.GetWaterBodiesNearby(ctx, lat, lon, enrichmentEnvironmentRadiusKm)
+	if err != nil {
+		uc.logger.Warn("Failed to get water bodies for enrichment", zap.Error(err))
+	} else {
+		summary.WaterBodies = waterBodies
+	}
+
+	beaches, err := uc.environmentRepo.GetBeachesNearby(ctx, lat, lon, enrichmentEnvironmentRadiusKm)
+	if err != nil {
+		uc.logger.Warn("Failed to get beaches for enrichment", zap.Error(err))
+	} else {
+		for _, b := range beaches {
+			b.Lat, b.Lon = utils.RoundCoordinate(b.Lat), utils.RoundCoordinate(b.Lon)
+		}
+		summary.Beaches = beaches
+	}
+
+	return summary
+}
+
+// findNearestPOIs находит ближайшие POI (отсортированы по расстоянию)
+func (uc *EnrichmentUseCase) findNearestPOIs(ctx context.Context, lat, lon float64) ([]domain.POIWithDistance, error) {
+	pois, err := uc.poiRepo.GetNearby(ctx, lat, lon, enrichmentPOIRadiusKm, nil, domain.POINearbyOptions{}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nearby pois: %w", err)
+	}
+
+	if len(pois) > enrichmentPOILimit {
+		pois = pois[:enrichmentPOILimit]
+	}
+
+	result := make([]domain.POIWithDistance, 0, len(pois))
+	for _, poi := range pois {
+		result = append(result, domain.POIWithDistance{
+			ID:             poi.ID,
+			Name:           poi.Name,
+			Category:       poi.Category,
+			Subcategory:    poi.Subcategory,
+			Lat:            utils.RoundCoordinate(poi.Lat),
+			Lon:            utils.RoundCoordinate(poi.Lon),
+			LinearDistance: utils.DistanceMeters(poi.DistanceM, lat, lon, poi.Lat, poi.Lon),
+		})
+	}
+
+	return result, nil
+}
+
 // isAddressVisible определяет, является ли адрес видимым (точным)
 func (uc *EnrichmentUseCase) isAddressVisible(event *domain.LocationEnrichEvent) bool {
 	// Адрес считается точным, если есть улица и номер дома ИЛИ точные координаты
@@ -476,21 +658,3 @@ func (uc *EnrichmentUseCase) isAddressVisible(event *domain.LocationEnrichEvent)
 
 	return hasStreetAddress || hasCoordinates
 }
-
-// calculateDistance вычисляет расстояние между двумя точками в метрах (формула Haversine)
-func (uc *EnrichmentUseCase) calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
-	const earthRadius = 6371000.0 // meters
-
-	dLat := (lat2 - lat1) * (math.Pi / 180.0)
-	dLon := (lon2 - lon1) * (math.Pi / 180.0)
-
-	lat1Rad := lat1 * (math.Pi / 180.0)
-	lat2Rad := lat2 * (math.Pi / 180.0)
-
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
-		math.Cos(lat1Rad)*math.Cos(lat2Rad)*
-			math.Sin(dLon/2)*math.Sin(dLon/2)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-
-	return earthRadius * c
-}
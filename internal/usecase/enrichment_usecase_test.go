@@ -6,8 +6,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
 
 	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase"
+	"github.com/location-microservice/internal/usecase/dto"
 )
 
 // MockBoundaryRepository is a mock of BoundaryRepository
@@ -23,8 +27,40 @@ func (m *MockBoundaryRepository) GetByID(ctx context.Context, id int64) (*domain
 	return args.Get(0).(*domain.AdminBoundary), args.Error(1)
 }
 
-func (m *MockBoundaryRepository) SearchByText(ctx context.Context, query string, lang string, adminLevels []int, limit int) ([]*domain.AdminBoundary, error) {
-	args := m.Called(ctx, query, lang, adminLevels, limit)
+func (m *MockBoundaryRepository) GetDataCoverage(ctx context.Context, gridSize int) (*domain.DataCoverage, error) {
+	args := m.Called(ctx, gridSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataCoverage), args.Error(1)
+}
+
+func (m *MockBoundaryRepository) GetAdminLevels(ctx context.Context) ([]domain.AdminLevelInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AdminLevelInfo), args.Error(1)
+}
+
+func (m *MockBoundaryRepository) GetBoundaryBBox(ctx context.Context, id int64) (*domain.BoundaryBBox, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BoundaryBBox), args.Error(1)
+}
+
+func (m *MockBoundaryRepository) GetBoundariesIntersectingPolygon(ctx context.Context, polygonGeoJSON string, levels []int) ([]domain.BoundaryOverlap, error) {
+	args := m.Called(ctx, polygonGeoJSON, levels)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BoundaryOverlap), args.Error(1)
+}
+
+func (m *MockBoundaryRepository) SearchByText(ctx context.Context, query string, lang string, adminLevels []int, limit int, opts domain.BoundarySearchOptions) ([]*domain.AdminBoundary, error) {
+	args := m.Called(ctx, query, lang, adminLevels, limit, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -39,8 +75,24 @@ func (m *MockBoundaryRepository) ReverseGeocode(ctx context.Context, lat, lon fl
 	return args.Get(0).(*domain.Address), args.Error(1)
 }
 
-func (m *MockBoundaryRepository) GetTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	args := m.Called(ctx, z, x, y)
+func (m *MockBoundaryRepository) GetGeocodeCell(ctx context.Context, lat, lon float64) (*domain.GeocodeCell, error) {
+	args := m.Called(ctx, lat, lon)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GeocodeCell), args.Error(1)
+}
+
+func (m *MockBoundaryRepository) ReverseGeocodeWithDepth(ctx context.Context, lat, lon float64) (*domain.ReverseGeocodeMatch, error) {
+	args := m.Called(ctx, lat, lon)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReverseGeocodeMatch), args.Error(1)
+}
+
+func (m *MockBoundaryRepository) GetTile(ctx context.Context, z, x, y int, minAreaSqKm float64) ([]byte, error) {
+	args := m.Called(ctx, z, x, y, minAreaSqKm)
 	return args.Get(0).([]byte), args.Error(1)
 }
 
@@ -76,6 +128,14 @@ func (m *MockBoundaryRepository) GetByAdminLevel(ctx context.Context, level int,
 	return args.Get(0).([]*domain.AdminBoundary), args.Error(1)
 }
 
+func (m *MockBoundaryRepository) GetByAdminLevels(ctx context.Context, levels []int, limit int) ([]*domain.AdminBoundary, error) {
+	args := m.Called(ctx, levels, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AdminBoundary), args.Error(1)
+}
+
 func (m *MockBoundaryRepository) GetBoundariesInRadius(ctx context.Context, lat, lon, radiusKm float64) ([]*domain.AdminBoundary, error) {
 	args := m.Called(ctx, lat, lon, radiusKm)
 	if args.Get(0) == nil {
@@ -84,6 +144,22 @@ func (m *MockBoundaryRepository) GetBoundariesInRadius(ctx context.Context, lat,
 	return args.Get(0).([]*domain.AdminBoundary), args.Error(1)
 }
 
+func (m *MockBoundaryRepository) GetNearestBoundaries(ctx context.Context, lat, lon float64, level int, limit int) ([]domain.NearestBoundary, error) {
+	args := m.Called(ctx, lat, lon, level, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NearestBoundary), args.Error(1)
+}
+
+func (m *MockBoundaryRepository) GetNearestPlace(ctx context.Context, lat, lon float64) (*domain.Place, error) {
+	args := m.Called(ctx, lat, lon)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Place), args.Error(1)
+}
+
 func (m *MockBoundaryRepository) GetBoundariesRadiusTile(ctx context.Context, lat, lon, radiusKm float64) ([]byte, error) {
 	args := m.Called(ctx, lat, lon, radiusKm)
 	return args.Get(0).([]byte), args.Error(1)
@@ -105,6 +181,22 @@ func (m *MockBoundaryRepository) SearchByTextBatch(ctx context.Context, requests
 	return args.Get(0).([]domain.BoundarySearchResult), args.Error(1)
 }
 
+func (m *MockBoundaryRepository) LookupAddresses(ctx context.Context, lookups []domain.AddressLookup) ([]domain.AddressLookupResult, error) {
+	args := m.Called(ctx, lookups)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AddressLookupResult), args.Error(1)
+}
+
+func (m *MockBoundaryRepository) SearchWithinParent(ctx context.Context, query string, parentID int64, levels []int, limit int) ([]*domain.AdminBoundary, error) {
+	args := m.Called(ctx, query, parentID, levels, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AdminBoundary), args.Error(1)
+}
+
 func (m *MockBoundaryRepository) GetByPointBatch(ctx context.Context, points []domain.LatLon) (map[int][]*domain.AdminBoundary, error) {
 	args := m.Called(ctx, points)
 	if args.Get(0) == nil {
@@ -118,8 +210,8 @@ type MockTransportRepository struct {
 	mock.Mock
 }
 
-func (m *MockTransportRepository) GetNearestStations(ctx context.Context, lat, lon float64, types []string, maxDistance float64, limit int) ([]*domain.TransportStation, error) {
-	args := m.Called(ctx, lat, lon, types, maxDistance, limit)
+func (m *MockTransportRepository) GetNearestStations(ctx context.Context, lat, lon float64, types []string, maxDistance float64, limit int, surfaceOnly bool, excludeWithinM float64) ([]*domain.TransportStation, error) {
+	args := m.Called(ctx, lat, lon, types, maxDistance, limit, surfaceOnly, excludeWithinM)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -150,6 +242,30 @@ func (m *MockTransportRepository) GetStationsByLineID(ctx context.Context, lineI
 	return args.Get(0).([]*domain.TransportStation), args.Error(1)
 }
 
+func (m *MockTransportRepository) GetStationByID(ctx context.Context, id int64) (*domain.TransportStation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransportStation), args.Error(1)
+}
+
+func (m *MockTransportRepository) GetStationsAlongLine(ctx context.Context, lineID int64, bufferM float64) ([]*domain.TransportStation, error) {
+	args := m.Called(ctx, lineID, bufferM)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TransportStation), args.Error(1)
+}
+
+func (m *MockTransportRepository) GetStationsInBoundary(ctx context.Context, boundaryID int64, types []string) ([]*domain.TransportStation, error) {
+	args := m.Called(ctx, boundaryID, types)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TransportStation), args.Error(1)
+}
+
 func (m *MockTransportRepository) GetTransportTile(ctx context.Context, z, x, y int) ([]byte, error) {
 	args := m.Called(ctx, z, x, y)
 	return args.Get(0).([]byte), args.Error(1)
@@ -186,8 +302,8 @@ func (m *MockTransportRepository) GetTransportRadiusTile(ctx context.Context, la
 	return args.Get(0).([]byte), args.Error(1)
 }
 
-func (m *MockTransportRepository) GetTransportTileByTypes(ctx context.Context, z, x, y int, types []string) ([]byte, error) {
-	args := m.Called(ctx, z, x, y, types)
+func (m *MockTransportRepository) GetTransportTileByTypes(ctx context.Context, z, x, y int, types []string, surfaceOnly bool) ([]byte, error) {
+	args := m.Called(ctx, z, x, y, types, surfaceOnly)
 	return args.Get(0).([]byte), args.Error(1)
 }
 
@@ -199,14 +315,27 @@ func (m *MockTransportRepository) GetLinesByStationID(ctx context.Context, stati
 	return args.Get(0).([]*domain.TransportLine), args.Error(1)
 }
 
-func (m *MockTransportRepository) GetNearestStationsGrouped(ctx context.Context, lat, lon float64, priorities []domain.TransportPriority, maxDistance float64) ([]*domain.TransportStation, error) {
-	args := m.Called(ctx, lat, lon, priorities, maxDistance)
+func (m *MockTransportRepository) GetNearestStationsGrouped(ctx context.Context, lat, lon float64, priorities []domain.TransportPriority, maxDistance float64, opts domain.NearestStationsOptions) ([]*domain.TransportStation, error) {
+	args := m.Called(ctx, lat, lon, priorities, maxDistance, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.TransportStation), args.Error(1)
 }
 
+func (m *MockTransportRepository) GetLinesInBBox(ctx context.Context, bbox domain.BoundingBox, modes []string) ([]domain.TransportLineGeometry, error) {
+	args := m.Called(ctx, bbox, modes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TransportLineGeometry), args.Error(1)
+}
+
+func (m *MockTransportRepository) CountStationsNearby(ctx context.Context, lat, lon float64, types []string, maxDistance float64) (int, error) {
+	args := m.Called(ctx, lat, lon, types, maxDistance)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockTransportRepository) GetNearestStationsWithLinesBatch(ctx context.Context, req domain.BatchTransportRequest) ([]domain.TransportStationWithLines, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -231,14 +360,30 @@ func (m *MockTransportRepository) GetLinesByStationIDsBatch(ctx context.Context,
 	return args.Get(0).(map[int64][]domain.TransportLineInfo), args.Error(1)
 }
 
-func (m *MockTransportRepository) GetNearestTransportByPriority(ctx context.Context, lat, lon float64, radiusM float64, limit int) ([]domain.NearestTransportWithLines, error) {
-	args := m.Called(ctx, lat, lon, radiusM, limit)
+func (m *MockTransportRepository) GetSnappedDistances(ctx context.Context, lat, lon float64, stationIDs []int64) (map[int64]domain.SnappedDistance, error) {
+	args := m.Called(ctx, lat, lon, stationIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]domain.SnappedDistance), args.Error(1)
+}
+
+func (m *MockTransportRepository) GetNearestTransportByPriority(ctx context.Context, lat, lon float64, radiusM float64, limit int, minPerMode map[string]int) ([]domain.NearestTransportWithLines, error) {
+	args := m.Called(ctx, lat, lon, radiusM, limit, minPerMode)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.NearestTransportWithLines), args.Error(1)
 }
 
+func (m *MockTransportRepository) GetStationEntrances(ctx context.Context, stationIDs []int64, maxDistanceM float64) (map[int64][]domain.StationEntrance, error) {
+	args := m.Called(ctx, stationIDs, maxDistanceM)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64][]domain.StationEntrance), args.Error(1)
+}
+
 func (m *MockTransportRepository) GetNearestTransportByPriorityBatch(ctx context.Context, points []domain.TransportSearchPoint, radiusM float64, limitPerPoint int) ([]domain.BatchTransportResult, error) {
 	args := m.Called(ctx, points, radiusM, limitPerPoint)
 	if args.Get(0) == nil {
@@ -247,6 +392,14 @@ func (m *MockTransportRepository) GetNearestTransportByPriorityBatch(ctx context
 	return args.Get(0).([]domain.BatchTransportResult), args.Error(1)
 }
 
+func (m *MockTransportRepository) GetLinesGeometry(ctx context.Context, ids []int64, dedupeByRef bool) (map[int64]*domain.TransportLineGeometry, error) {
+	args := m.Called(ctx, ids, dedupeByRef)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]*domain.TransportLineGeometry), args.Error(1)
+}
+
 func (m *MockTransportRepository) GetStationsInBBox(ctx context.Context, swLat, swLon, neLat, neLon float64, types []string, limit, offset int) ([]domain.TransportStationWithLines, int, error) {
 	args := m.Called(ctx, swLat, swLon, neLat, neLon, types, limit, offset)
 	if args.Get(0) == nil {
@@ -255,6 +408,22 @@ func (m *MockTransportRepository) GetStationsInBBox(ctx context.Context, swLat,
 	return args.Get(0).([]domain.TransportStationWithLines), args.Int(1), args.Error(2)
 }
 
+func (m *MockTransportRepository) GetClusteredStations(ctx context.Context, lat, lon, radiusM, clusterM float64, limit int) ([]domain.ClusteredStation, error) {
+	args := m.Called(ctx, lat, lon, radiusM, clusterM, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ClusteredStation), args.Error(1)
+}
+
+func (m *MockTransportRepository) GetTransportCoverage(ctx context.Context, lat, lon float64, bands []float64) (*domain.TransportCoverage, error) {
+	args := m.Called(ctx, lat, lon, bands)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransportCoverage), args.Error(1)
+}
+
 // NOTE: The old EnrichmentUseCase tests have been removed as the usecase has been refactored.
 // The new enrichment logic is now in EnrichedLocationUseCase which is tested in enriched_location_usecase_test.go
 // The old EnrichmentUseCase is kept for backward compatibility but is no longer the primary interface.
@@ -268,7 +437,348 @@ func TestMockRepositories(t *testing.T) {
 	assert.NotNil(t, mockTransport)
 }
 
+func TestEnrichmentUseCase_EnrichLocationWithProfile(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.3851, 2.1734
+
+	profiles, err := domain.BuildEnrichmentProfiles(map[string][]string{"transit": {"transport"}})
+	assert.NoError(t, err)
+
+	t.Run("runs only features from profile", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		mockTransport := &MockTransportRepository{}
+		uc := usecase.NewEnrichmentUseCase(mockBoundary, mockTransport, nil, nil, logger,
+			[]string{"metro"}, 1, profiles, domain.EnrichmentProfileMinimal, nil, nil)
+
+		mockTransport.On("GetNearestStations", ctx, lat, lon, []string{"metro"}, 1.0, 10, false, 0.0).
+			Return([]*domain.TransportStation{{ID: 7, Name: "Catalunya", Type: "subway", Lat: 41.3870, Lon: 2.1700}}, nil)
+		mockTransport.On("GetLinesByStationID", ctx, int64(7)).Return([]*domain.TransportLine{}, nil)
+
+		result, err := uc.EnrichLocationWithProfile(ctx, &domain.LocationEnrichEvent{
+			Country:   "Spain",
+			Latitude:  &lat,
+			Longitude: &lon,
+		}, "transit")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "transit", result.Profile)
+		assert.Nil(t, result.EnrichedLocation)
+		assert.Len(t, result.NearestTransport, 1)
+		mockTransport.AssertExpectations(t)
+		mockBoundary.AssertNotCalled(t, "ReverseGeocode", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		uc := usecase.NewEnrichmentUseCase(&MockBoundaryRepository{}, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, profiles, domain.EnrichmentProfileMinimal, nil, nil)
+
+		_, err := uc.EnrichLocationWithProfile(ctx, &domain.LocationEnrichEvent{Country: "Spain"}, "premium")
+		assert.Error(t, err)
+	})
+
+	t.Run("falls back to minimal default profile", func(t *testing.T) {
+		uc := usecase.NewEnrichmentUseCase(&MockBoundaryRepository{}, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, nil, "unknown", nil, nil)
+
+		assert.Equal(t, domain.EnrichmentProfileMinimal, uc.DefaultProfile())
+		assert.Len(t, uc.Profiles(), 2)
+	})
+}
+
+func TestEnrichmentUseCase_EnrichAddress(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.3851, 2.1734
+
+	profiles, err := domain.BuildEnrichmentProfiles(map[string][]string{"transit": {"transport"}})
+	assert.NoError(t, err)
+
+	t.Run("parses address and enriches with profile", func(t *testing.T) {
+		mockTransport := &MockTransportRepository{}
+		uc := usecase.NewEnrichmentUseCase(&MockBoundaryRepository{}, mockTransport, nil, nil, logger,
+			[]string{"metro"}, 1, profiles, domain.EnrichmentProfileMinimal, nil, nil)
+
+		mockTransport.On("GetNearestStations", ctx, lat, lon, []string{"metro"}, 1.0, 10, false, 0.0).
+			Return([]*domain.TransportStation{{ID: 7, Name: "Catalunya", Type: "subway", Lat: 41.3870, Lon: 2.1700}}, nil)
+		mockTransport.On("GetLinesByStationID", ctx, int64(7)).Return([]*domain.TransportLine{}, nil)
+
+		result, err := uc.EnrichAddress(ctx, dto.EnrichAddressRequest{
+			Address:   "Carrer de Mallorca 401, 08013 Barcelona, España",
+			Latitude:  &lat,
+			Longitude: &lon,
+		}, "transit")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Carrer de Mallorca", result.Parsed.Street)
+		assert.Equal(t, "401", result.Parsed.HouseNumber)
+		assert.Equal(t, "08013", result.Parsed.PostalCode)
+		assert.Equal(t, "Barcelona", result.Parsed.City)
+		assert.Equal(t, "España", result.Parsed.Country)
+		assert.Equal(t, "transit", result.Result.Profile)
+		assert.Len(t, result.Result.NearestTransport, 1)
+		mockTransport.AssertExpectations(t)
+	})
+
+	t.Run("country required without coordinates", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewEnrichmentUseCase(mockBoundary, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, profiles, domain.EnrichmentProfileMinimal, nil, nil)
+
+		_, err := uc.EnrichAddress(ctx, dto.EnrichAddressRequest{Address: "Calle Mayor 5"}, "")
+
+		assert.Error(t, err)
+		mockBoundary.AssertNotCalled(t, "SearchByText", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEnrichmentDebugUseCase_Enrich(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.3851, 2.1734
+	city := "Barcelona"
+
+	t.Run("name resolution", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewEnrichmentUseCase(mockBoundary, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, nil, domain.EnrichmentProfileMinimal, nil, nil)
+		debugUC := usecase.NewEnrichmentDebugUseCase(uc, logger)
+
+		mockBoundary.On("SearchByText", ctx, city, "", []int{8}, 1, domain.BoundarySearchOptions{}).
+			Return([]*domain.AdminBoundary{{ID: 10, Name: city, AdminLevel: 8, ParentID: ptrInt64(2)}}, nil)
+		mockBoundary.On("GetByID", ctx, int64(10)).
+			Return(&domain.AdminBoundary{ID: 10, Name: city, AdminLevel: 8, ParentID: ptrInt64(2)}, nil)
+		mockBoundary.On("GetByID", ctx, int64(2)).
+			Return(&domain.AdminBoundary{ID: 2, Name: "España", AdminLevel: 2}, nil)
+
+		result, err := debugUC.Enrich(ctx, &domain.LocationEnrichEvent{Country: "Spain", City: &city}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "name:level8", result.ResolutionStrategy)
+		assert.Empty(t, result.Warnings)
+		assert.Equal(t, int64(10), result.Result.EnrichedLocation.City.ID)
+	})
+
+	t.Run("incomplete hierarchy falls back to coordinates", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewEnrichmentUseCase(mockBoundary, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, nil, domain.EnrichmentProfileMinimal, nil, nil)
+		debugUC := usecase.NewEnrichmentDebugUseCase(uc, logger)
+
+		mockBoundary.On("SearchByText", ctx, city, "", []int{8}, 1, domain.BoundarySearchOptions{}).
+			Return([]*domain.AdminBoundary{{ID: 10, Name: city, AdminLevel: 8}}, nil)
+		mockBoundary.On("GetByID", ctx, int64(10)).Return(nil, errors.ErrLocationNotFound)
+		mockBoundary.On("GetByPoint", ctx, lat, lon).
+			Return([]*domain.AdminBoundary{{ID: 2, Name: "España", AdminLevel: 2}}, nil)
+
+		result, err := debugUC.Enrich(ctx, &domain.LocationEnrichEvent{
+			Country: "Spain", City: &city, Latitude: &lat, Longitude: &lon,
+		}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fallback:coordinates", result.ResolutionStrategy)
+		assert.Len(t, result.Warnings, 1)
+		assert.Equal(t, int64(2), result.Result.EnrichedLocation.Country.ID)
+	})
+
+	t.Run("overlapping boundaries reported as discarded", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewEnrichmentUseCase(mockBoundary, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, nil, domain.EnrichmentProfileMinimal, nil, nil)
+		debugUC := usecase.NewEnrichmentDebugUseCase(uc, logger)
+
+		large, small := 32000.0, 8000.0
+		mockBoundary.On("GetByPoint", ctx, lat, lon).Return([]*domain.AdminBoundary{
+			{ID: 2, Name: "España", AdminLevel: 2},
+			{ID: 40, Name: "Disputed A", AdminLevel: 4, AreaSqKm: &large},
+			{ID: 41, Name: "Disputed B", AdminLevel: 4, AreaSqKm: &small},
+		}, nil)
+
+		result, err := debugUC.Enrich(ctx, &domain.LocationEnrichEvent{Country: "Spain", Latitude: &lat, Longitude: &lon}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(41), result.Result.EnrichedLocation.Region.ID)
+		if assert.Len(t, result.DiscardedBoundaries, 1) {
+			assert.Equal(t, int64(40), result.DiscardedBoundaries[0].ID)
+			assert.Equal(t, int64(41), result.DiscardedBoundaries[0].KeptID)
+		}
+	})
+
+	t.Run("unresolved location", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewEnrichmentUseCase(mockBoundary, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, nil, domain.EnrichmentProfileMinimal, nil, nil)
+		debugUC := usecase.NewEnrichmentDebugUseCase(uc, logger)
+
+		mockBoundary.On("SearchByText", ctx, "Atlantis", "", []int{2}, 1, domain.BoundarySearchOptions{}).
+			Return([]*domain.AdminBoundary{}, nil)
+
+		result, err := debugUC.Enrich(ctx, &domain.LocationEnrichEvent{Country: "Atlantis"}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "unresolved", result.ResolutionStrategy)
+		assert.NotEmpty(t, result.Warnings)
+		assert.NotEmpty(t, result.Result.Error)
+	})
+}
+
+func TestEnrichmentUseCase_ResolutionOrder(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.3851, 2.1734
+	city := "Barcelna"
+
+	t.Run("coordinates first skips names", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewEnrichmentUseCase(mockBoundary, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, nil, domain.EnrichmentProfileMinimal,
+			[]domain.ResolutionStrategy{domain.ResolutionStrategyCoordinates, domain.ResolutionStrategyName}, nil)
+		debugUC := usecase.NewEnrichmentDebugUseCase(uc, logger)
+
+		mockBoundary.On("GetByPoint", ctx, lat, lon).
+			Return([]*domain.AdminBoundary{{ID: 2, Name: "España", AdminLevel: 2}}, nil)
+
+		result, err := debugUC.Enrich(ctx, &domain.LocationEnrichEvent{
+			Country: "Spain", City: &city, Latitude: &lat, Longitude: &lon,
+		}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "coordinates", result.ResolutionStrategy)
+		assert.Empty(t, result.Warnings)
+		mockBoundary.AssertNotCalled(t, "SearchByText")
+	})
+
+	t.Run("failed strategy falls through to next", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewEnrichmentUseCase(mockBoundary, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, nil, domain.EnrichmentProfileMinimal, nil, nil)
+		debugUC := usecase.NewEnrichmentDebugUseCase(uc, logger)
+
+		mockBoundary.On("SearchByText", ctx, city, "", []int{8}, 1, domain.BoundarySearchOptions{}).
+			Return([]*domain.AdminBoundary{}, nil)
+		mockBoundary.On("SearchByText", ctx, "Spain", "", []int{2}, 1, domain.BoundarySearchOptions{}).
+			Return([]*domain.AdminBoundary{{ID: 2, Name: "España", AdminLevel: 2}}, nil)
+		mockBoundary.On("GetByID", ctx, int64(2)).
+			Return(&domain.AdminBoundary{ID: 2, Name: "España", AdminLevel: 2}, nil)
+
+		result, err := debugUC.Enrich(ctx, &domain.LocationEnrichEvent{Country: "Spain", City: &city}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fallback:country", result.ResolutionStrategy)
+		assert.Len(t, result.Warnings, 1)
+		assert.Equal(t, int64(2), result.Result.EnrichedLocation.Country.ID)
+	})
+}
+
+func TestEnrichmentUseCase_SubprovinceLevel(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.5632, 2.0089
+
+	enrich := func(t *testing.T) *domain.EnrichedLocation {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewEnrichmentUseCase(mockBoundary, &MockTransportRepository{}, nil, nil, logger,
+			nil, 1, nil, domain.EnrichmentProfileMinimal,
+			[]domain.ResolutionStrategy{domain.ResolutionStrategyCoordinates}, nil)
+		debugUC := usecase.NewEnrichmentDebugUseCase(uc, logger)
+
+		mockBoundary.On("GetByPoint", ctx, lat, lon).Return([]*domain.AdminBoundary{
+			{ID: 2, Name: "España", AdminLevel: 2},
+			{ID: 6, Name: "Barcelona", AdminLevel: 6},
+			{ID: 7, Name: "Vallès Occidental", AdminLevel: 7},
+			{ID: 8, Name: "Terrassa", AdminLevel: 8},
+			{ID: 11, Name: "Ca n'Anglada", AdminLevel: 11},
+		}, nil)
+
+		result, err := debugUC.Enrich(ctx, &domain.LocationEnrichEvent{
+			Country: "Spain", Latitude: &lat, Longitude: &lon,
+		}, "")
+		assert.NoError(t, err)
+		return result.Result.EnrichedLocation
+	}
+
+	t.Run("levels 7 and 11 are enriched like address", func(t *testing.T) {
+		loc := enrich(t)
+		if assert.NotNil(t, loc.Subprovince) {
+			assert.Equal(t, "Vallès Occidental", loc.Subprovince.Name)
+		}
+		if assert.NotNil(t, loc.Neighborhood) {
+			assert.Equal(t, int64(11), loc.Neighborhood.ID)
+		}
+		assert.Nil(t, loc.Subdistrict)
+		assert.Equal(t, "Terrassa", loc.City.Name)
+	})
+
+	t.Run("excluded level is left out", func(t *testing.T) {
+		usecase.ConfigureEnrichedLocationLevels([]int{7})
+		defer usecase.ConfigureEnrichedLocationLevels(nil)
+
+		loc := enrich(t)
+		assert.Nil(t, loc.Subprovince)
+		assert.NotNil(t, loc.Neighborhood)
+		assert.NotNil(t, loc.Province)
+	})
+}
+
 // Helper function
 func ptrInt64(v int64) *int64 {
 	return &v
 }
+
+func TestEnrichmentUseCase_AdaptiveTransportRadius(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.3851, 2.1734
+	profiles, err := domain.BuildEnrichmentProfiles(map[string][]string{"transit": {"transport"}})
+	assert.NoError(t, err)
+
+	adaptive := &domain.AdaptiveTransportRadius{ProbeRadiusM: 1000, TargetCount: 10, MinRadiusM: 400, MaxRadiusM: 3000}
+	event := &domain.LocationEnrichEvent{Country: "Spain", Latitude: &lat, Longitude: &lon}
+
+	t.Run("dense area shrinks radius", func(t *testing.T) {
+		mockTransport := &MockTransportRepository{}
+		uc := usecase.NewEnrichmentUseCase(&MockBoundaryRepository{}, mockTransport, nil, nil, logger,
+			[]string{"metro"}, 1.5, profiles, domain.EnrichmentProfileMinimal, nil, adaptive)
+
+		// 40 станций в пробном километре - радиус 500м
+		mockTransport.On("CountStationsNearby", ctx, lat, lon, []string{"metro"}, 1.0).Return(40, nil)
+		mockTransport.On("GetNearestStations", ctx, lat, lon, []string{"metro"}, 0.5, 10, false, 0.0).
+			Return([]*domain.TransportStation{}, nil)
+
+		debugUC := usecase.NewEnrichmentDebugUseCase(uc, logger)
+		result, err := debugUC.Enrich(ctx, event, "transit")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 500.0, result.TransportRadiusM)
+		mockTransport.AssertExpectations(t)
+	})
+
+	t.Run("count failure keeps fixed radius", func(t *testing.T) {
+		mockTransport := &MockTransportRepository{}
+		uc := usecase.NewEnrichmentUseCase(&MockBoundaryRepository{}, mockTransport, nil, nil, logger,
+			[]string{"metro"}, 1.5, profiles, domain.EnrichmentProfileMinimal, nil, adaptive)
+
+		mockTransport.On("CountStationsNearby", ctx, lat, lon, []string{"metro"}, 1.0).Return(0, errors.ErrDatabaseError)
+		mockTransport.On("GetNearestStations", ctx, lat, lon, []string{"metro"}, 1.5, 10, false, 0.0).
+			Return([]*domain.TransportStation{}, nil)
+
+		_, err := uc.EnrichLocationWithProfile(ctx, event, "transit")
+
+		assert.NoError(t, err)
+		mockTransport.AssertExpectations(t)
+	})
+
+	t.Run("disabled uses fixed radius without probe", func(t *testing.T) {
+		mockTransport := &MockTransportRepository{}
+		uc := usecase.NewEnrichmentUseCase(&MockBoundaryRepository{}, mockTransport, nil, nil, logger,
+			[]string{"metro"}, 1.5, profiles, domain.EnrichmentProfileMinimal, nil, nil)
+
+		mockTransport.On("GetNearestStations", ctx, lat, lon, []string{"metro"}, 1.5, 10, false, 0.0).
+			Return([]*domain.TransportStation{}, nil)
+
+		_, err := uc.EnrichLocationWithProfile(ctx, event, "transit")
+
+		assert.NoError(t, err)
+		mockTransport.AssertNotCalled(t, "CountStationsNearby", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultBoundaryGreenSpacesLimit — лимит зеленых зон в границе по умолчанию
+	defaultBoundaryGreenSpacesLimit = 10
+	// maxBoundaryGreenSpacesLimit — максимальный лимит зеленых зон в границе
+	maxBoundaryGreenSpacesLimit = 50
+	// defaultEnvironmentSummaryRadiusKm — радиус поиска ближайших объектов по умолчанию
+	defaultEnvironmentSummaryRadiusKm = 2.0
+)
+
+// EnvironmentUseCase — выборки экологических объектов (зеленые зоны, вода, пляжи) вне тайлов
+type EnvironmentUseCase struct {
+	environmentRepo repository.EnvironmentRepository
+	logger          *zap.Logger
+}
+
+// NewEnvironmentUseCase создает новый EnvironmentUseCase
+func NewEnvironmentUseCase(environmentRepo repository.EnvironmentRepository, logger *zap.Logger) *EnvironmentUseCase {
+	return &EnvironmentUseCase{
+		environmentRepo: environmentRepo,
+		logger:          logger,
+	}
+}
+
+// GetGreenSpacesInBoundary возвращает крупнейшие зеленые зоны административной границы (limit=0 - по умолчанию)
+func (uc *EnvironmentUseCase) GetGreenSpacesInBoundary(ctx context.Context, boundaryID int64, limit int) ([]*domain.GreenSpace, error) {
+	if limit == 0 {
+		limit = defaultBoundaryGreenSpacesLimit
+	}
+	if limit < 0 || limit > maxBoundaryGreenSpacesLimit {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"limit": limit,
+			"max":   maxBoundaryGreenSpacesLimit,
+		})
+	}
+
+	spaces, err := uc.environmentRepo.GetGreenSpacesInBoundary(ctx, boundaryID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if spaces == nil {
+		spaces = []*domain.GreenSpace{}
+	}
+
+	for _, space := range spaces {
+		space.CenterLat = utils.RoundCoordinate(space.CenterLat)
+		space.CenterLon = utils.RoundCoordinate(space.CenterLon)
+	}
+
+	return spaces, nil
+}
+
+// GetEnvironmentSummary возвращает ближайшие зеленую зону, пляж, водоем и источник шума в радиусе
+// maxRadiusKm (0 - по умолчанию 2 км). Четыре запроса выполняются параллельно; ошибка одного вида
+// не прерывает остальные, а отмечается в Failed.
+func (uc *EnvironmentUseCase) GetEnvironmentSummary(ctx context.Context, lat, lon float64, maxRadiusKm float64) (*dto.EnvironmentSummaryResponse, error) {
+	if !utils.ValidateCoordinates(lat, lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+	if maxRadiusKm == 0 {
+		maxRadiusKm = defaultEnvironmentSummaryRadiusKm
+	}
+	if !utils.ValidateRadius(maxRadiusKm) {
+		return nil, errors.ErrInvalidRadius
+	}
+	result := &dto.EnvironmentSummaryResponse{RadiusKm: maxRadiusKm}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	// Репозитории сортируют объекты по расстоянию, ближайший - первый
+	run := func(kind string, nearest func() (*dto.EnvironmentNearestItem, error)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item, err := nearest()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				uc.logger.Warn("Failed to get nearest environment feature", zap.String("kind", kind), zap.Error(err))
+				result.Failed = append(result.Failed, kind)
+				return
+			}
+			switch kind {
+			case "green_space":
+				result.GreenSpace = item
+			case "beach":
+				result.Beach = item
+			case "water_body":
+				result.WaterBody = item
+			case "noise_source":
+				result.NoiseSource = item
+			}
+		}()
+	}
+
+	run("green_space", func() (*dto.EnvironmentNearestItem, error) {
+		spaces, err := uc.environmentRepo.GetGreenSpacesNearby(ctx, lat, lon, maxRadiusKm, 0, domain.EnvironmentOrderOptions{})
+		if err != nil || len(spaces) == 0 {
+			return nil, err
+		}
+		return nearestItem(spaces[0].ID, spaces[0].Type, spaces[0].Name, spaces[0].DistanceM), nil
+	})
+	run("beach", func() (*dto.EnvironmentNearestItem, error) {
+		beaches, err := uc.environmentRepo.GetBeachesNearby(ctx, lat, lon, maxRadiusKm)
+		if err != nil || len(beaches) == 0 {
+			return nil, err
+		}
+		return nearestItem(beaches[0].ID, "beach", beaches[0].Name, beaches[0].DistanceM), nil
+	})
+	run("water_body", func() (*dto.EnvironmentNearestItem, error) {
+		water, err := uc.environmentRepo.GetWaterBodiesNearby(ctx, lat, lon, maxRadiusKm)
+		if err != nil || len(water) == 0 {
+			return nil, err
+		}
+		return nearestItem(water[0].ID, water[0].Type, water[0].Name, water[0].DistanceM), nil
+	})
+	run("noise_source", func() (*dto.EnvironmentNearestItem, error) {
+		sources, err := uc.environmentRepo.GetNoiseSourcesNearby(ctx, lat, lon, maxRadiusKm)
+		if err != nil || len(sources) == 0 {
+			return nil, err
+		}
+		return nearestItem(sources[0].ID, sources[0].Type, sources[0].Name, sources[0].DistanceM), nil
+	})
+
+	wg.Wait()
+	sort.Strings(result.Failed)
+
+	return result, nil
+}
+
+// nearestItem собирает компактное описание объекта с расстоянием, округленным до метра
+func nearestItem(id int64, kind string, name *string, distanceM *float64) *dto.EnvironmentNearestItem {
+	item := &dto.EnvironmentNearestItem{ID: id, Type: kind}
+	if name != nil {
+		item.Name = *name
+	}
+	if distanceM != nil {
+		item.DistanceM = math.Round(*distanceM)
+	}
+	return item
+}
@@ -0,0 +1,132 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase"
+)
+
+func TestEnvironmentUseCase_GetGreenSpacesInBoundary(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("default limit and rounded centers", func(t *testing.T) {
+		mockEnv := &mockEnvironmentRepository{}
+		crossing := 120000.0
+		mockEnv.On("GetGreenSpacesInBoundary", ctx, int64(-347950), 10).
+			Return([]*domain.GreenSpace{
+				{OSMId: 1, Type: "nature_reserve", AreaSqM: 80000000, CenterLat: 41.4312345678, CenterLon: 2.1098765432, IntersectionAreaSqM: &crossing},
+				{OSMId: 2, Type: "park", AreaSqM: 170000, CenterLat: 41.3881, CenterLon: 2.1873},
+			}, nil)
+
+		uc := usecase.NewEnvironmentUseCase(mockEnv, logger)
+		spaces, err := uc.GetGreenSpacesInBoundary(ctx, -347950, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, spaces, 2)
+		assert.Equal(t, 41.431235, spaces[0].CenterLat)
+		assert.Equal(t, 2.109877, spaces[0].CenterLon)
+		assert.Equal(t, crossing, *spaces[0].IntersectionAreaSqM)
+		assert.Nil(t, spaces[1].IntersectionAreaSqM)
+		mockEnv.AssertExpectations(t)
+	})
+
+	t.Run("no green spaces is an empty list", func(t *testing.T) {
+		mockEnv := &mockEnvironmentRepository{}
+		mockEnv.On("GetGreenSpacesInBoundary", ctx, int64(2), 5).Return(nil, nil)
+
+		uc := usecase.NewEnvironmentUseCase(mockEnv, logger)
+		spaces, err := uc.GetGreenSpacesInBoundary(ctx, 2, 5)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, spaces)
+		assert.Empty(t, spaces)
+	})
+
+	t.Run("unknown boundary", func(t *testing.T) {
+		mockEnv := &mockEnvironmentRepository{}
+		mockEnv.On("GetGreenSpacesInBoundary", ctx, int64(1), 5).Return(nil, errors.ErrLocationNotFound)
+
+		uc := usecase.NewEnvironmentUseCase(mockEnv, logger)
+		_, err := uc.GetGreenSpacesInBoundary(ctx, 1, 5)
+
+		assert.ErrorIs(t, err, errors.ErrLocationNotFound)
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		uc := usecase.NewEnvironmentUseCase(&mockEnvironmentRepository{}, logger)
+
+		_, err := uc.GetGreenSpacesInBoundary(ctx, 1, 500)
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	})
+}
+
+func TestEnvironmentUseCase_GetEnvironmentSummary(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.3851, 2.1734
+
+	t.Run("nearest of each kind with per-kind failures", func(t *testing.T) {
+		mockEnv := &mockEnvironmentRepository{}
+		parkName := "Parc de la Ciutadella"
+		near, far := 412.6, 950.0
+
+		mockEnv.On("GetGreenSpacesNearby", ctx, lat, lon, 2.0, 0.0, domain.EnvironmentOrderOptions{}).
+			Return([]*domain.GreenSpace{
+				{ID: 1, Type: "park", Name: &parkName, DistanceM: &near},
+				{ID: 2, Type: "garden", DistanceM: &far},
+			}, nil)
+		mockEnv.On("GetBeachesNearby", ctx, lat, lon, 2.0).Return([]*domain.Beach{}, nil)
+		mockEnv.On("GetWaterBodiesNearby", ctx, lat, lon, 2.0).Return(nil, errors.ErrDatabaseError)
+		mockEnv.On("GetNoiseSourcesNearby", ctx, lat, lon, 2.0).
+			Return([]*domain.NoiseSource{{ID: 7, Type: "railway", DistanceM: &far}}, nil)
+
+		uc := usecase.NewEnvironmentUseCase(mockEnv, logger)
+		summary, err := uc.GetEnvironmentSummary(ctx, lat, lon, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2.0, summary.RadiusKm)
+		if assert.NotNil(t, summary.GreenSpace) {
+			assert.Equal(t, int64(1), summary.GreenSpace.ID)
+			assert.Equal(t, parkName, summary.GreenSpace.Name)
+			assert.Equal(t, 413.0, summary.GreenSpace.DistanceM)
+		}
+		assert.Nil(t, summary.Beach)
+		assert.Nil(t, summary.WaterBody)
+		assert.Equal(t, "railway", summary.NoiseSource.Type)
+		assert.Equal(t, []string{"water_body"}, summary.Failed)
+		mockEnv.AssertExpectations(t)
+	})
+
+	t.Run("radius out of range", func(t *testing.T) {
+		uc := usecase.NewEnvironmentUseCase(&mockEnvironmentRepository{}, logger)
+
+		_, err := uc.GetEnvironmentSummary(ctx, lat, lon, 500)
+		assert.ErrorIs(t, err, errors.ErrInvalidRadius)
+	})
+
+	t.Run("distances in kilometers", func(t *testing.T) {
+		mockEnv := &mockEnvironmentRepository{}
+		near := 412.6
+
+		mockEnv.On("GetGreenSpacesNearby", ctx, lat, lon, 2.0, 0.0, domain.EnvironmentOrderOptions{}).
+			Return([]*domain.GreenSpace{{ID: 1, Type: "park", DistanceM: &near}}, nil)
+		mockEnv.On("GetBeachesNearby", ctx, lat, lon, 2.0).Return([]*domain.Beach{}, nil)
+		mockEnv.On("GetWaterBodiesNearby", ctx, lat, lon, 2.0).Return([]*domain.WaterBody{}, nil)
+		mockEnv.On("GetNoiseSourcesNearby", ctx, lat, lon, 2.0).Return([]*domain.NoiseSource{}, nil)
+
+		uc := usecase.NewEnvironmentUseCase(mockEnv, logger)
+		summary, err := uc.GetEnvironmentSummary(ctx, lat, lon, 0)
+		assert.NoError(t, err)
+		summary.ConvertDistances(utils.DistanceUnitKilometers)
+
+		assert.Equal(t, 0.413, summary.GreenSpace.DistanceM)
+	})
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+// addressLevelName - название административного уровня адреса
+type addressLevelName struct {
+	level int
+	name  string
+}
+
+// forwardGeocodeLevels возвращает заполненные административные уровни адреса от общего к детальному
+// (уровни как в DetectLocationBatch: страна 2, регион 4, провинция 6, город 8, район 9, квартал 10)
+func forwardGeocodeLevels(a dto.ForwardGeocodeAddress) []addressLevelName {
+	levels := []addressLevelName{{level: 2, name: strings.TrimSpace(a.Country)}}
+	for _, l := range []struct {
+		level int
+		name  *string
+	}{
+		{4, a.Region}, {6, a.Province}, {8, a.City}, {9, a.District}, {10, a.Neighborhood},
+	} {
+		if l.name != nil && strings.TrimSpace(*l.name) != "" {
+			levels = append(levels, addressLevelName{level: l.level, name: strings.TrimSpace(*l.name)})
+		}
+	}
+	return levels
+}
+
+// levelSearchKey - поиск уровня адреса по названию внутри уже найденной границы адреса
+type levelSearchKey struct {
+	addressLevelName
+	parentID int64
+}
+
+// ForwardGeocodeBatch находит координаты для пачки структурированных адресов - обратная операция
+// к ReverseGeocodeBatch. Работает как DetectLocationBatch для name-based локаций:
+// 1. Административные уровни ищутся по названию от общего к детальному, раунд на уровень адреса:
+// каждый следующий уровень - только внутри границы, найденной на предыдущих (ParentID), иначе
+// country=Spain, city=Córdoba нашел бы Córdoba в Аргентине. Уровень, не найденный внутри родителя,
+// пропускается и в MatchedLevels не входит. Одинаковые запросы раунда - один раз на пакет
+// 2. Область адреса - самая детальная найденная граница. Адреса одной области (например, одного
+// города) группируются в под-пакет LookupAddresses, где полигон области читается один раз;
+// улица и дом ищутся только в областях не крупнее города
+// 3. Результат - точка дома, улицы или точка внутри области с достоверностью; ошибки - в строке адреса
+func (uc *SearchUseCase) ForwardGeocodeBatch(
+	ctx context.Context,
+	req dto.ForwardGeocodeBatchRequest,
+) (*dto.ForwardGeocodeBatchResponse, error) {
+	if len(req.Addresses) == 0 {
+		return nil, errors.ErrInvalidRequest
+	}
+	if err := validateBatchSize(len(req.Addresses)); err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.ForwardGeocodeResult, len(req.Addresses))
+	levelsByAddress := make([][]addressLevelName, len(req.Addresses))
+	rounds := 0
+	for i, a := range req.Addresses {
+		results[i] = dto.ForwardGeocodeResult{Index: i}
+		levelsByAddress[i] = forwardGeocodeLevels(a)
+		rounds = max(rounds, len(levelsByAddress[i]))
+	}
+
+	// Шаг 1: поиск административных уровней по названию внутри найденных родителей
+	dbQueriesCount := 0
+	scopes := make([]*domain.AdminBoundary, len(req.Addresses))
+	for round := 0; round < rounds; round++ {
+		requestByKey := make(map[levelSearchKey]int)
+		keyByAddress := make(map[int]levelSearchKey)
+		var searchRequests []domain.BoundarySearchRequest
+		for i := range req.Addresses {
+			if round >= len(levelsByAddress[i]) {
+				continue
+			}
+			key := levelSearchKey{addressLevelName: levelsByAddress[i][round]}
+			if scopes[i] != nil {
+				key.parentID = scopes[i].OSMId
+			}
+			keyByAddress[i] = key
+			if _, ok := requestByKey[key]; ok {
+				continue
+			}
+			requestByKey[key] = len(searchRequests)
+			searchRequests = append(searchRequests, domain.BoundarySearchRequest{
+				Index:      len(searchRequests),
+				Name:       key.name,
+				AdminLevel: key.level,
+				ParentID:   key.parentID,
+			})
+		}
+
+		foundByRequest := make(map[int]*domain.AdminBoundary, len(searchRequests))
+		for _, chunk := range batchChunks(len(searchRequests)) {
+			chunkResults, err := uc.boundaryRepo.SearchByTextBatch(ctx, searchRequests[chunk[0]:chunk[1]])
+			dbQueriesCount++
+			if err != nil {
+				uc.logger.Error("SearchByTextBatch failed",
+					zap.Int("round", round), zap.Int("chunk_start", chunk[0]), zap.Error(err))
+				return nil, err
+			}
+			for _, sr := range chunkResults {
+				if sr.Found && sr.Boundary != nil {
+					foundByRequest[sr.Index] = sr.Boundary
+				}
+			}
+		}
+
+		for i, key := range keyByAddress {
+			if b, ok := foundByRequest[requestByKey[key]]; ok {
+				results[i].MatchedLevels++
+				scopes[i] = b // уровни идут от общего к детальному
+			}
+		}
+	}
+
+	// Шаг 2: поиск точек, сгруппированный по областям
+	var lookups []domain.AddressLookup
+	for i, a := range req.Addresses {
+		if scopes[i] == nil {
+			results[i].Error = "no boundaries found by name"
+			continue
+		}
+
+		lookup := domain.AddressLookup{Index: i, ScopeID: scopes[i].OSMId}
+		if scopes[i].AdminLevel >= domain.AddressLevelCity && a.Street != nil {
+			lookup.Street = strings.TrimSpace(*a.Street)
+			if a.HouseNumber != nil {
+				lookup.HouseNumber = strings.TrimSpace(*a.HouseNumber)
+			}
+			if a.PostalCode != nil {
+				lookup.PostalCode = strings.TrimSpace(*a.PostalCode)
+			}
+		}
+		lookups = append(lookups, lookup)
+	}
+
+	sort.SliceStable(lookups, func(a, b int) bool {
+		return lookups[a].ScopeID < lookups[b].ScopeID
+	})
+	scopeCount := 0
+	for i, l := range lookups {
+		if i == 0 || l.ScopeID != lookups[i-1].ScopeID {
+			scopeCount++
+		}
+	}
+
+	points := make(map[int]domain.AddressLookupResult, len(lookups))
+	for _, chunk := range batchChunks(len(lookups)) {
+		chunkResults, err := uc.boundaryRepo.LookupAddresses(ctx, lookups[chunk[0]:chunk[1]])
+		dbQueriesCount++
+		if err != nil {
+			uc.logger.Warn("LookupAddresses failed", zap.Int("chunk_start", chunk[0]), zap.Error(err))
+			for _, l := range lookups[chunk[0]:chunk[1]] {
+				results[l.Index].Error = "address lookup failed"
+			}
+			continue
+		}
+		for _, p := range chunkResults {
+			points[p.Index] = p
+		}
+	}
+
+	// Шаг 3: точки и достоверность
+	successCount, errorCount := 0, 0
+	for i := range results {
+		r := &results[i]
+		if r.Error == "" {
+			if p, ok := points[i]; ok {
+				scope := scopes[i]
+				lat, lon := utils.RoundCoordinate(p.Lat), utils.RoundCoordinate(p.Lon)
+				r.Lat, r.Lon = &lat, &lon
+				r.Precision = p.Precision
+				r.Confidence = domain.ForwardGeocodeConfidence(p.Precision, scope.AdminLevel, r.MatchedLevels, len(levelsByAddress[i]))
+				r.Boundary = &dto.BoundaryInfoDTO{
+					ID:             scope.ID,
+					Name:           scope.Name,
+					TranslateNames: scope.Translations(),
+				}
+				r.AdminLevel = scope.AdminLevel
+			} else {
+				r.Error = "boundary geometry not found"
+			}
+		}
+
+		if r.Error == "" {
+			successCount++
+		} else {
+			errorCount++
+		}
+	}
+
+	uc.logger.Info("ForwardGeocodeBatch completed",
+		zap.Int("total", len(req.Addresses)),
+		zap.Int("success", successCount),
+		zap.Int("errors", errorCount),
+		zap.Int("scopes", scopeCount),
+		zap.Int("db_queries", dbQueriesCount))
+
+	return &dto.ForwardGeocodeBatchResponse{
+		Results: results,
+		Meta: dto.ForwardGeocodeBatchMeta{
+			TotalAddresses: len(req.Addresses),
+			SuccessCount:   successCount,
+			ErrorCount:     errorCount,
+			ScopeCount:     scopeCount,
+			DBQueriesCount: dbQueriesCount,
+		},
+	}, nil
+}
@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"container/list"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/location-microservice/internal/domain"
+)
+
+// geocodeCellCacheSize - число ячеек в кеше обратного геокодирования (GEOCODE_CELL_CACHE_SIZE),
+// <= 0 - выключен. Конфиг подставляет 1000 вместо 0, выключает кеш отрицательное значение.
+var geocodeCellCacheSize = 1000
+
+// ConfigureGeocodeCellCache задает размер кеша обратного геокодирования по ячейкам.
+// Вызывается один раз при старте, до создания SearchUseCase.
+func ConfigureGeocodeCellCache(size int) {
+	geocodeCellCacheSize = size
+}
+
+const (
+	// geocodeCellFillMisses - после стольких промахов с одним адресом ищется ячейка: одиночные точки
+	// не удваивают число запросов к БД, ячейка нужна только областям с повторными запросами
+	geocodeCellFillMisses = 2
+	// geocodeCellGridDeg - шаг сетки индекса ячеек: Get проверяет только ячейки клетки точки
+	geocodeCellGridDeg = 0.25
+	// geocodeCellMaxGridKeys - ячейка, bbox которой занимает больше клеток сетки, не кешируется
+	geocodeCellMaxGridKeys = 4096
+)
+
+// geocodeCellEntry - адрес, общий для всех точек ячейки
+type geocodeCellEntry struct {
+	cell     *domain.GeocodeCell
+	address  domain.Address
+	gridKeys []geocodeGridKey
+	expires  time.Time
+}
+
+// geocodeCellMiss - промахи кеша с одним адресом; noCell - ячейки для адреса нет (отрицательный кеш)
+type geocodeCellMiss struct {
+	count   int
+	noCell  bool
+	expires time.Time
+}
+
+// geocodeGridKey - клетка сетки geocodeCellGridDeg
+type geocodeGridKey struct {
+	lat, lon int
+}
+
+func geocodeGridCoord(v float64) int {
+	return int(math.Floor(v / geocodeCellGridDeg))
+}
+
+// geocodeCellCache - in-process LRU кеш адресов по ячейкам (самым детальным содержащим полигонам).
+// В отличие от ключа по округленным координатам, попадание дает любая точка внутри ячейки:
+// соседние объявления в одном районе или поселке разносятся на километры, но адрес у них общий.
+// Записи живут ttl: после переимпорта границ адрес обновится без перезапуска (или через Flush).
+type geocodeCellCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries *list.List                         // *geocodeCellEntry, в начале - последние использованные
+	byID    map[int64]*list.Element            // boundary_id ячейки -> элемент списка
+	byGrid  map[geocodeGridKey][]*list.Element // клетка сетки -> ячейки, bbox которых ее задевает
+	misses  map[string]geocodeCellMiss         // ключ адреса промаха -> промахи
+}
+
+func newGeocodeCellCache(size int, ttl time.Duration) *geocodeCellCache {
+	if size <= 0 || ttl <= 0 {
+		return nil
+	}
+	return &geocodeCellCache{
+		size:    size,
+		ttl:     ttl,
+		entries: list.New(),
+		byID:    make(map[int64]*list.Element, size),
+		byGrid:  make(map[geocodeGridKey][]*list.Element),
+		misses:  make(map[string]geocodeCellMiss),
+	}
+}
+
+// Get возвращает адрес ячейки, содержащей точку. Точка в полигоне проверяется только для ячеек
+// клетки сетки, в которую попала точка; просроченные ячейки удаляются.
+func (c *geocodeCellCache) Get(lat, lon float64) (*domain.Address, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for _, el := range slices.Clone(c.byGrid[geocodeGridKey{lat: geocodeGridCoord(lat), lon: geocodeGridCoord(lon)}]) {
+		entry := el.Value.(*geocodeCellEntry)
+		if now.After(entry.expires) {
+			c.remove(el)
+			continue
+		}
+		if entry.cell.Contains(lat, lon) {
+			c.entries.MoveToFront(el)
+			addr := entry.address
+			return &addr, true
+		}
+	}
+	return nil, false
+}
+
+// RecordMiss учитывает промах с адресом addr и сообщает, пора ли искать ячейку: после
+// geocodeCellFillMisses промахов с тем же адресом, если ячейки для него еще не искали безуспешно
+func (c *geocodeCellCache) RecordMiss(addr *domain.Address) bool {
+	if c == nil || addr == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	key := geocodeAddressKey(addr)
+	miss, ok := c.misses[key]
+	if !ok || now.After(miss.expires) {
+		c.trimMisses(now)
+		miss = geocodeCellMiss{expires: now.Add(c.ttl)}
+	}
+	miss.count++
+	c.misses[key] = miss
+	return !miss.noCell && miss.count >= geocodeCellFillMisses
+}
+
+// PutNoCell запоминает, что для адреса ячейки нет: до истечения ttl ее не ищут снова
+func (c *geocodeCellCache) PutNoCell(addr *domain.Address) {
+	if c == nil || addr == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := geocodeAddressKey(addr)
+	miss := c.misses[key]
+	miss.noCell = true
+	miss.expires = time.Now().Add(c.ttl)
+	c.misses[key] = miss
+}
+
+// Put сохраняет адрес ячейки, вытесняя давно не использованные ячейки сверх размера
+func (c *geocodeCellCache) Put(cell *domain.GeocodeCell, addr *domain.Address) {
+	if c == nil || cell == nil || addr == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := geocodeAddressKey(addr)
+	gridKeys := geocodeCellGridKeys(cell.BBox)
+	if gridKeys == nil {
+		// Слишком большая ячейка - как отсутствие ячейки: не искать ее снова до истечения ttl
+		c.misses[key] = geocodeCellMiss{noCell: true, expires: time.Now().Add(c.ttl)}
+		return
+	}
+	delete(c.misses, key)
+	if el, ok := c.byID[cell.BoundaryID]; ok {
+		c.remove(el)
+	}
+
+	el := c.entries.PushFront(&geocodeCellEntry{
+		cell:     cell,
+		address:  *addr,
+		gridKeys: gridKeys,
+		expires:  time.Now().Add(c.ttl),
+	})
+	c.byID[cell.BoundaryID] = el
+	for _, key := range gridKeys {
+		c.byGrid[key] = append(c.byGrid[key], el)
+	}
+	for c.entries.Len() > c.size {
+		c.remove(c.entries.Back())
+	}
+}
+
+// Flush удаляет все ячейки и промахи и возвращает число удаленных ячеек
+func (c *geocodeCellCache) Flush() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.entries.Len()
+	c.entries.Init()
+	c.byID = make(map[int64]*list.Element, c.size)
+	c.byGrid = make(map[geocodeGridKey][]*list.Element)
+	c.misses = make(map[string]geocodeCellMiss)
+	return n
+}
+
+// remove удаляет ячейку из списка и индексов; вызывается под mu
+func (c *geocodeCellCache) remove(el *list.Element) {
+	entry := c.entries.Remove(el).(*geocodeCellEntry)
+	delete(c.byID, entry.cell.BoundaryID)
+	for _, key := range entry.gridKeys {
+		rest := slices.DeleteFunc(c.byGrid[key], func(e *list.Element) bool { return e == el })
+		if len(rest) == 0 {
+			delete(c.byGrid, key)
+			continue
+		}
+		c.byGrid[key] = rest
+	}
+}
+
+// trimMisses держит число адресов промахов в пределах размера кеша: сначала удаляются
+// просроченные, если не хватило - все. Вызывается под mu.
+func (c *geocodeCellCache) trimMisses(now time.Time) {
+	if len(c.misses) < c.size {
+		return
+	}
+	for key, miss := range c.misses {
+		if now.After(miss.expires) {
+			delete(c.misses, key)
+		}
+	}
+	if len(c.misses) >= c.size {
+		clear(c.misses)
+	}
+}
+
+// geocodeCellGridKeys возвращает клетки сетки, которые задевает bbox ячейки;
+// nil - ячейка слишком большая для индекса
+func geocodeCellGridKeys(bbox domain.BoundingBox) []geocodeGridKey {
+	minLat, maxLat := geocodeGridCoord(bbox.MinLat), geocodeGridCoord(bbox.MaxLat)
+	minLon, maxLon := geocodeGridCoord(bbox.MinLon), geocodeGridCoord(bbox.MaxLon)
+	if (maxLat-minLat+1)*(maxLon-minLon+1) > geocodeCellMaxGridKeys {
+		return nil
+	}
+
+	keys := make([]geocodeGridKey, 0, (maxLat-minLat+1)*(maxLon-minLon+1))
+	for lat := minLat; lat <= maxLat; lat++ {
+		for lon := minLon; lon <= maxLon; lon++ {
+			keys = append(keys, geocodeGridKey{lat: lat, lon: lon})
+		}
+	}
+	return keys
+}
+
+// geocodeAddressKey - ключ адреса для учета промахов: у всех точек одной ячейки адрес одинаков
+func geocodeAddressKey(addr *domain.Address) string {
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	return strings.Join([]string{
+		addr.Country, addr.Region, addr.Province, deref(addr.Subprovince), addr.City,
+		deref(addr.District), deref(addr.Subdistrict), deref(addr.Neighborhood),
+	}, "\x00")
+}
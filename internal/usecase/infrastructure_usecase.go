@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/utils"
 	"go.uber.org/zap"
 )
 
@@ -50,7 +51,7 @@ func (uc *InfrastructureUseCase) GetInfrastructure(
 	}
 
 	transportStations, err := uc.transportRepo.GetNearestStationsGrouped(
-		ctx, lat, lon, transportPriorities, transportRadius,
+		ctx, lat, lon, transportPriorities, transportRadius, domain.NearestStationsOptions{},
 	)
 	if err != nil {
 		uc.logger.Error("Failed to get transport", zap.Error(err))
@@ -124,8 +125,8 @@ func (uc *InfrastructureUseCase) buildResultWithoutWalking(
 	for _, station := range stations {
 		// Используем дистанцию из БД если есть, иначе вычисляем
 		var linearDist float64
-		if station.Distance != nil {
-			linearDist = *station.Distance
+		if station.DistanceM != nil {
+			linearDist = *station.DistanceM
 		} else {
 			linearDist = uc.calculateDistance(lat, lon, station.Lat, station.Lon)
 		}
@@ -148,8 +149,8 @@ func (uc *InfrastructureUseCase) buildResultWithoutWalking(
 			StationID:      station.ID,
 			Name:           station.Name,
 			Type:           station.Type,
-			Lat:            station.Lat,
-			Lon:            station.Lon,
+			Lat:            utils.RoundCoordinate(station.Lat),
+			Lon:            utils.RoundCoordinate(station.Lon),
 			Lines:          lineInfos,
 			LinearDistance: linearDist,
 		})
@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+	"go.uber.org/zap"
+)
+
+// Параметры составляющих оценки локации
+const (
+	scoreTransportRadiusM   = 1000.0 // радиус учета станций
+	scoreTransportLimit     = 10     // максимум станций в оценке
+	scoreTransportSaturated = 2.5    // сумма вкладов станций, дающая 100 баллов
+
+	scoreGreenRadiusKm     = 1.0     // радиус учета зеленых зон
+	scoreGreenFullAreaSqM  = 20000.0 // площадь зоны, дающая полный вклад
+	scoreGreenSaturated    = 1.5     // сумма вкладов зон, дающая 100 баллов
+	scoreGreenFeatureLimit = 10      // максимум зон в объяснении
+
+	scoreNoiseRadiusKm     = 0.5 // радиус учета источников шума
+	scoreNoiseSaturated    = 1.5 // сумма штрафов, обнуляющая оценку
+	scoreNoiseFeatureLimit = 10  // максимум источников в объяснении
+
+	scorePOIRadiusM   = 500  // радиус подсчета POI
+	scorePOISaturated = 80.0 // количество POI, дающее 100 баллов
+)
+
+// Радиус поиска объектов с тегом ele для высотного контекста
+const (
+	DefaultElevationRadiusKm = 1.0
+	maxElevationRadiusKm     = 10.0
+)
+
+// scoreTransportModeWeights - вклад станции по виду транспорта (на нулевом расстоянии)
+var scoreTransportModeWeights = map[string]float64{
+	"metro": 1.0,
+	"train": 0.8,
+	"tram":  0.6,
+	"ferry": 0.5,
+	"bus":   0.4,
+}
+
+// scoreNoiseIntensityWeights - штраф источника шума по интенсивности (на нулевом расстоянии)
+var scoreNoiseIntensityWeights = map[string]float64{
+	"high":   1.0,
+	"medium": 0.6,
+	"low":    0.3,
+}
+
+// LocationScoreUseCase - композитная оценка локации из транспорта, зелени, шума и плотности POI
+type LocationScoreUseCase struct {
+	transportRepo   repository.TransportRepository
+	environmentRepo repository.EnvironmentRepository
+	poiRepo         repository.POIRepository
+	weights         domain.LocationScoreWeights
+	logger          *zap.Logger
+}
+
+// NewLocationScoreUseCase создает новый LocationScoreUseCase (nil weights - веса по умолчанию)
+func NewLocationScoreUseCase(
+	transportRepo repository.TransportRepository,
+	environmentRepo repository.EnvironmentRepository,
+	poiRepo repository.POIRepository,
+	weights domain.LocationScoreWeights,
+	logger *zap.Logger,
+) *LocationScoreUseCase {
+	if weights == nil {
+		weights = domain.DefaultLocationScoreWeights()
+	}
+
+	return &LocationScoreUseCase{
+		transportRepo:   transportRepo,
+		environmentRepo: environmentRepo,
+		poiRepo:         poiRepo,
+		weights:         weights,
+		logger:          logger,
+	}
+}
+
+// GetLocationScore считает составляющие оценки параллельно и сводит их во взвешенный композит.
+// Составляющая, данные которой не удалось получить, помечается недоступной и не учитывается в композите.
+func (uc *LocationScoreUseCase) GetLocationScore(ctx context.Context, lat, lon float64) (*domain.LocationScore, error) {
+	if !utils.ValidateCoordinates(lat, lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+
+	scorers := map[domain.LocationScoreComponent]func(context.Context, float64, float64) (float64, []domain.LocationScoreFeature, error){
+		domain.LocationScoreTransport:  uc.scoreTransport,
+		domain.LocationScoreGreenSpace: uc.scoreGreenSpace,
+		domain.LocationScoreNoise:      uc.scoreNoise,
+		domain.LocationScorePOIDensity: uc.scorePOIDensity,
+	}
+
+	components := make([]domain.LocationSubScore, len(domain.LocationScoreComponents))
+	var wg sync.WaitGroup
+	for i, component := range domain.LocationScoreComponents {
+		wg.Add(1)
+		go func(i int, component domain.LocationScoreComponent) {
+			defer wg.Done()
+
+			sub := domain.LocationSubScore{
+				Component: component,
+				Weight:    uc.weights[component],
+				Features:  []domain.LocationScoreFeature{},
+			}
+			score, features, err := scorers[component](ctx, lat, lon)
+			if err != nil {
+				uc.logger.Warn("Failed to compute location sub-score",
+					zap.String("component", string(component)),
+					zap.Error(err))
+				sub.Error = err.Error()
+			} else {
+				sub.Available = true
+				sub.Score = roundScore(score)
+				if features != nil {
+					sub.Features = features
+				}
+			}
+			components[i] = sub
+		}(i, component)
+	}
+	wg.Wait()
+
+	var weighted, totalWeight float64
+	for _, sub := range components {
+		if !sub.Available {
+			continue
+		}
+		weighted += sub.Score * sub.Weight
+		totalWeight += sub.Weight
+	}
+	if totalWeight == 0 {
+		return nil, errors.ErrDatabaseError
+	}
+
+	return &domain.LocationScore{
+		Lat:        lat,
+		Lon:        lon,
+		Score:      roundScore(weighted / totalWeight),
+		Components: components,
+	}, nil
+}
+
+// GetElevationContext возвращает приблизительный высотный контекст точки по тегам ele объектов OSM.
+// radiusKm=0 - радиус по умолчанию. Отсутствие данных не ошибка: результат с HasData=false.
+func (uc *LocationScoreUseCase) GetElevationContext(ctx context.Context, lat, lon, radiusKm float64) (*domain.ElevationContext, error) {
+	if !utils.ValidateCoordinates(lat, lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+	if radiusKm == 0 {
+		radiusKm = DefaultElevationRadiusKm
+	}
+	if radiusKm < 0 || radiusKm > maxElevationRadiusKm {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"radius_km": radiusKm,
+			"max":       maxElevationRadiusKm,
+		})
+	}
+
+	result, err := uc.environmentRepo.GetElevationContext(ctx, lat, lon, radiusKm)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Nearest != nil {
+		result.Nearest.Lat = utils.RoundCoordinate(result.Nearest.Lat)
+		result.Nearest.Lon = utils.RoundCoordinate(result.Nearest.Lon)
+	}
+
+	return result, nil
+}
+
+// scoreTransport - станции в радиусе с весом по виду транспорта, линейно убывающим с расстоянием
+func (uc *LocationScoreUseCase) scoreTransport(ctx context.Context, lat, lon float64) (float64, []domain.LocationScoreFeature, error) {
+	stations, err := uc.transportRepo.GetNearestTransportByPriority(ctx, lat, lon, scoreTransportRadiusM, scoreTransportLimit, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var total float64
+	features := make([]domain.LocationScoreFeature, 0, len(stations))
+	for _, station := range stations {
+		c := scoreTransportModeWeights[station.Type] * distanceDecay(station.DistanceM, scoreTransportRadiusM)
+		if c <= 0 {
+			continue
+		}
+		total += c
+		distance := station.DistanceM
+		features = append(features, domain.LocationScoreFeature{
+			Type:         station.Type,
+			Name:         station.Name,
+			DistanceM:    &distance,
+			Contribution: roundScore(c / scoreTransportSaturated * 100),
+		})
+	}
+
+	return math.Min(100, total/scoreTransportSaturated*100), features, nil
+}
+
+// scoreGreenSpace - зеленые зоны в радиусе: вклад растет с площадью и убывает с расстоянием
+func (uc *LocationScoreUseCase) scoreGreenSpace(ctx context.Context, lat, lon float64) (float64, []domain.LocationScoreFeature, error) {
+	spaces, err := uc.environmentRepo.GetGreenSpacesNearby(ctx, lat, lon, scoreGreenRadiusKm, 0, domain.EnvironmentOrderOptions{})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var total float64
+	features := make([]domain.LocationScoreFeature, 0, len(spaces))
+	for _, space := range spaces {
+		distance := utils.DistanceMeters(space.DistanceM, lat, lon, space.CenterLat, space.CenterLon)
+		c := math.Min(1, space.AreaSqM/scoreGreenFullAreaSqM) * distanceDecay(distance, scoreGreenRadiusKm*1000)
+		if c <= 0 {
+			continue
+		}
+		total += c
+
+		name := ""
+		if space.Name != nil {
+			name = *space.Name
+		}
+		features = append(features, domain.LocationScoreFeature{
+			Type:         space.Type,
+			Name:         name,
+			DistanceM:    &distance,
+			Contribution: roundScore(c / scoreGreenSaturated * 100),
+		})
+	}
+
+	return math.Min(100, total/scoreGreenSaturated*100), topScoreFeatures(features, scoreGreenFeatureLimit), nil
+}
+
+// scoreNoise - 100 баллов без источников шума, каждый источник в радиусе дает штраф по интенсивности
+func (uc *LocationScoreUseCase) scoreNoise(ctx context.Context, lat, lon float64) (float64, []domain.LocationScoreFeature, error) {
+	sources, err := uc.environmentRepo.GetNoiseSourcesNearby(ctx, lat, lon, scoreNoiseRadiusKm)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var penalty float64
+	features := make([]domain.LocationScoreFeature, 0, len(sources))
+	for _, source := range sources {
+		intensity := "low"
+		if source.Intensity != nil {
+			intensity = *source.Intensity
+		}
+		distance := utils.DistanceMeters(source.DistanceM, lat, lon, source.Lat, source.Lon)
+		p := scoreNoiseIntensityWeights[intensity] * distanceDecay(distance, scoreNoiseRadiusKm*1000)
+		if p <= 0 {
+			continue
+		}
+		penalty += p
+
+		name := ""
+		if source.Name != nil {
+			name = *source.Name
+		}
+		features = append(features, domain.LocationScoreFeature{
+			Type:         source.Type,
+			Name:         name,
+			DistanceM:    &distance,
+			Contribution: -roundScore(p / scoreNoiseSaturated * 100),
+		})
+	}
+
+	return math.Max(0, 100-penalty/scoreNoiseSaturated*100), topScoreFeatures(features, scoreNoiseFeatureLimit), nil
+}
+
+// scorePOIDensity - количество POI в радиусе, объяснение - разбивка по категориям
+func (uc *LocationScoreUseCase) scorePOIDensity(ctx context.Context, lat, lon float64) (float64, []domain.LocationScoreFeature, error) {
+	counts, err := uc.poiRepo.CountByCategories(ctx, lat, lon, scorePOIRadiusM)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	total := 0
+	features := make([]domain.LocationScoreFeature, 0, len(counts))
+	for category, count := range counts {
+		total += count
+		features = append(features, domain.LocationScoreFeature{
+			Type:         category,
+			Count:        count,
+			Contribution: roundScore(float64(count) / scorePOISaturated * 100),
+		})
+	}
+
+	return math.Min(100, float64(total)/scorePOISaturated*100), topScoreFeatures(features, len(features)), nil
+}
+
+// distanceDecay - линейное убывание от 1 в точке до 0 на границе радиуса
+func distanceDecay(distanceM, radiusM float64) float64 {
+	if distanceM >= radiusM {
+		return 0
+	}
+	return 1 - math.Max(0, distanceM)/radiusM
+}
+
+// topScoreFeatures сортирует объекты по модулю вклада и оставляет не больше limit
+func topScoreFeatures(features []domain.LocationScoreFeature, limit int) []domain.LocationScoreFeature {
+	sort.SliceStable(features, func(i, j int) bool {
+		ci, cj := math.Abs(features[i].Contribution), math.Abs(features[j].Contribution)
+		if ci != cj {
+			return ci > cj
+		}
+		return features[i].Type < features[j].Type
+	})
+	if len(features) > limit {
+		features = features[:limit]
+	}
+	return features
+}
+
+// roundScore округляет оценку до десятых
+func roundScore(v float64) float64 {
+	return math.Round(v*10) / 10
+}
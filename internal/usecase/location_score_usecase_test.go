@@ -0,0 +1,275 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase"
+)
+
+// ---- Mock Environment Repository (for LocationScoreUseCase tests) ----
+
+type mockEnvironmentRepository struct {
+	mock.Mock
+}
+
+func (m *mockEnvironmentRepository) GetGreenSpacesNearby(ctx context.Context, lat, lon float64, radiusKm float64, minAreaSqM float64, opts domain.EnvironmentOrderOptions) ([]*domain.GreenSpace, error) {
+	args := m.Called(ctx, lat, lon, radiusKm, minAreaSqM, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GreenSpace), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetGreenSpacesInBoundary(ctx context.Context, boundaryID int64, limit int) ([]*domain.GreenSpace, error) {
+	args := m.Called(ctx, boundaryID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GreenSpace), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetWaterBodiesNearby(ctx context.Context, lat, lon float64, radiusKm float64) ([]*domain.WaterBody, error) {
+	args := m.Called(ctx, lat, lon, radiusKm)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WaterBody), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetBeachesNearby(ctx context.Context, lat, lon float64, radiusKm float64) ([]*domain.Beach, error) {
+	args := m.Called(ctx, lat, lon, radiusKm)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Beach), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetNoiseSourcesNearby(ctx context.Context, lat, lon float64, radiusKm float64) ([]*domain.NoiseSource, error) {
+	args := m.Called(ctx, lat, lon, radiusKm)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.NoiseSource), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetTouristZonesNearby(ctx context.Context, lat, lon float64, radiusKm float64) ([]*domain.TouristZone, error) {
+	args := m.Called(ctx, lat, lon, radiusKm)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TouristZone), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetElevationContext(ctx context.Context, lat, lon float64, radiusKm float64) (*domain.ElevationContext, error) {
+	args := m.Called(ctx, lat, lon, radiusKm)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ElevationContext), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetGreenSpaceByID(ctx context.Context, id int64) (*domain.GreenSpace, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GreenSpace), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetBeachByID(ctx context.Context, id int64) (*domain.Beach, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Beach), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetTouristZoneByID(ctx context.Context, id int64) (*domain.TouristZone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TouristZone), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetGreenSpacesTile(ctx context.Context, z, x, y int) ([]byte, error) {
+	args := m.Called(ctx, z, x, y)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetWaterTile(ctx context.Context, z, x, y int) ([]byte, error) {
+	args := m.Called(ctx, z, x, y)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetBeachesTile(ctx context.Context, z, x, y int) ([]byte, error) {
+	args := m.Called(ctx, z, x, y)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetNoiseSourcesTile(ctx context.Context, z, x, y int) ([]byte, error) {
+	args := m.Called(ctx, z, x, y)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetTouristZonesTile(ctx context.Context, z, x, y int) ([]byte, error) {
+	args := m.Called(ctx, z, x, y)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *mockEnvironmentRepository) GetEnvironmentRadiusTile(ctx context.Context, lat, lon, radiusKm float64) ([]byte, error) {
+	args := m.Called(ctx, lat, lon, radiusKm)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// ---- Tests ----
+
+func TestLocationScoreUseCase_GetLocationScore(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.3851, 2.1734
+
+	parkName := "Parc de la Ciutadella"
+	parkDistance := 250.0
+	highIntensity := "high"
+	roadDistance := 250.0
+
+	setup := func() (*MockTransportRepository, *mockEnvironmentRepository, *mockPOIRepository) {
+		mockTransport := &MockTransportRepository{}
+		mockEnv := &mockEnvironmentRepository{}
+		mockPOI := &mockPOIRepository{}
+
+		mockTransport.On("GetNearestTransportByPriority", ctx, lat, lon, 1000.0, 10, map[string]int(nil)).
+			Return([]domain.NearestTransportWithLines{
+				{StationID: 1, Name: "Catalunya", Type: "metro", DistanceM: 0},
+				{StationID: 2, Name: "Far stop", Type: "bus", DistanceM: 1500},
+			}, nil)
+		mockEnv.On("GetGreenSpacesNearby", ctx, lat, lon, 1.0, 0.0, domain.EnvironmentOrderOptions{}).
+			Return([]*domain.GreenSpace{
+				{OSMId: 10, Type: "park", Name: &parkName, AreaSqM: 100000, DistanceM: &parkDistance},
+			}, nil)
+		mockEnv.On("GetNoiseSourcesNearby", ctx, lat, lon, 0.5).
+			Return([]*domain.NoiseSource{
+				{OSMId: 20, Type: "highway", Intensity: &highIntensity, DistanceM: &roadDistance},
+			}, nil)
+
+		return mockTransport, mockEnv, mockPOI
+	}
+
+	t.Run("weighted composite with explained sub-scores", func(t *testing.T) {
+		mockTransport, mockEnv, mockPOI := setup()
+		mockPOI.On("CountByCategories", ctx, lat, lon, 500).
+			Return(map[string]int{"food_drink": 30, "shopping": 10}, nil)
+
+		weights := domain.LocationScoreWeights{
+			domain.LocationScoreTransport:  1,
+			domain.LocationScoreGreenSpace: 1,
+			domain.LocationScoreNoise:      1,
+			domain.LocationScorePOIDensity: 1,
+		}
+		uc := usecase.NewLocationScoreUseCase(mockTransport, mockEnv, mockPOI, weights, logger)
+
+		result, err := uc.GetLocationScore(ctx, lat, lon)
+		assert.NoError(t, err)
+		assert.Len(t, result.Components, 4)
+
+		transport := result.Components[0]
+		assert.Equal(t, domain.LocationScoreTransport, transport.Component)
+		assert.Equal(t, 40.0, transport.Score, "metro at 0m gives 1.0 of 2.5 saturation")
+		assert.Len(t, transport.Features, 1, "station outside radius does not contribute")
+
+		green := result.Components[1]
+		assert.Equal(t, 50.0, green.Score, "large park at 250m gives 0.75 of 1.5 saturation")
+		assert.Equal(t, parkName, green.Features[0].Name)
+
+		noise := result.Components[2]
+		assert.Equal(t, 66.7, noise.Score, "high intensity road at 250m gives 0.5 of 1.5 penalty")
+		assert.Less(t, noise.Features[0].Contribution, 0.0)
+
+		poi := result.Components[3]
+		assert.Equal(t, 50.0, poi.Score)
+		assert.Equal(t, "food_drink", poi.Features[0].Type, "categories sorted by contribution")
+
+		assert.Equal(t, 51.7, result.Score)
+	})
+
+	t.Run("failed component is excluded from composite", func(t *testing.T) {
+		mockTransport, mockEnv, mockPOI := setup()
+		mockPOI.On("CountByCategories", ctx, lat, lon, 500).Return(nil, errors.ErrDatabaseError)
+
+		weights := domain.LocationScoreWeights{
+			domain.LocationScoreTransport:  1,
+			domain.LocationScoreGreenSpace: 1,
+			domain.LocationScoreNoise:      0,
+			domain.LocationScorePOIDensity: 1,
+		}
+		uc := usecase.NewLocationScoreUseCase(mockTransport, mockEnv, mockPOI, weights, logger)
+
+		result, err := uc.GetLocationScore(ctx, lat, lon)
+		assert.NoError(t, err)
+		assert.False(t, result.Components[3].Available)
+		assert.NotEmpty(t, result.Components[3].Error)
+		assert.Equal(t, 45.0, result.Score, "average of transport and green only")
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		uc := usecase.NewLocationScoreUseCase(&MockTransportRepository{}, &mockEnvironmentRepository{}, &mockPOIRepository{}, nil, logger)
+
+		_, err := uc.GetLocationScore(ctx, 95, lon)
+		assert.ErrorIs(t, err, errors.ErrInvalidCoordinates)
+	})
+}
+
+func TestLocationScoreUseCase_GetElevationContext(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.4183, 2.1195
+
+	t.Run("default radius and rounded nearest", func(t *testing.T) {
+		mockEnv := &mockEnvironmentRepository{}
+		minEle, maxEle := 120.0, 512.0
+		mockEnv.On("GetElevationContext", ctx, lat, lon, usecase.DefaultElevationRadiusKm).
+			Return(&domain.ElevationContext{
+				Lat: lat, Lon: lon, RadiusKm: usecase.DefaultElevationRadiusKm, HasData: true,
+				Nearest: &domain.ElevationFeature{OSMId: 1, Type: "peak", Name: "Tibidabo", EleM: 512,
+					Lat: 41.42212345678, Lon: 2.11876543219, DistanceM: 420},
+				MinEleM: &minEle, MaxEleM: &maxEle, FeatureCount: 3,
+			}, nil)
+
+		uc := usecase.NewLocationScoreUseCase(&MockTransportRepository{}, mockEnv, &mockPOIRepository{}, nil, logger)
+		result, err := uc.GetElevationContext(ctx, lat, lon, 0)
+
+		assert.NoError(t, err)
+		assert.True(t, result.HasData)
+		assert.Equal(t, 41.422123, result.Nearest.Lat)
+		assert.Equal(t, 2.118765, result.Nearest.Lon)
+		mockEnv.AssertExpectations(t)
+	})
+
+	t.Run("no elevation data is not an error", func(t *testing.T) {
+		mockEnv := &mockEnvironmentRepository{}
+		mockEnv.On("GetElevationContext", ctx, lat, lon, 2.0).
+			Return(&domain.ElevationContext{Lat: lat, Lon: lon, RadiusKm: 2}, nil)
+
+		uc := usecase.NewLocationScoreUseCase(&MockTransportRepository{}, mockEnv, &mockPOIRepository{}, nil, logger)
+		result, err := uc.GetElevationContext(ctx, lat, lon, 2)
+
+		assert.NoError(t, err)
+		assert.False(t, result.HasData)
+		assert.Nil(t, result.Nearest)
+	})
+
+	t.Run("radius out of range", func(t *testing.T) {
+		uc := usecase.NewLocationScoreUseCase(&MockTransportRepository{}, &mockEnvironmentRepository{}, &mockPOIRepository{}, nil, logger)
+
+		_, err := uc.GetElevationContext(ctx, lat, lon, 50)
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/utils"
 	"go.uber.org/zap"
 )
 
@@ -217,8 +218,8 @@ func (s *MapboxBatchScheduler) processBatch(ctx context.Context, batch []*Mapbox
 
 			// Используем дистанцию из БД если есть, иначе вычисляем
 			var linearDist float64
-			if station.Distance != nil {
-				linearDist = *station.Distance
+			if station.DistanceM != nil {
+				linearDist = *station.DistanceM
 			} else {
 				linearDist = calculateDistance(
 					origins[mapping.OriginIndex].Lat,
@@ -236,8 +237,8 @@ func (s *MapboxBatchScheduler) processBatch(ctx context.Context, batch []*Mapbox
 				StationID:       station.ID,
 				Name:            station.Name,
 				Type:            station.Type,
-				Lat:             station.Lat,
-				Lon:             station.Lon,
+				Lat:             utils.RoundCoordinate(station.Lat),
+				Lon:             utils.RoundCoordinate(station.Lon),
 				Lines:           lines,
 				LinearDistance:  linearDist,
 				WalkingDistance: walkingDistance,
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+const (
+	// nearbyDeltaSessionTTL — сессия без обновлений дольше TTL забывается
+	nearbyDeltaSessionTTL = 5 * time.Minute
+	// maxNearbyDeltaSessions — верхняя граница числа сессий в памяти
+	maxNearbyDeltaSessions = 10000
+	// maxNearbyDeltaLimit — максимум POI в радиусе на одно обновление
+	maxNearbyDeltaLimit = 100
+)
+
+// nearbyDeltaSession — набор ID POI одной категории, уже отправленных клиенту
+type nearbyDeltaSession struct {
+	id       string
+	category string
+	ids      map[string]struct{}
+	seenAt   time.Time
+}
+
+// nearbyDeltaSessions — in-process LRU сессий дельт POI поблизости
+type nearbyDeltaSessions struct {
+	mu      sync.Mutex
+	entries *list.List               // *nearbyDeltaSession, в начале - последние обновленные
+	byID    map[string]*list.Element // session_id -> элемент списка
+	now     func() time.Time
+}
+
+func newNearbyDeltaSessions() *nearbyDeltaSessions {
+	return &nearbyDeltaSessions{
+		entries: list.New(),
+		byID:    make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// delta сравнивает текущий набор POI с отправленным ранее в сессии и запоминает текущий.
+// Неизвестная, истекшая или открытая для другой категории сессия заменяется новой:
+// все POI считаются вошедшими.
+func (s *nearbyDeltaSessions) delta(sessionID, category string, items []dto.POISimple) (string, []dto.POISimple, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.expire(now)
+
+	var session *nearbyDeltaSession
+	if el, ok := s.byID[sessionID]; ok {
+		session = el.Value.(*nearbyDeltaSession)
+		if session.category != category {
+			s.remove(el)
+			session = nil
+		} else {
+			s.entries.MoveToFront(el)
+		}
+	}
+	if session == nil {
+		session = &nearbyDeltaSession{id: uuid.NewString(), category: category}
+		s.byID[session.id] = s.entries.PushFront(session)
+		for s.entries.Len() > maxNearbyDeltaSessions {
+			s.remove(s.entries.Back())
+		}
+	}
+
+	current := make(map[string]struct{}, len(items))
+	entered := make([]dto.POISimple, 0)
+	for _, item := range items {
+		current[item.ID] = struct{}{}
+		if _, sent := session.ids[item.ID]; !sent {
+			entered = append(entered, item)
+		}
+	}
+
+	left := make([]string, 0)
+	for id := range session.ids {
+		if _, ok := current[id]; !ok {
+			left = append(left, id)
+		}
+	}
+
+	session.ids = current
+	session.seenAt = now
+	return session.id, entered, left
+}
+
+// expire удаляет истекшие сессии с конца списка: там самые давно обновленные. Вызывается под mu.
+func (s *nearbyDeltaSessions) expire(now time.Time) {
+	for el := s.entries.Back(); el != nil; el = s.entries.Back() {
+		if now.Sub(el.Value.(*nearbyDeltaSession).seenAt) <= nearbyDeltaSessionTTL {
+			return
+		}
+		s.remove(el)
+	}
+}
+
+// remove удаляет сессию из списка и индекса; вызывается под mu
+func (s *nearbyDeltaSessions) remove(el *list.Element) {
+	session := s.entries.Remove(el).(*nearbyDeltaSession)
+	delete(s.byID, session.id)
+}
+
+// GetNearbyPOIDelta ищет POI вокруг нового центра через GetNearbyPOI и возвращает только изменения
+// относительно предыдущего обновления той же сессии: вошедшие в радиус POI и ID покинувших его.
+func (uc *NearbyUseCase) GetNearbyPOIDelta(ctx context.Context, req dto.NearbyDeltaRequest) (*dto.NearbyDelta, error) {
+	if req.Limit < 0 || req.Limit > maxNearbyDeltaLimit {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"limit": "must be between 1 and 100",
+		})
+	}
+
+	result, err := uc.GetNearbyPOI(ctx, req.Category, req.Lat, req.Lon, req.RadiusKm, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, entered, left := uc.deltas.delta(req.SessionID, req.Category, result.Items)
+	if sessionID != req.SessionID {
+		uc.logger.Debug("Nearby POI delta session started",
+			zap.String("session_id", sessionID),
+			zap.String("category", req.Category))
+	}
+
+	return &dto.NearbyDelta{
+		SessionID: sessionID,
+		Category:  result.Category,
+		Entered:   entered,
+		Left:      left,
+		Total:     result.Total,
+	}, nil
+}
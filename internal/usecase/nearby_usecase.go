@@ -26,6 +26,7 @@ const (
 type NearbyUseCase struct {
 	transportUC *TransportUseCase
 	poiUC       *POIUseCase
+	deltas      *nearbyDeltaSessions
 	logger      *zap.Logger
 }
 
@@ -38,6 +39,7 @@ func NewNearbyUseCase(
 	return &NearbyUseCase{
 		transportUC: transportUC,
 		poiUC:       poiUC,
+		deltas:      newNearbyDeltaSessions(),
 		logger:      logger,
 	}
 }
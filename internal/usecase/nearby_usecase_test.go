@@ -9,7 +9,10 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/usecase"
+	"github.com/location-microservice/internal/usecase/dto"
 )
 
 // ---- Mock POI Repository (for NearbyUseCase tests) ----
@@ -23,13 +26,21 @@ func (m *mockPOIRepository) GetByID(ctx context.Context, id int64) (*domain.POI,
 	return args.Get(0).(*domain.POI), args.Error(1)
 }
 
-func (m *mockPOIRepository) GetNearby(ctx context.Context, lat, lon float64, radiusKm float64, categories []string) ([]*domain.POI, error) {
-	args := m.Called(ctx, lat, lon, radiusKm, categories)
+func (m *mockPOIRepository) GetPOIBuilding(ctx context.Context, poiID int64) (*domain.Building, error) {
+	args := m.Called(ctx, poiID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Building), args.Error(1)
+}
+
+func (m *mockPOIRepository) GetNearby(ctx context.Context, lat, lon float64, radiusKm float64, categories []string, opts domain.POINearbyOptions, limit int) ([]*domain.POI, error) {
+	args := m.Called(ctx, lat, lon, radiusKm, categories, opts, limit)
 	return args.Get(0).([]*domain.POI), args.Error(1)
 }
 
-func (m *mockPOIRepository) Search(ctx context.Context, query string, categories []string, limit int) ([]*domain.POI, error) {
-	args := m.Called(ctx, query, categories, limit)
+func (m *mockPOIRepository) Search(ctx context.Context, query string, categories []string, tagFilter domain.POITagFilter, limit int) ([]*domain.POI, error) {
+	args := m.Called(ctx, query, categories, tagFilter, limit)
 	return args.Get(0).([]*domain.POI), args.Error(1)
 }
 
@@ -43,11 +54,19 @@ func (m *mockPOIRepository) GetCategories(ctx context.Context) ([]*domain.POICat
 	return args.Get(0).([]*domain.POICategory), args.Error(1)
 }
 
-func (m *mockPOIRepository) GetSubcategories(ctx context.Context, categoryID int64) ([]*domain.POISubcategory, error) {
-	args := m.Called(ctx, categoryID)
+func (m *mockPOIRepository) GetSubcategories(ctx context.Context, categoryID int64, bbox *domain.BoundingBox) ([]*domain.POISubcategory, error) {
+	args := m.Called(ctx, categoryID, bbox)
 	return args.Get(0).([]*domain.POISubcategory), args.Error(1)
 }
 
+func (m *mockPOIRepository) GetCategoryTree(ctx context.Context) ([]domain.CategoryNode, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CategoryNode), args.Error(1)
+}
+
 func (m *mockPOIRepository) GetPOITile(ctx context.Context, z, x, y int, categories []string) ([]byte, error) {
 	args := m.Called(ctx, z, x, y, categories)
 	return args.Get(0).([]byte), args.Error(1)
@@ -63,16 +82,24 @@ func (m *mockPOIRepository) GetPOIByBoundaryTile(ctx context.Context, boundaryID
 	return args.Get(0).([]byte), args.Error(1)
 }
 
-func (m *mockPOIRepository) GetPOITileByCategories(ctx context.Context, z, x, y int, categories, subcategories []string) ([]byte, error) {
-	args := m.Called(ctx, z, x, y, categories, subcategories)
+func (m *mockPOIRepository) GetPOITileByCategories(ctx context.Context, z, x, y int, categories, subcategories []string, surfaceOnly, withLabels bool, categoryLimit int) ([]byte, error) {
+	args := m.Called(ctx, z, x, y, categories, subcategories, surfaceOnly, withLabels, categoryLimit)
 	return args.Get(0).([]byte), args.Error(1)
 }
 
-func (m *mockPOIRepository) GetPOIInBBox(ctx context.Context, swLat, swLon, neLat, neLon float64, categories, subcategories []string, limit, offset int) ([]*domain.POI, int, error) {
-	args := m.Called(ctx, swLat, swLon, neLat, neLon, categories, subcategories, limit, offset)
+func (m *mockPOIRepository) GetPOIInBBox(ctx context.Context, swLat, swLon, neLat, neLon float64, categories, subcategories []string, importance domain.POIImportanceFilter, limit, offset int) ([]*domain.POI, int, error) {
+	args := m.Called(ctx, swLat, swLon, neLat, neLon, categories, subcategories, importance, limit, offset)
 	return args.Get(0).([]*domain.POI), args.Int(1), args.Error(2)
 }
 
+func (m *mockPOIRepository) GetPOIsAlongPath(ctx context.Context, path []domain.Coordinate, bufferM float64, categories []string, limit int) ([]*domain.POI, error) {
+	args := m.Called(ctx, path, bufferM, categories, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.POI), args.Error(1)
+}
+
 func (m *mockPOIRepository) CountByCategories(ctx context.Context, lat, lon float64, radiusMeters int) (map[string]int, error) {
 	args := m.Called(ctx, lat, lon, radiusMeters)
 	if args.Get(0) == nil {
@@ -81,6 +108,22 @@ func (m *mockPOIRepository) CountByCategories(ctx context.Context, lat, lon floa
 	return args.Get(0).(map[string]int), args.Error(1)
 }
 
+func (m *mockPOIRepository) GetNearestPerCategoryBatch(ctx context.Context, points []domain.LatLon, categories []string, maxRadiusKm float64) ([]map[string]*domain.POIWithDistance, error) {
+	args := m.Called(ctx, points, categories, maxRadiusKm)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]map[string]*domain.POIWithDistance), args.Error(1)
+}
+
+func (m *mockPOIRepository) GetActivityCenter(ctx context.Context, bbox domain.BoundingBox, categories []string, weighted bool) (*domain.ActivityCenter, error) {
+	args := m.Called(ctx, bbox, categories, weighted)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ActivityCenter), args.Error(1)
+}
+
 // ---- Tests ----
 
 func TestNearbyUseCase_GetNearbyTransport_Success(t *testing.T) {
@@ -90,7 +133,7 @@ func TestNearbyUseCase_GetNearbyTransport_Success(t *testing.T) {
 	ctx := context.Background()
 
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	poiUC := usecase.NewPOIUseCase(mockPOI, logger)
+	poiUC := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 	uc := usecase.NewNearbyUseCase(transportUC, poiUC, logger)
 
 	// Mock transport priority search
@@ -99,6 +142,7 @@ func TestNearbyUseCase_GetNearbyTransport_Success(t *testing.T) {
 		mock.AnythingOfType("float64"),
 		mock.AnythingOfType("float64"),
 		mock.AnythingOfType("int"),
+		mock.Anything,
 	).Return([]domain.NearestTransportWithLines{
 		{
 			StationID: 1,
@@ -106,7 +150,7 @@ func TestNearbyUseCase_GetNearbyTransport_Success(t *testing.T) {
 			Type:      "metro",
 			Lat:       41.39,
 			Lon:       2.16,
-			Distance:  300.5,
+			DistanceM: 300.5,
 			Lines: []domain.TransportLineInfo{
 				{ID: 1, Name: "L3", Type: "metro"},
 			},
@@ -128,7 +172,7 @@ func TestNearbyUseCase_GetNearbyTransport_InvalidCoordinates(t *testing.T) {
 	mockPOI := &mockPOIRepository{}
 
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	poiUC := usecase.NewPOIUseCase(mockPOI, logger)
+	poiUC := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 	uc := usecase.NewNearbyUseCase(transportUC, poiUC, logger)
 
 	result, err := uc.GetNearbyTransport(context.Background(), 999, 999, 1500, 10)
@@ -144,11 +188,11 @@ func TestNearbyUseCase_GetNearbyTransport_DefaultValues(t *testing.T) {
 	ctx := context.Background()
 
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	poiUC := usecase.NewPOIUseCase(mockPOI, logger)
+	poiUC := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 	uc := usecase.NewNearbyUseCase(transportUC, poiUC, logger)
 
 	mockTransport.On("GetNearestTransportByPriority", mock.Anything,
-		mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
 	).Return([]domain.NearestTransportWithLines{}, nil)
 
 	// radius=0 and limit=0 → defaults
@@ -165,7 +209,7 @@ func TestNearbyUseCase_GetNearbyPOI_Success(t *testing.T) {
 	ctx := context.Background()
 
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	poiUC := usecase.NewPOIUseCase(mockPOI, logger)
+	poiUC := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 	uc := usecase.NewNearbyUseCase(transportUC, poiUC, logger)
 
 	// Mock POI GetNearby — medical category maps to [pharmacy, hospital, clinic, doctors, dentist, veterinary]
@@ -176,6 +220,8 @@ func TestNearbyUseCase_GetNearbyPOI_Success(t *testing.T) {
 		mock.MatchedBy(func(cats []string) bool {
 			return len(cats) > 0 && cats[0] == "pharmacy"
 		}),
+		domain.POINearbyOptions{},
+		mock.Anything,
 	).Return([]*domain.POI{
 		{
 			ID:          1,
@@ -212,7 +258,7 @@ func TestNearbyUseCase_GetNearbyPOI_InvalidCategory(t *testing.T) {
 	mockPOI := &mockPOIRepository{}
 
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	poiUC := usecase.NewPOIUseCase(mockPOI, logger)
+	poiUC := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 	uc := usecase.NewNearbyUseCase(transportUC, poiUC, logger)
 
 	result, err := uc.GetNearbyPOI(context.Background(), "invalid_category", 41.3851, 2.1734, 1.0, 20)
@@ -227,7 +273,7 @@ func TestNearbyUseCase_GetNearbyPOI_InvalidCoordinates(t *testing.T) {
 	mockPOI := &mockPOIRepository{}
 
 	transportUC := usecase.NewTransportUseCase(mockTransport, logger)
-	poiUC := usecase.NewPOIUseCase(mockPOI, logger)
+	poiUC := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
 	uc := usecase.NewNearbyUseCase(transportUC, poiUC, logger)
 
 	result, err := uc.GetNearbyPOI(context.Background(), "medical", 999, 999, 1.0, 20)
@@ -273,3 +319,350 @@ func TestGetOSMCategories(t *testing.T) {
 	// invalid → nil
 	assert.Nil(t, domain.GetOSMCategories("invalid"))
 }
+
+func TestPOIUseCase_SearchByRadius_UsesRepositoryDistance(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	mockPOI := new(mockPOIRepository)
+	uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+	dbDistance := 123.456
+	mockPOI.On("GetNearby", ctx, 41.3851, 2.1734, 1.0, []string(nil), domain.POINearbyOptions{}, 101).
+		Return([]*domain.POI{
+			// Координаты совпадают с точкой запроса: Haversine дал бы 0
+			{ID: 1, OSMId: 1, Name: "Cafe", Category: "cafe", Lat: 41.3851, Lon: 2.1734, DistanceM: &dbDistance},
+			{ID: 2, OSMId: 2, Name: "Bar", Category: "bar", Lat: 41.3851, Lon: 2.1734},
+		}, nil)
+
+	result, err := uc.SearchByRadius(ctx, dto.RadiusPOIRequest{Lat: 41.3851, Lon: 2.1734, RadiusKm: 1.0})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.POIs, 2)
+	assert.Equal(t, 123.46, result.POIs[0].Distance)
+	assert.Equal(t, 0.0, result.POIs[1].Distance)
+	mockPOI.AssertExpectations(t)
+}
+
+func TestPOIUseCase_SearchByRadius_HasMoreAtMaxLimit(t *testing.T) {
+	ctx := context.Background()
+	mockPOI := new(mockPOIRepository)
+	uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, zap.NewNop())
+
+	// Репозиторий отдает до LimitPOIs строк, но строку сверх limit по умолчанию (100) - тоже
+	pois := make([]*domain.POI, 101)
+	for i := range pois {
+		pois[i] = &domain.POI{ID: int64(i + 1), OSMId: int64(i + 1), Name: "Cafe", Category: "cafe", Lat: 41.3851, Lon: 2.1734}
+	}
+	mockPOI.On("GetNearby", ctx, 41.3851, 2.1734, 1.0, []string(nil), domain.POINearbyOptions{}, 101).
+		Return(pois, nil)
+
+	result, err := uc.SearchByRadius(ctx, dto.RadiusPOIRequest{Lat: 41.3851, Lon: 2.1734, RadiusKm: 1.0})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.POIs, 100)
+	assert.True(t, result.Page.HasMore)
+	mockPOI.AssertExpectations(t)
+}
+
+func TestPOIUseCase_SearchByRadius_DistanceUnit(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	mockPOI := new(mockPOIRepository)
+	uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+	dbDistance := 123.456
+	mockPOI.On("GetNearby", ctx, 41.3851, 2.1734, 1.0, []string(nil), domain.POINearbyOptions{}, 101).
+		Return([]*domain.POI{
+			{ID: 1, OSMId: 1, Name: "Cafe", Category: "cafe", Lat: 41.3851, Lon: 2.1734, DistanceM: &dbDistance},
+		}, nil)
+
+	result, err := uc.SearchByRadius(ctx, dto.RadiusPOIRequest{Lat: 41.3851, Lon: 2.1734, RadiusKm: 1.0, DistanceUnit: "km"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 123.46, result.POIs[0].Distance)
+	result.ConvertDistances(utils.DistanceUnitKilometers)
+	assert.Equal(t, 0.123, result.POIs[0].Distance)
+	mockPOI.AssertExpectations(t)
+}
+
+func TestPOIUseCase_SearchByRadius_TagFilter(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("tags passed to repository", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		filter := domain.POITagFilter{
+			HasTags:     []string{"website"},
+			RequireTags: map[string]string{"outdoor_seating": "yes"},
+		}
+		mockPOI.On("GetNearby", ctx, 41.3851, 2.1734, 1.0, []string{"restaurant"}, domain.POINearbyOptions{TagFilter: filter}, 101).
+			Return([]*domain.POI{{ID: 1, OSMId: 1, Name: "Terraza", Category: "restaurant", Lat: 41.3851, Lon: 2.1734}}, nil)
+
+		result, err := uc.SearchByRadius(ctx, dto.RadiusPOIRequest{
+			Lat: 41.3851, Lon: 2.1734, RadiusKm: 1.0,
+			Categories:  []string{"restaurant"},
+			HasTags:     filter.HasTags,
+			RequireTags: filter.RequireTags,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.POIs, 1)
+		mockPOI.AssertExpectations(t)
+	})
+
+	t.Run("category priority passed to repository, order kept under limit", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		far, near := 900.0, 50.0
+		priority := []string{"healthcare"}
+		mockPOI.On("GetNearby", ctx, 41.3851, 2.1734, 1.0, []string(nil), domain.POINearbyOptions{CategoryPriority: priority}, 2).
+			Return([]*domain.POI{
+				{ID: 1, OSMId: 1, Name: "Hospital", Category: "healthcare", Lat: 41.39, Lon: 2.17, DistanceM: &far},
+				{ID: 2, OSMId: 2, Name: "Vending", Category: "shopping", Lat: 41.385, Lon: 2.173, DistanceM: &near},
+			}, nil)
+
+		result, err := uc.SearchByRadius(ctx, dto.RadiusPOIRequest{
+			Lat: 41.3851, Lon: 2.1734, RadiusKm: 1.0,
+			Limit:            1,
+			CategoryPriority: priority,
+		})
+
+		assert.NoError(t, err)
+		if assert.Len(t, result.POIs, 1) {
+			assert.Equal(t, "Hospital", result.POIs[0].Name)
+		}
+		mockPOI.AssertExpectations(t)
+	})
+
+	t.Run("invalid tag key rejected", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		_, err := uc.SearchByRadius(ctx, dto.RadiusPOIRequest{
+			Lat: 41.3851, Lon: 2.1734, RadiusKm: 1.0,
+			HasTags: []string{"name'; DROP TABLE planet_osm_point; --"},
+		})
+
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+		mockPOI.AssertNotCalled(t, "GetNearby")
+	})
+
+	t.Run("importance filter passed to repository", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		importance := 7
+		filter := domain.POIImportanceFilter{MinImportance: 3, OrderByImportance: true}
+		mockPOI.On("GetNearby", ctx, 41.3851, 2.1734, 1.0, []string(nil), domain.POINearbyOptions{Importance: filter}, 101).
+			Return([]*domain.POI{
+				{ID: 1, OSMId: 1, Name: "Museu Picasso", Category: "leisure", Subcategory: "museum", Lat: 41.385, Lon: 2.181, Importance: &importance},
+			}, nil)
+
+		result, err := uc.SearchByRadius(ctx, dto.RadiusPOIRequest{
+			Lat: 41.3851, Lon: 2.1734, RadiusKm: 1.0,
+			MinImportance: 3,
+			OrderBy:       "importance",
+		})
+
+		assert.NoError(t, err)
+		if assert.Len(t, result.POIs, 1) && assert.NotNil(t, result.POIs[0].Importance) {
+			assert.Equal(t, 7, *result.POIs[0].Importance)
+		}
+		mockPOI.AssertExpectations(t)
+	})
+
+	t.Run("invalid order_by rejected", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		_, err := uc.SearchByRadius(ctx, dto.RadiusPOIRequest{
+			Lat: 41.3851, Lon: 2.1734, RadiusKm: 1.0,
+			OrderBy: "rating",
+		})
+
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+		mockPOI.AssertNotCalled(t, "GetNearby")
+	})
+}
+
+func TestPOIUseCase_GetPOIsAlongPath(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("defaults applied and travel order kept", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		path := []domain.Coordinate{{Lat: 41.38, Lon: 2.17}, {Lat: 41.40, Lon: 2.17}}
+		first, second := 10.0, 1500.123
+		near, far := 5.0, 150.0
+		mockPOI.On("GetPOIsAlongPath", ctx, path, 200.0, []string{"fuel"}, 101).
+			Return([]*domain.POI{
+				{ID: 1, OSMId: 1, Name: "Repsol", Category: "fuel", DistanceM: &far, PathOffsetM: &first},
+				{ID: 2, OSMId: 2, Name: "Cepsa", Category: "fuel", DistanceM: &near, PathOffsetM: &second},
+			}, nil)
+
+		result, err := uc.GetPOIsAlongPath(ctx, dto.PathPOIRequest{
+			Path:       []dto.Point{{Lat: 41.38, Lon: 2.17}, {Lat: 41.40, Lon: 2.17}},
+			Categories: []string{"fuel"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, "1", result.POIs[0].ID)
+		assert.Equal(t, 1500.12, result.POIs[1].PathOffset)
+		assert.InDelta(t, 2224, result.PathLengthM, 5)
+		assert.Equal(t, utils.LimitPagination(100, false), result.Page)
+		mockPOI.AssertExpectations(t)
+	})
+
+	t.Run("single point path rejected", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		_, err := uc.GetPOIsAlongPath(ctx, dto.PathPOIRequest{Path: []dto.Point{{Lat: 41.38, Lon: 2.17}}})
+
+		assert.Error(t, err)
+		mockPOI.AssertNotCalled(t, "GetPOIsAlongPath")
+	})
+
+	t.Run("too long path rejected", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		// Барселона - Мадрид, ~500 км
+		_, err := uc.GetPOIsAlongPath(ctx, dto.PathPOIRequest{
+			Path: []dto.Point{{Lat: 41.38, Lon: 2.17}, {Lat: 40.42, Lon: -3.70}},
+		})
+
+		assert.Error(t, err)
+		mockPOI.AssertNotCalled(t, "GetPOIsAlongPath")
+	})
+}
+
+func TestPOIUseCase_GetSubcategories(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("counts with taxonomy labels", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+		bbox := &domain.BoundingBox{MinLat: 41.38, MinLon: 2.17, MaxLat: 41.40, MaxLon: 2.19}
+
+		mockPOI.On("GetSubcategories", mock.Anything, int64(7), bbox).Return([]*domain.POISubcategory{
+			{CategoryID: 7, Code: "pharmacy", NameEn: "pharmacy", POICount: 12},
+			{CategoryID: 7, Code: "general", NameEn: "general", POICount: 3},
+			{CategoryID: 7, Code: "herbalist", NameEn: "herbalist", POICount: 1},
+		}, nil)
+
+		result, err := uc.GetSubcategories(ctx, 7, "es", bbox)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+		assert.Equal(t, "Farmacia", result[0].Name)
+		assert.Equal(t, "Pharmacy", result[0].NameEn)
+		assert.Equal(t, "Аптека", result[0].NameRu)
+		assert.Equal(t, 12, result[0].POICount)
+		assert.Equal(t, "Otros", result[1].Name)
+		// нет в таксономии - подпись равна коду
+		assert.Equal(t, "herbalist", result[2].Name)
+		mockPOI.AssertExpectations(t)
+	})
+
+	t.Run("unknown language falls back to english", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+
+		mockPOI.On("GetSubcategories", mock.Anything, int64(7), (*domain.BoundingBox)(nil)).Return([]*domain.POISubcategory{
+			{CategoryID: 7, Code: "hospital", POICount: 2},
+		}, nil)
+
+		result, err := uc.GetSubcategories(ctx, 7, "zz", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Hospital", result[0].Name)
+		mockPOI.AssertExpectations(t)
+	})
+
+	t.Run("inverted bbox rejected", func(t *testing.T) {
+		mockPOI := new(mockPOIRepository)
+		uc := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+		bbox := &domain.BoundingBox{MinLat: 41.40, MinLon: 2.17, MaxLat: 41.38, MaxLon: 2.19}
+
+		_, err := uc.GetSubcategories(ctx, 7, "en", bbox)
+
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+		mockPOI.AssertNotCalled(t, "GetSubcategories")
+	})
+}
+
+func TestNearbyUseCase_GetNearbyPOIDelta(t *testing.T) {
+	logger := zap.NewNop()
+	mockPOI := &mockPOIRepository{}
+	ctx := context.Background()
+
+	poiUC := usecase.NewPOIUseCase(mockPOI, &MockCacheRepository{}, logger)
+	uc := usecase.NewNearbyUseCase(usecase.NewTransportUseCase(&MockTransportRepository{}, logger), poiUC, logger)
+
+	poi := func(id int64) *domain.POI {
+		return &domain.POI{ID: id, OSMId: id, Name: "POI", Category: "pharmacy", Lat: 41.386, Lon: 2.175}
+	}
+	expectNearby := func(lat float64, pois ...*domain.POI) {
+		mockPOI.On("GetNearby", mock.Anything, lat, 2.1734, 1.0,
+			mock.Anything, domain.POINearbyOptions{}, mock.Anything,
+		).Return(pois, nil).Once()
+	}
+
+	expectNearby(41.3851, poi(1), poi(2))
+	expectNearby(41.3861, poi(2), poi(3))
+
+	first, err := uc.GetNearbyPOIDelta(ctx, dto.NearbyDeltaRequest{Category: "medical", Lat: 41.3851, Lon: 2.1734})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, first.SessionID)
+	assert.Len(t, first.Entered, 2)
+	assert.Empty(t, first.Left)
+
+	second, err := uc.GetNearbyPOIDelta(ctx, dto.NearbyDeltaRequest{
+		SessionID: first.SessionID, Category: "medical", Lat: 41.3861, Lon: 2.1734,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, first.SessionID, second.SessionID)
+	if assert.Len(t, second.Entered, 1) {
+		assert.Equal(t, "3", second.Entered[0].ID)
+	}
+	assert.Equal(t, []string{"1"}, second.Left)
+	assert.Equal(t, 2, second.Total)
+
+	t.Run("unknown session starts over", func(t *testing.T) {
+		expectNearby(41.3851, poi(1))
+
+		result, err := uc.GetNearbyPOIDelta(ctx, dto.NearbyDeltaRequest{SessionID: "expired", Category: "medical", Lat: 41.3851, Lon: 2.1734})
+		assert.NoError(t, err)
+		assert.NotEqual(t, "expired", result.SessionID)
+		assert.Len(t, result.Entered, 1)
+	})
+
+	t.Run("session of another category starts over", func(t *testing.T) {
+		mockPOI.On("GetNearby", mock.Anything, 41.3861, 2.1734, 1.0,
+			mock.Anything, domain.POINearbyOptions{}, mock.Anything,
+		).Return([]*domain.POI{poi(3)}, nil).Once()
+
+		result, err := uc.GetNearbyPOIDelta(ctx, dto.NearbyDeltaRequest{
+			SessionID: first.SessionID, Category: "groceries", Lat: 41.3861, Lon: 2.1734,
+		})
+		assert.NoError(t, err)
+		assert.NotEqual(t, first.SessionID, result.SessionID)
+		assert.Len(t, result.Entered, 1)
+		assert.Empty(t, result.Left)
+	})
+
+	t.Run("limit above cap rejected", func(t *testing.T) {
+		_, err := uc.GetNearbyPOIDelta(ctx, dto.NearbyDeltaRequest{Category: "medical", Lat: 41.3851, Lon: 2.1734, Limit: 500})
+		assert.Error(t, err)
+	})
+
+	mockPOI.AssertExpectations(t)
+}
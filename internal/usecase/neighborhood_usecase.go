@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxNeighborhoodPOIRadiusKm - максимальный радиус поиска удобств (км)
+	maxNeighborhoodPOIRadiusKm = 5.0
+	// defaultNeighborhoodTransportLimit - станций транспорта по умолчанию
+	defaultNeighborhoodTransportLimit = 5
+	// maxNeighborhoodTransportLimit - максимум станций транспорта
+	maxNeighborhoodTransportLimit = 20
+	// neighborhoodCacheTTL - POI и станции меняются только при импорте, блок кешируется на час
+	neighborhoodCacheTTL = time.Hour
+)
+
+// NeighborhoodUseCase собирает блок "рядом с объектом" для страницы объявления: ближайший POI
+// каждой категории и ближайший транспорт с линиями одним запросом и одним ключом кеша.
+// Транспорт берется из TransportUseCase - станции, линии и время ходьбы такие же, как в /transport/priority.
+type NeighborhoodUseCase struct {
+	poiRepo     repository.POIRepository
+	transportUC *TransportUseCase
+	cacheRepo   repository.CacheRepository
+	logger      *zap.Logger
+}
+
+// NewNeighborhoodUseCase создает новый NeighborhoodUseCase
+func NewNeighborhoodUseCase(
+	poiRepo repository.POIRepository,
+	transportUC *TransportUseCase,
+	cacheRepo repository.CacheRepository,
+	logger *zap.Logger,
+) *NeighborhoodUseCase {
+	return &NeighborhoodUseCase{
+		poiRepo:     poiRepo,
+		transportUC: transportUC,
+		cacheRepo:   cacheRepo,
+		logger:      logger,
+	}
+}
+
+// GetNeighborhoodContext возвращает ближайшие удобства по категориям и ближайший транспорт.
+// Удобства и транспорт запрашиваются параллельно; без удобств ответа нет. При ошибке транспорта
+// блок возвращается без станций с meta.transport_error и не кешируется.
+func (uc *NeighborhoodUseCase) GetNeighborhoodContext(
+	ctx context.Context,
+	lat, lon float64,
+	opts dto.NeighborhoodContextOptions,
+) (*dto.NeighborhoodContextResponse, error) {
+	if !utils.ValidateCoordinates(lat, lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+
+	categories, err := normalizeNeighborhoodCategories(opts.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	poiRadiusKm := opts.POIRadiusKm
+	if poiRadiusKm == 0 {
+		poiRadiusKm = defaultNearbyRadiusKm
+	}
+	transportRadius := opts.TransportRadius
+	if transportRadius == 0 {
+		transportRadius = defaultTransportNearbyRadiusM
+	}
+	transportLimit := opts.TransportLimit
+	if transportLimit == 0 {
+		transportLimit = defaultNeighborhoodTransportLimit
+	}
+	if poiRadiusKm < 0 || poiRadiusKm > maxNeighborhoodPOIRadiusKm ||
+		transportRadius < 100 || transportRadius > 10000 ||
+		transportLimit < 1 || transportLimit > maxNeighborhoodTransportLimit {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"poi_radius":       fmt.Sprintf("must be up to %g km", maxNeighborhoodPOIRadiusKm),
+			"transport_radius": "must be between 100 and 10000 m",
+			"transport_limit":  fmt.Sprintf("must be between 1 and %d", maxNeighborhoodTransportLimit),
+		})
+	}
+
+	// Ключ по координатам с точностью ~1 м: соседние запросы той же страницы попадают в кеш
+	cacheKey := fmt.Sprintf("%s:neighborhood:%.5f:%.5f:%s:%g:%g:%d",
+		domain.CacheNamespaceSearch, lat, lon, strings.Join(categories, ","),
+		poiRadiusKm, transportRadius, transportLimit)
+	if cached, err := uc.cacheRepo.Get(ctx, cacheKey); err == nil && cached != nil {
+		var response dto.NeighborhoodContextResponse
+		if err := json.Unmarshal(cached, &response); err == nil {
+			return &response, nil
+		}
+		uc.logger.Warn("Failed to decode cached neighborhood context", zap.String("key", cacheKey))
+	}
+
+	var wg sync.WaitGroup
+	var amenities []map[string]*domain.POIWithDistance
+	var transport *dto.PriorityTransportResponse
+	var amenitiesErr, transportErr error
+
+	// Горутина 1: ближайший POI каждой категории
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		points := []domain.LatLon{{Lat: lat, Lon: lon}}
+		amenities, amenitiesErr = uc.poiRepo.GetNearestPerCategoryBatch(ctx, points, categories, poiRadiusKm)
+	}()
+
+	// Горутина 2: ближайший транспорт с приоритетом (metro/train -> tram -> bus) и линиями
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		transport, transportErr = uc.transportUC.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{
+			Lat:    lat,
+			Lon:    lon,
+			Radius: transportRadius,
+			Limit:  transportLimit,
+		})
+	}()
+
+	wg.Wait()
+
+	if amenitiesErr != nil {
+		uc.logger.Error("Failed to get nearest amenities", zap.Error(amenitiesErr))
+		return nil, amenitiesErr
+	}
+	if transportErr != nil {
+		uc.logger.Warn("Failed to get nearest transport, continuing without transport", zap.Error(transportErr))
+		transport = &dto.PriorityTransportResponse{}
+	}
+
+	response := &dto.NeighborhoodContextResponse{
+		Amenities: make(map[string]dto.POISimple, len(categories)),
+		Transport: transport.Stations,
+	}
+	if response.Transport == nil {
+		response.Transport = []dto.PriorityTransportStation{}
+	}
+
+	if len(amenities) > 0 {
+		for category, poi := range amenities[0] {
+			response.Amenities[category] = dto.POISimple{
+				ID:          strconv.FormatInt(poi.ID, 10),
+				Name:        poi.Name,
+				Category:    poi.Category,
+				Subcategory: poi.Subcategory,
+				Lat:         utils.RoundCoordinate(poi.Lat),
+				Lon:         utils.RoundCoordinate(poi.Lon),
+				Distance:    math.Round(poi.LinearDistance*100) / 100,
+			}
+		}
+	}
+
+	response.Meta = dto.NeighborhoodContextMeta{
+		SearchPoint:      dto.Point{Lat: lat, Lon: lon},
+		Categories:       categories,
+		POIRadiusKm:      poiRadiusKm,
+		TransportRadiusM: transportRadius,
+		HasHighPriority:  transport.Meta.HasHighPriority,
+		PriorityType:     transport.Meta.PriorityType,
+		TransportError:   transportErr != nil,
+	}
+
+	if transportErr == nil {
+		if data, err := json.Marshal(response); err == nil {
+			if err := uc.cacheRepo.Set(ctx, cacheKey, data, neighborhoodCacheTTL); err != nil {
+				uc.logger.Warn("Failed to cache neighborhood context", zap.String("key", cacheKey), zap.Error(err))
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// normalizeNeighborhoodCategories проверяет категории POI, убирает повторы и сортирует их
+// (порядок не влияет на ключ кеша); пусто - все категории
+func normalizeNeighborhoodCategories(categories []string) ([]string, error) {
+	if len(categories) == 0 {
+		categories = domain.ValidPOICategories()
+	}
+
+	seen := make(map[string]bool, len(categories))
+	result := make([]string, 0, len(categories))
+	for _, category := range categories {
+		category = strings.TrimSpace(category)
+		if !domain.IsValidPOICategory(category) {
+			return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+				"category": category,
+				"valid":    domain.ValidPOICategories(),
+			})
+		}
+		if !seen[category] {
+			seen[category] = true
+			result = append(result, category)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
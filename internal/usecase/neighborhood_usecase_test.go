@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase"
+	"github.com/location-microservice/internal/usecase/dto"
+)
+
+func TestNeighborhoodUseCase_GetNeighborhoodContext(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.3851, 2.1734
+	points := []domain.LatLon{{Lat: lat, Lon: lon}}
+	cacheKey := "search:neighborhood:41.38510:2.17340:food_drink,healthcare:1:1500:5"
+
+	t.Run("combines amenities and transport and caches the block", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		mockTransport := &MockTransportRepository{}
+		mockCache := &MockCacheRepository{}
+
+		mockCache.On("Get", ctx, cacheKey).Return(nil, errors.ErrCacheError)
+		mockPOI.On("GetNearestPerCategoryBatch", ctx, points, []string{"food_drink", "healthcare"}, 1.0).
+			Return([]map[string]*domain.POIWithDistance{{
+				"healthcare": {ID: 7, Name: "Farmacia", Category: "healthcare", Subcategory: "pharmacy", Lat: 41.386, Lon: 2.174, LinearDistance: 120.456},
+			}}, nil)
+		mockTransport.On("GetNearestTransportByPriority", ctx, lat, lon, 1500.0, 5, map[string]int(nil)).
+			Return([]domain.NearestTransportWithLines{{
+				StationID: 1, Name: "Catalunya", Type: "metro", Lat: 41.3870, Lon: 2.1700, DistanceM: 300,
+				Lines: []domain.TransportLineInfo{{ID: 10, Name: "L1", Ref: "L1", Type: "subway"}},
+			}}, nil)
+		mockCache.On("Set", ctx, cacheKey, mock.Anything, mock.Anything).Return(nil)
+
+		uc := usecase.NewNeighborhoodUseCase(mockPOI, usecase.NewTransportUseCase(mockTransport, logger), mockCache, logger)
+		result, err := uc.GetNeighborhoodContext(ctx, lat, lon, dto.NeighborhoodContextOptions{
+			Categories: []string{"healthcare", "food_drink", "healthcare"},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, result.Amenities, 1)
+		assert.Equal(t, "7", result.Amenities["healthcare"].ID)
+		assert.Equal(t, 120.46, result.Amenities["healthcare"].Distance)
+		require.Len(t, result.Transport, 1)
+		assert.Equal(t, 300.0, result.Transport[0].LinearDistance)
+		assert.Equal(t, 360.0, result.Transport[0].WalkingDistance)
+		assert.Len(t, result.Transport[0].Lines, 1)
+		assert.True(t, result.Meta.HasHighPriority)
+		assert.False(t, result.Meta.TransportError)
+		assert.Equal(t, []string{"food_drink", "healthcare"}, result.Meta.Categories)
+		mockPOI.AssertExpectations(t)
+		mockTransport.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("served from cache", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		mockTransport := &MockTransportRepository{}
+		mockCache := &MockCacheRepository{}
+
+		cached, _ := json.Marshal(dto.NeighborhoodContextResponse{
+			Amenities: map[string]dto.POISimple{"healthcare": {ID: "7"}},
+		})
+		mockCache.On("Get", ctx, cacheKey).Return(cached, nil)
+
+		uc := usecase.NewNeighborhoodUseCase(mockPOI, usecase.NewTransportUseCase(mockTransport, logger), mockCache, logger)
+		result, err := uc.GetNeighborhoodContext(ctx, lat, lon, dto.NeighborhoodContextOptions{
+			Categories: []string{"healthcare", "food_drink"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "7", result.Amenities["healthcare"].ID)
+		mockPOI.AssertNotCalled(t, "GetNearestPerCategoryBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockTransport.AssertNotCalled(t, "GetNearestTransportByPriority", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("transport failure returns amenities without caching", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		mockTransport := &MockTransportRepository{}
+		mockCache := &MockCacheRepository{}
+
+		mockCache.On("Get", ctx, cacheKey).Return(nil, errors.ErrCacheError)
+		mockPOI.On("GetNearestPerCategoryBatch", ctx, points, []string{"food_drink", "healthcare"}, 1.0).
+			Return([]map[string]*domain.POIWithDistance{{
+				"food_drink": {ID: 3, Name: "Bar", Category: "food_drink", LinearDistance: 50},
+			}}, nil)
+		mockTransport.On("GetNearestTransportByPriority", ctx, lat, lon, 1500.0, 5, map[string]int(nil)).
+			Return(nil, errors.ErrDatabaseError)
+
+		uc := usecase.NewNeighborhoodUseCase(mockPOI, usecase.NewTransportUseCase(mockTransport, logger), mockCache, logger)
+		result, err := uc.GetNeighborhoodContext(ctx, lat, lon, dto.NeighborhoodContextOptions{
+			Categories: []string{"food_drink", "healthcare"},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, result.Amenities, 1)
+		assert.Empty(t, result.Transport)
+		assert.True(t, result.Meta.TransportError)
+		mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("amenities failure fails the request", func(t *testing.T) {
+		mockPOI := &mockPOIRepository{}
+		mockTransport := &MockTransportRepository{}
+		mockCache := &MockCacheRepository{}
+
+		mockCache.On("Get", ctx, cacheKey).Return(nil, errors.ErrCacheError)
+		mockPOI.On("GetNearestPerCategoryBatch", ctx, points, []string{"food_drink", "healthcare"}, 1.0).
+			Return(nil, errors.ErrDatabaseError)
+		mockTransport.On("GetNearestTransportByPriority", ctx, lat, lon, 1500.0, 5, map[string]int(nil)).
+			Return([]domain.NearestTransportWithLines{}, nil)
+
+		uc := usecase.NewNeighborhoodUseCase(mockPOI, usecase.NewTransportUseCase(mockTransport, logger), mockCache, logger)
+		_, err := uc.GetNeighborhoodContext(ctx, lat, lon, dto.NeighborhoodContextOptions{
+			Categories: []string{"food_drink", "healthcare"},
+		})
+
+		assert.ErrorIs(t, err, errors.ErrDatabaseError)
+	})
+
+	t.Run("invalid category", func(t *testing.T) {
+		uc := usecase.NewNeighborhoodUseCase(&mockPOIRepository{}, usecase.NewTransportUseCase(&MockTransportRepository{}, logger), &MockCacheRepository{}, logger)
+		_, err := uc.GetNeighborhoodContext(ctx, lat, lon, dto.NeighborhoodContextOptions{
+			Categories: []string{"casino"},
+		})
+
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	})
+}
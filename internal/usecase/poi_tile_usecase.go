@@ -17,35 +17,39 @@ import (
 type POITileUseCase struct {
 	poiRepo      repository.POIRepository
 	cacheRepo    repository.CacheRepository
+	tileArchive  repository.TileArchiveRepository // nil - без предрассчитанных тайлов
 	logger       *zap.Logger
 	tileCacheTTL time.Duration
-	maxFeatures  int
 }
 
 func NewPOITileUseCase(
 	poiRepo repository.POIRepository,
 	cacheRepo repository.CacheRepository,
+	tileArchive repository.TileArchiveRepository,
 	logger *zap.Logger,
 	tileCacheTTL time.Duration,
-	maxFeatures int,
 ) *POITileUseCase {
-	if maxFeatures == 0 {
-		maxFeatures = 1000 // Default max features
-	}
 	return &POITileUseCase{
 		poiRepo:      poiRepo,
 		cacheRepo:    cacheRepo,
+		tileArchive:  tileArchive,
 		logger:       logger,
 		tileCacheTTL: tileCacheTTL,
-		maxFeatures:  maxFeatures,
 	}
 }
 
-// GetPOITile возвращает MVT тайл с POI с фильтрацией по категориям и подкатегориям
-func (uc *POITileUseCase) GetPOITile(ctx context.Context, z, x, y int, categories, subcategories []string) ([]byte, error) {
-	// Валидация zoom level (consistent with existing tile endpoints)
-	if z < 0 || z > 18 {
-		return nil, errors.ErrInvalidZoom
+// GetPOITile возвращает MVT тайл с POI с фильтрацией по категориям и подкатегориям.
+// surfaceOnly скрывает подземные и indoor объекты, withLabels добавляет атрибуты rank и min_zoom.
+// categoryLimit ограничивает число POI одной категории, 0 - лимит тайла делится между категориями поровну.
+func (uc *POITileUseCase) GetPOITile(ctx context.Context, z, x, y int, categories, subcategories []string, surfaceOnly, withLabels bool, categoryLimit int) ([]byte, error) {
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+
+	if categoryLimit < 0 {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"category_limit": categoryLimit,
+		})
 	}
 
 	// Валидация категорий
@@ -57,8 +61,19 @@ func (uc *POITileUseCase) GetPOITile(ctx context.Context, z, x, y int, categorie
 		}
 	}
 
+	if err := checkTileLayerVisible(domain.TileLayerPOI, z); err != nil {
+		return nil, err
+	}
+
+	// Архив рассчитан без фильтров и дополнительных атрибутов
+	if len(categories) == 0 && len(subcategories) == 0 && !surfaceOnly && !withLabels && categoryLimit == 0 {
+		if tile, ok := archivedTile(ctx, uc.tileArchive, uc.logger, domain.TileLayerPOI, z, x, y); ok {
+			return tile, nil
+		}
+	}
+
 	// Создаем cache key
-	cacheKey := uc.createCacheKey(z, x, y, categories, subcategories)
+	cacheKey := uc.createCacheKey(z, x, y, categories, subcategories, surfaceOnly, withLabels, categoryLimit)
 
 	// Проверяем кеш
 	cached, err := uc.cacheRepo.Get(ctx, cacheKey)
@@ -68,7 +83,7 @@ func (uc *POITileUseCase) GetPOITile(ctx context.Context, z, x, y int, categorie
 	}
 
 	// Генерируем тайл из БД
-	tile, err := uc.poiRepo.GetPOITileByCategories(ctx, z, x, y, categories, subcategories)
+	tile, err := uc.poiRepo.GetPOITileByCategories(ctx, z, x, y, categories, subcategories, surfaceOnly, withLabels, categoryLimit)
 	if err != nil {
 		uc.logger.Error("Failed to get POI tile",
 			zap.Int("z", z),
@@ -91,7 +106,7 @@ func (uc *POITileUseCase) GetPOITile(ctx context.Context, z, x, y int, categorie
 }
 
 // createCacheKey создает ключ для кеширования с учетом параметров фильтрации
-func (uc *POITileUseCase) createCacheKey(z, x, y int, categories, subcategories []string) string {
+func (uc *POITileUseCase) createCacheKey(z, x, y int, categories, subcategories []string, surfaceOnly, withLabels bool, categoryLimit int) string {
 	// Сортируем массивы для стабильного хеша
 	sortedCategories := make([]string, len(categories))
 	copy(sortedCategories, categories)
@@ -105,9 +120,18 @@ func (uc *POITileUseCase) createCacheKey(z, x, y int, categories, subcategories
 	params := fmt.Sprintf("%s|%s",
 		strings.Join(sortedCategories, ","),
 		strings.Join(sortedSubcategories, ","))
+	if surfaceOnly {
+		params += "|surface"
+	}
+	if withLabels {
+		params += "|labels"
+	}
+	if categoryLimit > 0 {
+		params += fmt.Sprintf("|per_category=%d", categoryLimit)
+	}
 
 	// Хешируем параметры
 	hash := fmt.Sprintf("%x", md5.Sum([]byte(params)))
 
-	return fmt.Sprintf("tile:poi:%d:%d:%d:%s", z, x, y, hash)
+	return domain.TileCacheKey("poi", z, x, y) + ":" + hash
 }
@@ -2,6 +2,10 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
 
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
@@ -11,18 +15,29 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	defaultPathBufferM = 200.0 // ширина коридора вокруг маршрута по умолчанию, м
+	maxPathLengthKm    = 200.0 // максимальная длина маршрута для поиска POI вдоль него
+
+	// categoryTreeCacheTTL - таксономия меняется только при импорте, дерево кешируется надолго
+	categoryTreeCacheTTL = 24 * time.Hour
+)
+
 type POIUseCase struct {
-	poiRepo repository.POIRepository
-	logger  *zap.Logger
+	poiRepo   repository.POIRepository
+	cacheRepo repository.CacheRepository
+	logger    *zap.Logger
 }
 
 func NewPOIUseCase(
 	poiRepo repository.POIRepository,
+	cacheRepo repository.CacheRepository,
 	logger *zap.Logger,
 ) *POIUseCase {
 	return &POIUseCase{
-		poiRepo: poiRepo,
-		logger:  logger,
+		poiRepo:   poiRepo,
+		cacheRepo: cacheRepo,
+		logger:    logger,
 	}
 }
 
@@ -45,6 +60,20 @@ func (uc *POIUseCase) SearchByRadius(
 		req.Limit = 100
 	}
 
+	tagFilter := domain.POITagFilter{HasTags: req.HasTags, RequireTags: req.RequireTags}
+	if err := tagFilter.Validate(); err != nil {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"tags": err.Error(),
+		})
+	}
+
+	importance, err := domain.NewPOIImportanceFilter(req.MinImportance, req.OrderBy)
+	if err != nil {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"importance": err.Error(),
+		})
+	}
+
 	// Search POIs
 	pois, err := uc.poiRepo.GetNearby(
 		ctx,
@@ -52,6 +81,15 @@ func (uc *POIUseCase) SearchByRadius(
 		req.Lon,
 		req.RadiusKm,
 		req.Categories,
+		domain.POINearbyOptions{
+			TagFilter:        tagFilter,
+			SurfaceOnly:      req.SurfaceOnly,
+			IncludeAddress:   req.IncludeAddress,
+			ExcludeWithinM:   req.ExcludeWithinM,
+			CategoryPriority: req.CategoryPriority,
+			Importance:       importance,
+		},
+		req.Limit+1, // лишняя строка сверх limit показывает, есть ли следующая страница
 	)
 	if err != nil {
 		uc.logger.Error("Failed to search POIs by radius", zap.Error(err))
@@ -59,14 +97,15 @@ func (uc *POIUseCase) SearchByRadius(
 	}
 
 	// Apply limit
-	if len(pois) > req.Limit {
+	hasMore := len(pois) > req.Limit
+	if hasMore {
 		pois = pois[:req.Limit]
 	}
 
 	// Build response
 	result := make([]dto.POISimple, 0, len(pois))
 	for _, poi := range pois {
-		distance := utils.HaversineDistance(req.Lat, req.Lon, poi.Lat, poi.Lon) * 1000 // to meters
+		distance := math.Round(utils.DistanceMeters(poi.DistanceM, req.Lat, req.Lon, poi.Lat, poi.Lon)*100) / 100
 
 		// Convert to DTO with string ID
 		result = append(result, dto.ConvertPOI(poi, distance))
@@ -75,9 +114,104 @@ func (uc *POIUseCase) SearchByRadius(
 	return &dto.RadiusPOIResponse{
 		POIs:  result,
 		Total: len(result),
+		Page:  utils.LimitPagination(req.Limit, hasMore),
+	}, nil
+}
+
+// GetPOIsAlongPath возвращает POI в коридоре вокруг маршрута в порядке движения по нему
+func (uc *POIUseCase) GetPOIsAlongPath(ctx context.Context, req dto.PathPOIRequest) (*dto.PathPOIResponse, error) {
+	if len(req.Path) < 2 {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"path": "at least two points required",
+		})
+	}
+
+	path := make([]domain.Coordinate, len(req.Path))
+	var lengthKm float64
+	for i, p := range req.Path {
+		if !utils.ValidateCoordinates(p.Lat, p.Lon) {
+			return nil, errors.ErrInvalidCoordinates.WithDetails(map[string]interface{}{
+				"point_index": i,
+			})
+		}
+		if i > 0 {
+			lengthKm += utils.HaversineDistance(req.Path[i-1].Lat, req.Path[i-1].Lon, p.Lat, p.Lon)
+		}
+		path[i] = domain.Coordinate{Lat: p.Lat, Lon: p.Lon}
+	}
+	if lengthKm > maxPathLengthKm {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"path_length_km":     math.Round(lengthKm*10) / 10,
+			"max_path_length_km": maxPathLengthKm,
+		})
+	}
+
+	if req.BufferM == 0 {
+		req.BufferM = defaultPathBufferM
+	}
+	if req.Limit == 0 {
+		req.Limit = 100
+	}
+
+	// Лишняя строка сверх limit показывает, есть ли POI дальше по маршруту
+	pois, err := uc.poiRepo.GetPOIsAlongPath(ctx, path, req.BufferM, req.Categories, req.Limit+1)
+	if err != nil {
+		uc.logger.Error("Failed to get POIs along path", zap.Int("points", len(path)), zap.Error(err))
+		return nil, err
+	}
+	hasMore := len(pois) > req.Limit
+	if hasMore {
+		pois = pois[:req.Limit]
+	}
+
+	result := make([]dto.PathPOI, 0, len(pois))
+	for _, poi := range pois {
+		var distance, offset float64
+		if poi.DistanceM != nil {
+			distance = math.Round(*poi.DistanceM*100) / 100
+		}
+		if poi.PathOffsetM != nil {
+			offset = math.Round(*poi.PathOffsetM*100) / 100
+		}
+		result = append(result, dto.PathPOI{
+			POISimple:  dto.ConvertPOI(poi, distance),
+			PathOffset: offset,
+		})
+	}
+
+	return &dto.PathPOIResponse{
+		POIs:        result,
+		Total:       len(result),
+		PathLengthM: math.Round(lengthKm*1000*100) / 100,
+		Page:        utils.LimitPagination(req.Limit, hasMore),
 	}, nil
 }
 
+// GetByID возвращает полную информацию о POI по OSM ID
+func (uc *POIUseCase) GetByID(ctx context.Context, id int64) (*dto.POIDetailsResponse, error) {
+	poi, err := uc.poiRepo.GetByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to get POI by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	result := dto.ConvertPOIDetails(poi)
+	return &result, nil
+}
+
+// GetBuilding возвращает контур здания, в котором находится POI, или ближайшее к нему здание
+func (uc *POIUseCase) GetBuilding(ctx context.Context, id int64) (*domain.Building, error) {
+	building, err := uc.poiRepo.GetPOIBuilding(ctx, id)
+	if err != nil {
+		if err != errors.ErrLocationNotFound {
+			uc.logger.Error("Failed to get POI building", zap.Int64("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return building, nil
+}
+
 func (uc *POIUseCase) GetCategories(ctx context.Context, lang string) ([]*domain.POICategory, error) {
 	categories, err := uc.poiRepo.GetCategories(ctx)
 	if err != nil {
@@ -88,13 +222,69 @@ func (uc *POIUseCase) GetCategories(ctx context.Context, lang string) ([]*domain
 	return categories, nil
 }
 
-func (uc *POIUseCase) GetSubcategories(ctx context.Context, categoryID int64, lang string) ([]*domain.POISubcategory, error) {
-	subcategories, err := uc.poiRepo.GetSubcategories(ctx, categoryID)
+// GetCategoryTree возвращает дерево категория -> подкатегории с количеством POI для фильтра на клиенте
+// одним запросом. Дерево кешируется без подписей на categoryTreeCacheTTL, подписи из таксономии
+// на языке lang проставляются после кеша.
+func (uc *POIUseCase) GetCategoryTree(ctx context.Context, lang string) ([]domain.CategoryNode, error) {
+	cacheKey := fmt.Sprintf("%s:poi_category_tree", domain.CacheNamespaceStats)
+
+	var tree []domain.CategoryNode
+	if cached, err := uc.cacheRepo.Get(ctx, cacheKey); err == nil && cached != nil {
+		if err := json.Unmarshal(cached, &tree); err != nil {
+			uc.logger.Warn("Failed to decode cached category tree", zap.String("key", cacheKey))
+			tree = nil
+		}
+	}
+
+	if tree == nil {
+		var err error
+		tree, err = uc.poiRepo.GetCategoryTree(ctx)
+		if err != nil {
+			uc.logger.Error("Failed to get POI category tree", zap.Error(err))
+			return nil, err
+		}
+		if tree == nil {
+			tree = []domain.CategoryNode{}
+		}
+
+		if data, err := json.Marshal(tree); err == nil {
+			if err := uc.cacheRepo.Set(ctx, cacheKey, data, categoryTreeCacheTTL); err != nil {
+				uc.logger.Warn("Failed to cache category tree", zap.String("key", cacheKey), zap.Error(err))
+			}
+		}
+	}
+
+	for i := range tree {
+		tree[i].ApplyTaxonomyLabels(lang)
+	}
+
+	return tree, nil
+}
+
+// GetSubcategories возвращает подкатегории категории с количеством POI и подписями из таксономии.
+// bbox != nil - количество считается только в прямоугольнике (для фасетного фильтра по видимой области).
+func (uc *POIUseCase) GetSubcategories(ctx context.Context, categoryID int64, lang string, bbox *domain.BoundingBox) ([]*domain.POISubcategory, error) {
+	if bbox != nil {
+		if !utils.ValidateCoordinates(bbox.MinLat, bbox.MinLon) || !utils.ValidateCoordinates(bbox.MaxLat, bbox.MaxLon) {
+			return nil, errors.ErrInvalidCoordinates
+		}
+		if bbox.MinLat >= bbox.MaxLat || bbox.MinLon >= bbox.MaxLon {
+			return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+				"bbox": "south-west corner must be below and left of north-east corner",
+			})
+		}
+	}
+
+	subcategories, err := uc.poiRepo.GetSubcategories(ctx, categoryID, bbox)
 	if err != nil {
 		uc.logger.Error("Failed to get POI subcategories", zap.Error(err))
 		return nil, err
 	}
 
+	for _, subcategory := range subcategories {
+		subcategory.ApplyTaxonomyLabels(lang)
+	}
+
 	return subcategories, nil
 }
 
@@ -104,15 +294,8 @@ func (uc *POIUseCase) GetPOITile(
 	z, x, y int,
 	categories []string,
 ) ([]byte, error) {
-	// Validate zoom level
-	if z < 0 || z > 20 {
-		return nil, errors.ErrInvalidZoom
-	}
-
-	// Validate tile coordinates
-	maxTile := 1 << uint(z)
-	if x < 0 || x >= maxTile || y < 0 || y >= maxTile {
-		return nil, errors.ErrInvalidTileCoordinates
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
 	}
 
 	tile, err := uc.poiRepo.GetPOITile(ctx, z, x, y, categories)
@@ -177,7 +360,14 @@ func (uc *POIUseCase) GetPOIInBBox(ctx context.Context, req dto.BBoxPOIRequest)
 		req.Offset = 0
 	}
 
-	pois, total, err := uc.poiRepo.GetPOIInBBox(ctx, req.SwLat, req.SwLon, req.NeLat, req.NeLon, req.Categories, req.Subcategories, req.Limit, req.Offset)
+	importance, err := domain.NewPOIImportanceFilter(req.MinImportance, req.OrderBy)
+	if err != nil {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"importance": err.Error(),
+		})
+	}
+
+	pois, total, err := uc.poiRepo.GetPOIInBBox(ctx, req.SwLat, req.SwLon, req.NeLat, req.NeLon, req.Categories, req.Subcategories, importance, req.Limit, req.Offset)
 	if err != nil {
 		uc.logger.Error("Failed to get POI in bbox", zap.Error(err))
 		return nil, err
@@ -193,9 +383,52 @@ func (uc *POIUseCase) GetPOIInBBox(ctx context.Context, req dto.BBoxPOIRequest)
 		Total:  total,
 		Limit:  req.Limit,
 		Offset: req.Offset,
+		Page:   utils.OffsetPagination(req.Limit, req.Offset, total),
 	}, nil
 }
 
+// maxActivityCenterBBoxSpanDeg - максимальная сторона bbox центра активности: на большей области
+// агрегат проходит по POI целого региона, а центр перестает описывать район
+const maxActivityCenterBBoxSpanDeg = 0.5
+
+// GetActivityCenter возвращает центр активности bbox - среднее координат POI (с весом по значимости
+// при req.Weighted) и число POI, по которым он посчитан
+func (uc *POIUseCase) GetActivityCenter(ctx context.Context, req dto.ActivityCenterRequest) (*dto.ActivityCenterResponse, error) {
+	if !utils.ValidateCoordinates(req.SwLat, req.SwLon) || !utils.ValidateCoordinates(req.NeLat, req.NeLon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+	if req.SwLat > req.NeLat || req.SwLon > req.NeLon {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"reason": "sw corner must be south-west of ne corner",
+		})
+	}
+	if req.NeLat-req.SwLat > maxActivityCenterBBoxSpanDeg || req.NeLon-req.SwLon > maxActivityCenterBBoxSpanDeg {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"bbox": fmt.Sprintf("each side must not exceed %.1f degrees", maxActivityCenterBBoxSpanDeg),
+		})
+	}
+	for _, cat := range req.Categories {
+		if !domain.IsValidPOICategory(cat) {
+			return nil, errors.New("INVALID_POI_CATEGORY", fmt.Sprintf("invalid category: %s", cat), 400)
+		}
+	}
+
+	bbox := domain.BoundingBox{MinLat: req.SwLat, MinLon: req.SwLon, MaxLat: req.NeLat, MaxLon: req.NeLon}
+	center, err := uc.poiRepo.GetActivityCenter(ctx, bbox, req.Categories, req.Weighted)
+	if err != nil {
+		uc.logger.Error("Failed to get activity center", zap.Error(err))
+		return nil, err
+	}
+
+	resp := &dto.ActivityCenterResponse{POICount: center.POICount, Weighted: req.Weighted}
+	if center.POICount > 0 {
+		lat := utils.RoundCoordinate(center.Lat)
+		lon := utils.RoundCoordinate(center.Lon)
+		resp.Lat, resp.Lon = &lat, &lon
+	}
+	return resp, nil
+}
+
 // GetPOIByBoundaryTile генерирует MVT тайл с POI внутри административной границы
 func (uc *POIUseCase) GetPOIByBoundaryTile(
 	ctx context.Context,
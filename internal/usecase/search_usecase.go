@@ -2,13 +2,20 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"go.uber.org/zap"
 
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/cachemode"
 	"github.com/location-microservice/internal/pkg/errors"
 	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/usecase/dto"
@@ -20,6 +27,8 @@ type SearchUseCase struct {
 	cacheRepo    repository.CacheRepository
 	logger       *zap.Logger
 	cacheTTL     time.Duration
+	geocodeCells *geocodeCellCache // кеш обратного геокодирования по ячейкам, nil - выключен
+	polygon      PolygonLimits
 }
 
 // NewSearchUseCase - создание нового SearchUseCase
@@ -28,12 +37,15 @@ func NewSearchUseCase(
 	cacheRepo repository.CacheRepository,
 	logger *zap.Logger,
 	cacheTTL time.Duration,
+	polygonLimits PolygonLimits,
 ) *SearchUseCase {
 	return &SearchUseCase{
 		boundaryRepo: boundaryRepo,
 		cacheRepo:    cacheRepo,
 		logger:       logger,
 		cacheTTL:     cacheTTL,
+		geocodeCells: newGeocodeCellCache(geocodeCellCacheSize, cacheTTL),
+		polygon:      polygonLimits.withDefaults(),
 	}
 }
 
@@ -44,18 +56,28 @@ func (uc *SearchUseCase) Search(ctx context.Context, req dto.SearchRequest) (*dt
 		req.Limit = 10
 	}
 
-	// Поиск границ
+	// Поиск границ; лишняя строка сверх limit показывает, есть ли еще совпадения
 	boundaries, err := uc.boundaryRepo.SearchByText(
 		ctx,
 		req.Query,
 		req.Language,
 		req.AdminLevels,
-		req.Limit,
+		req.Limit+1,
+		domain.BoundarySearchOptions{
+			MinPopulation: req.MinPopulation,
+			MinAreaSqKm:   req.MinAreaSqKm,
+			OrderBy:       domain.BoundarySearchOrderBy(req.OrderBy),
+			NameDisplay:   domain.BoundaryNameDisplay(req.NameDisplay),
+		},
 	)
 	if err != nil {
 		uc.logger.Error("Failed to search boundaries", zap.Error(err))
 		return nil, err
 	}
+	hasMore := len(boundaries) > req.Limit
+	if hasMore {
+		boundaries = boundaries[:req.Limit]
+	}
 
 	// Преобразование в response
 	results := make([]dto.SearchResult, 0, len(boundaries))
@@ -67,9 +89,268 @@ func (uc *SearchUseCase) Search(ctx context.Context, req dto.SearchRequest) (*dt
 	return &dto.SearchResponse{
 		Results: results,
 		Total:   len(results),
+		Page:    utils.LimitPagination(req.Limit, hasMore),
+	}, nil
+}
+
+// SearchWithinParent - поиск границ по названию внутри родительской границы (автодополнение в выбранном регионе)
+func (uc *SearchUseCase) SearchWithinParent(ctx context.Context, req dto.SearchWithinParentRequest) (*dto.SearchResponse, error) {
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	boundaries, err := uc.boundaryRepo.SearchWithinParent(
+		ctx,
+		req.Query,
+		req.ParentID,
+		req.AdminLevels,
+		req.Limit+1,
+	)
+	if err != nil {
+		uc.logger.Error("Failed to search boundaries within parent",
+			zap.Int64("parent_id", req.ParentID),
+			zap.Error(err))
+		return nil, err
+	}
+	hasMore := len(boundaries) > req.Limit
+	if hasMore {
+		boundaries = boundaries[:req.Limit]
+	}
+
+	results := make([]dto.SearchResult, 0, len(boundaries))
+	for _, b := range boundaries {
+		results = append(results, dto.ConvertSearchResult(b))
+	}
+
+	return &dto.SearchResponse{
+		Results: results,
+		Total:   len(results),
+		Page:    utils.LimitPagination(req.Limit, hasMore),
 	}, nil
 }
 
+// Лимиты выдачи GetNearestBoundaries
+const (
+	defaultNearestBoundariesLimit = 5
+	maxNearestBoundariesLimit     = 20
+)
+
+// GetNearestBoundaries возвращает ближайшие к точке границы уровня level, даже если точка лежит чуть
+// за пределами полигона (неточная координата у края города). limit <= 0 - 5 границ.
+func (uc *SearchUseCase) GetNearestBoundaries(ctx context.Context, lat, lon float64, level, limit int) ([]domain.NearestBoundary, error) {
+	if !utils.ValidateCoordinates(lat, lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+	if level < 2 || level > 11 {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"level": "must be between 2 and 11",
+		})
+	}
+	if limit <= 0 {
+		limit = defaultNearestBoundariesLimit
+	}
+	if limit > maxNearestBoundariesLimit {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"limit": maxNearestBoundariesLimit,
+		})
+	}
+	boundaries, err := uc.boundaryRepo.GetNearestBoundaries(ctx, lat, lon, level, limit)
+	if err != nil {
+		uc.logger.Error("Failed to get nearest boundaries",
+			zap.Float64("lat", lat),
+			zap.Float64("lon", lon),
+			zap.Int("level", level),
+			zap.Error(err))
+		return nil, err
+	}
+
+	for i := range boundaries {
+		b := &boundaries[i]
+		b.CenterLat, b.CenterLon = utils.RoundCoordinate(b.CenterLat), utils.RoundCoordinate(b.CenterLon)
+		b.Distance = math.Round(b.DistanceM*100) / 100
+		b.DistanceM = math.Round(b.DistanceM*10) / 10
+	}
+
+	return boundaries, nil
+}
+
+// GetNearestPlace возвращает ближайший к точке населенный пункт, отмеченный точкой place
+// (city/town/village/hamlet), для подписей вида "3 км от Sitges"
+func (uc *SearchUseCase) GetNearestPlace(ctx context.Context, lat, lon float64) (*domain.Place, error) {
+	if !utils.ValidateCoordinates(lat, lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+	place, err := uc.boundaryRepo.GetNearestPlace(ctx, lat, lon)
+	if err != nil {
+		uc.logger.Error("Failed to get nearest place",
+			zap.Float64("lat", lat),
+			zap.Float64("lon", lon),
+			zap.Error(err))
+		return nil, err
+	}
+	if place == nil {
+		return nil, errors.ErrLocationNotFound
+	}
+
+	place.Lat, place.Lon = utils.RoundCoordinate(place.Lat), utils.RoundCoordinate(place.Lon)
+	place.Distance = math.Round(place.DistanceM*100) / 100
+	place.DistanceM = math.Round(place.DistanceM*10) / 10
+
+	return place, nil
+}
+
+// GetBoundaryBBox возвращает bbox и центроид границы для подгонки карты к региону
+func (uc *SearchUseCase) GetBoundaryBBox(ctx context.Context, id int64) (*domain.BoundaryBBox, error) {
+	bbox, err := uc.boundaryRepo.GetBoundaryBBox(ctx, id)
+	if err != nil {
+		if err != errors.ErrLocationNotFound {
+			uc.logger.Error("Failed to get boundary bbox", zap.Int64("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	bbox.BBox = domain.BoundingBox{
+		MinLat: utils.RoundCoordinate(bbox.BBox.MinLat),
+		MinLon: utils.RoundCoordinate(bbox.BBox.MinLon),
+		MaxLat: utils.RoundCoordinate(bbox.BBox.MaxLat),
+		MaxLon: utils.RoundCoordinate(bbox.BBox.MaxLon),
+	}
+	bbox.CenterLat, bbox.CenterLon = utils.RoundCoordinate(bbox.CenterLat), utils.RoundCoordinate(bbox.CenterLon)
+
+	return bbox, nil
+}
+
+// GetBoundaryByID возвращает административную границу по ID
+func (uc *SearchUseCase) GetBoundaryByID(ctx context.Context, id int64) (*dto.BoundaryDetailsResponse, error) {
+	boundary, err := uc.boundaryRepo.GetByID(ctx, id)
+	if err != nil {
+		if err != errors.ErrLocationNotFound {
+			uc.logger.Error("Failed to get boundary by ID", zap.Int64("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &dto.BoundaryDetailsResponse{AdminBoundary: boundary}, nil
+}
+
+// Ограничения входного полигона GetBoundariesIntersectingPolygon по умолчанию
+const (
+	DefaultPolygonMaxAreaSqKm = 50000.0
+	DefaultPolygonMaxVertices = 10000
+)
+
+// PolygonLimits - ограничения входного полигона GetBoundariesIntersectingPolygon
+// (GEOCODE_POLYGON_MAX_AREA_SQ_KM, GEOCODE_POLYGON_MAX_VERTICES); 0 - значение по умолчанию
+type PolygonLimits struct {
+	MaxAreaSqKm float64
+	MaxVertices int
+}
+
+func (l PolygonLimits) withDefaults() PolygonLimits {
+	if l.MaxAreaSqKm <= 0 {
+		l.MaxAreaSqKm = DefaultPolygonMaxAreaSqKm
+	}
+	if l.MaxVertices <= 0 {
+		l.MaxVertices = DefaultPolygonMaxVertices
+	}
+	return l
+}
+
+// GetBoundariesIntersectingPolygon возвращает административные границы, которые пересекает полигон,
+// с площадью пересечения и долей входного полигона внутри каждой границы.
+// Полигоны больше PolygonLimits по площади или числу вершин отклоняются: пересечение считается
+// со всеми границами внутри, для полигона размером с континент это все страны и регионы.
+func (uc *SearchUseCase) GetBoundariesIntersectingPolygon(ctx context.Context, req dto.PolygonBoundariesRequest) ([]domain.BoundaryOverlap, error) {
+	if err := validatePolygonGeoJSON(req.Polygon, uc.polygon); err != nil {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"polygon": err.Error(),
+		})
+	}
+
+	overlaps, err := uc.boundaryRepo.GetBoundariesIntersectingPolygon(ctx, string(req.Polygon), req.AdminLevels)
+	if err != nil {
+		uc.logger.Error("Failed to get boundaries intersecting polygon", zap.Ints("levels", req.AdminLevels), zap.Error(err))
+		return nil, err
+	}
+
+	for i := range overlaps {
+		overlaps[i].AreaSqKm = math.Round(overlaps[i].AreaSqKm*1000) / 1000
+		overlaps[i].OverlapAreaSqKm = math.Round(overlaps[i].OverlapAreaSqKm*1000) / 1000
+		overlaps[i].OverlapPercent = math.Round(overlaps[i].OverlapPercent*100) / 100
+	}
+
+	return overlaps, nil
+}
+
+// validatePolygonGeoJSON проверяет, что геометрия - Polygon или MultiPolygon с замкнутыми кольцами
+// и корректными координатами в пределах limits. Самопересечения исправляются в БД через ST_MakeValid.
+func validatePolygonGeoJSON(raw json.RawMessage, limits PolygonLimits) error {
+	var geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(raw, &geometry); err != nil {
+		return fmt.Errorf("invalid GeoJSON: %v", err)
+	}
+
+	var polygons [][][][]float64
+	switch geometry.Type {
+	case "Polygon":
+		var polygon [][][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &polygon); err != nil {
+			return fmt.Errorf("invalid Polygon coordinates: %v", err)
+		}
+		polygons = [][][][]float64{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(geometry.Coordinates, &polygons); err != nil {
+			return fmt.Errorf("invalid MultiPolygon coordinates: %v", err)
+		}
+	default:
+		return fmt.Errorf("geometry type must be Polygon or MultiPolygon, got %q", geometry.Type)
+	}
+	if len(polygons) == 0 {
+		return fmt.Errorf("geometry has no polygons")
+	}
+
+	vertices := 0
+	areaSqKm := 0.0
+	for _, polygon := range polygons {
+		if len(polygon) == 0 {
+			return fmt.Errorf("polygon has no rings")
+		}
+		for i, ring := range polygon {
+			if len(ring) < 4 {
+				return fmt.Errorf("ring must have at least 4 positions")
+			}
+			for _, pos := range ring {
+				if len(pos) < 2 || !utils.ValidateCoordinates(pos[1], pos[0]) {
+					return fmt.Errorf("invalid position %v", pos)
+				}
+			}
+			first, last := ring[0], ring[len(ring)-1]
+			if first[0] != last[0] || first[1] != last[1] {
+				return fmt.Errorf("ring is not closed")
+			}
+			vertices += len(ring)
+
+			// Первое кольцо - внешнее, остальные - дыры
+			if i == 0 {
+				areaSqKm += utils.RingAreaSqKm(ring)
+			} else {
+				areaSqKm -= utils.RingAreaSqKm(ring)
+			}
+		}
+	}
+	if vertices > limits.MaxVertices {
+		return fmt.Errorf("polygon has %d vertices, at most %d allowed", vertices, limits.MaxVertices)
+	}
+	if areaSqKm > limits.MaxAreaSqKm {
+		return fmt.Errorf("polygon area is %.0f km², at most %.0f km² allowed", areaSqKm, limits.MaxAreaSqKm)
+	}
+
+	return nil
+}
+
 // ReverseGeocode - обратное геокодирование координат
 func (uc *SearchUseCase) ReverseGeocode(ctx context.Context, req dto.ReverseGeocodeRequest) (*dto.ReverseGeocodeResponse, error) {
 	// Валидация координат
@@ -77,6 +358,15 @@ func (uc *SearchUseCase) ReverseGeocode(ctx context.Context, req dto.ReverseGeoc
 		return nil, errors.ErrInvalidCoordinates
 	}
 
+	// Точка внутри уже найденной ячейки - адрес без запроса к БД
+	if cachemode.CanRead(ctx) {
+		if addr, ok := uc.geocodeCells.Get(req.Lat, req.Lon); ok {
+			return &dto.ReverseGeocodeResponse{
+				Address: *addr,
+			}, nil
+		}
+	}
+
 	// Получение адреса
 	addr, err := uc.boundaryRepo.ReverseGeocode(ctx, req.Lat, req.Lon)
 	if err != nil {
@@ -84,16 +374,157 @@ func (uc *SearchUseCase) ReverseGeocode(ctx context.Context, req dto.ReverseGeoc
 		return nil, err
 	}
 
+	uc.cacheGeocodeCell(ctx, req.Lat, req.Lon, addr)
+
 	return &dto.ReverseGeocodeResponse{
 		Address: *addr,
 	}, nil
 }
 
+// cacheGeocodeCell запоминает адрес за ячейкой точки. Ячейка ищется только для адреса, который
+// промахнулся повторно, а адрес без ячейки запоминается, чтобы не искать ее на каждом промахе.
+// Ошибка поиска ячейки не влияет на ответ: следующая точка из той же области просто снова пойдет в БД.
+func (uc *SearchUseCase) cacheGeocodeCell(ctx context.Context, lat, lon float64, addr *domain.Address) {
+	if uc.geocodeCells == nil || !cachemode.CanWrite(ctx) || !uc.geocodeCells.RecordMiss(addr) {
+		return
+	}
+	cell, err := uc.boundaryRepo.GetGeocodeCell(ctx, lat, lon)
+	if err != nil {
+		uc.logger.Warn("Failed to get geocode cell", zap.Error(err))
+		return
+	}
+	if cell == nil {
+		uc.geocodeCells.PutNoCell(addr)
+		return
+	}
+	uc.geocodeCells.Put(cell, addr)
+}
+
+// FlushGeocodeCells очищает in-process кеш обратного геокодирования по ячейкам
+// и возвращает число удаленных ячеек
+func (uc *SearchUseCase) FlushGeocodeCells() int {
+	return uc.geocodeCells.Flush()
+}
+
+const (
+	// defaultBreadcrumbSeparator - разделитель уровней в строке breadcrumb
+	defaultBreadcrumbSeparator = " › "
+	// maxBreadcrumbSeparatorLen - максимальная длина разделителя в символах
+	maxBreadcrumbSeparatorLen = 8
+)
+
+// GetBreadcrumb возвращает административный путь точки от страны к самому детальному уровню
+// и строку из названий на языке lang (без перевода - основное название), соединенных sep.
+// На уровень берется одна граница (domain.PickBoundaryPerLevel), безымянные уровни пропускаются,
+// а из подряд идущих одинаковых названий ("Madrid › Madrid") остается самый детальный уровень.
+func (uc *SearchUseCase) GetBreadcrumb(ctx context.Context, lat, lon float64, lang, sep string) (*dto.BreadcrumbResponse, error) {
+	if !utils.ValidateCoordinates(lat, lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+	if sep == "" {
+		sep = defaultBreadcrumbSeparator
+	}
+	if utf8.RuneCountInString(sep) > maxBreadcrumbSeparatorLen {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"sep": fmt.Sprintf("must be at most %d characters", maxBreadcrumbSeparatorLen),
+		})
+	}
+
+	boundaries, err := uc.boundaryRepo.GetByPoint(ctx, lat, lon)
+	if err != nil {
+		uc.logger.Error("Failed to get boundaries for breadcrumb", zap.Error(err))
+		return nil, err
+	}
+
+	boundaries, _ = domain.PickBoundaryPerLevel(boundaries)
+	sort.SliceStable(boundaries, func(i, j int) bool { return boundaries[i].AdminLevel < boundaries[j].AdminLevel })
+
+	items := make([]dto.BreadcrumbItem, 0, len(boundaries))
+	for _, b := range boundaries {
+		name := b.LocalizedName(lang)
+		if name == "" {
+			continue
+		}
+		item := dto.BreadcrumbItem{Level: b.AdminLevel, Name: name, ID: b.ID}
+		if n := len(items); n > 0 && items[n-1].Name == name {
+			items[n-1] = item
+			continue
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return nil, errors.ErrLocationNotFound
+	}
+
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+
+	return &dto.BreadcrumbResponse{
+		Items: items,
+		Text:  strings.Join(names, sep),
+	}, nil
+}
+
+// Параметры расчета достоверности обратного геокодирования
+const (
+	// confidenceExpectedLevels - число уровней (2, 4, 6, 8, 9, 10), при котором иерархия считается полной
+	confidenceExpectedLevels = 6
+	// confidenceEdgeScaleMeters - масштаб расстояния до края полигона: на этом расстоянии вклад ~63%
+	confidenceEdgeScaleMeters = 200.0
+	confidenceLevelsWeight    = 0.6
+	confidenceEdgeWeight      = 0.4
+)
+
+// ReverseGeocodeWithConfidence - обратное геокодирование с оценкой достоверности.
+// Достоверность растет с количеством совпавших уровней иерархии и с удаленностью точки
+// от края самого детального содержащего полигона
+func (uc *SearchUseCase) ReverseGeocodeWithConfidence(
+	ctx context.Context,
+	req dto.ReverseGeocodeRequest,
+) (*dto.ReverseGeocodeConfidenceResponse, error) {
+	if !utils.ValidateCoordinates(req.Lat, req.Lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+
+	match, err := uc.boundaryRepo.ReverseGeocodeWithDepth(ctx, req.Lat, req.Lon)
+	if err != nil {
+		uc.logger.Error("Failed to reverse geocode with confidence", zap.Error(err))
+		return nil, err
+	}
+
+	return &dto.ReverseGeocodeConfidenceResponse{
+		Address:            match.Address,
+		Confidence:         geocodeConfidence(match.MatchedLevels, match.EdgeDistanceMeters),
+		MatchedLevels:      match.MatchedLevels,
+		SmallestAdminLevel: match.SmallestAdminLevel,
+		EdgeDistanceMeters: math.Round(match.EdgeDistanceMeters*10) / 10,
+	}, nil
+}
+
+// geocodeConfidence рассчитывает достоверность в диапазоне [0, 1]
+func geocodeConfidence(matchedLevels int, edgeDistanceMeters float64) float64 {
+	if matchedLevels <= 0 {
+		return 0
+	}
+
+	levelsScore := math.Min(float64(matchedLevels)/confidenceExpectedLevels, 1)
+	edgeScore := 1 - math.Exp(-math.Max(edgeDistanceMeters, 0)/confidenceEdgeScaleMeters)
+
+	confidence := confidenceLevelsWeight*levelsScore + confidenceEdgeWeight*edgeScore
+	return math.Round(confidence*1000) / 1000
+}
+
 // BatchReverseGeocode - пакетное обратное геокодирование
 func (uc *SearchUseCase) BatchReverseGeocode(
 	ctx context.Context,
 	req dto.BatchReverseGeocodeRequest,
 ) (*dto.BatchReverseGeocodeResponse, error) {
+	if err := validateBatchSize(len(req.Points)); err != nil {
+		return nil, err
+	}
+
 	addresses := make([]domain.Address, len(req.Points))
 
 	for i, point := range req.Points {
@@ -119,6 +550,54 @@ func (uc *SearchUseCase) BatchReverseGeocode(
 	}, nil
 }
 
+// reverseGeocodeStreamChunk - размер под-пакета потокового обратного геокодирования
+const reverseGeocodeStreamChunk = 200
+
+// StreamReverseGeocode геокодирует точки под-пакетами и передает результат каждой точки в emit
+// по мере готовности под-пакета. Ошибка БД помечает строки под-пакета и не прерывает поток,
+// ошибка emit (клиент отключился) или отмена ctx прерывают обработку.
+func (uc *SearchUseCase) StreamReverseGeocode(
+	ctx context.Context,
+	points []dto.Point,
+	emit func(dto.ReverseGeocodeStreamItem) error,
+) error {
+	for start := 0; start < len(points); start += reverseGeocodeStreamChunk {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := min(start+reverseGeocodeStreamChunk, len(points))
+		chunk := make([]domain.LatLon, 0, end-start)
+		for _, p := range points[start:end] {
+			chunk = append(chunk, domain.LatLon{Lat: p.Lat, Lon: p.Lon})
+		}
+
+		addresses, err := uc.boundaryRepo.ReverseGeocodeBatch(ctx, chunk)
+		if err != nil {
+			uc.logger.Warn("Failed to geocode stream chunk",
+				zap.Int("offset", start),
+				zap.Int("size", len(chunk)),
+				zap.Error(err))
+		}
+
+		for i, p := range chunk {
+			item := dto.ReverseGeocodeStreamItem{Index: start + i, Lat: p.Lat, Lon: p.Lon}
+			switch {
+			case err != nil:
+				item.Error = "reverse geocoding failed"
+			case i < len(addresses):
+				item.Address = addresses[i]
+			}
+
+			if err := emit(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // DetectLocationBatch обогащает пачку локаций эффективно (2 параллельных запроса в БД)
 // Логика:
 // 1. Делим локации на 2 группы: visible (есть координаты) и name-based (нет координат или не visible)
@@ -134,6 +613,9 @@ func (uc *SearchUseCase) DetectLocationBatch(
 	if len(req.Locations) == 0 {
 		return nil, errors.ErrInvalidRequest
 	}
+	if err := validateBatchSize(len(req.Locations)); err != nil {
+		return nil, err
+	}
 
 	uc.logger.Info("DetectLocationBatch started",
 		zap.Int("total_locations", len(req.Locations)))
@@ -165,12 +647,14 @@ func (uc *SearchUseCase) DetectLocationBatch(
 		nameBasedResults []domain.BoundarySearchResult
 		visibleErr       error
 		nameBasedErr     error
+		queries          int // запросов к БД (по одному на под-пакет)
 	}
 
 	resultChan := make(chan batchResult, 1)
 
 	go func() {
 		var wg sync.WaitGroup
+		var queriesMu sync.Mutex
 		var result batchResult
 
 		// Горутина для visible локаций (reverse geocoding по координатам)
@@ -184,11 +668,21 @@ func (uc *SearchUseCase) DetectLocationBatch(
 					points[i] = domain.LatLon{Lat: *loc.Latitude, Lon: *loc.Longitude}
 				}
 
-				boundariesByPoint, err := uc.boundaryRepo.GetByPointBatch(ctx, points)
-				if err != nil {
-					uc.logger.Error("GetByPointBatch failed", zap.Error(err))
-					result.visibleErr = err
-					return
+				// Под-пакеты выполняются последовательно, ключи результата сдвигаются на начало под-пакета
+				boundariesByPoint := make(map[int][]*domain.AdminBoundary, len(points))
+				for _, chunk := range batchChunks(len(points)) {
+					chunkResults, err := uc.boundaryRepo.GetByPointBatch(ctx, points[chunk[0]:chunk[1]])
+					queriesMu.Lock()
+					result.queries++
+					queriesMu.Unlock()
+					if err != nil {
+						uc.logger.Error("GetByPointBatch failed", zap.Int("chunk_start", chunk[0]), zap.Error(err))
+						result.visibleErr = err
+						return
+					}
+					for i, boundaries := range chunkResults {
+						boundariesByPoint[chunk[0]+i] = boundaries
+					}
 				}
 				result.visibleResults = boundariesByPoint
 			}()
@@ -249,15 +743,21 @@ func (uc *SearchUseCase) DetectLocationBatch(
 					})
 				}
 
-				if len(searchRequests) > 0 {
-					results, err := uc.boundaryRepo.SearchByTextBatch(ctx, searchRequests)
+				// Запросы несут собственные Index, поэтому результаты под-пакетов просто объединяются
+				var results []domain.BoundarySearchResult
+				for _, chunk := range batchChunks(len(searchRequests)) {
+					chunkResults, err := uc.boundaryRepo.SearchByTextBatch(ctx, searchRequests[chunk[0]:chunk[1]])
+					queriesMu.Lock()
+					result.queries++
+					queriesMu.Unlock()
 					if err != nil {
-						uc.logger.Error("SearchByTextBatch failed", zap.Error(err))
+						uc.logger.Error("SearchByTextBatch failed", zap.Int("chunk_start", chunk[0]), zap.Error(err))
 						result.nameBasedErr = err
 						return
 					}
-					result.nameBasedResults = results
+					results = append(results, chunkResults...)
 				}
+				result.nameBasedResults = results
 			}()
 		}
 
@@ -285,14 +785,7 @@ func (uc *SearchUseCase) DetectLocationBatch(
 
 	successCount := 0
 	errorCount := 0
-	dbQueriesCount := 0
-
-	if len(visibleLocations) > 0 {
-		dbQueriesCount++
-	}
-	if len(nameBasedLocations) > 0 {
-		dbQueriesCount++
-	}
+	dbQueriesCount := batchRes.queries
 
 	// Обрабатываем visible локации (reverse geocoding)
 	if batchRes.visibleErr != nil {
@@ -411,43 +904,13 @@ func (uc *SearchUseCase) boundariesToEnrichedLocation(boundaries []*domain.Admin
 	result := &dto.EnrichedLocationDTO{}
 
 	for _, b := range boundaries {
+		if enrichedLevelsExcluded[b.AdminLevel] {
+			continue
+		}
 		info := &dto.BoundaryInfoDTO{
 			ID:             b.ID,
 			Name:           b.Name,
-			TranslateNames: make(map[string]string),
-		}
-
-		// Собираем переводы
-		if b.NameEn != "" {
-			info.TranslateNames["en"] = b.NameEn
-		}
-		if b.NameEs != "" {
-			info.TranslateNames["es"] = b.NameEs
-		}
-		if b.NameCa != "" {
-			info.TranslateNames["ca"] = b.NameCa
-		}
-		if b.NameRu != "" {
-			info.TranslateNames["ru"] = b.NameRu
-		}
-		if b.NameUk != "" {
-			info.TranslateNames["uk"] = b.NameUk
-		}
-		if b.NameFr != "" {
-			info.TranslateNames["fr"] = b.NameFr
-		}
-		if b.NamePt != "" {
-			info.TranslateNames["pt"] = b.NamePt
-		}
-		if b.NameIt != "" {
-			info.TranslateNames["it"] = b.NameIt
-		}
-		if b.NameDe != "" {
-			info.TranslateNames["de"] = b.NameDe
-		}
-
-		if len(info.TranslateNames) == 0 {
-			info.TranslateNames = nil
+			TranslateNames: b.Translations(), // языки из NAME_LANGUAGES, без переводов - nil
 		}
 
 		switch b.AdminLevel {
@@ -457,11 +920,15 @@ func (uc *SearchUseCase) boundariesToEnrichedLocation(boundaries []*domain.Admin
 			result.Region = info
 		case 6:
 			result.Province = info
+		case 7:
+			result.Subprovince = info
 		case 8:
 			result.City = info
 		case 9:
 			result.District = info
 		case 10:
+			result.Subdistrict = info
+		case 11:
 			result.Neighborhood = info
 		}
 	}
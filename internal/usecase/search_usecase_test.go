@@ -2,7 +2,9 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,6 +13,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/cachemode"
+	pkgerrors "github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/usecase"
 	"github.com/location-microservice/internal/usecase/dto"
 )
@@ -38,6 +43,11 @@ func (m *MockCacheRepository) Delete(ctx context.Context, key string) error {
 	return args.Error(0)
 }
 
+func (m *MockCacheRepository) DeleteByPattern(ctx context.Context, pattern string, match func(key string) bool) (int, error) {
+	args := m.Called(ctx, pattern, match)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockCacheRepository) Exists(ctx context.Context, key string) (bool, error) {
 	args := m.Called(ctx, key)
 	return args.Bool(0), args.Error(1)
@@ -75,7 +85,7 @@ func TestSearchUseCase_DetectLocationBatch(t *testing.T) {
 	mockCache := &MockCacheRepository{}
 	ctx := context.Background()
 
-	uc := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour)
+	uc := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour, usecase.PolygonLimits{})
 
 	t.Run("success with mixed visible and name-based locations", func(t *testing.T) {
 		// Location 0: visible (has coordinates) - will use GetByPointBatch
@@ -174,7 +184,7 @@ func TestSearchUseCase_DetectLocationBatch_VisibleLocations(t *testing.T) {
 	mockCache := &MockCacheRepository{}
 	ctx := context.Background()
 
-	uc := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour)
+	uc := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour, usecase.PolygonLimits{})
 
 	t.Run("success with only visible locations", func(t *testing.T) {
 		locations := []dto.LocationInput{
@@ -230,7 +240,7 @@ func TestSearchUseCase_DetectLocationBatch_VisibleLocations(t *testing.T) {
 	t.Run("visible location with no boundaries found", func(t *testing.T) {
 		mockBoundary2 := &MockBoundaryRepository{}
 		mockCache2 := &MockCacheRepository{}
-		uc2 := usecase.NewSearchUseCase(mockBoundary2, mockCache2, logger, 1*time.Hour)
+		uc2 := usecase.NewSearchUseCase(mockBoundary2, mockCache2, logger, 1*time.Hour, usecase.PolygonLimits{})
 
 		locations := []dto.LocationInput{
 			{
@@ -272,7 +282,7 @@ func TestSearchUseCase_DetectLocationBatch_NameBasedLocations(t *testing.T) {
 	mockCache := &MockCacheRepository{}
 	ctx := context.Background()
 
-	uc := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour)
+	uc := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour, usecase.PolygonLimits{})
 
 	t.Run("success with only name-based locations", func(t *testing.T) {
 		locations := []dto.LocationInput{
@@ -332,7 +342,7 @@ func TestSearchUseCase_DetectLocationBatch_NameBasedLocations(t *testing.T) {
 	t.Run("name-based location with no results", func(t *testing.T) {
 		mockBoundary2 := &MockBoundaryRepository{}
 		mockCache2 := &MockCacheRepository{}
-		uc2 := usecase.NewSearchUseCase(mockBoundary2, mockCache2, logger, 1*time.Hour)
+		uc2 := usecase.NewSearchUseCase(mockBoundary2, mockCache2, logger, 1*time.Hour, usecase.PolygonLimits{})
 
 		locations := []dto.LocationInput{
 			{
@@ -370,7 +380,7 @@ func TestSearchUseCase_DetectLocationBatch_Errors(t *testing.T) {
 	mockCache := &MockCacheRepository{}
 	ctx := context.Background()
 
-	uc := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour)
+	uc := usecase.NewSearchUseCase(mockBoundary, mockCache, logger, 1*time.Hour, usecase.PolygonLimits{})
 
 	t.Run("GetByPointBatch error", func(t *testing.T) {
 		locations := []dto.LocationInput{
@@ -403,7 +413,7 @@ func TestSearchUseCase_DetectLocationBatch_Errors(t *testing.T) {
 	t.Run("SearchByTextBatch error", func(t *testing.T) {
 		mockBoundary2 := &MockBoundaryRepository{}
 		mockCache2 := &MockCacheRepository{}
-		uc2 := usecase.NewSearchUseCase(mockBoundary2, mockCache2, logger, 1*time.Hour)
+		uc2 := usecase.NewSearchUseCase(mockBoundary2, mockCache2, logger, 1*time.Hour, usecase.PolygonLimits{})
 
 		locations := []dto.LocationInput{
 			{
@@ -429,3 +439,829 @@ func TestSearchUseCase_DetectLocationBatch_Errors(t *testing.T) {
 		mockBoundary2.AssertExpectations(t)
 	})
 }
+
+func TestSearchUseCase_ReverseGeocodeWithConfidence(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("deep match far from edge has higher confidence than shallow match near edge", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("ReverseGeocodeWithDepth", ctx, 41.3851, 2.1734).Return(&domain.ReverseGeocodeMatch{
+			Address:            domain.Address{Country: "Spain", City: "Barcelona"},
+			MatchedLevels:      6,
+			SmallestAdminLevel: 10,
+			EdgeDistanceMeters: 800,
+		}, nil)
+		mockBoundary.On("ReverseGeocodeWithDepth", ctx, 41.0, 1.0).Return(&domain.ReverseGeocodeMatch{
+			Address:            domain.Address{Country: "Spain"},
+			MatchedLevels:      1,
+			SmallestAdminLevel: 2,
+			EdgeDistanceMeters: 5,
+		}, nil)
+
+		deep, err := uc.ReverseGeocodeWithConfidence(ctx, dto.ReverseGeocodeRequest{Lat: 41.3851, Lon: 2.1734})
+		assert.NoError(t, err)
+		shallow, err := uc.ReverseGeocodeWithConfidence(ctx, dto.ReverseGeocodeRequest{Lat: 41.0, Lon: 1.0})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 6, deep.MatchedLevels)
+		assert.Equal(t, "Barcelona", deep.Address.City)
+		assert.Greater(t, deep.Confidence, 0.9)
+		assert.LessOrEqual(t, deep.Confidence, 1.0)
+		assert.Less(t, shallow.Confidence, 0.2)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		_, err := uc.ReverseGeocodeWithConfidence(ctx, dto.ReverseGeocodeRequest{Lat: 95, Lon: 2})
+		assert.Error(t, err)
+		mockBoundary.AssertNotCalled(t, "ReverseGeocodeWithDepth")
+	})
+
+	t.Run("repository error is returned", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("ReverseGeocodeWithDepth", ctx, 0.0, 0.5).Return(nil, errors.New("not found"))
+
+		_, err := uc.ReverseGeocodeWithConfidence(ctx, dto.ReverseGeocodeRequest{Lat: 0.0, Lon: 0.5})
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchUseCase_GetBreadcrumb(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	lat, lon := 41.3917, 2.1649
+
+	mockBoundary := &MockBoundaryRepository{}
+	uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+	catalonia := &domain.AdminBoundary{ID: 4, Name: "Catalunya", AdminLevel: 4}
+	catalonia.SetNames(map[string]string{"en": "Catalonia"})
+	mockBoundary.On("GetByPoint", ctx, lat, lon).Return([]*domain.AdminBoundary{
+		{ID: 10, Name: "la Dreta de l'Eixample", AdminLevel: 10},
+		{ID: 2, Name: "España", AdminLevel: 2},
+		catalonia,
+		{ID: 6, Name: "Barcelona", AdminLevel: 6},
+		{ID: 7, Name: "", AdminLevel: 7},
+		{ID: 8, Name: "Barcelona", AdminLevel: 8},
+	}, nil)
+
+	t.Run("ordered from country with localized names", func(t *testing.T) {
+		result, err := uc.GetBreadcrumb(ctx, lat, lon, "en", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "España › Catalonia › Barcelona › la Dreta de l'Eixample", result.Text)
+		assert.Equal(t, []dto.BreadcrumbItem{
+			{Level: 2, Name: "España", ID: 2},
+			{Level: 4, Name: "Catalonia", ID: 4},
+			{Level: 8, Name: "Barcelona", ID: 8},
+			{Level: 10, Name: "la Dreta de l'Eixample", ID: 10},
+		}, result.Items)
+	})
+
+	t.Run("custom separator", func(t *testing.T) {
+		result, err := uc.GetBreadcrumb(ctx, lat, lon, "", " / ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "España / Catalunya / Barcelona / la Dreta de l'Eixample", result.Text)
+	})
+
+	t.Run("too long separator", func(t *testing.T) {
+		_, err := uc.GetBreadcrumb(ctx, lat, lon, "", " ---------> ")
+		assert.Error(t, err)
+	})
+
+	t.Run("nothing found", func(t *testing.T) {
+		mockBoundary.On("GetByPoint", ctx, 0.0, 0.5).Return([]*domain.AdminBoundary{}, nil)
+
+		_, err := uc.GetBreadcrumb(ctx, 0.0, 0.5, "", "")
+		assert.ErrorIs(t, err, pkgerrors.ErrLocationNotFound)
+	})
+}
+
+func TestSearchUseCase_GetBoundaryByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, zap.NewNop(), 1*time.Hour, usecase.PolygonLimits{})
+		mockBoundary.On("GetByID", ctx, int64(-349035)).
+			Return(&domain.AdminBoundary{ID: -349035, Name: "Barcelona", AdminLevel: 8}, nil)
+
+		result, err := uc.GetBoundaryByID(ctx, -349035)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Barcelona", result.Name)
+		assert.Nil(t, result.EditMeta)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, zap.NewNop(), 1*time.Hour, usecase.PolygonLimits{})
+		mockBoundary.On("GetByID", ctx, int64(1)).Return(nil, pkgerrors.ErrLocationNotFound)
+
+		_, err := uc.GetBoundaryByID(ctx, 1)
+
+		assert.ErrorIs(t, err, pkgerrors.ErrLocationNotFound)
+	})
+}
+
+func TestSearchUseCase_Search_HasMoreAtMaxLimit(t *testing.T) {
+	ctx := context.Background()
+	mockBoundary := &MockBoundaryRepository{}
+	uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, zap.NewNop(), 1*time.Hour, usecase.PolygonLimits{})
+
+	// limit 100 - максимум запроса и лимит репозитория; строка сверх него репозиторием не обрезается
+	boundaries := make([]*domain.AdminBoundary, 101)
+	for i := range boundaries {
+		boundaries[i] = &domain.AdminBoundary{ID: int64(-i - 1), Name: "San", AdminLevel: 8}
+	}
+	mockBoundary.On("SearchByText", ctx, "San", "", []int(nil), 101, domain.BoundarySearchOptions{}).
+		Return(boundaries, nil)
+
+	result, err := uc.Search(ctx, dto.SearchRequest{Query: "San", Limit: 100})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100, result.Total)
+	assert.True(t, result.Page.HasMore)
+	mockBoundary.AssertExpectations(t)
+}
+
+func TestSearchUseCase_Search_PopulationFilters(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	population := 1600000
+
+	mockBoundary := &MockBoundaryRepository{}
+	uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+	opts := domain.BoundarySearchOptions{
+		MinPopulation: 10000,
+		MinAreaSqKm:   5,
+		OrderBy:       domain.BoundarySearchOrderByPopulation,
+	}
+	mockBoundary.On("SearchByText", ctx, "Barc", "en", []int(nil), 11, opts).
+		Return([]*domain.AdminBoundary{
+			{ID: -347950, Name: "Barcelona", AdminLevel: 8, Population: &population},
+		}, nil)
+
+	result, err := uc.Search(ctx, dto.SearchRequest{
+		Query:         "Barc",
+		Language:      "en",
+		MinPopulation: 10000,
+		MinAreaSqKm:   5,
+		OrderBy:       "population",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+	assert.Equal(t, &population, result.Results[0].Population)
+	mockBoundary.AssertExpectations(t)
+}
+
+func TestSearchUseCase_Search_NameDisplay(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	mockBoundary := &MockBoundaryRepository{}
+	uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+	opts := domain.BoundarySearchOptions{NameDisplay: domain.BoundaryNameDisplayMatched}
+	mockBoundary.On("SearchByText", ctx, "Londres", "es", []int(nil), 11, opts).
+		Return([]*domain.AdminBoundary{
+			{ID: -65606, Name: "Londres", AdminLevel: 6, OriginalName: "London", MatchedField: "name:es"},
+		}, nil)
+
+	result, err := uc.Search(ctx, dto.SearchRequest{
+		Query:       "Londres",
+		Language:    "es",
+		NameDisplay: "matched",
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Results, 1) {
+		assert.Equal(t, "Londres", result.Results[0].Name)
+		assert.Equal(t, "London", result.Results[0].OriginalName)
+		assert.Equal(t, "name:es", result.Results[0].MatchedField)
+	}
+	mockBoundary.AssertExpectations(t)
+}
+
+func TestSearchUseCase_SearchWithinParent(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("default limit and results converted", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("SearchWithinParent", ctx, "San", int64(-349053), []int{8}, 11).
+			Return([]*domain.AdminBoundary{
+				{ID: -2000, Name: "Sant Cugat del Vallès", AdminLevel: 8},
+			}, nil)
+
+		result, err := uc.SearchWithinParent(ctx, dto.SearchWithinParentRequest{
+			Query:       "San",
+			ParentID:    -349053,
+			AdminLevels: []int{8},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Total)
+		assert.Equal(t, "-2000", result.Results[0].ID)
+		assert.False(t, result.Page.HasMore)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("extra row trimmed and reported as has_more", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("SearchWithinParent", ctx, "San", int64(-349053), []int(nil), 3).
+			Return([]*domain.AdminBoundary{
+				{ID: -1, Name: "Sant Adrià"},
+				{ID: -2, Name: "Sant Boi"},
+				{ID: -3, Name: "Sant Cugat"},
+			}, nil)
+
+		result, err := uc.SearchWithinParent(ctx, dto.SearchWithinParentRequest{Query: "San", ParentID: -349053, Limit: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, "-2", result.Results[1].ID)
+		assert.Equal(t, utils.LimitPagination(2, true), result.Page)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("repository error is returned", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("SearchWithinParent", ctx, "San", int64(1), []int(nil), 6).
+			Return(nil, errors.New("not found"))
+
+		_, err := uc.SearchWithinParent(ctx, dto.SearchWithinParentRequest{Query: "San", ParentID: 1, Limit: 5})
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchUseCase_GetBoundaryBBox(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("coordinates are rounded", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("GetBoundaryBBox", ctx, int64(-347950)).Return(&domain.BoundaryBBox{
+			ID:        -347950,
+			BBox:      domain.BoundingBox{MinLat: 41.320004123, MinLon: 2.0524327891, MaxLat: 41.4695761234, MaxLon: 2.2280099876},
+			CenterLat: 41.39797654321,
+			CenterLon: 2.14908812345,
+		}, nil)
+
+		result, err := uc.GetBoundaryBBox(ctx, -347950)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.BoundingBox{MinLat: 41.320004, MinLon: 2.052433, MaxLat: 41.469576, MaxLon: 2.22801}, result.BBox)
+		assert.Equal(t, 41.397977, result.CenterLat)
+		assert.Equal(t, 2.149088, result.CenterLon)
+	})
+
+	t.Run("not found is passed through", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("GetBoundaryBBox", ctx, int64(1)).Return(nil, pkgerrors.ErrLocationNotFound)
+
+		_, err := uc.GetBoundaryBBox(ctx, 1)
+		assert.ErrorIs(t, err, pkgerrors.ErrLocationNotFound)
+	})
+}
+
+func TestSearchUseCase_GetNearestBoundaries(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("default limit and rounding", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("GetNearestBoundaries", ctx, 41.45, 2.25, 8, 5).Return([]domain.NearestBoundary{
+			{AdminBoundary: domain.AdminBoundary{ID: 1, Name: "Badalona", AdminLevel: 8, CenterLat: 41.4500012345, CenterLon: 2.2474987654}, DistanceM: 0, Contains: true},
+			{AdminBoundary: domain.AdminBoundary{ID: 2, Name: "Sant Adrià de Besòs", AdminLevel: 8}, DistanceM: 312.4567},
+		}, nil)
+
+		result, err := uc.GetNearestBoundaries(ctx, 41.45, 2.25, 8, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.True(t, result[0].Contains)
+		assert.Equal(t, 41.450001, result[0].CenterLat)
+		assert.Equal(t, 312.5, result[1].DistanceM)
+		assert.Equal(t, 312.46, result[1].Distance)
+
+		dto.ConvertNearestBoundaryDistances(result, utils.DistanceUnitKilometers)
+		assert.Equal(t, 0.312, result[1].Distance)
+		assert.Equal(t, 312.5, result[1].DistanceM)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		_, err := uc.GetNearestBoundaries(ctx, 41.45, 2.25, 1, 5)
+		assert.ErrorIs(t, err, pkgerrors.ErrInvalidRequest)
+
+		_, err = uc.GetNearestBoundaries(ctx, 41.45, 2.25, 8, 21)
+		assert.ErrorIs(t, err, pkgerrors.ErrInvalidRequest)
+
+		_, err = uc.GetNearestBoundaries(ctx, 95, 2.25, 8, 5)
+		assert.ErrorIs(t, err, pkgerrors.ErrInvalidCoordinates)
+
+		mockBoundary.AssertNotCalled(t, "GetNearestBoundaries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSearchUseCase_GetNearestPlace(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("rounds coordinates and distance", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		population := 29000
+		mockBoundary.On("GetNearestPlace", ctx, 41.225, 1.78).Return(&domain.Place{
+			ID: 1, Name: "Sitges", Type: "town", Population: &population,
+			Lat: 41.2350012345, Lon: 1.8114987654, DistanceM: 2876.5432,
+		}, nil)
+
+		place, err := uc.GetNearestPlace(ctx, 41.225, 1.78)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Sitges", place.Name)
+		assert.Equal(t, "town", place.Type)
+		assert.Equal(t, 41.235001, place.Lat)
+		assert.Equal(t, 2876.5, place.DistanceM)
+		assert.Equal(t, 2876.54, place.Distance)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("no place found", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("GetNearestPlace", ctx, 41.225, 1.78).Return(nil, nil)
+
+		_, err := uc.GetNearestPlace(ctx, 41.225, 1.78)
+		assert.ErrorIs(t, err, pkgerrors.ErrLocationNotFound)
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		_, err := uc.GetNearestPlace(ctx, 95, 1.78)
+		assert.ErrorIs(t, err, pkgerrors.ErrInvalidCoordinates)
+		mockBoundary.AssertNotCalled(t, "GetNearestPlace", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSearchUseCase_GetBoundariesIntersectingPolygon(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	square := `{"type":"Polygon","coordinates":[[[2.10,41.35],[2.20,41.35],[2.20,41.45],[2.10,41.45],[2.10,41.35]]]}`
+
+	t.Run("overlaps are rounded", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("GetBoundariesIntersectingPolygon", ctx, square, []int{8}).
+			Return([]domain.BoundaryOverlap{
+				{ID: -347950, Name: "Barcelona", AdminLevel: 8, AreaSqKm: 101.35123, OverlapAreaSqKm: 60.12345, OverlapPercent: 65.4321},
+			}, nil)
+
+		result, err := uc.GetBoundariesIntersectingPolygon(ctx, dto.PolygonBoundariesRequest{
+			Polygon:     json.RawMessage(square),
+			AdminLevels: []int{8},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 60.123, result[0].OverlapAreaSqKm)
+		assert.Equal(t, 65.43, result[0].OverlapPercent)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("invalid polygons are rejected", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		for name, polygon := range map[string]string{
+			"not json":        `{"type":`,
+			"point":           `{"type":"Point","coordinates":[2.1,41.3]}`,
+			"unclosed ring":   `{"type":"Polygon","coordinates":[[[2.1,41.3],[2.2,41.3],[2.2,41.4],[2.1,41.4]]]}`,
+			"too few points":  `{"type":"Polygon","coordinates":[[[2.1,41.3],[2.2,41.3],[2.1,41.3]]]}`,
+			"bad coordinates": `{"type":"Polygon","coordinates":[[[2.1,141.3],[2.2,41.3],[2.2,41.4],[2.1,141.3]]]}`,
+			"empty multi":     `{"type":"MultiPolygon","coordinates":[]}`,
+		} {
+			_, err := uc.GetBoundariesIntersectingPolygon(ctx, dto.PolygonBoundariesRequest{Polygon: json.RawMessage(polygon)})
+			assert.ErrorIs(t, err, pkgerrors.ErrInvalidRequest, name)
+		}
+		mockBoundary.AssertNotCalled(t, "GetBoundariesIntersectingPolygon")
+	})
+
+	t.Run("polygons over configured limits are rejected", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		// Квадрат 0.1° x 0.1° на широте 41° - около 93 км²
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour,
+			usecase.PolygonLimits{MaxAreaSqKm: 50, MaxVertices: 4})
+
+		_, err := uc.GetBoundariesIntersectingPolygon(ctx, dto.PolygonBoundariesRequest{Polygon: json.RawMessage(square)})
+		assert.ErrorIs(t, err, pkgerrors.ErrInvalidRequest, "vertices")
+
+		uc = usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour,
+			usecase.PolygonLimits{MaxAreaSqKm: 50})
+
+		_, err = uc.GetBoundariesIntersectingPolygon(ctx, dto.PolygonBoundariesRequest{Polygon: json.RawMessage(square)})
+		assert.ErrorIs(t, err, pkgerrors.ErrInvalidRequest, "area")
+
+		continent := `{"type":"Polygon","coordinates":[[[-10,35],[30,35],[30,70],[-10,70],[-10,35]]]}`
+		uc = usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		_, err = uc.GetBoundariesIntersectingPolygon(ctx, dto.PolygonBoundariesRequest{Polygon: json.RawMessage(continent)})
+		assert.ErrorIs(t, err, pkgerrors.ErrInvalidRequest, "default area")
+		mockBoundary.AssertNotCalled(t, "GetBoundariesIntersectingPolygon")
+	})
+
+	t.Run("holes are subtracted from polygon area", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour,
+			usecase.PolygonLimits{MaxAreaSqKm: 50})
+
+		withHole := `{"type":"Polygon","coordinates":[[[2.10,41.35],[2.20,41.35],[2.20,41.45],[2.10,41.45],[2.10,41.35]],` +
+			`[[2.11,41.36],[2.19,41.36],[2.19,41.44],[2.11,41.44],[2.11,41.36]]]}`
+		mockBoundary.On("GetBoundariesIntersectingPolygon", ctx, withHole, []int(nil)).Return([]domain.BoundaryOverlap{}, nil)
+
+		_, err := uc.GetBoundariesIntersectingPolygon(ctx, dto.PolygonBoundariesRequest{Polygon: json.RawMessage(withHole)})
+
+		assert.NoError(t, err)
+		mockBoundary.AssertExpectations(t)
+	})
+}
+
+func TestSearchUseCase_StreamReverseGeocode(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	points := make([]dto.Point, 250)
+	for i := range points {
+		points[i] = dto.Point{Lat: 41 + float64(i)/1000, Lon: 2}
+	}
+
+	t.Run("points are geocoded in chunks and emitted in order", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		first := make([]*domain.Address, 200)
+		first[0] = &domain.Address{Country: "Spain", City: "Barcelona"}
+		mockBoundary.On("ReverseGeocodeBatch", ctx, mock.MatchedBy(func(p []domain.LatLon) bool { return len(p) == 200 })).
+			Return(first, nil).Once()
+		mockBoundary.On("ReverseGeocodeBatch", ctx, mock.MatchedBy(func(p []domain.LatLon) bool { return len(p) == 50 })).
+			Return(nil, errors.New("db error")).Once()
+
+		var items []dto.ReverseGeocodeStreamItem
+		err := uc.StreamReverseGeocode(ctx, points, func(item dto.ReverseGeocodeStreamItem) error {
+			items = append(items, item)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 250)
+		assert.Equal(t, "Barcelona", items[0].Address.City)
+		assert.Nil(t, items[1].Address)
+		assert.Empty(t, items[1].Error)
+		assert.Equal(t, 249, items[249].Index)
+		assert.NotEmpty(t, items[249].Error, "failed chunk should be reported per point")
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("emit error stops the stream", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("ReverseGeocodeBatch", ctx, mock.Anything).Return(make([]*domain.Address, 200), nil).Once()
+
+		emitted := 0
+		err := uc.StreamReverseGeocode(ctx, points, func(dto.ReverseGeocodeStreamItem) error {
+			emitted++
+			return errors.New("client gone")
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, emitted)
+		mockBoundary.AssertNumberOfCalls(t, "ReverseGeocodeBatch", 1)
+	})
+}
+
+func TestSearchUseCase_ForwardGeocodeBatch(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	spain := &domain.AdminBoundary{ID: 1, OSMId: 1, AdminLevel: 2, Name: "España"}
+	barcelona := &domain.AdminBoundary{ID: 100, OSMId: 100, AdminLevel: 8, Name: "Barcelona"}
+
+	t.Run("same names are searched once and addresses of a city share a scope", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		req := dto.ForwardGeocodeBatchRequest{Addresses: []dto.ForwardGeocodeAddress{
+			{Country: "Spain", City: ptrString("Barcelona"), Street: ptrString("Carrer de Mallorca"), HouseNumber: ptrString("401")},
+			{Country: "Spain", City: ptrString("Barcelona"), Street: ptrString("Carrer de Provença")},
+			{Country: "Spain"},
+			{Country: "Atlantis"},
+		}}
+
+		// Раунд стран: Spain, Atlantis; раунд городов: Barcelona внутри Испании
+		mockBoundary.On("SearchByTextBatch", ctx, mock.MatchedBy(func(requests []domain.BoundarySearchRequest) bool {
+			return len(requests) == 2 && requests[0].AdminLevel == 2 && requests[0].ParentID == 0
+		})).Return([]domain.BoundarySearchResult{
+			{Index: 0, Found: true, Boundary: spain},
+			{Index: 1},
+		}, nil).Once()
+		mockBoundary.On("SearchByTextBatch", ctx, mock.MatchedBy(func(requests []domain.BoundarySearchRequest) bool {
+			return len(requests) == 1 && requests[0].Name == "Barcelona" && requests[0].ParentID == spain.OSMId
+		})).Return([]domain.BoundarySearchResult{
+			{Index: 0, Found: true, Boundary: barcelona},
+		}, nil).Once()
+
+		mockBoundary.On("LookupAddresses", ctx, mock.MatchedBy(func(lookups []domain.AddressLookup) bool {
+			return len(lookups) == 3 && lookups[0].ScopeID == 1 &&
+				lookups[1].ScopeID == 100 && lookups[1].HouseNumber == "401" &&
+				lookups[2].ScopeID == 100 && lookups[2].Street == "Carrer de Provença"
+		})).Return([]domain.AddressLookupResult{
+			{Index: 0, Lat: 41.3985, Lon: 2.1685, Precision: domain.GeocodePrecisionHouse},
+			{Index: 1, Lat: 41.3941, Lon: 2.1601, Precision: domain.GeocodePrecisionStreet},
+			{Index: 2, Lat: 40.4, Lon: -3.7, Precision: domain.GeocodePrecisionBoundary},
+		}, nil).Once()
+
+		resp, err := uc.ForwardGeocodeBatch(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Results, 4)
+		assert.Equal(t, 3, resp.Meta.SuccessCount)
+		assert.Equal(t, 1, resp.Meta.ErrorCount)
+		assert.Equal(t, 2, resp.Meta.ScopeCount)
+		assert.Equal(t, 3, resp.Meta.DBQueriesCount)
+
+		assert.Equal(t, domain.GeocodePrecisionHouse, resp.Results[0].Precision)
+		assert.Equal(t, 41.3985, *resp.Results[0].Lat)
+		assert.Equal(t, "Barcelona", resp.Results[0].Boundary.Name)
+		assert.Equal(t, 0.95, resp.Results[0].Confidence)
+		assert.Equal(t, 0.75, resp.Results[1].Confidence)
+		assert.Equal(t, 2, resp.Results[2].AdminLevel)
+
+		assert.Equal(t, 3, resp.Results[3].Index)
+		assert.Nil(t, resp.Results[3].Lat)
+		assert.NotEmpty(t, resp.Results[3].Error)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("level outside the resolved parent is not matched", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("SearchByTextBatch", ctx, mock.MatchedBy(func(requests []domain.BoundarySearchRequest) bool {
+			return requests[0].AdminLevel == 2
+		})).Return([]domain.BoundarySearchResult{{Index: 0, Found: true, Boundary: spain}}, nil).Once()
+		// Córdoba внутри Испании не найдена (одноименный город Аргентины отсекает ParentID)
+		mockBoundary.On("SearchByTextBatch", ctx, mock.MatchedBy(func(requests []domain.BoundarySearchRequest) bool {
+			return requests[0].Name == "Córdoba" && requests[0].ParentID == spain.OSMId
+		})).Return([]domain.BoundarySearchResult{{Index: 0}}, nil).Once()
+		mockBoundary.On("LookupAddresses", ctx, mock.MatchedBy(func(lookups []domain.AddressLookup) bool {
+			return len(lookups) == 1 && lookups[0].ScopeID == spain.OSMId && lookups[0].Street == ""
+		})).Return([]domain.AddressLookupResult{
+			{Index: 0, Lat: 40.4, Lon: -3.7, Precision: domain.GeocodePrecisionBoundary},
+		}, nil).Once()
+
+		resp, err := uc.ForwardGeocodeBatch(ctx, dto.ForwardGeocodeBatchRequest{
+			Addresses: []dto.ForwardGeocodeAddress{{Country: "Spain", City: ptrString("Córdoba"), Street: ptrString("Calle Cruz Conde")}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.Results[0].MatchedLevels)
+		assert.Equal(t, 2, resp.Results[0].AdminLevel)
+		assert.Equal(t, 0.025, resp.Results[0].Confidence)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("failed lookup is reported per address", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("SearchByTextBatch", ctx, mock.Anything).
+			Return([]domain.BoundarySearchResult{{Index: 0, Found: true, Boundary: spain}}, nil)
+		mockBoundary.On("LookupAddresses", ctx, mock.Anything).Return(nil, errors.New("db error"))
+
+		resp, err := uc.ForwardGeocodeBatch(ctx, dto.ForwardGeocodeBatchRequest{
+			Addresses: []dto.ForwardGeocodeAddress{{Country: "Spain"}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.Meta.ErrorCount)
+		assert.Equal(t, "address lookup failed", resp.Results[0].Error)
+	})
+
+	t.Run("name search error fails the batch", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("SearchByTextBatch", ctx, mock.Anything).Return(nil, errors.New("db error"))
+
+		resp, err := uc.ForwardGeocodeBatch(ctx, dto.ForwardGeocodeBatchRequest{
+			Addresses: []dto.ForwardGeocodeAddress{{Country: "Spain"}},
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestSearchUseCase_ReverseGeocode_CellCache(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	// Ячейки - квадраты 1x1 градус с юго-западным углом в (lat, lon)
+	square := func(id int64, lat, lon float64) *domain.GeocodeCell {
+		cell, err := domain.NewGeocodeCell(id, 10, fmt.Sprintf(
+			`{"type":"Polygon","coordinates":[[[%[2]g,%[1]g],[%[2]g,%[3]g],[%[4]g,%[3]g],[%[4]g,%[1]g],[%[2]g,%[1]g]]]}`,
+			lat, lon, lat+1, lon+1))
+		if err != nil {
+			t.Fatalf("cell: %v", err)
+		}
+		return cell
+	}
+
+	t.Run("point inside a cached cell skips the database", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		addr := &domain.Address{Country: "Spain", City: "Olot"}
+		mockBoundary.On("ReverseGeocode", ctx, 42.1, 2.1).Return(addr, nil).Once()
+		mockBoundary.On("ReverseGeocode", ctx, 42.2, 2.2).Return(addr, nil).Once()
+		// Ячейка ищется только на втором промахе с тем же адресом
+		mockBoundary.On("GetGeocodeCell", ctx, 42.2, 2.2).Return(square(-1, 42, 2), nil).Once()
+
+		for _, p := range []dto.ReverseGeocodeRequest{{Lat: 42.1, Lon: 2.1}, {Lat: 42.2, Lon: 2.2}} {
+			result, err := uc.ReverseGeocode(ctx, p)
+			assert.NoError(t, err)
+			assert.Equal(t, "Olot", result.Address.City)
+		}
+
+		// Другая точка той же ячейки в десятках километров - без запросов к БД
+		cached, err := uc.ReverseGeocode(ctx, dto.ReverseGeocodeRequest{Lat: 42.9, Lon: 2.8})
+		assert.NoError(t, err)
+		assert.Equal(t, "Olot", cached.Address.City)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("single miss does not look up the cell", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("ReverseGeocode", ctx, 42.1, 2.1).Return(&domain.Address{City: "Olot"}, nil).Once()
+		mockBoundary.On("ReverseGeocode", ctx, 41.4, 2.2).Return(&domain.Address{City: "Barcelona"}, nil).Once()
+
+		for _, p := range []dto.ReverseGeocodeRequest{{Lat: 42.1, Lon: 2.1}, {Lat: 41.4, Lon: 2.2}} {
+			_, err := uc.ReverseGeocode(ctx, p)
+			assert.NoError(t, err)
+		}
+		mockBoundary.AssertNotCalled(t, "GetGeocodeCell", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("cache mode bypasses cells", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		disabledCtx := cachemode.WithMode(ctx, cachemode.Disabled)
+		refreshCtx := cachemode.WithMode(ctx, cachemode.Refresh)
+		addr := &domain.Address{Country: "Spain", City: "Girona"}
+		mockBoundary.On("ReverseGeocode", disabledCtx, 41.9, 2.8).Return(addr, nil).Twice()
+		mockBoundary.On("ReverseGeocode", refreshCtx, 41.9, 2.8).Return(addr, nil).Twice()
+		mockBoundary.On("GetGeocodeCell", refreshCtx, 41.9, 2.8).Return(square(-3, 41, 2), nil).Once()
+
+		// Кеш выключен: ни чтения, ни записи ячейки, промахи не считаются
+		for i := 0; i < 2; i++ {
+			_, err := uc.ReverseGeocode(disabledCtx, dto.ReverseGeocodeRequest{Lat: 41.9, Lon: 2.8})
+			assert.NoError(t, err)
+		}
+		// no-cache: запрос в БД, ячейка обновляется
+		for i := 0; i < 2; i++ {
+			_, err := uc.ReverseGeocode(refreshCtx, dto.ReverseGeocodeRequest{Lat: 41.9, Lon: 2.8})
+			assert.NoError(t, err)
+		}
+		// Обычный запрос берет адрес из обновленной ячейки
+		cached, err := uc.ReverseGeocode(ctx, dto.ReverseGeocodeRequest{Lat: 41.5, Lon: 2.5})
+		assert.NoError(t, err)
+		assert.Equal(t, "Girona", cached.Address.City)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("missing cell is remembered", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		addr := &domain.Address{Country: "Spain", City: "Madrid"}
+		mockBoundary.On("ReverseGeocode", ctx, 40.4, -3.7).Return(addr, nil).Times(3)
+		mockBoundary.On("GetGeocodeCell", ctx, 40.4, -3.7).Return(nil, nil).Once()
+
+		for i := 0; i < 3; i++ {
+			_, err := uc.ReverseGeocode(ctx, dto.ReverseGeocodeRequest{Lat: 40.4, Lon: -3.7})
+			assert.NoError(t, err)
+		}
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("least recently used cell is evicted", func(t *testing.T) {
+		usecase.ConfigureGeocodeCellCache(1)
+		defer usecase.ConfigureGeocodeCellCache(1000)
+
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("ReverseGeocode", ctx, 42.5, 2.5).Return(&domain.Address{City: "A"}, nil).Times(3)
+		mockBoundary.On("GetGeocodeCell", ctx, 42.5, 2.5).Return(square(-1, 42, 2), nil).Once()
+		mockBoundary.On("ReverseGeocode", ctx, 10.5, 10.5).Return(&domain.Address{City: "B"}, nil).Twice()
+		mockBoundary.On("GetGeocodeCell", ctx, 10.5, 10.5).Return(square(-2, 10, 10), nil).Once()
+
+		// A и B кешируются на втором промахе; ячейка B вытесняет A, и A снова идет в БД
+		for _, p := range []dto.ReverseGeocodeRequest{
+			{Lat: 42.5, Lon: 2.5}, {Lat: 42.5, Lon: 2.5},
+			{Lat: 10.5, Lon: 10.5}, {Lat: 10.5, Lon: 10.5},
+			{Lat: 42.5, Lon: 2.5},
+		} {
+			_, err := uc.ReverseGeocode(ctx, p)
+			assert.NoError(t, err)
+		}
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("cells expire after ttl and can be flushed", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 50*time.Millisecond, usecase.PolygonLimits{})
+
+		addr := &domain.Address{Country: "Spain", City: "Olot"}
+		mockBoundary.On("ReverseGeocode", ctx, 42.5, 2.5).Return(addr, nil).Times(3)
+		mockBoundary.On("GetGeocodeCell", ctx, 42.5, 2.5).Return(square(-1, 42, 2), nil).Once()
+
+		for i := 0; i < 2; i++ {
+			_, err := uc.ReverseGeocode(ctx, dto.ReverseGeocodeRequest{Lat: 42.5, Lon: 2.5})
+			assert.NoError(t, err)
+		}
+		time.Sleep(60 * time.Millisecond)
+		_, err := uc.ReverseGeocode(ctx, dto.ReverseGeocodeRequest{Lat: 42.5, Lon: 2.5})
+		assert.NoError(t, err)
+		mockBoundary.AssertExpectations(t)
+		assert.Equal(t, 0, uc.FlushGeocodeCells())
+	})
+
+	t.Run("flush drops cached cells", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("ReverseGeocode", ctx, 42.5, 2.5).Return(&domain.Address{City: "Olot"}, nil).Times(3)
+		mockBoundary.On("GetGeocodeCell", ctx, 42.5, 2.5).Return(square(-1, 42, 2), nil).Once()
+
+		for i := 0; i < 2; i++ {
+			_, err := uc.ReverseGeocode(ctx, dto.ReverseGeocodeRequest{Lat: 42.5, Lon: 2.5})
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 1, uc.FlushGeocodeCells())
+		_, err := uc.ReverseGeocode(ctx, dto.ReverseGeocodeRequest{Lat: 42.5, Lon: 2.5})
+		assert.NoError(t, err)
+		mockBoundary.AssertExpectations(t)
+	})
+
+	t.Run("disabled cache", func(t *testing.T) {
+		usecase.ConfigureGeocodeCellCache(0)
+		defer usecase.ConfigureGeocodeCellCache(1000)
+
+		mockBoundary := &MockBoundaryRepository{}
+		uc := usecase.NewSearchUseCase(mockBoundary, &MockCacheRepository{}, logger, 1*time.Hour, usecase.PolygonLimits{})
+
+		mockBoundary.On("ReverseGeocode", ctx, 42.1, 2.1).Return(&domain.Address{City: "Olot"}, nil).Once()
+
+		_, err := uc.ReverseGeocode(ctx, dto.ReverseGeocodeRequest{Lat: 42.1, Lon: 2.1})
+		assert.NoError(t, err)
+		mockBoundary.AssertNotCalled(t, "GetGeocodeCell", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
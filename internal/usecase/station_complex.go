@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"math"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultComplexRadiusM - радиус поиска пересадочных узлов по умолчанию (метры)
+	defaultComplexRadiusM = 1000
+	// defaultComplexClusterM - станции узла не дальше этого расстояния друг от друга (по цепочке)
+	defaultComplexClusterM = 150
+	// defaultComplexLimit, maxComplexLimit - число узлов в ответе
+	defaultComplexLimit = 10
+	maxComplexLimit     = 50
+)
+
+// GetStationComplexes возвращает пересадочные узлы рядом с точкой: станции в радиусе кластеризуются
+// по расстоянию (ClusterDistance), внутри кластера в узел объединяются станции с похожими названиями
+// (domain.BuildStationComplexes). Станции узла сгруппированы по виду транспорта, линии объединены.
+func (uc *TransportUseCase) GetStationComplexes(
+	ctx context.Context,
+	req dto.StationComplexRequest,
+) (*dto.StationComplexesResponse, error) {
+	if !utils.ValidateCoordinates(req.Lat, req.Lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+
+	radius := req.Radius
+	if radius == 0 {
+		radius = defaultComplexRadiusM
+	}
+	clusterM := req.ClusterDistance
+	if clusterM == 0 {
+		clusterM = defaultComplexClusterM
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultComplexLimit
+	}
+	if radius < 100 || radius > 5000 || clusterM < 20 || clusterM > 500 || limit < 1 || limit > maxComplexLimit {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"radius":           "must be between 100 and 5000 m",
+			"cluster_distance": "must be between 20 and 500 m",
+			"limit":            "must be between 1 and 50",
+		})
+	}
+
+	// Каждый кластер дает хотя бы один узел, а расстояние узла - расстояние его ближайшей станции,
+	// поэтому limit ближайших узлов лежат в limit ближайших кластерах
+	stations, err := uc.transportRepo.GetClusteredStations(ctx, req.Lat, req.Lon, radius, clusterM, limit)
+	if err != nil {
+		uc.logger.Error("Failed to get clustered stations", zap.Error(err))
+		return nil, err
+	}
+
+	complexes := domain.BuildStationComplexes(stations)
+	if len(complexes) > limit {
+		complexes = complexes[:limit]
+	}
+
+	result := make([]dto.StationComplexDTO, 0, len(complexes))
+	totalStations := 0
+	for _, c := range complexes {
+		byMode := make(map[string][]dto.PriorityTransportStation, len(c.StationsByMode))
+		for mode, members := range c.StationsByMode {
+			for _, s := range members {
+				byMode[mode] = append(byMode[mode], uc.priorityStationDTO(s, s.DistanceM*walkingDetourFactor))
+			}
+			totalStations += len(members)
+		}
+
+		result = append(result, dto.StationComplexDTO{
+			ID:             c.ID,
+			Name:           c.Name,
+			Lat:            utils.RoundCoordinate(c.Lat),
+			Lon:            utils.RoundCoordinate(c.Lon),
+			Distance:       math.Round(c.DistanceM*100) / 100,
+			Modes:          c.Modes,
+			StationsByMode: byMode,
+			Lines:          transportLinesDTO(c.Lines),
+		})
+	}
+
+	return &dto.StationComplexesResponse{
+		Complexes: result,
+		Meta: dto.StationComplexesMeta{
+			SearchPoint:      dto.Point{Lat: req.Lat, Lon: req.Lon},
+			RadiusM:          radius,
+			ClusterDistanceM: clusterM,
+			TotalStations:    totalStations,
+		},
+	}, nil
+}
+
+// priorityStationDTO - станция с расстоянием по прямой, пешим расстоянием walkingDistance (метры)
+// и временем ходьбы при скорости пешехода usecase
+func (uc *TransportUseCase) priorityStationDTO(s domain.NearestTransportWithLines, walkingDistance float64) dto.PriorityTransportStation {
+	walkingTime := walkingDistance / uc.walkingSpeedMps / 60 // в минутах
+
+	return dto.PriorityTransportStation{
+		StationID:       s.StationID,
+		Name:            s.Name,
+		NameEn:          s.NameEn,
+		Type:            s.Type,
+		Lat:             utils.RoundCoordinate(s.Lat),
+		Lon:             utils.RoundCoordinate(s.Lon),
+		LinearDistance:  math.Round(s.DistanceM*100) / 100,
+		WalkingDistance: math.Round(walkingDistance*100) / 100,
+		WalkingTime:     math.Round(walkingTime*10) / 10,
+		Lines:           transportLinesDTO(s.Lines),
+	}
+}
+
+// transportLinesDTO преобразует линии станции в DTO
+func transportLinesDTO(lines []domain.TransportLineInfo) []dto.TransportLineInfoEnriched {
+	result := make([]dto.TransportLineInfoEnriched, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, dto.TransportLineInfoEnriched{
+			ID:    line.ID,
+			Name:  line.Name,
+			Ref:   line.Ref,
+			Type:  line.Type,
+			Color: line.Color,
+		})
+	}
+	return result
+}
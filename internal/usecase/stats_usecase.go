@@ -2,31 +2,137 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
 	"go.uber.org/zap"
 )
 
+const (
+	// maxCoverageGridSize - максимальная сторона сетки покрытия (ячеек по каждой оси)
+	maxCoverageGridSize = 50
+	// coverageCacheTTL - покрытие меняется только при импорте, кешируется надолго
+	coverageCacheTTL = 24 * time.Hour
+)
+
+// coverageGridSizes - допустимые стороны сетки покрытия по возрастанию. Запрошенная сторона
+// округляется вверх до ближайшей: каждая сетка - полный проход по точкам, а число вариантов
+// в кеше ограничено этим списком.
+var coverageGridSizes = []int{0, 2, 5, 10, 20, maxCoverageGridSize}
+
 // StatsUseCase обрабатывает бизнес-логику для статистики
 type StatsUseCase struct {
-	statsRepo repository.StatsRepository
-	cacheRepo repository.CacheRepository
-	logger    *zap.Logger
+	statsRepo    repository.StatsRepository
+	boundaryRepo repository.BoundaryRepository
+	cacheRepo    repository.CacheRepository
+	logger       *zap.Logger
 }
 
 // NewStatsUseCase создает новый экземпляр StatsUseCase
 func NewStatsUseCase(
 	statsRepo repository.StatsRepository,
+	boundaryRepo repository.BoundaryRepository,
 	cacheRepo repository.CacheRepository,
 	logger *zap.Logger,
 ) *StatsUseCase {
 	return &StatsUseCase{
-		statsRepo: statsRepo,
-		cacheRepo: cacheRepo,
-		logger:    logger,
+		statsRepo:    statsRepo,
+		boundaryRepo: boundaryRepo,
+		cacheRepo:    cacheRepo,
+		logger:       logger,
+	}
+}
+
+// GetDataCoverage возвращает охват данных развертывания (bbox, границы по уровням,
+// при gridSize > 0 - сетку пустых/заполненных ячеек, сторона из coverageGridSizes).
+// Результат кешируется на coverageCacheTTL.
+func (uc *StatsUseCase) GetDataCoverage(ctx context.Context, gridSize int) (*domain.DataCoverage, error) {
+	if gridSize < 0 || gridSize > maxCoverageGridSize {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"grid": gridSize,
+			"max":  maxCoverageGridSize,
+		})
+	}
+	for _, size := range coverageGridSizes {
+		if size >= gridSize {
+			gridSize = size
+			break
+		}
+	}
+
+	cacheKey := fmt.Sprintf("%s:coverage:grid:%d", domain.CacheNamespaceStats, gridSize)
+	if cached, err := uc.cacheRepo.Get(ctx, cacheKey); err == nil && cached != nil {
+		var coverage domain.DataCoverage
+		if err := json.Unmarshal(cached, &coverage); err == nil {
+			return &coverage, nil
+		}
+		uc.logger.Warn("Failed to decode cached coverage", zap.String("key", cacheKey))
+	}
+
+	coverage, err := uc.boundaryRepo.GetDataCoverage(ctx, gridSize)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage.BBox = roundBoundingBox(coverage.BBox)
+	coverage.CenterLat = utils.RoundCoordinate(coverage.CenterLat)
+	coverage.CenterLon = utils.RoundCoordinate(coverage.CenterLon)
+	if coverage.Grid != nil {
+		for i := range coverage.Grid.Cells {
+			coverage.Grid.Cells[i].BBox = roundBoundingBox(coverage.Grid.Cells[i].BBox)
+		}
+	}
+
+	if data, err := json.Marshal(coverage); err == nil {
+		if err := uc.cacheRepo.Set(ctx, cacheKey, data, coverageCacheTTL); err != nil {
+			uc.logger.Warn("Failed to cache coverage", zap.String("key", cacheKey), zap.Error(err))
+		}
+	}
+
+	return coverage, nil
+}
+
+// GetAdminLevels возвращает admin_level, присутствующие в данных развертывания, с количеством
+// и примером названия. Набор уровней меняется только при импорте, кешируется на coverageCacheTTL.
+func (uc *StatsUseCase) GetAdminLevels(ctx context.Context) ([]domain.AdminLevelInfo, error) {
+	cacheKey := fmt.Sprintf("%s:admin_levels", domain.CacheNamespaceStats)
+	if cached, err := uc.cacheRepo.Get(ctx, cacheKey); err == nil && cached != nil {
+		var levels []domain.AdminLevelInfo
+		if err := json.Unmarshal(cached, &levels); err == nil {
+			return levels, nil
+		}
+		uc.logger.Warn("Failed to decode cached admin levels", zap.String("key", cacheKey))
+	}
+
+	levels, err := uc.boundaryRepo.GetAdminLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if levels == nil {
+		levels = []domain.AdminLevelInfo{}
+	}
+
+	if data, err := json.Marshal(levels); err == nil {
+		if err := uc.cacheRepo.Set(ctx, cacheKey, data, coverageCacheTTL); err != nil {
+			uc.logger.Warn("Failed to cache admin levels", zap.String("key", cacheKey), zap.Error(err))
+		}
+	}
+
+	return levels, nil
+}
+
+// roundBoundingBox округляет координаты bbox до точности ответа
+func roundBoundingBox(b domain.BoundingBox) domain.BoundingBox {
+	return domain.BoundingBox{
+		MinLat: utils.RoundCoordinate(b.MinLat),
+		MinLon: utils.RoundCoordinate(b.MinLon),
+		MaxLat: utils.RoundCoordinate(b.MaxLat),
+		MaxLon: utils.RoundCoordinate(b.MaxLon),
 	}
 }
 
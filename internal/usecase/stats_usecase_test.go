@@ -0,0 +1,143 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase"
+)
+
+func TestStatsUseCase_GetDataCoverage(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("fetches from db and caches rounded result", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		mockCache := &MockCacheRepository{}
+
+		mockCache.On("Get", ctx, "stats:coverage:grid:2").Return(nil, errors.ErrCacheError)
+		mockBoundary.On("GetDataCoverage", ctx, 2).Return(&domain.DataCoverage{
+			BBox:         domain.BoundingBox{MinLat: 41.31234567, MinLon: 2.05, MaxLat: 41.47, MaxLon: 2.23},
+			TotalPoints:  3,
+			ByAdminLevel: map[int]int{8: 1},
+			Grid: &domain.CoverageGrid{Size: 2, EmptyCells: 3, Cells: []domain.CoverageCell{
+				{Row: 0, Col: 0, Points: 3},
+				{Row: 0, Col: 1, Empty: true},
+				{Row: 1, Col: 0, Empty: true},
+				{Row: 1, Col: 1, Empty: true},
+			}},
+		}, nil)
+		mockCache.On("Set", ctx, "stats:coverage:grid:2", mock.Anything, mock.Anything).Return(nil)
+
+		uc := usecase.NewStatsUseCase(nil, mockBoundary, mockCache, logger)
+		coverage, err := uc.GetDataCoverage(ctx, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 41.312346, coverage.BBox.MinLat)
+		assert.Equal(t, 3, coverage.Grid.EmptyCells)
+		mockBoundary.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("served from cache", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		mockCache := &MockCacheRepository{}
+
+		cached, _ := json.Marshal(domain.DataCoverage{TotalPoints: 42, ByAdminLevel: map[int]int{2: 1}})
+		mockCache.On("Get", ctx, "stats:coverage:grid:0").Return(cached, nil)
+
+		uc := usecase.NewStatsUseCase(nil, mockBoundary, mockCache, logger)
+		coverage, err := uc.GetDataCoverage(ctx, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), coverage.TotalPoints)
+		mockBoundary.AssertNotCalled(t, "GetDataCoverage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("grid size rounded up to allowed size", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		mockCache := &MockCacheRepository{}
+
+		cached, _ := json.Marshal(domain.DataCoverage{TotalPoints: 42, Grid: &domain.CoverageGrid{Size: 10}})
+		mockCache.On("Get", ctx, "stats:coverage:grid:10").Return(cached, nil)
+
+		uc := usecase.NewStatsUseCase(nil, mockBoundary, mockCache, logger)
+		coverage, err := uc.GetDataCoverage(ctx, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, coverage.Grid.Size)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("grid too large", func(t *testing.T) {
+		uc := usecase.NewStatsUseCase(nil, &MockBoundaryRepository{}, &MockCacheRepository{}, logger)
+
+		_, err := uc.GetDataCoverage(ctx, 500)
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	})
+}
+
+func TestStatsUseCase_GetAdminLevels(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("fetches from db and caches", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		mockCache := &MockCacheRepository{}
+
+		levels := []domain.AdminLevelInfo{
+			{Level: 2, Count: 1, ExampleName: "España"},
+			{Level: 8, Count: 947, ExampleName: "Madrid"},
+		}
+		mockCache.On("Get", ctx, "stats:admin_levels").Return(nil, errors.ErrCacheError)
+		mockBoundary.On("GetAdminLevels", ctx).Return(levels, nil)
+		mockCache.On("Set", ctx, "stats:admin_levels", mock.Anything, mock.Anything).Return(nil)
+
+		uc := usecase.NewStatsUseCase(nil, mockBoundary, mockCache, logger)
+		result, err := uc.GetAdminLevels(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, levels, result)
+		mockBoundary.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("served from cache", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		mockCache := &MockCacheRepository{}
+
+		cached, _ := json.Marshal([]domain.AdminLevelInfo{{Level: 4, Count: 17, ExampleName: "Cataluña"}})
+		mockCache.On("Get", ctx, "stats:admin_levels").Return(cached, nil)
+
+		uc := usecase.NewStatsUseCase(nil, mockBoundary, mockCache, logger)
+		result, err := uc.GetAdminLevels(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.AdminLevelInfo{{Level: 4, Count: 17, ExampleName: "Cataluña"}}, result)
+		mockBoundary.AssertNotCalled(t, "GetAdminLevels", mock.Anything)
+	})
+
+	t.Run("no boundaries gives empty list", func(t *testing.T) {
+		mockBoundary := &MockBoundaryRepository{}
+		mockCache := &MockCacheRepository{}
+
+		mockCache.On("Get", ctx, "stats:admin_levels").Return(nil, errors.ErrCacheError)
+		mockBoundary.On("GetAdminLevels", ctx).Return(nil, nil)
+		mockCache.On("Set", ctx, "stats:admin_levels", []byte("[]"), mock.Anything).Return(nil)
+
+		uc := usecase.NewStatsUseCase(nil, mockBoundary, mockCache, logger)
+		result, err := uc.GetAdminLevels(ctx)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Empty(t, result)
+		mockCache.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"math"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase/dto"
+)
+
+// maxTileCoverCount - максимум тайлов в ответе GetTileCover: больше - уже не прогрев окна карты,
+// а обход слоя целиком, и список из миллионов тайлов не нужен ни клиенту, ни серверу
+const maxTileCoverCount = 1024
+
+// GetTileCover перечисляет тайлы зума z, покрывающие bbox, по стандартной схеме slippy map.
+// Чистое вычисление без БД - для прогрева кеша и управления кешем тайлов на клиенте.
+// bbox, пересекающий антимеридиан (min_lon > max_lon), не поддерживается: его нужно разбить на два.
+func (uc *TileUseCase) GetTileCover(bbox domain.BoundingBox, z int) (*dto.TileCoverResponse, error) {
+	for _, v := range []float64{bbox.MinLat, bbox.MinLon, bbox.MaxLat, bbox.MaxLon} {
+		// NaN проходит любые сравнения ниже и превращается в MinInt при переводе в номер тайла
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.ErrInvalidCoordinates.WithDetails(map[string]interface{}{
+				"bbox": "coordinates must be finite numbers",
+			})
+		}
+	}
+	if z < 0 || z > domain.TileMaxZoom {
+		return nil, errors.ErrInvalidZoom.WithDetails(map[string]interface{}{
+			"zoom":     z,
+			"max_zoom": domain.TileMaxZoom,
+		})
+	}
+	if bbox.MinLat < -90 || bbox.MaxLat > 90 || bbox.MinLon < -180 || bbox.MaxLon > 180 {
+		return nil, errors.ErrInvalidCoordinates.WithDetails(map[string]interface{}{
+			"bbox": "latitude must be within [-90, 90], longitude within [-180, 180]",
+		})
+	}
+	if bbox.MinLat > bbox.MaxLat || bbox.MinLon > bbox.MaxLon {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"bbox": "min corner must be below and left of max corner",
+		})
+	}
+
+	// Стороны проверяются до перемножения: на больших зумах произведение не нужно и может переполниться
+	r := domain.TileCover(bbox, z)
+	if width, height := r.MaxX-r.MinX+1, r.MaxY-r.MinY+1; width > maxTileCoverCount || height > maxTileCoverCount ||
+		width*height > maxTileCoverCount {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"width":     width,
+			"height":    height,
+			"max_tiles": maxTileCoverCount,
+			"bbox":      "too many tiles at this zoom, use a smaller bbox or zoom",
+		})
+	}
+
+	return &dto.TileCoverResponse{
+		Zoom:  z,
+		BBox:  bbox,
+		MinX:  r.MinX,
+		MaxX:  r.MaxX,
+		MinY:  r.MinY,
+		MaxY:  r.MaxY,
+		Count: r.Count(),
+		Tiles: r.Tiles(),
+	}, nil
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/usecase/dto"
+)
+
+// GetTileSchema возвращает атрибуты и их типы для каждого слоя MVT и минимальный зум слоев,
+// видимость которых задает политика тайлов
+func (uc *TileUseCase) GetTileSchema() *dto.TileSchemaResponse {
+	layers := make([]domain.TileLayerSchema, 0, len(uc.tileSchema))
+	for _, layer := range uc.tileSchema {
+		layer.MinZoom = nil
+		if minZoom, ok := tileZoomPolicy.LayerMinZoom(domain.TileLayer(layer.Name)); ok {
+			layer.MinZoom = &minZoom
+		}
+		layers = append(layers, layer)
+	}
+	return &dto.TileSchemaResponse{Layers: layers}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
 	"github.com/location-microservice/internal/usecase/dto"
 	"go.uber.org/zap"
@@ -19,6 +20,8 @@ type TileUseCase struct {
 	environmentRepo repository.EnvironmentRepository
 	poiRepo         repository.POIRepository
 	cacheRepo       repository.CacheRepository
+	tileArchive     repository.TileArchiveRepository // nil - без предрассчитанных тайлов
+	tileSchema      []domain.TileLayerSchema         // схема слоев MVT, по которой репозитории собирают тайлы
 	logger          *zap.Logger
 	tileCacheTTL         time.Duration
 	boundaryTileCacheTTL time.Duration
@@ -30,6 +33,8 @@ func NewTileUseCase(
 	environmentRepo repository.EnvironmentRepository,
 	poiRepo repository.POIRepository,
 	cacheRepo repository.CacheRepository,
+	tileArchive repository.TileArchiveRepository,
+	tileSchema []domain.TileLayerSchema,
 	logger *zap.Logger,
 	tileCacheTTL time.Duration,
 ) *TileUseCase {
@@ -45,15 +50,55 @@ func NewTileUseCase(
 		environmentRepo:      environmentRepo,
 		poiRepo:              poiRepo,
 		cacheRepo:            cacheRepo,
+		tileArchive:          tileArchive,
+		tileSchema:           tileSchema,
 		logger:               logger,
 		tileCacheTTL:         tileCacheTTL,
 		boundaryTileCacheTTL: boundaryTTL,
 	}
 }
 
-func (uc *TileUseCase) GetBoundaryTile(ctx context.Context, z, x, y int) ([]byte, error) {
+// archivedTile возвращает предрассчитанный тайл слоя, если он есть в MBTiles архиве.
+// Ошибка чтения архива не фатальна: тайл генерируется из PostGIS.
+func archivedTile(ctx context.Context, archive repository.TileArchiveRepository, logger *zap.Logger, layer domain.TileLayer, z, x, y int) ([]byte, bool) {
+	if archive == nil {
+		return nil, false
+	}
+
+	tile, found, err := archive.GetTile(ctx, layer, z, x, y)
+	if err != nil {
+		logger.Warn("Tile archive lookup failed, generating tile from DB",
+			zap.String("layer", string(layer)),
+			zap.Int("z", z),
+			zap.Int("x", x),
+			zap.Int("y", y),
+			zap.Error(err))
+		return nil, false
+	}
+	return tile, found
+}
+
+// GetBoundaryTile возвращает MVT тайл границ. minAreaSqKm <= 0 - порог площади по умолчанию для зума
+func (uc *TileUseCase) GetBoundaryTile(ctx context.Context, z, x, y int, minAreaSqKm float64) ([]byte, error) {
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+	if err := checkTileLayerVisible(domain.TileLayerBoundaries, z); err != nil {
+		return nil, err
+	}
+
+	// Архив рассчитан с порогом площади по умолчанию
+	if minAreaSqKm <= 0 {
+		if tile, ok := archivedTile(ctx, uc.tileArchive, uc.logger, domain.TileLayerBoundaries, z, x, y); ok {
+			return tile, nil
+		}
+	}
+
 	// Check cache first
-	cacheKey := fmt.Sprintf("tile:boundaries:%d:%d:%d", z, x, y)
+	cacheKey := domain.TileCacheKey("boundaries", z, x, y)
+	if minAreaSqKm > 0 {
+		cacheKey = fmt.Sprintf("%s:minarea:%g", cacheKey, minAreaSqKm)
+	}
 	cached, err := uc.cacheRepo.Get(ctx, cacheKey)
 	if err == nil && cached != nil {
 		uc.logger.Info("Boundary tile from cache",
@@ -69,7 +114,7 @@ func (uc *TileUseCase) GetBoundaryTile(ctx context.Context, z, x, y int) ([]byte
 		zap.Int("z", z),
 		zap.Int("x", x),
 		zap.Int("y", y))
-	tile, err := uc.boundaryRepo.GetTile(ctx, z, x, y)
+	tile, err := uc.boundaryRepo.GetTile(ctx, z, x, y, minAreaSqKm)
 	if err != nil {
 		uc.logger.Error("Failed to get boundary tile", zap.Error(err))
 		return nil, err
@@ -90,7 +135,11 @@ func (uc *TileUseCase) GetBoundaryTile(ctx context.Context, z, x, y int) ([]byte
 }
 
 func (uc *TileUseCase) GetTransportTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	cacheKey := fmt.Sprintf("tile:transport:%d:%d:%d", z, x, y)
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+
+	cacheKey := domain.TileCacheKey("transport", z, x, y)
 	cached, err := uc.cacheRepo.Get(ctx, cacheKey)
 	if err == nil && cached != nil {
 		return cached, nil
@@ -106,7 +155,18 @@ func (uc *TileUseCase) GetTransportTile(ctx context.Context, z, x, y int) ([]byt
 }
 
 func (uc *TileUseCase) GetGreenSpacesTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	cacheKey := fmt.Sprintf("tile:greenspaces:%d:%d:%d", z, x, y)
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+	if err := checkTileLayerVisible(domain.TileLayerGreenSpaces, z); err != nil {
+		return nil, err
+	}
+
+	if tile, ok := archivedTile(ctx, uc.tileArchive, uc.logger, domain.TileLayerGreenSpaces, z, x, y); ok {
+		return tile, nil
+	}
+
+	cacheKey := domain.TileCacheKey("greenspaces", z, x, y)
 	cached, err := uc.cacheRepo.Get(ctx, cacheKey)
 	if err == nil && cached != nil {
 		return cached, nil
@@ -125,7 +185,18 @@ func (uc *TileUseCase) GetGreenSpacesTile(ctx context.Context, z, x, y int) ([]b
 
 // GetWaterTile возвращает MVT тайл с водными объектами
 func (uc *TileUseCase) GetWaterTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	cacheKey := fmt.Sprintf("tile:water:%d:%d:%d", z, x, y)
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+	if err := checkTileLayerVisible(domain.TileLayerWater, z); err != nil {
+		return nil, err
+	}
+
+	if tile, ok := archivedTile(ctx, uc.tileArchive, uc.logger, domain.TileLayerWater, z, x, y); ok {
+		return tile, nil
+	}
+
+	cacheKey := domain.TileCacheKey("water", z, x, y)
 	cached, err := uc.cacheRepo.Get(ctx, cacheKey)
 	if err == nil && cached != nil {
 		return cached, nil
@@ -143,7 +214,18 @@ func (uc *TileUseCase) GetWaterTile(ctx context.Context, z, x, y int) ([]byte, e
 
 // GetBeachesTile возвращает MVT тайл с пляжами
 func (uc *TileUseCase) GetBeachesTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	cacheKey := fmt.Sprintf("tile:beaches:%d:%d:%d", z, x, y)
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+	if err := checkTileLayerVisible(domain.TileLayerBeaches, z); err != nil {
+		return nil, err
+	}
+
+	if tile, ok := archivedTile(ctx, uc.tileArchive, uc.logger, domain.TileLayerBeaches, z, x, y); ok {
+		return tile, nil
+	}
+
+	cacheKey := domain.TileCacheKey("beaches", z, x, y)
 	cached, err := uc.cacheRepo.Get(ctx, cacheKey)
 	if err == nil && cached != nil {
 		return cached, nil
@@ -161,7 +243,18 @@ func (uc *TileUseCase) GetBeachesTile(ctx context.Context, z, x, y int) ([]byte,
 
 // GetNoiseSourcesTile возвращает MVT тайл с источниками шума
 func (uc *TileUseCase) GetNoiseSourcesTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	cacheKey := fmt.Sprintf("tile:noise:%d:%d:%d", z, x, y)
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+	if err := checkTileLayerVisible(domain.TileLayerNoiseSources, z); err != nil {
+		return nil, err
+	}
+
+	if tile, ok := archivedTile(ctx, uc.tileArchive, uc.logger, domain.TileLayerNoiseSources, z, x, y); ok {
+		return tile, nil
+	}
+
+	cacheKey := domain.TileCacheKey("noise", z, x, y)
 	cached, err := uc.cacheRepo.Get(ctx, cacheKey)
 	if err == nil && cached != nil {
 		return cached, nil
@@ -179,7 +272,18 @@ func (uc *TileUseCase) GetNoiseSourcesTile(ctx context.Context, z, x, y int) ([]
 
 // GetTouristZonesTile возвращает MVT тайл с туристическими зонами
 func (uc *TileUseCase) GetTouristZonesTile(ctx context.Context, z, x, y int) ([]byte, error) {
-	cacheKey := fmt.Sprintf("tile:tourist:%d:%d:%d", z, x, y)
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+	if err := checkTileLayerVisible(domain.TileLayerTouristZones, z); err != nil {
+		return nil, err
+	}
+
+	if tile, ok := archivedTile(ctx, uc.tileArchive, uc.logger, domain.TileLayerTouristZones, z, x, y); ok {
+		return tile, nil
+	}
+
+	cacheKey := domain.TileCacheKey("tourist", z, x, y)
 	cached, err := uc.cacheRepo.Get(ctx, cacheKey)
 	if err == nil && cached != nil {
 		return cached, nil
@@ -246,8 +350,8 @@ func (uc *TileUseCase) GetRadiusTiles(ctx context.Context, req dto.RadiusTilesRe
 
 	// Создаем cache key с округленными координатами для лучшего cache hit rate
 	layersHash := fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprintf("%v", layers))))
-	cacheKey := fmt.Sprintf("radius-tiles:%.4f:%.4f:%.2f:%s",
-		req.Lat, req.Lon, req.RadiusKm, layersHash)
+	cacheKey := fmt.Sprintf("%s:radius:%.4f:%.4f:%.2f:%s",
+		domain.CacheNamespaceTiles, req.Lat, req.Lon, req.RadiusKm, layersHash)
 
 	// Проверяем кеш
 	cached, err := uc.cacheRepo.Get(ctx, cacheKey)
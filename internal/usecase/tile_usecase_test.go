@@ -0,0 +1,197 @@
+package usecase_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase"
+)
+
+func TestTileUseCase_LayerVisibility(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("layer hidden at zoom returns layer disabled", func(t *testing.T) {
+		envRepo := new(mockEnvironmentRepository)
+		cacheRepo := new(MockCacheRepository)
+		uc := usecase.NewTileUseCase(nil, nil, envRepo, nil, cacheRepo, nil, nil, zap.NewNop(), time.Hour)
+
+		// Пляжи по умолчанию видны с 12 зума
+		_, err := uc.GetBeachesTile(ctx, 8, 128, 96)
+
+		assert.ErrorIs(t, err, errors.ErrTileLayerDisabled)
+		envRepo.AssertNotCalled(t, "GetBeachesTile")
+		cacheRepo.AssertNotCalled(t, "Get")
+	})
+
+	t.Run("empty tile at visible zoom is not an error", func(t *testing.T) {
+		envRepo := new(mockEnvironmentRepository)
+		cacheRepo := new(MockCacheRepository)
+		uc := usecase.NewTileUseCase(nil, nil, envRepo, nil, cacheRepo, nil, nil, zap.NewNop(), time.Hour)
+
+		cacheRepo.On("Get", mock.Anything, "tile:beaches:14:8290:6119").Return(nil, nil)
+		cacheRepo.On("Set", mock.Anything, "tile:beaches:14:8290:6119", []byte{}, time.Hour).Return(nil)
+		envRepo.On("GetBeachesTile", mock.Anything, 14, 8290, 6119).Return([]byte{}, nil)
+
+		tile, err := uc.GetBeachesTile(ctx, 14, 8290, 6119)
+
+		assert.NoError(t, err)
+		assert.Empty(t, tile)
+		envRepo.AssertExpectations(t)
+	})
+}
+
+func TestTileUseCase_TileCoordinates(t *testing.T) {
+	ctx := context.Background()
+	envRepo := new(mockEnvironmentRepository)
+	cacheRepo := new(MockCacheRepository)
+	uc := usecase.NewTileUseCase(nil, nil, envRepo, nil, cacheRepo, nil, nil, zap.NewNop(), time.Hour)
+
+	cases := map[string]struct {
+		z, x, y int
+		want    error
+	}{
+		"x beyond 2^z":   {z: 2, x: 5000, y: 1, want: errors.ErrInvalidTileCoordinates},
+		"y equals 2^z":   {z: 14, x: 8290, y: 16384, want: errors.ErrInvalidTileCoordinates},
+		"negative x":     {z: 14, x: -1, y: 6119, want: errors.ErrInvalidTileCoordinates},
+		"zoom too large": {z: 23, x: 0, y: 0, want: errors.ErrInvalidZoom},
+		"negative zoom":  {z: -1, x: 0, y: 0, want: errors.ErrInvalidZoom},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := uc.GetWaterTile(ctx, tc.z, tc.x, tc.y)
+			assert.ErrorIs(t, err, tc.want)
+
+			_, err = uc.GetTransportTile(ctx, tc.z, tc.x, tc.y)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+
+	envRepo.AssertNotCalled(t, "GetWaterTile")
+	cacheRepo.AssertNotCalled(t, "Get")
+}
+
+func TestTileUseCase_GetTileSchema_LayerMinZoom(t *testing.T) {
+	tileSchema := []domain.TileLayerSchema{
+		{Name: string(domain.TileLayerBeaches), Geometry: "polygon"},
+		{Name: "stations", Geometry: "point"},
+	}
+	usecase.ConfigureTileZoomPolicy(domain.DefaultTileZoomPolicy().WithLayerMinZooms(map[domain.TileLayer]int{
+		domain.TileLayerBeaches: 13,
+	}))
+	defer usecase.ConfigureTileZoomPolicy(domain.DefaultTileZoomPolicy())
+
+	uc := usecase.NewTileUseCase(nil, nil, nil, nil, nil, nil, tileSchema, zap.NewNop(), time.Hour)
+	schema := uc.GetTileSchema()
+
+	assert.Len(t, schema.Layers, 2)
+	if assert.NotNil(t, schema.Layers[0].MinZoom) {
+		assert.Equal(t, 13, *schema.Layers[0].MinZoom)
+	}
+	assert.Nil(t, schema.Layers[1].MinZoom, "stations are not governed by the zoom policy")
+}
+
+func TestTileUseCase_GetTileDelta(t *testing.T) {
+	uc := usecase.NewTileUseCase(nil, nil, nil, nil, nil, nil, nil, zap.NewNop(), time.Hour)
+
+	assert.NoError(t, usecase.ConfigureTileDataVersion("2026-10-01"))
+	defer usecase.ConfigureTileDataVersion("")
+
+	t.Run("etag of current version is unchanged", func(t *testing.T) {
+		since := domain.TileETag("2026-10-01", []byte("tile"))
+
+		result, err := uc.GetTileDelta("water", 14, 8290, 6119, since)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TileDeltaUnchanged, result.Status)
+		assert.Equal(t, "2026-10-01", result.DataVersion)
+		assert.Empty(t, result.Changes)
+	})
+
+	t.Run("older version or content etag needs full tile", func(t *testing.T) {
+		for _, since := range []string{
+			domain.TileETag("2026-09-01", []byte("tile")),
+			domain.TileETag("", []byte("tile")),
+			"",
+		} {
+			result, err := uc.GetTileDelta("transport", 14, 8290, 6119, since)
+
+			assert.NoError(t, err)
+			assert.Equal(t, domain.TileDeltaFull, result.Status, since)
+		}
+	})
+
+	t.Run("unknown layer and invalid coordinates rejected", func(t *testing.T) {
+		_, err := uc.GetTileDelta("roads", 14, 8290, 6119, "")
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+
+		_, err = uc.GetTileDelta("water", 2, 5000, 1, "")
+		assert.ErrorIs(t, err, errors.ErrInvalidTileCoordinates)
+	})
+
+	t.Run("quotes in version rejected", func(t *testing.T) {
+		assert.Error(t, usecase.ConfigureTileDataVersion(`v"1`))
+		assert.Equal(t, "2026-10-01", usecase.TileDataVersion())
+	})
+}
+
+func TestTileUseCase_GetTileCover(t *testing.T) {
+	uc := usecase.NewTileUseCase(nil, nil, nil, nil, nil, nil, nil, zap.NewNop(), time.Hour)
+
+	t.Run("tiles covering bbox", func(t *testing.T) {
+		bbox := domain.BoundingBox{MinLat: 41.38, MinLon: 2.15, MaxLat: 41.40, MaxLon: 2.18}
+
+		result, err := uc.GetTileCover(bbox, 14)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 6, result.Count)
+		assert.Len(t, result.Tiles, 6)
+		assert.Equal(t, domain.TileCoord{Z: 14, X: 8289, Y: 6118}, result.Tiles[0])
+		assert.Equal(t, 8291, result.MaxX)
+		assert.Equal(t, 6119, result.MaxY)
+	})
+
+	t.Run("too many tiles rejected", func(t *testing.T) {
+		world := domain.BoundingBox{MinLat: -90, MinLon: -180, MaxLat: 90, MaxLon: 180}
+
+		result, err := uc.GetTileCover(world, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 1024, result.Count)
+
+		_, err = uc.GetTileCover(world, 6)
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	})
+
+	t.Run("invalid zoom and bbox rejected", func(t *testing.T) {
+		bbox := domain.BoundingBox{MinLat: 41.38, MinLon: 2.15, MaxLat: 41.40, MaxLon: 2.18}
+
+		_, err := uc.GetTileCover(bbox, 23)
+		assert.ErrorIs(t, err, errors.ErrInvalidZoom)
+
+		_, err = uc.GetTileCover(domain.BoundingBox{MinLat: 41.40, MinLon: 2.15, MaxLat: 41.38, MaxLon: 2.18}, 14)
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+
+		_, err = uc.GetTileCover(domain.BoundingBox{MinLat: 41.38, MinLon: 2.15, MaxLat: 95, MaxLon: 2.18}, 14)
+		assert.ErrorIs(t, err, errors.ErrInvalidCoordinates)
+		_, err = uc.GetTileCover(domain.BoundingBox{MinLat: 41.39, MinLon: math.NaN(), MaxLat: 41.39, MaxLon: 2.18}, 14)
+		assert.ErrorIs(t, err, errors.ErrInvalidCoordinates)
+
+		_, err = uc.GetTileCover(domain.BoundingBox{MinLat: 41.39, MinLon: 2.15, MaxLat: 41.39, MaxLon: math.Inf(1)}, 14)
+		assert.ErrorIs(t, err, errors.ErrInvalidCoordinates)
+	})
+
+	t.Run("wide strip at max zoom rejected", func(t *testing.T) {
+		strip := domain.BoundingBox{MinLat: 41.39, MinLon: -180, MaxLat: 41.39, MaxLon: 180}
+
+		_, err := uc.GetTileCover(strip, domain.TileMaxZoom)
+
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	})
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase/dto"
+)
+
+// tileDataVersion - версия данных тайлов (TILE_DATA_VERSION), пусто - версия не задана
+var tileDataVersion string
+
+// ConfigureTileDataVersion задает версию данных тайлов. Версия входит в ETag в кавычках,
+// поэтому кавычки и пробельные символы в ней недопустимы.
+// Вызывается один раз при старте, до обработки запросов.
+func ConfigureTileDataVersion(version string) error {
+	if strings.ContainsAny(version, "\" \t\r\n,") {
+		return fmt.Errorf("invalid tile data version %q: quotes, commas and spaces are not allowed", version)
+	}
+	tileDataVersion = version
+	return nil
+}
+
+// TileDataVersion возвращает текущую версию данных тайлов
+func TileDataVersion() string {
+	return tileDataVersion
+}
+
+// tileDeltaLayers - слои z/x/y тайлов, для которых доступна дельта
+var tileDeltaLayers = map[string]bool{
+	string(domain.TileLayerBoundaries):   true,
+	string(domain.TileLayerGreenSpaces):  true,
+	string(domain.TileLayerWater):        true,
+	string(domain.TileLayerBeaches):      true,
+	string(domain.TileLayerNoiseSources): true,
+	string(domain.TileLayerTouristZones): true,
+	string(domain.TileLayerPOI):          true,
+	"transport":                          true,
+}
+
+// GetTileDelta сравнивает версию тайла клиента (since - его ETag) с текущей версией данных.
+// Тайлы меняются только с импортом данных, поэтому совпавшая версия означает, что тайл актуален,
+// и тайл не генерируется. Пообъектный diff пока не строится: при другой версии - статус full.
+func (uc *TileUseCase) GetTileDelta(layer string, z, x, y int, since string) (*dto.TileDeltaResponse, error) {
+	if !tileDeltaLayers[layer] {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"layer": layer,
+		})
+	}
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.TileDeltaResponse{
+		Layer:       layer,
+		Z:           z,
+		X:           x,
+		Y:           y,
+		Status:      domain.TileDeltaFull,
+		DataVersion: tileDataVersion,
+		Since:       since,
+	}
+	if tileDataVersion != "" && domain.TileETagVersion(since) == tileDataVersion {
+		resp.Status = domain.TileDeltaUnchanged
+	}
+	return resp, nil
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+)
+
+// tileZoomPolicy - политика слоев по зумам, та же, что у репозиториев тайлов.
+// Задается при старте через ConfigureTileZoomPolicy.
+var tileZoomPolicy = domain.DefaultTileZoomPolicy()
+
+// ConfigureTileZoomPolicy подключает политику тайлов из конфига.
+// Вызывается один раз при старте, до обработки запросов.
+func ConfigureTileZoomPolicy(policy domain.TileZoomPolicy) {
+	tileZoomPolicy = policy
+}
+
+// checkTileLayerVisible возвращает ErrTileLayerDisabled (404), если слой не показывается на зуме z.
+// Так клиент отличает выключенный слой от пустого тайла (204) и ошибки генерации (500).
+func checkTileLayerVisible(layer domain.TileLayer, z int) error {
+	if !tileZoomPolicy.LayerVisible(layer, z) {
+		return errors.ErrTileLayerDisabled.WithDetails(map[string]interface{}{
+			"layer": string(layer),
+			"zoom":  z,
+		})
+	}
+	return nil
+}
+
+// validateTileCoordinates проверяет тайл до запроса к БД: 0 <= z <= TileMaxZoom и 0 <= x, y < 2^z.
+// Иначе ST_TileEnvelope строит бессмысленный конверт и клиент получает пустой тайл вместо 400.
+func validateTileCoordinates(z, x, y int) error {
+	if z < 0 || z > domain.TileMaxZoom {
+		return errors.ErrInvalidZoom.WithDetails(map[string]interface{}{
+			"zoom":     z,
+			"max_zoom": domain.TileMaxZoom,
+		})
+	}
+	if maxTile := 1 << z; x < 0 || x >= maxTile || y < 0 || y >= maxTile {
+		return errors.ErrInvalidTileCoordinates.WithDetails(map[string]interface{}{
+			"z":         z,
+			"x":         x,
+			"y":         y,
+			"max_coord": maxTile - 1,
+		})
+	}
+	return nil
+}
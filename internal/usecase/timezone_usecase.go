@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/location-microservice/internal/domain/repository"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
+	"github.com/location-microservice/internal/usecase/dto"
+	"go.uber.org/zap"
+)
+
+// TimezoneUseCase - часовой пояс координаты для расписаний и расчета opening_hours в местном времени
+type TimezoneUseCase struct {
+	timezoneRepo repository.TimezoneRepository
+	logger       *zap.Logger
+}
+
+// NewTimezoneUseCase создает новый экземпляр TimezoneUseCase
+func NewTimezoneUseCase(timezoneRepo repository.TimezoneRepository, logger *zap.Logger) *TimezoneUseCase {
+	return &TimezoneUseCase{
+		timezoneRepo: timezoneRepo,
+		logger:       logger,
+	}
+}
+
+// GetLocation возвращает часовой пояс точки как *time.Location.
+// Пояс, неизвестный базе tzdata (опечатка в теге OSM), считается отсутствующим.
+func (uc *TimezoneUseCase) GetLocation(ctx context.Context, lat, lon float64) (*time.Location, error) {
+	if !utils.ValidateCoordinates(lat, lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+
+	tz, err := uc.timezoneRepo.GetTimezone(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		uc.logger.Warn("Unknown timezone in OSM data",
+			zap.String("timezone", tz),
+			zap.Float64("lat", lat),
+			zap.Float64("lon", lon),
+			zap.Error(err))
+		return nil, errors.ErrTimezoneUnavailable
+	}
+
+	return loc, nil
+}
+
+// GetTimezone возвращает идентификатор IANA и текущее смещение от UTC для точки
+func (uc *TimezoneUseCase) GetTimezone(ctx context.Context, lat, lon float64) (*dto.TimezoneResponse, error) {
+	loc, err := uc.GetLocation(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TimezoneResponse{
+		Timezone:  loc.String(),
+		UTCOffset: time.Now().In(loc).Format("-07:00"),
+	}, nil
+}
@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/usecase"
+)
+
+// ---- Mock Timezone Repository ----
+
+type mockTimezoneRepository struct {
+	mock.Mock
+}
+
+func (m *mockTimezoneRepository) GetTimezone(ctx context.Context, lat, lon float64) (string, error) {
+	args := m.Called(ctx, lat, lon)
+	return args.String(0), args.Error(1)
+}
+
+func TestTimezoneUseCase_GetTimezone(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("timezone from repository", func(t *testing.T) {
+		repo := new(mockTimezoneRepository)
+		uc := usecase.NewTimezoneUseCase(repo, zap.NewNop())
+		repo.On("GetTimezone", ctx, 28.12, -15.43).Return("Atlantic/Canary", nil)
+
+		result, err := uc.GetTimezone(ctx, 28.12, -15.43)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Atlantic/Canary", result.Timezone)
+		assert.Contains(t, []string{"+00:00", "+01:00"}, result.UTCOffset)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("no timezone data", func(t *testing.T) {
+		repo := new(mockTimezoneRepository)
+		uc := usecase.NewTimezoneUseCase(repo, zap.NewNop())
+		repo.On("GetTimezone", ctx, 0.5, -30.0).Return("", errors.ErrTimezoneUnavailable)
+
+		_, err := uc.GetTimezone(ctx, 0.5, -30.0)
+
+		assert.ErrorIs(t, err, errors.ErrTimezoneUnavailable)
+	})
+
+	t.Run("unknown timezone tag", func(t *testing.T) {
+		repo := new(mockTimezoneRepository)
+		uc := usecase.NewTimezoneUseCase(repo, zap.NewNop())
+		repo.On("GetTimezone", ctx, 41.39, 2.17).Return("Europe/Barcelona", nil)
+
+		_, err := uc.GetLocation(ctx, 41.39, 2.17)
+
+		assert.ErrorIs(t, err, errors.ErrTimezoneUnavailable)
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		repo := new(mockTimezoneRepository)
+		uc := usecase.NewTimezoneUseCase(repo, zap.NewNop())
+
+		_, err := uc.GetTimezone(ctx, 91, 0)
+
+		assert.ErrorIs(t, err, errors.ErrInvalidCoordinates)
+		repo.AssertNotCalled(t, "GetTimezone", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -2,7 +2,11 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"slices"
+	"sort"
+	"strconv"
 
 	"github.com/location-microservice/internal/domain"
 	"github.com/location-microservice/internal/domain/repository"
@@ -12,6 +16,12 @@ import (
 	"go.uber.org/zap"
 )
 
+// walkingDetourFactor - поправка прямого расстояния на обход кварталов при оценке пешего пути
+const walkingDetourFactor = 1.2
+
+// defaultWalkingSpeedMps - скорость пешехода для оценки времени ходьбы (1.39 м/с = ~5 км/ч)
+const defaultWalkingSpeedMps = 1.39
+
 type TransportUseCase struct {
 	transportRepo   repository.TransportRepository
 	logger          *zap.Logger
@@ -27,7 +37,7 @@ func NewTransportUseCase(
 		transportRepo:   transportRepo,
 		logger:          logger,
 		defaultRadius:   1500, // 1.5 km
-		walkingSpeedMps: 1.39, // ~5 km/h
+		walkingSpeedMps: defaultWalkingSpeedMps,
 	}
 }
 
@@ -53,6 +63,8 @@ func (uc *TransportUseCase) GetNearestStations(
 		req.Types,
 		req.MaxDistance,
 		5, // limit to 5 stations
+		req.SurfaceOnly,
+		req.ExcludeWithinM,
 	)
 	if err != nil {
 		uc.logger.Error("Failed to get nearest stations", zap.Error(err))
@@ -74,7 +86,7 @@ func (uc *TransportUseCase) GetNearestStations(
 		}
 
 		// Calculate distance
-		distance := utils.HaversineDistance(req.Lat, req.Lon, station.Lat, station.Lon) * 1000 // to meters
+		distance := math.Round(utils.DistanceMeters(station.DistanceM, req.Lat, req.Lon, station.Lat, station.Lon)*100) / 100
 
 		// Convert to DTO with string IDs
 		result = append(result, dto.ConvertTransportStation(station, transportLines, distance))
@@ -90,6 +102,10 @@ func (uc *TransportUseCase) BatchGetNearestStations(
 	ctx context.Context,
 	req dto.BatchNearestTransportRequest,
 ) (*dto.BatchNearestTransportResponse, error) {
+	if err := validateBatchSize(len(req.Points)); err != nil {
+		return nil, err
+	}
+
 	// Валидация входных данных
 	for i, point := range req.Points {
 		if !utils.ValidateCoordinates(point.Lat, point.Lon) {
@@ -117,62 +133,66 @@ func (uc *TransportUseCase) BatchGetNearestStations(
 	// Канал для результатов
 	resultsChan := make(chan indexedResult, len(req.Points))
 
-	// Параллельная обработка каждой точки
-	for i, point := range req.Points {
-		go func(idx int, pt dto.Point) {
-			// Получение ближайших станций
-			stations, err := uc.transportRepo.GetNearestStations(
-				ctx,
-				pt.Lat,
-				pt.Lon,
-				req.Types,
-				maxDistance,
-				5, // лимит на 5 станций
-			)
-			if err != nil {
-				uc.logger.Error("Failed to get nearest stations in batch",
-					zap.Int("point_index", idx),
-					zap.Error(err))
-				resultsChan <- indexedResult{index: idx, err: err}
-				return
-			}
+	// Параллельная обработка точек под-пакета, под-пакеты выполняются последовательно
+	results := make([][]dto.TransportStationWithLines, len(req.Points))
+	for _, chunk := range batchChunks(len(req.Points)) {
+		for i := chunk[0]; i < chunk[1]; i++ {
+			go func(idx int, pt dto.Point) {
+				// Получение ближайших станций
+				stations, err := uc.transportRepo.GetNearestStations(
+					ctx,
+					pt.Lat,
+					pt.Lon,
+					req.Types,
+					maxDistance,
+					5, // лимит на 5 станций
+					false,
+					req.ExcludeWithinM,
+				)
+				if err != nil {
+					uc.logger.Error("Failed to get nearest stations in batch",
+						zap.Int("point_index", idx),
+						zap.Error(err))
+					resultsChan <- indexedResult{index: idx, err: err}
+					return
+				}
 
-			// Формирование результата с линиями
-			result := make([]dto.TransportStationWithLines, 0, len(stations))
-			for _, station := range stations {
-				// Получение линий для станции
-				var transportLines []*domain.TransportLine
-				if len(station.LineIDs) > 0 {
-					lines, err := uc.transportRepo.GetLinesByIDs(ctx, station.LineIDs)
-					if err != nil {
-						uc.logger.Warn("Failed to get lines for station in batch",
-							zap.Int64("station_id", station.ID),
-							zap.Int("point_index", idx))
-					} else {
-						transportLines = lines
+				// Формирование результата с линиями
+				result := make([]dto.TransportStationWithLines, 0, len(stations))
+				for _, station := range stations {
+					// Получение линий для станции
+					var transportLines []*domain.TransportLine
+					if len(station.LineIDs) > 0 {
+						lines, err := uc.transportRepo.GetLinesByIDs(ctx, station.LineIDs)
+						if err != nil {
+							uc.logger.Warn("Failed to get lines for station in batch",
+								zap.Int64("station_id", station.ID),
+								zap.Int("point_index", idx))
+						} else {
+							transportLines = lines
+						}
 					}
-				}
 
-				// Расчет расстояния
-				distance := utils.HaversineDistance(pt.Lat, pt.Lon, station.Lat, station.Lon) * 1000 // в метры
+					// Расчет расстояния
+					distance := math.Round(utils.DistanceMeters(station.DistanceM, pt.Lat, pt.Lon, station.Lat, station.Lon)*100) / 100
 
-				// Convert to DTO with string IDs
-				result = append(result, dto.ConvertTransportStation(station, transportLines, distance))
-			}
+					// Convert to DTO with string IDs
+					result = append(result, dto.ConvertTransportStation(station, transportLines, distance))
+				}
 
-			resultsChan <- indexedResult{index: idx, stations: result}
-		}(i, point)
-	}
+				resultsChan <- indexedResult{index: idx, stations: result}
+			}(i, req.Points[i])
+		}
 
-	// Сбор результатов
-	results := make([][]dto.TransportStationWithLines, len(req.Points))
-	for i := 0; i < len(req.Points); i++ {
-		res := <-resultsChan
-		if res.err != nil {
-			// Возвращаем пустой массив для точек с ошибкой
-			results[res.index] = []dto.TransportStationWithLines{}
-		} else {
-			results[res.index] = res.stations
+		// Сбор результатов под-пакета
+		for i := chunk[0]; i < chunk[1]; i++ {
+			res := <-resultsChan
+			if res.err != nil {
+				// Возвращаем пустой массив для точек с ошибкой
+				results[res.index] = []dto.TransportStationWithLines{}
+			} else {
+				results[res.index] = res.stations
+			}
 		}
 	}
 	close(resultsChan)
@@ -182,11 +202,11 @@ func (uc *TransportUseCase) BatchGetNearestStations(
 	}, nil
 }
 
-// GetTransportTileByTypes возвращает MVT тайл с транспортом с фильтрацией по типам
-func (uc *TransportUseCase) GetTransportTileByTypes(ctx context.Context, z, x, y int, types []string) ([]byte, error) {
-	// Валидация zoom level
-	if z < 0 || z > 18 {
-		return nil, errors.ErrInvalidZoom
+// GetTransportTileByTypes возвращает MVT тайл с транспортом с фильтрацией по типам.
+// surfaceOnly скрывает подземные и indoor станции.
+func (uc *TransportUseCase) GetTransportTileByTypes(ctx context.Context, z, x, y int, types []string, surfaceOnly bool) ([]byte, error) {
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
 	}
 
 	// Валидация типов транспорта
@@ -200,7 +220,7 @@ func (uc *TransportUseCase) GetTransportTileByTypes(ctx context.Context, z, x, y
 	}
 
 	// Получаем тайл из репозитория
-	tile, err := uc.transportRepo.GetTransportTileByTypes(ctx, z, x, y, types)
+	tile, err := uc.transportRepo.GetTransportTileByTypes(ctx, z, x, y, types, surfaceOnly)
 	if err != nil {
 		uc.logger.Error("Failed to get transport tile by types",
 			zap.Int("z", z),
@@ -214,6 +234,25 @@ func (uc *TransportUseCase) GetTransportTileByTypes(ctx context.Context, z, x, y
 	return tile, nil
 }
 
+// GetStationByID возвращает станцию с линиями, проходящими через нее
+func (uc *TransportUseCase) GetStationByID(ctx context.Context, stationID int64) (*domain.TransportStation, []*domain.TransportLine, error) {
+	station, err := uc.transportRepo.GetStationByID(ctx, stationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	station.Lat, station.Lon = utils.RoundCoordinate(station.Lat), utils.RoundCoordinate(station.Lon)
+
+	lines, err := uc.GetLinesByStationID(ctx, stationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, line := range lines {
+		station.LineIDs = append(station.LineIDs, line.ID)
+	}
+
+	return station, lines, nil
+}
+
 // GetLinesByStationID возвращает линии для станции (для hover логики)
 func (uc *TransportUseCase) GetLinesByStationID(ctx context.Context, stationID int64) ([]*domain.TransportLine, error) {
 	lines, err := uc.transportRepo.GetLinesByStationID(ctx, stationID)
@@ -227,6 +266,248 @@ func (uc *TransportUseCase) GetLinesByStationID(ctx context.Context, stationID i
 	return lines, nil
 }
 
+// Буфер вокруг линии для GetStationsAlongLine (метры)
+const (
+	DefaultStationsAlongLineBufferM = 50.0
+	maxStationsAlongLineBufferM     = 500.0
+)
+
+// GetStationsAlongLine возвращает приближенный упорядоченный список станций линии по геометрии:
+// станции в буфере вокруг линии, отсортированные по положению вдоль нее.
+// Будет заменен разбором relation members, когда он появится.
+func (uc *TransportUseCase) GetStationsAlongLine(ctx context.Context, lineID int64, bufferM float64) ([]*domain.TransportStation, error) {
+	if bufferM == 0 {
+		bufferM = DefaultStationsAlongLineBufferM
+	}
+	if bufferM < 0 || bufferM > maxStationsAlongLineBufferM {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"buffer_m": fmt.Sprintf("must be between 0 and %.0f", maxStationsAlongLineBufferM),
+		})
+	}
+
+	// Проверяем существование линии, чтобы отличить неизвестную линию от линии без станций
+	if _, err := uc.transportRepo.GetLineByID(ctx, lineID); err != nil {
+		return nil, err
+	}
+
+	stations, err := uc.transportRepo.GetStationsAlongLine(ctx, lineID, bufferM)
+	if err != nil {
+		uc.logger.Error("Failed to get stations along line",
+			zap.Int64("line_id", lineID),
+			zap.Float64("buffer_m", bufferM),
+			zap.Error(err))
+		return nil, err
+	}
+
+	for _, s := range stations {
+		s.Lat, s.Lon = utils.RoundCoordinate(s.Lat), utils.RoundCoordinate(s.Lon)
+	}
+
+	return stations, nil
+}
+
+// GetStationsInBoundary возвращает станции внутри административной границы (например, все станции метро города).
+// Пустой types - все типы транспорта.
+func (uc *TransportUseCase) GetStationsInBoundary(ctx context.Context, boundaryID int64, types []string) ([]*domain.TransportStation, error) {
+	stations, err := uc.transportRepo.GetStationsInBoundary(ctx, boundaryID, types)
+	if err != nil {
+		uc.logger.Error("Failed to get stations in boundary",
+			zap.Int64("boundary_id", boundaryID),
+			zap.Strings("types", types),
+			zap.Error(err))
+		return nil, err
+	}
+
+	for _, s := range stations {
+		s.Lat, s.Lon = utils.RoundCoordinate(s.Lat), utils.RoundCoordinate(s.Lon)
+	}
+
+	return stations, nil
+}
+
+// Ограничения для GetTransportCoverage
+const (
+	maxCoverageBands   = 10
+	maxCoverageRadiusM = 5000.0
+)
+
+// DefaultCoverageBands - радиусы по умолчанию для покрытия транспортом (метры)
+var DefaultCoverageBands = []float64{300, 600, 1000}
+
+// GetTransportCoverage возвращает количество станций по типам во вложенных радиусах вокруг точки.
+// Радиусы сортируются по возрастанию, дубли отбрасываются.
+func (uc *TransportUseCase) GetTransportCoverage(ctx context.Context, lat, lon float64, bands []float64) (*domain.TransportCoverage, error) {
+	if !utils.ValidateCoordinates(lat, lon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+
+	if len(bands) == 0 {
+		bands = DefaultCoverageBands
+	}
+	if len(bands) > maxCoverageBands {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"bands": fmt.Sprintf("at most %d bands allowed", maxCoverageBands),
+		})
+	}
+
+	normalized := make([]float64, 0, len(bands))
+	seen := make(map[float64]bool, len(bands))
+	for _, b := range bands {
+		if b <= 0 || b > maxCoverageRadiusM {
+			return nil, errors.ErrInvalidRadius
+		}
+		if !seen[b] {
+			seen[b] = true
+			normalized = append(normalized, b)
+		}
+	}
+	sort.Float64s(normalized)
+
+	coverage, err := uc.transportRepo.GetTransportCoverage(ctx, lat, lon, normalized)
+	if err != nil {
+		uc.logger.Error("Failed to get transport coverage",
+			zap.Float64("lat", lat),
+			zap.Float64("lon", lon),
+			zap.Float64s("bands", normalized),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return coverage, nil
+}
+
+// GetLinesGeometry возвращает GeoJSON геометрии нескольких линий одним запросом
+// (порядок ответа соответствует порядку ID в запросе)
+func (uc *TransportUseCase) GetLinesGeometry(
+	ctx context.Context,
+	req dto.TransportLinesGeometryRequest,
+) (*dto.TransportLinesGeometryResponse, error) {
+	lineIDs := make([]int64, 0, len(req.IDs))
+	for _, idStr := range req.IDs {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+				"id": idStr,
+			})
+		}
+		lineIDs = append(lineIDs, id)
+	}
+
+	geometries, err := uc.transportRepo.GetLinesGeometry(ctx, lineIDs, req.DedupeByRef)
+	if err != nil {
+		uc.logger.Error("Failed to get lines geometry",
+			zap.Int64s("line_ids", lineIDs),
+			zap.Error(err))
+		return nil, err
+	}
+
+	lines := make([]dto.TransportLineGeometryDTO, 0, len(geometries))
+	seen := make(map[int64]bool, len(geometries))
+	for _, id := range lineIDs {
+		g, ok := geometries[id]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		lines = append(lines, dto.TransportLineGeometryDTO{
+			ID:       strconv.FormatInt(g.LineID, 10),
+			Name:     g.Name,
+			Ref:      g.Ref,
+			Type:     g.Type,
+			Color:    g.Color,
+			Geometry: g.Geometry,
+		})
+	}
+
+	return &dto.TransportLinesGeometryResponse{
+		Lines: lines,
+		Total: len(lines),
+	}, nil
+}
+
+// maxLinesBBoxSpanDeg - максимальная сторона bbox для линий с геометрией: на большей области
+// ответ превращается в выгрузку всей сети
+const maxLinesBBoxSpanDeg = 0.5
+
+// GetLinesInBBox возвращает линии транспорта, проходящие через bbox, с геометрией, обрезанной по bbox
+func (uc *TransportUseCase) GetLinesInBBox(
+	ctx context.Context,
+	req dto.BBoxTransportLinesRequest,
+) (*dto.TransportLinesGeometryResponse, error) {
+	if !utils.ValidateCoordinates(req.SwLat, req.SwLon) || !utils.ValidateCoordinates(req.NeLat, req.NeLon) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+	if req.SwLat >= req.NeLat || req.SwLon >= req.NeLon {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"bbox": "sw corner must be south-west of ne corner",
+		})
+	}
+	if req.NeLat-req.SwLat > maxLinesBBoxSpanDeg || req.NeLon-req.SwLon > maxLinesBBoxSpanDeg {
+		return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"bbox": fmt.Sprintf("each side must not exceed %.1f degrees", maxLinesBBoxSpanDeg),
+		})
+	}
+	for _, t := range req.Types {
+		if !domain.IsValidTransportType(t) {
+			return nil, errors.ErrInvalidTransportType
+		}
+	}
+
+	bbox := domain.BoundingBox{MinLat: req.SwLat, MinLon: req.SwLon, MaxLat: req.NeLat, MaxLon: req.NeLon}
+	geometries, err := uc.transportRepo.GetLinesInBBox(ctx, bbox, req.Types)
+	if err != nil {
+		uc.logger.Error("Failed to get lines in bbox",
+			zap.Strings("types", req.Types),
+			zap.Error(err))
+		return nil, err
+	}
+
+	lines := make([]dto.TransportLineGeometryDTO, 0, len(geometries))
+	for _, g := range geometries {
+		lines = append(lines, dto.TransportLineGeometryDTO{
+			ID:       strconv.FormatInt(g.LineID, 10),
+			Name:     g.Name,
+			Ref:      g.Ref,
+			Type:     g.Type,
+			Color:    g.Color,
+			Geometry: g.Geometry,
+		})
+	}
+
+	return &dto.TransportLinesGeometryResponse{
+		Lines: lines,
+		Total: len(lines),
+	}, nil
+}
+
+// normalizeMinPerMode проверяет резерв слотов по видам транспорта: вид - один из PriorityTransportModes,
+// число не отрицательное; нулевые значения отбрасываются, пустой резерв - nil (строгий приоритет)
+func normalizeMinPerMode(minPerMode map[string]int) (map[string]int, error) {
+	var result map[string]int
+	for mode, count := range minPerMode {
+		if !slices.Contains(domain.PriorityTransportModes(), mode) {
+			return nil, errors.ErrInvalidTransportType.WithDetails(map[string]interface{}{
+				"min_per_mode":  mode,
+				"allowed_modes": domain.PriorityTransportModes(),
+			})
+		}
+		if count < 0 {
+			return nil, errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+				"min_per_mode": mode + " count must not be negative",
+			})
+		}
+		if count == 0 {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]int, len(minPerMode))
+		}
+		result[mode] = count
+	}
+	return result, nil
+}
+
 // GetNearestTransportByPriority возвращает ближайший транспорт с приоритетом по типу и расстоянию.
 // Приоритет: metro/train -> bus/tram (если нет высокоприоритетного в радиусе).
 func (uc *TransportUseCase) GetNearestTransportByPriority(
@@ -249,14 +530,25 @@ func (uc *TransportUseCase) GetNearestTransportByPriority(
 		limit = 5
 	}
 
+	minPerMode, err := normalizeMinPerMode(req.MinPerMode)
+	if err != nil {
+		return nil, err
+	}
+
+	distanceMode, err := parseDistanceMode(req.DistanceMode)
+	if err != nil {
+		return nil, err
+	}
+
 	uc.logger.Info("GetNearestTransportByPriority",
 		zap.Float64("lat", req.Lat),
 		zap.Float64("lon", req.Lon),
 		zap.Float64("radius", radius),
-		zap.Int("limit", limit))
+		zap.Int("limit", limit),
+		zap.Any("min_per_mode", minPerMode))
 
 	// Получаем станции с приоритетом
-	stations, err := uc.transportRepo.GetNearestTransportByPriority(ctx, req.Lat, req.Lon, radius, limit)
+	stations, err := uc.transportRepo.GetNearestTransportByPriority(ctx, req.Lat, req.Lon, radius, limit, minPerMode)
 	if err != nil {
 		uc.logger.Error("Failed to get priority transport", zap.Error(err))
 		return nil, err
@@ -265,39 +557,27 @@ func (uc *TransportUseCase) GetNearestTransportByPriority(
 	// Определяем тип приоритета (4-уровневая система)
 	hasHighPriority, priorityType := DeterminePriorityMeta(stations)
 
+	snappedByID := uc.snappedDistances(ctx, req.Lat, req.Lon, stations, distanceMode)
+
 	// Преобразуем в DTO с расчётом времени ходьбы
 	walkingSpeedKmH := uc.walkingSpeedMps * 3.6 // м/с -> км/ч
 	result := make([]dto.PriorityTransportStation, 0, len(stations))
 
 	for _, s := range stations {
-		// Примерное время пешком (манхэттенское расстояние + 20%)
-		walkingDistance := s.Distance * 1.2
-		walkingTime := walkingDistance / uc.walkingSpeedMps / 60 // в минутах
-
-		// Преобразуем линии
-		lines := make([]dto.TransportLineInfoEnriched, 0, len(s.Lines))
-		for _, line := range s.Lines {
-			lines = append(lines, dto.TransportLineInfoEnriched{
-				ID:    line.ID,
-				Name:  line.Name,
-				Ref:   line.Ref,
-				Type:  line.Type,
-				Color: line.Color,
-			})
-		}
+		d, snapped := snappedByID[s.StationID]
+		station := uc.priorityStationDTO(s, walkingDistanceM(s.DistanceM, d, snapped))
+		station.Snapped = snapped
+		result = append(result, station)
+	}
 
-		result = append(result, dto.PriorityTransportStation{
-			StationID:       s.StationID,
-			Name:            s.Name,
-			NameEn:          s.NameEn,
-			Type:            s.Type,
-			Lat:             s.Lat,
-			Lon:             s.Lon,
-			LinearDistance:  math.Round(s.Distance*100) / 100,
-			WalkingDistance: math.Round(walkingDistance*100) / 100,
-			WalkingTime:     math.Round(walkingTime*10) / 10,
-			Lines:           lines,
-		})
+	if req.GroupLinesByMode {
+		groupStationLinesByMode(result)
+	}
+
+	if req.IncludeEntrances {
+		if err := uc.attachStationEntrances(ctx, result); err != nil {
+			return nil, err
+		}
 	}
 
 	return &dto.PriorityTransportResponse{
@@ -309,10 +589,114 @@ func (uc *TransportUseCase) GetNearestTransportByPriority(
 			HasHighPriority: hasHighPriority,
 			PriorityType:    priorityType,
 			WalkingSpeedKmH: walkingSpeedKmH,
+			DistanceMode:    string(distanceMode),
 		},
 	}, nil
 }
 
+// parseDistanceMode разбирает distance_mode запроса; пусто - DistanceModeStraight
+func parseDistanceMode(raw string) (domain.DistanceMode, error) {
+	switch mode := domain.DistanceMode(raw); mode {
+	case "":
+		return domain.DistanceModeStraight, nil
+	case domain.DistanceModeStraight, domain.DistanceModeSnapped:
+		return mode, nil
+	default:
+		return "", errors.ErrInvalidRequest.WithDetails(map[string]interface{}{
+			"distance_mode": "must be straight or snapped",
+		})
+	}
+}
+
+// walkingDistanceM оценивает пеший путь до станции в метрах. Без привязки к сети - прямая + 20%
+// на обход кварталов. С привязкой - подход к дороге, средний участок и отход к станции; поправка
+// на обход применяется к среднему участку, только если он измерен не вдоль сети.
+func walkingDistanceM(linearM float64, d domain.SnappedDistance, snapped bool) float64 {
+	if !snapped {
+		return linearM * walkingDetourFactor
+	}
+	network := d.NetworkM
+	if !d.AlongNetwork {
+		network *= walkingDetourFactor
+	}
+	return d.OriginOffsetM + network + d.StationOffsetM
+}
+
+// snappedDistances возвращает расстояния через пешеходную сеть для режима snapped. Ошибка не прерывает
+// запрос: станции без привязки (и все станции при ошибке) получают расстояние по прямой.
+func (uc *TransportUseCase) snappedDistances(
+	ctx context.Context,
+	lat, lon float64,
+	stations []domain.NearestTransportWithLines,
+	mode domain.DistanceMode,
+) map[int64]domain.SnappedDistance {
+	if mode != domain.DistanceModeSnapped || len(stations) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(stations))
+	for i, s := range stations {
+		ids[i] = s.StationID
+	}
+
+	distances, err := uc.transportRepo.GetSnappedDistances(ctx, lat, lon, ids)
+	if err != nil {
+		uc.logger.Warn("Failed to get snapped distances, using straight-line", zap.Error(err))
+		return nil
+	}
+	return distances
+}
+
+// attachStationEntrances добавляет станциям метро их входы (railway=subway_entrance)
+func (uc *TransportUseCase) attachStationEntrances(ctx context.Context, stations []dto.PriorityTransportStation) error {
+	var metroIDs []int64
+	for _, s := range stations {
+		if s.Type == domain.TransportTypeMetro {
+			metroIDs = append(metroIDs, s.StationID)
+		}
+	}
+	if len(metroIDs) == 0 {
+		return nil
+	}
+
+	entrances, err := uc.transportRepo.GetStationEntrances(ctx, metroIDs, domain.StationEntranceMaxDistanceM)
+	if err != nil {
+		uc.logger.Error("Failed to get station entrances", zap.Error(err))
+		return err
+	}
+
+	for i := range stations {
+		for _, e := range entrances[stations[i].StationID] {
+			stations[i].Entrances = append(stations[i].Entrances, dto.StationEntranceDTO{
+				ID:       e.ID,
+				Name:     e.Name,
+				Ref:      e.Ref,
+				Lat:      utils.RoundCoordinate(e.Lat),
+				Lon:      utils.RoundCoordinate(e.Lon),
+				Distance: math.Round(e.DistanceM*100) / 100,
+			})
+		}
+	}
+	return nil
+}
+
+// AdaptiveRadiusM возвращает радиус поиска транспорта в точке (метры), подобранный по числу
+// станций в пробном радиусе adaptive. Если подсчет не удался, возвращает fallbackM.
+func (uc *TransportUseCase) AdaptiveRadiusM(
+	ctx context.Context,
+	lat, lon float64,
+	adaptive domain.AdaptiveTransportRadius,
+	fallbackM float64,
+) float64 {
+	count, err := uc.transportRepo.CountStationsNearby(ctx, lat, lon, nil, adaptive.ProbeRadiusM/1000)
+	if err != nil {
+		uc.logger.Warn("Failed to count stations for adaptive transport radius",
+			zap.Float64("fallback_m", fallbackM), zap.Error(err))
+		return fallbackM
+	}
+	return adaptive.RadiusFor(count)
+}
+
 // GetNearestTransportByPriorityBatch возвращает ближайший транспорт с приоритетом
 // для множества точек одним эффективным запросом к БД.
 func (uc *TransportUseCase) GetNearestTransportByPriorityBatch(
@@ -323,6 +707,9 @@ func (uc *TransportUseCase) GetNearestTransportByPriorityBatch(
 	if len(req.Points) == 0 {
 		return nil, errors.ErrInvalidRequest
 	}
+	if err := validateBatchSize(len(req.Points)); err != nil {
+		return nil, err
+	}
 
 	for _, p := range req.Points {
 		if !utils.ValidateCoordinates(p.Lat, p.Lon) {
@@ -341,6 +728,11 @@ func (uc *TransportUseCase) GetNearestTransportByPriorityBatch(
 		limit = 3
 	}
 
+	distanceMode, err := parseDistanceMode(req.DistanceMode)
+	if err != nil {
+		return nil, err
+	}
+
 	uc.logger.Info("GetNearestTransportByPriorityBatch",
 		zap.Int("points_count", len(req.Points)),
 		zap.Float64("radius", radius),
@@ -350,17 +742,25 @@ func (uc *TransportUseCase) GetNearestTransportByPriorityBatch(
 	domainPoints := make([]domain.TransportSearchPoint, len(req.Points))
 	for i, p := range req.Points {
 		domainPoints[i] = domain.TransportSearchPoint{
-			Lat:   p.Lat,
-			Lon:   p.Lon,
-			Limit: limit,
+			Lat:     p.Lat,
+			Lon:     p.Lon,
+			Limit:   limit,
+			RadiusM: p.Radius,
 		}
 	}
 
-	// Получаем станции одним запросом
-	batchResults, err := uc.transportRepo.GetNearestTransportByPriorityBatch(ctx, domainPoints, radius, limit)
-	if err != nil {
-		uc.logger.Error("Failed to get batch priority transport", zap.Error(err))
-		return nil, err
+	// Получаем станции одним запросом на под-пакет, индексы точек приводим к индексам запроса
+	batchResults := make([]domain.BatchTransportResult, 0, len(domainPoints))
+	for _, chunk := range batchChunks(len(domainPoints)) {
+		chunkResults, err := uc.transportRepo.GetNearestTransportByPriorityBatch(ctx, domainPoints[chunk[0]:chunk[1]], radius, limit)
+		if err != nil {
+			uc.logger.Error("Failed to get batch priority transport", zap.Int("chunk_start", chunk[0]), zap.Error(err))
+			return nil, err
+		}
+		for _, br := range chunkResults {
+			br.PointIndex += chunk[0]
+			batchResults = append(batchResults, br)
+		}
 	}
 
 	// Преобразуем в DTO с расчётом времени ходьбы
@@ -370,9 +770,12 @@ func (uc *TransportUseCase) GetNearestTransportByPriorityBatch(
 
 	for i, br := range batchResults {
 		stations := make([]dto.PriorityTransportStation, 0, len(br.Stations))
+		// В режиме snapped - отдельный запрос на точку: режим включается явно и дороже прямой
+		snappedByID := uc.snappedDistances(ctx, br.SearchPoint.Lat, br.SearchPoint.Lon, br.Stations, distanceMode)
 
 		for _, s := range br.Stations {
-			walkingDistance := s.Distance * 1.2
+			d, snapped := snappedByID[s.StationID]
+			walkingDistance := walkingDistanceM(s.DistanceM, d, snapped)
 			walkingTime := walkingDistance / uc.walkingSpeedMps / 60
 
 			lines := make([]dto.TransportLineInfoEnriched, 0, len(s.Lines))
@@ -391,15 +794,20 @@ func (uc *TransportUseCase) GetNearestTransportByPriorityBatch(
 				Name:            s.Name,
 				NameEn:          s.NameEn,
 				Type:            s.Type,
-				Lat:             s.Lat,
-				Lon:             s.Lon,
-				LinearDistance:  math.Round(s.Distance*100) / 100,
+				Lat:             utils.RoundCoordinate(s.Lat),
+				Lon:             utils.RoundCoordinate(s.Lon),
+				LinearDistance:  math.Round(s.DistanceM*100) / 100,
 				WalkingDistance: math.Round(walkingDistance*100) / 100,
 				WalkingTime:     math.Round(walkingTime*10) / 10,
+				Snapped:         snapped,
 				Lines:           lines,
 			})
 		}
 
+		if req.GroupLinesByMode {
+			groupStationLinesByMode(stations)
+		}
+
 		results[i] = dto.PriorityTransportPointResult{
 			PointIndex:  br.PointIndex,
 			SearchPoint: dto.Point{Lat: br.SearchPoint.Lat, Lon: br.SearchPoint.Lon},
@@ -415,10 +823,72 @@ func (uc *TransportUseCase) GetNearestTransportByPriorityBatch(
 			TotalStations:   totalStations,
 			RadiusM:         radius,
 			WalkingSpeedKmH: walkingSpeedKmH,
+			DistanceMode:    string(distanceMode),
 		},
 	}, nil
 }
 
+// lineMode сводит тип маршрута OSM (route) к виду транспорта
+func lineMode(routeType string) string {
+	switch routeType {
+	case "subway", "metro":
+		return domain.TransportTypeMetro
+	case "light_rail", "tram":
+		return domain.TransportTypeTram
+	case "":
+		return "other"
+	default:
+		return routeType
+	}
+}
+
+// lineModePriority - порядок видов транспорта при группировке линий
+func lineModePriority(mode string) int {
+	switch mode {
+	case domain.TransportTypeMetro:
+		return domain.TransportPriorityMetro
+	case domain.TransportTypeTrain:
+		return domain.TransportPriorityTrain
+	case domain.TransportTypeTram:
+		return domain.TransportPriorityTram
+	case domain.TransportTypeBus:
+		return domain.TransportPriorityBus
+	default:
+		return domain.TransportPriorityUnknown
+	}
+}
+
+// groupStationLinesByMode переносит линии каждой станции из Lines в LinesByMode.
+// Порядок линий внутри вида сохраняется, виды упорядочены по приоритету транспорта в LineModes.
+func groupStationLinesByMode(stations []dto.PriorityTransportStation) {
+	for i := range stations {
+		if len(stations[i].Lines) == 0 {
+			continue
+		}
+
+		byMode := make(map[string][]dto.TransportLineInfoEnriched)
+		var modes []string
+		for _, line := range stations[i].Lines {
+			mode := lineMode(line.Type)
+			if _, ok := byMode[mode]; !ok {
+				modes = append(modes, mode)
+			}
+			byMode[mode] = append(byMode[mode], line)
+		}
+		sort.SliceStable(modes, func(a, b int) bool {
+			pa, pb := lineModePriority(modes[a]), lineModePriority(modes[b])
+			if pa != pb {
+				return pa < pb
+			}
+			return modes[a] < modes[b]
+		})
+
+		stations[i].LinesByMode = byMode
+		stations[i].LineModes = modes
+		stations[i].Lines = nil
+	}
+}
+
 // DeterminePriorityMeta определяет наивысший приоритет среди станций и возвращает
 // hasHighPriority (true если metro или train) и label наивысшего приоритета.
 func DeterminePriorityMeta(stations []domain.NearestTransportWithLines) (bool, string) {
@@ -492,5 +962,6 @@ Stations: items,
 Total:    total,
 Limit:    req.Limit,
 Offset:   req.Offset,
+Page:     utils.OffsetPagination(req.Limit, req.Offset, total),
 }, nil
 }
@@ -9,6 +9,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/location-microservice/internal/domain"
+	"github.com/location-microservice/internal/pkg/errors"
+	"github.com/location-microservice/internal/pkg/utils"
 	"github.com/location-microservice/internal/usecase"
 	"github.com/location-microservice/internal/usecase/dto"
 )
@@ -29,7 +31,7 @@ func TestTransportUseCase_GetNearestTransportByPriority(t *testing.T) {
 				Type:      "metro",
 				Lat:       41.3850,
 				Lon:       2.1700,
-				Distance:  250.5,
+				DistanceM: 250.5,
 				Lines: []domain.TransportLineInfo{
 					{
 						ID:    1,
@@ -41,7 +43,7 @@ func TestTransportUseCase_GetNearestTransportByPriority(t *testing.T) {
 			},
 		}
 
-		mockTransportRepo.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5).
+		mockTransportRepo.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int(nil)).
 			Return(stations, nil)
 
 		req := dto.PriorityTransportRequest{
@@ -77,12 +79,12 @@ func TestTransportUseCase_GetNearestTransportByPriority(t *testing.T) {
 				Type:      "bus",
 				Lat:       41.3855,
 				Lon:       2.1740,
-				Distance:  150.0,
+				DistanceM: 150.0,
 				Lines:     []domain.TransportLineInfo{},
 			},
 		}
 
-		mockTransportRepo2.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5).
+		mockTransportRepo2.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int(nil)).
 			Return(stations, nil)
 
 		req := dto.PriorityTransportRequest{
@@ -121,7 +123,7 @@ func TestTransportUseCase_GetNearestTransportByPriority(t *testing.T) {
 		mockTransportRepo3 := &MockTransportRepository{}
 		uc3 := usecase.NewTransportUseCase(mockTransportRepo3, logger)
 
-		mockTransportRepo3.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5).
+		mockTransportRepo3.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int(nil)).
 			Return([]domain.NearestTransportWithLines{}, nil)
 
 		req := dto.PriorityTransportRequest{
@@ -138,6 +140,209 @@ func TestTransportUseCase_GetNearestTransportByPriority(t *testing.T) {
 
 		mockTransportRepo3.AssertExpectations(t)
 	})
+
+	t.Run("groups lines by mode", func(t *testing.T) {
+		mockTransportRepo4 := &MockTransportRepository{}
+		uc4 := usecase.NewTransportUseCase(mockTransportRepo4, logger)
+
+		mockTransportRepo4.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int(nil)).
+			Return([]domain.NearestTransportWithLines{
+				{
+					StationID: 300,
+					Name:      "Passeig de Gracia",
+					Type:      "metro",
+					Lines: []domain.TransportLineInfo{
+						{ID: 1, Name: "V15", Type: "bus"},
+						{ID: 2, Name: "R2", Type: "train"},
+						{ID: 3, Name: "L3", Type: "subway"},
+						{ID: 4, Name: "L4", Type: "subway"},
+						{ID: 5, Name: "H10", Type: "bus"},
+					},
+				},
+			}, nil)
+
+		req := dto.PriorityTransportRequest{
+			Lat:              41.3851,
+			Lon:              2.1734,
+			GroupLinesByMode: true,
+		}
+
+		resp, err := uc4.GetNearestTransportByPriority(ctx, req)
+
+		assert.NoError(t, err)
+		station := resp.Stations[0]
+		assert.Nil(t, station.Lines)
+		assert.Equal(t, []string{"metro", "train", "bus"}, station.LineModes)
+		assert.Len(t, station.LinesByMode["metro"], 2)
+		assert.Equal(t, "L3", station.LinesByMode["metro"][0].Name)
+		assert.Len(t, station.LinesByMode["train"], 1)
+		assert.Equal(t, []string{"V15", "H10"}, []string{station.LinesByMode["bus"][0].Name, station.LinesByMode["bus"][1].Name})
+
+		mockTransportRepo4.AssertExpectations(t)
+	})
+
+	t.Run("includes metro entrances", func(t *testing.T) {
+		mockTransportRepo5 := &MockTransportRepository{}
+		uc5 := usecase.NewTransportUseCase(mockTransportRepo5, logger)
+
+		mockTransportRepo5.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int(nil)).
+			Return([]domain.NearestTransportWithLines{
+				{StationID: 300, Name: "Passeig de Gràcia", Type: "metro", DistanceM: 120},
+				{StationID: 400, Name: "Pg de Gràcia", Type: "bus", DistanceM: 80},
+			}, nil)
+		mockTransportRepo5.On("GetStationEntrances", ctx, []int64{300}, domain.StationEntranceMaxDistanceM).
+			Return(map[int64][]domain.StationEntrance{
+				300: {
+					{ID: 31, Ref: "A", Lat: 41.391712345, Lon: 2.165123456, DistanceM: 42.123},
+					{ID: 32, Name: "Passeig de Gràcia - Aragó", Lat: 41.3925, Lon: 2.1641, DistanceM: 95.5},
+				},
+			}, nil)
+
+		resp, err := uc5.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{
+			Lat: 41.3851, Lon: 2.1734, IncludeEntrances: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Stations[0].Entrances, 2)
+		assert.Equal(t, dto.StationEntranceDTO{ID: 31, Ref: "A", Lat: 41.391712, Lon: 2.165123, Distance: 42.12}, resp.Stations[0].Entrances[0])
+		assert.Nil(t, resp.Stations[1].Entrances)
+		mockTransportRepo5.AssertExpectations(t)
+	})
+
+	t.Run("entrances are not queried by default", func(t *testing.T) {
+		mockTransportRepo6 := &MockTransportRepository{}
+		uc6 := usecase.NewTransportUseCase(mockTransportRepo6, logger)
+
+		mockTransportRepo6.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int(nil)).
+			Return([]domain.NearestTransportWithLines{{StationID: 300, Name: "Passeig de Gràcia", Type: "metro"}}, nil)
+
+		resp, err := uc6.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{Lat: 41.3851, Lon: 2.1734})
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp.Stations[0].Entrances)
+		mockTransportRepo6.AssertNotCalled(t, "GetStationEntrances")
+	})
+
+	t.Run("passes min per mode without zero counts", func(t *testing.T) {
+		mockTransportRepo7 := &MockTransportRepository{}
+		uc7 := usecase.NewTransportUseCase(mockTransportRepo7, logger)
+
+		mockTransportRepo7.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int{"bus": 1}).
+			Return([]domain.NearestTransportWithLines{
+				{StationID: 300, Name: "Passeig de Gràcia", Type: "metro", DistanceM: 120},
+				{StationID: 400, Name: "Aragó - Pg de Gràcia", Type: "bus", DistanceM: 900},
+			}, nil)
+
+		resp, err := uc7.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{
+			Lat: 41.3851, Lon: 2.1734, MinPerMode: map[string]int{"bus": 1, "tram": 0},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Stations, 2)
+		mockTransportRepo7.AssertExpectations(t)
+	})
+
+	t.Run("snapped distance mode", func(t *testing.T) {
+		mockTransportRepo9 := &MockTransportRepository{}
+		uc9 := usecase.NewTransportUseCase(mockTransportRepo9, logger)
+
+		mockTransportRepo9.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int(nil)).
+			Return([]domain.NearestTransportWithLines{
+				{StationID: 300, Name: "Passeig de Gràcia", Type: "metro", DistanceM: 100},
+				{StationID: 400, Name: "Aragó", Type: "bus", DistanceM: 200},
+			}, nil)
+		mockTransportRepo9.On("GetSnappedDistances", ctx, 41.3851, 2.1734, []int64{300, 400}).
+			Return(map[int64]domain.SnappedDistance{
+				300: {OriginOffsetM: 20, NetworkM: 150, StationOffsetM: 30},
+			}, nil)
+
+		resp, err := uc9.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{
+			Lat: 41.3851, Lon: 2.1734, DistanceMode: "snapped",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "snapped", resp.Meta.DistanceMode)
+		// 20 + 150*1.2 + 30
+		assert.True(t, resp.Stations[0].Snapped)
+		assert.Equal(t, 230.0, resp.Stations[0].WalkingDistance)
+		assert.Equal(t, 100.0, resp.Stations[0].LinearDistance)
+		// Без привязки к сети - по прямой с поправкой
+		assert.False(t, resp.Stations[1].Snapped)
+		assert.Equal(t, 240.0, resp.Stations[1].WalkingDistance)
+		mockTransportRepo9.AssertExpectations(t)
+	})
+
+	t.Run("snapped distance along network", func(t *testing.T) {
+		mockTransportRepo11 := &MockTransportRepository{}
+		uc11 := usecase.NewTransportUseCase(mockTransportRepo11, logger)
+
+		mockTransportRepo11.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int(nil)).
+			Return([]domain.NearestTransportWithLines{
+				{StationID: 300, Name: "Passeig de Gràcia", Type: "metro", DistanceM: 100},
+			}, nil)
+		mockTransportRepo11.On("GetSnappedDistances", ctx, 41.3851, 2.1734, []int64{300}).
+			Return(map[int64]domain.SnappedDistance{
+				300: {OriginOffsetM: 20, NetworkM: 150, StationOffsetM: 30, AlongNetwork: true},
+			}, nil)
+
+		resp, err := uc11.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{
+			Lat: 41.3851, Lon: 2.1734, DistanceMode: "snapped",
+		})
+
+		assert.NoError(t, err)
+		// Средний участок измерен вдоль сети - без поправки на обход: 20 + 150 + 30
+		assert.Equal(t, 200.0, resp.Stations[0].WalkingDistance)
+		mockTransportRepo11.AssertExpectations(t)
+	})
+
+	t.Run("invalid distance mode", func(t *testing.T) {
+		mockTransportRepo10 := &MockTransportRepository{}
+		uc10 := usecase.NewTransportUseCase(mockTransportRepo10, logger)
+
+		_, err := uc10.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{
+			Lat: 41.3851, Lon: 2.1734, DistanceMode: "routed",
+		})
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+		mockTransportRepo10.AssertNotCalled(t, "GetNearestTransportByPriority")
+	})
+
+	t.Run("invalid min per mode", func(t *testing.T) {
+		mockTransportRepo8 := &MockTransportRepository{}
+		uc8 := usecase.NewTransportUseCase(mockTransportRepo8, logger)
+
+		_, err := uc8.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{
+			Lat: 41.3851, Lon: 2.1734, MinPerMode: map[string]int{"ferry": 1},
+		})
+		assert.ErrorIs(t, err, errors.ErrInvalidTransportType)
+
+		_, err = uc8.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{
+			Lat: 41.3851, Lon: 2.1734, MinPerMode: map[string]int{"bus": -1},
+		})
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+		mockTransportRepo8.AssertNotCalled(t, "GetNearestTransportByPriority")
+	})
+
+	t.Run("distances in kilometers", func(t *testing.T) {
+		mockTransportRepo9 := &MockTransportRepository{}
+		uc9 := usecase.NewTransportUseCase(mockTransportRepo9, logger)
+
+		mockTransportRepo9.On("GetNearestTransportByPriority", ctx, 41.3851, 2.1734, 1500.0, 5, map[string]int(nil)).
+			Return([]domain.NearestTransportWithLines{
+				{StationID: 100, Name: "Catalunya", Type: "metro", DistanceM: 1250.4},
+			}, nil)
+
+		resp, err := uc9.GetNearestTransportByPriority(ctx, dto.PriorityTransportRequest{
+			Lat: 41.3851, Lon: 2.1734, Radius: 1500, Limit: 5,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1250.4, resp.Stations[0].LinearDistance)
+		resp.ConvertDistances(utils.DistanceUnitKilometers)
+		assert.Equal(t, 1.25, resp.Stations[0].LinearDistance)
+		assert.Equal(t, 1.5, resp.Stations[0].WalkingDistance)
+		assert.Equal(t, 1500.0, resp.Meta.RadiusM, "radius_m stays in meters")
+		assert.Equal(t, "km", resp.Meta.DistanceUnit)
+	})
 }
 
 func TestTransportUseCase_GetNearestTransportByPriorityBatch(t *testing.T) {
@@ -163,7 +368,7 @@ func TestTransportUseCase_GetNearestTransportByPriorityBatch(t *testing.T) {
 						Type:      "metro",
 						Lat:       41.3850,
 						Lon:       2.1700,
-						Distance:  250.5,
+						DistanceM: 250.5,
 						Lines: []domain.TransportLineInfo{
 							{
 								ID:   1,
@@ -187,7 +392,7 @@ func TestTransportUseCase_GetNearestTransportByPriorityBatch(t *testing.T) {
 						Type:      "metro",
 						Lat:       48.8584,
 						Lon:       2.3470,
-						Distance:  180.0,
+						DistanceM: 180.0,
 						Lines:     []domain.TransportLineInfo{},
 					},
 				},
@@ -230,6 +435,47 @@ func TestTransportUseCase_GetNearestTransportByPriorityBatch(t *testing.T) {
 		mockTransportRepo.AssertExpectations(t)
 	})
 
+	t.Run("snapped distance mode per point", func(t *testing.T) {
+		mockTransportRepo3 := &MockTransportRepository{}
+		uc3 := usecase.NewTransportUseCase(mockTransportRepo3, logger)
+
+		mockTransportRepo3.On("GetNearestTransportByPriorityBatch", ctx, mock.Anything, 1500.0, 3).
+			Return([]domain.BatchTransportResult{
+				{
+					PointIndex:  0,
+					SearchPoint: domain.Coordinate{Lat: 41.3851, Lon: 2.1734},
+					Stations:    []domain.NearestTransportWithLines{{StationID: 300, Type: "metro", DistanceM: 100}},
+				},
+				{
+					PointIndex:  1,
+					SearchPoint: domain.Coordinate{Lat: 48.8566, Lon: 2.3522},
+					Stations:    []domain.NearestTransportWithLines{{StationID: 200, Type: "metro", DistanceM: 180}},
+				},
+			}, nil)
+		mockTransportRepo3.On("GetSnappedDistances", ctx, 41.3851, 2.1734, []int64{300}).
+			Return(map[int64]domain.SnappedDistance{
+				300: {OriginOffsetM: 20, NetworkM: 150, StationOffsetM: 30},
+			}, nil)
+		mockTransportRepo3.On("GetSnappedDistances", ctx, 48.8566, 2.3522, []int64{200}).
+			Return(map[int64]domain.SnappedDistance{}, nil)
+
+		resp, err := uc3.GetNearestTransportByPriorityBatch(ctx, dto.PriorityTransportBatchRequest{
+			Points: []dto.PriorityTransportPoint{
+				{Lat: 41.3851, Lon: 2.1734},
+				{Lat: 48.8566, Lon: 2.3522},
+			},
+			DistanceMode: "snapped",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "snapped", resp.Meta.DistanceMode)
+		assert.True(t, resp.Results[0].Stations[0].Snapped)
+		assert.Equal(t, 230.0, resp.Results[0].Stations[0].WalkingDistance)
+		assert.False(t, resp.Results[1].Stations[0].Snapped)
+		assert.Equal(t, 216.0, resp.Results[1].Stations[0].WalkingDistance)
+		mockTransportRepo3.AssertExpectations(t)
+	})
+
 	t.Run("empty points returns error", func(t *testing.T) {
 		req := dto.PriorityTransportBatchRequest{
 			Points: []dto.PriorityTransportPoint{},
@@ -287,6 +533,419 @@ func TestTransportUseCase_GetNearestTransportByPriorityBatch(t *testing.T) {
 	})
 }
 
+func TestTransportUseCase_BatchLimits(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	assert.NoError(t, usecase.ConfigureBatchLimits(5, 2))
+	defer func() { _ = usecase.ConfigureBatchLimits(0, 0) }()
+
+	points := func(n int) []dto.PriorityTransportPoint {
+		result := make([]dto.PriorityTransportPoint, n)
+		for i := range result {
+			result[i] = dto.PriorityTransportPoint{Lat: 41.38 + float64(i)*0.001, Lon: 2.17}
+		}
+		return result
+	}
+
+	t.Run("oversized batch is rejected with 413", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		_, err := uc.GetNearestTransportByPriorityBatch(ctx, dto.PriorityTransportBatchRequest{Points: points(6)})
+
+		assert.ErrorIs(t, err, errors.ErrBatchTooLarge)
+		assert.Equal(t, 413, errors.ErrBatchTooLarge.StatusCode)
+		mockTransportRepo.AssertNotCalled(t, "GetNearestTransportByPriorityBatch")
+	})
+
+	t.Run("large batch is split into sub-batches", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		// Под-пакеты [0,2), [2,4), [4,5): репозиторий нумерует точки внутри под-пакета с нуля
+		for _, chunk := range [][2]int{{0, 2}, {2, 4}, {4, 5}} {
+			all := points(5)
+			expected := make([]domain.BatchTransportResult, 0, chunk[1]-chunk[0])
+			for i := chunk[0]; i < chunk[1]; i++ {
+				expected = append(expected, domain.BatchTransportResult{
+					PointIndex:  i - chunk[0],
+					SearchPoint: domain.Coordinate{Lat: all[i].Lat, Lon: all[i].Lon},
+				})
+			}
+			firstLat, size := all[chunk[0]].Lat, chunk[1]-chunk[0]
+			mockTransportRepo.On("GetNearestTransportByPriorityBatch", ctx,
+				mock.MatchedBy(func(pts []domain.TransportSearchPoint) bool {
+					return len(pts) == size && pts[0].Lat == firstLat
+				}), 1500.0, 3).Return(expected, nil).Once()
+		}
+
+		result, err := uc.GetNearestTransportByPriorityBatch(ctx, dto.PriorityTransportBatchRequest{Points: points(5)})
+
+		assert.NoError(t, err)
+		mockTransportRepo.AssertNumberOfCalls(t, "GetNearestTransportByPriorityBatch", 3)
+		assert.Len(t, result.Results, 5)
+		for i, r := range result.Results {
+			assert.Equal(t, i, r.PointIndex)
+		}
+		assert.Equal(t, 41.384, result.Results[4].SearchPoint.Lat)
+	})
+}
+
+func TestTransportUseCase_GetLinesGeometry(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("keeps request order and skips deduplicated lines", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		geometries := map[int64]*domain.TransportLineGeometry{
+			-20: {LineID: -20, Name: "L3", Ref: "L3", Type: "subway", Geometry: []byte(`{"type":"LineString","coordinates":[[2.1,41.3],[2.2,41.4]]}`)},
+			-10: {LineID: -10, Name: "L1", Ref: "L1", Type: "subway", Color: ptrString("#E32019"), Geometry: []byte(`{"type":"LineString","coordinates":[[2.0,41.3],[2.1,41.4]]}`)},
+		}
+		mockTransportRepo.On("GetLinesGeometry", ctx, []int64{-20, -21, -10}, true).Return(geometries, nil)
+
+		result, err := uc.GetLinesGeometry(ctx, dto.TransportLinesGeometryRequest{
+			IDs:         []string{"-20", "-21", "-10"},
+			DedupeByRef: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, "-20", result.Lines[0].ID)
+		assert.Equal(t, "-10", result.Lines[1].ID)
+		assert.Equal(t, "#E32019", *result.Lines[1].Color)
+		mockTransportRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		_, err := uc.GetLinesGeometry(ctx, dto.TransportLinesGeometryRequest{IDs: []string{"abc"}})
+
+		assert.Error(t, err)
+		mockTransportRepo.AssertNotCalled(t, "GetLinesGeometry")
+	})
+}
+
+func TestTransportUseCase_GetLinesInBBox(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("passes bbox and modes to repository", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		bbox := domain.BoundingBox{MinLat: 41.37, MinLon: 2.15, MaxLat: 41.40, MaxLon: 2.19}
+		lines := []domain.TransportLineGeometry{
+			{LineID: -10, Name: "L1", Ref: "L1", Type: "subway", Color: ptrString("#E32019"), Geometry: []byte(`{"type":"LineString","coordinates":[[2.15,41.37],[2.19,41.40]]}`)},
+		}
+		mockTransportRepo.On("GetLinesInBBox", ctx, bbox, []string{"metro"}).Return(lines, nil)
+
+		result, err := uc.GetLinesInBBox(ctx, dto.BBoxTransportLinesRequest{
+			SwLat: 41.37, SwLon: 2.15, NeLat: 41.40, NeLon: 2.19,
+			Types: []string{"metro"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Total)
+		assert.Equal(t, "-10", result.Lines[0].ID)
+		assert.Equal(t, "#E32019", *result.Lines[0].Color)
+		mockTransportRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		cases := map[string]dto.BBoxTransportLinesRequest{
+			"swapped corners": {SwLat: 41.40, SwLon: 2.15, NeLat: 41.37, NeLon: 2.19},
+			"too large":       {SwLat: 41.0, SwLon: 2.0, NeLat: 42.0, NeLon: 2.2},
+			"unknown type":    {SwLat: 41.37, SwLon: 2.15, NeLat: 41.40, NeLon: 2.19, Types: []string{"rocket"}},
+			"bad coordinates": {SwLat: 91, SwLon: 2.15, NeLat: 92, NeLon: 2.19},
+		}
+		for name, req := range cases {
+			mockTransportRepo := &MockTransportRepository{}
+			uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+			_, err := uc.GetLinesInBBox(ctx, req)
+
+			assert.Error(t, err, name)
+			mockTransportRepo.AssertNotCalled(t, "GetLinesInBBox")
+		}
+	})
+}
+
+func TestTransportUseCase_GetNearestStations_SurfaceOnly(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	mockTransportRepo := &MockTransportRepository{}
+	uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+	mockTransportRepo.On("GetNearestStations", ctx, 41.3851, 2.1734, []string{"metro"}, 5000.0, 5, true, 0.0).
+		Return([]*domain.TransportStation{
+			{ID: 1, Name: "Catalunya", Type: "station", Lat: 41.3870, Lon: 2.1700},
+		}, nil)
+
+	result, err := uc.GetNearestStations(ctx, dto.NearestTransportRequest{
+		Lat:         41.3851,
+		Lon:         2.1734,
+		Types:       []string{"metro"},
+		SurfaceOnly: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Stations, 1)
+	assert.Equal(t, "1", result.Stations[0].ID)
+	mockTransportRepo.AssertExpectations(t)
+}
+
+func TestTransportUseCase_GetNearestStations_ExcludeWithin(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	mockTransportRepo := &MockTransportRepository{}
+	uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+	mockTransportRepo.On("GetNearestStations", ctx, 41.3851, 2.1734, []string{"metro"}, 5000.0, 5, false, 25.0).
+		Return([]*domain.TransportStation{
+			{ID: 2, Name: "Passeig de Gràcia", Type: "station", Lat: 41.3917, Lon: 2.1649},
+		}, nil)
+
+	result, err := uc.GetNearestStations(ctx, dto.NearestTransportRequest{
+		Lat:            41.3851,
+		Lon:            2.1734,
+		Types:          []string{"metro"},
+		ExcludeWithinM: 25,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Stations, 1)
+	assert.Equal(t, "2", result.Stations[0].ID)
+	mockTransportRepo.AssertExpectations(t)
+}
+
+func TestTransportUseCase_GetTransportCoverage(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("bands are sorted and deduplicated", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		expected := &domain.TransportCoverage{Lat: 41.3851, Lon: 2.1734}
+		mockTransportRepo.On("GetTransportCoverage", ctx, 41.3851, 2.1734, []float64{300, 1000}).
+			Return(expected, nil)
+
+		result, err := uc.GetTransportCoverage(ctx, 41.3851, 2.1734, []float64{1000, 300, 1000})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		mockTransportRepo.AssertExpectations(t)
+	})
+
+	t.Run("default bands", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		mockTransportRepo.On("GetTransportCoverage", ctx, 41.3851, 2.1734, usecase.DefaultCoverageBands).
+			Return(&domain.TransportCoverage{}, nil)
+
+		_, err := uc.GetTransportCoverage(ctx, 41.3851, 2.1734, nil)
+
+		assert.NoError(t, err)
+		mockTransportRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid band", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		_, err := uc.GetTransportCoverage(ctx, 41.3851, 2.1734, []float64{300, 10000})
+
+		assert.Error(t, err)
+		mockTransportRepo.AssertNotCalled(t, "GetTransportCoverage")
+	})
+}
+
+func TestTransportUseCase_GetStationComplexes(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	clustered := func(id int64, name, mode string, distance float64, cluster int, lines ...int64) domain.ClusteredStation {
+		s := domain.ClusteredStation{Cluster: cluster}
+		s.StationID, s.Name, s.Type, s.DistanceM = id, name, mode, distance
+		for _, l := range lines {
+			s.Lines = append(s.Lines, domain.TransportLineInfo{ID: l, Name: "L"})
+		}
+		return s
+	}
+
+	t.Run("groups hub stations by mode", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		mockTransportRepo.On("GetClusteredStations", ctx, 41.387, 2.17, 1000.0, 150.0, 1).
+			Return([]domain.ClusteredStation{
+				clustered(1, "Catalunya", "bus", 80, 0, 100),
+				clustered(2, "Catalunya", "metro", 120, 0, 10, 11),
+				clustered(3, "Pelai", "bus", 300, 0, 100),
+			}, nil)
+
+		result, err := uc.GetStationComplexes(ctx, dto.StationComplexRequest{Lat: 41.387, Lon: 2.17, Limit: 1})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Complexes, 1)
+		assert.Equal(t, 80.0, result.Complexes[0].Distance)
+		result.ConvertDistances(utils.DistanceUnitKilometers)
+		hub := result.Complexes[0]
+		assert.Equal(t, int64(2), hub.ID)
+		assert.Equal(t, []string{"metro", "bus"}, hub.Modes)
+		assert.Equal(t, 0.08, hub.Distance)
+		assert.Len(t, hub.StationsByMode["metro"][0].Lines, 2)
+		assert.Len(t, hub.Lines, 3)
+		assert.Equal(t, 2, result.Meta.TotalStations)
+		mockTransportRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid cluster distance", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		_, err := uc.GetStationComplexes(ctx, dto.StationComplexRequest{Lat: 41.387, Lon: 2.17, ClusterDistance: 1000})
+
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+		mockTransportRepo.AssertNotCalled(t, "GetClusteredStations")
+	})
+}
+
+func TestTransportUseCase_GetStationsAlongLine(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("default buffer", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		expected := []*domain.TransportStation{{ID: 1, Name: "Catalunya"}, {ID: 2, Name: "Passeig de Gràcia"}}
+		mockTransportRepo.On("GetLineByID", ctx, int64(42)).Return(&domain.TransportLine{ID: 42}, nil)
+		mockTransportRepo.On("GetStationsAlongLine", ctx, int64(42), usecase.DefaultStationsAlongLineBufferM).
+			Return(expected, nil)
+
+		result, err := uc.GetStationsAlongLine(ctx, 42, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		mockTransportRepo.AssertExpectations(t)
+	})
+
+	t.Run("coordinates are rounded in response", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		mockTransportRepo.On("GetLineByID", ctx, int64(42)).Return(&domain.TransportLine{ID: 42}, nil)
+		mockTransportRepo.On("GetStationsAlongLine", ctx, int64(42), 100.0).
+			Return([]*domain.TransportStation{{ID: 1, Lat: 41.38706612345678, Lon: 2.16996598765432}}, nil)
+
+		result, err := uc.GetStationsAlongLine(ctx, 42, 100)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 41.387066, result[0].Lat)
+		assert.Equal(t, 2.169966, result[0].Lon)
+	})
+
+	t.Run("unknown line", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		mockTransportRepo.On("GetLineByID", ctx, int64(42)).Return(nil, errors.ErrLocationNotFound)
+
+		_, err := uc.GetStationsAlongLine(ctx, 42, 100)
+
+		assert.ErrorIs(t, err, errors.ErrLocationNotFound)
+		mockTransportRepo.AssertNotCalled(t, "GetStationsAlongLine")
+	})
+
+	t.Run("invalid buffer", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		_, err := uc.GetStationsAlongLine(ctx, 42, 5000)
+
+		assert.Error(t, err)
+		mockTransportRepo.AssertNotCalled(t, "GetLineByID")
+	})
+}
+
+func TestTransportUseCase_GetStationByID(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("station with lines", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		mockTransportRepo.On("GetStationByID", ctx, int64(7)).
+			Return(&domain.TransportStation{ID: 7, Name: "Catalunya", Lat: 41.38706612345678, Lon: 2.16996598765432, LineIDs: []int64{}}, nil)
+		mockTransportRepo.On("GetLinesByStationID", ctx, int64(7)).
+			Return([]*domain.TransportLine{{ID: 3, Ref: "L3"}, {ID: 1, Ref: "L1"}}, nil)
+
+		station, lines, err := uc.GetStationByID(ctx, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Catalunya", station.Name)
+		assert.Equal(t, 41.387066, station.Lat)
+		assert.Equal(t, 2.169966, station.Lon)
+		assert.Equal(t, []int64{3, 1}, station.LineIDs)
+		assert.Len(t, lines, 2)
+		mockTransportRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown station", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		mockTransportRepo.On("GetStationByID", ctx, int64(7)).Return(nil, errors.ErrLocationNotFound)
+
+		_, _, err := uc.GetStationByID(ctx, 7)
+
+		assert.ErrorIs(t, err, errors.ErrLocationNotFound)
+		mockTransportRepo.AssertNotCalled(t, "GetLinesByStationID")
+	})
+}
+
+func TestTransportUseCase_GetStationsInBoundary(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("types passed through and coordinates rounded", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		mockTransportRepo.On("GetStationsInBoundary", ctx, int64(-347950), []string{"metro"}).
+			Return([]*domain.TransportStation{{ID: 1, Name: "Catalunya", Lat: 41.38706612345678, Lon: 2.16996598765432}}, nil)
+
+		result, err := uc.GetStationsInBoundary(ctx, -347950, []string{"metro"})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 41.387066, result[0].Lat)
+		assert.Equal(t, 2.169966, result[0].Lon)
+		mockTransportRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown boundary", func(t *testing.T) {
+		mockTransportRepo := &MockTransportRepository{}
+		uc := usecase.NewTransportUseCase(mockTransportRepo, logger)
+
+		mockTransportRepo.On("GetStationsInBoundary", ctx, int64(1), []string(nil)).Return(nil, errors.ErrLocationNotFound)
+
+		_, err := uc.GetStationsInBoundary(ctx, 1, nil)
+
+		assert.ErrorIs(t, err, errors.ErrLocationNotFound)
+	})
+}
+
 func TestDeterminePriorityMeta(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/location-microservice/internal/domain"
@@ -18,6 +19,10 @@ import (
 const (
 	maxBatchSize    = 20                     // максимум сообщений за раз
 	emptyQueueSleep = 100 * time.Millisecond // пауза если очередь пуста
+
+	// dbConnsPerConsumer - соединений OSM базы, которые одновременно занимает обогащение пачки:
+	// DetectLocationBatch и поиск транспорта идут параллельно (EnrichLocationBatch)
+	dbConnsPerConsumer = 2
 )
 
 // LocationEnrichmentWorker обрабатывает события обогащения локаций
@@ -25,8 +30,10 @@ type LocationEnrichmentWorker struct {
 	*worker.BaseWorker
 	streamRepo         repository.StreamRepository
 	enrichedLocationUC usecase.BatchLocationEnricher
+	resultRepo         repository.EnrichmentResultRepository // nil - результаты только публикуются в стрим
 	consumerName       string
 	maxRetries         int
+	concurrency        int // параллельных потребителей стрима, по умолчанию 1
 }
 
 // NewLocationEnrichmentWorker создает новый LocationEnrichmentWorker
@@ -46,7 +53,37 @@ func NewLocationEnrichmentWorker(
 		enrichedLocationUC: enrichedLocationUC,
 		consumerName:       consumerName,
 		maxRetries:         maxRetries,
+		concurrency:        1,
+	}
+}
+
+// SetResultRepository включает сохранение результатов обогащения в основную БД.
+// Вызывается до Start.
+func (w *LocationEnrichmentWorker) SetResultRepository(repo repository.EnrichmentResultRepository) {
+	w.resultRepo = repo
+}
+
+// SetConcurrency задает число параллельных потребителей стрима: каждый читает свои пачки через
+// consumer group под собственным именем и сам ACK'ает обработанные сообщения. Число ограничивается
+// пулом OSM базы (dbMaxConns), чтобы потребители не ждали соединений; dbMaxConns <= 0 - без ограничения.
+// Вызывается до Start, возвращает итоговое число потребителей.
+func (w *LocationEnrichmentWorker) SetConcurrency(concurrency, dbMaxConns int) int {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if dbMaxConns > 0 {
+		limit := max(dbMaxConns/dbConnsPerConsumer, 1)
+		if concurrency > limit {
+			w.Logger().Warn("Worker concurrency limited by OSM DB pool size",
+				zap.Int("requested", concurrency),
+				zap.Int("db_max_conns", dbMaxConns),
+				zap.Int("concurrency", limit))
+			concurrency = limit
+		}
 	}
+
+	w.concurrency = concurrency
+	return concurrency
 }
 
 // Start запускает воркер
@@ -55,7 +92,8 @@ func (w *LocationEnrichmentWorker) Start(ctx context.Context) error {
 	logger.Info("Starting LocationEnrichmentWorker (batch mode)",
 		zap.String("consumer_group", w.ConsumerGroup()),
 		zap.String("consumer_name", w.consumerName),
-		zap.Int("max_batch_size", maxBatchSize))
+		zap.Int("max_batch_size", maxBatchSize),
+		zap.Int("concurrency", w.concurrency))
 
 	// Создаем consumer group (используем background context для инициализации)
 	initCtx, initCancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -65,31 +103,54 @@ func (w *LocationEnrichmentWorker) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to create consumer group: %w", err)
 	}
 
-	// Основной цикл обработки
+	if w.concurrency <= 1 {
+		w.consume(ctx, w.consumerName)
+		return nil
+	}
+
+	// Потребители с разными именами в одной consumer group получают разные сообщения,
+	// неподтвержденные сообщения каждого видны в XPENDING под его именем
+	var wg sync.WaitGroup
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func(consumerName string) {
+			defer wg.Done()
+			w.consume(ctx, consumerName)
+		}(fmt.Sprintf("%s-%d", w.consumerName, i))
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// consume - цикл одного потребителя: читает и обрабатывает пачки до остановки воркера
+func (w *LocationEnrichmentWorker) consume(ctx context.Context, consumerName string) {
+	logger := w.Logger().With(zap.String("consumer_name", consumerName))
+
 	for {
 		// Проверяем сигнал остановки ПЕРЕД чтением новых сообщений
 		if w.shouldStop(ctx) {
 			logger.Info("Worker stopping gracefully")
-			return nil
+			return
 		}
 
 		// Создаем короткий контекст для операций с Redis (не блокирующих)
 		opCtx, opCancel := context.WithTimeout(context.Background(), 5*time.Second)
 
 		// Обрабатываем batch сообщений
-		processed, err := w.processBatch(opCtx)
+		processed, err := w.processBatch(opCtx, consumerName)
 		opCancel()
 
 		if err != nil {
 			// Проверяем, не связана ли ошибка с shutdown
 			if w.shouldStop(ctx) {
 				logger.Info("Worker stopping after batch error")
-				return nil
+				return
 			}
 			logger.Error("Failed to process batch", zap.Error(err))
 			// Короткая пауза при ошибке с проверкой shutdown
 			if w.sleepWithShutdownCheck(ctx, time.Second) {
-				return nil
+				return
 			}
 			continue
 		}
@@ -98,7 +159,7 @@ func (w *LocationEnrichmentWorker) Start(ctx context.Context) error {
 		if processed == 0 {
 			if w.sleepWithShutdownCheck(ctx, emptyQueueSleep) {
 				logger.Info("Worker stopped while idle")
-				return nil
+				return
 			}
 		}
 	}
@@ -131,15 +192,15 @@ func (w *LocationEnrichmentWorker) sleepWithShutdownCheck(ctx context.Context, d
 
 // processBatch читает и обрабатывает batch сообщений
 // Возвращает количество обработанных сообщений
-func (w *LocationEnrichmentWorker) processBatch(ctx context.Context) (int, error) {
-	logger := w.Logger()
+func (w *LocationEnrichmentWorker) processBatch(ctx context.Context, consumerName string) (int, error) {
+	logger := w.Logger().With(zap.String("consumer_name", consumerName))
 
 	// 1. Читаем до 20 сообщений (неблокирующий режим)
 	messages, err := w.streamRepo.ConsumeBatch(
 		ctx,
 		domain.StreamLocationEnrich,
 		w.ConsumerGroup(),
-		w.consumerName,
+		consumerName,
 		maxBatchSize,
 	)
 	if err != nil {
@@ -214,6 +275,8 @@ func (w *LocationEnrichmentWorker) processBatch(ctx context.Context) (int, error
 
 	// 5. Публикуем результаты в stream:location:done
 	publishErrors := 0
+	enrichedAt := time.Now().UTC()
+	var stored []*domain.EnrichmentResult
 	for _, result := range resp.Results {
 		if result.Index >= len(events) {
 			logger.Error("Result index exceeds events count - this should not happen",
@@ -231,6 +294,9 @@ func (w *LocationEnrichmentWorker) processBatch(ctx context.Context) (int, error
 			zap.Any("longitude", event.Longitude))
 
 		doneEvent := w.buildDoneEvent(event, result)
+		if w.resultRepo != nil {
+			stored = append(stored, domain.NewEnrichmentResult(doneEvent, enrichedAt))
+		}
 
 		logger.Debug("Publishing done event", zap.Any("event", doneEvent))
 
@@ -243,6 +309,16 @@ func (w *LocationEnrichmentWorker) processBatch(ctx context.Context) (int, error
 		}
 	}
 
+	// Сохранение в БД - дополнительная запись для аудита: ошибка не мешает ACK,
+	// результаты уже опубликованы в стрим
+	if w.resultRepo != nil {
+		if err := w.resultRepo.UpsertResults(ctx, stored); err != nil {
+			logger.Error("Failed to persist enrichment results",
+				zap.Int("result_count", len(stored)),
+				zap.Error(err))
+		}
+	}
+
 	// 6. ACK всех обработанных сообщений
 	// Note: Мы ACK'аем все сообщения даже если некоторые публикации упали,
 	// так как повторная обработка не решит проблему с публикацией.
@@ -336,6 +412,13 @@ func (w *LocationEnrichmentWorker) convertEnrichedLocation(dto *dto.EnrichedLoca
 			TranslateNames: dto.Province.TranslateNames,
 		}
 	}
+	if dto.Subprovince != nil {
+		result.Subprovince = &domain.BoundaryInfo{
+			ID:             dto.Subprovince.ID,
+			Name:           dto.Subprovince.Name,
+			TranslateNames: dto.Subprovince.TranslateNames,
+		}
+	}
 	if dto.City != nil {
 		result.City = &domain.BoundaryInfo{
 			ID:             dto.City.ID,
@@ -350,6 +433,13 @@ func (w *LocationEnrichmentWorker) convertEnrichedLocation(dto *dto.EnrichedLoca
 			TranslateNames: dto.District.TranslateNames,
 		}
 	}
+	if dto.Subdistrict != nil {
+		result.Subdistrict = &domain.BoundaryInfo{
+			ID:             dto.Subdistrict.ID,
+			Name:           dto.Subdistrict.Name,
+			TranslateNames: dto.Subdistrict.TranslateNames,
+		}
+	}
 	if dto.Neighborhood != nil {
 		result.Neighborhood = &domain.BoundaryInfo{
 			ID:             dto.Neighborhood.ID,
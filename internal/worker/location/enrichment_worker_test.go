@@ -3,6 +3,7 @@ package location_test
 import (
 	"context"
 	"encoding/json"
+	"sync"
 	"testing"
 	"time"
 
@@ -287,7 +288,141 @@ func TestLocationEnrichmentWorker_BatchProcessing(t *testing.T) {
 	mockUseCase.AssertExpectations(t)
 }
 
+// MockEnrichmentResultRepository is a mock of EnrichmentResultRepository
+type MockEnrichmentResultRepository struct {
+	mock.Mock
+}
+
+func (m *MockEnrichmentResultRepository) UpsertResults(ctx context.Context, results []*domain.EnrichmentResult) error {
+	args := m.Called(ctx, results)
+	return args.Error(0)
+}
+
+// TestLocationEnrichmentWorker_PersistsResults tests that enriched results are upserted when persistence is enabled
+func TestLocationEnrichmentWorker_PersistsResults(t *testing.T) {
+	mockStream := &MockStreamRepository{}
+	mockUseCase := &MockEnrichedLocationUseCase{}
+	mockResults := &MockEnrichmentResultRepository{}
+
+	worker := location.NewLocationEnrichmentWorker(
+		mockStream,
+		mockUseCase,
+		"test-group",
+		3,
+		zap.NewNop(),
+	)
+	worker.SetResultRepository(mockResults)
+
+	propertyID := uuid.New()
+	eventJSON, _ := json.Marshal(&domain.LocationEnrichEvent{PropertyID: propertyID, Country: "Spain"})
+	messages := []domain.StreamMessage{
+		{ID: "1234567890-0", Stream: domain.StreamLocationEnrich, Data: map[string]interface{}{"data": string(eventJSON)}},
+	}
+
+	mockStream.On("CreateConsumerGroup", mock.Anything, domain.StreamLocationEnrich, "test-group").Return(nil)
+	mockStream.On("ConsumeBatch", mock.Anything, domain.StreamLocationEnrich, "test-group", mock.AnythingOfType("string"), 20).
+		Return(messages, nil).Once()
+	mockStream.On("ConsumeBatch", mock.Anything, domain.StreamLocationEnrich, "test-group", mock.AnythingOfType("string"), 20).
+		Return([]domain.StreamMessage{}, nil)
+	mockUseCase.On("EnrichLocationBatch", mock.Anything, mock.Anything).Return(&dto.EnrichLocationBatchResponse{
+		Results: []dto.EnrichedLocationResult{
+			{Index: 0, EnrichedLocation: &dto.EnrichedLocationDTO{Country: &dto.BoundaryInfoDTO{ID: 1, Name: "España"}}},
+		},
+		Meta: dto.EnrichLocationBatchMeta{TotalLocations: 1, SuccessCount: 1},
+	}, nil)
+	mockStream.On("PublishToStream", mock.Anything, domain.StreamLocationDone, mock.Anything).Return(nil)
+	mockResults.On("UpsertResults", mock.Anything, mock.MatchedBy(func(results []*domain.EnrichmentResult) bool {
+		return len(results) == 1 && results[0].PropertyID == propertyID &&
+			results[0].CountryID != nil && *results[0].CountryID == 1 && !results[0].EnrichedAt.IsZero()
+	})).Return(nil).Once()
+	mockStream.On("AckMessages", mock.Anything, domain.StreamLocationEnrich, "test-group", []string{"1234567890-0"}).Return(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- worker.Start(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("Worker did not stop in time")
+	}
+
+	mockResults.AssertExpectations(t)
+	mockStream.AssertExpectations(t)
+}
+
 // Helper functions
 func ptrBool(v bool) *bool {
 	return &v
 }
+
+// TestLocationEnrichmentWorker_SetConcurrency tests concurrency is bounded by the OSM DB pool
+func TestLocationEnrichmentWorker_SetConcurrency(t *testing.T) {
+	worker := location.NewLocationEnrichmentWorker(
+		&MockStreamRepository{},
+		&MockEnrichedLocationUseCase{},
+		"test-group",
+		3,
+		zap.NewNop(),
+	)
+
+	assert.Equal(t, 4, worker.SetConcurrency(4, 10))
+	assert.Equal(t, 5, worker.SetConcurrency(8, 10), "each consumer uses two DB connections")
+	assert.Equal(t, 1, worker.SetConcurrency(4, 1))
+	assert.Equal(t, 1, worker.SetConcurrency(0, 10))
+	assert.Equal(t, 8, worker.SetConcurrency(8, 0), "no pool limit")
+}
+
+// TestLocationEnrichmentWorker_ConcurrentConsumers tests each consumer reads the stream under its own name
+func TestLocationEnrichmentWorker_ConcurrentConsumers(t *testing.T) {
+	mockStream := &MockStreamRepository{}
+	mockUseCase := &MockEnrichedLocationUseCase{}
+
+	worker := location.NewLocationEnrichmentWorker(
+		mockStream,
+		mockUseCase,
+		"test-group",
+		3,
+		zap.NewNop(),
+	)
+	worker.SetConcurrency(3, 10)
+
+	mockStream.On("CreateConsumerGroup", mock.Anything, domain.StreamLocationEnrich, "test-group").
+		Return(nil).Once()
+
+	var mu sync.Mutex
+	consumers := make(map[string]bool)
+	mockStream.On("ConsumeBatch", mock.Anything, domain.StreamLocationEnrich, "test-group", mock.AnythingOfType("string"), 20).
+		Run(func(args mock.Arguments) {
+			mu.Lock()
+			consumers[args.String(3)] = true
+			mu.Unlock()
+		}).
+		Return([]domain.StreamMessage{}, nil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- worker.Start(context.Background())
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	assert.NoError(t, worker.Stop())
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Worker did not stop")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, consumers, 3)
+	for name := range consumers {
+		assert.Regexp(t, `-[0-2]$`, name)
+	}
+}
@@ -0,0 +1,20 @@
+-- Результаты обогащения локаций воркером (WORKER_PERSIST_RESULTS=true):
+-- одна строка на объект недвижимости, повторное обогащение перезаписывает строку (побеждает последнее)
+CREATE TABLE enrichment_results (
+    property_id         UUID NOT NULL,
+    country_id          BIGINT,
+    region_id           BIGINT,
+    province_id         BIGINT,
+    city_id             BIGINT,
+    district_id         BIGINT,
+    subdistrict_id      BIGINT,
+    neighborhood_id     BIGINT,
+    nearest_transport   JSONB NOT NULL DEFAULT '[]'::jsonb,
+    error               TEXT,
+    enriched_at         TIMESTAMPTZ NOT NULL,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Уникальный индекс - ключ upsert (ON CONFLICT (property_id)) и поиск результата по объекту
+CREATE UNIQUE INDEX idx_enrichment_results_property_id ON enrichment_results (property_id);
+CREATE INDEX idx_enrichment_results_enriched_at ON enrichment_results (enriched_at);
@@ -88,7 +88,20 @@ CREATE INDEX IF NOT EXISTS idx_planet_osm_line_osm_id
 ON planet_osm_line (osm_id);
 
 -- ============================================================
--- 5. Update statistics
+-- 5. Simplified geometry of large admin boundaries (levels 2-6)
+--    Used by reverse geocoding when BOUNDARY_SIMPLIFIED_CONTAINS=true; the service refuses
+--    to start with that flag if the column is missing. No index: the bbox prefilter stays on way
+-- ============================================================
+ALTER TABLE planet_osm_polygon ADD COLUMN IF NOT EXISTS way_simplified geometry(Geometry, 3857);
+UPDATE planet_osm_polygon
+SET way_simplified = ST_MakeValid(ST_SimplifyPreserveTopology(way, 50))
+WHERE boundary = 'administrative'
+  AND admin_level IN ('2', '3', '4', '5', '6')
+  AND way IS NOT NULL
+  AND way_simplified IS NULL;
+
+-- ============================================================
+-- 6. Update statistics
 -- ============================================================
 ANALYZE planet_osm_point;
 ANALYZE planet_osm_line;